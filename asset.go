@@ -0,0 +1,122 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package blockchain provides chain-agnostic assets and transfers abstractions
+// implemented by the chain specific packages, e.g. bitcoin/assets.
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// assetSeparator defines separator between asset chain, protocol and id in the asset string.
+const assetSeparator = ":"
+
+var (
+	// ErrInvalidAsset describes that asset is malformed.
+	ErrInvalidAsset = errors.New("invalid asset")
+	// ErrUnsupportedAsset describes that asset chain or protocol is not supported by the transfer builder.
+	ErrUnsupportedAsset = errors.New("unsupported asset")
+)
+
+// Chain defines blockchain identifier.
+type Chain string
+
+const (
+	// ChainBitcoin defines bitcoin blockchain.
+	ChainBitcoin Chain = "bitcoin"
+)
+
+// Protocol defines asset protocol on top of the chain.
+type Protocol string
+
+const (
+	// ProtocolNative defines chain native coin, e.g. BTC.
+	ProtocolNative Protocol = "native"
+	// ProtocolRunes defines runes fungible tokens.
+	ProtocolRunes Protocol = "runes"
+	// ProtocolInscriptions defines ordinals inscriptions.
+	ProtocolInscriptions Protocol = "inscriptions"
+)
+
+// Asset describes chain-agnostic asset identifier.
+type Asset struct {
+	Chain    Chain
+	Protocol Protocol
+	ID       string // protocol specific asset identifier, e.g. rune id, empty for native coins.
+}
+
+// NativeAsset returns native coin asset of the chain.
+func NativeAsset(chain Chain) Asset {
+	return Asset{Chain: chain, Protocol: ProtocolNative}
+}
+
+// ParseAsset parses asset from string in format "chain:protocol[:id]", e.g. "bitcoin:runes:840000:3".
+func ParseAsset(s string) (Asset, error) {
+	parts := strings.SplitN(s, assetSeparator, 3)
+	if len(parts) < 2 {
+		return Asset{}, fmt.Errorf("%w: %s", ErrInvalidAsset, s)
+	}
+
+	asset := Asset{Chain: Chain(parts[0]), Protocol: Protocol(parts[1])}
+	if len(parts) == 3 {
+		asset.ID = parts[2]
+	}
+
+	return asset, asset.Validate()
+}
+
+// IsNative returns true if asset is the chain native coin.
+func (asset Asset) IsNative() bool {
+	return asset.Protocol == ProtocolNative
+}
+
+// Validate returns error if asset is malformed.
+func (asset Asset) Validate() error {
+	switch {
+	case asset.Chain == "":
+		return fmt.Errorf("%w: empty chain", ErrInvalidAsset)
+	case asset.Protocol == "":
+		return fmt.Errorf("%w: empty protocol", ErrInvalidAsset)
+	case asset.IsNative() && asset.ID != "":
+		return fmt.Errorf("%w: native asset with id %s", ErrInvalidAsset, asset.ID)
+	case !asset.IsNative() && asset.ID == "":
+		return fmt.Errorf("%w: empty %s id", ErrInvalidAsset, asset.Protocol)
+	}
+
+	return nil
+}
+
+// String returns asset as string in format "chain:protocol[:id]".
+func (asset Asset) String() string {
+	parts := []string{string(asset.Chain), string(asset.Protocol)}
+	if asset.ID != "" {
+		parts = append(parts, asset.ID)
+	}
+
+	return strings.Join(parts, assetSeparator)
+}
+
+// Amount describes amount of the asset.
+type Amount struct {
+	Asset Asset
+	Value *big.Int // in the smallest asset units, e.g. satoshi for BTC, 1 for inscriptions.
+}
+
+// NewAmount is a constructor for Amount.
+func NewAmount(asset Asset, value *big.Int) Amount {
+	return Amount{Asset: asset, Value: value}
+}
+
+// String returns amount as string, e.g. "1000 bitcoin:native".
+func (amount Amount) String() string {
+	value := "<nil>"
+	if amount.Value != nil {
+		value = amount.Value.String()
+	}
+
+	return value + " " + amount.Asset.String()
+}
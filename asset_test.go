@@ -0,0 +1,80 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package blockchain_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain"
+)
+
+func TestAsset(t *testing.T) {
+	t.Run("ParseAsset", func(t *testing.T) {
+		tests := []struct {
+			asset string
+			valid bool
+		}{
+			{"bitcoin:native", true},
+			{"bitcoin:runes:840000:3", true},
+			{"bitcoin:inscriptions:6fb976ab49dcec017f1e201e84395983204ae1a7c2abf7ced0a85d692e442799i0", true},
+			{"bitcoin", false},
+			{"bitcoin:runes", false},
+			{"bitcoin:native:1", false},
+			{":native", false},
+			{"bitcoin:", false},
+		}
+
+		for _, test := range tests {
+			asset, err := blockchain.ParseAsset(test.asset)
+			if !test.valid {
+				require.ErrorIs(t, err, blockchain.ErrInvalidAsset, test.asset)
+				continue
+			}
+
+			require.NoError(t, err, test.asset)
+			require.Equal(t, test.asset, asset.String())
+		}
+
+		asset, err := blockchain.ParseAsset("bitcoin:runes:840000:3")
+		require.NoError(t, err)
+		require.Equal(t, blockchain.Asset{Chain: blockchain.ChainBitcoin, Protocol: blockchain.ProtocolRunes, ID: "840000:3"}, asset)
+		require.True(t, blockchain.NativeAsset(blockchain.ChainBitcoin).IsNative())
+	})
+
+	t.Run("TransferRequest", func(t *testing.T) {
+		valid := blockchain.TransferRequest{
+			Amount:  blockchain.NewAmount(blockchain.NativeAsset(blockchain.ChainBitcoin), big.NewInt(1000)),
+			From:    blockchain.Account{Address: "from"},
+			To:      "to",
+			FeeRate: big.NewInt(1000),
+		}
+		require.NoError(t, valid.Validate())
+		require.Equal(t, "1000 bitcoin:native", valid.Amount.String())
+
+		request := valid
+		request.Amount.Value = big.NewInt(0)
+		require.ErrorIs(t, request.Validate(), blockchain.ErrInvalidTransferRequest)
+
+		request = valid
+		request.Amount.Asset = blockchain.Asset{Chain: blockchain.ChainBitcoin, Protocol: blockchain.ProtocolInscriptions, ID: "id"}
+		require.ErrorIs(t, request.Validate(), blockchain.ErrInvalidTransferRequest)
+
+		request = valid
+		request.To = ""
+		require.ErrorIs(t, request.Validate(), blockchain.ErrInvalidTransferRequest)
+
+		request = valid
+		request.FeeRate = nil
+		require.ErrorIs(t, request.Validate(), blockchain.ErrInvalidTransferRequest)
+
+		request = valid
+		request.Amount.Asset.Chain = "ethereum"
+		_, err := blockchain.NewRouter().BuildTransfer(context.Background(), request)
+		require.ErrorIs(t, err, blockchain.ErrUnsupportedAsset)
+	})
+}
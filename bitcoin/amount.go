@@ -0,0 +1,131 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+var (
+	// ErrInvalidAmount describes that amount string is not a valid btc amount.
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrNegativeAmount describes that amount is below zero.
+	ErrNegativeAmount = errors.New("negative amount")
+	// ErrAmountOverflow describes that amount exceeds bitcoin max supply.
+	ErrAmountOverflow = errors.New("amount exceeds max supply")
+)
+
+const (
+	// SatoshiPerBTC defines number of satoshi in one btc.
+	SatoshiPerBTC = 100_000_000
+	// btcDecimals defines number of btc decimal places.
+	btcDecimals = 8
+)
+
+// MaxSatoshi defines bitcoin max supply in satoshi, the upper bound of the valid amount.
+var MaxSatoshi = big.NewInt(21_000_000 * SatoshiPerBTC)
+
+// btcAmountRegexp defines format of the btc amount string, e.g. 0.00029500.
+var btcAmountRegexp = regexp.MustCompile(`^\d+(\.\d{1,8})?$`)
+
+// Amount describes btc amount in satoshi in the range [0, MaxSatoshi].
+// Amount is immutable, zero value is 0 satoshi.
+type Amount struct {
+	satoshi *big.Int
+}
+
+// NewAmount returns amount of satoshi, e.g. NewAmount(29500) for 0.000295 BTC.
+func NewAmount(satoshi int64) (Amount, error) {
+	return AmountFromSatoshi(big.NewInt(satoshi))
+}
+
+// AmountFromSatoshi returns amount of satoshi, nil is 0 satoshi.
+func AmountFromSatoshi(satoshi *big.Int) (Amount, error) {
+	if satoshi == nil {
+		return Amount{}, nil
+	}
+
+	return newAmount(new(big.Int).Set(satoshi))
+}
+
+// AmountFromBTCString parses amount in btc with up to 8 decimal places, e.g. "0.00029500".
+func AmountFromBTCString(btc string) (Amount, error) {
+	if !btcAmountRegexp.MatchString(btc) {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, btc)
+	}
+
+	whole, fraction, _ := strings.Cut(btc, ".")
+	satoshi, _ := new(big.Int).SetString(whole+fraction+strings.Repeat("0", btcDecimals-len(fraction)), 10)
+
+	return newAmount(satoshi)
+}
+
+// newAmount returns amount over the satoshi value, which is not copied.
+func newAmount(satoshi *big.Int) (Amount, error) {
+	switch {
+	case numbers.IsNegative(satoshi):
+		return Amount{}, fmt.Errorf("%w: %s satoshi", ErrNegativeAmount, satoshi)
+	case numbers.IsGreater(satoshi, MaxSatoshi):
+		return Amount{}, fmt.Errorf("%w: %s satoshi", ErrAmountOverflow, satoshi)
+	default:
+		return Amount{satoshi: satoshi}, nil
+	}
+}
+
+// Satoshi returns copy of the amount in satoshi.
+func (amount Amount) Satoshi() *big.Int {
+	if amount.satoshi == nil {
+		return big.NewInt(0)
+	}
+
+	return new(big.Int).Set(amount.satoshi)
+}
+
+// Int64 returns amount in satoshi, max supply fits into int64.
+func (amount Amount) Int64() int64 {
+	return amount.Satoshi().Int64()
+}
+
+// IsZero returns true if amount is 0 satoshi.
+func (amount Amount) IsZero() bool {
+	return amount.satoshi == nil || numbers.IsZero(amount.satoshi)
+}
+
+// Cmp compares amounts, returns -1 if amount is less than other, 0 if equal and +1 if greater.
+func (amount Amount) Cmp(other Amount) int {
+	return amount.Satoshi().Cmp(other.Satoshi())
+}
+
+// Add returns sum of the amounts, ErrAmountOverflow if the sum exceeds max supply.
+func (amount Amount) Add(other Amount) (Amount, error) {
+	return newAmount(new(big.Int).Add(amount.Satoshi(), other.Satoshi()))
+}
+
+// Sub returns difference of the amounts, ErrNegativeAmount if other amount is greater.
+func (amount Amount) Sub(other Amount) (Amount, error) {
+	return newAmount(new(big.Int).Sub(amount.Satoshi(), other.Satoshi()))
+}
+
+// Mul returns amount multiplied by n, ErrAmountOverflow if the product exceeds max supply.
+func (amount Amount) Mul(n int64) (Amount, error) {
+	return newAmount(new(big.Int).Mul(amount.Satoshi(), big.NewInt(n)))
+}
+
+// BTCString returns amount in btc with 8 decimal places, e.g. "0.00029500".
+func (amount Amount) BTCString() string {
+	satoshi := fmt.Sprintf("%0*s", btcDecimals+1, amount.Satoshi().String())
+
+	return satoshi[:len(satoshi)-btcDecimals] + "." + satoshi[len(satoshi)-btcDecimals:]
+}
+
+// String returns amount in btc with unit, e.g. "0.00029500 BTC".
+func (amount Amount) String() string {
+	return amount.BTCString() + " BTC"
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+)
+
+func TestAmount(t *testing.T) {
+	t.Run("from btc string", func(t *testing.T) {
+		tests := []struct {
+			btc     string
+			satoshi int64
+			err     error
+		}{
+			{"0.00029500", 29500, nil},
+			{"0.000295", 29500, nil},
+			{"1", bitcoin.SatoshiPerBTC, nil},
+			{"21000000.00000000", 21_000_000 * bitcoin.SatoshiPerBTC, nil},
+			{"0.00000001", 1, nil},
+			{"21000000.00000001", 0, bitcoin.ErrAmountOverflow},
+			{"0.000000001", 0, bitcoin.ErrInvalidAmount},
+			{"-1", 0, bitcoin.ErrInvalidAmount},
+			{"1.", 0, bitcoin.ErrInvalidAmount},
+			{"1e8", 0, bitcoin.ErrInvalidAmount},
+			{"", 0, bitcoin.ErrInvalidAmount},
+		}
+		for _, test := range tests {
+			amount, err := bitcoin.AmountFromBTCString(test.btc)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err, test.btc)
+				continue
+			}
+
+			require.NoError(t, err, test.btc)
+			require.EqualValues(t, test.satoshi, amount.Int64(), test.btc)
+		}
+	})
+
+	t.Run("formatting", func(t *testing.T) {
+		tests := []struct {
+			satoshi int64
+			btc     string
+		}{
+			{0, "0.00000000"},
+			{1, "0.00000001"},
+			{29500, "0.00029500"},
+			{bitcoin.SatoshiPerBTC, "1.00000000"},
+			{1_234_567_890, "12.34567890"},
+		}
+		for _, test := range tests {
+			amount, err := bitcoin.NewAmount(test.satoshi)
+			require.NoError(t, err)
+			require.Equal(t, test.btc, amount.BTCString())
+
+			parsed, err := bitcoin.AmountFromBTCString(amount.BTCString())
+			require.NoError(t, err)
+			require.Zero(t, parsed.Cmp(amount))
+		}
+
+		require.Equal(t, "0.00000000 BTC", bitcoin.Amount{}.String())
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		a, err := bitcoin.NewAmount(29500)
+		require.NoError(t, err)
+		b, err := bitcoin.AmountFromBTCString("0.0002")
+		require.NoError(t, err)
+
+		sum, err := a.Add(b)
+		require.NoError(t, err)
+		require.EqualValues(t, 49500, sum.Int64())
+
+		diff, err := a.Sub(b)
+		require.NoError(t, err)
+		require.EqualValues(t, 9500, diff.Int64())
+
+		_, err = b.Sub(a)
+		require.ErrorIs(t, err, bitcoin.ErrNegativeAmount)
+
+		product, err := a.Mul(3)
+		require.NoError(t, err)
+		require.EqualValues(t, 88500, product.Int64())
+
+		maxAmount, err := bitcoin.AmountFromSatoshi(bitcoin.MaxSatoshi)
+		require.NoError(t, err)
+		_, err = maxAmount.Add(a)
+		require.ErrorIs(t, err, bitcoin.ErrAmountOverflow)
+		_, err = a.Mul(1 << 40)
+		require.ErrorIs(t, err, bitcoin.ErrAmountOverflow)
+
+		require.Equal(t, 1, a.Cmp(b))
+		require.True(t, bitcoin.Amount{}.IsZero())
+	})
+
+	t.Run("immutable", func(t *testing.T) {
+		satoshi := big.NewInt(1000)
+		amount, err := bitcoin.AmountFromSatoshi(satoshi)
+		require.NoError(t, err)
+
+		satoshi.SetInt64(1)
+		amount.Satoshi().SetInt64(2)
+		require.EqualValues(t, 1000, amount.Int64())
+
+		_, err = bitcoin.AmountFromSatoshi(big.NewInt(-1))
+		require.ErrorIs(t, err, bitcoin.ErrNegativeAmount)
+	})
+}
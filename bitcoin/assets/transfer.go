@@ -0,0 +1,252 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package assets implements chain-agnostic blockchain.TransferBuilder over the bitcoin
+// transaction builders for BTC, runes and inscriptions transfers.
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/BoostyLabs/blockchain"
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+// ErrOrderingConflict describes that TxBuilder inputs or outputs ordering options conflict with the transfer.
+var ErrOrderingConflict = errors.New("ordering options conflict with transfer")
+
+// UTXOSource describes source of the spendable outputs, e.g. ord indexer.
+type UTXOSource interface {
+	// UTXOs returns unspent outputs of the address with linked runes.
+	// NOTE: Outputs which hold inscriptions must be excluded to not spend them as fee.
+	UTXOs(ctx context.Context, address string) ([]bitcoin.UTXO, error)
+	// InscriptionUTXO returns unspent output which holds the inscription.
+	InscriptionUTXO(ctx context.Context, id *inscriptions.ID) (bitcoin.UTXO, error)
+}
+
+// TransferBuilder builds bitcoin transfers of the chain-agnostic assets:
+//   - bitcoin:native - BTC transfer, amount in satoshi;
+//   - bitcoin:runes:<rune id> - runes transfer, amount in rune units;
+//   - bitcoin:inscriptions:<inscription id> - inscription transfer with its whole postage.
+//
+// NOTE: Inscription transfer requires TxBuilder without inputs and outputs ordering options,
+// since inscription input and output must be the first ones to transfer inscribed sat,
+// ErrOrderingConflict is returned otherwise.
+type TransferBuilder struct {
+	txBuilder *txbuilder.TxBuilder
+	source    UTXOSource
+}
+
+// NewTransferBuilder is a constructor for TransferBuilder.
+func NewTransferBuilder(txBuilder *txbuilder.TxBuilder, source UTXOSource) *TransferBuilder {
+	return &TransferBuilder{
+		txBuilder: txBuilder,
+		source:    source,
+	}
+}
+
+// Chain returns bitcoin chain.
+func (b *TransferBuilder) Chain() blockchain.Chain {
+	return blockchain.ChainBitcoin
+}
+
+// BuildTransfer returns transfer with unsigned transaction in PSBT format
+// and estimated fee in satoshi.
+func (b *TransferBuilder) BuildTransfer(ctx context.Context, request blockchain.TransferRequest) (blockchain.Transfer, error) {
+	if err := request.Validate(); err != nil {
+		return blockchain.Transfer{}, err
+	}
+
+	asset := request.Amount.Asset
+	if asset.Chain != blockchain.ChainBitcoin {
+		return blockchain.Transfer{}, fmt.Errorf("%w: %s", blockchain.ErrUnsupportedAsset, asset.String())
+	}
+
+	var (
+		serializedPSBT []byte
+		fee            *big.Int
+		err            error
+	)
+	switch asset.Protocol {
+	case blockchain.ProtocolNative:
+		serializedPSBT, fee, err = b.buildBTCTransfer(ctx, request)
+	case blockchain.ProtocolRunes:
+		serializedPSBT, fee, err = b.buildRunesTransfer(ctx, request)
+	case blockchain.ProtocolInscriptions:
+		serializedPSBT, fee, err = b.buildInscriptionTransfer(ctx, request)
+	default:
+		return blockchain.Transfer{}, fmt.Errorf("%w: %s", blockchain.ErrUnsupportedAsset, asset.String())
+	}
+	if err != nil {
+		return blockchain.Transfer{}, err
+	}
+
+	return blockchain.Transfer{
+		Chain:   blockchain.ChainBitcoin,
+		Payload: serializedPSBT,
+		Fee:     blockchain.NewAmount(blockchain.NativeAsset(blockchain.ChainBitcoin), fee),
+	}, nil
+}
+
+// buildBTCTransfer builds BTC transfer transaction.
+func (b *TransferBuilder) buildBTCTransfer(ctx context.Context, request blockchain.TransferRequest) ([]byte, *big.Int, error) {
+	sender, err := b.paymentData(ctx, request.From, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := txbuilder.BaseBTCTransferParams{
+		Sender:                sender,
+		TransferSatoshiAmount: request.Amount.Value,
+		SatoshiPerKVByte:      request.FeeRate,
+		RecipientAddress:      request.To,
+	}
+	if request.FeePayer != nil {
+		params.FeePayer, err = b.paymentData(ctx, *request.FeePayer, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	result, err := b.txBuilder.BuildBTCTransferTxContext(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result.SerializedPSBT, result.EstimatedFee, nil
+}
+
+// buildRunesTransfer builds runes transfer transaction.
+func (b *TransferBuilder) buildRunesTransfer(ctx context.Context, request blockchain.TransferRequest) ([]byte, *big.Int, error) {
+	runeID, err := runes.NewRuneIDFromString(request.Amount.Asset.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", blockchain.ErrInvalidAsset, err)
+	}
+
+	utxos, err := b.source.UTXOs(ctx, request.From.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var runeUTXOs []bitcoin.UTXO
+	for _, utxo := range utxos {
+		if utxo.RuneAmount(runeID).Sign() > 0 {
+			runeUTXOs = append(runeUTXOs, utxo)
+		}
+	}
+	sort.SliceStable(runeUTXOs, func(i, j int) bool {
+		return runeUTXOs[i].RuneAmount(runeID).Cmp(runeUTXOs[j].RuneAmount(runeID)) > 0
+	})
+
+	feePayerAccount := request.From
+	if request.FeePayer != nil {
+		feePayerAccount = *request.FeePayer
+	}
+	feePayer, err := b.paymentData(ctx, feePayerAccount, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := b.txBuilder.BuildRunesTransferTxContext(ctx, txbuilder.BaseRunesTransferParams{
+		RuneID:             runeID,
+		TransferRuneAmount: request.Amount.Value,
+		RunesSender: &txbuilder.PaymentData{
+			UTXOs:   runeUTXOs,
+			Address: request.From.Address,
+			PubKey:  request.From.PubKey,
+		},
+		FeePayer:              feePayer,
+		SatoshiPerKVByte:      request.FeeRate,
+		RunesRecipientAddress: request.To,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result.SerializedPSBT, result.EstimatedFee, nil
+}
+
+// buildInscriptionTransfer builds inscription transfer transaction. The whole inscription utxo
+// is sent to the recipient output #0 and the fee is paid by the fee payer.
+func (b *TransferBuilder) buildInscriptionTransfer(ctx context.Context, request blockchain.TransferRequest) ([]byte, *big.Int, error) {
+	if !b.txBuilder.KeepsOrder() {
+		return nil, nil, fmt.Errorf("%w: inscription must be transferred by the first input and output", ErrOrderingConflict)
+	}
+
+	id, err := inscriptions.NewIDFromString(request.Amount.Asset.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", blockchain.ErrInvalidAsset, err)
+	}
+
+	inscriptionUTXO, err := b.source.InscriptionUTXO(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if inscriptionUTXO.Address != request.From.Address {
+		return nil, nil, fmt.Errorf("%w: inscription %s is not owned by %s", blockchain.ErrInvalidTransferRequest,
+			id.String(), request.From.Address)
+	}
+
+	feePayerAccount := request.From
+	if request.FeePayer != nil {
+		feePayerAccount = *request.FeePayer
+	}
+	feePayer, err := b.paymentData(ctx, feePayerAccount, &inscriptionUTXO)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := b.txBuilder.BuildBTCTransferTxContext(ctx, txbuilder.BaseBTCTransferParams{
+		Sender: &txbuilder.PaymentData{
+			UTXOs:   []bitcoin.UTXO{inscriptionUTXO},
+			Address: request.From.Address,
+			PubKey:  request.From.PubKey,
+		},
+		FeePayer:              feePayer,
+		TransferSatoshiAmount: inscriptionUTXO.Amount,
+		SatoshiPerKVByte:      request.FeeRate,
+		RecipientAddress:      request.To,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result.SerializedPSBT, result.EstimatedFee, nil
+}
+
+// paymentData returns account payment data with utxos without runes sorted by btc amount desc.
+// excluded utxo is skipped if provided.
+func (b *TransferBuilder) paymentData(ctx context.Context, account blockchain.Account, excluded *bitcoin.UTXO) (*txbuilder.PaymentData, error) {
+	utxos, err := b.source.UTXOs(ctx, account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	baseUTXOs := make([]bitcoin.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if utxo.HasRunes() {
+			continue
+		}
+		if excluded != nil && utxo.Outpoint == excluded.Outpoint {
+			continue
+		}
+
+		baseUTXOs = append(baseUTXOs, utxo)
+	}
+	sort.SliceStable(baseUTXOs, func(i, j int) bool {
+		return baseUTXOs[i].Amount.Cmp(baseUTXOs[j].Amount) > 0
+	})
+
+	return &txbuilder.PaymentData{
+		UTXOs:   baseUTXOs,
+		Address: account.Address,
+		PubKey:  account.PubKey,
+	}, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package assets_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain"
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/assets"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+// utxoSource is an in-memory assets.UTXOSource.
+type utxoSource struct {
+	utxos        map[string][]bitcoin.UTXO
+	inscriptions map[string]bitcoin.UTXO
+}
+
+func (s *utxoSource) UTXOs(_ context.Context, address string) ([]bitcoin.UTXO, error) {
+	return s.utxos[address], nil
+}
+
+func (s *utxoSource) InscriptionUTXO(_ context.Context, id *inscriptions.ID) (bitcoin.UTXO, error) {
+	utxo, ok := s.inscriptions[id.String()]
+	if !ok {
+		return utxo, errors.New("inscription not found")
+	}
+
+	return utxo, nil
+}
+
+func TestTransferBuilder(t *testing.T) {
+	ctx := context.Background()
+	networkParams := &chaincfg.TestNet3Params
+	runeID := runes.RuneID{Block: 840000, TxID: 3}
+	inscriptionID := "6fb976ab49dcec017f1e201e84395983204ae1a7c2abf7ced0a85d692e442799i0"
+
+	wallet, recipient := txbuildertest.NewWallet(t, networkParams), txbuildertest.NewWallet(t, networkParams)
+	sender := blockchain.Account{Address: wallet.Address, PubKey: wallet.PubKey}
+
+	inscriptionUTXO := wallet.UTXO("6fb976ab49dcec017f1e201e84395983204ae1a7c2abf7ced0a85d692e442799", 0, 10000)
+	source := &utxoSource{
+		utxos: map[string][]bitcoin.UTXO{
+			sender.Address: {
+				wallet.UTXO("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0, 50000),
+				{
+					Outpoint: bitcoin.MustOutpoint("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 1),
+					Amount:   big.NewInt(546),
+					Script:   wallet.Script,
+					Address:  wallet.Address,
+					Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(5000)}},
+				},
+			},
+		},
+		inscriptions: map[string]bitcoin.UTXO{inscriptionID: inscriptionUTXO},
+	}
+
+	router := blockchain.NewRouter(assets.NewTransferBuilder(txbuilder.NewTxBuilder(networkParams), source))
+
+	request := func(asset string, amount int64) blockchain.TransferRequest {
+		parsed, err := blockchain.ParseAsset(asset)
+		require.NoError(t, err)
+
+		return blockchain.TransferRequest{
+			Amount:  blockchain.NewAmount(parsed, big.NewInt(amount)),
+			From:    sender,
+			To:      recipient.Address,
+			FeeRate: big.NewInt(5000),
+		}
+	}
+	parse := func(t *testing.T, transfer blockchain.Transfer) *psbt.Packet {
+		require.Equal(t, blockchain.ChainBitcoin, transfer.Chain)
+		require.True(t, transfer.Fee.Asset.IsNative())
+		require.Positive(t, transfer.Fee.Value.Sign())
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(transfer.Payload), false)
+		require.NoError(t, err)
+
+		return p
+	}
+
+	t.Run("btc", func(t *testing.T) {
+		transfer, err := router.BuildTransfer(ctx, request("bitcoin:native", 20000))
+		require.NoError(t, err)
+
+		p := parse(t, transfer)
+		require.Len(t, p.UnsignedTx.TxIn, 1)
+		require.Equal(t, "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
+			p.UnsignedTx.TxIn[0].PreviousOutPoint.Hash.String())
+		require.EqualValues(t, 20000, p.UnsignedTx.TxOut[0].Value)
+	})
+
+	t.Run("runes", func(t *testing.T) {
+		transfer, err := router.BuildTransfer(ctx, request("bitcoin:runes:840000:3", 3000))
+		require.NoError(t, err)
+
+		p := parse(t, transfer)
+		require.Equal(t, "f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
+			p.UnsignedTx.TxIn[0].PreviousOutPoint.Hash.String())
+
+		runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[0].PkScript)
+		require.NoError(t, err)
+		require.Len(t, runestone.Edicts, 1)
+		require.EqualValues(t, 3000, runestone.Edicts[0].Amount.Int64())
+	})
+
+	t.Run("inscriptions", func(t *testing.T) {
+		transfer, err := router.BuildTransfer(ctx, request("bitcoin:inscriptions:"+inscriptionID, 1))
+		require.NoError(t, err)
+
+		p := parse(t, transfer)
+		require.Len(t, p.UnsignedTx.TxIn, 2)
+		require.Equal(t, inscriptionUTXO.Hash, p.UnsignedTx.TxIn[0].PreviousOutPoint.Hash)
+		require.Equal(t, "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
+			p.UnsignedTx.TxIn[1].PreviousOutPoint.Hash.String())
+		require.EqualValues(t, 10000, p.UnsignedTx.TxOut[0].Value)
+
+		_, err = router.BuildTransfer(ctx, request("bitcoin:inscriptions:"+inscriptionID, 2))
+		require.ErrorIs(t, err, blockchain.ErrInvalidTransferRequest)
+
+		for _, opt := range []txbuilder.Option{
+			txbuilder.WithOutputOrdering(txbuilder.OutputOrderingBIP69),
+			txbuilder.WithInputOrdering(txbuilder.InputOrderingBIP69),
+		} {
+			ordered := assets.NewTransferBuilder(txbuilder.NewTxBuilder(networkParams, opt), source)
+			_, err = ordered.BuildTransfer(ctx, request("bitcoin:inscriptions:"+inscriptionID, 1))
+			require.ErrorIs(t, err, assets.ErrOrderingConflict)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := router.BuildTransfer(ctx, request("bitcoin:brc20:ordi", 1))
+		require.ErrorIs(t, err, blockchain.ErrUnsupportedAsset)
+
+		_, err = router.BuildTransfer(ctx, request("bitcoin:runes:invalid", 1))
+		require.ErrorIs(t, err, blockchain.ErrInvalidAsset)
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package bip352 implements silent payments (BIP-352): addresses, sender output
+// keys derivation and receiver outputs scanning. Labels are not supported.
+package bip352
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/bech32"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+var (
+	// ErrInvalidAddress defines that silent payment address is malformed.
+	ErrInvalidAddress = errors.New("invalid silent payment address")
+	// ErrUnsupportedVersion defines that silent payment address version is not supported.
+	ErrUnsupportedVersion = errors.New("unsupported silent payment address version")
+	// ErrWrongNetwork defines that silent payment address belongs to another network.
+	ErrWrongNetwork = errors.New("silent payment address of wrong network")
+)
+
+const (
+	// maxVersion defines the greatest silent payment address version, 31 is reserved for backward incompatible changes.
+	maxVersion = 30
+	// keysLength defines length of the serialized scan and spend public keys.
+	keysLength = 2 * btcec.PubKeyBytesLenCompressed
+)
+
+// hrps defines silent payment addresses human-readable parts by networks.
+var hrps = map[string]string{
+	chaincfg.MainNetParams.Name:       "sp",
+	chaincfg.TestNet3Params.Name:      "tsp",
+	chaincfg.SigNetParams.Name:        "tsp",
+	chaincfg.RegressionNetParams.Name: "sprt",
+}
+
+// Address describes silent payment address.
+type Address struct {
+	Version  byte
+	ScanKey  *btcec.PublicKey
+	SpendKey *btcec.PublicKey
+}
+
+// NewAddress is a constructor for version 0 silent payment address.
+func NewAddress(scanKey, spendKey *btcec.PublicKey) *Address {
+	return &Address{ScanKey: scanKey, SpendKey: spendKey}
+}
+
+// IsAddress returns true if address looks like a silent payment address of any network.
+func IsAddress(address string) bool {
+	hrp, _, found := strings.Cut(strings.ToLower(address), "1")
+	if !found {
+		return false
+	}
+
+	for _, known := range hrps {
+		if hrp == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DecodeAddress decodes silent payment address of the network.
+// INFO: Address of the future versions is decoded by its first 66 bytes of keys.
+func DecodeAddress(address string, networkParams *chaincfg.Params) (*Address, error) {
+	hrp, data, err := bech32.DecodeNoLimit(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+	}
+
+	// INFO: bech32 decoding accepts both checksums, bech32m one is checked by encoding back.
+	encoded, err := bech32.EncodeM(hrp, data)
+	if err != nil || encoded != strings.ToLower(address) {
+		return nil, fmt.Errorf("%w: bech32m checksum is expected", ErrInvalidAddress)
+	}
+
+	if expected, ok := hrps[networkParams.Name]; !ok || hrp != expected {
+		return nil, fmt.Errorf("%w: %s is not for %s", ErrWrongNetwork, address, networkParams.Name)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: no data", ErrInvalidAddress)
+	}
+
+	version := data[0]
+	if version > maxVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	keys, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+	}
+
+	if len(keys) < keysLength || (version == 0 && len(keys) != keysLength) {
+		return nil, fmt.Errorf("%w: invalid keys length %d", ErrInvalidAddress, len(keys))
+	}
+
+	scanKey, err := btcec.ParsePubKey(keys[:btcec.PubKeyBytesLenCompressed])
+	if err != nil {
+		return nil, fmt.Errorf("%w: scan key: %w", ErrInvalidAddress, err)
+	}
+
+	spendKey, err := btcec.ParsePubKey(keys[btcec.PubKeyBytesLenCompressed:keysLength])
+	if err != nil {
+		return nil, fmt.Errorf("%w: spend key: %w", ErrInvalidAddress, err)
+	}
+
+	return &Address{Version: version, ScanKey: scanKey, SpendKey: spendKey}, nil
+}
+
+// Encode returns silent payment address of the network.
+func (a *Address) Encode(networkParams *chaincfg.Params) (string, error) {
+	hrp, ok := hrps[networkParams.Name]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown network %s", ErrWrongNetwork, networkParams.Name)
+	}
+
+	if a.Version > maxVersion {
+		return "", fmt.Errorf("%w: %d", ErrUnsupportedVersion, a.Version)
+	}
+
+	keys := append(a.ScanKey.SerializeCompressed(), a.SpendKey.SerializeCompressed()...)
+	data, err := bech32.ConvertBits(keys, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	return bech32.EncodeM(hrp, append([]byte{a.Version}, data...))
+}
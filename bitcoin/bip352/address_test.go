@@ -0,0 +1,54 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bip352_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/bip352"
+)
+
+func TestAddress(t *testing.T) {
+	const address = "sp1qqgste7k9hx0qftg6qmwlkqtwuy6cycyavzmzj85c6qdfhjdpdjtdgqjuexzk6murw56suy3e0rd2cgqvycxttddwsvgxe2usfpxumr70xc9pkqwv"
+
+	t.Run("decode", func(t *testing.T) {
+		require.True(t, bip352.IsAddress(address))
+		require.True(t, bip352.IsAddress(strings.ToUpper(address)))
+		require.False(t, bip352.IsAddress("bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"))
+
+		decoded, err := bip352.DecodeAddress(address, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.EqualValues(t, 0, decoded.Version)
+		require.Equal(t, "0220bcfac5b99e04ad1a06ddfb016ee13582609d60b6291e98d01a9bc9a16c96d4", hex.EncodeToString(decoded.ScanKey.SerializeCompressed()))
+		require.Equal(t, "025cc9856d6f8375350e123978daac200c260cb5b5ae83106cab90484dcd8fcf36", hex.EncodeToString(decoded.SpendKey.SerializeCompressed()))
+
+		encoded, err := decoded.Encode(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.Equal(t, address, encoded)
+
+		testnet, err := decoded.Encode(&chaincfg.TestNet3Params)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(testnet, "tsp1q"))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := bip352.DecodeAddress(address, &chaincfg.TestNet3Params)
+		require.ErrorIs(t, err, bip352.ErrWrongNetwork)
+
+		_, err = bip352.DecodeAddress(address[:len(address)-1]+"q", &chaincfg.MainNetParams)
+		require.ErrorIs(t, err, bip352.ErrInvalidAddress)
+
+		decoded, err := bip352.DecodeAddress(address, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		decoded.Version = 31
+		_, err = decoded.Encode(&chaincfg.MainNetParams)
+		require.ErrorIs(t, err, bip352.ErrUnsupportedVersion)
+	})
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bip352
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var (
+	// ErrNoInputs defines that there are no transaction inputs to derive outputs from.
+	ErrNoInputs = errors.New("no inputs")
+	// ErrInvalidInputsKey defines that sum of the eligible inputs keys is zero or infinity.
+	ErrInvalidInputsKey = errors.New("invalid inputs key")
+	// ErrInvalidTweak defines that shared secret tweak is out of curve order.
+	ErrInvalidTweak = errors.New("invalid shared secret tweak")
+)
+
+var (
+	// inputsTag defines BIP-352 tag of the input hash.
+	inputsTag = []byte("BIP0352/Inputs")
+	// sharedSecretTag defines BIP-352 tag of the shared secret tweak.
+	sharedSecretTag = []byte("BIP0352/SharedSecret")
+)
+
+// Input describes sender transaction input.
+type Input struct {
+	Outpoint   wire.OutPoint
+	PrivateKey *btcec.PrivateKey // input private key, nil if input is not eligible for silent payments.
+	Taproot    bool              // input spends taproot output, private key is negated if its public key y is odd.
+}
+
+// FoundOutput describes transaction output paying to the receiver.
+type FoundOutput struct {
+	Index     int      // index of the output in the scanned outputs.
+	OutputKey []byte   // x-only taproot output key.
+	Tweak     [32]byte // tweak to add to the spend private key to spend the output.
+}
+
+// InputHash returns input hash of the transaction outpoints and the sum of eligible inputs public keys.
+func InputHash(outpoints []wire.OutPoint, inputsKey *btcec.PublicKey) (chainhash.Hash, error) {
+	if len(outpoints) == 0 {
+		return chainhash.Hash{}, ErrNoInputs
+	}
+
+	var smallest []byte
+	for _, outpoint := range outpoints {
+		serialized := binary.LittleEndian.AppendUint32(append([]byte{}, outpoint.Hash[:]...), outpoint.Index)
+		if smallest == nil || bytes.Compare(serialized, smallest) < 0 {
+			smallest = serialized
+		}
+	}
+
+	return *chainhash.TaggedHash(inputsTag, smallest, inputsKey.SerializeCompressed()), nil
+}
+
+// SumPublicKeys returns sum of the eligible inputs public keys, as seen by the receiver.
+// NOTE: Taproot inputs keys should be lifted to even y, see schnorr.ParsePubKey.
+func SumPublicKeys(keys ...*btcec.PublicKey) (*btcec.PublicKey, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoInputs
+	}
+
+	var sum btcec.JacobianPoint
+	for _, key := range keys {
+		var point, result btcec.JacobianPoint
+		key.AsJacobian(&point)
+		btcec.AddNonConst(&sum, &point, &result)
+		sum = result
+	}
+
+	return toPublicKey(&sum)
+}
+
+// SenderOutputKeys returns x-only taproot output keys paying to the recipients in their order.
+// Outputs to the same recipient scan key get increasing indexes in order of recipients.
+func SenderOutputKeys(inputs []Input, recipients []*Address) ([][]byte, error) {
+	if len(inputs) == 0 {
+		return nil, ErrNoInputs
+	}
+
+	var (
+		inputsSecret btcec.ModNScalar
+		outpoints    = make([]wire.OutPoint, 0, len(inputs))
+	)
+	for _, input := range inputs {
+		outpoints = append(outpoints, input.Outpoint)
+		if input.PrivateKey == nil {
+			continue
+		}
+
+		key := input.PrivateKey.Key
+		if input.Taproot && input.PrivateKey.PubKey().SerializeCompressed()[0] == 0x03 {
+			key.Negate()
+		}
+
+		inputsSecret.Add(&key)
+	}
+
+	if inputsSecret.IsZero() {
+		return nil, fmt.Errorf("%w: zero inputs private key", ErrInvalidInputsKey)
+	}
+
+	inputsKey := btcec.PrivKeyFromScalar(&inputsSecret).PubKey()
+	inputHash, err := InputHash(outpoints, inputsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret btcec.ModNScalar
+	secret.SetBytes((*[32]byte)(&inputHash))
+	secret.Mul(&inputsSecret)
+
+	var (
+		outputKeys = make([][]byte, len(recipients))
+		counters   = make(map[string]uint32)
+	)
+	for i, recipient := range recipients {
+		sharedSecret, err := multiply(&secret, recipient.ScanKey)
+		if err != nil {
+			return nil, err
+		}
+
+		scanKey := string(recipient.ScanKey.SerializeCompressed())
+		outputKey, _, err := outputKey(sharedSecret, recipient.SpendKey, counters[scanKey])
+		if err != nil {
+			return nil, err
+		}
+
+		counters[scanKey]++
+		outputKeys[i] = outputKey
+	}
+
+	return outputKeys, nil
+}
+
+// Scan returns transaction outputs paying to the receiver scan and spend keys,
+// outputKeys are x-only taproot output keys of the transaction.
+func Scan(scanKey *btcec.PrivateKey, spendKey, inputsKey *btcec.PublicKey, inputHash chainhash.Hash, outputKeys [][]byte) ([]FoundOutput, error) {
+	var secret btcec.ModNScalar
+	secret.SetBytes((*[32]byte)(&inputHash))
+	secret.Mul(&scanKey.Key)
+
+	sharedSecret, err := multiply(&secret, inputsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []FoundOutput
+	for k := uint32(0); ; k++ {
+		key, tweak, err := outputKey(sharedSecret, spendKey, k)
+		if err != nil {
+			return nil, err
+		}
+
+		index := -1
+		for i, outputKey := range outputKeys {
+			if bytes.Equal(outputKey, key) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return found, nil
+		}
+
+		found = append(found, FoundOutput{Index: index, OutputKey: key, Tweak: tweak.Bytes()})
+	}
+}
+
+// OutputScript returns taproot output script of the x-only output key.
+func OutputScript(outputKey []byte) ([]byte, error) {
+	return txscript.NewScriptBuilder().AddOp(txscript.OP_1).AddData(outputKey).Script()
+}
+
+// outputKey returns x-only output key spend key tweaked with k-th shared secret tweak and the tweak.
+func outputKey(sharedSecret, spendKey *btcec.PublicKey, k uint32) ([]byte, *btcec.ModNScalar, error) {
+	hash := chainhash.TaggedHash(sharedSecretTag, sharedSecret.SerializeCompressed(), binary.BigEndian.AppendUint32(nil, k))
+
+	tweak := new(btcec.ModNScalar)
+	if overflow := tweak.SetBytes((*[32]byte)(hash)); overflow != 0 {
+		return nil, nil, ErrInvalidTweak
+	}
+
+	var tweakPoint, spendPoint, result btcec.JacobianPoint
+	btcec.ScalarBaseMultNonConst(tweak, &tweakPoint)
+	spendKey.AsJacobian(&spendPoint)
+	btcec.AddNonConst(&spendPoint, &tweakPoint, &result)
+
+	key, err := toPublicKey(&result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return schnorr.SerializePubKey(key), tweak, nil
+}
+
+// multiply returns the key multiplied by scalar.
+func multiply(scalar *btcec.ModNScalar, key *btcec.PublicKey) (*btcec.PublicKey, error) {
+	var point, result btcec.JacobianPoint
+	key.AsJacobian(&point)
+	btcec.ScalarMultNonConst(scalar, &point, &result)
+
+	return toPublicKey(&result)
+}
+
+// toPublicKey converts jacobian point to the public key, returns ErrInvalidInputsKey for infinity.
+func toPublicKey(point *btcec.JacobianPoint) (*btcec.PublicKey, error) {
+	if (point.X.IsZero() && point.Y.IsZero()) || point.Z.IsZero() {
+		return nil, fmt.Errorf("%w: infinity", ErrInvalidInputsKey)
+	}
+
+	point.ToAffine()
+
+	return btcec.NewPublicKey(&point.X, &point.Y), nil
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bip352_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/bip352"
+)
+
+func TestOutputs(t *testing.T) {
+	newKey := func(t *testing.T) *btcec.PrivateKey {
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		return key
+	}
+
+	scanKey, spendKey := newKey(t), newKey(t)
+	recipient := bip352.NewAddress(scanKey.PubKey(), spendKey.PubKey())
+
+	inputs := []bip352.Input{
+		{Outpoint: wire.OutPoint{Hash: chainhash.Hash{2}, Index: 1}, PrivateKey: newKey(t)},
+		{Outpoint: wire.OutPoint{Hash: chainhash.Hash{1}, Index: 7}, PrivateKey: newKey(t), Taproot: true},
+		{Outpoint: wire.OutPoint{Hash: chainhash.Hash{3}, Index: 0}}, // not eligible input.
+	}
+
+	outputKeys, err := bip352.SenderOutputKeys(inputs, []*bip352.Address{recipient, bip352.NewAddress(newKey(t).PubKey(), newKey(t).PubKey()), recipient})
+	require.NoError(t, err)
+	require.Len(t, outputKeys, 3)
+	require.NotEqual(t, outputKeys[0], outputKeys[2])
+
+	// INFO: receiver sees taproot input keys with even y.
+	taprootKey, err := schnorr.ParsePubKey(schnorr.SerializePubKey(inputs[1].PrivateKey.PubKey()))
+	require.NoError(t, err)
+
+	inputsKey, err := bip352.SumPublicKeys(inputs[0].PrivateKey.PubKey(), taprootKey)
+	require.NoError(t, err)
+
+	inputHash, err := bip352.InputHash([]wire.OutPoint{inputs[0].Outpoint, inputs[1].Outpoint, inputs[2].Outpoint}, inputsKey)
+	require.NoError(t, err)
+
+	found, err := bip352.Scan(scanKey, spendKey.PubKey(), inputsKey, inputHash, outputKeys)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	require.Equal(t, 0, found[0].Index)
+	require.Equal(t, 2, found[1].Index)
+
+	for _, output := range found {
+		var tweak btcec.ModNScalar
+		tweak.SetBytes(&output.Tweak)
+		tweak.Add(&spendKey.Key)
+
+		require.Equal(t, output.OutputKey, schnorr.SerializePubKey(btcec.PrivKeyFromScalar(&tweak).PubKey()))
+	}
+
+	t.Run("other receiver", func(t *testing.T) {
+		found, err := bip352.Scan(newKey(t), spendKey.PubKey(), inputsKey, inputHash, outputKeys)
+		require.NoError(t, err)
+		require.Empty(t, found)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := bip352.SenderOutputKeys(nil, []*bip352.Address{recipient})
+		require.ErrorIs(t, err, bip352.ErrNoInputs)
+
+		_, err = bip352.SenderOutputKeys(inputs[2:], []*bip352.Address{recipient})
+		require.ErrorIs(t, err, bip352.ErrInvalidInputsKey)
+	})
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+// ErrUnknownBlockHeight describes that block height is not encoded in the coinbase and is not provided.
+var ErrUnknownBlockHeight = errors.New("unknown block height")
+
+// BlockEventType defines type of the runes or inscriptions protocol event.
+type BlockEventType string
+
+const (
+	// BlockEventRuneEtched defines new rune etching.
+	BlockEventRuneEtched BlockEventType = "rune-etched"
+	// BlockEventRuneMinted defines rune mint.
+	BlockEventRuneMinted BlockEventType = "rune-minted"
+	// BlockEventRuneTransferred defines runes transfer by edict.
+	BlockEventRuneTransferred BlockEventType = "rune-transferred"
+	// BlockEventCenotaph defines malformed runestone, all runes of the transaction inputs are burned.
+	BlockEventCenotaph BlockEventType = "cenotaph"
+	// BlockEventInscriptionCreated defines new inscription reveal.
+	BlockEventInscriptionCreated BlockEventType = "inscription-created"
+	// BlockEventInscriptionTransferred defines spending of the output which holds inscription.
+	BlockEventInscriptionTransferred BlockEventType = "inscription-transferred"
+)
+
+// BlockEvent describes runes or inscriptions protocol event of the block transaction.
+type BlockEvent struct {
+	Type    BlockEventType
+	TxIndex int // transaction index in the block.
+	TxHash  string
+
+	Runestone *runes.Runestone // parsed runestone, may be partial for cenotaph, runes events only.
+	RuneID    runes.RuneID     // etched, minted or transferred rune.
+	Edict     *runes.Edict     // BlockEventRuneTransferred only.
+	Cenotaph  error            // cenotaph reason, BlockEventCenotaph only.
+
+	Inscription   *inscriptions.Inscription // BlockEventInscriptionCreated only.
+	InscriptionID inscriptions.ID
+	Input         int // reveal or spending input index, inscriptions events only.
+	// Location is an output which receives inscription, nil if it can not be resolved by the block data.
+	// INFO: Resolving requires amounts of the spent outputs, except inscriptions of the first input or with pointer.
+	Location *wire.OutPoint
+}
+
+// InscriptionLocator returns IDs of the inscriptions located on the outpoint before the block.
+type InscriptionLocator func(outpoint wire.OutPoint) []inscriptions.ID
+
+// ParseBlockOption defines functional option for ParseBlock.
+type ParseBlockOption func(*parseBlockConfig)
+
+// parseBlockConfig defines ParseBlock configuration.
+type parseBlockConfig struct {
+	height  *uint64
+	locator InscriptionLocator
+}
+
+// WithBlockHeight sets block height, required for blocks without BIP-34 height in the coinbase.
+func WithBlockHeight(height uint64) ParseBlockOption {
+	return func(config *parseBlockConfig) {
+		config.height = &height
+	}
+}
+
+// WithInscriptionLocator sets locator of the existing inscriptions to detect their transfers.
+// Inscriptions created in the block with resolved location are detected without locator.
+func WithInscriptionLocator(locator InscriptionLocator) ParseBlockOption {
+	return func(config *parseBlockConfig) {
+		config.locator = locator
+	}
+}
+
+// ParseBlock parses runes and inscriptions protocols events of the block transactions in the block order.
+// NOTE: Etching commitments and mint terms are not checked, since they require chain state.
+func ParseBlock(block *wire.MsgBlock, opts ...ParseBlockOption) ([]BlockEvent, error) {
+	var config parseBlockConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if config.height == nil {
+		height, ok := coinbaseHeight(block)
+		if !ok {
+			return nil, ErrUnknownBlockHeight
+		}
+
+		config.height = &height
+	}
+
+	var (
+		events    []BlockEvent
+		locations = make(map[wire.OutPoint][]inscriptions.ID)
+	)
+	for txIndex, tx := range block.Transactions {
+		txHash := tx.TxHash()
+		base := BlockEvent{TxIndex: txIndex, TxHash: txHash.String()}
+
+		if txIndex != 0 {
+			events = append(events, inscriptionTransfers(base, tx, locations, config.locator)...)
+		}
+
+		events = append(events, inscriptionReveals(base, tx, locations)...)
+		events = append(events, runesEvents(base, tx, runes.RuneID{Block: *config.height, TxID: uint32(txIndex)})...)
+	}
+
+	return events, nil
+}
+
+// runesEvents returns runes events of the transaction runestone.
+func runesEvents(base BlockEvent, tx *wire.MsgTx, etchedID runes.RuneID) []BlockEvent {
+	var script []byte
+	for _, output := range tx.TxOut {
+		if runes.IsPossibleRunestone(output.PkScript) {
+			script = output.PkScript
+			break
+		}
+	}
+	if script == nil {
+		return nil
+	}
+
+	runestone, err := runes.ParseRunestone(script)
+	if err == nil {
+		err = runestone.Verify(len(tx.TxOut))
+	}
+
+	base.Runestone = runestone
+	if err != nil {
+		base.Type, base.Cenotaph = BlockEventCenotaph, err
+		return []BlockEvent{base}
+	}
+
+	var events []BlockEvent
+	if runestone.Etching != nil {
+		event := base
+		event.Type, event.RuneID = BlockEventRuneEtched, etchedID
+		events = append(events, event)
+	}
+
+	if runestone.Mint != nil {
+		event := base
+		event.Type, event.RuneID = BlockEventRuneMinted, *runestone.Mint
+		events = append(events, event)
+	}
+
+	for i := range runestone.Edicts {
+		edict := runestone.Edicts[i]
+
+		// INFO: [Rust impl] rune id 0:0 refers to the rune etched by the transaction.
+		if edict.RuneID == (runes.RuneID{}) {
+			if runestone.Etching == nil {
+				continue
+			}
+
+			edict.RuneID = etchedID
+		}
+
+		event := base
+		event.Type, event.RuneID, event.Edict = BlockEventRuneTransferred, edict.RuneID, &edict
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// inscriptionReveals returns inscriptions created by the transaction inputs and stores their resolved locations.
+func inscriptionReveals(base BlockEvent, tx *wire.MsgTx, locations map[wire.OutPoint][]inscriptions.ID) []BlockEvent {
+	txHash := tx.TxHash()
+
+	var events []BlockEvent
+	for inputIndex, input := range tx.TxIn {
+		// INFO: tap script is the second to last witness item of the script path spending without annex.
+		if len(input.Witness) < 2 {
+			continue
+		}
+
+		parsed, err := inscriptions.ParseInscriptionsFromWitnessData(input.Witness[len(input.Witness)-2])
+		if err != nil {
+			continue
+		}
+
+		for _, inscription := range parsed {
+			inscription.ID = inscriptions.ID{TxID: &txHash, Index: uint32(len(events))}
+
+			event := base
+			event.Type, event.Inscription, event.InscriptionID, event.Input = BlockEventInscriptionCreated,
+				inscription, inscription.ID, inputIndex
+
+			// INFO: [Rust impl] pointer beyond outputs amount is ignored.
+			if inscription.Pointer != nil {
+				event.Location = outputBySatOffset(tx, txHash, inscription.Pointer)
+			}
+			if event.Location == nil && inputIndex == 0 {
+				event.Location = outputBySatOffset(tx, txHash, big.NewInt(0))
+			}
+			if event.Location != nil {
+				locations[*event.Location] = append(locations[*event.Location], inscription.ID)
+			}
+
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// inscriptionTransfers returns transfers of the inscriptions located on the transaction inputs.
+func inscriptionTransfers(base BlockEvent, tx *wire.MsgTx, locations map[wire.OutPoint][]inscriptions.ID,
+	locator InscriptionLocator) []BlockEvent {
+	var events []BlockEvent
+	for inputIndex, input := range tx.TxIn {
+		ids := locations[input.PreviousOutPoint]
+		delete(locations, input.PreviousOutPoint)
+		if locator != nil {
+			ids = append(ids, locator(input.PreviousOutPoint)...)
+		}
+
+		for _, id := range ids {
+			event := base
+			event.Type, event.InscriptionID, event.Input = BlockEventInscriptionTransferred, id, inputIndex
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// outputBySatOffset returns output which contains sat with offset from the start of the transaction outputs,
+// nil if the offset exceeds outputs amount, i.e. the sat is spent as fee.
+func outputBySatOffset(tx *wire.MsgTx, txHash chainhash.Hash, offset *big.Int) *wire.OutPoint {
+	if !offset.IsInt64() {
+		return nil
+	}
+
+	start := int64(0)
+	for index, output := range tx.TxOut {
+		if offset.Int64() < start+output.Value {
+			return wire.NewOutPoint(&txHash, uint32(index))
+		}
+
+		start += output.Value
+	}
+
+	return nil
+}
+
+// coinbaseHeight returns block height encoded by BIP-34 in the coinbase script sig.
+func coinbaseHeight(block *wire.MsgBlock) (uint64, bool) {
+	if len(block.Transactions) == 0 || len(block.Transactions[0].TxIn) == 0 {
+		return 0, false
+	}
+
+	script := block.Transactions[0].TxIn[0].SignatureScript
+	if len(script) == 0 {
+		return 0, false
+	}
+
+	switch op := script[0]; {
+	case op == txscript.OP_0:
+		return 0, true
+	case op >= txscript.OP_1 && op <= txscript.OP_16:
+		return uint64(op - (txscript.OP_1 - 1)), true
+	case op >= txscript.OP_DATA_1 && op <= txscript.OP_DATA_8 && len(script) > int(op):
+		var height [8]byte
+		copy(height[:], script[1:1+op])
+
+		// INFO: script numbers are signed, heights are positive.
+		if script[op]&0x80 != 0 {
+			return 0, false
+		}
+
+		return binary.LittleEndian.Uint64(height[:]), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+func TestParseBlock(t *testing.T) {
+	height := uint64(840000)
+	coinbaseScript, err := txscript.NewScriptBuilder().AddInt64(int64(height)).AddData([]byte("pool")).Script()
+	require.NoError(t, err)
+
+	coinbase := wire.NewMsgTx(2)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), coinbaseScript, nil))
+	coinbase.AddTxOut(wire.NewTxOut(312500000, []byte{txscript.OP_TRUE}))
+
+	rune_, err := runes.NewRuneFromString("BLOCKEVENTS")
+	require.NoError(t, err)
+
+	divisibility, spacers, symbol := byte(2), uint32(0), 'B'
+	etchingScript, err := (&runes.Runestone{
+		Etching: &runes.Etching{
+			Divisibility: &divisibility,
+			Premine:      big.NewInt(1000),
+			Rune:         rune_,
+			Spacers:      &spacers,
+			Symbol:       &symbol,
+		},
+		Edicts: []runes.Edict{{Amount: big.NewInt(400), Output: 1}},
+	}).IntoScript()
+	require.NoError(t, err)
+
+	inscription := &inscriptions.Inscription{ContentType: "text/plain", Body: []byte("block events")}
+	tapScript, err := inscription.IntoScriptForWitness(make([]byte, 32))
+	require.NoError(t, err)
+
+	// INFO: reveal transaction inscribes first sat of the input 0 and etches rune.
+	reveal := wire.NewMsgTx(2)
+	reveal.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: *wire.NewOutPoint(&chainhash.Hash{1}, 0),
+		Witness:          wire.TxWitness{make([]byte, 64), tapScript, make([]byte, 33)},
+	})
+	reveal.AddTxOut(wire.NewTxOut(546, []byte{txscript.OP_TRUE}))
+	reveal.AddTxOut(wire.NewTxOut(0, etchingScript))
+	revealHash := reveal.TxHash()
+
+	mintID := runes.RuneID{Block: 839999, TxID: 7}
+	mintScript, err := (&runes.Runestone{Mint: &mintID}).IntoScript()
+	require.NoError(t, err)
+
+	located := *wire.NewOutPoint(&chainhash.Hash{2}, 1)
+	locatedID := inscriptions.ID{TxID: &chainhash.Hash{3}, Index: 0}
+
+	// INFO: transfer transaction spends revealed inscription and inscription located by the locator, and mints rune.
+	transfer := wire.NewMsgTx(2)
+	transfer.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&revealHash, 0), nil, nil))
+	transfer.AddTxIn(wire.NewTxIn(&located, nil, nil))
+	transfer.AddTxOut(wire.NewTxOut(546, []byte{txscript.OP_TRUE}))
+	transfer.AddTxOut(wire.NewTxOut(0, mintScript))
+	transferHash := transfer.TxHash()
+
+	pointer := uint32(5)
+	cenotaphScript, err := (&runes.Runestone{Pointer: &pointer}).IntoScript()
+	require.NoError(t, err)
+
+	cenotaph := wire.NewMsgTx(2)
+	cenotaph.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{4}, 0), nil, nil))
+	cenotaph.AddTxOut(wire.NewTxOut(0, cenotaphScript))
+
+	block := &wire.MsgBlock{Transactions: []*wire.MsgTx{coinbase, reveal, transfer, cenotaph}}
+	locator := func(outpoint wire.OutPoint) []inscriptions.ID {
+		if outpoint == located {
+			return []inscriptions.ID{locatedID}
+		}
+
+		return nil
+	}
+
+	events, err := bitcoin.ParseBlock(block, bitcoin.WithInscriptionLocator(locator))
+	require.NoError(t, err)
+	require.Len(t, events, 7)
+
+	t.Run("inscription created", func(t *testing.T) {
+		event := events[0]
+		require.Equal(t, bitcoin.BlockEventInscriptionCreated, event.Type)
+		require.Equal(t, 1, event.TxIndex)
+		require.Equal(t, revealHash.String(), event.TxHash)
+		require.Equal(t, inscriptions.ID{TxID: &revealHash, Index: 0}, event.InscriptionID)
+		require.Equal(t, inscription.Body, event.Inscription.Body)
+		require.Equal(t, wire.NewOutPoint(&revealHash, 0), event.Location)
+	})
+
+	t.Run("rune etched", func(t *testing.T) {
+		etchedID := runes.RuneID{Block: height, TxID: 1}
+
+		require.Equal(t, bitcoin.BlockEventRuneEtched, events[1].Type)
+		require.Equal(t, etchedID, events[1].RuneID)
+		require.Equal(t, rune_, events[1].Runestone.Etching.Rune)
+
+		require.Equal(t, bitcoin.BlockEventRuneTransferred, events[2].Type)
+		require.Equal(t, etchedID, events[2].RuneID)
+		require.Equal(t, etchedID, events[2].Edict.RuneID)
+		require.EqualValues(t, 1, events[2].Edict.Output)
+	})
+
+	t.Run("inscriptions transferred", func(t *testing.T) {
+		require.Equal(t, bitcoin.BlockEventInscriptionTransferred, events[3].Type)
+		require.Equal(t, transferHash.String(), events[3].TxHash)
+		require.Equal(t, inscriptions.ID{TxID: &revealHash, Index: 0}, events[3].InscriptionID)
+		require.Equal(t, 0, events[3].Input)
+
+		require.Equal(t, bitcoin.BlockEventInscriptionTransferred, events[4].Type)
+		require.Equal(t, locatedID, events[4].InscriptionID)
+		require.Equal(t, 1, events[4].Input)
+	})
+
+	t.Run("rune minted", func(t *testing.T) {
+		require.Equal(t, bitcoin.BlockEventRuneMinted, events[5].Type)
+		require.Equal(t, mintID, events[5].RuneID)
+	})
+
+	t.Run("cenotaph", func(t *testing.T) {
+		require.Equal(t, bitcoin.BlockEventCenotaph, events[6].Type)
+		require.Equal(t, 3, events[6].TxIndex)
+		require.Error(t, events[6].Cenotaph)
+	})
+
+	t.Run("height", func(t *testing.T) {
+		block := &wire.MsgBlock{Transactions: []*wire.MsgTx{wire.NewMsgTx(2), reveal}}
+		block.Transactions[0].AddTxIn(wire.NewTxIn(&wire.OutPoint{}, nil, nil))
+
+		_, err := bitcoin.ParseBlock(block)
+		require.ErrorIs(t, err, bitcoin.ErrUnknownBlockHeight)
+
+		events, err := bitcoin.ParseBlock(block, bitcoin.WithBlockHeight(100))
+		require.NoError(t, err)
+		require.Equal(t, runes.RuneID{Block: 100, TxID: 1}, events[1].RuneID)
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package confirmations provides tracker of the transactions confirmations with chain reorganizations handling.
+package confirmations
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/rpc"
+)
+
+// ErrTxNotConfirmed describes that transaction is not included in the main chain block.
+var ErrTxNotConfirmed = errors.New("transaction is not confirmed")
+
+// Block describes main chain block.
+type Block struct {
+	Height int64
+	Hash   string
+}
+
+// BlockSource describes source of the main chain data, e.g. bitcoind RPC or Electrum server.
+type BlockSource interface {
+	// BestHeight returns height of the main chain tip.
+	BestHeight(ctx context.Context) (int64, error)
+	// BlockHash returns hash of the main chain block at the height.
+	BlockHash(ctx context.Context, height int64) (string, error)
+	// TxBlock returns main chain block which includes the transaction, ErrTxNotConfirmed should
+	// be returned (may be wrapped) if the transaction is unconfirmed. pkScript is any output
+	// script of the transaction, it is used by script indexed sources, e.g. Electrum.
+	TxBlock(ctx context.Context, txHash string, pkScript []byte) (Block, error)
+}
+
+// RPCSource is a BlockSource over bitcoind JSON-RPC.
+// NOTE: Node should be run with -txindex to find transactions not related to the node wallet.
+type RPCSource struct {
+	rpc rpc.Caller
+}
+
+// NewRPCSource is a constructor for RPCSource.
+func NewRPCSource(caller rpc.Caller) *RPCSource {
+	return &RPCSource{rpc: caller}
+}
+
+// BestHeight returns height of the main chain tip.
+func (s *RPCSource) BestHeight(ctx context.Context) (int64, error) {
+	var height int64
+	err := s.rpc.Call(ctx, "getblockcount", &height)
+
+	return height, err
+}
+
+// BlockHash returns hash of the main chain block at the height.
+func (s *RPCSource) BlockHash(ctx context.Context, height int64) (string, error) {
+	var hash string
+	err := s.rpc.Call(ctx, "getblockhash", &hash, height)
+
+	return hash, err
+}
+
+// TxBlock returns main chain block which includes the transaction.
+func (s *RPCSource) TxBlock(ctx context.Context, txHash string, _ []byte) (Block, error) {
+	var tx struct {
+		BlockHash string `json:"blockhash"`
+	}
+	if err := s.rpc.Call(ctx, "getrawtransaction", &tx, txHash, true); err != nil {
+		return Block{}, err
+	}
+
+	if tx.BlockHash == "" {
+		return Block{}, ErrTxNotConfirmed
+	}
+
+	var header struct {
+		Height        int64 `json:"height"`
+		Confirmations int64 `json:"confirmations"`
+	}
+	if err := s.rpc.Call(ctx, "getblockheader", &header, tx.BlockHash, true); err != nil {
+		return Block{}, err
+	}
+
+	// INFO: stale blocks have -1 confirmations.
+	if header.Confirmations < 1 {
+		return Block{}, ErrTxNotConfirmed
+	}
+
+	return Block{Height: header.Height, Hash: tx.BlockHash}, nil
+}
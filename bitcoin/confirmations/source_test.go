@@ -0,0 +1,58 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package confirmations_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/confirmations"
+)
+
+// rpcCaller responds with canned JSON results by method.
+type rpcCaller map[string]string
+
+func (c rpcCaller) Call(_ context.Context, method string, result any, _ ...any) error {
+	return json.Unmarshal([]byte(c[method]), result)
+}
+
+func TestRPCSource(t *testing.T) {
+	ctx := context.Background()
+	caller := rpcCaller{
+		"getblockcount":     `120`,
+		"getblockhash":      `"000000000000000000027d1fa7b9e3cf6b1f7e6c2b6b1f1d2a0b8b4f0f0c7e21"`,
+		"getrawtransaction": `{"txid":"tx","blockhash":"000000000000000000027d1fa7b9e3cf6b1f7e6c2b6b1f1d2a0b8b4f0f0c7e21"}`,
+		"getblockheader":    `{"height":118,"confirmations":3}`,
+	}
+	source := confirmations.NewRPCSource(caller)
+
+	height, err := source.BestHeight(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 120, height)
+
+	hash, err := source.BlockHash(ctx, 118)
+	require.NoError(t, err)
+	require.Equal(t, "000000000000000000027d1fa7b9e3cf6b1f7e6c2b6b1f1d2a0b8b4f0f0c7e21", hash)
+
+	block, err := source.TxBlock(ctx, "tx", nil)
+	require.NoError(t, err)
+	require.Equal(t, confirmations.Block{Height: 118, Hash: hash}, block)
+
+	t.Run("stale block", func(t *testing.T) {
+		caller["getblockheader"] = `{"height":118,"confirmations":-1}`
+
+		_, err := source.TxBlock(ctx, "tx", nil)
+		require.ErrorIs(t, err, confirmations.ErrTxNotConfirmed)
+	})
+
+	t.Run("mempool", func(t *testing.T) {
+		caller["getrawtransaction"] = `{"txid":"tx"}`
+
+		_, err := source.TxBlock(ctx, "tx", nil)
+		require.ErrorIs(t, err, confirmations.ErrTxNotConfirmed)
+	})
+}
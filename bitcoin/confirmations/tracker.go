@@ -0,0 +1,248 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package confirmations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTargetConfirmations defines confirmations depth after which transaction is considered final.
+	DefaultTargetConfirmations int64 = 6
+	// DefaultPollInterval defines interval between block source polls.
+	DefaultPollInterval = 30 * time.Second
+)
+
+// EventType defines type of the tracker event.
+type EventType string
+
+const (
+	// EventConfirmed defines that transaction is included in the main chain block.
+	EventConfirmed EventType = "confirmed"
+	// EventConfirmations defines that transaction confirmations count is changed.
+	EventConfirmations EventType = "confirmations"
+	// EventFinal defines that transaction reached target confirmations, it is not tracked anymore.
+	EventFinal EventType = "final"
+	// EventReorged defines that transaction block is removed from the main chain by reorganization,
+	// transaction is unconfirmed until included in another block.
+	EventReorged EventType = "reorged"
+)
+
+// Event describes change of the tracked transaction status.
+type Event struct {
+	Type          EventType
+	TxHash        string
+	Confirmations int64
+	Block         *Block // block which includes transaction, removed block for EventReorged.
+}
+
+// Status describes tracked transaction status.
+type Status struct {
+	Confirmations int64
+	Block         *Block // nil if transaction is unconfirmed.
+}
+
+// Config defines Tracker configuration.
+type Config struct {
+	Source              BlockSource
+	TargetConfirmations int64         // optional, DefaultTargetConfirmations if not set.
+	PollInterval        time.Duration // optional, DefaultPollInterval if not set.
+	// OnEvent is called sequentially for every event, it should not block for long.
+	OnEvent func(Event)
+}
+
+// trackedTx describes tracked transaction data.
+type trackedTx struct {
+	pkScript []byte
+	status   Status
+}
+
+// Tracker tracks transactions confirmations until target depth, detects chain reorganizations
+// which remove transactions blocks and emits events on status changes.
+// NOTE: Reorganizations deeper than target confirmations are not detected, since final
+// transactions are not tracked anymore.
+type Tracker struct {
+	config Config
+
+	mu  sync.Mutex
+	txs map[string]*trackedTx
+
+	pollMu sync.Mutex
+}
+
+// NewTracker is a constructor for Tracker.
+func NewTracker(config Config) *Tracker {
+	if config.TargetConfirmations <= 0 {
+		config.TargetConfirmations = DefaultTargetConfirmations
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+
+	return &Tracker{
+		config: config,
+		txs:    make(map[string]*trackedTx),
+	}
+}
+
+// Track starts tracking of the transaction, pkScript is any output script of the transaction
+// used by script indexed block sources, may be nil for others. Already tracked transaction is not reset.
+func (t *Tracker) Track(txHash string, pkScript []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.txs[txHash]; !ok {
+		t.txs[txHash] = &trackedTx{pkScript: slices.Clone(pkScript)}
+	}
+}
+
+// Untrack stops tracking of the transaction.
+func (t *Tracker) Untrack(txHash string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.txs, txHash)
+}
+
+// Status returns status of the tracked transaction, false if transaction is not tracked.
+func (t *Tracker) Status(txHash string) (Status, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tx, ok := t.txs[txHash]
+	if !ok {
+		return Status{}, false
+	}
+
+	return tx.status, true
+}
+
+// Run polls block source with configured interval until the context is canceled.
+// Poll errors are not fatal, they are reported by the onError callback, which may be nil.
+func (t *Tracker) Run(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(t.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.Poll(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll updates statuses of all tracked transactions by the block source and emits events.
+// It may be called on new block notifications to reduce latency. Errors of the failed transactions
+// are joined, their statuses are retried by the next poll. A reorganization detected before the failure
+// is applied anyway: the transaction becomes unconfirmed and EventReorged is emitted, since its block
+// is not in the main chain regardless of the failed lookup of the new one.
+func (t *Tracker) Poll(ctx context.Context) error {
+	t.pollMu.Lock()
+	defer t.pollMu.Unlock()
+
+	tip, err := t.config.Source.BestHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("best height: %w", err)
+	}
+
+	t.mu.Lock()
+	txs := make(map[string]trackedTx, len(t.txs))
+	hashes := make([]string, 0, len(t.txs))
+	for txHash, tx := range t.txs {
+		txs[txHash] = *tx
+		hashes = append(hashes, txHash)
+	}
+	t.mu.Unlock()
+
+	// INFO: transactions are processed in deterministic order.
+	slices.Sort(hashes)
+
+	var errs []error
+	for _, txHash := range hashes {
+		status := txs[txHash].status
+		events, err := t.update(ctx, tip, txHash, txs[txHash].pkScript, &status)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", txHash, err))
+		}
+
+		// INFO: transaction may be untracked during the update.
+		t.mu.Lock()
+		tx, ok := t.txs[txHash]
+		if ok {
+			tx.status = status
+			if status.Confirmations >= t.config.TargetConfirmations {
+				delete(t.txs, txHash)
+			}
+		}
+		t.mu.Unlock()
+
+		if ok {
+			t.emit(events)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// update updates transaction status by the block source, returns events of the changes.
+func (t *Tracker) update(ctx context.Context, tip int64, txHash string, pkScript []byte, status *Status) (events []Event, _ error) {
+	if status.Block != nil {
+		hash := ""
+		if status.Block.Height <= tip {
+			var err error
+			if hash, err = t.config.Source.BlockHash(ctx, status.Block.Height); err != nil {
+				return events, err
+			}
+		}
+
+		if hash != status.Block.Hash {
+			events = append(events, Event{Type: EventReorged, TxHash: txHash, Block: status.Block})
+			*status = Status{}
+		}
+	}
+
+	if status.Block == nil {
+		block, err := t.config.Source.TxBlock(ctx, txHash, pkScript)
+		if errors.Is(err, ErrTxNotConfirmed) {
+			return events, nil
+		}
+		if err != nil {
+			return events, err
+		}
+
+		status.Block = &block
+		status.Confirmations = max(tip-block.Height+1, 1)
+		events = append(events, Event{Type: EventConfirmed, TxHash: txHash, Confirmations: status.Confirmations, Block: status.Block})
+	} else if confirmations := tip - status.Block.Height + 1; confirmations != status.Confirmations {
+		status.Confirmations = confirmations
+		events = append(events, Event{Type: EventConfirmations, TxHash: txHash, Confirmations: confirmations, Block: status.Block})
+	}
+
+	if status.Confirmations >= t.config.TargetConfirmations {
+		events = append(events, Event{Type: EventFinal, TxHash: txHash, Confirmations: status.Confirmations, Block: status.Block})
+	}
+
+	return events, nil
+}
+
+// emit calls event callback for every event.
+func (t *Tracker) emit(events []Event) {
+	if t.config.OnEvent == nil {
+		return
+	}
+
+	for _, event := range events {
+		t.config.OnEvent(event)
+	}
+}
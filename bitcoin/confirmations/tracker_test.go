@@ -0,0 +1,196 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package confirmations_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/confirmations"
+)
+
+// chain is an in-memory main chain, block hashes are "<fork>-<height>".
+type chain struct {
+	blocks []string            // main chain block hashes by height.
+	txs    map[string][]string // block transactions by block hash.
+	fail   map[string]error    // TxBlock errors by transaction.
+}
+
+func newChain() *chain {
+	return &chain{blocks: []string{"genesis"}, txs: make(map[string][]string), fail: make(map[string]error)}
+}
+
+func (c *chain) mine(fork string, txs ...string) {
+	hash := fmt.Sprintf("%s-%d", fork, len(c.blocks))
+	c.blocks = append(c.blocks, hash)
+	c.txs[hash] = txs
+}
+
+func (c *chain) disconnect(blocks int) {
+	c.blocks = c.blocks[:len(c.blocks)-blocks]
+}
+
+func (c *chain) BestHeight(context.Context) (int64, error) {
+	return int64(len(c.blocks) - 1), nil
+}
+
+func (c *chain) BlockHash(_ context.Context, height int64) (string, error) {
+	return c.blocks[height], nil
+}
+
+func (c *chain) TxBlock(_ context.Context, txHash string, _ []byte) (confirmations.Block, error) {
+	if err := c.fail[txHash]; err != nil {
+		return confirmations.Block{}, err
+	}
+
+	for height, hash := range c.blocks {
+		for _, tx := range c.txs[hash] {
+			if tx == txHash {
+				return confirmations.Block{Height: int64(height), Hash: hash}, nil
+			}
+		}
+	}
+
+	return confirmations.Block{}, fmt.Errorf("%s: %w", txHash, confirmations.ErrTxNotConfirmed)
+}
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+	source := newChain()
+
+	var events []confirmations.Event
+	tracker := confirmations.NewTracker(confirmations.Config{
+		Source:              source,
+		TargetConfirmations: 3,
+		OnEvent:             func(event confirmations.Event) { events = append(events, event) },
+	})
+
+	popEvents := func() []confirmations.Event {
+		popped := events
+		events = nil
+
+		return popped
+	}
+
+	tracker.Track("tx1", nil)
+	tracker.Track("tx2", nil)
+
+	require.NoError(t, tracker.Poll(ctx))
+	require.Empty(t, popEvents())
+
+	status, ok := tracker.Status("tx1")
+	require.True(t, ok)
+	require.Nil(t, status.Block)
+
+	t.Run("confirmations", func(t *testing.T) {
+		source.mine("a", "tx1")
+		require.NoError(t, tracker.Poll(ctx))
+		require.Equal(t, []confirmations.Event{{
+			Type:          confirmations.EventConfirmed,
+			TxHash:        "tx1",
+			Confirmations: 1,
+			Block:         &confirmations.Block{Height: 1, Hash: "a-1"},
+		}}, popEvents())
+
+		source.mine("a", "tx2")
+		require.NoError(t, tracker.Poll(ctx))
+
+		got := popEvents()
+		require.Len(t, got, 2)
+		require.Equal(t, confirmations.EventConfirmations, got[0].Type)
+		require.EqualValues(t, 2, got[0].Confirmations)
+		require.Equal(t, confirmations.EventConfirmed, got[1].Type)
+		require.Equal(t, "tx2", got[1].TxHash)
+
+		// INFO: repeated poll without new blocks does not emit events.
+		require.NoError(t, tracker.Poll(ctx))
+		require.Empty(t, popEvents())
+	})
+
+	t.Run("reorg", func(t *testing.T) {
+		source.disconnect(1)
+		source.mine("b")
+		source.mine("b", "tx2")
+
+		require.NoError(t, tracker.Poll(ctx))
+
+		got := popEvents()
+		require.Len(t, got, 4)
+		require.Equal(t, confirmations.EventConfirmations, got[0].Type)
+		require.Equal(t, "tx1", got[0].TxHash)
+		require.EqualValues(t, 3, got[0].Confirmations)
+		require.Equal(t, confirmations.EventFinal, got[1].Type)
+		require.Equal(t, "tx1", got[1].TxHash)
+		require.Equal(t, confirmations.Event{
+			Type:   confirmations.EventReorged,
+			TxHash: "tx2",
+			Block:  &confirmations.Block{Height: 2, Hash: "a-2"},
+		}, got[2])
+		require.Equal(t, confirmations.Event{
+			Type:          confirmations.EventConfirmed,
+			TxHash:        "tx2",
+			Confirmations: 1,
+			Block:         &confirmations.Block{Height: 3, Hash: "b-3"},
+		}, got[3])
+
+		_, ok := tracker.Status("tx1")
+		require.False(t, ok)
+	})
+
+	t.Run("reorg to unconfirmed", func(t *testing.T) {
+		source.disconnect(2)
+		source.mine("c")
+
+		require.NoError(t, tracker.Poll(ctx))
+		require.Equal(t, []confirmations.Event{{
+			Type:   confirmations.EventReorged,
+			TxHash: "tx2",
+			Block:  &confirmations.Block{Height: 3, Hash: "b-3"},
+		}}, popEvents())
+
+		status, ok := tracker.Status("tx2")
+		require.True(t, ok)
+		require.Equal(t, confirmations.Status{}, status)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		errSource := errors.New("source error")
+		source.fail["tx3"] = errSource
+		tracker.Track("tx3", nil)
+
+		err := tracker.Poll(ctx)
+		require.ErrorIs(t, err, errSource)
+		require.ErrorContains(t, err, "tx3")
+
+		tracker.Untrack("tx3")
+		require.NoError(t, tracker.Poll(ctx))
+		popEvents()
+
+		// INFO: reorg is applied even if the transaction lookup fails after it.
+		source.mine("d", "tx4")
+		tracker.Track("tx4", nil)
+		require.NoError(t, tracker.Poll(ctx))
+		require.Len(t, popEvents(), 1)
+
+		source.disconnect(1)
+		source.mine("e")
+		source.fail["tx4"] = errSource
+
+		err = tracker.Poll(ctx)
+		require.ErrorIs(t, err, errSource)
+		require.Equal(t, []confirmations.Event{{
+			Type:   confirmations.EventReorged,
+			TxHash: "tx4",
+			Block:  &confirmations.Block{Height: 3, Hash: "d-3"},
+		}}, popEvents())
+
+		status, ok := tracker.Status("tx4")
+		require.True(t, ok)
+		require.Equal(t, confirmations.Status{}, status)
+	})
+}
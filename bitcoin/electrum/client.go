@@ -0,0 +1,297 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package electrum provides Electrum protocol client to query script hashes history and unspent
+// outputs from public Electrum servers, it implements confirmations.BlockSource.
+package electrum
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed describes that client connection is closed.
+var ErrClosed = errors.New("electrum connection is closed")
+
+const (
+	// ProtocolVersion defines Electrum protocol version negotiated by the client.
+	ProtocolVersion = "1.4"
+	// defaultClientName defines client name sent to the server if not configured.
+	defaultClientName = "blockchain"
+)
+
+// Config defines Client configuration.
+type Config struct {
+	Address    string      // server host:port.
+	TLS        *tls.Config // TLS configuration for SSL servers, plain TCP is used if nil.
+	ClientName string      // client name sent on version negotiation, optional.
+	// OnNotification is called sequentially from the connection read loop for every subscription
+	// notification, it should not block for long. Notifications are dropped if nil.
+	OnNotification func(Notification)
+}
+
+// Notification describes subscription notification sent by the server.
+type Notification struct {
+	ScriptHash string  // script hash notifications only.
+	Status     string  // new script hash status, empty if script hash has no history.
+	Header     *Header // new chain tip, headers notifications only.
+}
+
+// request defines JSON-RPC request.
+type request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// message defines JSON-RPC response or notification sent by the server.
+type message struct {
+	ID     *uint64         `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *Error          `json:"error"`
+}
+
+// Client is an Electrum protocol JSON-RPC client over single TCP or SSL connection.
+type Client struct {
+	conn           net.Conn
+	onNotification func(Notification)
+	id             atomic.Uint64
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	pending map[uint64]chan message
+	err     error
+	done    chan struct{}
+}
+
+// Dial connects to the Electrum server and negotiates protocol version.
+func Dial(ctx context.Context, config Config) (*Client, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+	if config.TLS != nil {
+		dialer := &tls.Dialer{Config: config.TLS}
+		conn, err = dialer.DialContext(ctx, "tcp", config.Address)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", config.Address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client := NewClient(conn, config.OnNotification)
+
+	clientName := config.ClientName
+	if clientName == "" {
+		clientName = defaultClientName
+	}
+
+	if _, err = client.ServerVersion(ctx, clientName); err != nil {
+		return nil, errors.Join(err, client.Close())
+	}
+
+	return client, nil
+}
+
+// NewClient is a constructor for Client over established connection, onNotification may be nil.
+// NOTE: Protocol version is not negotiated, use Dial or call ServerVersion first.
+func NewClient(conn net.Conn, onNotification func(Notification)) *Client {
+	client := &Client{
+		conn:           conn,
+		onNotification: onNotification,
+		pending:        make(map[uint64]chan message),
+		done:           make(chan struct{}),
+	}
+
+	go client.readLoop()
+
+	return client
+}
+
+// Close closes the connection, pending calls are failed with ErrClosed.
+func (c *Client) Close() error {
+	err := c.conn.Close()
+	<-c.done
+
+	return err
+}
+
+// Done returns channel which is closed when the connection is closed or failed.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Call calls server method and decodes its result into the result value, result may be nil.
+func (c *Client) Call(ctx context.Context, method string, result any, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+
+	id := c.id.Add(1)
+	data, err := json.Marshal(request{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	response := make(chan message, 1)
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.pending[id] = response
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+	}()
+
+	if err = c.write(ctx, append(data, '\n')); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+
+	var msg message
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case msg = <-response:
+	case <-c.done:
+		return fmt.Errorf("%s: %w", method, c.closeErr())
+	}
+
+	if msg.Error != nil {
+		return fmt.Errorf("%s: %w", method, msg.Error)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(msg.Result, result)
+}
+
+// ServerVersion negotiates protocol version, it should be the first call of the connection.
+// Returns server software version.
+func (c *Client) ServerVersion(ctx context.Context, clientName string) (string, error) {
+	var version []string
+	if err := c.Call(ctx, "server.version", &version, clientName, ProtocolVersion); err != nil {
+		return "", err
+	}
+
+	if len(version) == 0 {
+		return "", errors.New("server.version: empty response")
+	}
+
+	return version[0], nil
+}
+
+// Ping pings the server to keep the connection alive.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.Call(ctx, "server.ping", nil)
+}
+
+// write writes request line to the connection.
+func (c *Client) write(ctx context.Context, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+
+	_, err := c.conn.Write(data)
+
+	return err
+}
+
+// readLoop reads server messages until the connection is closed, dispatching responses
+// to the pending calls and notifications to the callback.
+func (c *Client) readLoop() {
+	reader := bufio.NewReader(c.conn)
+
+	var err error
+	for {
+		var line []byte
+		if line, err = reader.ReadBytes('\n'); err != nil {
+			break
+		}
+
+		var msg message
+		if err = json.Unmarshal(line, &msg); err != nil {
+			err = fmt.Errorf("invalid server message: %w", err)
+			break
+		}
+
+		if msg.ID == nil {
+			c.notify(msg)
+			continue
+		}
+
+		c.mu.Lock()
+		response, ok := c.pending[*msg.ID]
+		c.mu.Unlock()
+		if ok {
+			response <- msg
+		}
+	}
+
+	c.mu.Lock()
+	c.err = fmt.Errorf("%w: %w", ErrClosed, err)
+	c.mu.Unlock()
+
+	_ = c.conn.Close()
+	close(c.done)
+}
+
+// notify decodes subscription notification and passes it to the callback.
+func (c *Client) notify(msg message) {
+	if c.onNotification == nil {
+		return
+	}
+
+	switch msg.Method {
+	case methodScriptHashSubscribe:
+		var params []*string
+		if err := json.Unmarshal(msg.Params, &params); err != nil || len(params) != 2 || params[0] == nil {
+			return
+		}
+
+		notification := Notification{ScriptHash: *params[0]}
+		if params[1] != nil {
+			notification.Status = *params[1]
+		}
+
+		c.onNotification(notification)
+	case methodHeadersSubscribe:
+		var params []Header
+		if err := json.Unmarshal(msg.Params, &params); err != nil || len(params) == 0 {
+			return
+		}
+
+		c.onNotification(Notification{Header: &params[0]})
+	}
+}
+
+// closeErr returns connection close error.
+func (c *Client) closeErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package electrum_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/confirmations"
+	"github.com/BoostyLabs/blockchain/bitcoin/electrum"
+)
+
+// server serves canned results by method over the connection, a results entry may be
+// a JSON result or {"error": ...} object, notifications are sent after the response.
+type server struct {
+	results       map[string]string
+	notifications map[string][]string
+}
+
+func (s *server) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var request struct {
+			ID     uint64 `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			return
+		}
+
+		result, ok := s.results[request.Method]
+		if !ok {
+			result = `{"error":{"code":-32601,"message":"unknown method"}}`
+		}
+
+		response := fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, request.ID, result)
+		if strings.HasPrefix(result, `{"error":`) {
+			response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,%s`, request.ID, result[1:])
+		}
+
+		if _, err := conn.Write([]byte(response + "\n")); err != nil {
+			return
+		}
+
+		for _, notification := range s.notifications[request.Method] {
+			if _, err := conn.Write([]byte(notification + "\n")); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	address := "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"
+	decoded, err := btcutil.DecodeAddress(address, &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	script, err := txscript.PayToAddrScript(decoded)
+	require.NoError(t, err)
+	scriptHash := electrum.ScriptHash(script)
+
+	header := wire.BlockHeader{Version: 4, Timestamp: time.Unix(1700000000, 0), Bits: 0x17034219}
+	var serialized bytes.Buffer
+	require.NoError(t, header.Serialize(&serialized))
+	headerHex := hex.EncodeToString(serialized.Bytes())
+
+	srv := &server{
+		results: map[string]string{
+			"server.version":                    `["ElectrumX 1.16.0","1.4"]`,
+			"blockchain.scripthash.subscribe":   `"8b0f6c2e"`,
+			"blockchain.scripthash.listunspent": `[{"tx_hash":"aa","tx_pos":1,"height":100,"value":1500},{"tx_hash":"bb","tx_pos":0,"height":0,"value":546}]`,
+			"blockchain.scripthash.get_history": `[{"tx_hash":"aa","height":100},{"tx_hash":"bb","height":0,"fee":200}]`,
+			"blockchain.headers.subscribe":      `{"height":102,"hex":"` + headerHex + `"}`,
+			"blockchain.block.header":           `"` + headerHex + `"`,
+		},
+		notifications: map[string][]string{
+			"blockchain.scripthash.subscribe": {
+				`{"jsonrpc":"2.0","method":"blockchain.scripthash.subscribe","params":["` + scriptHash + `","9c3d1f"]}`,
+				`{"jsonrpc":"2.0","method":"blockchain.scripthash.subscribe","params":["` + scriptHash + `",null]}`,
+			},
+		},
+	}
+
+	serverConn, clientConn := net.Pipe()
+	go srv.serve(serverConn)
+
+	notifications := make(chan electrum.Notification, 2)
+	client := electrum.NewClient(clientConn, func(notification electrum.Notification) {
+		notifications <- notification
+	})
+
+	version, err := client.ServerVersion(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, "ElectrumX 1.16.0", version)
+
+	t.Run("script hash", func(t *testing.T) {
+		// INFO: script hash of the empty script from the protocol docs.
+		require.Equal(t, "55b852781b9995a44c939b64e441ae2724b96f99c8f4fb9a141cfc9842c4b0e3", electrum.ScriptHash(nil))
+	})
+
+	t.Run("subscribe", func(t *testing.T) {
+		status, err := client.Subscribe(ctx, scriptHash)
+		require.NoError(t, err)
+		require.Equal(t, "8b0f6c2e", status)
+
+		require.Equal(t, electrum.Notification{ScriptHash: scriptHash, Status: "9c3d1f"}, <-notifications)
+		require.Equal(t, electrum.Notification{ScriptHash: scriptHash}, <-notifications)
+	})
+
+	t.Run("utxos", func(t *testing.T) {
+		utxos, err := client.UTXOs(ctx, address, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.Len(t, utxos, 2)
+		outpoint, err := bitcoin.NewOutpoint("aa", 1)
+		require.NoError(t, err)
+		require.Equal(t, outpoint, utxos[0].Outpoint)
+		require.Equal(t, big.NewInt(1500), utxos[0].Amount)
+		require.Equal(t, script, utxos[0].Script)
+		require.Equal(t, address, utxos[1].Address)
+	})
+
+	t.Run("history", func(t *testing.T) {
+		history, err := client.History(ctx, scriptHash)
+		require.NoError(t, err)
+		require.Equal(t, []electrum.HistoryItem{{TxHash: "aa", Height: 100}, {TxHash: "bb", Fee: 200}}, history)
+	})
+
+	t.Run("block source", func(t *testing.T) {
+		height, err := client.BestHeight(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 102, height)
+
+		block, err := client.TxBlock(ctx, "aa", script)
+		require.NoError(t, err)
+		require.Equal(t, confirmations.Block{Height: 100, Hash: header.BlockHash().String()}, block)
+
+		_, err = client.TxBlock(ctx, "bb", script)
+		require.ErrorIs(t, err, confirmations.ErrTxNotConfirmed)
+
+		_, err = client.TxBlock(ctx, "cc", script)
+		require.ErrorIs(t, err, confirmations.ErrTxNotConfirmed)
+
+		_, err = client.TxBlock(ctx, "aa", nil)
+		require.ErrorIs(t, err, electrum.ErrScriptRequired)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		err := client.Ping(ctx)
+
+		var serverErr *electrum.Error
+		require.ErrorAs(t, err, &serverErr)
+		require.Equal(t, -32601, serverErr.Code)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		require.NoError(t, client.Close())
+
+		_, err := client.History(ctx, scriptHash)
+		require.ErrorIs(t, err, electrum.ErrClosed)
+	})
+}
@@ -0,0 +1,19 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package electrum
+
+import (
+	"fmt"
+)
+
+// Error is the error type to describe Electrum server error response.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error returns error description.
+func (e *Error) Error() string {
+	return fmt.Sprintf("electrum error %d: %s", e.Code, e.Message)
+}
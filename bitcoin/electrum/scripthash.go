@@ -0,0 +1,126 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package electrum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+)
+
+const (
+	// methodScriptHashSubscribe defines script hash subscription method, notifications use the same method.
+	methodScriptHashSubscribe = "blockchain.scripthash.subscribe"
+	// methodHeadersSubscribe defines chain tip subscription method, notifications use the same method.
+	methodHeadersSubscribe = "blockchain.headers.subscribe"
+)
+
+// Unspent describes unspent output of the script hash.
+type Unspent struct {
+	TxHash string `json:"tx_hash"`
+	TxPos  uint32 `json:"tx_pos"`
+	Height int64  `json:"height"` // 0 for mempool transactions.
+	Value  int64  `json:"value"`  // in Satoshi.
+}
+
+// HistoryItem describes transaction of the script hash history.
+type HistoryItem struct {
+	TxHash string `json:"tx_hash"`
+	// Height is a block height of the confirmed transaction, 0 for mempool transaction
+	// and -1 for mempool transaction with unconfirmed inputs.
+	Height int64 `json:"height"`
+	Fee    int64 `json:"fee,omitempty"` // in Satoshi, mempool transactions only.
+}
+
+// ScriptHash returns Electrum script hash of the output script: reversed sha256 in hex.
+func ScriptHash(pkScript []byte) string {
+	hash := sha256.Sum256(pkScript)
+	slices.Reverse(hash[:])
+
+	return hex.EncodeToString(hash[:])
+}
+
+// Subscribe subscribes to the script hash status changes, which are passed to the notifications
+// callback. Returns current status, empty if script hash has no history.
+func (c *Client) Subscribe(ctx context.Context, scriptHash string) (string, error) {
+	var status *string
+	if err := c.Call(ctx, methodScriptHashSubscribe, &status, scriptHash); err != nil {
+		return "", err
+	}
+
+	if status == nil {
+		return "", nil
+	}
+
+	return *status, nil
+}
+
+// Unsubscribe unsubscribes from the script hash status changes,
+// returns false if script hash was not subscribed.
+func (c *Client) Unsubscribe(ctx context.Context, scriptHash string) (bool, error) {
+	var unsubscribed bool
+	err := c.Call(ctx, "blockchain.scripthash.unsubscribe", &unsubscribed, scriptHash)
+
+	return unsubscribed, err
+}
+
+// ListUnspent returns unspent outputs of the script hash, including mempool ones.
+func (c *Client) ListUnspent(ctx context.Context, scriptHash string) ([]Unspent, error) {
+	var unspent []Unspent
+	err := c.Call(ctx, "blockchain.scripthash.listunspent", &unspent, scriptHash)
+
+	return unspent, err
+}
+
+// History returns confirmed and mempool transactions of the script hash.
+func (c *Client) History(ctx context.Context, scriptHash string) ([]HistoryItem, error) {
+	var history []HistoryItem
+	err := c.Call(ctx, "blockchain.scripthash.get_history", &history, scriptHash)
+
+	return history, err
+}
+
+// UTXOs returns unspent outputs of the address, including mempool ones.
+// NOTE: Electrum servers do not index runes, returned UTXOs have no linked runes.
+func (c *Client) UTXOs(ctx context.Context, address string, networkParams *chaincfg.Params) ([]bitcoin.UTXO, error) {
+	decoded, err := btcutil.DecodeAddress(address, networkParams)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := txscript.PayToAddrScript(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	unspent, err := c.ListUnspent(ctx, ScriptHash(script))
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([]bitcoin.UTXO, 0, len(unspent))
+	for _, output := range unspent {
+		outpoint, err := bitcoin.NewOutpoint(output.TxHash, output.TxPos)
+		if err != nil {
+			return nil, err
+		}
+
+		utxos = append(utxos, bitcoin.UTXO{
+			Outpoint: outpoint,
+			Amount:   big.NewInt(output.Value),
+			Script:   script,
+			Address:  address,
+		})
+	}
+
+	return utxos, nil
+}
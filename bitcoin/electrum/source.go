@@ -0,0 +1,87 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package electrum
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/confirmations"
+)
+
+// ErrScriptRequired describes that transaction can not be found without its output script.
+var ErrScriptRequired = errors.New("output script is required")
+
+// Header describes chain block header.
+type Header struct {
+	Height int64  `json:"height"`
+	Hex    string `json:"hex"` // serialized header.
+}
+
+// SubscribeHeaders subscribes to the chain tip changes, which are passed to the notifications
+// callback. Returns current chain tip.
+func (c *Client) SubscribeHeaders(ctx context.Context) (Header, error) {
+	var header Header
+	err := c.Call(ctx, methodHeadersSubscribe, &header)
+
+	return header, err
+}
+
+// BestHeight returns height of the main chain tip.
+func (c *Client) BestHeight(ctx context.Context) (int64, error) {
+	header, err := c.SubscribeHeaders(ctx)
+
+	return header.Height, err
+}
+
+// BlockHash returns hash of the main chain block at the height.
+func (c *Client) BlockHash(ctx context.Context, height int64) (string, error) {
+	var header string
+	if err := c.Call(ctx, "blockchain.block.header", &header, height); err != nil {
+		return "", err
+	}
+
+	serialized, err := hex.DecodeString(header)
+	if err != nil {
+		return "", fmt.Errorf("block header: %w", err)
+	}
+
+	return chainhash.DoubleHashH(serialized).String(), nil
+}
+
+// TxBlock returns main chain block which includes the transaction, it is found
+// by the history of the transaction output script, so pkScript is required.
+func (c *Client) TxBlock(ctx context.Context, txHash string, pkScript []byte) (confirmations.Block, error) {
+	if len(pkScript) == 0 {
+		return confirmations.Block{}, ErrScriptRequired
+	}
+
+	history, err := c.History(ctx, ScriptHash(pkScript))
+	if err != nil {
+		return confirmations.Block{}, err
+	}
+
+	for _, item := range history {
+		if item.TxHash != txHash {
+			continue
+		}
+
+		if item.Height <= 0 {
+			break
+		}
+
+		hash, err := c.BlockHash(ctx, item.Height)
+		if err != nil {
+			return confirmations.Block{}, err
+		}
+
+		return confirmations.Block{Height: item.Height, Hash: hash}, nil
+	}
+
+	return confirmations.Block{}, confirmations.ErrTxNotConfirmed
+}
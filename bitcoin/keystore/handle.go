@@ -0,0 +1,56 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package keystore
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+)
+
+// Handle signs with the keystore key without exposing it to the caller.
+// INFO: Schnorr nonces are derived deterministically from the key and the sighash (RFC6979),
+// so signing the same input twice never leaks the key, whereas callers can not use own nonces.
+type Handle struct {
+	keystore *Keystore
+	name     string
+	pubKey   *btcec.PublicKey
+}
+
+// Name returns name of the key in the keystore.
+func (handle *Handle) Name() string {
+	return handle.name
+}
+
+// PubKey returns public key of the key.
+func (handle *Handle) PubKey() *btcec.PublicKey {
+	return handle.pubKey
+}
+
+// SignTaproot signs taproot inputs by provided indexes, returns updated serialized PSBT.
+func (handle *Handle) SignTaproot(serializedPSBT []byte, inputs []int) (signed []byte, err error) {
+	err = handle.keystore.withPrivateKey(handle.name, func(privateKey *btcec.PrivateKey) error {
+		signed, err = handle.keystore.signer.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: serializedPSBT,
+			Inputs:         inputs,
+			PrivateKey:     privateKey,
+		})
+
+		return err
+	})
+
+	return signed, err
+}
+
+// SignInscriptionReveal signs inscription reveal input, returns updated serialized PSBT.
+func (handle *Handle) SignInscriptionReveal(serializedPSBT []byte, inscription *inscriptions.Inscription) (signed []byte, err error) {
+	err = handle.keystore.withPrivateKey(handle.name, func(privateKey *btcec.PrivateKey) error {
+		signed, err = handle.keystore.signer.SignInscriptionReveal(serializedPSBT, inscription, privateKey)
+
+		return err
+	})
+
+	return signed, err
+}
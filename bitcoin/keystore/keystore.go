@@ -0,0 +1,231 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package keystore provides storage of the encrypted private keys which hands out signing handles
+// instead of raw keys, so key material never leaves the keystore and is zeroized after use.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+)
+
+var (
+	// ErrInvalidKeyFile describes that encrypted key is malformed.
+	ErrInvalidKeyFile = errors.New("invalid key file")
+	// ErrInvalidPassphrase describes that encrypted key can not be decrypted with the passphrase.
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+	// ErrKeyNotFound describes that key is not loaded to the keystore or is already removed.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyExists describes that key with the same name is already loaded to the keystore.
+	ErrKeyExists = errors.New("key already exists")
+)
+
+const (
+	// keyFileVersion defines version of the encrypted key format.
+	keyFileVersion byte = 1
+	// saltLen defines length of the scrypt salt.
+	saltLen = 16
+	// aesKeyLen defines length of the AES-256 key derived with scrypt.
+	aesKeyLen = 32
+	// headerLen defines length of the key file header: version, scrypt params, salt and nonce.
+	headerLen = 4 + saltLen + 12
+)
+
+// ScryptParams defines scrypt key derivation cost parameters.
+type ScryptParams struct {
+	LogN byte // CPU/memory cost is 2^LogN.
+	R    byte
+	P    byte
+}
+
+var (
+	// DefaultScryptParams are recommended scrypt parameters, derivation takes ~128 MB and a fraction of second.
+	DefaultScryptParams = ScryptParams{LogN: 17, R: 8, P: 1}
+	// LightScryptParams are scrypt parameters for constrained environments and tests.
+	LightScryptParams = ScryptParams{LogN: 12, R: 8, P: 1}
+)
+
+// EncryptKey encrypts private key with AES-256-GCM using key derived from the passphrase with scrypt.
+//
+//	Key file struct
+//	┌──────────┬─────────────────────────────────────────────┐
+//	│  length  │                description                  │
+//	├==========┼=============================================┤
+//	│        1 │ version                                     │
+//	├──────────┼─────────────────────────────────────────────┤
+//	│        3 │ scrypt params: log2(N), r, p                │
+//	├──────────┼─────────────────────────────────────────────┤
+//	│       16 │ scrypt salt                                 │
+//	├──────────┼─────────────────────────────────────────────┤
+//	│       12 │ AES-GCM nonce                               │
+//	├──────────┼─────────────────────────────────────────────┤
+//	│       48 │ encrypted private key with GCM tag, header  │
+//	│          │ is authenticated as additional data.        │
+//	└──────────┴─────────────────────────────────────────────┘
+func EncryptKey(privateKey *btcec.PrivateKey, passphrase []byte, params ScryptParams) ([]byte, error) {
+	header := make([]byte, headerLen)
+	header[0], header[1], header[2], header[3] = keyFileVersion, params.LogN, params.R, params.P
+	if _, err := rand.Read(header[4:]); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(header, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := privateKey.Serialize()
+	defer clear(secret)
+
+	return aead.Seal(header, header[4+saltLen:], secret, header), nil
+}
+
+// decryptKey decrypts private key encrypted with EncryptKey.
+// NOTE: Caller is responsible to zeroize returned secret.
+func decryptKey(encrypted, passphrase []byte) ([]byte, error) {
+	if len(encrypted) <= headerLen || encrypted[0] != keyFileVersion {
+		return nil, ErrInvalidKeyFile
+	}
+
+	header := encrypted[:headerLen]
+	aead, err := newAEAD(header, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := aead.Open(nil, header[4+saltLen:], encrypted[headerLen:], header)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	if len(secret) != btcec.PrivKeyBytesLen {
+		clear(secret)
+		return nil, ErrInvalidKeyFile
+	}
+
+	return secret, nil
+}
+
+// newAEAD returns AES-256-GCM cipher with key derived from the passphrase by the header scrypt params.
+func newAEAD(header, passphrase []byte) (cipher.AEAD, error) {
+	logN, r, p := header[1], header[2], header[3]
+	if logN == 0 || logN >= 32 || r == 0 || p == 0 {
+		return nil, fmt.Errorf("%w: scrypt params", ErrInvalidKeyFile)
+	}
+
+	key, err := scrypt.Key(passphrase, header[4:4+saltLen], 1<<logN, int(r), int(p), aesKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyFile, err)
+	}
+	defer clear(key)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Keystore holds decrypted private keys and hands out Handle to sign with them.
+type Keystore struct {
+	signer *signer.Signer
+
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+// New is a constructor for Keystore.
+func New(signer *signer.Signer) *Keystore {
+	return &Keystore{
+		signer: signer,
+		keys:   make(map[string][]byte),
+	}
+}
+
+// Load decrypts the key with the passphrase and stores it by the name, returns handle to sign with the key.
+func (keystore *Keystore) Load(name string, encrypted, passphrase []byte) (*Handle, error) {
+	secret, err := decryptKey(encrypted, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	keystore.mu.Lock()
+	defer keystore.mu.Unlock()
+
+	if _, ok := keystore.keys[name]; ok {
+		clear(secret)
+		return nil, fmt.Errorf("%w: %s", ErrKeyExists, name)
+	}
+	keystore.keys[name] = secret
+
+	return keystore.newHandle(name, secret), nil
+}
+
+// Handle returns handle to sign with the key loaded by the name.
+func (keystore *Keystore) Handle(name string) (*Handle, error) {
+	keystore.mu.RLock()
+	defer keystore.mu.RUnlock()
+
+	secret, ok := keystore.keys[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, name)
+	}
+
+	return keystore.newHandle(name, secret), nil
+}
+
+// Remove zeroizes and removes the key, its handles become unusable.
+func (keystore *Keystore) Remove(name string) {
+	keystore.mu.Lock()
+	defer keystore.mu.Unlock()
+
+	if secret, ok := keystore.keys[name]; ok {
+		clear(secret)
+		delete(keystore.keys, name)
+	}
+}
+
+// Close zeroizes and removes all keys.
+func (keystore *Keystore) Close() {
+	keystore.mu.Lock()
+	defer keystore.mu.Unlock()
+
+	for name, secret := range keystore.keys {
+		clear(secret)
+		delete(keystore.keys, name)
+	}
+}
+
+// newHandle returns handle of the key.
+func (keystore *Keystore) newHandle(name string, secret []byte) *Handle {
+	privateKey, pubKey := btcec.PrivKeyFromBytes(secret)
+	privateKey.Zero()
+
+	return &Handle{keystore: keystore, name: name, pubKey: pubKey}
+}
+
+// withPrivateKey calls fn with private key of the name, private key is zeroized after fn returns.
+func (keystore *Keystore) withPrivateKey(name string, fn func(privateKey *btcec.PrivateKey) error) error {
+	keystore.mu.RLock()
+	defer keystore.mu.RUnlock()
+
+	secret, ok := keystore.keys[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, name)
+	}
+
+	privateKey, _ := btcec.PrivKeyFromBytes(secret)
+	defer privateKey.Zero()
+
+	return fn(privateKey)
+}
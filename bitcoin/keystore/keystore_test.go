@@ -0,0 +1,93 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package keystore_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/keystore"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestKeystore(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+	passphrase := []byte("correct horse battery staple")
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	encrypted, err := keystore.EncryptKey(wallet.PrivateKey, passphrase, keystore.LightScryptParams)
+	require.NoError(t, err)
+	require.NotContains(t, string(encrypted), string(wallet.PrivateKey.Serialize()))
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(9000, wallet.Script))
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	require.NoError(t, err)
+	packet.Inputs[0].WitnessUtxo = wire.NewTxOut(10000, wallet.Script)
+
+	var serializedPSBT bytes.Buffer
+	require.NoError(t, packet.Serialize(&serializedPSBT))
+
+	ks := keystore.New(signer.NewSigner(networkParams, signer.VerifySignatures()))
+	defer ks.Close()
+
+	t.Run("sign", func(t *testing.T) {
+		handle, err := ks.Load("hot", encrypted, passphrase)
+		require.NoError(t, err)
+		require.True(t, wallet.PrivateKey.PubKey().IsEqual(handle.PubKey()))
+
+		signed, err := handle.SignTaproot(serializedPSBT.Bytes(), []int{0})
+		require.NoError(t, err)
+
+		signedPacket, err := psbt.NewFromRawBytes(bytes.NewReader(signed), false)
+		require.NoError(t, err)
+		require.NotEmpty(t, signedPacket.Inputs[0].TaprootKeySpendSig)
+
+		handle, err = ks.Handle("hot")
+		require.NoError(t, err)
+		require.Equal(t, "hot", handle.Name())
+
+		_, err = ks.Load("hot", encrypted, passphrase)
+		require.ErrorIs(t, err, keystore.ErrKeyExists)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		handle, err := ks.Load("cold", encrypted, passphrase)
+		require.NoError(t, err)
+
+		ks.Remove("cold")
+		_, err = handle.SignTaproot(serializedPSBT.Bytes(), []int{0})
+		require.ErrorIs(t, err, keystore.ErrKeyNotFound)
+
+		_, err = ks.Handle("cold")
+		require.ErrorIs(t, err, keystore.ErrKeyNotFound)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ks.Load("invalid", encrypted, []byte("wrong"))
+		require.ErrorIs(t, err, keystore.ErrInvalidPassphrase)
+
+		tampered := bytes.Clone(encrypted)
+		tampered[2]++ // scrypt r param is authenticated.
+		_, err = ks.Load("invalid", tampered, passphrase)
+		require.ErrorIs(t, err, keystore.ErrInvalidPassphrase)
+
+		_, err = ks.Load("invalid", encrypted[:32], passphrase)
+		require.ErrorIs(t, err, keystore.ErrInvalidKeyFile)
+
+		tampered = bytes.Clone(encrypted)
+		tampered[1] = 0
+		_, err = ks.Load("invalid", tampered, passphrase)
+		require.ErrorIs(t, err, keystore.ErrInvalidKeyFile)
+	})
+}
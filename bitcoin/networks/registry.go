@@ -0,0 +1,126 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package networks provides registry of the transaction builders and signers per bitcoin network,
+// so services running many networks in one process resolve instances by address or network name
+// instead of passing network params around.
+package networks
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+var (
+	// ErrUnknownNetwork describes that network is not registered or address belongs to none of the registered networks.
+	ErrUnknownNetwork = errors.New("unknown network")
+	// ErrAmbiguousAddress describes that address is valid for many registered networks, e.g. testnet3 and signet,
+	// so the network must be resolved by its name.
+	ErrAmbiguousAddress = errors.New("address belongs to many registered networks")
+	// ErrNetworkRegistered describes that network with the same name is already registered.
+	ErrNetworkRegistered = errors.New("network is already registered")
+)
+
+// Network describes instances bound to the same network params.
+type Network struct {
+	Params    *chaincfg.Params
+	TxBuilder *txbuilder.TxBuilder
+	Signer    *signer.Signer
+}
+
+// Registry holds TxBuilder and Signer instances per network.
+// Registry is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	networks map[string]*Network // by network name.
+}
+
+// NewRegistry is a constructor for Registry.
+func NewRegistry() *Registry {
+	return &Registry{networks: make(map[string]*Network)}
+}
+
+// Register creates TxBuilder and Signer with the network params and options, and registers them
+// by the network name, see chaincfg.Params.Name.
+func (r *Registry) Register(params *chaincfg.Params, builderOpts []txbuilder.Option, signerOpts []signer.Option) (*Network, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.networks[params.Name]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrNetworkRegistered, params.Name)
+	}
+
+	network := &Network{
+		Params:    params,
+		TxBuilder: txbuilder.NewTxBuilder(params, builderOpts...),
+		Signer:    signer.NewSigner(params, signerOpts...),
+	}
+	r.networks[params.Name] = network
+
+	return network, nil
+}
+
+// ByName returns registered network by its name, e.g. "mainnet" or "testnet3".
+func (r *Registry) ByName(name string) (*Network, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	network, ok := r.networks[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, name)
+	}
+
+	return network, nil
+}
+
+// ByAddress returns registered network the address belongs to.
+// Returns ErrAmbiguousAddress if the address is valid for many registered networks.
+func (r *Registry) ByAddress(address string) (*Network, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matched []*Network
+	for _, network := range r.networks {
+		decoded, err := btcutil.DecodeAddress(address, network.Params)
+		if err == nil && decoded.IsForNet(network.Params) {
+			matched = append(matched, network)
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("%w: address %s", ErrUnknownNetwork, address)
+	case 1:
+		return matched[0], nil
+	default:
+		names := make([]string, 0, len(matched))
+		for _, network := range matched {
+			names = append(names, network.Params.Name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("%w: %s is valid for %v", ErrAmbiguousAddress, address, names)
+	}
+}
+
+// Names returns sorted names of the registered networks.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.networks))
+	for name := range r.networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
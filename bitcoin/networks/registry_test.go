@@ -0,0 +1,72 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package networks_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/networks"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestRegistry(t *testing.T) {
+	addressFor := func(params *chaincfg.Params) string { return txbuildertest.NewWallet(t, params).Address }
+
+	registry := networks.NewRegistry()
+	mainnet, err := registry.Register(&chaincfg.MainNetParams, []txbuilder.Option{txbuilder.WithOutputRoles()}, nil)
+	require.NoError(t, err)
+	testnet, err := registry.Register(&chaincfg.TestNet3Params, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mainnet", "testnet3"}, registry.Names())
+
+	t.Run("register twice", func(t *testing.T) {
+		_, err := registry.Register(&chaincfg.MainNetParams, nil, nil)
+		require.ErrorIs(t, err, networks.ErrNetworkRegistered)
+	})
+
+	t.Run("by name", func(t *testing.T) {
+		network, err := registry.ByName("mainnet")
+		require.NoError(t, err)
+		require.Same(t, mainnet, network)
+
+		_, err = registry.ByName("regtest")
+		require.ErrorIs(t, err, networks.ErrUnknownNetwork)
+	})
+
+	t.Run("by address", func(t *testing.T) {
+		network, err := registry.ByAddress(addressFor(&chaincfg.MainNetParams))
+		require.NoError(t, err)
+		require.Same(t, mainnet, network)
+
+		network, err = registry.ByAddress(addressFor(&chaincfg.TestNet3Params))
+		require.NoError(t, err)
+		require.Same(t, testnet, network)
+
+		_, err = network.TxBuilder.DecodeAddress(addressFor(&chaincfg.TestNet3Params))
+		require.NoError(t, err)
+
+		_, err = registry.ByAddress(addressFor(&chaincfg.RegressionNetParams))
+		require.ErrorIs(t, err, networks.ErrUnknownNetwork)
+
+		_, err = registry.ByAddress("invalid")
+		require.ErrorIs(t, err, networks.ErrUnknownNetwork)
+	})
+
+	t.Run("ambiguous address", func(t *testing.T) {
+		_, err := registry.Register(&chaincfg.SigNetParams, nil, nil)
+		require.NoError(t, err)
+
+		// INFO: testnet3 and signet share bech32 human-readable part.
+		_, err = registry.ByAddress(addressFor(&chaincfg.SigNetParams))
+		require.ErrorIs(t, err, networks.ErrAmbiguousAddress)
+
+		network, err := registry.ByAddress(addressFor(&chaincfg.MainNetParams))
+		require.NoError(t, err)
+		require.Same(t, mainnet, network)
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package opreturn provides tagged-varint message codec used by OP_RETURN
+// based protocols, e.g. runes and protorunes.
+package opreturn
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/BoostyLabs/blockchain/internal/sequencereader"
+)
+
+// ErrTruncated defines that tag has no value.
+var ErrTruncated = errors.New("truncated payload")
+
+// Message defines tagged-varint message: sequence of tag/value pairs,
+// optionally followed by the body tag with the rest of integers as body.
+type Message struct {
+	Fields map[uint64][]*big.Int // field values by tag, the same tag may be repeated.
+	Body   []*big.Int            // integers after the body tag, nil if there is no body tag.
+}
+
+// TagValidator decides how to handle the tag during decoding. Returns true to keep
+// the field, false to ignore it, or error to reject the whole message.
+type TagValidator func(tag *big.Int) (bool, error)
+
+// Codec defines tagged-varint message codec.
+type Codec struct {
+	BodyTag     uint64       // tag after which all integers belong to the body.
+	ValidateTag TagValidator // optional, fields with tags which fit uint64 are kept if not set.
+}
+
+// Decode decodes Message from integer sequence.
+func (codec Codec) Decode(sequence []*big.Int) (*Message, error) {
+	var (
+		message = &Message{Fields: make(map[uint64][]*big.Int)}
+		sr      = sequencereader.New(sequence)
+	)
+	for sr.HasNext() {
+		tag, _ := sr.Next() // skip error due to loop condition check.
+
+		keep := tag.IsUint64()
+		if codec.ValidateTag != nil {
+			var err error
+			if keep, err = codec.ValidateTag(tag); err != nil {
+				return nil, err
+			}
+
+			keep = keep && tag.IsUint64()
+		}
+
+		if keep && tag.Uint64() == codec.BodyTag {
+			message.Body = append(make([]*big.Int, 0, sr.Len()), sr.Remaining()...)
+
+			break
+		}
+
+		value, err := sr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("%w: value of tag %s: %w", ErrTruncated, tag.String(), err)
+		}
+
+		if keep {
+			message.Fields[tag.Uint64()] = append(message.Fields[tag.Uint64()], value)
+		}
+	}
+
+	if len(message.Fields) == 0 {
+		message.Fields = nil
+	}
+
+	return message, nil
+}
+
+// DecodePayload decodes Message from payload of LEB128 encoded integers.
+func (codec Codec) DecodePayload(payload []byte) (*Message, error) {
+	sequence, err := DecodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return codec.Decode(sequence)
+}
+
+// Encode returns Message as integer sequence, fields are ordered by tag.
+func (codec Codec) Encode(message *Message) []*big.Int {
+	tags := make([]uint64, 0, len(message.Fields))
+	size := 0
+	for tag, values := range message.Fields {
+		tags = append(tags, tag)
+		size += len(values) * 2
+	}
+
+	// sort tags for immutability.
+	slices.Sort(tags)
+
+	sequence := make([]*big.Int, 0, size+1+len(message.Body))
+	for _, tag := range tags {
+		for _, value := range message.Fields[tag] {
+			sequence = append(sequence, new(big.Int).SetUint64(tag), value)
+		}
+	}
+
+	if message.Body != nil {
+		sequence = append(sequence, new(big.Int).SetUint64(codec.BodyTag))
+		sequence = append(sequence, message.Body...)
+	}
+
+	return sequence
+}
+
+// EncodePayload returns Message as payload of LEB128 encoded integers.
+func (codec Codec) EncodePayload(message *Message) ([]byte, error) {
+	return EncodePayload(codec.Encode(message))
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package opreturn_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/opreturn"
+	"github.com/BoostyLabs/blockchain/internal/sequencereader"
+)
+
+func TestCodec(t *testing.T) {
+	ints := func(values ...int64) []*big.Int {
+		sequence := make([]*big.Int, 0, len(values))
+		for _, value := range values {
+			sequence = append(sequence, big.NewInt(value))
+		}
+
+		return sequence
+	}
+
+	codec := opreturn.Codec{BodyTag: 0}
+
+	t.Run("encode and decode", func(t *testing.T) {
+		message := &opreturn.Message{
+			Fields: map[uint64][]*big.Int{
+				20: ints(7),
+				2:  ints(1, 3),
+			},
+			Body: ints(4, 5, 6),
+		}
+
+		sequence := codec.Encode(message)
+		require.Equal(t, ints(2, 1, 2, 3, 20, 7, 0, 4, 5, 6), sequence)
+
+		decoded, err := codec.Decode(sequence)
+		require.NoError(t, err)
+		require.Equal(t, message, decoded)
+
+		payload, err := codec.EncodePayload(message)
+		require.NoError(t, err)
+
+		decoded, err = codec.DecodePayload(payload)
+		require.NoError(t, err)
+		require.Equal(t, message, decoded)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		decoded, err := codec.Decode(nil)
+		require.NoError(t, err)
+		require.Nil(t, decoded.Fields)
+		require.Nil(t, decoded.Body)
+
+		decoded, err = codec.Decode(ints(0))
+		require.NoError(t, err)
+		require.NotNil(t, decoded.Body)
+		require.Empty(t, decoded.Body)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := codec.Decode(ints(2, 1, 4))
+		require.ErrorIs(t, err, opreturn.ErrTruncated)
+		require.ErrorIs(t, err, sequencereader.ErrEnded)
+		require.ErrorContains(t, err, "value of tag 4")
+		require.ErrorContains(t, err, "truncated at element 3")
+	})
+
+	t.Run("tag validation", func(t *testing.T) {
+		errRejected := errors.New("rejected")
+		codec := opreturn.Codec{
+			BodyTag: 0,
+			ValidateTag: func(tag *big.Int) (bool, error) {
+				switch {
+				case tag.Cmp(big.NewInt(100)) < 0:
+					return true, nil
+				case tag.Bit(0) == 1:
+					return false, nil
+				default:
+					return false, errRejected
+				}
+			},
+		}
+
+		decoded, err := codec.Decode(ints(2, 1, 101, 5, 4, 3))
+		require.NoError(t, err)
+		require.Equal(t, map[uint64][]*big.Int{2: ints(1), 4: ints(3)}, decoded.Fields)
+
+		_, err = codec.Decode(ints(2, 1, 102, 5))
+		require.ErrorIs(t, err, errRejected)
+	})
+
+	t.Run("tag overflows uint64", func(t *testing.T) {
+		tag := new(big.Int).Lsh(big.NewInt(1), 64)
+		decoded, err := codec.Decode([]*big.Int{tag, big.NewInt(1)})
+		require.NoError(t, err)
+		require.Nil(t, decoded.Fields)
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package opreturn
+
+import (
+	"math/big"
+
+	"github.com/aviate-labs/leb128"
+
+	"github.com/BoostyLabs/blockchain/u128"
+)
+
+var (
+	// ErrVarintOverlong defines that LEB128 integer is encoded with more bytes than u128 requires.
+	ErrVarintOverlong = u128.ErrVarintOverlong
+	// ErrVarintOverflow defines that LEB128 integer overflows u128.
+	ErrVarintOverflow = u128.ErrOverflow
+	// ErrVarintUnterminated defines that LEB128 integer has no terminating byte.
+	ErrVarintUnterminated = u128.ErrVarintUnterminated
+)
+
+// DecodeVarint decodes LEB128 encoded u128 integer from the beginning of the data.
+// Returns decoded integer and number of bytes read.
+// INFO: [Rust impl] ord varint::decode.
+func DecodeVarint(data []byte) (*big.Int, int, error) {
+	value, n, err := u128.DecodeLEB128(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return value.Big(), n, nil
+}
+
+// DecodePayload decodes payload of LEB128 encoded integers into integer sequence.
+func DecodePayload(payload []byte) ([]*big.Int, error) {
+	sequence := make([]*big.Int, 0)
+	for len(payload) > 0 {
+		num, n, err := DecodeVarint(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		sequence = append(sequence, num)
+		payload = payload[n:]
+	}
+
+	return sequence, nil
+}
+
+// EncodePayload encodes integer sequence into payload of LEB128 encoded integers.
+func EncodePayload(sequence []*big.Int) ([]byte, error) {
+	payload := make([]byte, 0)
+	for _, num := range sequence {
+		bytes, err := leb128.EncodeUnsigned(num)
+		if err != nil {
+			return nil, err
+		}
+
+		payload = append(payload, bytes...)
+	}
+
+	return payload, nil
+}
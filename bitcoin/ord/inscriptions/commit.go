@@ -0,0 +1,118 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+// ErrCommitmentMismatch defines that commit output does not commit to the inscription.
+var ErrCommitmentMismatch = errors.New("output does not commit to the inscription")
+
+// CommitAddress returns taproot commit address of the single leaf script tree with the witness
+// script, tweaked with the public key. Public key is either compressed (33 bytes) or x-only (32 bytes).
+func CommitAddress(pubKey, script []byte, chainParams *chaincfg.Params) (string, error) {
+	internalKey, err := utils.ParseXOnlyPubKey(pubKey)
+	if err != nil {
+		return "", err
+	}
+
+	address, err := utils.P2TRAddressFromInternalKey(internalKey, utils.TapScriptRoot(script), chainParams)
+	if err != nil {
+		return "", err
+	}
+
+	return address.String(), nil
+}
+
+// TapLeafHash returns tap leaf hash of the inscription witness script with the public key.
+// Public key is either compressed (33 bytes) or x-only (32 bytes).
+func (i *Inscription) TapLeafHash(pubKey []byte) (chainhash.Hash, error) {
+	script, err := i.witnessScript(pubKey)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	return txscript.NewBaseTapLeaf(script).TapHash(), nil
+}
+
+// RevealLeaf describes reveal input script path spending data, so external signers could produce
+// the script path signature (BIP-341) of the reveal input without the signer package.
+type RevealLeaf struct {
+	Script       []byte         // inscription witness script of the tap leaf.
+	LeafHash     chainhash.Hash // tap leaf hash committed by the script path signature hash.
+	ControlBlock []byte         // serialized control block proving the leaf is in the commit script tree.
+}
+
+// RevealTapLeaf returns reveal input tap leaf of the inscription witness script with the public key
+// in the single leaf script tree. Public key is either compressed (33 bytes) or x-only (32 bytes).
+// INFO: Reveal input witness is <signature> <leaf script> <control block>.
+func (i *Inscription) RevealTapLeaf(pubKey []byte) (RevealLeaf, error) {
+	script, err := i.witnessScript(pubKey)
+	if err != nil {
+		return RevealLeaf{}, err
+	}
+
+	return revealLeaf(pubKey, txscript.NewBaseTapLeaf(script))
+}
+
+// VerifyCommitment returns ErrCommitmentMismatch if commit output script pub key is not
+// the taproot output committing to the inscription witness script with the public key.
+func (i *Inscription) VerifyCommitment(pubKey, pkScript []byte) error {
+	internalKey, err := utils.ParseXOnlyPubKey(pubKey)
+	if err != nil {
+		return err
+	}
+
+	script, err := i.witnessScript(pubKey)
+	if err != nil {
+		return err
+	}
+
+	if len(pkScript) != 34 || pkScript[0] != txscript.OP_1 || pkScript[1] != txscript.OP_DATA_32 ||
+		!utils.VerifyTweak(internalKey, pkScript[2:], utils.TapScriptRoot(script)) {
+		return fmt.Errorf("%w: %x", ErrCommitmentMismatch, pkScript)
+	}
+
+	return nil
+}
+
+// witnessScript returns inscription witness script with x-only public key.
+func (i *Inscription) witnessScript(pubKey []byte) ([]byte, error) {
+	internalKey, err := utils.ParseXOnlyPubKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.IntoScriptForWitness(schnorr.SerializePubKey(internalKey))
+}
+
+// revealLeaf returns reveal data of the first leaf of the script tree with the leaves tweaked with the public key.
+func revealLeaf(pubKey []byte, leaves ...txscript.TapLeaf) (RevealLeaf, error) {
+	internalKey, err := utils.ParseXOnlyPubKey(pubKey)
+	if err != nil {
+		return RevealLeaf{}, err
+	}
+
+	tree := txscript.AssembleTaprootScriptTree(leaves...)
+	controlBlock := tree.LeafMerkleProofs[0].ToControlBlock(internalKey)
+	controlBlockBytes, err := controlBlock.ToBytes()
+	if err != nil {
+		return RevealLeaf{}, err
+	}
+
+	return RevealLeaf{
+		Script:       leaves[0].Script,
+		LeafHash:     leaves[0].TapHash(),
+		ControlBlock: controlBlockBytes,
+	}, nil
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+func TestCommitment(t *testing.T) {
+	privateKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	pubKey := privateKey.PubKey().SerializeCompressed()
+	xOnlyPubKey := schnorr.SerializePubKey(privateKey.PubKey())
+	inscription := &inscriptions.Inscription{ContentType: "text/plain;charset=utf-8", Body: []byte("Hello, world!")}
+
+	address, err := inscription.IntoAddress(hex.EncodeToString(pubKey), &chaincfg.TestNet3Params)
+	require.NoError(t, err)
+
+	decoded, err := btcutil.DecodeAddress(address, &chaincfg.TestNet3Params)
+	require.NoError(t, err)
+
+	pkScript, err := txscript.PayToAddrScript(decoded)
+	require.NoError(t, err)
+
+	t.Run("CommitAddress", func(t *testing.T) {
+		script, err := inscription.IntoScriptForWitness(xOnlyPubKey)
+		require.NoError(t, err)
+
+		for _, key := range [][]byte{pubKey, xOnlyPubKey} {
+			commitAddress, err := inscriptions.CommitAddress(key, script, &chaincfg.TestNet3Params)
+			require.NoError(t, err)
+			require.Equal(t, address, commitAddress)
+		}
+	})
+
+	t.Run("TapLeafHash", func(t *testing.T) {
+		script, err := inscription.IntoScriptForWitness(xOnlyPubKey)
+		require.NoError(t, err)
+
+		leafHash, err := inscription.TapLeafHash(pubKey)
+		require.NoError(t, err)
+		require.Equal(t, txscript.NewBaseTapLeaf(script).TapHash(), leafHash)
+
+		xOnlyLeafHash, err := inscription.TapLeafHash(xOnlyPubKey)
+		require.NoError(t, err)
+		require.Equal(t, leafHash, xOnlyLeafHash)
+	})
+
+	t.Run("RevealTapLeaf", func(t *testing.T) {
+		leaf, err := inscription.RevealTapLeaf(pubKey)
+		require.NoError(t, err)
+
+		script, err := inscription.IntoScriptForWitness(xOnlyPubKey)
+		require.NoError(t, err)
+		require.Equal(t, script, leaf.Script)
+		require.Equal(t, txscript.NewBaseTapLeaf(script).TapHash(), leaf.LeafHash)
+
+		// INFO: sign reveal input as the external signer does.
+		prevOut := wire.NewTxOut(10000, pkScript)
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+		tx.AddTxOut(wire.NewTxOut(9000, pkScript))
+
+		fetcher := txscript.NewCannedPrevOutputFetcher(prevOut.PkScript, prevOut.Value)
+		sigHashes := txscript.NewTxSigHashes(tx, fetcher)
+		sigHash, err := txscript.CalcTapscriptSignaturehash(sigHashes, txscript.SigHashDefault, tx, 0, fetcher,
+			txscript.NewBaseTapLeaf(leaf.Script))
+		require.NoError(t, err)
+
+		signature, err := schnorr.Sign(privateKey, sigHash)
+		require.NoError(t, err)
+		tx.TxIn[0].Witness = wire.TxWitness{signature.Serialize(), leaf.Script, leaf.ControlBlock}
+
+		engine, err := txscript.NewEngine(prevOut.PkScript, tx, 0, txscript.StandardVerifyFlags, nil, sigHashes,
+			prevOut.Value, fetcher)
+		require.NoError(t, err)
+		require.NoError(t, engine.Execute())
+
+		_, err = inscription.RevealTapLeaf([]byte{1, 2, 3})
+		require.Error(t, err)
+	})
+
+	t.Run("CommitRune", func(t *testing.T) {
+		rune_, err := runes.NewRuneFromString("UNCOMMONGOODS")
+		require.NoError(t, err)
+
+		committed := inscription.CommitRune(rune_)
+		require.Nil(t, inscription.Rune)
+		require.Equal(t, rune_, committed.Rune)
+		require.Equal(t, inscription.Body, committed.Body)
+
+		script, err := committed.IntoScriptForWitness(xOnlyPubKey)
+		require.NoError(t, err)
+		require.True(t, runes.ScriptCommitsToRune(script, rune_))
+
+		script, err = inscription.IntoScriptForWitness(xOnlyPubKey)
+		require.NoError(t, err)
+		require.False(t, runes.ScriptCommitsToRune(script, rune_))
+	})
+
+	t.Run("VerifyCommitment", func(t *testing.T) {
+		require.NoError(t, inscription.VerifyCommitment(pubKey, pkScript))
+		require.NoError(t, inscription.VerifyCommitment(xOnlyPubKey, pkScript))
+
+		other := &inscriptions.Inscription{ContentType: "text/plain;charset=utf-8", Body: []byte("Hello, world?")}
+		require.ErrorIs(t, other.VerifyCommitment(pubKey, pkScript), inscriptions.ErrCommitmentMismatch)
+		require.ErrorIs(t, inscription.VerifyCommitment(pubKey, pkScript[1:]), inscriptions.ErrCommitmentMismatch)
+
+		_, err := inscription.TapLeafHash([]byte{1, 2, 3})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedContentType defines that inscription content type is not allowed.
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	// ErrContentTooLarge defines that inscription body exceeds maximum size for its content type.
+	ErrContentTooLarge = errors.New("content is too large")
+)
+
+// MaxStandardContentSize defines maximum body size in bytes which fits into standard reveal
+// transaction, witness data is discounted, so 400k weight units limit minus envelope overhead.
+const MaxStandardContentSize = 390_000
+
+// contentTypesByExtension defines content types by file extensions.
+// INFO: [Rust impl] ord media table.
+var contentTypesByExtension = map[string]string{
+	".apng":  "image/apng",
+	".avif":  "image/avif",
+	".css":   "text/css",
+	".flac":  "audio/flac",
+	".gif":   "image/gif",
+	".glb":   "model/gltf-binary",
+	".gltf":  "model/gltf+json",
+	".html":  "text/html;charset=utf-8",
+	".jpeg":  "image/jpeg",
+	".jpg":   "image/jpeg",
+	".js":    "text/javascript",
+	".json":  "application/json",
+	".md":    "text/markdown;charset=utf-8",
+	".mp3":   "audio/mpeg",
+	".mp4":   "video/mp4",
+	".otf":   "font/otf",
+	".pdf":   "application/pdf",
+	".png":   "image/png",
+	".py":    "text/x-python",
+	".stl":   "model/stl",
+	".svg":   "image/svg+xml",
+	".ttf":   "font/ttf",
+	".txt":   "text/plain;charset=utf-8",
+	".wav":   "audio/wav",
+	".webm":  "video/webm",
+	".webp":  "image/webp",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".yaml":  "text/plain;charset=utf-8",
+	".yml":   "text/plain;charset=utf-8",
+}
+
+// ContentRule describes allowed content type with maximum body size.
+type ContentRule struct {
+	ContentType string // MIME type without parameters, "type/*" matches any subtype.
+	MaxSize     int    // maximum body size in bytes, 0 means no limit.
+}
+
+// DefaultContentRules defines content types rendered by ord with standardness size caps.
+var DefaultContentRules = []ContentRule{
+	{ContentType: "image/*", MaxSize: MaxStandardContentSize},
+	{ContentType: "audio/*", MaxSize: MaxStandardContentSize},
+	{ContentType: "video/*", MaxSize: MaxStandardContentSize},
+	{ContentType: "font/*", MaxSize: MaxStandardContentSize},
+	{ContentType: "model/*", MaxSize: MaxStandardContentSize},
+	{ContentType: "text/*", MaxSize: MaxStandardContentSize},
+	{ContentType: "application/json", MaxSize: MaxStandardContentSize},
+	{ContentType: "application/pdf", MaxSize: MaxStandardContentSize},
+	{ContentType: "application/pgp-signature", MaxSize: MaxStandardContentSize},
+	{ContentType: "application/yaml", MaxSize: MaxStandardContentSize},
+}
+
+// DetectContentType returns content type of the body. Content type is taken by filename
+// extension if known, otherwise it is sniffed from the body content.
+func DetectContentType(body []byte, filename string) string {
+	if contentType, ok := contentTypesByExtension[strings.ToLower(filepath.Ext(filename))]; ok {
+		return contentType
+	}
+
+	return http.DetectContentType(body)
+}
+
+// ValidateContent checks that inscription content type is allowed by rules and body fits the size limit.
+// Inscriptions with delegate are not checked for content type, since their content is taken from delegate.
+func (i *Inscription) ValidateContent(rules []ContentRule) error {
+	if i.Delegate != nil && len(i.Body) == 0 {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(i.ContentType)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentType, i.ContentType)
+	}
+
+	for _, rule := range rules {
+		if !rule.matches(mediaType) {
+			continue
+		}
+
+		if rule.MaxSize > 0 && len(i.Body) > rule.MaxSize {
+			return fmt.Errorf("%w: %d bytes of %s, max: %d", ErrContentTooLarge, len(i.Body), mediaType, rule.MaxSize)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnsupportedContentType, mediaType)
+}
+
+// matches returns true if media type satisfies the rule.
+func (rule ContentRule) matches(mediaType string) bool {
+	if prefix, ok := strings.CutSuffix(rule.ContentType, "/*"); ok {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+
+	return strings.EqualFold(rule.ContentType, mediaType)
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+)
+
+func TestContent(t *testing.T) {
+	t.Run("DetectContentType", func(t *testing.T) {
+		tests := []struct {
+			body     []byte
+			filename string
+			expected string
+		}{
+			{[]byte("<svg></svg>"), "image.SVG", "image/svg+xml"},
+			{[]byte("glTF"), "model.glb", "model/gltf-binary"},
+			{[]byte("{}"), "data.json", "application/json"},
+			{[]byte("\x89PNG\x0d\x0a\x1a\x0a"), "", "image/png"},
+			{[]byte("<!DOCTYPE html><html></html>"), "index", "text/html; charset=utf-8"},
+			{[]byte("hello"), "", "text/plain; charset=utf-8"},
+		}
+		for _, test := range tests {
+			require.Equal(t, test.expected, inscriptions.DetectContentType(test.body, test.filename), test.filename)
+		}
+	})
+
+	t.Run("ValidateContent", func(t *testing.T) {
+		delegate := inscriptions.ID{TxID: mustHash(t, "618ffb4e23e19566c7567841187a1c424dfd775e4f8cb633a7a3d4836784835f")}
+		tests := []struct {
+			inscription *inscriptions.Inscription
+			err         error
+		}{
+			{&inscriptions.Inscription{ContentType: "image/png", Body: make([]byte, 1024)}, nil},
+			{&inscriptions.Inscription{ContentType: "text/html;charset=utf-8", Body: []byte("<html></html>")}, nil},
+			{&inscriptions.Inscription{ContentType: "model/gltf+json", Body: []byte("{}")}, nil},
+			{&inscriptions.Inscription{ContentType: "Application/JSON", Body: []byte("{}")}, nil},
+			{&inscriptions.Inscription{Delegate: &delegate}, nil},
+			{&inscriptions.Inscription{ContentType: "image/png", Body: make([]byte, inscriptions.MaxStandardContentSize+1)}, inscriptions.ErrContentTooLarge},
+			{&inscriptions.Inscription{ContentType: "application/octet-stream", Body: []byte{1}}, inscriptions.ErrUnsupportedContentType},
+			{&inscriptions.Inscription{Body: []byte{1}}, inscriptions.ErrUnsupportedContentType},
+		}
+		for _, test := range tests {
+			require.ErrorIs(t, test.inscription.ValidateContent(inscriptions.DefaultContentRules), test.err, test.inscription.ContentType)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrDelegateNotFound defines that delegate inscription could not be fetched.
+var ErrDelegateNotFound = errors.New("delegate inscription not found")
+
+// ContentFetcher describes source of inscriptions to resolve delegated content.
+type ContentFetcher interface {
+	// FetchInscription returns inscription by its ID, ErrDelegateNotFound should
+	// be returned (may be wrapped) if there is no inscription with such ID.
+	FetchInscription(ctx context.Context, id ID) (*Inscription, error)
+}
+
+// Content describes effective content of the inscription.
+type Content struct {
+	ContentType     string
+	ContentEncoding string
+	Body            []byte
+}
+
+// ResolveContent returns effective content of the inscription. If Delegate is set, content
+// of the delegate inscription is returned, own body and content type are ignored.
+// INFO: [Rust impl] delegation is not recursive, delegate of the delegate is not followed.
+func (i *Inscription) ResolveContent(ctx context.Context, fetcher ContentFetcher) (Content, error) {
+	if i.Delegate == nil {
+		return i.content(), nil
+	}
+
+	if i.Delegate.TxID == nil {
+		return Content{}, fmt.Errorf("%w: invalid delegate id", ErrMalformedInscription)
+	}
+
+	delegate, err := fetcher.FetchInscription(ctx, *i.Delegate)
+	if err != nil {
+		return Content{}, fmt.Errorf("fetch delegate %s: %w", i.Delegate.String(), err)
+	}
+
+	if delegate == nil {
+		return Content{}, fmt.Errorf("%w: %s", ErrDelegateNotFound, i.Delegate.String())
+	}
+
+	return delegate.content(), nil
+}
+
+// content returns own content of the inscription.
+func (i *Inscription) content() Content {
+	return Content{
+		ContentType:     i.ContentType,
+		ContentEncoding: i.ContentEncoding,
+		Body:            i.Body,
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+)
+
+// fetcher is in-memory implementation of inscriptions.ContentFetcher.
+type fetcher map[string]*inscriptions.Inscription
+
+// FetchInscription returns inscription by its ID.
+func (f fetcher) FetchInscription(_ context.Context, id inscriptions.ID) (*inscriptions.Inscription, error) {
+	inscription, ok := f[id.String()]
+	if !ok {
+		return nil, inscriptions.ErrDelegateNotFound
+	}
+
+	return inscription, nil
+}
+
+func TestResolveContent(t *testing.T) {
+	ctx := context.Background()
+	delegateID := inscriptions.ID{TxID: mustHash(t, "618ffb4e23e19566c7567841187a1c424dfd775e4f8cb633a7a3d4836784835f")}
+	missingID := inscriptions.ID{TxID: mustHash(t, "618ffb4e23e19566c7567841187a1c424dfd775e4f8cb633a7a3d4836784835f"), Index: 1}
+
+	source := fetcher{
+		delegateID.String(): {
+			ContentType:     "text/html",
+			ContentEncoding: "br",
+			Body:            []byte("<html></html>"),
+			Delegate:        &missingID,
+		},
+	}
+
+	t.Run("own content", func(t *testing.T) {
+		content, err := (&inscriptions.Inscription{ContentType: "text/plain", Body: []byte("data")}).ResolveContent(ctx, source)
+		require.NoError(t, err)
+		require.Equal(t, inscriptions.Content{ContentType: "text/plain", Body: []byte("data")}, content)
+	})
+
+	t.Run("delegated content", func(t *testing.T) {
+		inscription := &inscriptions.Inscription{ContentType: "text/plain", Body: []byte("ignored"), Delegate: &delegateID}
+		content, err := inscription.ResolveContent(ctx, source)
+		require.NoError(t, err)
+		require.Equal(t, inscriptions.Content{ContentType: "text/html", ContentEncoding: "br", Body: []byte("<html></html>")}, content)
+	})
+
+	t.Run("missing delegate", func(t *testing.T) {
+		_, err := (&inscriptions.Inscription{Delegate: &missingID}).ResolveContent(ctx, source)
+		require.ErrorIs(t, err, inscriptions.ErrDelegateNotFound)
+	})
+}
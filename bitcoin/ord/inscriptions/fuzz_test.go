@@ -0,0 +1,26 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+)
+
+func FuzzParseInscriptionFromWitnessData(f *testing.F) {
+	seed, err := hex.DecodeString("20a9a7255fda3a07a2a3a651bae594a0ede366bb8c87bc13de4e76c2c189724a80ac0063036f7264010118746578742f706c61696e3b636861727365743d7574662d38000d48656c6c6f2c20776f726c642168")
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(seed)
+	f.Add([]byte{0x00, 0x63, 0x03, 'o', 'r', 'd', 0x68})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = inscriptions.ParseInscriptionFromWitnessData(data)
+		_, _ = inscriptions.ParseInscriptionsFromWitnessData(data)
+	})
+}
@@ -6,12 +6,13 @@ package inscriptions
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
+	"slices"
 	"strings"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
-	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/txscript"
 
@@ -56,6 +57,9 @@ type Inscription struct {
 	Parents         []*ID
 	Pointer         *big.Int
 	Rune            *runes.Rune
+	// Unbound defines that inscription is not bound to any sat, it is set by the parser if the envelope
+	// contains unrecognized even tag, TagUnbound is pushed to the script if it is set, see Vindicated.
+	Unbound bool
 }
 
 // IsPossibleInscriptionWitnessData returns true if witness data is possible to be parsed to inscription.
@@ -67,9 +71,9 @@ func IsPossibleInscriptionWitnessData(data []byte) bool {
 
 // disasmWitnessDataWithBoundsIndexes returns disassembled witness data with start and end indexes of inscription script.
 func disasmWitnessDataWithBoundsIndexes(data []byte) (disasm string, start int, end int, err error) {
-	disasm, err = txscript.DisasmString(data)
+	disasm, err = disasmWitnessData(data)
 	if err != nil {
-		return disasm, start, end, ErrMalformedInscription
+		return disasm, start, end, err
 	}
 
 	start = strings.Index(disasm, inscriptionStartDisASM)
@@ -88,7 +92,85 @@ func ParseInscriptionFromWitnessData(data []byte) (*Inscription, error) {
 		return nil, err
 	}
 
-	sr := sequencereader.New[string](strings.Split(disasm[start:end+len(inscriptionEndDisASM)], " "))
+	return parseEnvelope(strings.Split(disasm[start:end+len(inscriptionEndDisASM)], " "))
+}
+
+// ParseInscriptionsFromWitnessData parses all inscription envelopes of the witness data into Inscriptions.
+// ID.Index of each Inscription is set to the relative index of its envelope in the witness data,
+// so the reveal TxID and the number of inscriptions in the previous inputs should be applied by the caller.
+func ParseInscriptionsFromWitnessData(data []byte) ([]*Inscription, error) {
+	disasm, err := disasmWitnessData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := strings.Split(disasm, " ")
+	startOps := strings.Split(inscriptionStartDisASM, " ")
+
+	var inscriptions []*Inscription
+	for idx := 0; idx+len(startOps) <= len(ops); idx++ {
+		if !slices.Equal(ops[idx:idx+len(startOps)], startOps) {
+			continue
+		}
+
+		end := slices.Index(ops[idx:], inscriptionEndDisASM)
+		if end == -1 {
+			break
+		}
+
+		inscription, err := parseEnvelope(ops[idx : idx+end+1])
+		if err != nil {
+			return nil, err
+		}
+
+		inscription.ID.Index = uint32(len(inscriptions))
+		inscriptions = append(inscriptions, inscription)
+		idx += end
+	}
+
+	if len(inscriptions) == 0 {
+		return nil, ErrMalformedInscription
+	}
+
+	return inscriptions, nil
+}
+
+// disasmWitnessData returns one-line disassembly of the witness data like txscript.DisasmString, but
+// small integers pushed by OP_1NEGATE and OP_1 - OP_16 are hex encoded pushed bytes, e.g. "01" for OP_1,
+// since ord treats them as data pushes, see ParseInscriptionStream.
+func disasmWitnessData(data []byte) (string, error) {
+	var (
+		ops       []string
+		tokenizer = txscript.MakeScriptTokenizer(witnessScriptVersion, data)
+	)
+	for tokenizer.Next() {
+		if tokenizer.Opcode() == txscript.OP_0 {
+			ops = append(ops, "0")
+			continue
+		}
+
+		if pushed, err := pushedData(&tokenizer); err == nil {
+			ops = append(ops, hex.EncodeToString(pushed))
+			continue
+		}
+
+		name, err := txscript.DisasmString([]byte{tokenizer.Opcode()})
+		if err != nil {
+			return "", ErrMalformedInscription
+		}
+
+		ops = append(ops, name)
+	}
+	if tokenizer.Err() != nil {
+		return "", ErrMalformedInscription
+	}
+
+	return strings.Join(ops, " "), nil
+}
+
+// parseEnvelope parses disassembled inscription envelope from OP_FALSE to OP_ENDIF into Inscription.
+func parseEnvelope(ops []string) (_ *Inscription, err error) {
+	sr := sequencereader.New[string](ops)
 	// At least OP_FALSE OP_IF OP_PUSH "ord" OP_ENDIF.
 	if sr.Len() < 4 {
 		return nil, ErrMalformedInscription
@@ -110,7 +192,7 @@ func ParseInscriptionFromWitnessData(data []byte) (*Inscription, error) {
 			var value string
 			value, err = sr.Next()
 			if err != nil {
-				return nil, ErrMalformedInscription
+				return nil, fmt.Errorf("%w: value of tag %s: %w", ErrMalformedInscription, tag, err)
 			}
 
 			err = inscription.fillFieldByTag(tag, value)
@@ -205,9 +287,18 @@ func (i *Inscription) fillFieldByTag(tag string, value string) (err error) {
 		if err != nil {
 			return err
 		}
-	case TagNote.HexString(), TagNop.HexString(), TagUnbound.HexString():
+	case TagNote.HexString(), TagNop.HexString():
 	default:
-		return ErrMalformedInscription
+		// INFO: [Rust impl] unrecognized even tag makes inscription unbound, odd one is ignored.
+		// Tag is a little endian number, so its parity is defined by the first byte.
+		tagBytes, err := hex.DecodeString(tag)
+		if err != nil || len(tagBytes) == 0 {
+			return ErrMalformedInscription
+		}
+
+		if tagBytes[0]%2 == 0 {
+			i.Unbound = true
+		}
 	}
 
 	return nil
@@ -263,6 +354,11 @@ func (i *Inscription) IntoScript() ([]byte, error) {
 		scriptBuilder.AddData(reverse.Bytes(i.Rune.Value().Bytes()))
 	}
 
+	if i.Unbound {
+		scriptBuilder.AddOps(TagUnbound.IntoDataPush())
+		scriptBuilder.AddData(nil)
+	}
+
 	if len(i.Body) != 0 {
 		scriptBuilder.AddOp(txscript.OP_0)
 		script, err := scriptBuilder.Script()
@@ -337,7 +433,17 @@ func ceilQuotient(divided, divisor int) int {
 	return ceilQuo
 }
 
+// CommitRune returns copy of the Inscription with the Rune field set, so its witness script pushes
+// the rune commitment required to etch the rune, see [runes.Rune.Commitment].
+func (i *Inscription) CommitRune(rune_ *runes.Rune) *Inscription {
+	inscription := *i
+	inscription.Rune = rune_
+
+	return &inscription
+}
+
 // IntoScriptForWitness returns Inscription as a script with pubKey verify at the beginning for witness data.
+// INFO: Rune field is pushed as the rune commitment of the etching, see CommitRune.
 func (i *Inscription) IntoScriptForWitness(serializedPubKey []byte) ([]byte, error) {
 	scriptBuilder := txscript.NewScriptBuilder()
 	scriptBuilder.AddData(serializedPubKey)
@@ -368,23 +474,12 @@ func (i *Inscription) IntoAddress(publicKey string, chainParams *chaincfg.Params
 		return "", err
 	}
 
-	serializedPubKey := schnorr.SerializePubKey(pubKeyBtcec)
-	pkScript, err := i.IntoScriptForWitness(serializedPubKey)
-	if err != nil {
-		return "", err
-	}
-
-	tapLeaf := txscript.NewBaseTapLeaf(pkScript)
-	tapScriptTree := txscript.AssembleTaprootScriptTree(tapLeaf)
-	tapScriptRootHash := tapScriptTree.RootNode.TapHash()
-	outputKey := txscript.ComputeTaprootOutputKey(pubKeyBtcec, tapScriptRootHash[:])
-
-	addr, err := btcutil.NewAddressTaproot(schnorr.SerializePubKey(outputKey), chainParams)
+	pkScript, err := i.IntoScriptForWitness(schnorr.SerializePubKey(pubKeyBtcec))
 	if err != nil {
 		return "", err
 	}
 
-	return addr.String(), nil
+	return CommitAddress(pubKey, pkScript, chainParams)
 }
 
 // VBytesSize returns estimated inscription input size in virtual bytes.
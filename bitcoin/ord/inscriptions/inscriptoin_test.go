@@ -251,6 +251,74 @@ func TestInscription(t *testing.T) {
 		}
 	})
 
+	t.Run("ParseInscriptionsFromWitnessData", func(t *testing.T) {
+		first := &inscriptions.Inscription{
+			ContentType: "text/plain;charset=utf-8",
+			Body:        []byte("Hello, world!"),
+		}
+		second := &inscriptions.Inscription{
+			ContentType: "image/png",
+			Pointer:     big.NewInt(600),
+			Body:        make([]byte, 2048),
+		}
+
+		firstScript, err := first.IntoScriptForWitness(make([]byte, 32))
+		require.NoError(t, err)
+		secondScript, err := second.IntoScript()
+		require.NoError(t, err)
+
+		parsed, err := inscriptions.ParseInscriptionsFromWitnessData(append(firstScript, secondScript...))
+		require.NoError(t, err)
+		require.Len(t, parsed, 2)
+
+		second.ID.Index = 1
+		require.EqualValues(t, first, parsed[0])
+		require.EqualValues(t, second, parsed[1])
+
+		parsed, err = inscriptions.ParseInscriptionsFromWitnessData(firstScript)
+		require.NoError(t, err)
+		require.EqualValues(t, []*inscriptions.Inscription{first}, parsed)
+
+		_, err = inscriptions.ParseInscriptionsFromWitnessData(firstScript[:len(firstScript)-1])
+		require.ErrorIs(t, err, inscriptions.ErrMalformedInscription)
+	})
+
+	t.Run("unrecognized tags", func(t *testing.T) {
+		// INFO: OP_FALSE OP_IF "ord" <tag> 0xff 0x01 "text/plain" OP_0 "hello" OP_ENDIF.
+		envelope := func(tag string) []byte {
+			return mustDecodeHex(t, "0063036f7264"+tag+"01ff01010a746578742f706c61696e000568656c6c6f68")
+		}
+		expected := &inscriptions.Inscription{ContentType: "text/plain", Body: []byte("hello")}
+
+		tests := []struct {
+			tag     string
+			unbound bool
+		}{
+			{"0111", false},   // odd.
+			{"010f", false},   // note.
+			{"0104", true},    // even.
+			{"0142", true},    // unbound.
+			{"020401", true},  // parity is defined by the first byte.
+			{"020104", false}, // odd multi-byte.
+		}
+		for _, test := range tests {
+			inscription, err := inscriptions.ParseInscriptionFromWitnessData(envelope(test.tag))
+			require.NoError(t, err, test.tag)
+			require.Equal(t, test.unbound, inscription.Unbound, test.tag)
+
+			inscription.Unbound = false
+			require.Equal(t, expected, inscription)
+		}
+
+		unbound := &inscriptions.Inscription{ContentType: "text/plain", Body: []byte("hello"), Unbound: true}
+		script, err := unbound.IntoScript()
+		require.NoError(t, err)
+
+		inscription, err := inscriptions.ParseInscriptionFromWitnessData(script)
+		require.NoError(t, err)
+		require.Equal(t, unbound, inscription)
+	})
+
 	t.Run("IntoAddress", func(t *testing.T) {
 		rune1, err := runes.NewRuneFromString("HELLO")
 		require.NoError(t, err)
@@ -329,3 +397,10 @@ func mustHash(t *testing.T, hash string) *chainhash.Hash {
 
 	return h
 }
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return b
+}
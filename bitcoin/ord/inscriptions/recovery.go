@@ -0,0 +1,136 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+// ErrInvalidRecoveryDelay defines that recovery leaf delay is not a valid relative lock time in blocks.
+var ErrInvalidRecoveryDelay = errors.New("invalid recovery delay")
+
+// RecoveryControlBlockVBytes defines extra size in virtual bytes of the reveal input witness committed
+// to the tree with recovery leaf: control block contains the recovery leaf hash (32 bytes).
+const RecoveryControlBlockVBytes = 8
+
+// RecoveryLeaf defines pubkey-only refund script path of the inscription commit output, spendable by
+// the recovery key after relative delay in blocks (BIP-112), if the reveal transaction is never performed.
+type RecoveryLeaf struct {
+	PubKey []byte // recovery public key, either compressed (33 bytes) or x-only (32 bytes).
+	Delay  uint16 // relative lock time in blocks, recovery input sequence must be not less.
+}
+
+// Script returns recovery leaf script: <delay> OP_CHECKSEQUENCEVERIFY OP_DROP <x-only pubkey> OP_CHECKSIG.
+func (leaf RecoveryLeaf) Script() ([]byte, error) {
+	if leaf.Delay == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRecoveryDelay, leaf.Delay)
+	}
+
+	pubKey, err := utils.ParseXOnlyPubKey(leaf.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return txscript.NewScriptBuilder().
+		AddInt64(int64(leaf.Delay)).
+		AddOp(txscript.OP_CHECKSEQUENCEVERIFY).
+		AddOp(txscript.OP_DROP).
+		AddData(schnorr.SerializePubKey(pubKey)).
+		AddOp(txscript.OP_CHECKSIG).
+		Script()
+}
+
+// Sequence returns minimal sequence of the input spending commit output by the recovery leaf.
+func (leaf RecoveryLeaf) Sequence() uint32 {
+	return uint32(leaf.Delay) & wire.SequenceLockTimeMask
+}
+
+// RecoveryTapLeaves returns leaves of the commit script tree: inscription witness script with
+// the public key (#0) and recovery leaf script (#1).
+func (i *Inscription) RecoveryTapLeaves(pubKey []byte, recovery RecoveryLeaf) ([]txscript.TapLeaf, error) {
+	inscriptionScript, err := i.witnessScript(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryScript, err := recovery.Script()
+	if err != nil {
+		return nil, err
+	}
+
+	return []txscript.TapLeaf{txscript.NewBaseTapLeaf(inscriptionScript), txscript.NewBaseTapLeaf(recoveryScript)}, nil
+}
+
+// RecoveryRevealTapLeaf is like RevealTapLeaf, but returns inscription leaf of the script tree
+// with the recovery leaf, its control block contains the recovery leaf hash.
+func (i *Inscription) RecoveryRevealTapLeaf(pubKey []byte, recovery RecoveryLeaf) (RevealLeaf, error) {
+	leaves, err := i.RecoveryTapLeaves(pubKey, recovery)
+	if err != nil {
+		return RevealLeaf{}, err
+	}
+
+	return revealLeaf(pubKey, leaves...)
+}
+
+// RecoveryCommitAddress returns taproot commit address of the script tree with the inscription and
+// recovery leaves, tweaked with the public key. Public key is either compressed (33 bytes) or x-only (32 bytes).
+func (i *Inscription) RecoveryCommitAddress(pubKey []byte, recovery RecoveryLeaf, chainParams *chaincfg.Params) (string, error) {
+	internalKey, err := utils.ParseXOnlyPubKey(pubKey)
+	if err != nil {
+		return "", err
+	}
+
+	scriptRoot, err := i.recoveryScriptRoot(pubKey, recovery)
+	if err != nil {
+		return "", err
+	}
+
+	address, err := utils.P2TRAddressFromInternalKey(internalKey, scriptRoot, chainParams)
+	if err != nil {
+		return "", err
+	}
+
+	return address.String(), nil
+}
+
+// VerifyRecoveryCommitment returns ErrCommitmentMismatch if commit output script pub key is not the
+// taproot output committing to the script tree with the inscription and recovery leaves.
+func (i *Inscription) VerifyRecoveryCommitment(pubKey, pkScript []byte, recovery RecoveryLeaf) error {
+	internalKey, err := utils.ParseXOnlyPubKey(pubKey)
+	if err != nil {
+		return err
+	}
+
+	scriptRoot, err := i.recoveryScriptRoot(pubKey, recovery)
+	if err != nil {
+		return err
+	}
+
+	if len(pkScript) != 34 || pkScript[0] != txscript.OP_1 || pkScript[1] != txscript.OP_DATA_32 ||
+		!utils.VerifyTweak(internalKey, pkScript[2:], scriptRoot) {
+		return fmt.Errorf("%w: %x", ErrCommitmentMismatch, pkScript)
+	}
+
+	return nil
+}
+
+// recoveryScriptRoot returns merkle root of the script tree with the inscription and recovery leaves.
+func (i *Inscription) recoveryScriptRoot(pubKey []byte, recovery RecoveryLeaf) ([]byte, error) {
+	leaves, err := i.RecoveryTapLeaves(pubKey, recovery)
+	if err != nil {
+		return nil, err
+	}
+
+	root := txscript.AssembleTaprootScriptTree(leaves...).RootNode.TapHash()
+
+	return root[:], nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+)
+
+func TestRecoveryLeaf(t *testing.T) {
+	privateKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	recoveryKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	pubKey := privateKey.PubKey().SerializeCompressed()
+	inscription := &inscriptions.Inscription{ContentType: "text/plain;charset=utf-8", Body: []byte("Hello, world!")}
+	recovery := inscriptions.RecoveryLeaf{PubKey: recoveryKey.PubKey().SerializeCompressed(), Delay: 144}
+
+	t.Run("Script", func(t *testing.T) {
+		script, err := recovery.Script()
+		require.NoError(t, err)
+
+		disasm, err := txscript.DisasmString(script)
+		require.NoError(t, err)
+		require.Equal(t, "9000 OP_CHECKSEQUENCEVERIFY OP_DROP "+
+			hex.EncodeToString(schnorr.SerializePubKey(recoveryKey.PubKey()))+" OP_CHECKSIG", disasm)
+		require.EqualValues(t, 144, recovery.Sequence())
+
+		_, err = inscriptions.RecoveryLeaf{PubKey: recovery.PubKey}.Script()
+		require.ErrorIs(t, err, inscriptions.ErrInvalidRecoveryDelay)
+	})
+
+	t.Run("RecoveryCommitAddress", func(t *testing.T) {
+		address, err := inscription.RecoveryCommitAddress(pubKey, recovery, &chaincfg.TestNet3Params)
+		require.NoError(t, err)
+
+		singleLeafAddress, err := inscription.IntoAddress(hex.EncodeToString(pubKey), &chaincfg.TestNet3Params)
+		require.NoError(t, err)
+		require.NotEqual(t, singleLeafAddress, address)
+
+		decoded, err := btcutil.DecodeAddress(address, &chaincfg.TestNet3Params)
+		require.NoError(t, err)
+
+		pkScript, err := txscript.PayToAddrScript(decoded)
+		require.NoError(t, err)
+
+		require.NoError(t, inscription.VerifyRecoveryCommitment(pubKey, pkScript, recovery))
+		require.NoError(t, inscription.VerifyRecoveryCommitment(schnorr.SerializePubKey(privateKey.PubKey()), pkScript, recovery))
+		require.ErrorIs(t, inscription.VerifyCommitment(pubKey, pkScript), inscriptions.ErrCommitmentMismatch)
+
+		otherRecovery := recovery
+		otherRecovery.Delay++
+		require.ErrorIs(t, inscription.VerifyRecoveryCommitment(pubKey, pkScript, otherRecovery), inscriptions.ErrCommitmentMismatch)
+
+		leaves, err := inscription.RecoveryTapLeaves(pubKey, recovery)
+		require.NoError(t, err)
+		require.Len(t, leaves, 2)
+
+		leafHash, err := inscription.TapLeafHash(pubKey)
+		require.NoError(t, err)
+		require.Equal(t, leafHash, leaves[0].TapHash())
+	})
+
+	t.Run("RecoveryRevealTapLeaf", func(t *testing.T) {
+		address, err := inscription.RecoveryCommitAddress(pubKey, recovery, &chaincfg.TestNet3Params)
+		require.NoError(t, err)
+
+		decoded, err := btcutil.DecodeAddress(address, &chaincfg.TestNet3Params)
+		require.NoError(t, err)
+
+		leaf, err := inscription.RecoveryRevealTapLeaf(pubKey, recovery)
+		require.NoError(t, err)
+
+		leafHash, err := inscription.TapLeafHash(pubKey)
+		require.NoError(t, err)
+		require.Equal(t, leafHash, leaf.LeafHash)
+
+		controlBlock, err := txscript.ParseControlBlock(leaf.ControlBlock)
+		require.NoError(t, err)
+		require.Len(t, controlBlock.InclusionProof, 32) // recovery leaf hash.
+		require.NoError(t, txscript.VerifyTaprootLeafCommitment(controlBlock, decoded.ScriptAddress(), leaf.Script))
+
+		single, err := inscription.RevealTapLeaf(pubKey)
+		require.NoError(t, err)
+		require.Equal(t, single.Script, leaf.Script)
+		require.NotEqual(t, single.ControlBlock, leaf.ControlBlock)
+	})
+}
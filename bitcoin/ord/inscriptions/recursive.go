@@ -0,0 +1,183 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRecursiveReference defines that recursive endpoint reference is malformed or unknown.
+var ErrInvalidRecursiveReference = errors.New("invalid recursive reference")
+
+// ErrUnknownEndpoint defines that recursive endpoint reference has unknown endpoint.
+var ErrUnknownEndpoint = errors.New("unknown recursive endpoint")
+
+// Endpoint defines recursive endpoint of the ord server available for inscriptions content.
+type Endpoint string
+
+const (
+	// EndpointContent defines content of the inscription endpoint: /content/<id>.
+	EndpointContent Endpoint = "/content"
+	// EndpointBlockHash defines block hash endpoint: /r/blockhash[/<height>].
+	EndpointBlockHash Endpoint = "/r/blockhash"
+	// EndpointBlockHeight defines latest block height endpoint: /r/blockheight.
+	EndpointBlockHeight Endpoint = "/r/blockheight"
+	// EndpointBlockTime defines latest block time endpoint: /r/blocktime.
+	EndpointBlockTime Endpoint = "/r/blocktime"
+	// EndpointChildren defines children of the inscription endpoint: /r/children/<id>[/<page>].
+	EndpointChildren Endpoint = "/r/children"
+	// EndpointInscription defines inscription info endpoint: /r/inscription/<id>.
+	EndpointInscription Endpoint = "/r/inscription"
+	// EndpointMetadata defines inscription metadata endpoint: /r/metadata/<id>.
+	EndpointMetadata Endpoint = "/r/metadata"
+	// EndpointParents defines parents of the inscription endpoint: /r/parents/<id>[/<page>].
+	EndpointParents Endpoint = "/r/parents"
+	// EndpointSat defines inscriptions on the sat endpoint: /r/sat/<sat>[/<page>] or /r/sat/<sat>/at/<index>.
+	EndpointSat Endpoint = "/r/sat"
+)
+
+// RecursiveReference describes parsed recursive endpoint reference.
+type RecursiveReference struct {
+	Endpoint Endpoint
+	ID       *ID      // referenced inscription, if endpoint takes one.
+	Args     []string // rest of the path segments, e.g. height or page.
+}
+
+// recursiveReferenceRegexp defines pattern to find root-relative recursive references in HTML/SVG/JS/CSS
+// bodies. Paths preceded by a host, scheme or word character, e.g. in absolute external URLs, are not matched.
+var recursiveReferenceRegexp = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.:/-])(/(?:content|r)/[A-Za-z0-9/-]*)`)
+
+// localReferenceRegexp defines pattern to find local file references in attributes and CSS url() values.
+var localReferenceRegexp = regexp.MustCompile(`((?:src|href|xlink:href)\s*=\s*["']|url\(\s*["']?)([^"'()\s]+)`)
+
+// ContentPath returns /content/<id> reference to the inscription.
+func ContentPath(id ID) string {
+	return string(EndpointContent) + "/" + id.String()
+}
+
+// RecursivePath returns recursive endpoint reference with provided path segments.
+func RecursivePath(endpoint Endpoint, segments ...string) string {
+	if len(segments) == 0 {
+		return string(endpoint)
+	}
+
+	return string(endpoint) + "/" + strings.Join(segments, "/")
+}
+
+// ParseRecursiveReference parses and validates recursive endpoint reference, e.g. /r/children/<id>/1.
+func ParseRecursiveReference(path string) (*RecursiveReference, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	invalid := fmt.Errorf("%w: %s", ErrInvalidRecursiveReference, path)
+	unknown := fmt.Errorf("%w: %w: %s", ErrInvalidRecursiveReference, ErrUnknownEndpoint, path)
+
+	var endpoint Endpoint
+	switch {
+	case segments[0] == "content":
+		endpoint, segments = EndpointContent, segments[1:]
+	case segments[0] == "r" && len(segments) > 1:
+		endpoint, segments = Endpoint("/r/"+segments[1]), segments[2:]
+	default:
+		return nil, unknown
+	}
+
+	reference := &RecursiveReference{Endpoint: endpoint}
+	switch endpoint {
+	case EndpointBlockHeight, EndpointBlockTime:
+		if len(segments) != 0 {
+			return nil, invalid
+		}
+	case EndpointBlockHash:
+		if len(segments) > 1 || !areNumbers(segments) {
+			return nil, invalid
+		}
+	case EndpointContent, EndpointInscription, EndpointMetadata, EndpointChildren, EndpointParents:
+		maxSegments := 1
+		if endpoint == EndpointChildren || endpoint == EndpointParents {
+			maxSegments = 2 // with page.
+		}
+
+		if len(segments) == 0 || len(segments) > maxSegments || !areNumbers(segments[1:]) {
+			return nil, invalid
+		}
+
+		id, err := NewIDFromString(segments[0])
+		if err != nil {
+			return nil, invalid
+		}
+
+		reference.ID, segments = id, segments[1:]
+	case EndpointSat:
+		switch {
+		case len(segments) == 3 && segments[1] == "at":
+			if !areNumbers(segments[:1]) || !isSignedNumber(segments[2]) {
+				return nil, invalid
+			}
+		case len(segments) == 0 || len(segments) > 2 || !areNumbers(segments):
+			return nil, invalid
+		}
+	default:
+		return nil, unknown
+	}
+
+	reference.Args = segments
+
+	return reference, nil
+}
+
+// ExtractRecursiveReferences returns all valid recursive references from the inscription body.
+// References with unknown endpoints are skipped as ord server does not recognize them either.
+// Returns ErrInvalidRecursiveReference if any of found references to the known endpoints is malformed.
+func ExtractRecursiveReferences(body []byte) ([]*RecursiveReference, error) {
+	var references []*RecursiveReference
+	for _, match := range recursiveReferenceRegexp.FindAllSubmatch(body, -1) {
+		reference, err := ParseRecursiveReference(string(match[1]))
+		if errors.Is(err, ErrUnknownEndpoint) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		references = append(references, reference)
+	}
+
+	return references, nil
+}
+
+// RewriteLocalReferences replaces local file references in src/href attributes and
+// CSS url() values with /content/<id> references, for batch inscriptions where files
+// are inscribed together and referenced by their names. Leading "./" is ignored.
+func RewriteLocalReferences(body []byte, files map[string]ID) []byte {
+	return localReferenceRegexp.ReplaceAllFunc(body, func(match []byte) []byte {
+		groups := localReferenceRegexp.FindSubmatch(match)
+		id, ok := files[strings.TrimPrefix(string(groups[2]), "./")]
+		if !ok {
+			return match
+		}
+
+		return append(append([]byte{}, groups[1]...), ContentPath(id)...)
+	})
+}
+
+// areNumbers returns true if all segments are unsigned decimal numbers.
+func areNumbers(segments []string) bool {
+	for _, segment := range segments {
+		if _, err := strconv.ParseUint(segment, 10, 64); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isSignedNumber returns true if segment is signed decimal number.
+func isSignedNumber(segment string) bool {
+	_, err := strconv.ParseInt(segment, 10, 64)
+
+	return err == nil
+}
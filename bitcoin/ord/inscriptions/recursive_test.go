@@ -0,0 +1,87 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+)
+
+func TestRecursive(t *testing.T) {
+	id := inscriptions.ID{TxID: mustHash(t, "618ffb4e23e19566c7567841187a1c424dfd775e4f8cb633a7a3d4836784835f"), Index: 2}
+
+	t.Run("paths", func(t *testing.T) {
+		require.Equal(t, "/content/618ffb4e23e19566c7567841187a1c424dfd775e4f8cb633a7a3d4836784835fi2", inscriptions.ContentPath(id))
+		require.Equal(t, "/r/blockheight", inscriptions.RecursivePath(inscriptions.EndpointBlockHeight))
+		require.Equal(t, "/r/blockhash/840000", inscriptions.RecursivePath(inscriptions.EndpointBlockHash, "840000"))
+	})
+
+	t.Run("ParseRecursiveReference", func(t *testing.T) {
+		tests := []struct {
+			path     string
+			expected *inscriptions.RecursiveReference
+		}{
+			{"/content/" + id.String(), &inscriptions.RecursiveReference{Endpoint: inscriptions.EndpointContent, ID: &id, Args: []string{}}},
+			{"/r/children/" + id.String() + "/3", &inscriptions.RecursiveReference{Endpoint: inscriptions.EndpointChildren, ID: &id, Args: []string{"3"}}},
+			{"/r/blockheight", &inscriptions.RecursiveReference{Endpoint: inscriptions.EndpointBlockHeight, Args: []string{}}},
+			{"/r/blockhash/840000", &inscriptions.RecursiveReference{Endpoint: inscriptions.EndpointBlockHash, Args: []string{"840000"}}},
+			{"/r/sat/1023795949035695/at/-1", &inscriptions.RecursiveReference{Endpoint: inscriptions.EndpointSat, Args: []string{"1023795949035695", "at", "-1"}}},
+			{"/r/unknown", nil},
+			{"/r/", nil},
+			{"/r/blockheight/1", nil},
+			{"/content/abc", nil},
+			{"/r/metadata/" + id.String() + "/1", nil},
+			{"/r/sat/abc", nil},
+		}
+		for _, test := range tests {
+			reference, err := inscriptions.ParseRecursiveReference(test.path)
+			if test.expected == nil {
+				require.ErrorIs(t, err, inscriptions.ErrInvalidRecursiveReference, test.path)
+				continue
+			}
+
+			require.NoError(t, err, test.path)
+			require.Equal(t, test.expected, reference)
+		}
+
+		_, err := inscriptions.ParseRecursiveReference("/r/unknown")
+		require.ErrorIs(t, err, inscriptions.ErrUnknownEndpoint)
+
+		_, err = inscriptions.ParseRecursiveReference("/r/blockheight/1")
+		require.NotErrorIs(t, err, inscriptions.ErrUnknownEndpoint)
+	})
+
+	t.Run("ExtractRecursiveReferences", func(t *testing.T) {
+		body := []byte(`<html><img src="/content/` + id.String() + `"><script>fetch("/r/blockheight");fetch("/r/undelegated-content/1")</script></html>`)
+		references, err := inscriptions.ExtractRecursiveReferences(body)
+		require.NoError(t, err)
+		require.Len(t, references, 2)
+		require.Equal(t, inscriptions.EndpointContent, references[0].Endpoint)
+		require.Equal(t, inscriptions.EndpointBlockHeight, references[1].Endpoint)
+
+		_, err = inscriptions.ExtractRecursiveReferences([]byte(`<img src="/content/invalid">`))
+		require.ErrorIs(t, err, inscriptions.ErrInvalidRecursiveReference)
+
+		// INFO: paths of absolute external URLs are not recursive references.
+		body = []byte(`<img src="https://cdn.example.com/content/images/logo.png"><img src="http://localhost:8080/r/blockheight">` +
+			`<img src='/content/` + id.String() + `'>`)
+		references, err = inscriptions.ExtractRecursiveReferences(body)
+		require.NoError(t, err)
+		require.Len(t, references, 1)
+		require.Equal(t, id, *references[0].ID)
+	})
+
+	t.Run("RewriteLocalReferences", func(t *testing.T) {
+		body := []byte(`<svg><image href="./image.png"/><style>div{background:url('bg.png')}</style><a href="other.html"></a></svg>`)
+		files := map[string]inscriptions.ID{"image.png": id, "bg.png": {TxID: id.TxID, Index: 3}}
+
+		expected := `<svg><image href="/content/` + id.String() + `"/>` +
+			`<style>div{background:url('/content/618ffb4e23e19566c7567841187a1c424dfd775e4f8cb633a7a3d4836784835fi3')}</style>` +
+			`<a href="other.html"></a></svg>`
+		require.Equal(t, expected, string(inscriptions.RewriteLocalReferences(body, files)))
+	})
+}
@@ -0,0 +1,21 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions
+
+// JubileeHeight defines mainnet block height since which cursed inscriptions are numbered as blessed ones.
+// NOTE: [Rust impl] testnet, signet and regtest jubilee heights differ.
+const JubileeHeight uint64 = 824544
+
+// Cursed returns true if the envelope content makes inscription cursed: it contains unrecognized
+// even tag or pointer. Curses depending on the envelope position in the transaction are not checked.
+func (i *Inscription) Cursed() bool {
+	return i.Unbound || i.Pointer != nil
+}
+
+// Vindicated returns true if the cursed inscription is revealed in the block with provided height
+// after the jubilee, so it is numbered as the blessed one.
+// NOTE: Unbound inscription stays unbound after vindication.
+func (i *Inscription) Vindicated(height uint64) bool {
+	return i.Cursed() && height >= JubileeHeight
+}
@@ -0,0 +1,29 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+)
+
+func TestStatus(t *testing.T) {
+	t.Run("Cursed", func(t *testing.T) {
+		require.False(t, (&inscriptions.Inscription{Body: []byte("test")}).Cursed())
+		require.True(t, (&inscriptions.Inscription{Unbound: true}).Cursed())
+		require.True(t, (&inscriptions.Inscription{Pointer: big.NewInt(0)}).Cursed())
+	})
+
+	t.Run("Vindicated", func(t *testing.T) {
+		unbound := &inscriptions.Inscription{Unbound: true}
+		require.False(t, unbound.Vindicated(inscriptions.JubileeHeight-1))
+		require.True(t, unbound.Vindicated(inscriptions.JubileeHeight))
+
+		require.False(t, (&inscriptions.Inscription{}).Vindicated(inscriptions.JubileeHeight))
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// ErrBodySizeLimit defines that inscription body exceeds configured size limit.
+var ErrBodySizeLimit = errors.New("inscription body exceeds size limit")
+
+// witnessScriptVersion defines script version used to tokenize witness data.
+const witnessScriptVersion uint16 = 0
+
+// ParseInscriptionStream parses the first inscription envelope of the witness data and writes its body
+// to the body writer push by push instead of accumulating it in memory. Returned Inscription contains
+// metadata only, Body is left empty. maxBodySize limits the body size in bytes, non-positive value
+// means no limit. Returns Inscription with the number of body bytes written.
+func ParseInscriptionStream(data []byte, body io.Writer, maxBodySize int64) (*Inscription, int64, error) {
+	tokenizer := txscript.MakeScriptTokenizer(witnessScriptVersion, data)
+	if !skipToEnvelope(&tokenizer) {
+		return nil, 0, ErrMalformedInscription
+	}
+
+	var (
+		inscription = new(Inscription)
+		written     int64
+	)
+	for tokenizer.Next() {
+		switch tokenizer.Opcode() {
+		case txscript.OP_ENDIF:
+			return inscription, written, nil
+		case txscript.OP_0: // means that all next data pushes are body parts.
+			for tokenizer.Next() {
+				if tokenizer.Opcode() == txscript.OP_ENDIF {
+					return inscription, written, nil
+				}
+
+				chunk, err := pushedData(&tokenizer)
+				if err != nil {
+					return nil, written, err
+				}
+
+				if maxBodySize > 0 && written+int64(len(chunk)) > maxBodySize {
+					return nil, written, ErrBodySizeLimit
+				}
+
+				n, err := body.Write(chunk)
+				written += int64(n)
+				if err != nil {
+					return nil, written, err
+				}
+			}
+		default:
+			tag, err := pushedData(&tokenizer)
+			if err != nil {
+				return nil, written, err
+			}
+
+			if !tokenizer.Next() {
+				return nil, written, ErrMalformedInscription
+			}
+
+			value, err := pushedData(&tokenizer)
+			if err != nil {
+				return nil, written, err
+			}
+
+			valueHex := "0"
+			if len(value) != 0 {
+				valueHex = hex.EncodeToString(value)
+			}
+
+			if err = inscription.fillFieldByTag(hex.EncodeToString(tag), valueHex); err != nil {
+				return nil, written, err
+			}
+		}
+	}
+
+	// envelope is not terminated with OP_ENDIF or script is malformed.
+	return nil, written, ErrMalformedInscription
+}
+
+// skipToEnvelope moves tokenizer to the OP_PUSH "ord" of the first OP_FALSE OP_IF OP_PUSH "ord" sequence.
+// Returns false if there is no inscription envelope in the script.
+func skipToEnvelope(tokenizer *txscript.ScriptTokenizer) bool {
+	previous := [2]byte{txscript.OP_INVALIDOPCODE, txscript.OP_INVALIDOPCODE}
+	for tokenizer.Next() {
+		if previous[0] == txscript.OP_FALSE && previous[1] == txscript.OP_IF &&
+			bytes.Equal(tokenizer.Data(), []byte(inscriptionOrdTag)) {
+			return true
+		}
+
+		previous[0], previous[1] = previous[1], tokenizer.Opcode()
+	}
+
+	return false
+}
+
+// pushedData returns data pushed by the current opcode, small integers are returned as a single byte.
+func pushedData(tokenizer *txscript.ScriptTokenizer) ([]byte, error) {
+	opcode := tokenizer.Opcode()
+	switch {
+	case opcode == txscript.OP_0:
+		return []byte{}, nil
+	case opcode >= txscript.OP_1 && opcode <= txscript.OP_16:
+		return []byte{opcode - txscript.OP_1 + 1}, nil
+	case opcode == txscript.OP_1NEGATE:
+		return []byte{0x81}, nil
+	case opcode <= txscript.OP_PUSHDATA4:
+		return tokenizer.Data(), nil
+	}
+
+	return nil, ErrMalformedInscription
+}
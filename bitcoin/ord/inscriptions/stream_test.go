@@ -0,0 +1,117 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package inscriptions_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+func TestParseInscriptionStream(t *testing.T) {
+	rune_, err := runes.NewRuneFromString("TESTRUNE")
+	require.NoError(t, err)
+
+	body := make([]byte, 100_000)
+	_, err = rand.Read(body)
+	require.NoError(t, err)
+
+	inscription := &inscriptions.Inscription{
+		ContentType:  "image/png",
+		Metaprotocol: []byte("brc-20"),
+		Pointer:      big.NewInt(1),
+		Rune:         rune_,
+		Body:         body,
+	}
+
+	script, err := inscription.IntoScriptForWitness(make([]byte, 32))
+	require.NoError(t, err)
+
+	t.Run("metadata and body", func(t *testing.T) {
+		var buffer bytes.Buffer
+		parsed, written, err := inscriptions.ParseInscriptionStream(script, &buffer, 0)
+		require.NoError(t, err)
+		require.EqualValues(t, len(body), written)
+		require.Equal(t, body, buffer.Bytes())
+		require.Empty(t, parsed.Body)
+
+		parsed.Body = buffer.Bytes()
+		require.EqualValues(t, inscription, parsed)
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		var buffer bytes.Buffer
+		_, _, err := inscriptions.ParseInscriptionStream(script, &buffer, int64(len(body)-1))
+		require.ErrorIs(t, err, inscriptions.ErrBodySizeLimit)
+
+		buffer.Reset()
+		_, written, err := inscriptions.ParseInscriptionStream(script, &buffer, int64(len(body)))
+		require.NoError(t, err)
+		require.EqualValues(t, len(body), written)
+	})
+
+	t.Run("same as ParseInscriptionFromWitnessData", func(t *testing.T) {
+		data, err := hex.DecodeString("20a9a7255fda3a07a2a3a651bae594a0ede366bb8c87bc13de4e76c2c189724a80ac0063036f7264010118746578742f706c61696e3b636861727365743d7574662d38000d48656c6c6f2c20776f726c642168")
+		require.NoError(t, err)
+
+		expected, err := inscriptions.ParseInscriptionFromWitnessData(data)
+		require.NoError(t, err)
+
+		var buffer bytes.Buffer
+		parsed, _, err := inscriptions.ParseInscriptionStream(data, &buffer, 0)
+		require.NoError(t, err)
+
+		parsed.Body = buffer.Bytes()
+		require.EqualValues(t, expected, parsed)
+	})
+
+	t.Run("small integer pushes", func(t *testing.T) {
+		// INFO: OP_1 content type tag, OP_2 pointer tag with OP_16 value, OP_5 body push.
+		data, err := hex.DecodeString("0063036f726451" + "0a" + hex.EncodeToString([]byte("text/plain")) + "5260005568")
+		require.NoError(t, err)
+
+		expected := &inscriptions.Inscription{
+			ContentType: "text/plain",
+			Pointer:     big.NewInt(16),
+			Body:        []byte{5},
+		}
+
+		parsed, err := inscriptions.ParseInscriptionFromWitnessData(data)
+		require.NoError(t, err)
+		require.EqualValues(t, expected, parsed)
+
+		all, err := inscriptions.ParseInscriptionsFromWitnessData(data)
+		require.NoError(t, err)
+		require.Equal(t, []*inscriptions.Inscription{expected}, all)
+
+		var buffer bytes.Buffer
+		parsed, _, err = inscriptions.ParseInscriptionStream(data, &buffer, 0)
+		require.NoError(t, err)
+
+		parsed.Body = buffer.Bytes()
+		require.EqualValues(t, expected, parsed)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		tests := []string{
+			"63036f72640101106170706c69636174696f6e2f6a736f6e68",
+			"0063036f72640101106170706c69636174696f6e2f6a736f6e",
+			"0063036f72640101106170706c69636174696f6e2f6a736f6e0101010168",
+		}
+		for _, test := range tests {
+			data, err := hex.DecodeString(test)
+			require.NoError(t, err)
+
+			_, _, err = inscriptions.ParseInscriptionStream(data, &bytes.Buffer{}, 0)
+			require.Error(t, err)
+		}
+	})
+}
@@ -17,6 +17,7 @@ const (
 	// Points on the sat at the given position in the outputs for the inscription to be made.
 	TagPointer Tag = 2
 	// TagUnbound defines unbound tag in the inscription protocol.
+	// Even tag unrecognized by the indexers, makes inscription unbound, see Inscription.Unbound.
 	TagUnbound Tag = 66
 	// TagContentType defines content-type tag in the inscription protocol.
 	// Defines content-type of the inscription content. The value is the MIME type of the body.
@@ -0,0 +1,255 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package protorunes implements protorunes extension of the runes protocol: protostones
+// encoded into the runestone Protocol field, protoburns and protomessages.
+package protorunes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/opreturn"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/sequencereader"
+)
+
+// ErrInvalidProtostone defines malformed protostone.
+// INFO: invalid protostones do not affect runestone, runes are handled by the runes protocol rules.
+var ErrInvalidProtostone = errors.New("invalid protostone")
+
+// chunkSize defines number of bytes packed into each integer of the Protocol field and
+// protostone Message, so integers are always less than 2^120 and fit u128.
+const chunkSize = 15
+
+// Protostone defines protorunes message of the specific protocol.
+type Protostone struct {
+	ProtocolTag *big.Int // protocol identifier, e.g. 1 for alkanes.
+	Burn        *big.Int // protocol tag runes are burned into (protoburn), optional.
+	Message     []byte   // protocol message, e.g. calldata (protomessage), optional.
+	Edicts      []runes.Edict
+	Refund      *uint32 // output index for protorunes if message execution fails, optional.
+	Pointer     *uint32 // output index for unallocated protorunes, optional.
+	From        *uint32 // index of the edict protoburn takes runes from, optional.
+}
+
+// IsProtoburn returns true if protostone burns runes into protorunes.
+func (protostone *Protostone) IsProtoburn() bool {
+	return protostone.Burn != nil
+}
+
+// IsProtomessage returns true if protostone carries protocol message.
+func (protostone *Protostone) IsProtomessage() bool {
+	return len(protostone.Message) != 0
+}
+
+// protostoneCodec defines tagged-varint codec of the protostone.
+var protostoneCodec = opreturn.Codec{BodyTag: TagBody, ValidateTag: validateTag}
+
+// validateTag keeps known protostone tags, unknown even tag invalidates protostone, odd one is ignored.
+func validateTag(tag *big.Int) (bool, error) {
+	if tag.IsUint64() {
+		if _, ok := knownTags[tag.Uint64()]; ok {
+			return true, nil
+		}
+	}
+
+	if tag.Bit(0) == 0 {
+		return false, fmt.Errorf("%w: unrecognized even tag %s", ErrInvalidProtostone, tag)
+	}
+
+	return false, nil
+}
+
+// ParseProtostones parses protostones from the runestone Protocol field values.
+func ParseProtostones(values []*big.Int) ([]Protostone, error) {
+	payload, err := joinChunks(values)
+	if err != nil {
+		return nil, err
+	}
+
+	sequence, err := opreturn.DecodePayload(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProtostone, err)
+	}
+
+	var (
+		protostones []Protostone
+		sr          = sequencereader.New(sequence)
+	)
+	for sr.HasNext() {
+		protocolTag, _ := sr.Next() // skip error due to loop condition check.
+		// INFO: zero protocol tag means the rest is padding of the last chunk.
+		if protocolTag.Sign() == 0 {
+			break
+		}
+
+		length, err := sr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w: missing length: %w", ErrInvalidProtostone, opreturn.ErrTruncated, err)
+		}
+
+		if !length.IsUint64() || length.Uint64() > uint64(sr.Len()) {
+			return nil, fmt.Errorf("%w: %w: length %s at element %d, left %d", ErrInvalidProtostone, opreturn.ErrTruncated,
+				length, sr.Pos()-1, sr.Len())
+		}
+
+		fields := make([]*big.Int, 0, length.Uint64())
+		for range length.Uint64() {
+			value, _ := sr.Next() // skip error due to length check.
+			fields = append(fields, value)
+		}
+
+		protostone, err := parseProtostone(protocolTag, fields)
+		if err != nil {
+			return nil, err
+		}
+
+		protostones = append(protostones, *protostone)
+	}
+
+	return protostones, nil
+}
+
+// parseProtostone parses protostone fields from integer sequence.
+func parseProtostone(protocolTag *big.Int, sequence []*big.Int) (*Protostone, error) {
+	message, err := protostoneCodec.Decode(sequence)
+	if err != nil {
+		if errors.Is(err, opreturn.ErrTruncated) {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProtostone, err)
+		}
+
+		return nil, err
+	}
+
+	protostone := &Protostone{ProtocolTag: protocolTag}
+	if values := message.Fields[TagBurn]; len(values) != 0 {
+		protostone.Burn = values[0]
+	}
+
+	if values := message.Fields[TagMessage]; len(values) != 0 {
+		if protostone.Message, err = joinChunks(values); err != nil {
+			return nil, err
+		}
+
+		// NOTE: padding of the last chunk is trimmed, so message which ends with zero bytes can not be restored exactly.
+		protostone.Message = bytes.TrimRight(protostone.Message, "\x00")
+	}
+
+	for _, field := range []struct {
+		tag   uint64
+		value **uint32
+	}{
+		{TagRefund, &protostone.Refund},
+		{TagPointer, &protostone.Pointer},
+		{TagFrom, &protostone.From},
+	} {
+		values := message.Fields[field.tag]
+		if len(values) == 0 {
+			continue
+		}
+
+		if !values[0].IsUint64() || values[0].Uint64() > math.MaxUint32 {
+			return nil, fmt.Errorf("%w: tag %d value %s overflows uint32", ErrInvalidProtostone, field.tag, values[0])
+		}
+
+		value := uint32(values[0].Uint64())
+		*field.value = &value
+	}
+
+	if message.Body != nil {
+		protostone.Edicts, err = runes.ParseEdictsFromIntSeq(sequencereader.New(message.Body))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProtostone, err)
+		}
+	}
+
+	return protostone, nil
+}
+
+// ToIntSeq returns Protostone as sequence of integers: protocol tag, length and fields.
+func (protostone *Protostone) ToIntSeq() []*big.Int {
+	message := &opreturn.Message{Fields: make(map[uint64][]*big.Int)}
+	if protostone.Burn != nil {
+		message.Fields[TagBurn] = []*big.Int{protostone.Burn}
+	}
+
+	if len(protostone.Message) != 0 {
+		message.Fields[TagMessage] = splitChunks(protostone.Message)
+	}
+
+	if protostone.Refund != nil {
+		message.Fields[TagRefund] = []*big.Int{big.NewInt(int64(*protostone.Refund))}
+	}
+
+	if protostone.Pointer != nil {
+		message.Fields[TagPointer] = []*big.Int{big.NewInt(int64(*protostone.Pointer))}
+	}
+
+	if protostone.From != nil {
+		message.Fields[TagFrom] = []*big.Int{big.NewInt(int64(*protostone.From))}
+	}
+
+	if len(protostone.Edicts) != 0 {
+		message.Body = runes.EdictsToIntSeq(protostone.Edicts)
+	}
+
+	fields := protostoneCodec.Encode(message)
+
+	return append([]*big.Int{protostone.ProtocolTag, big.NewInt(int64(len(fields)))}, fields...)
+}
+
+// ProtostonesToValues returns protostones as the runestone Protocol field values.
+func ProtostonesToValues(protostones []Protostone) ([]*big.Int, error) {
+	sequence := make([]*big.Int, 0)
+	for _, protostone := range protostones {
+		if protostone.ProtocolTag == nil || protostone.ProtocolTag.Sign() == 0 {
+			return nil, fmt.Errorf("%w: missing protocol tag", ErrInvalidProtostone)
+		}
+
+		sequence = append(sequence, protostone.ToIntSeq()...)
+	}
+
+	payload, err := opreturn.EncodePayload(sequence)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidProtostone, err)
+	}
+
+	return splitChunks(payload), nil
+}
+
+// splitChunks packs bytes into integers by chunkSize bytes in little-endian order.
+func splitChunks(data []byte) []*big.Int {
+	values := make([]*big.Int, 0, (len(data)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(data); start += chunkSize {
+		chunk := data[start:min(start+chunkSize, len(data))]
+		bigEndian := make([]byte, len(chunk))
+		for i, b := range chunk {
+			bigEndian[len(chunk)-1-i] = b
+		}
+
+		values = append(values, new(big.Int).SetBytes(bigEndian))
+	}
+
+	return values
+}
+
+// joinChunks unpacks integers into bytes by chunkSize bytes in little-endian order.
+func joinChunks(values []*big.Int) ([]byte, error) {
+	data := make([]byte, 0, len(values)*chunkSize)
+	for _, value := range values {
+		if value.Sign() < 0 || value.BitLen() > chunkSize*8 {
+			return nil, fmt.Errorf("%w: chunk %s overflows %d bytes", ErrInvalidProtostone, value, chunkSize)
+		}
+
+		bigEndian := value.FillBytes(make([]byte, chunkSize))
+		for i := len(bigEndian) - 1; i >= 0; i-- {
+			data = append(data, bigEndian[i])
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package protorunes_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/opreturn"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/protorunes"
+)
+
+func TestParseProtostones(t *testing.T) {
+	// values packs LEB128 encoded integers into Protocol field values of 15 bytes.
+	values := func(ints ...int64) []*big.Int {
+		sequence := make([]*big.Int, 0, len(ints))
+		for _, value := range ints {
+			sequence = append(sequence, big.NewInt(value))
+		}
+
+		payload, err := opreturn.EncodePayload(sequence)
+		require.NoError(t, err)
+
+		result := make([]*big.Int, 0)
+		for start := 0; start < len(payload); start += 15 {
+			chunk := payload[start:min(start+15, len(payload))]
+			value := new(big.Int)
+			for i := len(chunk) - 1; i >= 0; i-- {
+				value.Lsh(value, 8).Or(value, big.NewInt(int64(chunk[i])))
+			}
+
+			result = append(result, value)
+		}
+
+		return result
+	}
+
+	t.Run("protoburn", func(t *testing.T) {
+		protostones, err := protorunes.ParseProtostones(values(1, 4, 83, 1, 91, 0))
+		require.NoError(t, err)
+		require.Len(t, protostones, 1)
+		require.EqualValues(t, 1, protostones[0].ProtocolTag.Int64())
+		require.EqualValues(t, 1, protostones[0].Burn.Int64())
+		require.EqualValues(t, 0, *protostones[0].Pointer)
+		require.Nil(t, protostones[0].Edicts)
+	})
+
+	t.Run("unknown odd tag is ignored", func(t *testing.T) {
+		protostones, err := protorunes.ParseProtostones(values(1, 4, 85, 7, 93, 2))
+		require.NoError(t, err)
+		require.Len(t, protostones, 1)
+		require.EqualValues(t, 2, *protostones[0].Refund)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := map[string][]*big.Int{
+			"unknown even tag":   values(1, 2, 84, 1),
+			"length overflow":    values(1, 5, 83, 1),
+			"truncated field":    values(1, 1, 83),
+			"invalid edicts":     values(1, 3, 0, 1, 2),
+			"pointer overflow":   values(1, 2, 91, 1<<33),
+			"chunk overflow":     {new(big.Int).Lsh(big.NewInt(1), 120)},
+			"unterminated value": {new(big.Int).SetBytes(bytes.Repeat([]byte{0x80}, 15))},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := protorunes.ParseProtostones(test)
+				require.ErrorIs(t, err, protorunes.ErrInvalidProtostone)
+			})
+		}
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		expected := []protorunes.Protostone{
+			{ProtocolTag: big.NewInt(1), Burn: big.NewInt(1)},
+			{ProtocolTag: big.NewInt(20), Message: []byte("calldata")},
+		}
+
+		encoded, err := protorunes.ProtostonesToValues(expected)
+		require.NoError(t, err)
+
+		protostones, err := protorunes.ParseProtostones(encoded)
+		require.NoError(t, err)
+		require.Equal(t, expected, protostones)
+	})
+}
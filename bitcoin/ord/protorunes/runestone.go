@@ -0,0 +1,96 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package protorunes
+
+import (
+	"math/big"
+
+	"github.com/btcsuite/btcd/txscript"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/opreturn"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+// runestoneCodec defines codec to access raw runestone fields, runestone is validated by runes package.
+var runestoneCodec = opreturn.Codec{BodyTag: uint64(runes.TagBody)}
+
+// Runestone defines runestone with protostones in the Protocol field.
+type Runestone struct {
+	runes.Runestone
+	Protostones []Protostone
+}
+
+// ParseRunestone parses Runestone with protostones from script code.
+// Returns runes.ErrCenotaph if runestone is cenotaph and ErrInvalidProtostone if protostones are malformed.
+func ParseRunestone(script []byte) (*Runestone, error) {
+	runestone, err := runes.ParseRunestone(script)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := runes.PreparePayload(script)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := runestoneCodec.DecodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	protostones, err := ParseProtostones(message.Fields[TagProtocol])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Runestone{Runestone: *runestone, Protostones: protostones}, nil
+}
+
+// Serialize returns Runestone with protostones as bytes array.
+func (runestone *Runestone) Serialize() ([]byte, error) {
+	payload, err := runestone.Runestone.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(runestone.Protostones) == 0 {
+		return payload, nil
+	}
+
+	message, err := runestoneCodec.DecodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.Fields == nil {
+		message.Fields = make(map[uint64][]*big.Int)
+	}
+
+	message.Fields[TagProtocol], err = ProtostonesToValues(runestone.Protostones)
+	if err != nil {
+		return nil, err
+	}
+
+	return runestoneCodec.EncodePayload(message)
+}
+
+// IntoScript returns Runestone with protostones as script bytes,
+// payload is split into OP_PUSH_<num> commands of at most 75 bytes.
+// NOTE: protostones payload may exceed standard OP_RETURN size of 80 bytes.
+func (runestone *Runestone) IntoScript() ([]byte, error) {
+	payload, err := runestone.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	// OP_RETURN + OP_13 + (OP_PUSH_<num> + data)*.
+	script := []byte{txscript.OP_RETURN, txscript.OP_13}
+	for len(payload) > 0 {
+		size := min(len(payload), txscript.OP_DATA_75)
+		script = append(append(script, byte(size)), payload[:size]...)
+		payload = payload[size:]
+	}
+
+	return script, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package protorunes_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/protorunes"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestRunestone(t *testing.T) {
+	runestone := &protorunes.Runestone{
+		Runestone: runes.Runestone{
+			Edicts: []runes.Edict{
+				{RuneID: runes.RuneID{Block: 840000, TxID: 1}, Amount: big.NewInt(1000), Output: 1},
+			},
+			Pointer: ptr[uint32](0),
+		},
+		Protostones: []protorunes.Protostone{
+			{
+				ProtocolTag: big.NewInt(1),
+				Burn:        big.NewInt(1),
+				Pointer:     ptr[uint32](2),
+				From:        ptr[uint32](0),
+			},
+			{
+				ProtocolTag: big.NewInt(1),
+				Message:     []byte{0x02, 0x01, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x6d, 0x6f, 0x72, 0x65, 0x20, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73},
+				Edicts: []runes.Edict{
+					{RuneID: runes.RuneID{Block: 2, TxID: 1}, Amount: big.NewInt(500), Output: 3},
+					{RuneID: runes.RuneID{Block: 2, TxID: 5}, Amount: big.NewInt(7), Output: 2},
+				},
+				Refund:  ptr[uint32](1),
+				Pointer: ptr[uint32](3),
+			},
+		},
+	}
+
+	script, err := runestone.IntoScript()
+	require.NoError(t, err)
+	require.True(t, runes.IsPossibleRunestone(script))
+
+	t.Run("round trip", func(t *testing.T) {
+		parsed, err := protorunes.ParseRunestone(script)
+		require.NoError(t, err)
+		require.Equal(t, runestone, parsed)
+		require.True(t, parsed.Protostones[0].IsProtoburn())
+		require.False(t, parsed.Protostones[0].IsProtomessage())
+		require.True(t, parsed.Protostones[1].IsProtomessage())
+	})
+
+	t.Run("runes compatibility", func(t *testing.T) {
+		parsed, err := runes.ParseRunestone(script)
+		require.NoError(t, err)
+		require.Equal(t, &runestone.Runestone, parsed)
+	})
+
+	t.Run("without protostones", func(t *testing.T) {
+		script, err := runestone.Runestone.IntoScript()
+		require.NoError(t, err)
+
+		parsed, err := protorunes.ParseRunestone(script)
+		require.NoError(t, err)
+		require.Equal(t, runestone.Runestone, parsed.Runestone)
+		require.Empty(t, parsed.Protostones)
+	})
+
+	t.Run("missing protocol tag", func(t *testing.T) {
+		_, err := (&protorunes.Runestone{
+			Runestone:   runestone.Runestone,
+			Protostones: []protorunes.Protostone{{Burn: big.NewInt(1)}},
+		}).IntoScript()
+		require.ErrorIs(t, err, protorunes.ErrInvalidProtostone)
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package protorunes
+
+const (
+	// TagProtocol defines runestone field tag with encoded protostones.
+	// INFO: tag is odd, so runes indexers ignore it without producing cenotaph.
+	TagProtocol uint64 = 16383
+
+	// TagBody defines protostone Body tag, the rest of the protostone are edicts.
+	TagBody uint64 = 0
+	// TagMessage defines protostone Message tag.
+	TagMessage uint64 = 81
+	// TagBurn defines protostone Burn tag.
+	TagBurn uint64 = 83
+	// TagFrom defines protostone From tag.
+	TagFrom uint64 = 87
+	// TagPointer defines protostone Pointer tag.
+	TagPointer uint64 = 91
+	// TagRefund defines protostone Refund tag.
+	TagRefund uint64 = 93
+)
+
+// knownTags defines protostone tags recognized by parser.
+var knownTags = map[uint64]struct{}{
+	TagBody:    {},
+	TagMessage: {},
+	TagBurn:    {},
+	TagFrom:    {},
+	TagPointer: {},
+	TagRefund:  {},
+}
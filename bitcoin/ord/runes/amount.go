@@ -0,0 +1,112 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+	"github.com/BoostyLabs/blockchain/u128"
+)
+
+// ErrInvalidAmount defines that rune amount can not be formatted or parsed.
+var ErrInvalidAmount = errors.New("invalid rune amount")
+
+// decimalPoint defines separator between integer and fractional parts of the amount.
+const decimalPoint = "."
+
+// FormatAmount returns rune amount in rune units as decimal string with divisibility
+// applied, trailing fractional zeros are omitted, e.g. 100050 with divisibility 3 -> "100.05".
+// Negative amounts are formatted with a leading "-", nil amount is formatted as "<nil>".
+// NOTE: The result is parsable back by ParseAmount with the same divisibility only if the amount
+// is valid, see ValidateAmount.
+func FormatAmount(amount *big.Int, divisibility byte) string {
+	if amount == nil {
+		return "<nil>"
+	}
+
+	digits := new(big.Int).Abs(amount).String()
+	sign := ""
+	if numbers.IsNegative(amount) {
+		sign = "-"
+	}
+
+	if divisibility == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= int(divisibility) {
+		digits = strings.Repeat("0", int(divisibility)-len(digits)+1) + digits
+	}
+
+	point := len(digits) - int(divisibility)
+	integer, fractional := digits[:point], strings.TrimRight(digits[point:], "0")
+	if fractional == "" {
+		return sign + integer
+	}
+
+	return sign + integer + decimalPoint + fractional
+}
+
+// ParseAmount parses decimal string into rune amount in rune units with divisibility
+// applied, e.g. "123.456" with divisibility 5 -> 12345600.
+// Returns ErrInvalidAmount if the string is malformed, has more fractional digits
+// than divisibility allows or the result overflows uint128.
+func ParseAmount(amount string, divisibility byte) (*big.Int, error) {
+	if divisibility > MaxDivisibility {
+		return nil, fmt.Errorf("%w: divisibility %d is greater than %d", ErrInvalidAmount, divisibility, MaxDivisibility)
+	}
+
+	integer, fractional, hasPoint := strings.Cut(amount, decimalPoint)
+	if integer == "" || (hasPoint && fractional == "") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	if len(fractional) > int(divisibility) {
+		return nil, fmt.Errorf("%w: %q has more than %d fractional digits", ErrInvalidAmount, amount, divisibility)
+	}
+
+	digits := integer + fractional + strings.Repeat("0", int(divisibility)-len(fractional))
+	for _, char := range digits {
+		if char < '0' || char > '9' {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+		}
+	}
+
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	if err := ValidateAmount(value, divisibility); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// ValidateAmount returns ErrInvalidAmount if rune amount in rune units is nil, negative or overflows uint128,
+// or divisibility is greater than MaxDivisibility.
+func ValidateAmount(amount *big.Int, divisibility byte) error {
+	if divisibility > MaxDivisibility {
+		return fmt.Errorf("%w: divisibility %d is greater than %d", ErrInvalidAmount, divisibility, MaxDivisibility)
+	}
+
+	if amount == nil {
+		return fmt.Errorf("%w: amount is not set", ErrInvalidAmount)
+	}
+
+	if numbers.IsNegative(amount) {
+		return fmt.Errorf("%w: %s is negative", ErrInvalidAmount, FormatAmount(amount, divisibility))
+	}
+
+	if _, err := u128.FromBig(amount); err != nil {
+		return fmt.Errorf("%w: %s overflows uint128", ErrInvalidAmount, FormatAmount(amount, divisibility))
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+func TestAmount(t *testing.T) {
+	t.Run("FormatAmount", func(t *testing.T) {
+		tests := []struct {
+			amount       *big.Int
+			divisibility byte
+			expected     string
+		}{
+			{big.NewInt(0), 0, "0"},
+			{big.NewInt(0), 5, "0"},
+			{big.NewInt(123456), 0, "123456"},
+			{big.NewInt(123456), 3, "123.456"},
+			{big.NewInt(100050), 3, "100.05"},
+			{big.NewInt(100000), 3, "100"},
+			{big.NewInt(5), 4, "0.0005"},
+			{big.NewInt(123), 3, "0.123"},
+			{numbers.MaxUInt128Value, 38, "3.40282366920938463463374607431768211455"},
+			{big.NewInt(-100050), 3, "-100.05"},
+			{nil, 3, "<nil>"},
+		}
+		for _, test := range tests {
+			require.Equal(t, test.expected, runes.FormatAmount(test.amount, test.divisibility))
+		}
+
+		// INFO: invalid amounts are not parsable back.
+		_, err := runes.ParseAmount(runes.FormatAmount(big.NewInt(-1), 0), 0)
+		require.ErrorIs(t, err, runes.ErrInvalidAmount)
+	})
+
+	t.Run("ParseAmount", func(t *testing.T) {
+		tests := []struct {
+			amount       string
+			divisibility byte
+			expected     *big.Int
+		}{
+			{"0", 0, big.NewInt(0)},
+			{"123456", 0, big.NewInt(123456)},
+			{"123.456", 3, big.NewInt(123456)},
+			{"123.456", 5, big.NewInt(12345600)},
+			{"0.0005", 4, big.NewInt(5)},
+			{"100", 2, big.NewInt(10000)},
+			{"3.40282366920938463463374607431768211455", 38, numbers.MaxUInt128Value},
+		}
+		for _, test := range tests {
+			amount, err := runes.ParseAmount(test.amount, test.divisibility)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, amount, test.amount)
+		}
+	})
+
+	t.Run("ParseAmount (invalid)", func(t *testing.T) {
+		tests := []struct {
+			amount       string
+			divisibility byte
+		}{
+			{"", 0},
+			{".5", 2},
+			{"5.", 2},
+			{"1.5", 0},
+			{"1.555", 2},
+			{"-1", 0},
+			{"+1", 0},
+			{"1e5", 0},
+			{"1,5", 2},
+			{"1", 39},
+			{"340282366920938463463374607431768211456", 0},
+			{"3.40282366920938463463374607431768211456", 38},
+		}
+		for _, test := range tests {
+			_, err := runes.ParseAmount(test.amount, test.divisibility)
+			require.ErrorIs(t, err, runes.ErrInvalidAmount, test.amount)
+		}
+	})
+
+	t.Run("ValidateAmount", func(t *testing.T) {
+		require.NoError(t, runes.ValidateAmount(numbers.MaxUInt128Value, runes.MaxDivisibility))
+
+		overflow := new(big.Int).Add(numbers.MaxUInt128Value, big.NewInt(1))
+		for _, err := range []error{
+			runes.ValidateAmount(big.NewInt(-1), 0),
+			runes.ValidateAmount(nil, 0),
+			runes.ValidateAmount(overflow, 2),
+			runes.ValidateAmount(big.NewInt(1), runes.MaxDivisibility+1),
+		} {
+			require.ErrorIs(t, err, runes.ErrInvalidAmount)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		for divisibility := byte(0); divisibility <= runes.MaxDivisibility; divisibility++ {
+			for _, amount := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(1000), big.NewInt(987654321), numbers.MaxUInt128Value} {
+				parsed, err := runes.ParseAmount(runes.FormatAmount(amount, divisibility), divisibility)
+				require.NoError(t, err)
+				require.Equal(t, 0, amount.Cmp(parsed))
+			}
+		}
+	})
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcd/txscript"
+
+	"github.com/BoostyLabs/blockchain/internal/reverse"
+)
+
+// annexTag defines the first byte of the taproot witness annex (BIP-341).
+const annexTag byte = 0x50
+
+// CommitConfirmations defines minimum number of confirmations of the transaction with the output
+// spent by the etching reveal input, etching of the named rune is ignored by indexers otherwise.
+const CommitConfirmations uint64 = 6
+
+// CommitMaturityHeight returns the earliest block height the etching reveal transaction could be included in,
+// commitHeight is the height of the block with the transaction creating commitment output.
+func CommitMaturityHeight(commitHeight uint64) uint64 {
+	return commitHeight + CommitConfirmations - 1
+}
+
+// Commitment returns rune name commitment: rune value in little endian with trailing zeros trimmed.
+// Etching of the named rune is valid only if the reveal input tapscript pushes the commitment.
+func (r *Rune) Commitment() []byte {
+	return reverse.Bytes(r.Value().Bytes())
+}
+
+// ScriptCommitsToRune returns true if the tapscript contains data push of the rune commitment.
+// INFO: [Rust impl] script is parsed up to the first malformed instruction.
+func ScriptCommitsToRune(script []byte, rune_ *Rune) bool {
+	commitment := rune_.Commitment()
+	tokenizer := txscript.MakeScriptTokenizer(0, script)
+	for tokenizer.Next() {
+		if tokenizer.Opcode() <= txscript.OP_PUSHDATA4 && bytes.Equal(tokenizer.Data(), commitment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WitnessCommitsToRune returns true if the script path spending input witness contains tapscript
+// with the rune commitment, see ScriptCommitsToRune. Key path spending witness commits to nothing.
+// NOTE: Spent output must also be the taproot output with CommitConfirmations, which is not checked.
+func WitnessCommitsToRune(witness [][]byte, rune_ *Rune) bool {
+	if len(witness) >= 2 && len(witness[len(witness)-1]) != 0 && witness[len(witness)-1][0] == annexTag {
+		witness = witness[:len(witness)-1]
+	}
+	if len(witness) < 2 {
+		return false
+	}
+
+	return ScriptCommitsToRune(witness[len(witness)-2], rune_)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+func TestCommitment(t *testing.T) {
+	rune_, err := runes.NewRuneFromString("UNCOMMONGOODS")
+	require.NoError(t, err)
+
+	t.Run("Commitment", func(t *testing.T) {
+		first, err := runes.NewRuneFromNumber(big.NewInt(0))
+		require.NoError(t, err)
+		require.Empty(t, first.Commitment())
+
+		value, err := runes.NewRuneFromNumber(big.NewInt(0x010203))
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x03, 0x02, 0x01}, value.Commitment())
+
+		require.Equal(t, rune_.Value(), new(big.Int).SetBytes(reverseCopy(rune_.Commitment())))
+	})
+
+	script, err := txscript.NewScriptBuilder().
+		AddData(make([]byte, 32)).AddOp(txscript.OP_CHECKSIG).
+		AddOp(txscript.OP_FALSE).AddOp(txscript.OP_IF).
+		AddData([]byte("ord")).AddData([]byte{13}).AddData(rune_.Commitment()).
+		AddOp(txscript.OP_ENDIF).
+		Script()
+	require.NoError(t, err)
+
+	other, err := runes.NewRuneFromString("UNCOMMONGOODZ")
+	require.NoError(t, err)
+
+	t.Run("ScriptCommitsToRune", func(t *testing.T) {
+		require.True(t, runes.ScriptCommitsToRune(script, rune_))
+		require.False(t, runes.ScriptCommitsToRune(script, other))
+
+		// INFO: instructions after the malformed one are not parsed.
+		require.False(t, runes.ScriptCommitsToRune(append([]byte{txscript.OP_PUSHDATA1, 0xff}, script...), rune_))
+	})
+
+	t.Run("CommitMaturityHeight", func(t *testing.T) {
+		// INFO: commitment block is the first confirmation.
+		require.EqualValues(t, 840005, runes.CommitMaturityHeight(840000))
+	})
+
+	t.Run("WitnessCommitsToRune", func(t *testing.T) {
+		signature, controlBlock := make([]byte, 64), make([]byte, 33)
+		require.True(t, runes.WitnessCommitsToRune([][]byte{signature, script, controlBlock}, rune_))
+		require.True(t, runes.WitnessCommitsToRune([][]byte{signature, script, controlBlock, {0x50, 0x01}}, rune_))
+		require.False(t, runes.WitnessCommitsToRune([][]byte{signature, script, controlBlock}, other))
+		require.False(t, runes.WitnessCommitsToRune([][]byte{signature}, rune_))
+		require.False(t, runes.WitnessCommitsToRune(nil, rune_))
+	})
+}
+
+// reverseCopy returns reversed copy of the bytes.
+func reverseCopy(data []byte) []byte {
+	reversed := make([]byte, len(data))
+	for i := range data {
+		reversed[len(data)-1-i] = data[i]
+	}
+
+	return reversed
+}
@@ -4,6 +4,7 @@
 package runes
 
 import (
+	"fmt"
 	"math/big"
 	"slices"
 
@@ -32,14 +33,30 @@ func ParseEdictsFromIntSeq(sr *sequencereader.SequenceReader[*big.Int]) ([]Edict
 		amount, _ := sr.Next()
 		output, _ := sr.Next()
 
-		edict := Edict{
-			RuneID: prevRuneID.Next(RuneID{
-				Block: block.Uint64(),
-				TxID:  uint32(tx.Uint64()),
-			}),
-			Amount: amount,
-			Output: uint32(output.Uint64()),
+		var (
+			delta RuneID
+			edict = Edict{Amount: amount}
+			err   error
+		)
+		if delta.Block, err = toUint64(block); err != nil {
+			return nil, err
 		}
+		if delta.TxID, err = toUint32(tx); err != nil {
+			return nil, err
+		}
+		if edict.Output, err = toUint32(output); err != nil {
+			return nil, err
+		}
+		if err = checkUint128(amount); err != nil {
+			return nil, err
+		}
+
+		// INFO: [Rust impl] rune id overflow produces cenotaph.
+		if prevRuneID.overflows(delta) {
+			return nil, fmt.Errorf("%w: rune id overflow", ErrCenotaph)
+		}
+
+		edict.RuneID = prevRuneID.Next(delta)
 
 		prevRuneID.Set(edict.RuneID)
 		edicts = append(edicts, edict)
@@ -65,35 +82,21 @@ func SortEdicts(edicts []Edict) {
 	})
 }
 
-// UseDelta converts list of Edits using delta encoding.
+// UseDelta converts list of Edits using delta encoding, see DeltaEncode.
 func UseDelta(sortedEdicts []Edict) []Edict {
 	var (
-		deltaEdicts   = make([]Edict, len(sortedEdicts))
-		previousBlock uint64
-		previousTx    uint32
-		blockDelta    uint64
-		txDelta       uint32
+		deltaEdicts = make([]Edict, len(sortedEdicts))
+		previous    RuneID
 	)
 
 	for idx, edict := range sortedEdicts {
-		blockDelta = edict.RuneID.Block - previousBlock
-		if blockDelta == 0 {
-			txDelta = edict.RuneID.TxID - previousTx
-		} else {
-			txDelta = edict.RuneID.TxID
-		}
-
 		deltaEdicts[idx] = Edict{
-			RuneID: RuneID{
-				Block: blockDelta,
-				TxID:  txDelta,
-			},
+			RuneID: previous.delta(edict.RuneID),
 			Amount: edict.Amount,
 			Output: edict.Output,
 		}
 
-		previousBlock = edict.RuneID.Block
-		previousTx = edict.RuneID.TxID
+		previous = edict.RuneID
 	}
 
 	return deltaEdicts
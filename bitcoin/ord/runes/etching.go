@@ -4,7 +4,28 @@
 package runes
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/BoostyLabs/blockchain/u128"
+)
+
+var (
+	// ErrSupplyOverflow defines that etching max supply overflows uint128.
+	ErrSupplyOverflow = errors.New("supply overflows uint128")
+	// ErrUnmintable defines that etching has no open mint terms.
+	ErrUnmintable = errors.New("rune is not mintable")
+	// ErrMintNotStarted defines that open mint is not started yet.
+	ErrMintNotStarted = errors.New("mint is not started")
+	// ErrMintEnded defines that open mint is already ended.
+	ErrMintEnded = errors.New("mint is ended")
+	// ErrMintCapReached defines that all mints are already done.
+	ErrMintCapReached = errors.New("mint cap is reached")
+	// ErrInvalidSymbol defines that etching symbol is not a single printable unicode scalar value.
+	ErrInvalidSymbol = errors.New("invalid rune symbol")
 )
 
 // Etching defines values to create new rune.
@@ -27,3 +48,168 @@ type Terms struct {
 	OffsetStart *uint64
 	OffsetEnd   *uint64
 }
+
+// Validate returns ErrInvalidSymbol if the symbol is not a valid unicode scalar value or is a control character.
+// INFO: zero symbol is allowed, since it is filled by default for the parsed etching without symbol.
+func (etching *Etching) Validate() error {
+	if etching.Symbol == nil || *etching.Symbol == 0 {
+		return nil
+	}
+
+	symbol := *etching.Symbol
+	if !utf8.ValidRune(symbol) || unicode.IsControl(symbol) {
+		return fmt.Errorf("%w: %U", ErrInvalidSymbol, symbol)
+	}
+
+	return nil
+}
+
+// SymbolFromString returns etching symbol from the string with exactly one unicode code point,
+// e.g. user input. Returns ErrInvalidSymbol for multiple code points, like emoji grapheme clusters.
+func SymbolFromString(s string) (rune, error) {
+	if !utf8.ValidString(s) || utf8.RuneCountInString(s) != 1 {
+		return 0, fmt.Errorf("%w: %q is not a single code point", ErrInvalidSymbol, s)
+	}
+
+	symbol, _ := utf8.DecodeRuneInString(s)
+
+	if err := (&Etching{Symbol: &symbol}).Validate(); err != nil {
+		return 0, err
+	}
+
+	return symbol, nil
+}
+
+// MaxSupply returns maximum rune supply: premine + cap * amount.
+// Returns ErrSupplyOverflow if the supply does not fit into uint128.
+func (etching *Etching) MaxSupply() (*big.Int, error) {
+	premine, err := u128FromOptional(etching.Premine)
+	if err != nil {
+		return nil, err
+	}
+
+	if etching.Terms == nil {
+		return premine.Big(), nil
+	}
+
+	cap_, err := u128FromOptional(etching.Terms.Cap)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := u128FromOptional(etching.Terms.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	minted, err := cap_.Mul(amount)
+	if err != nil {
+		return nil, ErrSupplyOverflow
+	}
+
+	supply, err := premine.Add(minted)
+	if err != nil {
+		return nil, ErrSupplyOverflow
+	}
+
+	return supply.Big(), nil
+}
+
+// IsMintable returns nil if one more mint of the rune etched in etchingBlock
+// is allowed in the block with provided height when mints were already done.
+// Otherwise, returns ErrUnmintable, ErrMintNotStarted, ErrMintEnded or ErrMintCapReached.
+// INFO: [Rust impl] mint start is the latest of absolute and relative start,
+// mint end is the earliest of absolute and relative end.
+func (etching *Etching) IsMintable(etchingBlock, height uint64, mints *big.Int) error {
+	if etching.Terms == nil {
+		return ErrUnmintable
+	}
+
+	if start, ok := etching.Terms.Start(etchingBlock); ok && height < start {
+		return fmt.Errorf("%w: starts at %d", ErrMintNotStarted, start)
+	}
+
+	if end, ok := etching.Terms.End(etchingBlock); ok && height >= end {
+		return fmt.Errorf("%w: ended at %d", ErrMintEnded, end)
+	}
+
+	cap_ := big.NewInt(0)
+	if etching.Terms.Cap != nil {
+		cap_ = etching.Terms.Cap
+	}
+
+	if mints == nil {
+		mints = big.NewInt(0)
+	}
+
+	if mints.Cmp(cap_) >= 0 {
+		return fmt.Errorf("%w: %s", ErrMintCapReached, cap_.String())
+	}
+
+	return nil
+}
+
+// Start returns the first block of the open mint if any limitation is set.
+func (terms *Terms) Start(etchingBlock uint64) (uint64, bool) {
+	var (
+		start uint64
+		ok    bool
+	)
+	if terms.HeightStart != nil {
+		start, ok = *terms.HeightStart, true
+	}
+
+	if terms.OffsetStart != nil {
+		relative := saturatingAdd(etchingBlock, *terms.OffsetStart)
+		if !ok || relative > start {
+			start = relative
+		}
+		ok = true
+	}
+
+	return start, ok
+}
+
+// End returns the block when the open mint ends (exclusive) if any limitation is set.
+func (terms *Terms) End(etchingBlock uint64) (uint64, bool) {
+	var (
+		end uint64
+		ok  bool
+	)
+	if terms.HeightEnd != nil {
+		end, ok = *terms.HeightEnd, true
+	}
+
+	if terms.OffsetEnd != nil {
+		relative := saturatingAdd(etchingBlock, *terms.OffsetEnd)
+		if !ok || relative < end {
+			end = relative
+		}
+		ok = true
+	}
+
+	return end, ok
+}
+
+// u128FromOptional returns value as U128 or zero if the value is nil.
+func u128FromOptional(value *big.Int) (u128.U128, error) {
+	if value == nil {
+		return u128.Zero, nil
+	}
+
+	result, err := u128.FromBig(value)
+	if err != nil {
+		return u128.Zero, ErrSupplyOverflow
+	}
+
+	return result, nil
+}
+
+// saturatingAdd returns a + b or max uint64 value in case of overflow.
+func saturatingAdd(a, b uint64) uint64 {
+	if a+b < a {
+		return ^uint64(0)
+	}
+
+	return a + b
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+func TestEtching(t *testing.T) {
+	t.Run("MaxSupply", func(t *testing.T) {
+		tests := []struct {
+			etching  runes.Etching
+			expected *big.Int
+			err      error
+		}{
+			{runes.Etching{}, big.NewInt(0), nil},
+			{runes.Etching{Premine: big.NewInt(1000)}, big.NewInt(1000), nil},
+			{runes.Etching{Premine: big.NewInt(1000), Terms: &runes.Terms{Cap: big.NewInt(10)}}, big.NewInt(1000), nil},
+			{runes.Etching{Premine: big.NewInt(1000), Terms: &runes.Terms{Cap: big.NewInt(10), Amount: big.NewInt(7)}}, big.NewInt(1070), nil},
+			{runes.Etching{Terms: &runes.Terms{Cap: numbers.MaxUInt128Value, Amount: big.NewInt(1)}}, numbers.MaxUInt128Value, nil},
+			{runes.Etching{Premine: big.NewInt(1), Terms: &runes.Terms{Cap: numbers.MaxUInt128Value, Amount: big.NewInt(1)}}, nil, runes.ErrSupplyOverflow},
+			{runes.Etching{Terms: &runes.Terms{Cap: numbers.MaxUInt128Value, Amount: big.NewInt(2)}}, nil, runes.ErrSupplyOverflow},
+			{runes.Etching{Premine: new(big.Int).Add(numbers.MaxUInt128Value, big.NewInt(1))}, nil, runes.ErrSupplyOverflow},
+		}
+		for _, test := range tests {
+			supply, err := test.etching.MaxSupply()
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, test.expected, supply)
+		}
+	})
+
+	t.Run("IsMintable", func(t *testing.T) {
+		etching := runes.Etching{
+			Terms: &runes.Terms{
+				Amount:      big.NewInt(100),
+				Cap:         big.NewInt(10),
+				HeightStart: ptr[uint64](840010),
+				HeightEnd:   ptr[uint64](840100),
+				OffsetStart: ptr[uint64](20),
+				OffsetEnd:   ptr[uint64](50),
+			},
+		}
+
+		tests := []struct {
+			height uint64
+			mints  *big.Int
+			err    error
+		}{
+			{840000, big.NewInt(0), runes.ErrMintNotStarted},
+			{840019, big.NewInt(0), runes.ErrMintNotStarted},
+			{840020, big.NewInt(0), nil},
+			{840049, big.NewInt(9), nil},
+			{840049, big.NewInt(10), runes.ErrMintCapReached},
+			{840050, big.NewInt(0), runes.ErrMintEnded},
+			{840100, nil, runes.ErrMintEnded},
+		}
+		for _, test := range tests {
+			require.ErrorIs(t, etching.IsMintable(840000, test.height, test.mints), test.err, test.height)
+		}
+
+		require.ErrorIs(t, (&runes.Etching{}).IsMintable(840000, 840000, nil), runes.ErrUnmintable)
+		require.ErrorIs(t, (&runes.Etching{Terms: &runes.Terms{}}).IsMintable(840000, 840000, nil), runes.ErrMintCapReached)
+		require.NoError(t, (&runes.Etching{Terms: &runes.Terms{Cap: big.NewInt(1)}}).IsMintable(840000, 1, nil))
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		symbol := func(r rune) *rune { return &r }
+
+		require.NoError(t, (&runes.Etching{}).Validate())
+		require.NoError(t, (&runes.Etching{Symbol: symbol(0)}).Validate())
+		require.NoError(t, (&runes.Etching{Symbol: symbol('$')}).Validate())
+		require.NoError(t, (&runes.Etching{Symbol: symbol('🐕')}).Validate())
+		require.ErrorIs(t, (&runes.Etching{Symbol: symbol('\n')}).Validate(), runes.ErrInvalidSymbol)
+		require.ErrorIs(t, (&runes.Etching{Symbol: symbol(0xD800)}).Validate(), runes.ErrInvalidSymbol)
+		require.ErrorIs(t, (&runes.Etching{Symbol: symbol(0x110000)}).Validate(), runes.ErrInvalidSymbol)
+		require.ErrorIs(t, (&runes.Etching{Symbol: symbol(-1)}).Validate(), runes.ErrInvalidSymbol)
+
+		_, err := (&runes.Runestone{Etching: &runes.Etching{Symbol: symbol(0x7F)}}).Serialize()
+		require.ErrorIs(t, err, runes.ErrInvalidSymbol)
+	})
+
+	t.Run("SymbolFromString", func(t *testing.T) {
+		symbol, err := runes.SymbolFromString("🐕")
+		require.NoError(t, err)
+		require.Equal(t, '🐕', symbol)
+
+		for _, s := range []string{"", "ab", "👍🏽", "🇺🇦", "\t", "\xff"} {
+			_, err = runes.SymbolFromString(s)
+			require.ErrorIs(t, err, runes.ErrInvalidSymbol, s)
+		}
+	})
+}
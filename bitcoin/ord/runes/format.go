@@ -0,0 +1,173 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// runestoneJSON defines ord-compatible JSON representation of the Runestone.
+type runestoneJSON struct {
+	Edicts  []edictJSON  `json:"edicts"`
+	Etching *etchingJSON `json:"etching"`
+	Mint    *string      `json:"mint"`
+	Pointer *uint32      `json:"pointer"`
+}
+
+// edictJSON defines ord-compatible JSON representation of the Edict.
+type edictJSON struct {
+	ID     string   `json:"id"`
+	Amount *big.Int `json:"amount"`
+	Output uint32   `json:"output"`
+}
+
+// etchingJSON defines ord-compatible JSON representation of the Etching.
+type etchingJSON struct {
+	Divisibility *byte      `json:"divisibility"`
+	Premine      *big.Int   `json:"premine"`
+	Rune         *string    `json:"rune"`
+	Spacers      *uint32    `json:"spacers"`
+	Symbol       *string    `json:"symbol"`
+	Terms        *termsJSON `json:"terms"`
+	Turbo        bool       `json:"turbo"`
+}
+
+// termsJSON defines ord-compatible JSON representation of the Terms.
+type termsJSON struct {
+	Amount *big.Int   `json:"amount"`
+	Cap    *big.Int   `json:"cap"`
+	Height [2]*uint64 `json:"height"`
+	Offset [2]*uint64 `json:"offset"`
+}
+
+// MarshalJSON returns Runestone as JSON in ord-compatible notation.
+func (runestone *Runestone) MarshalJSON() ([]byte, error) {
+	data := runestoneJSON{
+		Edicts:  make([]edictJSON, 0, len(runestone.Edicts)),
+		Pointer: runestone.Pointer,
+	}
+
+	for _, edict := range runestone.Edicts {
+		data.Edicts = append(data.Edicts, edictJSON{
+			ID:     edict.RuneID.String(),
+			Amount: edict.Amount,
+			Output: edict.Output,
+		})
+	}
+
+	if runestone.Mint != nil {
+		mint := runestone.Mint.String()
+		data.Mint = &mint
+	}
+
+	if etching := runestone.Etching; etching != nil {
+		data.Etching = &etchingJSON{
+			Divisibility: etching.Divisibility,
+			Premine:      etching.Premine,
+			Spacers:      etching.Spacers,
+			Turbo:        etching.Turbo,
+		}
+
+		if etching.Rune != nil {
+			name := etching.Rune.String()
+			data.Etching.Rune = &name
+		}
+
+		if etching.Symbol != nil {
+			symbol := string(*etching.Symbol)
+			data.Etching.Symbol = &symbol
+		}
+
+		if terms := etching.Terms; terms != nil {
+			data.Etching.Terms = &termsJSON{
+				Amount: terms.Amount,
+				Cap:    terms.Cap,
+				Height: [2]*uint64{terms.HeightStart, terms.HeightEnd},
+				Offset: [2]*uint64{terms.OffsetStart, terms.OffsetEnd},
+			}
+		}
+	}
+
+	return json.Marshal(data)
+}
+
+// FormatHuman returns Runestone as multiline human-readable text. Etching name
+// is printed with spacers applied, premine is adjusted by etching divisibility.
+func (runestone *Runestone) FormatHuman() string {
+	var sb strings.Builder
+
+	if etching := runestone.Etching; etching != nil {
+		var spacers uint32
+		if etching.Spacers != nil {
+			spacers = *etching.Spacers
+		}
+
+		name := "<reserved>"
+		if etching.Rune != nil {
+			name = etching.Rune.StringWithSeparator(spacers)
+		}
+
+		var divisibility byte
+		if etching.Divisibility != nil {
+			divisibility = *etching.Divisibility
+		}
+
+		sb.WriteString("etching: " + name)
+		if etching.Symbol != nil && *etching.Symbol != 0 {
+			sb.WriteString(" (" + string(*etching.Symbol) + ")")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("  divisibility: %d\n", divisibility))
+		if etching.Premine != nil {
+			sb.WriteString("  premine: " + FormatAmount(etching.Premine, divisibility) + "\n")
+		}
+
+		if terms := etching.Terms; terms != nil {
+			sb.WriteString("  terms:\n")
+			if terms.Amount != nil {
+				sb.WriteString("    amount: " + FormatAmount(terms.Amount, divisibility) + "\n")
+			}
+			if terms.Cap != nil {
+				sb.WriteString("    cap: " + terms.Cap.String() + "\n")
+			}
+			sb.WriteString("    height: " + formatRange(terms.HeightStart, terms.HeightEnd) + "\n")
+			sb.WriteString("    offset: " + formatRange(terms.OffsetStart, terms.OffsetEnd) + "\n")
+		}
+
+		sb.WriteString(fmt.Sprintf("  turbo: %t\n", etching.Turbo))
+	}
+
+	if runestone.Mint != nil {
+		sb.WriteString("mint: " + runestone.Mint.String() + "\n")
+	}
+
+	if runestone.Pointer != nil {
+		sb.WriteString(fmt.Sprintf("pointer: %d\n", *runestone.Pointer))
+	}
+
+	if len(runestone.Edicts) != 0 {
+		sb.WriteString("edicts:\n")
+		for _, edict := range runestone.Edicts {
+			sb.WriteString(fmt.Sprintf("  %s: %s -> output %d\n", edict.RuneID.String(), edict.Amount.String(), edict.Output))
+		}
+	}
+
+	return sb.String()
+}
+
+// formatRange returns optional range bounds as string.
+func formatRange(start, end *uint64) string {
+	bound := func(value *uint64) string {
+		if value == nil {
+			return "-"
+		}
+
+		return fmt.Sprintf("%d", *value)
+	}
+
+	return "[" + bound(start) + ";" + bound(end) + ")"
+}
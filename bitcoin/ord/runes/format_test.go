@@ -0,0 +1,87 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+func TestFormat(t *testing.T) {
+	rune_, err := runes.NewRuneFromString("HELLORUNE")
+	require.NoError(t, err)
+
+	runestone := &runes.Runestone{
+		Etching: &runes.Etching{
+			Divisibility: ptr[byte](2),
+			Premine:      big.NewInt(100005),
+			Rune:         rune_,
+			Spacers:      ptr[uint32](0b10000),
+			Symbol:       ptr('$'),
+			Terms: &runes.Terms{
+				Amount:      big.NewInt(1000),
+				Cap:         big.NewInt(50),
+				HeightStart: ptr[uint64](840000),
+			},
+			Turbo: true,
+		},
+		Pointer: ptr[uint32](1),
+	}
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		data, err := json.Marshal(runestone)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"edicts": [],
+			"etching": {
+				"divisibility": 2,
+				"premine": 100005,
+				"rune": "HELLORUNE",
+				"spacers": 16,
+				"symbol": "$",
+				"terms": {"amount": 1000, "cap": 50, "height": [840000, null], "offset": [null, null]},
+				"turbo": true
+			},
+			"mint": null,
+			"pointer": 1
+		}`, string(data))
+
+		data, err = json.Marshal(&runes.Runestone{
+			Edicts: []runes.Edict{{RuneID: runes.RuneID{Block: 840000, TxID: 3}, Amount: big.NewInt(15), Output: 2}},
+			Mint:   &runes.RuneID{Block: 840000, TxID: 1},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"edicts": [{"id": "840000:3", "amount": 15, "output": 2}],
+			"etching": null,
+			"mint": "840000:1",
+			"pointer": null
+		}`, string(data))
+	})
+
+	t.Run("FormatHuman", func(t *testing.T) {
+		require.Equal(t, "etching: HELLO•RUNE ($)\n"+
+			"  divisibility: 2\n"+
+			"  premine: 1000.05\n"+
+			"  terms:\n"+
+			"    amount: 10\n"+
+			"    cap: 50\n"+
+			"    height: [840000;-)\n"+
+			"    offset: [-;-)\n"+
+			"  turbo: true\n"+
+			"pointer: 1\n", runestone.FormatHuman())
+
+		require.Equal(t, "mint: 840000:1\n"+
+			"edicts:\n"+
+			"  840000:3: 15 -> output 2\n", (&runes.Runestone{
+			Edicts: []runes.Edict{{RuneID: runes.RuneID{Block: 840000, TxID: 3}, Amount: big.NewInt(15), Output: 2}},
+			Mint:   &runes.RuneID{Block: 840000, TxID: 1},
+		}).FormatHuman())
+	})
+}
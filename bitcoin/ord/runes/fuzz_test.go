@@ -0,0 +1,167 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/sequencereader"
+)
+
+// runestoneSeeds defines valid runestone scripts used as fuzzing corpus seeds.
+var runestoneSeeds = []string{
+	"6a5d09008fe69d0154d70e01",
+	"6a5d0a14b0dd9d011482011601",
+	"6a5d15010a0201030004dedfd1e58fd617054d0680b19164",
+	"6a5d1a020104fae2a3e9ac8cb9d814010403800205240680c2d72f1601",
+}
+
+func FuzzParseRunestone(f *testing.F) {
+	for _, seed := range runestoneSeeds {
+		script, err := hex.DecodeString(seed)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(script)
+	}
+
+	f.Fuzz(func(t *testing.T, script []byte) {
+		runestone, err := runes.ParseRunestone(script)
+		if err != nil {
+			return
+		}
+
+		// parsed runestone must be serializable without loss of data.
+		script, err = runestone.IntoScript()
+		if err != nil {
+			return // payload may not fit single push or etching symbol is not printable.
+		}
+
+		reparsed, err := runes.ParseRunestone(script)
+		if err != nil {
+			t.Fatalf("parse serialized runestone: %v", err)
+		}
+
+		if !reflect.DeepEqual(runestone, reparsed) {
+			t.Fatalf("runestone changed after serialization: %+v, %+v", runestone, reparsed)
+		}
+	})
+}
+
+func FuzzParseMessage(f *testing.F) {
+	for _, seed := range runestoneSeeds {
+		script, err := hex.DecodeString(seed)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(script[3:])
+	}
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		sequence, err := runes.PayloadIntoIntSequence(payload)
+		if err != nil {
+			return
+		}
+
+		_, _ = runes.ParseMessage(sequencereader.New(sequence))
+	})
+}
+
+func FuzzParseEdictsFromIntSeq(f *testing.F) {
+	f.Add([]byte{0x8f, 0xe6, 0x9d, 0x01, 0x54, 0xd7, 0x0e, 0x01})
+	f.Add([]byte{0x01, 0x02, 0x03, 0x04, 0x00, 0x02, 0x03, 0x04})
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		sequence, err := runes.PayloadIntoIntSequence(payload)
+		if err != nil {
+			return
+		}
+
+		edicts, err := runes.ParseEdictsFromIntSeq(sequencereader.New(sequence))
+		if err != nil {
+			return
+		}
+
+		if len(edicts) != len(sequence)/4 {
+			t.Fatalf("unexpected edicts count: %d, sequence len: %d", len(edicts), len(sequence))
+		}
+	})
+}
+
+// runeIDsFromBytes splits data into rune ids, 12 bytes each.
+func runeIDsFromBytes(data []byte) []runes.RuneID {
+	ids := make([]runes.RuneID, 0, len(data)/12)
+	for ; len(data) >= 12; data = data[12:] {
+		ids = append(ids, runes.RuneID{
+			Block: binary.LittleEndian.Uint64(data[:8]),
+			TxID:  binary.LittleEndian.Uint32(data[8:12]),
+		})
+	}
+
+	return ids
+}
+
+func FuzzDeltaEncode(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0, 0x40, 0xd1, 0x0c, 0, 0, 0, 0, 0, 1, 0, 0, 0})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ids := runeIDsFromBytes(data)
+		slices.SortFunc(ids, func(a, b runes.RuneID) int {
+			switch {
+			case a.Less(b):
+				return -1
+			case b.Less(a):
+				return 1
+			default:
+				return 0
+			}
+		})
+
+		deltas, err := runes.DeltaEncode(ids)
+		if err != nil {
+			t.Fatalf("encode sorted rune ids: %v", err)
+		}
+
+		decoded, err := runes.DeltaDecode(deltas)
+		if err != nil {
+			t.Fatalf("decode rune ids: %v", err)
+		}
+
+		if !reflect.DeepEqual(ids, decoded) {
+			t.Fatalf("rune ids changed after delta encoding: %v, %v", ids, decoded)
+		}
+	})
+}
+
+func FuzzDeltaDecode(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0, 0x40, 0xd1, 0x0c, 0, 0, 0, 0, 0, 1, 0, 0, 0})
+	f.Add([]byte{1, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		deltas := runeIDsFromBytes(data)
+		ids, err := runes.DeltaDecode(deltas)
+		if err != nil {
+			return // rune id overflow.
+		}
+
+		encoded, err := runes.DeltaEncode(ids)
+		if err != nil {
+			t.Fatalf("encode decoded rune ids: %v", err)
+		}
+
+		if !reflect.DeepEqual(deltas, encoded) {
+			t.Fatalf("deltas changed after decoding: %v, %v", deltas, encoded)
+		}
+	})
+}
@@ -4,12 +4,21 @@
 package runes
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 	"strings"
 )
 
+var (
+	// ErrRuneIDsNotSorted describes that rune ids are not sorted in ascending order, so can not be delta encoded.
+	ErrRuneIDsNotSorted = errors.New("rune ids are not sorted")
+	// ErrRuneIDOverflow describes that delta decoded rune id overflows block or transaction index.
+	ErrRuneIDOverflow = errors.New("rune id overflow")
+)
+
 // RuneID defined the id of the rune.
 type RuneID struct {
 	Block uint64
@@ -45,6 +54,25 @@ func (id *RuneID) Next(delta RuneID) RuneID {
 	return RuneID{Block: id.Block + delta.Block, TxID: delta.TxID}
 }
 
+// Less returns true if id precedes runeID, ordering by block first and transaction index next.
+func (id *RuneID) Less(runeID RuneID) bool {
+	return id.Block < runeID.Block || (id.Block == runeID.Block && id.TxID < runeID.TxID)
+}
+
+// delta returns delta of the runeID relative to the id, inverse of Next. The id must not be greater than the runeID.
+func (id *RuneID) delta(runeID RuneID) RuneID {
+	if runeID.Block == id.Block {
+		return RuneID{TxID: runeID.TxID - id.TxID}
+	}
+
+	return RuneID{Block: runeID.Block - id.Block, TxID: runeID.TxID}
+}
+
+// overflows returns true if Next with the delta overflows block or transaction index.
+func (id *RuneID) overflows(delta RuneID) bool {
+	return delta.Block > math.MaxUint64-id.Block || (delta.Block == 0 && delta.TxID > math.MaxUint32-id.TxID)
+}
+
 // Set is a copying setter, sets runeID values to id.
 func (id *RuneID) Set(runeID RuneID) {
 	id.Block = runeID.Block
@@ -60,3 +88,45 @@ func (id *RuneID) String() string {
 func (id *RuneID) ToIntSeq() []*big.Int {
 	return []*big.Int{big.NewInt(int64(id.Block)), big.NewInt(int64(id.TxID))}
 }
+
+// DeltaEncode returns rune ids delta encoded as in runestone edicts: each id is encoded relative to
+// the previous one starting from 0:0. If the block is the same as previous, delta block is 0 and
+// delta tx is the difference of transaction indexes, otherwise delta block is the difference of blocks
+// and delta tx is the transaction index as is.
+// Returns ErrRuneIDsNotSorted if ids are not sorted in ascending order, equal ids are allowed.
+func DeltaEncode(ids []RuneID) ([]RuneID, error) {
+	var (
+		previous RuneID
+		deltas   = make([]RuneID, 0, len(ids))
+	)
+	for idx, id := range ids {
+		if id.Less(previous) {
+			return nil, fmt.Errorf("%w: %s at index %d follows %s", ErrRuneIDsNotSorted, id.String(), idx, previous.String())
+		}
+
+		deltas = append(deltas, previous.delta(id))
+		previous = id
+	}
+
+	return deltas, nil
+}
+
+// DeltaDecode returns rune ids from deltas produced by DeltaEncode or read from runestone edicts.
+// Returns ErrRuneIDOverflow if any decoded id overflows block or transaction index,
+// runestone with such edicts is a cenotaph.
+func DeltaDecode(deltas []RuneID) ([]RuneID, error) {
+	var (
+		previous RuneID
+		ids      = make([]RuneID, 0, len(deltas))
+	)
+	for idx, delta := range deltas {
+		if previous.overflows(delta) {
+			return nil, fmt.Errorf("%w: delta %s at index %d after %s", ErrRuneIDOverflow, delta.String(), idx, previous.String())
+		}
+
+		previous = previous.Next(delta)
+		ids = append(ids, previous)
+	}
+
+	return ids, nil
+}
@@ -4,6 +4,7 @@
 package runes_test
 
 import (
+	"math"
 	"math/big"
 	"testing"
 
@@ -77,4 +78,52 @@ func TestRuneID(t *testing.T) {
 			}
 		}
 	})
+	t.Run("DeltaEncode", func(t *testing.T) {
+		ids := []runes.RuneID{
+			{Block: 0, TxID: 3},
+			{Block: 0, TxID: 5},
+			{Block: 840000, TxID: 1},
+			{Block: 840000, TxID: 1},
+			{Block: 840000, TxID: 7},
+			{Block: 840010, TxID: 0},
+		}
+		deltas := []runes.RuneID{
+			{Block: 0, TxID: 3},
+			{Block: 0, TxID: 2},
+			{Block: 840000, TxID: 1},
+			{Block: 0, TxID: 0},
+			{Block: 0, TxID: 6},
+			{Block: 10, TxID: 0},
+		}
+
+		encoded, err := runes.DeltaEncode(ids)
+		require.NoError(t, err)
+		require.Equal(t, deltas, encoded)
+
+		decoded, err := runes.DeltaDecode(deltas)
+		require.NoError(t, err)
+		require.Equal(t, ids, decoded)
+
+		encoded, err = runes.DeltaEncode(nil)
+		require.NoError(t, err)
+		require.Empty(t, encoded)
+
+		_, err = runes.DeltaEncode([]runes.RuneID{{Block: 840000, TxID: 7}, {Block: 840000, TxID: 1}})
+		require.ErrorIs(t, err, runes.ErrRuneIDsNotSorted)
+
+		_, err = runes.DeltaEncode([]runes.RuneID{{Block: 840001, TxID: 0}, {Block: 840000, TxID: 7}})
+		require.ErrorIs(t, err, runes.ErrRuneIDsNotSorted)
+	})
+
+	t.Run("DeltaDecode overflow", func(t *testing.T) {
+		_, err := runes.DeltaDecode([]runes.RuneID{{Block: 1, TxID: 0}, {Block: math.MaxUint64, TxID: 0}})
+		require.ErrorIs(t, err, runes.ErrRuneIDOverflow)
+
+		_, err = runes.DeltaDecode([]runes.RuneID{{Block: 1, TxID: math.MaxUint32}, {Block: 0, TxID: 1}})
+		require.ErrorIs(t, err, runes.ErrRuneIDOverflow)
+
+		decoded, err := runes.DeltaDecode([]runes.RuneID{{Block: 1, TxID: math.MaxUint32}, {Block: 1, TxID: 0}})
+		require.NoError(t, err)
+		require.Equal(t, []runes.RuneID{{Block: 1, TxID: math.MaxUint32}, {Block: 2, TxID: 0}}, decoded)
+	})
 }
@@ -4,85 +4,72 @@
 package runes
 
 import (
+	"fmt"
+	"math"
 	"math/big"
-	"slices"
 
+	"github.com/BoostyLabs/blockchain/bitcoin/opreturn"
 	"github.com/BoostyLabs/blockchain/internal/sequencereader"
 )
 
-// fieldType defines helping struct for ordering map.
-type fieldType struct {
-	Tag  Tag
-	Nums []*big.Int
-}
-
 // Message defines helping struct for serialising and deserializing Runestone.
 type Message struct {
 	Edicts []Edict
 	Fields map[Tag][]*big.Int
 }
 
+// messageCodec defines tagged-varint codec of the runestone message.
+var messageCodec = opreturn.Codec{BodyTag: uint64(TagBody), ValidateTag: validateTag}
+
 // ParseMessage parses Message from integer sequence.
 func ParseMessage(sr *sequencereader.SequenceReader[*big.Int]) (*Message, error) {
-	message := &Message{
-		Fields: make(map[Tag][]*big.Int),
+	decoded, err := messageCodec.Decode(sr.Remaining())
+	if err != nil {
+		return nil, err
 	}
 
-	for sr.HasNext() {
-		var (
-			err          error
-			tagBigInt, _ = sr.Next() // skip error due to loop condition check.
-			tag          = Tag(tagBigInt.Uint64())
-		)
-		if TagBody == tag {
-			message.Edicts, err = ParseEdictsFromIntSeq(sr)
-			if err != nil {
-				return nil, err
-			}
-
-			break
+	message := new(Message)
+	if decoded.Fields != nil {
+		message.Fields = make(map[Tag][]*big.Int, len(decoded.Fields))
+		for tag, values := range decoded.Fields {
+			message.Fields[Tag(tag)] = values
 		}
+	}
 
-		value, err := sr.Next()
+	if decoded.Body != nil {
+		message.Edicts, err = ParseEdictsFromIntSeq(sequencereader.New(decoded.Body))
 		if err != nil {
-			return nil, ErrTruncated
+			return nil, err
 		}
+	}
+
+	return message, nil
+}
 
-		message.Fields[tag] = append(message.Fields[tag], value)
+// validateTag keeps fields with tags which fit Tag type.
+// INFO: [Rust impl] unrecognized even tag produces cenotaph, odd one is ignored.
+func validateTag(tag *big.Int) (bool, error) {
+	if tag.IsUint64() && tag.Uint64() <= math.MaxUint8 {
+		return true, nil
 	}
 
-	if len(message.Fields) == 0 {
-		message.Fields = nil
+	if tag.Bit(0) == 0 {
+		return false, fmt.Errorf("%w: unrecognized even tag %s", ErrCenotaph, tag)
 	}
 
-	return message, nil
+	return false, nil
 }
 
 // ToIntSeq returns Message as sequence on integers.
 func (message *Message) ToIntSeq() []*big.Int {
-	ordered := make([]fieldType, 0, len(message.Fields))
+	encoding := &opreturn.Message{Fields: make(map[uint64][]*big.Int, len(message.Fields))}
 	for tag, ints := range message.Fields {
-		ordered = append(ordered, fieldType{tag, ints})
-	}
-
-	// sort ordered for immutability.
-	slices.SortFunc(ordered, func(a, b fieldType) int {
-		return int(a.Tag) - int(b.Tag)
-	})
-
-	// key/value -> 2 ints + 1 extra for mint 2nd value + edicts*4 for
-	// edicts values - 1 because edicts key value is group of 4 ints.
-	sequence := make([]*big.Int, 0, len(message.Fields)*2+len(message.Edicts)*4)
-	for _, field := range ordered {
-		for _, val := range field.Nums {
-			sequence = append(sequence, field.Tag.BigInt(), val)
-		}
+		encoding.Fields[uint64(tag)] = ints
 	}
 
 	if message.Edicts != nil {
-		sequence = append(sequence, TagBody.BigInt())
-		sequence = append(sequence, EdictsToIntSeq(message.Edicts)...)
+		encoding.Body = EdictsToIntSeq(message.Edicts)
 	}
 
-	return sequence
+	return messageCodec.Encode(encoding)
 }
@@ -5,10 +5,13 @@ package runes
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 
 	"github.com/BoostyLabs/blockchain/internal/numbers"
+	"github.com/BoostyLabs/blockchain/u128"
 )
 
 // DefaultSpacer defines default spacer for Rune name.
@@ -119,7 +122,7 @@ func NewRuneFromString(runeStr string) (*Rune, error) {
 		value = value.Add(value, big.NewInt(int64(c)-'A'))
 	}
 
-	if numbers.IsGreater(value, numbers.MaxUInt128Value) {
+	if _, err := u128.FromBig(value); err != nil {
 		return nil, errors.New("value overflows uint128")
 	}
 	if numbers.IsGreater(value, FirstReservedRuneNameInt) {
@@ -169,7 +172,7 @@ func NewRuneFromStringWithSpacer(runeStr string, spacer ...rune) (*Rune, uint32,
 
 // NewRuneFromNumber creates new Rune from number.
 func NewRuneFromNumber(number *big.Int) (*Rune, error) {
-	if numbers.IsGreater(number, numbers.MaxUInt128Value) || number.Sign() < 0 {
+	if _, err := u128.FromBig(number); err != nil {
 		return nil, errors.New("invalid number")
 	}
 	if !numbers.IsLess(number, FirstReservedRuneNameInt) {
@@ -230,6 +233,26 @@ func (r *Rune) StringWithSeparator(spacers uint32, spacer ...rune) string {
 	return symbol
 }
 
+// IsReserved returns true if the Rune name is in the reserved names range,
+// such names can not be etched explicitly.
+func (r *Rune) IsReserved() bool {
+	return !numbers.IsLess(r.value, FirstReservedRuneNameInt)
+}
+
+// IsUnlockedAt returns true if the Rune name is available to be etched in the block with provided height.
+func (r *Rune) IsUnlockedAt(height uint64) bool {
+	return !numbers.IsLess(r.value, MinAtHeight(height).value)
+}
+
+// UnlockHeight returns the earliest block height when the Rune name becomes available to be etched.
+func (r *Rune) UnlockHeight() uint64 {
+	end := ProtocolBlockStart + SubsidyHalvingInterval
+
+	return uint64(sort.Search(int(end), func(height int) bool {
+		return r.IsUnlockedAt(uint64(height))
+	}))
+}
+
 // RuneReserve returns allocated rune name in case it was omitted in etching.
 func RuneReserve(runeID RuneID) *Rune {
 	// INFO: [Rust impl] 6402364363415443603228541259936211926 + (u128::from(block) << 32 | u128::from(tx))
@@ -240,6 +263,16 @@ func RuneReserve(runeID RuneID) *Rune {
 	return &Rune{value: reservedName}
 }
 
+// ResolveReservedRune returns the final name of the rune etched without explicit name.
+// runeID is the id of the confirmed etching transaction: block height and transaction index.
+func ResolveReservedRune(runeID RuneID) (*Rune, error) {
+	if runeID.Block == 0 {
+		return nil, fmt.Errorf("invalid etching rune id: %s", runeID.String())
+	}
+
+	return RuneReserve(runeID), nil
+}
+
 // MinNameLength returns unlocked rune name length depending on block.
 func MinNameLength(currentBlock uint64) int {
 	if currentBlock < ProtocolBlockStart {
@@ -208,6 +208,16 @@ func TestRunes(t *testing.T) {
 		}
 	})
 
+	t.Run("ResolveReservedRune", func(t *testing.T) {
+		rune_, err := runes.ResolveReservedRune(runes.RuneID{Block: 100, TxID: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, "AAAAAAAAAAAAAAAAAACBMITDVSR", rune_.String())
+		require.True(t, rune_.IsReserved())
+
+		_, err = runes.ResolveReservedRune(runes.RuneID{})
+		require.Error(t, err)
+	})
+
 	t.Run("MinNameLength", func(t *testing.T) {
 		tests := []struct {
 			block    uint64
@@ -311,4 +321,36 @@ func TestRunes(t *testing.T) {
 			require.EqualValues(t, test.minimum, runeStr, "%d -> %d (%s)", test.height, test.minimum, runeStr)
 		}
 	})
+	t.Run("UnlockHeight", func(t *testing.T) {
+		start := runes.ProtocolBlockStart
+		interval := runes.UnlockNamePeriod
+		tests := []struct {
+			name     string
+			from, to uint64 // expected unlock height range.
+		}{
+			{"AAAAAAAAAAAAA", 0, 0},
+			{"ZZZZZZZZZZZZ", start - 1, start},
+			{"HELLO", start + interval*7, start + interval*8},
+			{"A", start + interval*12 - 1, start + interval*12},
+		}
+
+		for _, test := range tests {
+			rune_, err := runes.NewRuneFromString(test.name)
+			require.NoError(t, err)
+
+			height := rune_.UnlockHeight()
+			require.True(t, test.from <= height && height <= test.to, "%s -> %d", test.name, height)
+			require.True(t, rune_.IsUnlockedAt(height))
+			if height > 0 {
+				require.False(t, rune_.IsUnlockedAt(height-1))
+			}
+		}
+	})
+
+	t.Run("IsReserved", func(t *testing.T) {
+		rune_, err := runes.NewRuneFromString("ZZZZZZZZZZZZZZZZZZZZZZZZZZ")
+		require.NoError(t, err)
+		require.False(t, rune_.IsReserved())
+		require.True(t, runes.RuneReserve(runes.RuneID{Block: 840000, TxID: 1}).IsReserved())
+	})
 }
@@ -4,16 +4,20 @@
 package runes
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"math/big"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/aviate-labs/leb128"
 	"github.com/btcsuite/btcd/txscript"
 
+	"github.com/BoostyLabs/blockchain/bitcoin/opreturn"
 	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes/utils"
 	"github.com/BoostyLabs/blockchain/internal/sequencereader"
+	"github.com/BoostyLabs/blockchain/u128"
 )
 
 const (
@@ -30,7 +34,7 @@ var ErrCenotaph = errors.New("cenotaph")
 var ErrOverflow = errors.New("payload overflow")
 
 // ErrTruncated defines that payload is do not have required fields.
-var ErrTruncated = errors.New("truncated payload")
+var ErrTruncated = opreturn.ErrTruncated
 
 // Runestone abstractly defines runestone fields.
 type Runestone struct {
@@ -50,7 +54,7 @@ func ParseRunestone(script []byte) (runestone *Runestone, err error) {
 
 	sequence, err := PayloadIntoIntSequence(payload)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrCenotaph, err)
 	}
 
 	return runestone, runestone.parse(sequencereader.New(sequence))
@@ -100,35 +104,47 @@ func (runestone *Runestone) parse(sr *sequencereader.SequenceReader[*big.Int]) e
 	for tag, ints := range message.Fields {
 		switch tag {
 		case TagMint:
-			failure = !utils.IfLen(ints, 2).Then(func() error {
-				runestone.mint().Block = ints[0].Uint64()
-				runestone.mint().TxID = uint32(ints[1].Int64())
-				return nil
-			}).Ok()
+			res := utils.IfLen(ints, 2).Then(func() (err error) {
+				if runestone.mint().Block, err = toUint64(ints[0]); err != nil {
+					return err
+				}
+
+				runestone.mint().TxID, err = toUint32(ints[1])
+				return err
+			})
+
+			failure = !res.Ok()
+			err = res.Error()
 		case TagPointer:
-			failure = !utils.IfLen(ints, 1).Then(func() error {
-				*runestone.pointer() = uint32(ints[0].Uint64())
-				return nil
-			}).Ok()
+			res := utils.IfLen(ints, 1).Then(func() (err error) {
+				*runestone.pointer(), err = toUint32(ints[0])
+				return err
+			})
+
+			failure = !res.Ok()
+			err = res.Error()
 		case TagDivisibility:
 			res := utils.IfLen(ints, 1).Then(func() error {
-				divisibility := byte(ints[0].Uint64())
-				runestone.etching().Divisibility = &divisibility
-
-				if *runestone.Etching.Divisibility > MaxDivisibility {
+				if ints[0].Cmp(big.NewInt(int64(MaxDivisibility))) > 0 {
 					return errors.New("too large divisibility")
 				}
 
+				divisibility := byte(ints[0].Uint64())
+				runestone.etching().Divisibility = &divisibility
+
 				return nil
 			})
 
 			failure = !etching || !res.Ok()
 			err = res.Error()
 		case TagPremine:
-			failure = !etching || !utils.IfLen(ints, 1).Then(func() error {
+			res := utils.IfLen(ints, 1).Then(func() error {
 				runestone.etching().Premine = ints[0]
-				return nil
-			}).Ok()
+				return checkUint128(ints[0])
+			})
+
+			failure = !etching || !res.Ok()
+			err = res.Error()
 		case TagRune:
 			res := utils.IfLen(ints, 1).Then(func() error {
 				rune, err := NewRuneFromNumber(ints[0])
@@ -140,13 +156,13 @@ func (runestone *Runestone) parse(sr *sequencereader.SequenceReader[*big.Int]) e
 			err = res.Error()
 		case TagSpacers:
 			res := utils.IfLen(ints, 1).Then(func() error {
-				spacers := uint32(ints[0].Uint64())
-				runestone.etching().Spacers = &spacers
-
-				if *runestone.Etching.Spacers > MaxSpacers {
+				if ints[0].Cmp(big.NewInt(int64(MaxSpacers))) > 0 {
 					return errors.New("too large spacers")
 				}
 
+				spacers := uint32(ints[0].Uint64())
+				runestone.etching().Spacers = &spacers
+
 				return nil
 			})
 
@@ -154,44 +170,67 @@ func (runestone *Runestone) parse(sr *sequencereader.SequenceReader[*big.Int]) e
 			err = res.Error()
 		case TagSymbol:
 			failure = !etching || !utils.IfLen(ints, 1).Then(func() error {
-				symbol := rune(ints[0].Int64())
+				// INFO: [Rust impl] invalid char is ignored, since the tag is odd.
+				if !ints[0].IsUint64() || ints[0].Uint64() > unicode.MaxRune || !utf8.ValidRune(rune(ints[0].Uint64())) {
+					return nil
+				}
+
+				symbol := rune(ints[0].Uint64())
 				runestone.etching().Symbol = &symbol
 				return nil
 			}).Ok()
 		case TagAmount:
-			failure = !terms || !utils.IfLen(ints, 1).Then(func() error {
+			res := utils.IfLen(ints, 1).Then(func() error {
 				runestone.terms().Amount = ints[0]
-				return nil
-			}).Ok()
+				return checkUint128(ints[0])
+			})
+
+			failure = !terms || !res.Ok()
+			err = res.Error()
 		case TagCap:
-			failure = !terms || !utils.IfLen(ints, 1).Then(func() error {
+			res := utils.IfLen(ints, 1).Then(func() error {
 				runestone.terms().Cap = ints[0]
-				return nil
-			}).Ok()
+				return checkUint128(ints[0])
+			})
+
+			failure = !terms || !res.Ok()
+			err = res.Error()
 		case TagHeightStart:
-			failure = !terms || !utils.IfLen(ints, 1).Then(func() error {
-				height := ints[0].Uint64()
+			res := utils.IfLen(ints, 1).Then(func() error {
+				height, err := toUint64(ints[0])
 				runestone.terms().HeightStart = &height
-				return nil
-			}).Ok()
+				return err
+			})
+
+			failure = !terms || !res.Ok()
+			err = res.Error()
 		case TagHeightEnd:
-			failure = !terms || !utils.IfLen(ints, 1).Then(func() error {
-				height := ints[0].Uint64()
+			res := utils.IfLen(ints, 1).Then(func() error {
+				height, err := toUint64(ints[0])
 				runestone.terms().HeightEnd = &height
-				return nil
-			}).Ok()
+				return err
+			})
+
+			failure = !terms || !res.Ok()
+			err = res.Error()
 		case TagOffsetStart:
-			failure = !terms || !utils.IfLen(ints, 1).Then(func() error {
-				offset := ints[0].Uint64()
+			res := utils.IfLen(ints, 1).Then(func() error {
+				offset, err := toUint64(ints[0])
 				runestone.terms().OffsetStart = &offset
-				return nil
-			}).Ok()
+				return err
+			})
+
+			failure = !terms || !res.Ok()
+			err = res.Error()
 		case TagOffsetEnd:
-			failure = !terms || !utils.IfLen(ints, 1).Then(func() error {
-				offset := ints[0].Uint64()
+			res := utils.IfLen(ints, 1).Then(func() error {
+				offset, err := toUint64(ints[0])
 				runestone.terms().OffsetEnd = &offset
-				return nil
-			}).Ok()
+				return err
+			})
+
+			failure = !terms || !res.Ok()
+			err = res.Error()
 		}
 
 		if failure {
@@ -234,23 +273,50 @@ func (runestone *Runestone) Serialize() ([]byte, error) {
 	}
 	flags := big.NewInt(0)
 	if runestone.Etching != nil {
+		if err := runestone.Etching.Validate(); err != nil {
+			return nil, err
+		}
+
 		flags = AddFlag(flags, FlagEtching)
-		message.Fields[TagDivisibility] = []*big.Int{big.NewInt(int64(*runestone.Etching.Divisibility))}
-		message.Fields[TagPremine] = []*big.Int{runestone.Etching.Premine}
-		message.Fields[TagRune] = []*big.Int{runestone.Etching.Rune.Value()}
+		if runestone.Etching.Divisibility != nil {
+			message.Fields[TagDivisibility] = []*big.Int{big.NewInt(int64(*runestone.Etching.Divisibility))}
+		}
+		if runestone.Etching.Premine != nil {
+			message.Fields[TagPremine] = []*big.Int{runestone.Etching.Premine}
+		}
+		// INFO: Omitted rune name is allocated from the reserved names range, see RuneReserve.
+		if runestone.Etching.Rune != nil {
+			message.Fields[TagRune] = []*big.Int{runestone.Etching.Rune.Value()}
+		}
 		if runestone.Etching.Spacers != nil {
 			message.Fields[TagSpacers] = []*big.Int{big.NewInt(int64(*runestone.Etching.Spacers))}
 		}
-		message.Fields[TagSymbol] = []*big.Int{big.NewInt(int64(*runestone.Etching.Symbol))}
+		if runestone.Etching.Symbol != nil {
+			message.Fields[TagSymbol] = []*big.Int{big.NewInt(int64(*runestone.Etching.Symbol))}
+		}
 
 		if runestone.Etching.Terms != nil {
 			flags = AddFlag(flags, FlagTerms)
-			message.Fields[TagCap] = []*big.Int{runestone.Etching.Terms.Cap}
-			message.Fields[TagAmount] = []*big.Int{runestone.Etching.Terms.Amount}
-			message.Fields[TagHeightStart] = []*big.Int{new(big.Int).SetUint64(*runestone.Etching.Terms.HeightStart)}
-			message.Fields[TagHeightEnd] = []*big.Int{new(big.Int).SetUint64(*runestone.Etching.Terms.HeightEnd)}
-			message.Fields[TagOffsetStart] = []*big.Int{new(big.Int).SetUint64(*runestone.Etching.Terms.OffsetStart)}
-			message.Fields[TagOffsetEnd] = []*big.Int{new(big.Int).SetUint64(*runestone.Etching.Terms.OffsetEnd)}
+			// INFO: Omitted terms fields mean no limitation, so only set ones are encoded.
+			terms := runestone.Etching.Terms
+			if terms.Cap != nil {
+				message.Fields[TagCap] = []*big.Int{terms.Cap}
+			}
+			if terms.Amount != nil {
+				message.Fields[TagAmount] = []*big.Int{terms.Amount}
+			}
+			if terms.HeightStart != nil {
+				message.Fields[TagHeightStart] = []*big.Int{new(big.Int).SetUint64(*terms.HeightStart)}
+			}
+			if terms.HeightEnd != nil {
+				message.Fields[TagHeightEnd] = []*big.Int{new(big.Int).SetUint64(*terms.HeightEnd)}
+			}
+			if terms.OffsetStart != nil {
+				message.Fields[TagOffsetStart] = []*big.Int{new(big.Int).SetUint64(*terms.OffsetStart)}
+			}
+			if terms.OffsetEnd != nil {
+				message.Fields[TagOffsetEnd] = []*big.Int{new(big.Int).SetUint64(*terms.OffsetEnd)}
+			}
 		}
 
 		if runestone.Etching.Turbo {
@@ -416,7 +482,8 @@ func (runestone *Runestone) Verify(outputsNumber int) error {
 }
 
 // PreparePayload validates raw script payload, removes OP_<...> bytes,
-// returns collected data from OP_PUSH_<...> commands.
+// returns collected data from OP_PUSH_<...> commands. Data pushes are accepted
+// in any framing as ord does: OP_0, OP_DATA_<num> and OP_PUSHDATA1, 2 or 4.
 func PreparePayload(rawPayload []byte) ([]byte, error) {
 	if len(rawPayload) < 4 { // OP_RETURN + OP_13 + OP_PUSH_<num> + data(at least 1 byte).
 		return nil, errors.New("payload too short")
@@ -431,30 +498,18 @@ func PreparePayload(rawPayload []byte) ([]byte, error) {
 	}
 
 	payload := make([]byte, 0, len(rawPayload)-3)
-	buffer := bytes.NewReader(rawPayload[2:])
-	for buffer.Len() > 0 {
-		op, err := buffer.ReadByte()
-		if err != nil {
-			return nil, err
-		}
-
-		if op < txscript.OP_DATA_1 || op > txscript.OP_DATA_75 {
-			return nil, errors.New("missing OP_DATA_<num>")
-		}
-
-		data := make([]byte, op)
-		_, err = buffer.Read(data)
-		if err != nil {
-			return nil, err
+	tokenizer := txscript.MakeScriptTokenizer(0, rawPayload[2:])
+	for tokenizer.Next() {
+		if !isDataPush(tokenizer.Opcode()) {
+			return nil, fmt.Errorf("missing OP_PUSH_<num>: opcode %#x", tokenizer.Opcode())
 		}
 
-		payload = append(payload, data...)
+		payload = append(payload, tokenizer.Data()...)
+	}
+	if err := tokenizer.Err(); err != nil {
+		// INFO: tokenizer fails on data push exceeding the script only.
+		return nil, fmt.Errorf("%w: %w: %w", ErrTruncated, io.ErrUnexpectedEOF, err)
 	}
-
-	// TODO: figure out where it must be.
-	// if len(payload) > 18 {
-	// 	return nil, ErrOverflow
-	// }.
 
 	return payload, nil
 }
@@ -468,40 +523,52 @@ func IsPossibleRunestone(script []byte) bool {
 		return false
 	case script[1] != txscript.OP_13:
 		return false
-	case script[2] < txscript.OP_DATA_1 || script[2] > txscript.OP_DATA_75:
+	case !isDataPush(script[2]):
 		return false
 	}
 
 	return true
 }
 
+// isDataPush returns true if opcode pushes data: OP_0, OP_DATA_<num> or OP_PUSHDATA<num>.
+func isDataPush(opcode byte) bool {
+	return opcode <= txscript.OP_PUSHDATA4
+}
+
 // PayloadIntoIntSequence decodes payload in LEB128 into integer sequence.
+// Returns ErrVarintOverlong, ErrVarintOverflow or ErrVarintUnterminated if payload contains invalid integer.
 func PayloadIntoIntSequence(payload []byte) ([]*big.Int, error) {
-	sequence := make([]*big.Int, 0)
-	data := bytes.NewReader(payload)
-	for data.Len() > 0 {
-		num, err := leb128.DecodeUnsigned(data)
-		if err != nil {
-			return nil, err
-		}
+	return opreturn.DecodePayload(payload)
+}
+
+// IntSequenceIntoPayload encodes integer sequence into payload in LEB128.
+func IntSequenceIntoPayload(sequence []*big.Int) ([]byte, error) {
+	return opreturn.EncodePayload(sequence)
+}
 
-		sequence = append(sequence, num)
+// toUint64 returns value as uint64, ErrCenotaph if the value overflows uint64.
+func toUint64(value *big.Int) (uint64, error) {
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("%w: %s overflows uint64", ErrCenotaph, value)
 	}
 
-	return sequence, nil
+	return value.Uint64(), nil
 }
 
-// IntSequenceIntoPayload encodes integer sequence into payload in LEB128.
-func IntSequenceIntoPayload(sequence []*big.Int) ([]byte, error) {
-	payload := make([]byte, 0)
-	for _, num := range sequence {
-		bytes, err := leb128.EncodeUnsigned(num)
-		if err != nil {
-			return nil, err
-		}
+// toUint32 returns value as uint32, ErrCenotaph if the value overflows uint32.
+func toUint32(value *big.Int) (uint32, error) {
+	if !value.IsUint64() || value.Uint64() > math.MaxUint32 {
+		return 0, fmt.Errorf("%w: %s overflows uint32", ErrCenotaph, value)
+	}
 
-		payload = append(payload, bytes...)
+	return uint32(value.Uint64()), nil
+}
+
+// checkUint128 returns ErrCenotaph if the value overflows uint128.
+func checkUint128(value *big.Int) error {
+	if _, err := u128.FromBig(value); err != nil {
+		return fmt.Errorf("%w: %s overflows uint128", ErrCenotaph, value)
 	}
 
-	return payload, nil
+	return nil
 }
@@ -5,9 +5,11 @@ package runes_test
 
 import (
 	"encoding/hex"
+	"math"
 	"math/big"
 	"testing"
 
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/stretchr/testify/require"
 
 	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
@@ -233,6 +235,55 @@ func TestRunestone(t *testing.T) {
 			require.Equal(t, script, hex.EncodeToString(data))
 		})
 
+		t.Run("etching without rune name", func(t *testing.T) {
+			symbol := rune(77)
+			pointer := uint32(1)
+			runestone := &runes.Runestone{
+				Etching: &runes.Etching{
+					Divisibility: ptr[byte](0),
+					Premine:      big.NewInt(210000000),
+					Spacers:      ptr[uint32](0),
+					Symbol:       &symbol,
+				},
+				Pointer: &pointer,
+			}
+
+			data, err := runestone.IntoScript()
+			require.NoError(t, err)
+
+			parsedRunestone, err := runes.ParseRunestone(data)
+			require.NoError(t, err)
+			require.Equal(t, runestone, parsedRunestone)
+		})
+
+		t.Run("etching with terms and turbo", func(t *testing.T) {
+			rune_, err := runes.NewRuneFromString("TURBOMINTABLE")
+			require.NoError(t, err)
+
+			runestone := &runes.Runestone{
+				Etching: &runes.Etching{
+					Divisibility: ptr[byte](2),
+					Premine:      big.NewInt(0),
+					Rune:         rune_,
+					Spacers:      ptr[uint32](0),
+					Symbol:       ptr('T'),
+					Terms: &runes.Terms{
+						Amount:    big.NewInt(1000),
+						Cap:       big.NewInt(21000),
+						HeightEnd: ptr[uint64](900000),
+					},
+					Turbo: true,
+				},
+			}
+
+			data, err := runestone.IntoScript()
+			require.NoError(t, err)
+
+			parsedRunestone, err := runes.ParseRunestone(data)
+			require.NoError(t, err)
+			require.Equal(t, runestone, parsedRunestone)
+		})
+
 		t.Run("etching, real signet case", func(t *testing.T) {
 			script := "6a5d2b0126020104faa99c8abad4cba60305e6ef0706808080808080a8918bc0a2bbaf9ccfdc86c1bfbbcd051601"
 
@@ -336,6 +387,43 @@ func TestRunestone(t *testing.T) {
 		// TODO: Add tests.
 	})
 
+	t.Run("PreparePayload framing", func(t *testing.T) {
+		payload := "010a0201030004dedfd1e58fd617054d0680b19164"
+		expected, err := runes.ParseRunestone(mustDecodeHex(t, "6a5d15"+payload))
+		require.NoError(t, err)
+
+		for _, script := range []string{
+			"6a5d4c15" + payload,   // OP_PUSHDATA1.
+			"6a5d4d1500" + payload, // OP_PUSHDATA2.
+			"6a5d4e15000000" + payload,
+			"6a5d0a" + payload[:20] + "4c0b" + payload[20:], // OP_DATA_10 + OP_PUSHDATA1.
+			"6a5d004c15" + payload,                          // OP_0 pushes empty data.
+		} {
+			runestone, err := runes.ParseRunestone(mustDecodeHex(t, script))
+			require.NoError(t, err, script)
+			require.Equal(t, expected, runestone, script)
+		}
+
+		// INFO: payload larger than OP_DATA_75 can be pushed by OP_PUSHDATA1 only.
+		sequence := []*big.Int{big.NewInt(0), big.NewInt(840000), big.NewInt(1), big.NewInt(1000), big.NewInt(1)}
+		for i := 0; i < 16; i++ {
+			sequence = append(sequence, big.NewInt(0), big.NewInt(0), big.NewInt(1000), big.NewInt(1))
+		}
+		large, err := runes.IntSequenceIntoPayload(sequence)
+		require.NoError(t, err)
+		require.Greater(t, len(large), txscript.OP_DATA_75)
+
+		data, err := runes.PreparePayload(append([]byte{txscript.OP_RETURN, txscript.OP_13, txscript.OP_PUSHDATA1, byte(len(large))}, large...))
+		require.NoError(t, err)
+		require.Equal(t, large, data)
+
+		_, err = runes.PreparePayload(mustDecodeHex(t, "6a5d4c1501"))
+		require.ErrorIs(t, err, runes.ErrTruncated)
+
+		_, err = runes.PreparePayload(mustDecodeHex(t, "6a5d510a"))
+		require.Error(t, err)
+	})
+
 	t.Run("IsPossibleRunestone", func(t *testing.T) {
 		tests := []struct {
 			script string
@@ -356,6 +444,9 @@ func TestRunestone(t *testing.T) {
 			{"ffffff00", false},
 			{"ff5d1a00", false},
 			{"6a5d1a00", true},
+			{"6a5d4c0114", true},
+			{"6a5d4d010014", true},
+			{"6a5d5114", false},
 		}
 		for _, test := range tests {
 			script, err := hex.DecodeString(test.script)
@@ -490,6 +581,49 @@ func TestRunestone(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("overflowing values", func(t *testing.T) {
+		overflow64 := new(big.Int).Lsh(big.NewInt(1), 64)
+		overflow32 := new(big.Int).Lsh(big.NewInt(1), 32)
+
+		tests := []struct {
+			sequence []*big.Int
+			err      error
+		}{
+			{[]*big.Int{big.NewInt(260), big.NewInt(5)}, runes.ErrCenotaph},                            // even tag, would be TagRune if truncated.
+			{[]*big.Int{big.NewInt(256), big.NewInt(5)}, runes.ErrCenotaph},                            // even tag, would be TagBody if truncated.
+			{[]*big.Int{big.NewInt(257), big.NewInt(5)}, nil},                                          // odd tag is ignored.
+			{[]*big.Int{big.NewInt(22), overflow32}, runes.ErrCenotaph},                                // pointer.
+			{[]*big.Int{big.NewInt(20), overflow64, big.NewInt(20), big.NewInt(1)}, runes.ErrCenotaph}, // mint block.
+			{[]*big.Int{big.NewInt(20), big.NewInt(1), big.NewInt(20), overflow32}, runes.ErrCenotaph}, // mint tx.
+			{[]*big.Int{big.NewInt(0), overflow64, big.NewInt(1), big.NewInt(5), big.NewInt(0)}, runes.ErrCenotaph},
+			{[]*big.Int{big.NewInt(0), big.NewInt(1), overflow32, big.NewInt(5), big.NewInt(0)}, runes.ErrCenotaph},
+			{[]*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(5), overflow32}, runes.ErrCenotaph},
+			{[]*big.Int{
+				big.NewInt(0),
+				big.NewInt(1), new(big.Int).SetUint64(math.MaxUint32), big.NewInt(5), big.NewInt(0),
+				big.NewInt(0), big.NewInt(1), big.NewInt(5), big.NewInt(0),
+			}, runes.ErrCenotaph}, // tx id delta overflow.
+		}
+		for i, test := range tests {
+			payload, err := runes.IntSequenceIntoPayload(test.sequence)
+			require.NoError(t, err)
+
+			_, err = runes.ParseRunestone(append([]byte{0x6a, 0x5d, byte(len(payload))}, payload...))
+			require.ErrorIs(t, err, test.err, i)
+		}
+
+		_, err := runes.ParseRunestone([]byte{0x6a, 0x5d, 0x05, 0x16, 0x01})
+		require.ErrorIs(t, err, runes.ErrTruncated)
+	})
+}
+
+// mustDecodeHex returns decoded hex string.
+func mustDecodeHex(t *testing.T, s string) []byte {
+	data, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return data
 }
 
 // ptr returns pointer to the value.
@@ -0,0 +1,74 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidSpacers defines that spacers have bits beyond the last character of the rune name.
+	ErrInvalidSpacers = errors.New("invalid spacers")
+	// ErrInvalidSpacedName defines that spaced rune name is malformed.
+	ErrInvalidSpacedName = errors.New("invalid spaced rune name")
+)
+
+// AltSpacer defines alternative spacer accepted in the spaced rune names.
+const AltSpacer = '.'
+
+// ValidateSpacers returns ErrInvalidSpacers if spacers exceed MaxSpacers or have bits set beyond
+// the last character of the rune name with nameLen characters: bit i is a spacer after character i,
+// so only the first nameLen-1 bits are allowed.
+func ValidateSpacers(nameLen int, spacers uint32) error {
+	if spacers > MaxSpacers {
+		return fmt.Errorf("%w: %d exceeds %d", ErrInvalidSpacers, spacers, MaxSpacers)
+	}
+	if spacers == 0 {
+		return nil
+	}
+	if nameLen < 2 || spacers>>(nameLen-1) != 0 {
+		return fmt.Errorf("%w: %b for the name length %d", ErrInvalidSpacers, spacers, nameLen)
+	}
+
+	return nil
+}
+
+// NormalizeSpacedName returns spaced rune name with DefaultSpacer, both DefaultSpacer and AltSpacer are accepted.
+// Returns ErrInvalidSpacedName if the name has characters other than A-Z, leading, trailing or double spacers.
+func NormalizeSpacedName(name string) (string, error) {
+	var (
+		normalized strings.Builder
+		spacer     bool
+	)
+	for idx, char := range name {
+		switch {
+		case char >= 'A' && char <= 'Z':
+			normalized.WriteRune(char)
+			spacer = false
+		case char == DefaultSpacer || char == AltSpacer:
+			if idx == 0 {
+				return "", fmt.Errorf("%w: leading spacer in %q", ErrInvalidSpacedName, name)
+			}
+			if spacer {
+				return "", fmt.Errorf("%w: double spacer in %q", ErrInvalidSpacedName, name)
+			}
+
+			normalized.WriteRune(DefaultSpacer)
+			spacer = true
+		default:
+			return "", fmt.Errorf("%w: invalid character %q in %q", ErrInvalidSpacedName, char, name)
+		}
+	}
+
+	switch {
+	case normalized.Len() == 0:
+		return "", fmt.Errorf("%w: empty name", ErrInvalidSpacedName)
+	case spacer:
+		return "", fmt.Errorf("%w: trailing spacer in %q", ErrInvalidSpacedName, name)
+	}
+
+	return normalized.String(), nil
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+func TestSpacers(t *testing.T) {
+	t.Run("ValidateSpacers", func(t *testing.T) {
+		tests := []struct {
+			nameLen int
+			spacers uint32
+			valid   bool
+		}{
+			{1, 0, true},
+			{1, 1, false},
+			{2, 1, true},
+			{2, 2, false},
+			{5, 0b1111, true},
+			{5, 0b10000, false},
+			{28, 1 << 26, true},
+			{28, 1 << 27, false},
+			{0, 0, true},
+			{0, 1, false},
+			{28, runes.MaxSpacers + 1, false},
+		}
+
+		for _, test := range tests {
+			err := runes.ValidateSpacers(test.nameLen, test.spacers)
+			if test.valid {
+				require.NoError(t, err, test)
+			} else {
+				require.ErrorIs(t, err, runes.ErrInvalidSpacers, test)
+			}
+		}
+	})
+
+	t.Run("NormalizeSpacedName", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			normalized string
+			valid      bool
+		}{
+			{"A", "A", true},
+			{"HELLO•WORLD", "HELLO•WORLD", true},
+			{"HELLO.WORLD", "HELLO•WORLD", true},
+			{"A.B•C", "A•B•C", true},
+			{"", "", false},
+			{"•A", "", false},
+			{".A", "", false},
+			{"A•", "", false},
+			{"A..B", "", false},
+			{"A.•B", "", false},
+			{"hello", "", false},
+			{"A B", "", false},
+		}
+
+		for _, test := range tests {
+			normalized, err := runes.NormalizeSpacedName(test.name)
+			if !test.valid {
+				require.ErrorIs(t, err, runes.ErrInvalidSpacedName, test.name)
+				continue
+			}
+
+			require.NoError(t, err, test.name)
+			require.Equal(t, test.normalized, normalized)
+
+			rune_, spacers, err := runes.NewRuneFromStringWithSpacer(normalized)
+			require.NoError(t, err)
+			require.NoError(t, runes.ValidateSpacers(len(rune_.String()), spacers))
+			require.Equal(t, normalized, rune_.StringWithSeparator(spacers))
+		}
+	})
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes
+
+import (
+	"math/big"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/opreturn"
+)
+
+var (
+	// ErrVarintOverlong defines that LEB128 integer is encoded with more bytes than u128 requires.
+	ErrVarintOverlong = opreturn.ErrVarintOverlong
+	// ErrVarintOverflow defines that LEB128 integer overflows u128.
+	ErrVarintOverflow = opreturn.ErrVarintOverflow
+	// ErrVarintUnterminated defines that LEB128 integer has no terminating byte.
+	ErrVarintUnterminated = opreturn.ErrVarintUnterminated
+)
+
+// DecodeVarint decodes LEB128 encoded u128 integer from the beginning of the data.
+// Returns decoded integer and number of bytes read.
+func DecodeVarint(data []byte) (*big.Int, int, error) {
+	return opreturn.DecodeVarint(data)
+}
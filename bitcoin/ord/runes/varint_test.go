@@ -0,0 +1,67 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package runes_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+func TestDecodeVarint(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tests := []*big.Int{
+			big.NewInt(0),
+			big.NewInt(127),
+			big.NewInt(128),
+			big.NewInt(840000),
+			new(big.Int).Lsh(big.NewInt(1), 64),
+			numbers.MaxUInt128Value,
+		}
+		for _, test := range tests {
+			payload, err := runes.IntSequenceIntoPayload([]*big.Int{test})
+			require.NoError(t, err)
+
+			value, n, err := runes.DecodeVarint(append(payload, 0xff))
+			require.NoError(t, err)
+			require.Equal(t, len(payload), n)
+			require.Equal(t, 0, test.Cmp(value), test.String())
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			data []byte
+			err  error
+		}{
+			{[]byte{}, runes.ErrVarintUnterminated},
+			{[]byte{0x80}, runes.ErrVarintUnterminated},
+			{[]byte{0xff, 0xff}, runes.ErrVarintUnterminated},
+			{append(bytes.Repeat([]byte{0x80}, 18), 0x04), runes.ErrVarintOverflow}, // 129th bit is set.
+			{append(bytes.Repeat([]byte{0xff}, 18), 0x7f), runes.ErrVarintOverflow},
+			{append(bytes.Repeat([]byte{0x80}, 19), 0x00), runes.ErrVarintOverlong},
+		}
+		for _, test := range tests {
+			_, _, err := runes.DecodeVarint(test.data)
+			require.ErrorIs(t, err, test.err)
+		}
+
+		// the last byte of the max u128 value.
+		value, n, err := runes.DecodeVarint(append(bytes.Repeat([]byte{0xff}, 18), 0x03))
+		require.NoError(t, err)
+		require.Equal(t, 19, n)
+		require.Equal(t, 0, numbers.MaxUInt128Value.Cmp(value))
+	})
+
+	t.Run("runestone with invalid varint is cenotaph", func(t *testing.T) {
+		_, err := runes.ParseRunestone([]byte{0x6a, 0x5d, 0x02, 0x16, 0x80})
+		require.ErrorIs(t, err, runes.ErrCenotaph)
+		require.ErrorIs(t, err, runes.ErrVarintUnterminated)
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ErrInvalidOutpoint describes that outpoint transaction hash or output index is malformed.
+var ErrInvalidOutpoint = errors.New("invalid outpoint")
+
+// Outpoint describes reference to the transaction output.
+// The transaction hash is kept parsed, so it is validated once on creation.
+type Outpoint struct {
+	Hash  chainhash.Hash // transaction hash.
+	Index uint32         // output index in transaction outputs.
+}
+
+// NewOutpoint returns Outpoint of the transaction output by hex encoded transaction hash
+// (in byte-reversed order, as displayed by explorers) and output index.
+func NewOutpoint(txHash string, index uint32) (Outpoint, error) {
+	hash, err := chainhash.NewHashFromStr(txHash)
+	if err != nil {
+		return Outpoint{}, fmt.Errorf("%w: tx hash %q: %w", ErrInvalidOutpoint, txHash, err)
+	}
+
+	return Outpoint{Hash: *hash, Index: index}, nil
+}
+
+// MustOutpoint is like NewOutpoint, but panics if the transaction hash is malformed.
+// It simplifies initialization of the known outpoints, e.g. UTXO literals in tests.
+func MustOutpoint(txHash string, index uint32) Outpoint {
+	outpoint, err := NewOutpoint(txHash, index)
+	if err != nil {
+		panic(err)
+	}
+
+	return outpoint
+}
+
+// ParseOutpoint returns Outpoint parsed from "<tx hash>:<output index>" string, see Outpoint.String.
+func ParseOutpoint(s string) (Outpoint, error) {
+	txHash, index, ok := strings.Cut(s, ":")
+	if !ok {
+		return Outpoint{}, fmt.Errorf("%w: %q, expected <tx hash>:<output index>", ErrInvalidOutpoint, s)
+	}
+
+	parsedIndex, err := strconv.ParseUint(index, 10, 32)
+	if err != nil {
+		return Outpoint{}, fmt.Errorf("%w: output index %q: %w", ErrInvalidOutpoint, index, err)
+	}
+
+	return NewOutpoint(txHash, uint32(parsedIndex))
+}
+
+// TxHash returns hex encoded transaction hash in byte-reversed order.
+func (outpoint Outpoint) TxHash() string {
+	return outpoint.Hash.String()
+}
+
+// String returns outpoint as "<tx hash>:<output index>" string.
+func (outpoint Outpoint) String() string {
+	return fmt.Sprintf("%s:%d", outpoint.Hash.String(), outpoint.Index)
+}
+
+// WireOutPoint returns outpoint as wire.OutPoint used by transaction inputs.
+func (outpoint Outpoint) WireOutPoint() *wire.OutPoint {
+	return wire.NewOutPoint(&outpoint.Hash, outpoint.Index)
+}
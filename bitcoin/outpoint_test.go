@@ -0,0 +1,64 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+)
+
+func TestOutpoint(t *testing.T) {
+	const txHash = "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746"
+
+	hash, err := chainhash.NewHashFromStr(txHash)
+	require.NoError(t, err)
+
+	t.Run("new", func(t *testing.T) {
+		outpoint, err := bitcoin.NewOutpoint(txHash, 2)
+		require.NoError(t, err)
+		require.Equal(t, bitcoin.Outpoint{Hash: *hash, Index: 2}, outpoint)
+		require.Equal(t, txHash, outpoint.TxHash())
+		require.Equal(t, txHash+":2", outpoint.String())
+
+		wireOutPoint := outpoint.WireOutPoint()
+		require.Equal(t, *hash, wireOutPoint.Hash)
+		require.EqualValues(t, 2, wireOutPoint.Index)
+
+		_, err = bitcoin.NewOutpoint("not a hash", 0)
+		require.ErrorIs(t, err, bitcoin.ErrInvalidOutpoint)
+
+		_, err = bitcoin.NewOutpoint(txHash+"00", 0)
+		require.ErrorIs(t, err, bitcoin.ErrInvalidOutpoint)
+	})
+
+	t.Run("must", func(t *testing.T) {
+		require.Equal(t, bitcoin.Outpoint{Hash: *hash, Index: 2}, bitcoin.MustOutpoint(txHash, 2))
+		require.Panics(t, func() { bitcoin.MustOutpoint("not a hash", 0) })
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		outpoint, err := bitcoin.ParseOutpoint(txHash + ":4294967295")
+		require.NoError(t, err)
+		require.Equal(t, bitcoin.Outpoint{Hash: *hash, Index: 4294967295}, outpoint)
+
+		parsed, err := bitcoin.ParseOutpoint(outpoint.String())
+		require.NoError(t, err)
+		require.Equal(t, outpoint, parsed)
+
+		for _, s := range []string{"", txHash, txHash + ":", txHash + ":-1", txHash + ":4294967296", "zz:1"} {
+			_, err = bitcoin.ParseOutpoint(s)
+			require.ErrorIs(t, err, bitcoin.ErrInvalidOutpoint, s)
+		}
+	})
+
+	t.Run("utxo", func(t *testing.T) {
+		utxo := bitcoin.UTXO{Outpoint: bitcoin.Outpoint{Hash: *hash, Index: 1}}
+		require.Equal(t, txHash, utxo.TxHash())
+		require.EqualValues(t, 1, utxo.Index)
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package payjoin implements payjoin (BIP-78) sender and receiver flows over PSBTs built by txbuilder.
+package payjoin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/url"
+	"strconv"
+)
+
+// ErrInvalidParams describes that payjoin request parameters are malformed.
+var ErrInvalidParams = errors.New("invalid payjoin parameters")
+
+// Version defines supported payjoin protocol version.
+const Version = 1
+
+// Params defines optional sender parameters of the payjoin request.
+type Params struct {
+	// AdditionalFeeOutputIndex defines sender output the receiver may decrease to pay for its inputs fee, optional.
+	AdditionalFeeOutputIndex *int
+	// MaxAdditionalFeeContribution defines maximum amount in satoshi the receiver may take from the fee output.
+	MaxAdditionalFeeContribution *big.Int
+	// DisableOutputSubstitution forbids the receiver to change the payee output script or to decrease its amount.
+	DisableOutputSubstitution bool
+	// MinSatoshiPerKVByte defines minimum fee rate of the proposal in satoshi per kilo virtual byte, optional.
+	MinSatoshiPerKVByte *big.Int
+}
+
+// Query returns request query values of the parameters.
+func (p Params) Query() url.Values {
+	query := url.Values{"v": {strconv.Itoa(Version)}}
+	if p.AdditionalFeeOutputIndex != nil {
+		query.Set("additionalfeeoutputindex", strconv.Itoa(*p.AdditionalFeeOutputIndex))
+	}
+	if p.MaxAdditionalFeeContribution != nil {
+		query.Set("maxadditionalfeecontribution", p.MaxAdditionalFeeContribution.String())
+	}
+	if p.DisableOutputSubstitution {
+		query.Set("disableoutputsubstitution", "true")
+	}
+	if p.MinSatoshiPerKVByte != nil {
+		// INFO: minfeerate is in satoshi per virtual byte.
+		minFeeRate := new(big.Rat).SetFrac(p.MinSatoshiPerKVByte, big.NewInt(1000))
+		query.Set("minfeerate", minFeeRate.FloatString(3))
+	}
+
+	return query
+}
+
+// ParseParams parses request query values into parameters.
+// Returns version-unsupported receiver error if protocol version is not supported.
+func ParseParams(query url.Values) (params Params, _ error) {
+	if version := query.Get("v"); version != "" && version != strconv.Itoa(Version) {
+		return params, &ReceiverError{
+			Code:      ErrorCodeVersionUnsupported,
+			Message:   "version " + version + " is not supported",
+			Supported: []int{Version},
+		}
+	}
+
+	if value := query.Get("additionalfeeoutputindex"); value != "" {
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 {
+			return params, fmt.Errorf("%w: additionalfeeoutputindex: %s", ErrInvalidParams, value)
+		}
+
+		params.AdditionalFeeOutputIndex = &index
+	}
+
+	if value := query.Get("maxadditionalfeecontribution"); value != "" {
+		amount, ok := new(big.Int).SetString(value, 10)
+		if !ok || amount.Sign() < 0 {
+			return params, fmt.Errorf("%w: maxadditionalfeecontribution: %s", ErrInvalidParams, value)
+		}
+
+		params.MaxAdditionalFeeContribution = amount
+	}
+
+	params.DisableOutputSubstitution = query.Get("disableoutputsubstitution") == "true"
+
+	if value := query.Get("minfeerate"); value != "" {
+		feeRate, err := strconv.ParseFloat(value, 64)
+		if err != nil || feeRate < 0 || math.IsInf(feeRate, 0) || math.IsNaN(feeRate) {
+			return params, fmt.Errorf("%w: minfeerate: %s", ErrInvalidParams, value)
+		}
+
+		// INFO: vB * ( sat / kvB ) = 1000 sat.
+		params.MinSatoshiPerKVByte = big.NewInt(int64(math.Ceil(feeRate * 1000)))
+	}
+
+	return params, nil
+}
+
+// maxAdditionalFeeContribution returns maximum amount the receiver may take from the fee output, 0 if not set.
+func (p Params) maxAdditionalFeeContribution() *big.Int {
+	if p.AdditionalFeeOutputIndex == nil || p.MaxAdditionalFeeContribution == nil {
+		return big.NewInt(0)
+	}
+
+	return p.MaxAdditionalFeeContribution
+}
@@ -0,0 +1,358 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package payjoin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/payjoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+type wallet struct {
+	key     *btcec.PrivateKey
+	address string
+	script  []byte
+	pubKey  string
+}
+
+func newWallet(t *testing.T, networkParams *chaincfg.Params) wallet {
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	address, err := utils.P2TRAddressFromInternalKey(key.PubKey(), nil, networkParams)
+	require.NoError(t, err)
+
+	script, err := txscript.PayToAddrScript(address)
+	require.NoError(t, err)
+
+	return wallet{
+		key:     key,
+		address: address.EncodeAddress(),
+		script:  script,
+		pubKey:  hex.EncodeToString(schnorr.SerializePubKey(key.PubKey())),
+	}
+}
+
+func sign(t *testing.T, networkParams *chaincfg.Params, p *psbt.Packet, inputs []int, key *btcec.PrivateKey) *psbt.Packet {
+	var serialized bytes.Buffer
+	require.NoError(t, p.Serialize(&serialized))
+
+	signed, err := signer.NewSigner(networkParams).SignTaproot(signer.SignTaprootParams{
+		SerializedPSBT: serialized.Bytes(),
+		Inputs:         inputs,
+		PrivateKey:     key,
+	})
+	require.NoError(t, err)
+
+	p, err = psbt.NewFromRawBytes(bytes.NewReader(signed), false)
+	require.NoError(t, err)
+
+	for _, input := range inputs {
+		require.NoError(t, psbt.Finalize(p, input))
+	}
+
+	return p
+}
+
+func TestPayJoin(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+	sender, receiver := newWallet(t, networkParams), newWallet(t, networkParams)
+
+	result, err := txbuilder.NewTxBuilder(networkParams).BuildBTCTransferTx(txbuilder.BaseBTCTransferParams{
+		TransferSatoshiAmount: big.NewInt(100000),
+		Sender: &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{
+				{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+					Amount:   big.NewInt(850000),
+					Script:   sender.script,
+					Address:  sender.address,
+				},
+			},
+			Address: sender.address,
+			PubKey:  sender.pubKey,
+		},
+		SatoshiPerKVByte: big.NewInt(10000),
+		RecipientAddress: receiver.address,
+	})
+	require.NoError(t, err)
+
+	unsigned, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+	require.NoError(t, err)
+
+	original := sign(t, networkParams, unsigned, []int{0}, sender.key)
+	changeOutput := 1
+	require.Equal(t, sender.script, original.UnsignedTx.TxOut[changeOutput].PkScript)
+
+	receiverUTXO := &bitcoin.UTXO{
+		Outpoint: bitcoin.MustOutpoint("a3c3a4b4f0b7b6ab9d0d1e3e0c8f4a4b4f0e1c4d4f0b7b6ab9d0d1e3e0c8f4a4", 1),
+		Amount:   big.NewInt(50000),
+		Script:   receiver.script,
+		Address:  receiver.address,
+	}
+
+	server := httptest.NewServer(payjoin.NewHandler(func(original *psbt.Packet, params payjoin.Params) (*psbt.Packet, error) {
+		proposal, receiverInput, err := payjoin.Contribute(payjoin.ContributeParams{
+			Original:      original,
+			Params:        params,
+			UTXO:          receiverUTXO,
+			PubKey:        receiver.pubKey,
+			PayeeScript:   receiver.script,
+			NetworkParams: networkParams,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return sign(t, networkParams, proposal, []int{receiverInput}, receiver.key), nil
+	}))
+	defer server.Close()
+
+	params := payjoin.Params{
+		AdditionalFeeOutputIndex:     &changeOutput,
+		MaxAdditionalFeeContribution: big.NewInt(1000),
+		MinSatoshiPerKVByte:          big.NewInt(10000),
+	}
+
+	t.Run("params", func(t *testing.T) {
+		query := params.Query()
+		require.Equal(t, "1", query.Get("v"))
+		require.Equal(t, "10.000", query.Get("minfeerate"))
+
+		parsed, err := payjoin.ParseParams(query)
+		require.NoError(t, err)
+		require.Equal(t, params, parsed)
+
+		_, err = payjoin.ParseParams(url.Values{"v": {"2"}})
+		var receiverErr *payjoin.ReceiverError
+		require.ErrorAs(t, err, &receiverErr)
+		require.Equal(t, payjoin.ErrorCodeVersionUnsupported, receiverErr.Code)
+
+		_, err = payjoin.ParseParams(url.Values{"additionalfeeoutputindex": {"-1"}})
+		require.ErrorIs(t, err, payjoin.ErrInvalidParams)
+	})
+
+	t.Run("payjoin", func(t *testing.T) {
+		proposal, err := payjoin.Send(context.Background(), server.Client(), server.URL, original, params)
+		require.NoError(t, err)
+		require.Len(t, proposal.UnsignedTx.TxIn, 2)
+
+		processed, senderInputs, err := payjoin.ProcessProposal(original, proposal, receiver.script, params)
+		require.NoError(t, err)
+		require.Len(t, senderInputs, 1)
+
+		signed := sign(t, networkParams, processed, senderInputs, sender.key)
+		tx, err := psbt.Extract(signed)
+		require.NoError(t, err)
+
+		prevOutputs := txscript.NewMultiPrevOutFetcher(nil)
+		for i := range signed.Inputs {
+			prevOutputs.AddPrevOut(tx.TxIn[i].PreviousOutPoint, signed.Inputs[i].WitnessUtxo)
+		}
+
+		sigHashes := txscript.NewTxSigHashes(tx, prevOutputs)
+		for i, input := range signed.Inputs {
+			engine, err := txscript.NewEngine(input.WitnessUtxo.PkScript, tx, i, txscript.StandardVerifyFlags, nil,
+				sigHashes, input.WitnessUtxo.Value, prevOutputs)
+			require.NoError(t, err)
+			require.NoError(t, engine.Execute())
+		}
+
+		originalChange := original.UnsignedTx.TxOut[changeOutput].Value
+		changeDecrease := originalChange - tx.TxOut[changeOutput].Value
+		require.Positive(t, changeDecrease)
+		require.LessOrEqual(t, changeDecrease, int64(1000))
+		require.Greater(t, tx.TxOut[0].Value, original.UnsignedTx.TxOut[0].Value)
+	})
+
+	t.Run("contribution", func(t *testing.T) {
+		contribute := func(params payjoin.Params) *wire.MsgTx {
+			proposal, _, err := payjoin.Contribute(payjoin.ContributeParams{
+				Original:      original,
+				Params:        params,
+				UTXO:          receiverUTXO,
+				PubKey:        receiver.pubKey,
+				PayeeScript:   receiver.script,
+				NetworkParams: networkParams,
+			})
+			require.NoError(t, err)
+
+			return proposal.UnsignedTx
+		}
+
+		base := contribute(params)
+
+		// INFO: payment output pays fee up to the minimum fee rate.
+		minFeeRate := contribute(payjoin.Params{
+			AdditionalFeeOutputIndex:     &changeOutput,
+			MaxAdditionalFeeContribution: big.NewInt(1000),
+			MinSatoshiPerKVByte:          big.NewInt(20000),
+		})
+		require.Equal(t, base.TxOut[changeOutput].Value, minFeeRate.TxOut[changeOutput].Value)
+		require.Less(t, minFeeRate.TxOut[0].Value, base.TxOut[0].Value)
+
+		// INFO: fee output is not reduced below the dust amount.
+		result, err := txbuilder.NewTxBuilder(networkParams).BuildBTCTransferTx(txbuilder.BaseBTCTransferParams{
+			TransferSatoshiAmount: big.NewInt(100000),
+			Sender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 3),
+						Amount:   big.NewInt(102200),
+						Script:   sender.script,
+						Address:  sender.address,
+					},
+				},
+				Address: sender.address,
+				PubKey:  sender.pubKey,
+			},
+			SatoshiPerKVByte: big.NewInt(10000),
+			RecipientAddress: receiver.address,
+		})
+		require.NoError(t, err)
+
+		unsigned, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Less(t, unsigned.UnsignedTx.TxOut[changeOutput].Value, int64(1000))
+
+		proposal, _, err := payjoin.Contribute(payjoin.ContributeParams{
+			Original:      sign(t, networkParams, unsigned, []int{0}, sender.key),
+			Params:        params,
+			UTXO:          receiverUTXO,
+			PubKey:        receiver.pubKey,
+			PayeeScript:   receiver.script,
+			NetworkParams: networkParams,
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 546, proposal.UnsignedTx.TxOut[changeOutput].Value)
+
+		_, _, err = payjoin.Contribute(payjoin.ContributeParams{
+			Original:      original,
+			Params:        params,
+			UTXO:          receiverUTXO,
+			PubKey:        receiver.pubKey,
+			PayeeScript:   receiver.script,
+			NetworkParams: networkParams,
+			DustAmount:    big.NewInt(receiverUTXO.Amount.Int64() + original.UnsignedTx.TxOut[0].Value + 1),
+		})
+		var receiverErr *payjoin.ReceiverError
+		require.ErrorAs(t, err, &receiverErr)
+		require.Equal(t, payjoin.ErrorCodeNotEnoughMoney, receiverErr.Code)
+	})
+
+	t.Run("receiver errors", func(t *testing.T) {
+		_, err := payjoin.Send(context.Background(), server.Client(), server.URL, unsigned, params)
+		var receiverErr *payjoin.ReceiverError
+		require.ErrorAs(t, err, &receiverErr)
+		require.ErrorIs(t, err, payjoin.ErrReceiver)
+		require.Equal(t, payjoin.ErrorCodeOriginalPSBTRejected, receiverErr.Code)
+	})
+
+	t.Run("invalid proposal", func(t *testing.T) {
+		proposal, err := payjoin.Send(context.Background(), server.Client(), server.URL, original, params)
+		require.NoError(t, err)
+
+		mutate := func(mutation func(p *psbt.Packet)) *psbt.Packet {
+			var serialized bytes.Buffer
+			require.NoError(t, proposal.Serialize(&serialized))
+
+			p, err := psbt.NewFromRawBytes(&serialized, false)
+			require.NoError(t, err)
+
+			mutation(p)
+
+			return p
+		}
+
+		senderInput := 0
+		if proposal.UnsignedTx.TxIn[0].PreviousOutPoint != original.UnsignedTx.TxIn[0].PreviousOutPoint {
+			senderInput = 1
+		}
+
+		tests := []struct {
+			name     string
+			mutation func(p *psbt.Packet)
+			params   payjoin.Params
+		}{
+			{
+				name:     "lock time",
+				mutation: func(p *psbt.Packet) { p.UnsignedTx.LockTime++ },
+				params:   params,
+			},
+			{
+				name:     "sender sequence",
+				mutation: func(p *psbt.Packet) { p.UnsignedTx.TxIn[senderInput].Sequence-- },
+				params:   params,
+			},
+			{
+				name:     "sender input utxo",
+				mutation: func(p *psbt.Packet) { p.Inputs[senderInput].WitnessUtxo = wire.NewTxOut(1, sender.script) },
+				params:   params,
+			},
+			{
+				name:     "receiver input not finalized",
+				mutation: func(p *psbt.Packet) { p.Inputs[1-senderInput].FinalScriptWitness = nil },
+				params:   params,
+			},
+			{
+				name:     "change decreased over maximum",
+				mutation: func(p *psbt.Packet) { p.UnsignedTx.TxOut[changeOutput].Value -= 1000 },
+				params:   params,
+			},
+			{
+				name:     "change decreased without allowance",
+				mutation: func(p *psbt.Packet) {},
+				params:   payjoin.Params{},
+			},
+			{
+				name:     "payee substituted",
+				mutation: func(p *psbt.Packet) { p.UnsignedTx.TxOut[0].PkScript = sender.script },
+				params: payjoin.Params{
+					AdditionalFeeOutputIndex:     &changeOutput,
+					MaxAdditionalFeeContribution: big.NewInt(1000),
+					DisableOutputSubstitution:    true,
+				},
+			},
+			{
+				name:     "fee contribution exceeds fee increase",
+				mutation: func(p *psbt.Packet) { p.UnsignedTx.TxOut[0].Value += 500 },
+				params:   params,
+			},
+			{
+				name:     "fee rate",
+				mutation: func(p *psbt.Packet) {},
+				params: payjoin.Params{
+					AdditionalFeeOutputIndex:     &changeOutput,
+					MaxAdditionalFeeContribution: big.NewInt(1000),
+					MinSatoshiPerKVByte:          big.NewInt(20000),
+				},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				_, _, err := payjoin.ProcessProposal(original, mutate(test.mutation), receiver.script, test.params)
+				require.ErrorIs(t, err, payjoin.ErrInvalidProposal)
+			})
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package payjoin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+)
+
+var (
+	// ErrMissingUTXO describes that psbt input has no spent output data.
+	ErrMissingUTXO = errors.New("missing input utxo")
+	// ErrUnsupportedInput describes that weight of the psbt input spending can not be estimated.
+	ErrUnsupportedInput = errors.New("unsupported input script type")
+)
+
+// inputUTXO returns output spent by the psbt input.
+func inputUTXO(p *psbt.Packet, index int) (*wire.TxOut, error) {
+	input := p.Inputs[index]
+	if input.WitnessUtxo != nil {
+		return input.WitnessUtxo, nil
+	}
+
+	outpoint := p.UnsignedTx.TxIn[index].PreviousOutPoint
+	if input.NonWitnessUtxo != nil && input.NonWitnessUtxo.TxHash() == outpoint.Hash &&
+		int(outpoint.Index) < len(input.NonWitnessUtxo.TxOut) {
+		return input.NonWitnessUtxo.TxOut[outpoint.Index], nil
+	}
+
+	return nil, fmt.Errorf("%w: input %d", ErrMissingUTXO, index)
+}
+
+// hasUTXO returns true if psbt input has any spent output data.
+func hasUTXO(input *psbt.PInput) bool {
+	return input.WitnessUtxo != nil || input.NonWitnessUtxo != nil
+}
+
+// isFinalized returns true if psbt input has final script sig or witness.
+func isFinalized(input *psbt.PInput) bool {
+	return len(input.FinalScriptSig) > 0 || len(input.FinalScriptWitness) > 0
+}
+
+// fee returns absolute fee of the psbt, all inputs must have spent output data.
+func fee(p *psbt.Packet) (*big.Int, error) {
+	total := big.NewInt(0)
+	for i := range p.Inputs {
+		utxo, err := inputUTXO(p, i)
+		if err != nil {
+			return nil, err
+		}
+
+		total.Add(total, big.NewInt(utxo.Value))
+	}
+
+	for _, output := range p.UnsignedTx.TxOut {
+		total.Sub(total, big.NewInt(output.Value))
+	}
+
+	return total, nil
+}
+
+// txWeight returns weight of the signed transaction, exact for finalized inputs and estimated
+// by the spent script type for the others, see bitcoin.TxWeight.
+func txWeight(p *psbt.Packet) (int64, error) {
+	var buffer bytes.Buffer
+	if err := p.Serialize(&buffer); err != nil {
+		return 0, err
+	}
+
+	report, err := bitcoin.TxWeight(buffer.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	if len(report.UnknownInputs) != 0 {
+		return 0, fmt.Errorf("%w: input %d", ErrUnsupportedInput, report.UnknownInputs[0])
+	}
+
+	return report.Weight, nil
+}
+
+// feeRateSatisfied returns true if fee per weight is not lower than provided fee rate in satoshi per kilo virtual byte.
+func feeRateSatisfied(fee *big.Int, weight int64, satoshiPerKVByte *big.Int) bool {
+	// INFO: fee / ( weight / 4 ) >= rate / 1000  <=>  fee * 4000 >= rate * weight.
+	left := new(big.Int).Mul(fee, big.NewInt(4000))
+	right := new(big.Int).Mul(satoshiPerKVByte, big.NewInt(weight))
+
+	return left.Cmp(right) >= 0
+}
+
+// outputIndex returns index of the first output with provided script, -1 if not found.
+func outputIndex(tx *wire.MsgTx, script []byte) int {
+	for i, output := range tx.TxOut {
+		if bytes.Equal(output.PkScript, script) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// inputIndex returns index of the input spending provided outpoint, -1 if not found.
+func inputIndex(tx *wire.MsgTx, outpoint wire.OutPoint) int {
+	for i, input := range tx.TxIn {
+		if input.PreviousOutPoint == outpoint {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,272 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package payjoin
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+// ErrInvalidUTXO describes that receiver utxo can not be contributed.
+var ErrInvalidUTXO = errors.New("invalid receiver utxo")
+
+// maxBodySize defines maximum size of the original PSBT request body.
+const maxBodySize = 1 << 20
+
+// ContributeParams defines parameters for Contribute function.
+type ContributeParams struct {
+	Original      *psbt.Packet // finalized original PSBT received from the sender.
+	Params        Params       // sender parameters of the request.
+	UTXO          *bitcoin.UTXO
+	PubKey        string // hex encoded public key of the utxo owner.
+	PayeeScript   []byte // receiver payment output script pub key.
+	NetworkParams *chaincfg.Params
+	// DustAmount is the smallest amount in satoshi of the payment and fee outputs, optional,
+	// txbuilder default dust amount is used if not set.
+	DustAmount *big.Int
+}
+
+// Contribute validates original PSBT and returns payjoin proposal with the receiver utxo added as input
+// at the random position and the payment output increased by the utxo amount, returns receiver input index.
+// Receiver input fee is paid from the sender fee output up to the allowed contribution, which does not
+// make the fee output dust, the rest is paid from the payment output. Payment output pays extra fee if it is
+// needed to keep the proposal fee rate not lower than the sender minimum fee rate.
+// NOTE: Receiver input must be signed and finalized, then sender inputs data must be cleared by StripSenderInputs.
+func Contribute(params ContributeParams) (proposal *psbt.Packet, receiverInput int, err error) {
+	original := params.Original
+	if original == nil || original.UnsignedTx == nil {
+		return nil, 0, rejectOriginal("missing original psbt")
+	}
+
+	if err = original.SanityCheck(); err != nil {
+		return nil, 0, rejectOriginal("%s", err)
+	}
+
+	for i := range original.Inputs {
+		if !isFinalized(&original.Inputs[i]) || !hasUTXO(&original.Inputs[i]) {
+			return nil, 0, rejectOriginal("input %d is not finalized or has no utxo", i)
+		}
+	}
+
+	originalFee, err := fee(original)
+	if err != nil {
+		return nil, 0, rejectOriginal("%s", err)
+	}
+
+	originalWeight, err := txWeight(original)
+	if err != nil {
+		return nil, 0, rejectOriginal("%s", err)
+	}
+
+	payeeOutput := outputIndex(original.UnsignedTx, params.PayeeScript)
+	if payeeOutput < 0 {
+		return nil, 0, rejectOriginal("payment output not found")
+	}
+
+	feeOutput := -1
+	if index := params.Params.AdditionalFeeOutputIndex; index != nil {
+		if *index >= len(original.UnsignedTx.TxOut) || *index == payeeOutput {
+			return nil, 0, rejectOriginal("invalid additional fee output index %d", *index)
+		}
+
+		feeOutput = *index
+	}
+
+	if params.UTXO == nil || params.UTXO.Amount == nil {
+		return nil, 0, fmt.Errorf("%w: missing utxo", ErrInvalidUTXO)
+	}
+
+	utxoWeight := params.UTXO.WeightAsInput()
+	if utxoWeight == 0 {
+		return nil, 0, fmt.Errorf("%w: %s", ErrUnsupportedInput, params.UTXO.ScriptType())
+	}
+
+	inputBuilder, err := txbuilder.NewPSBTInputBuilder(params.PubKey, params.UTXO.Address, params.NetworkParams)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	outpoint := *params.UTXO.WireOutPoint()
+	if inputIndex(original.UnsignedTx, outpoint) >= 0 {
+		return nil, 0, fmt.Errorf("%w: utxo is already spent by the original", ErrInvalidUTXO)
+	}
+
+	receiverInput, err = randomIndex(len(original.UnsignedTx.TxIn) + 1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tx := original.UnsignedTx.Copy()
+	txIn := wire.NewTxIn(&outpoint, nil, nil)
+	txIn.Sequence = tx.TxIn[0].Sequence
+	tx.TxIn = append(tx.TxIn[:receiverInput], append([]*wire.TxIn{txIn}, tx.TxIn[receiverInput:]...)...)
+
+	// INFO: receiver input fee by the original fee rate, sender pays up to the maximum contribution.
+	additionalFee := new(big.Int).Mul(originalFee, big.NewInt(utxoWeight))
+	additionalFee.Div(additionalFee, big.NewInt(originalWeight))
+
+	dustAmount := params.DustAmount
+	if dustAmount == nil {
+		dustAmount = txbuilder.DefaultTxBuilderConfig().DustAmount
+	}
+
+	senderContribution := big.NewInt(0)
+	if feeOutput >= 0 {
+		// INFO: fee output is not reduced below the dust amount.
+		spendable := new(big.Int).Sub(big.NewInt(tx.TxOut[feeOutput].Value), dustAmount)
+		if spendable.Sign() > 0 {
+			senderContribution = bigMin(additionalFee, params.Params.maxAdditionalFeeContribution(), spendable)
+		}
+		tx.TxOut[feeOutput].Value -= senderContribution.Int64()
+	}
+
+	receiverFee := new(big.Int).Sub(additionalFee, senderContribution)
+	if minFeeRate := params.Params.MinSatoshiPerKVByte; minFeeRate != nil {
+		// INFO: ( weight / 4 ) vB * ( sat / kvB ) = 1000 sat, rounded up.
+		minFee := new(big.Int).Mul(minFeeRate, big.NewInt(originalWeight+utxoWeight))
+		minFee.Add(minFee, big.NewInt(3999))
+		minFee.Div(minFee, big.NewInt(4000))
+
+		proposalFee := new(big.Int).Add(originalFee, senderContribution)
+		proposalFee.Add(proposalFee, receiverFee)
+		if missing := new(big.Int).Sub(minFee, proposalFee); missing.Sign() > 0 {
+			receiverFee.Add(receiverFee, missing)
+		}
+	}
+
+	tx.TxOut[payeeOutput].Value += params.UTXO.Amount.Int64() - receiverFee.Int64()
+	if tx.TxOut[payeeOutput].Value < dustAmount.Int64() {
+		return nil, 0, &ReceiverError{Code: ErrorCodeNotEnoughMoney, Message: "utxo does not cover the input fee"}
+	}
+
+	proposal, err = psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i, input := range tx.TxIn {
+		if i == receiverInput {
+			continue
+		}
+
+		originalIndex := inputIndex(original.UnsignedTx, input.PreviousOutPoint)
+		proposal.Inputs[i] = original.Inputs[originalIndex]
+		txbuilder.ClearInputSignatures(&proposal.Inputs[i])
+	}
+
+	proposal.Inputs[receiverInput].WitnessUtxo = wire.NewTxOut(params.UTXO.Amount.Int64(), params.UTXO.Script)
+	inputBuilder.PrepareInput(&proposal.Inputs[receiverInput])
+
+	return proposal, receiverInput, nil
+}
+
+// StripSenderInputs clears sender inputs data of the signed proposal as required by the protocol.
+func StripSenderInputs(original, proposal *psbt.Packet) {
+	for i, input := range proposal.UnsignedTx.TxIn {
+		if inputIndex(original.UnsignedTx, input.PreviousOutPoint) >= 0 {
+			proposal.Inputs[i] = psbt.PInput{}
+		}
+	}
+}
+
+// ProposalFunc defines receiver function which returns signed proposal for the original PSBT.
+// Returned *ReceiverError is responded to the sender as is, other errors are responded as unavailable.
+type ProposalFunc func(original *psbt.Packet, params Params) (*psbt.Packet, error)
+
+// NewHandler returns payjoin receiver endpoint http handler.
+func NewHandler(proposalFunc ProposalFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		params, err := ParseParams(r.URL.Query())
+		if err != nil {
+			if !errors.Is(err, ErrReceiver) {
+				err = rejectOriginal("%s", err)
+			}
+
+			writeError(w, err)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			writeError(w, rejectOriginal("%s", err))
+			return
+		}
+
+		original, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(string(body))), true)
+		if err != nil {
+			writeError(w, rejectOriginal("%s", err))
+			return
+		}
+
+		proposal, err := proposalFunc(original, params)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		StripSenderInputs(original, proposal)
+
+		encoded, err := proposal.B64Encode()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(encoded))
+	})
+}
+
+// writeError writes receiver error response, unknown errors are responded as unavailable.
+func writeError(w http.ResponseWriter, err error) {
+	var receiverErr *ReceiverError
+	if !errors.As(err, &receiverErr) {
+		// INFO: internal error details are not exposed to the sender.
+		receiverErr = &ReceiverError{Code: ErrorCodeUnavailable, Message: "the payjoin endpoint is not available for now"}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(receiverErr)
+}
+
+// randomIndex returns random index in range [0, n).
+func randomIndex(n int) (int, error) {
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(index.Int64()), nil
+}
+
+// bigMin returns minimum of the values.
+func bigMin(values ...*big.Int) *big.Int {
+	minimum := values[0]
+	for _, value := range values[1:] {
+		if value.Cmp(minimum) < 0 {
+			minimum = value
+		}
+	}
+
+	return new(big.Int).Set(minimum)
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package payjoin
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrReceiver describes class of errors returned by payjoin receiver.
+var ErrReceiver = errors.New("payjoin receiver error")
+
+// ErrorCode defines well-known payjoin receiver error code.
+type ErrorCode string
+
+const (
+	// ErrorCodeUnavailable defines that receiver can not process the request for now.
+	ErrorCodeUnavailable ErrorCode = "unavailable"
+	// ErrorCodeNotEnoughMoney defines that receiver has no utxos to contribute.
+	ErrorCodeNotEnoughMoney ErrorCode = "not-enough-money"
+	// ErrorCodeVersionUnsupported defines that requested protocol version is not supported.
+	ErrorCodeVersionUnsupported ErrorCode = "version-unsupported"
+	// ErrorCodeOriginalPSBTRejected defines that original PSBT is rejected by the receiver.
+	ErrorCodeOriginalPSBTRejected ErrorCode = "original-psbt-rejected"
+)
+
+// ReceiverError is the error type to describe payjoin receiver error response.
+type ReceiverError struct {
+	Code      ErrorCode `json:"errorCode"`
+	Message   string    `json:"message"`
+	Supported []int     `json:"supported,omitempty"` // supported protocol versions, version-unsupported only.
+}
+
+// Error returns error description.
+func (e *ReceiverError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrReceiver, e.Code, e.Message)
+}
+
+// Is implements comparator method for [errors] package.
+func (e *ReceiverError) Is(target error) bool {
+	return target == ErrReceiver //nolint: errorlint
+}
+
+// rejectOriginal returns original-psbt-rejected receiver error.
+func rejectOriginal(format string, args ...any) *ReceiverError {
+	return &ReceiverError{Code: ErrorCodeOriginalPSBTRejected, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,275 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package payjoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+// ErrInvalidProposal describes that receiver proposal violates payjoin rules.
+var ErrInvalidProposal = errors.New("invalid payjoin proposal")
+
+// Send sends original finalized PSBT to the receiver payjoin endpoint and returns receiver proposal.
+// Receiver errors are returned as *ReceiverError.
+// NOTE: Proposal must be validated with ProcessProposal before signing.
+func Send(ctx context.Context, client *http.Client, endpoint string, original *psbt.Packet, params Params) (*psbt.Packet, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	query := endpointURL.Query()
+	for key, values := range params.Query() {
+		query[key] = values
+	}
+	endpointURL.RawQuery = query.Encode()
+
+	body, err := original.B64Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "text/plain")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		receiverErr := new(ReceiverError)
+		if err = json.Unmarshal(data, receiverErr); err != nil || receiverErr.Code == "" {
+			return nil, fmt.Errorf("%w: %s: %s", ErrReceiver, response.Status, bytes.TrimSpace(data))
+		}
+
+		return nil, receiverErr
+	}
+
+	return psbt.NewFromRawBytes(bytes.NewReader(bytes.TrimSpace(data)), true)
+}
+
+// ProcessProposal validates receiver proposal against the original finalized PSBT and sender parameters,
+// payeeScript is the script pub key of the original payment output.
+// Returns proposal with restored sender inputs data ready for signing and indexes of the sender inputs.
+func ProcessProposal(original, proposal *psbt.Packet, payeeScript []byte, params Params) (*psbt.Packet, []int, error) {
+	originalTx, proposalTx := original.UnsignedTx, proposal.UnsignedTx
+	if proposalTx.Version != originalTx.Version {
+		return nil, nil, fmt.Errorf("%w: version changed", ErrInvalidProposal)
+	}
+
+	if proposalTx.LockTime != originalTx.LockTime {
+		return nil, nil, fmt.Errorf("%w: lock time changed", ErrInvalidProposal)
+	}
+
+	if len(proposal.Inputs) != len(proposalTx.TxIn) || len(proposal.Outputs) != len(proposalTx.TxOut) {
+		return nil, nil, fmt.Errorf("%w: inputs or outputs count mismatch", ErrInvalidProposal)
+	}
+
+	senderInputs, err := checkProposalInputs(original, proposal)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	feeContribution, err := checkProposalOutputs(originalTx, proposalTx, payeeScript, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// INFO: sender inputs utxos are restored before fee calculation.
+	processed := &psbt.Packet{
+		UnsignedTx: proposalTx.Copy(),
+		Inputs:     make([]psbt.PInput, len(proposal.Inputs)),
+		Outputs:    make([]psbt.POutput, len(proposal.Outputs)),
+		Unknowns:   original.Unknowns,
+	}
+	copy(processed.Inputs, proposal.Inputs)
+
+	for _, index := range senderInputs {
+		originalIndex := inputIndex(originalTx, proposalTx.TxIn[index].PreviousOutPoint)
+		processed.Inputs[index] = original.Inputs[originalIndex]
+		txbuilder.ClearInputSignatures(&processed.Inputs[index])
+	}
+
+	for i, output := range proposalTx.TxOut {
+		if originalIndex := outputIndex(originalTx, output.PkScript); originalIndex >= 0 {
+			processed.Outputs[i] = original.Outputs[originalIndex]
+		}
+	}
+
+	if err = checkProposalFee(original, processed, feeContribution, params); err != nil {
+		return nil, nil, err
+	}
+
+	return processed, senderInputs, nil
+}
+
+// checkProposalInputs validates proposal inputs, returns proposal indexes of the sender inputs.
+func checkProposalInputs(original, proposal *psbt.Packet) ([]int, error) {
+	originalTx, proposalTx := original.UnsignedTx, proposal.UnsignedTx
+	senderInputs := make([]int, 0, len(originalTx.TxIn))
+	scriptTypes := make(map[bitcoin.ScriptType]struct{})
+
+	for i, input := range proposalTx.TxIn {
+		originalIndex := inputIndex(originalTx, input.PreviousOutPoint)
+		if originalIndex < 0 {
+			continue
+		}
+
+		if input.Sequence != originalTx.TxIn[originalIndex].Sequence {
+			return nil, fmt.Errorf("%w: sender input %d sequence changed", ErrInvalidProposal, i)
+		}
+
+		if isFinalized(&proposal.Inputs[i]) || hasUTXO(&proposal.Inputs[i]) {
+			return nil, fmt.Errorf("%w: sender input %d must not contain finalized or utxo data", ErrInvalidProposal, i)
+		}
+
+		utxo, err := inputUTXO(original, originalIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		scriptTypes[(&bitcoin.UTXO{Script: utxo.PkScript}).ScriptType()] = struct{}{}
+		senderInputs = append(senderInputs, i)
+	}
+
+	if len(senderInputs) != len(originalTx.TxIn) {
+		return nil, fmt.Errorf("%w: sender inputs missing", ErrInvalidProposal)
+	}
+
+	if len(senderInputs) == len(proposalTx.TxIn) {
+		return nil, fmt.Errorf("%w: receiver inputs missing", ErrInvalidProposal)
+	}
+
+	sequence := originalTx.TxIn[0].Sequence
+	for i, input := range proposalTx.TxIn {
+		if inputIndex(originalTx, input.PreviousOutPoint) >= 0 {
+			continue
+		}
+
+		if input.Sequence != sequence {
+			return nil, fmt.Errorf("%w: receiver input %d sequence mismatch", ErrInvalidProposal, i)
+		}
+
+		if !isFinalized(&proposal.Inputs[i]) {
+			return nil, fmt.Errorf("%w: receiver input %d is not finalized", ErrInvalidProposal, i)
+		}
+
+		utxo, err := inputUTXO(proposal, i)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProposal, err)
+		}
+
+		// INFO: receiver inputs must not reveal the payjoin by the script type if sender inputs are of a single type.
+		scriptType := (&bitcoin.UTXO{Script: utxo.PkScript}).ScriptType()
+		if _, ok := scriptTypes[scriptType]; !ok && len(scriptTypes) == 1 {
+			return nil, fmt.Errorf("%w: receiver input %d script type %s mismatch", ErrInvalidProposal, i, scriptType)
+		}
+	}
+
+	return senderInputs, nil
+}
+
+// checkProposalOutputs validates proposal outputs, returns amount the fee output is decreased by.
+func checkProposalOutputs(originalTx, proposalTx *wire.MsgTx, payeeScript []byte, params Params) (*big.Int, error) {
+	feeContribution := big.NewInt(0)
+	for i, output := range originalTx.TxOut {
+		isPayee := bytes.Equal(output.PkScript, payeeScript)
+
+		proposalIndex := outputIndex(proposalTx, output.PkScript)
+		if proposalIndex < 0 {
+			if isPayee && !params.DisableOutputSubstitution {
+				continue
+			}
+
+			return nil, fmt.Errorf("%w: output %d missing", ErrInvalidProposal, i)
+		}
+
+		proposalValue := proposalTx.TxOut[proposalIndex].Value
+		switch {
+		case isPayee:
+			if params.DisableOutputSubstitution && proposalValue < output.Value {
+				return nil, fmt.Errorf("%w: payee output decreased", ErrInvalidProposal)
+			}
+		case params.AdditionalFeeOutputIndex != nil && *params.AdditionalFeeOutputIndex == i:
+			if proposalValue < output.Value {
+				feeContribution.SetInt64(output.Value - proposalValue)
+			}
+		case proposalValue < output.Value:
+			return nil, fmt.Errorf("%w: output %d decreased", ErrInvalidProposal, i)
+		}
+	}
+
+	if feeContribution.Cmp(params.maxAdditionalFeeContribution()) > 0 {
+		return nil, fmt.Errorf("%w: fee contribution %s exceeds maximum", ErrInvalidProposal, feeContribution)
+	}
+
+	return feeContribution, nil
+}
+
+// checkProposalFee validates that sender fee contribution is spent on the fee and proposal fee rate.
+func checkProposalFee(original, proposal *psbt.Packet, feeContribution *big.Int, params Params) error {
+	originalFee, err := fee(original)
+	if err != nil {
+		return err
+	}
+
+	proposalFee, err := fee(proposal)
+	if err != nil {
+		return err
+	}
+
+	feeIncrease := new(big.Int).Sub(proposalFee, originalFee)
+	if feeIncrease.Sign() < 0 {
+		return fmt.Errorf("%w: fee decreased", ErrInvalidProposal)
+	}
+
+	if feeContribution.Cmp(feeIncrease) > 0 {
+		return fmt.Errorf("%w: fee contribution %s exceeds fee increase %s", ErrInvalidProposal, feeContribution, feeIncrease)
+	}
+
+	if params.MinSatoshiPerKVByte == nil {
+		return nil
+	}
+
+	weight, err := txWeight(proposal)
+	if err != nil {
+		return err
+	}
+
+	if !feeRateSatisfied(proposalFee, weight, params.MinSatoshiPerKVByte) {
+		return fmt.Errorf("%w: fee rate is lower than %s sat/kvB", ErrInvalidProposal, params.MinSatoshiPerKVByte)
+	}
+
+	return nil
+}
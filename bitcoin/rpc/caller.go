@@ -0,0 +1,16 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package rpc defines bitcoind JSON-RPC client used by the node backed packages,
+// e.g. validator, confirmations and zmq.
+package rpc
+
+import (
+	"context"
+)
+
+// Caller describes bitcoind JSON-RPC client, e.g. testharness.Harness.
+type Caller interface {
+	// Call calls RPC method and decodes its result into the result value, result may be nil.
+	Call(ctx context.Context, method string, result any, params ...any) error
+}
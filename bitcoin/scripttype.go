@@ -0,0 +1,115 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// ScriptType defines standard script pub key type.
+type ScriptType string
+
+const (
+	// ScriptTypeP2PK defines pay to public key script.
+	ScriptTypeP2PK ScriptType = "P2PK"
+	// ScriptTypeP2PKH defines pay to public key hash script.
+	ScriptTypeP2PKH ScriptType = "P2PKH"
+	// ScriptTypeP2SH defines pay to script hash script.
+	ScriptTypeP2SH ScriptType = "P2SH"
+	// ScriptTypeP2WPKH defines pay to witness public key hash script (segwit v0).
+	ScriptTypeP2WPKH ScriptType = "P2WPKH"
+	// ScriptTypeP2WSH defines pay to witness script hash script (segwit v0).
+	ScriptTypeP2WSH ScriptType = "P2WSH"
+	// ScriptTypeP2TR defines pay to taproot script (segwit v1).
+	ScriptTypeP2TR ScriptType = "P2TR"
+	// ScriptTypeP2A defines keyless pay to anchor script (segwit v1), spent with empty witness.
+	ScriptTypeP2A ScriptType = "P2A"
+	// ScriptTypeMultiSig defines bare multisig script.
+	ScriptTypeMultiSig ScriptType = "MULTISIG"
+	// ScriptTypeNullData defines provably unspendable OP_RETURN script.
+	ScriptTypeNullData ScriptType = "NULLDATA"
+	// ScriptTypeNonStandard defines any other script.
+	ScriptTypeNonStandard ScriptType = "NONSTANDARD"
+)
+
+// Input weight estimates in weight units of the standard single key spending,
+// non-witness data: outpoint (36) + script sig length (1) + script sig + sequence (4).
+// INFO: Witness data: items count + item length + signature [+ item length + compressed public key].
+const (
+	// p2trInputWeight defines key path spending with 65 bytes schnorr signature (non-default sighash type).
+	p2trInputWeight int64 = 41*4 + 1 + 1 + 65
+	// p2wpkhInputWeight defines spending with 72 bytes ecdsa signature.
+	p2wpkhInputWeight int64 = 41*4 + 1 + 1 + 72 + 1 + 33
+	// p2shP2WPKHInputWeight defines nested P2WPKH spending, script sig pushes 22 bytes witness program.
+	p2shP2WPKHInputWeight int64 = (41+23)*4 + 1 + 1 + 72 + 1 + 33
+	// p2pkhInputWeight defines spending with script sig of the signature and compressed public key pushes.
+	p2pkhInputWeight int64 = (41 + 1 + 72 + 1 + 33) * 4
+	// p2pkInputWeight defines spending with script sig of the signature push.
+	p2pkInputWeight int64 = (41 + 1 + 72) * 4
+	// p2aInputWeight defines anchor spending with empty witness of zero items.
+	p2aInputWeight int64 = 41*4 + 1
+)
+
+// p2aScript defines pay to anchor script: OP_1 OP_PUSHBYTES_2 0x4e73.
+var p2aScript = []byte{txscript.OP_1, txscript.OP_DATA_2, 0x4e, 0x73}
+
+// P2AScript returns pay to anchor (P2A) script pub key of the ephemeral anchor outputs.
+func P2AScript() []byte {
+	return bytes.Clone(p2aScript)
+}
+
+// IsP2AScript returns true if the script is pay to anchor (P2A) script pub key.
+func IsP2AScript(script []byte) bool {
+	return bytes.Equal(script, p2aScript)
+}
+
+// scriptTypesByClass defines script types by txscript classes.
+var scriptTypesByClass = map[txscript.ScriptClass]ScriptType{
+	txscript.PubKeyTy:              ScriptTypeP2PK,
+	txscript.PubKeyHashTy:          ScriptTypeP2PKH,
+	txscript.ScriptHashTy:          ScriptTypeP2SH,
+	txscript.WitnessV0PubKeyHashTy: ScriptTypeP2WPKH,
+	txscript.WitnessV0ScriptHashTy: ScriptTypeP2WSH,
+	txscript.WitnessV1TaprootTy:    ScriptTypeP2TR,
+	txscript.MultiSigTy:            ScriptTypeMultiSig,
+	txscript.NullDataTy:            ScriptTypeNullData,
+}
+
+// ScriptType returns type of the UTXO script pub key.
+func (utxo *UTXO) ScriptType() ScriptType {
+	if IsP2AScript(utxo.Script) {
+		return ScriptTypeP2A
+	}
+
+	if scriptType, ok := scriptTypesByClass[txscript.GetScriptClass(utxo.Script)]; ok {
+		return scriptType
+	}
+
+	return ScriptTypeNonStandard
+}
+
+// WeightAsInput returns estimated weight in weight units of the UTXO spending input.
+// P2SH is considered as nested P2WPKH, returns 0 if spending size can not be
+// estimated by the script only (P2WSH, bare multisig and non-standard scripts).
+// NOTE: Segwit marker and flag are not included, they are paid once per transaction.
+func (utxo *UTXO) WeightAsInput() int64 {
+	switch utxo.ScriptType() {
+	case ScriptTypeP2TR:
+		return p2trInputWeight
+	case ScriptTypeP2WPKH:
+		return p2wpkhInputWeight
+	case ScriptTypeP2SH:
+		return p2shP2WPKHInputWeight
+	case ScriptTypeP2PKH:
+		return p2pkhInputWeight
+	case ScriptTypeP2PK:
+		return p2pkInputWeight
+	case ScriptTypeP2A:
+		return p2aInputWeight
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+)
+
+func TestUTXOScriptType(t *testing.T) {
+	tests := []struct {
+		script     string
+		scriptType bitcoin.ScriptType
+		weight     int64
+	}{
+		{"5120abababababababababababababababababababababababababababababababab", bitcoin.ScriptTypeP2TR, 231},
+		{"0014cdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcd", bitcoin.ScriptTypeP2WPKH, 272},
+		{"0020abababababababababababababababababababababababababababababababab", bitcoin.ScriptTypeP2WSH, 0},
+		{"a914cdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcd87", bitcoin.ScriptTypeP2SH, 364},
+		{"76a914cdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcdcd88ac", bitcoin.ScriptTypeP2PKH, 592},
+		{"51024e73", bitcoin.ScriptTypeP2A, 165},
+		{"6a0401020304", bitcoin.ScriptTypeNullData, 0},
+		{"", bitcoin.ScriptTypeNonStandard, 0},
+	}
+	for _, test := range tests {
+		script, err := hex.DecodeString(test.script)
+		require.NoError(t, err)
+
+		utxo := bitcoin.UTXO{Script: script}
+		require.Equal(t, test.scriptType, utxo.ScriptType(), test.script)
+		require.Equal(t, test.weight, utxo.WeightAsInput(), test.script)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+var (
+	// ErrInvalidAdaptorSignature defines that adaptor signature is malformed or does not verify.
+	ErrInvalidAdaptorSignature = errors.New("invalid adaptor signature")
+	// ErrInvalidAdaptorSecret defines that adaptor secret is not the discrete logarithm of the adaptor point,
+	// or the signature is not adapted from the adaptor signature.
+	ErrInvalidAdaptorSecret = errors.New("invalid adaptor secret")
+)
+
+// AdaptorSignatureSize defines size of the serialized adaptor signature: compressed nonce point and scalar.
+const AdaptorSignatureSize = 65
+
+var (
+	// adaptorNonceTag defines tag of the adaptor signature nonce hash.
+	adaptorNonceTag = []byte("blockchain/adaptor/nonce")
+	// adaptorAuxTag defines tag of the adaptor signature aux randomness hash.
+	adaptorAuxTag = []byte("blockchain/adaptor/aux")
+)
+
+// AdaptorSignature defines Schnorr adaptor signature (pre-signature) of the message hash encrypted by
+// the adaptor point T: it is verifiable against T, but becomes valid BIP-340 signature only adapted with
+// the secret t (T = t*G), and revealing the adapted signature reveals t to the adaptor signature holder.
+// DLC contract execution transactions are signed with oracle outcome signature points as adaptor points,
+// PTLC hops are locked by the payment point tweaked per hop.
+type AdaptorSignature struct {
+	nonce btcec.PublicKey  // R = k*G + T, adapted signature nonce.
+	s     btcec.ModNScalar // s' = k + e*x, negated k if R has odd y.
+}
+
+// ParseAdaptorSignature parses serialized adaptor signature, see AdaptorSignature.Serialize.
+func ParseAdaptorSignature(serialized []byte) (*AdaptorSignature, error) {
+	if len(serialized) != AdaptorSignatureSize {
+		return nil, fmt.Errorf("%w: size %d", ErrInvalidAdaptorSignature, len(serialized))
+	}
+
+	nonce, err := btcec.ParsePubKey(serialized[:33])
+	if err != nil {
+		return nil, fmt.Errorf("%w: nonce: %w", ErrInvalidAdaptorSignature, err)
+	}
+
+	sig := &AdaptorSignature{nonce: *nonce}
+	if overflow := sig.s.SetByteSlice(serialized[33:]); overflow {
+		return nil, fmt.Errorf("%w: scalar overflow", ErrInvalidAdaptorSignature)
+	}
+
+	return sig, nil
+}
+
+// Serialize returns adaptor signature as 33 bytes compressed nonce point followed by 32 bytes scalar.
+func (sig *AdaptorSignature) Serialize() []byte {
+	s := sig.s.Bytes()
+
+	return append(sig.nonce.SerializeCompressed(), s[:]...)
+}
+
+// AdaptorSign returns adaptor signature of the 32 bytes message hash, e.g. taproot sighash, encrypted by
+// the adaptor point. Nonce is derived from the private key, the adaptor point and the message hash,
+// mixed with aux randomness if the Signer is configured with it, see WithAuxRand.
+func (signer *Signer) AdaptorSign(privateKey *btcec.PrivateKey, hash []byte, adaptorPoint *btcec.PublicKey) (*AdaptorSignature, error) {
+	switch {
+	case privateKey == nil:
+		return nil, ErrMissingPrivateKey
+	case len(hash) != chainhash.HashSize:
+		return nil, fmt.Errorf("%w: message hash size %d", ErrInvalidAdaptorSignature, len(hash))
+	case adaptorPoint == nil:
+		return nil, fmt.Errorf("%w: adaptor point is required", ErrInvalidAdaptorSignature)
+	}
+
+	// INFO: BIP-340 signs by the key with even y.
+	x := privateKey.Key
+	pubKey := privateKey.PubKey()
+	if pubKey.SerializeCompressed()[0] == 0x03 {
+		x.Negate()
+	}
+
+	var auxData [32]byte
+	if signer.auxRand != nil {
+		if _, err := io.ReadFull(signer.auxRand, auxData[:]); err != nil {
+			return nil, fmt.Errorf("aux randomness: %w", err)
+		}
+	}
+
+	secret := x.Bytes()
+	auxHash := chainhash.TaggedHash(adaptorAuxTag, auxData[:])
+	for i := range secret {
+		secret[i] ^= auxHash[i]
+	}
+
+	nonceHash := chainhash.TaggedHash(adaptorNonceTag, secret[:], adaptorPoint.SerializeCompressed(),
+		schnorr.SerializePubKey(pubKey), hash)
+
+	var k btcec.ModNScalar
+	k.SetBytes((*[32]byte)(nonceHash))
+	if k.IsZero() {
+		return nil, fmt.Errorf("%w: zero nonce", ErrInvalidAdaptorSignature)
+	}
+
+	var kG, t, r btcec.JacobianPoint
+	btcec.ScalarBaseMultNonConst(&k, &kG)
+	adaptorPoint.AsJacobian(&t)
+	btcec.AddNonConst(&kG, &t, &r)
+	if (r.X.IsZero() && r.Y.IsZero()) || r.Z.IsZero() {
+		return nil, fmt.Errorf("%w: nonce point is infinity", ErrInvalidAdaptorSignature)
+	}
+	r.ToAffine()
+
+	if r.Y.IsOdd() {
+		k.Negate()
+	}
+
+	e := challenge(&r.X, pubKey, hash)
+
+	sig := &AdaptorSignature{nonce: *btcec.NewPublicKey(&r.X, &r.Y)}
+	sig.s.Mul2(e, &x).Add(&k)
+
+	return sig, nil
+}
+
+// Verify returns ErrInvalidAdaptorSignature if the adaptor signature of the message hash by the public key
+// is not encrypted by the adaptor point, i.e. adapted signature would not be valid.
+func (sig *AdaptorSignature) Verify(hash []byte, pubKey, adaptorPoint *btcec.PublicKey) error {
+	if len(hash) != chainhash.HashSize || pubKey == nil || adaptorPoint == nil {
+		return fmt.Errorf("%w: invalid verification data", ErrInvalidAdaptorSignature)
+	}
+
+	// INFO: BIP-340 public key is x-only.
+	evenPubKey, err := schnorr.ParsePubKey(schnorr.SerializePubKey(pubKey))
+	if err != nil {
+		return err
+	}
+
+	var r, t, p, expected, actual btcec.JacobianPoint
+	sig.nonce.AsJacobian(&r)
+	adaptorPoint.AsJacobian(&t)
+	evenPubKey.AsJacobian(&p)
+
+	// INFO: s'*G = (R - T) + e*P, or (T - R) + e*P if R has odd y.
+	if r.Y.IsOdd() {
+		r.Y.Negate(1).Normalize()
+	} else {
+		t.Y.Negate(1).Normalize()
+	}
+	btcec.AddNonConst(&r, &t, &expected)
+
+	nonceX := r.X
+	nonceX.Normalize()
+	e := challenge(&nonceX, pubKey, hash)
+
+	var eP btcec.JacobianPoint
+	btcec.ScalarMultNonConst(e, &p, &eP)
+	btcec.AddNonConst(&expected, &eP, &expected)
+	btcec.ScalarBaseMultNonConst(&sig.s, &actual)
+
+	expected.ToAffine()
+	actual.ToAffine()
+	if !expected.X.Equals(&actual.X) || !expected.Y.Equals(&actual.Y) {
+		return ErrInvalidAdaptorSignature
+	}
+
+	return nil
+}
+
+// Adapt returns BIP-340 signature decrypted from the adaptor signature by the adaptor secret.
+// NOTE: Secret is not checked against the adaptor point, verify the returned signature before use.
+func (sig *AdaptorSignature) Adapt(secret *btcec.PrivateKey) (*schnorr.Signature, error) {
+	if secret == nil {
+		return nil, fmt.Errorf("%w: secret is required", ErrInvalidAdaptorSecret)
+	}
+
+	t := secret.Key
+	if sig.nonceOdd() {
+		t.Negate()
+	}
+
+	var s btcec.ModNScalar
+	s.Add2(&sig.s, &t)
+
+	var r btcec.FieldVal
+	r.SetByteSlice(sig.nonce.SerializeCompressed()[1:])
+
+	return schnorr.NewSignature(&r, &s), nil
+}
+
+// Extract returns adaptor secret from the signature adapted from the adaptor signature, e.g. published
+// in the spending transaction witness, and checks it against the adaptor point.
+func (sig *AdaptorSignature) Extract(signature *schnorr.Signature, adaptorPoint *btcec.PublicKey) (*btcec.PrivateKey, error) {
+	if signature == nil || adaptorPoint == nil {
+		return nil, fmt.Errorf("%w: signature and adaptor point are required", ErrInvalidAdaptorSecret)
+	}
+
+	serialized := signature.Serialize()
+	if [32]byte(serialized[:32]) != [32]byte(sig.nonce.SerializeCompressed()[1:]) {
+		return nil, fmt.Errorf("%w: signature nonce mismatch", ErrInvalidAdaptorSecret)
+	}
+
+	var s, t btcec.ModNScalar
+	s.SetByteSlice(serialized[32:])
+	t.Set(&sig.s).Negate().Add(&s)
+	if sig.nonceOdd() {
+		t.Negate()
+	}
+
+	secret := btcec.PrivKeyFromScalar(&t)
+	if !secret.PubKey().IsEqual(adaptorPoint) {
+		return nil, ErrInvalidAdaptorSecret
+	}
+
+	return secret, nil
+}
+
+// nonceOdd returns true if the adapted signature nonce point has odd y.
+func (sig *AdaptorSignature) nonceOdd() bool {
+	return sig.nonce.SerializeCompressed()[0] == 0x03
+}
+
+// SignaturePoint returns the point of the BIP-340 signature scalar the oracle will publish for the message hash
+// by its x-only public key and announced x-only nonce: S = R + e*P. It is used as the DLC outcome adaptor
+// point, the oracle attestation scalar is its secret.
+func SignaturePoint(oraclePubKey, oracleNonce []byte, hash []byte) (*btcec.PublicKey, error) {
+	pubKey, err := schnorr.ParsePubKey(oraclePubKey)
+	if err != nil {
+		return nil, fmt.Errorf("oracle public key: %w", err)
+	}
+
+	nonce, err := schnorr.ParsePubKey(oracleNonce)
+	if err != nil {
+		return nil, fmt.Errorf("oracle nonce: %w", err)
+	}
+
+	var nonceX btcec.FieldVal
+	nonceX.SetByteSlice(oracleNonce)
+	e := challenge(&nonceX, pubKey, hash)
+
+	var r, p, point btcec.JacobianPoint
+	nonce.AsJacobian(&r)
+	pubKey.AsJacobian(&p)
+	btcec.ScalarMultNonConst(e, &p, &point)
+	btcec.AddNonConst(&r, &point, &point)
+
+	return jacobianToPubKey(&point)
+}
+
+// TweakAdaptorPoint returns adaptor point tweaked by the scalar: T + tweak*G, e.g. PTLC hop decorrelation.
+// The tweaked point secret is the adaptor secret tweaked the same way, see TweakAdaptorSecret.
+func TweakAdaptorPoint(point *btcec.PublicKey, tweak *btcec.PrivateKey) (*btcec.PublicKey, error) {
+	var t, tweakG, result btcec.JacobianPoint
+	point.AsJacobian(&t)
+	btcec.ScalarBaseMultNonConst(&tweak.Key, &tweakG)
+	btcec.AddNonConst(&t, &tweakG, &result)
+
+	return jacobianToPubKey(&result)
+}
+
+// TweakAdaptorSecret returns adaptor secret tweaked by the scalar: t + tweak, see TweakAdaptorPoint.
+func TweakAdaptorSecret(secret, tweak *btcec.PrivateKey) (*btcec.PrivateKey, error) {
+	var t btcec.ModNScalar
+	t.Add2(&secret.Key, &tweak.Key)
+	if t.IsZero() {
+		return nil, fmt.Errorf("%w: tweaked secret is zero", ErrInvalidAdaptorSecret)
+	}
+
+	return btcec.PrivKeyFromScalar(&t), nil
+}
+
+// challenge returns BIP-340 challenge scalar: tagged hash of the nonce x, x-only public key and the message hash.
+func challenge(nonceX *btcec.FieldVal, pubKey *btcec.PublicKey, hash []byte) *btcec.ModNScalar {
+	nonceBytes := nonceX.Bytes()
+	commitment := chainhash.TaggedHash(chainhash.TagBIP0340Challenge, nonceBytes[:], schnorr.SerializePubKey(pubKey), hash)
+
+	var e btcec.ModNScalar
+	e.SetBytes((*[32]byte)(commitment))
+
+	return &e
+}
+
+// jacobianToPubKey returns affine public key of the point, error if it is the point at infinity.
+func jacobianToPubKey(point *btcec.JacobianPoint) (*btcec.PublicKey, error) {
+	if (point.X.IsZero() && point.Y.IsZero()) || point.Z.IsZero() {
+		return nil, fmt.Errorf("%w: point is infinity", ErrInvalidAdaptorSignature)
+	}
+	point.ToAffine()
+
+	return btcec.NewPublicKey(&point.X, &point.Y), nil
+}
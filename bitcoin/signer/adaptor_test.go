@@ -0,0 +1,152 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package signer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+)
+
+func TestAdaptorSignature(t *testing.T) {
+	s := signer.NewSigner(&chaincfg.MainNetParams)
+	hash := chainhash.HashB([]byte("contract execution transaction"))
+
+	newKey := func() *btcec.PrivateKey {
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		return key
+	}
+
+	t.Run("sign adapt extract", func(t *testing.T) {
+		// INFO: both key and nonce parities are covered by the multiple rounds.
+		for i := 0; i < 16; i++ {
+			privateKey, secret := newKey(), newKey()
+
+			adaptorSig, err := s.AdaptorSign(privateKey, hash, secret.PubKey())
+			require.NoError(t, err)
+			require.NoError(t, adaptorSig.Verify(hash, privateKey.PubKey(), secret.PubKey()))
+
+			parsed, err := signer.ParseAdaptorSignature(adaptorSig.Serialize())
+			require.NoError(t, err)
+			require.Equal(t, adaptorSig, parsed)
+
+			sig, err := adaptorSig.Adapt(secret)
+			require.NoError(t, err)
+			require.True(t, sig.Verify(hash, privateKey.PubKey()))
+
+			extracted, err := adaptorSig.Extract(sig, secret.PubKey())
+			require.NoError(t, err)
+			require.Equal(t, secret.Key, extracted.Key)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		privateKey, secret := newKey(), newKey()
+
+		adaptorSig, err := s.AdaptorSign(privateKey, hash, secret.PubKey())
+		require.NoError(t, err)
+
+		require.ErrorIs(t, adaptorSig.Verify(hash, newKey().PubKey(), secret.PubKey()), signer.ErrInvalidAdaptorSignature)
+		require.ErrorIs(t, adaptorSig.Verify(hash, privateKey.PubKey(), newKey().PubKey()), signer.ErrInvalidAdaptorSignature)
+		require.ErrorIs(t, adaptorSig.Verify(chainhash.HashB(hash), privateKey.PubKey(), secret.PubKey()),
+			signer.ErrInvalidAdaptorSignature)
+
+		sig, err := adaptorSig.Adapt(newKey())
+		require.NoError(t, err)
+		require.False(t, sig.Verify(hash, privateKey.PubKey()))
+
+		_, err = adaptorSig.Extract(sig, secret.PubKey())
+		require.ErrorIs(t, err, signer.ErrInvalidAdaptorSecret)
+
+		_, err = s.AdaptorSign(privateKey, hash[:31], secret.PubKey())
+		require.ErrorIs(t, err, signer.ErrInvalidAdaptorSignature)
+		_, err = s.AdaptorSign(nil, hash, secret.PubKey())
+		require.ErrorIs(t, err, signer.ErrMissingPrivateKey)
+
+		_, err = signer.ParseAdaptorSignature(adaptorSig.Serialize()[1:])
+		require.ErrorIs(t, err, signer.ErrInvalidAdaptorSignature)
+	})
+
+	t.Run("aux randomness", func(t *testing.T) {
+		privateKey, secret := newKey(), newKey()
+
+		first, err := s.AdaptorSign(privateKey, hash, secret.PubKey())
+		require.NoError(t, err)
+		second, err := s.AdaptorSign(privateKey, hash, secret.PubKey())
+		require.NoError(t, err)
+		require.Equal(t, first.Serialize(), second.Serialize())
+
+		randomized, err := signer.NewSigner(&chaincfg.MainNetParams, signer.WithAuxRand(bytes.NewReader(bytes.Repeat([]byte{1}, 32)))).
+			AdaptorSign(privateKey, hash, secret.PubKey())
+		require.NoError(t, err)
+		require.NotEqual(t, first.Serialize(), randomized.Serialize())
+		require.NoError(t, randomized.Verify(hash, privateKey.PubKey(), secret.PubKey()))
+	})
+
+	t.Run("oracle signature point", func(t *testing.T) {
+		oracleKey, oracleNonce := newKey(), newKey()
+		outcome := chainhash.HashB([]byte("outcome"))
+
+		point, err := signer.SignaturePoint(schnorr.SerializePubKey(oracleKey.PubKey()),
+			schnorr.SerializePubKey(oracleNonce.PubKey()), outcome)
+		require.NoError(t, err)
+
+		// INFO: oracle attestation is BIP-340 signature by the announced nonce, its scalar is the adaptor secret.
+		evenKey := func(key *btcec.PrivateKey) btcec.ModNScalar {
+			scalar := key.Key
+			if key.PubKey().SerializeCompressed()[0] == 0x03 {
+				scalar.Negate()
+			}
+
+			return scalar
+		}
+		challenge := chainhash.TaggedHash(chainhash.TagBIP0340Challenge, schnorr.SerializePubKey(oracleNonce.PubKey()),
+			schnorr.SerializePubKey(oracleKey.PubKey()), outcome)
+
+		var e, attestation btcec.ModNScalar
+		e.SetBytes((*[32]byte)(challenge))
+		x, k := evenKey(oracleKey), evenKey(oracleNonce)
+		attestation.Mul2(&e, &x).Add(&k)
+
+		attestationSecret := btcec.PrivKeyFromScalar(&attestation)
+		require.True(t, attestationSecret.PubKey().IsEqual(point))
+
+		privateKey := newKey()
+		adaptorSig, err := s.AdaptorSign(privateKey, hash, point)
+		require.NoError(t, err)
+
+		sig, err := adaptorSig.Adapt(attestationSecret)
+		require.NoError(t, err)
+		require.True(t, sig.Verify(hash, privateKey.PubKey()))
+
+		_, err = signer.SignaturePoint([]byte{1}, schnorr.SerializePubKey(oracleNonce.PubKey()), outcome)
+		require.Error(t, err)
+	})
+
+	t.Run("tweak", func(t *testing.T) {
+		secret, tweak := newKey(), newKey()
+
+		tweakedPoint, err := signer.TweakAdaptorPoint(secret.PubKey(), tweak)
+		require.NoError(t, err)
+		tweakedSecret, err := signer.TweakAdaptorSecret(secret, tweak)
+		require.NoError(t, err)
+		require.True(t, tweakedSecret.PubKey().IsEqual(tweakedPoint))
+
+		var negated btcec.ModNScalar
+		negated.Set(&secret.Key).Negate()
+		_, err = signer.TweakAdaptorSecret(secret, btcec.PrivKeyFromScalar(&negated))
+		require.ErrorIs(t, err, signer.ErrInvalidAdaptorSecret)
+		_, err = signer.TweakAdaptorPoint(secret.PubKey(), btcec.PrivKeyFromScalar(&negated))
+		require.ErrorIs(t, err, signer.ErrInvalidAdaptorSignature)
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+// ErrCannotFinalize describes that script path input signatures do not fit the leaf script.
+var ErrCannotFinalize = errors.New("input can not be finalized")
+
+// errPartiallySigned describes that script path input has valid, but not enough signatures to satisfy the leaf.
+var errPartiallySigned = fmt.Errorf("%w: not enough signatures", ErrCannotFinalize)
+
+// FinalizeTapscript finalizes taproot script path inputs by provided indexes, returns updated serialized PSBT.
+// Unlike psbt.Finalize, which pushes signatures in the order they were added to the input, witness stack
+// is assembled in the order required by the leaf script: signatures of the leaf keys in reverse order of
+// the keys in the script, since the first key is checked against the top stack item, with empty signatures
+// for the missing CHECKSIG and CHECKSIGADD keys, e.g. N-of-N or M-of-N CHECKSIGADD multisig.
+// CHECKSIGADD threshold (see utils.NewTaprootThresholdMultiSigLeafTapScript) is checked against signatures
+// count, signatures over the exact (OP_NUMEQUAL) threshold are replaced by the empty ones in the script order.
+func FinalizeTapscript(serializedPSBT []byte, inputs []int) ([]byte, error) {
+	packet, err := psbt.NewFromRawBytes(bytes.NewBuffer(serializedPSBT), false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, input := range inputs {
+		if input < 0 || len(packet.Inputs) <= input {
+			return nil, fmt.Errorf("%w: %d, inputs: %d", ErrInvalidInputIndex, input, len(packet.Inputs))
+		}
+
+		if err = finalizeTapscriptInput(&packet.Inputs[input]); err != nil {
+			return nil, fmt.Errorf("input %d: %w", input, err)
+		}
+	}
+
+	w := bytes.NewBuffer(nil)
+	if err = packet.Serialize(w); err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// finalizeTapscriptInput finalizes signed script path input with the witness stack in the leaf script keys order.
+func finalizeTapscriptInput(input *psbt.PInput) error {
+	if len(input.TaprootScriptSpendSig) == 0 {
+		return fmt.Errorf("%w: input is not signed by the script path", ErrCannotFinalize)
+	}
+
+	leafHash := input.TaprootScriptSpendSig[0].LeafHash
+	leaf, err := psbt.FindLeafScript(input, leafHash)
+	if err != nil {
+		return fmt.Errorf("%w: leaf script %x is not found", ErrCannotFinalize, leafHash)
+	}
+
+	signatures := make(map[string][]byte, len(input.TaprootScriptSpendSig))
+	for _, sig := range input.TaprootScriptSpendSig {
+		if !bytes.Equal(sig.LeafHash, leafHash) {
+			return fmt.Errorf("%w: signatures reference different leaves", ErrCannotFinalize)
+		}
+
+		signature := append([]byte{}, sig.Signature...)
+		if sig.SigHash != txscript.SigHashDefault {
+			signature = append(signature, byte(sig.SigHash))
+		}
+		signatures[string(sig.XOnlyPubKey)] = signature
+	}
+
+	script, err := utils.ParseLeafScript(leaf.Script)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCannotFinalize, err)
+	}
+	if len(script.Keys) == 0 {
+		return fmt.Errorf("%w: leaf script has no keys", ErrCannotFinalize)
+	}
+
+	// INFO: signatures over the exact threshold would fail OP_NUMEQUAL, so they are left empty.
+	skipped := make(map[int]bool)
+	for _, threshold := range script.Thresholds {
+		signed := 0
+		for i := threshold.From; i < threshold.To; i++ {
+			if _, ok := signatures[string(script.Keys[i].XOnlyPubKey)]; !ok {
+				continue
+			}
+
+			signed++
+			if threshold.Exact && signed > threshold.Required {
+				skipped[i] = true
+			}
+		}
+
+		if signed < threshold.Required {
+			return fmt.Errorf("%w: %d of %d signatures", errPartiallySigned, signed, threshold.Required)
+		}
+	}
+
+	witness := make(wire.TxWitness, 0, len(script.Keys)+2)
+	used := make(map[string]bool, len(signatures))
+	for i := len(script.Keys) - 1; i >= 0; i-- {
+		key := script.Keys[i]
+		signature, ok := signatures[string(key.XOnlyPubKey)]
+		if !ok && key.Opcode == txscript.OP_CHECKSIGVERIFY {
+			return fmt.Errorf("%w: signature of the key %x is required", errPartiallySigned, key.XOnlyPubKey)
+		}
+		used[string(key.XOnlyPubKey)] = true
+
+		// INFO: empty signature fails CHECKSIG and CHECKSIGADD without failing the script.
+		if skipped[i] {
+			signature = nil
+		}
+		witness = append(witness, signature)
+	}
+
+	for key := range signatures {
+		if !used[key] {
+			return fmt.Errorf("%w: key %x is not in the leaf script", ErrCannotFinalize, []byte(key))
+		}
+	}
+
+	return setFinalScriptWitness(input, append(witness, leaf.Script, leaf.ControlBlock))
+}
+
+// setFinalScriptWitness sets serialized final script witness of the input and clears the signing data.
+func setFinalScriptWitness(input *psbt.PInput, witness wire.TxWitness) error {
+	var buffer bytes.Buffer
+	if err := psbt.WriteTxWitness(&buffer, witness); err != nil {
+		return err
+	}
+
+	// INFO: finalizer clears the signing data (BIP-174), except the utxo.
+	*input = psbt.PInput{
+		WitnessUtxo:        input.WitnessUtxo,
+		NonWitnessUtxo:     input.NonWitnessUtxo,
+		FinalScriptWitness: buffer.Bytes(),
+		Unknowns:           input.Unknowns,
+	}
+
+	return nil
+}
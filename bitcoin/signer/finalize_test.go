@@ -0,0 +1,221 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package signer_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestFinalizeTapscript(t *testing.T) {
+	s := signer.NewSigner(&chaincfg.MainNetParams)
+
+	keys := make([]*btcec.PrivateKey, 3)
+	for i := range keys {
+		var err error
+		keys[i], err = btcec.NewPrivateKey()
+		require.NoError(t, err)
+	}
+	xOnly := func(i int) []byte { return schnorr.SerializePubKey(keys[i].PubKey()) }
+
+	// INFO: 3 of 3, exactly 2 of 3 and at least 2 of 3 tapscript multisig leaves.
+	allOf, err := utils.NewTaprootMultiSigLeafTapScript(keys[0].PubKey(), keys[1].PubKey(), keys[2].PubKey())
+	require.NoError(t, err)
+	twoOf, err := utils.NewTaprootThresholdMultiSigLeafTapScript(2, utils.ThresholdExact, keys[0].PubKey(),
+		keys[1].PubKey(), keys[2].PubKey())
+	require.NoError(t, err)
+	atLeastTwoOf, err := utils.NewTaprootThresholdMultiSigLeafTapScript(2, utils.ThresholdAtLeast, keys[0].PubKey(),
+		keys[1].PubKey(), keys[2].PubKey())
+	require.NoError(t, err)
+
+	// INFO: 2 of 2 with CHECKSIGVERIFY.
+	verify, err := txscript.NewScriptBuilder().
+		AddData(xOnly(0)).AddOp(txscript.OP_CHECKSIGVERIFY).
+		AddData(xOnly(1)).AddOp(txscript.OP_CHECKSIG).Script()
+	require.NoError(t, err)
+
+	leaves := []txscript.TapLeaf{allOf, twoOf, txscript.NewBaseTapLeaf(verify), atLeastTwoOf}
+	internalKey := utils.NUMSInternalKey()
+	scriptRoot := txscript.AssembleTaprootScriptTree(leaves...).RootNode.TapHash()
+	pkScript, err := txscript.PayToTaprootScript(utils.TweakedOutputKey(internalKey, scriptRoot[:]))
+	require.NoError(t, err)
+
+	newPSBT := func() []byte {
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+		tx.AddTxOut(wire.NewTxOut(9000, pkScript))
+
+		p, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+		p.Inputs[0].WitnessUtxo = wire.NewTxOut(10000, pkScript)
+
+		var serialized bytes.Buffer
+		require.NoError(t, p.Serialize(&serialized))
+
+		return serialized.Bytes()
+	}
+
+	sign := func(t *testing.T, serialized []byte, leaf int, signers ...int) []byte {
+		for _, key := range signers {
+			var err error
+			serialized, err = s.SignTapLeaf(signer.SignTapLeafParams{
+				SerializedPSBT: serialized,
+				Leaves:         leaves,
+				Leaf:           leaf,
+				InternalKey:    internalKey,
+				PrivateKey:     keys[key],
+			})
+			require.NoError(t, err)
+		}
+
+		return serialized
+	}
+
+	execute := func(t *testing.T, serialized []byte) error {
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(serialized), false)
+		require.NoError(t, err)
+
+		tx, err := psbt.Extract(p)
+		require.NoError(t, err)
+
+		prevOut := p.Inputs[0].WitnessUtxo
+		fetcher := txscript.NewCannedPrevOutputFetcher(prevOut.PkScript, prevOut.Value)
+		vm, err := txscript.NewEngine(prevOut.PkScript, tx, 0, txscript.StandardVerifyFlags, nil,
+			txscript.NewTxSigHashes(tx, fetcher), prevOut.Value, fetcher)
+		require.NoError(t, err)
+
+		return vm.Execute()
+	}
+
+	tests := []struct {
+		name    string
+		leaf    int
+		signers []int
+	}{
+		{name: "all of in order", leaf: 0, signers: []int{0, 1, 2}},
+		{name: "all of out of order", leaf: 0, signers: []int{2, 0, 1}},
+		{name: "two of", leaf: 1, signers: []int{2, 0}},
+		{name: "two of signed by all", leaf: 1, signers: []int{1, 2, 0}},
+		{name: "at least two of", leaf: 3, signers: []int{1, 2}},
+		{name: "at least two of signed by all", leaf: 3, signers: []int{2, 1, 0}},
+		{name: "checksigverify", leaf: 2, signers: []int{1, 0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signed := sign(t, newPSBT(), test.leaf, test.signers...)
+
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(signed), false)
+			require.NoError(t, err)
+			require.Len(t, p.Inputs[0].TaprootScriptSpendSig, len(test.signers))
+
+			finalized, err := signer.FinalizeTapscript(signed, []int{0})
+			require.NoError(t, err)
+			require.NoError(t, execute(t, finalized))
+		})
+	}
+
+	t.Run("resign", func(t *testing.T) {
+		signed := sign(t, newPSBT(), 0, 0, 1, 0, 2)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(signed), false)
+		require.NoError(t, err)
+		require.Len(t, p.Inputs[0].TaprootScriptSpendSig, 3)
+
+		// INFO: signatures of the other leaf are dropped.
+		signed = sign(t, signed, 1, 1)
+		p, err = psbt.NewFromRawBytes(bytes.NewReader(signed), false)
+		require.NoError(t, err)
+		require.Len(t, p.Inputs[0].TaprootScriptSpendSig, 1)
+	})
+
+	t.Run("placeholders", func(t *testing.T) {
+		finalized, err := signer.FinalizeTapscript(sign(t, newPSBT(), 1, 2, 1, 0), []int{0})
+		require.NoError(t, err)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(finalized), false)
+		require.NoError(t, err)
+		tx, err := psbt.Extract(p)
+		require.NoError(t, err)
+
+		// INFO: the first two keys of the script sign, the last key signature is replaced by the empty one.
+		witness := tx.TxIn[0].Witness
+		require.Len(t, witness, 5)
+		require.Empty(t, witness[0])
+		require.Len(t, witness[1], schnorr.SignatureSize)
+		require.Len(t, witness[2], schnorr.SignatureSize)
+	})
+
+	t.Run("not enough signatures", func(t *testing.T) {
+		for _, leaf := range []int{0, 1, 3} {
+			_, err := signer.FinalizeTapscript(sign(t, newPSBT(), leaf, 1), []int{0})
+			require.ErrorIs(t, err, signer.ErrCannotFinalize)
+		}
+
+		_, err := signer.FinalizeTapscript(sign(t, newPSBT(), 2, 1), []int{0})
+		require.ErrorIs(t, err, signer.ErrCannotFinalize)
+	})
+
+	t.Run("verify partially signed", func(t *testing.T) {
+		verifier := signer.NewSigner(&chaincfg.MainNetParams, signer.VerifySignatures())
+		signLeaf := func(serialized []byte, key int) ([]byte, error) {
+			return verifier.SignTapLeaf(signer.SignTapLeafParams{
+				SerializedPSBT: serialized,
+				Leaves:         leaves,
+				Leaf:           1,
+				InternalKey:    internalKey,
+				PrivateKey:     keys[key],
+			})
+		}
+
+		// INFO: the first co-signer of the 2 of 3 leaf can not satisfy the script yet.
+		signed, err := signLeaf(newPSBT(), 2)
+		require.NoError(t, err)
+
+		signed, err = signLeaf(signed, 0)
+		require.NoError(t, err)
+
+		finalized, err := signer.FinalizeTapscript(signed, []int{0})
+		require.NoError(t, err)
+		require.NoError(t, execute(t, finalized))
+
+		// INFO: invalid partial signature is rejected.
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(sign(t, newPSBT(), 1, 2)), false)
+		require.NoError(t, err)
+		p.Inputs[0].TaprootScriptSpendSig[0].Signature[0] ^= 1
+
+		var serialized bytes.Buffer
+		require.NoError(t, p.Serialize(&serialized))
+		_, err = signLeaf(serialized.Bytes(), 0)
+		require.ErrorIs(t, err, signer.ErrInvalidSignature)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := signer.FinalizeTapscript(newPSBT(), []int{0})
+		require.ErrorIs(t, err, signer.ErrCannotFinalize)
+
+		_, err = signer.FinalizeTapscript(newPSBT(), []int{1})
+		require.ErrorIs(t, err, signer.ErrInvalidInputIndex)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(sign(t, newPSBT(), 0, 0)), false)
+		require.NoError(t, err)
+		p.Inputs[0].TaprootScriptSpendSig[0].XOnlyPubKey = schnorr.SerializePubKey(internalKey)
+
+		var serialized bytes.Buffer
+		require.NoError(t, p.Serialize(&serialized))
+		_, err = signer.FinalizeTapscript(serialized.Bytes(), []int{0})
+		require.ErrorIs(t, err, signer.ErrCannotFinalize)
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package signer
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+// SignHTLCParams defines parameters for SignHTLCClaim and SignHTLCRefund methods.
+type SignHTLCParams struct {
+	SerializedPSBT []byte
+	Input          int // index of the input spending HTLC output.
+	HTLC           utils.HTLC
+	PrivateKey     *btcec.PrivateKey // recipient private key to claim, sender private key to refund.
+	Preimage       []byte            // swap secret, required to claim only.
+}
+
+// SignHTLCClaim signs the input spending HTLC output by the claim leaf script path and finalizes it
+// with the preimage witness, since PSBT has no field to carry it to the finalizer, returns updated
+// serialized PSBT. Once the transaction is broadcast the preimage is public, so the counterparty
+// chain HTLC is claimable by it.
+func (signer *Signer) SignHTLCClaim(params SignHTLCParams) ([]byte, error) {
+	if params.PrivateKey == nil {
+		return nil, ErrMissingPrivateKey
+	}
+
+	if err := params.HTLC.VerifyPreimage(params.Preimage); err != nil {
+		return nil, err
+	}
+
+	packet, err := parseInputPacket(params.SerializedPSBT, params.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = params.HTLC.VerifyOutput(packet.Inputs[params.Input].WitnessUtxo.PkScript); err != nil {
+		return nil, err
+	}
+
+	leaves, err := params.HTLC.TapLeaves()
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.signScriptPath(signScriptPathParams{
+		packet:      packet,
+		input:       params.Input,
+		leaf:        leaves[0],
+		leaves:      leaves,
+		internalKey: params.HTLC.TaprootInternalKey(),
+		privateKey:  params.PrivateKey,
+		preimage:    params.Preimage,
+	})
+}
+
+// SignHTLCRefund signs the input spending HTLC output by the refund leaf script path, returns updated
+// serialized PSBT. Transaction lock time should be not less than HTLC lock time of the same kind
+// (block height or timestamp) and the input sequence should enable lock time, i.e. be not final.
+func (signer *Signer) SignHTLCRefund(params SignHTLCParams) ([]byte, error) {
+	if params.PrivateKey == nil {
+		return nil, ErrMissingPrivateKey
+	}
+
+	packet, err := parseInputPacket(params.SerializedPSBT, params.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkRefundLockTime(packet.UnsignedTx, params.Input, params.HTLC.LockTime); err != nil {
+		return nil, err
+	}
+
+	if err = params.HTLC.VerifyOutput(packet.Inputs[params.Input].WitnessUtxo.PkScript); err != nil {
+		return nil, err
+	}
+
+	leaves, err := params.HTLC.TapLeaves()
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.signScriptPath(signScriptPathParams{
+		packet:      packet,
+		input:       params.Input,
+		leaf:        leaves[1],
+		leaves:      leaves,
+		internalKey: params.HTLC.TaprootInternalKey(),
+		privateKey:  params.PrivateKey,
+	})
+}
+
+// checkRefundLockTime returns ErrRefundLocked if the transaction lock time does not satisfy HTLC lock time.
+func checkRefundLockTime(tx *wire.MsgTx, input int, lockTime uint32) error {
+	switch {
+	case tx.TxIn[input].Sequence == wire.MaxTxInSequenceNum:
+		return fmt.Errorf("%w: input %d sequence is final, lock time is disabled", ErrRefundLocked, input)
+	case (tx.LockTime < txscript.LockTimeThreshold) != (lockTime < txscript.LockTimeThreshold),
+		tx.LockTime < lockTime:
+		return fmt.Errorf("%w: transaction lock time %d, htlc lock time %d", ErrRefundLocked, tx.LockTime, lockTime)
+	}
+
+	return nil
+}
+
+// finalizeWithPreimage finalizes signed script path input with the preimage pushed after the signature.
+func finalizeWithPreimage(input *psbt.PInput, preimage []byte) error {
+	if len(input.TaprootScriptSpendSig) != 1 {
+		return fmt.Errorf("%w: input is not signed by the script path", ErrInvalidSignature)
+	}
+
+	sig := input.TaprootScriptSpendSig[0]
+	leaf, err := psbt.FindLeafScript(input, sig.LeafHash)
+	if err != nil {
+		return fmt.Errorf("%w: signed leaf script is not found", ErrInvalidSignature)
+	}
+
+	signature := sig.Signature
+	if sig.SigHash != txscript.SigHashDefault {
+		signature = append(signature, byte(sig.SigHash))
+	}
+
+	return setFinalScriptWitness(input, wire.TxWitness{signature, preimage, leaf.Script, leaf.ControlBlock})
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package signer
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+// minRecoveryTxVersion defines minimal transaction version with relative lock time enforced (BIP-68).
+const minRecoveryTxVersion = 2
+
+// SignInscriptionRecoveryParams defines parameters for SignInscriptionRecovery method.
+type SignInscriptionRecoveryParams struct {
+	SerializedPSBT    []byte
+	Input             int // index of the input spending inscription commit output.
+	Inscription       *inscriptions.Inscription
+	InscriptionPubKey []byte // inscription commit public key, either compressed or x-only.
+	Recovery          inscriptions.RecoveryLeaf
+	PrivateKey        *btcec.PrivateKey // recovery leaf private key.
+}
+
+// SignInscriptionRevealWithRecovery is like SignInscriptionReveal, but for the commit output committing
+// to the script tree with the inscription and recovery leaves, see inscriptions.RecoveryCommitAddress.
+func (signer *Signer) SignInscriptionRevealWithRecovery(serializedPSBT []byte, inscription *inscriptions.Inscription,
+	recovery inscriptions.RecoveryLeaf, privateKey *btcec.PrivateKey) ([]byte, error) {
+	if privateKey == nil {
+		return nil, ErrMissingPrivateKey
+	}
+
+	packet, err := parseInputPacket(serializedPSBT, revealInput)
+	if err != nil {
+		return nil, err
+	}
+
+	xOnlyPubKey := schnorr.SerializePubKey(privateKey.PubKey())
+	if err = inscription.VerifyRecoveryCommitment(xOnlyPubKey, packet.Inputs[revealInput].WitnessUtxo.PkScript, recovery); err != nil {
+		return nil, err
+	}
+
+	leaves, err := inscription.RecoveryTapLeaves(xOnlyPubKey, recovery)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.signScriptPath(signScriptPathParams{
+		packet:      packet,
+		input:       revealInput,
+		leaf:        leaves[0],
+		leaves:      leaves,
+		internalKey: privateKey.PubKey(),
+		privateKey:  privateKey,
+	})
+}
+
+// SignInscriptionRecovery signs the input spending inscription commit output by the recovery leaf script path,
+// returns updated serialized PSBT. Transaction version should be at least 2 and the input sequence should
+// satisfy the recovery delay, see inscriptions.RecoveryLeaf.Sequence.
+func (signer *Signer) SignInscriptionRecovery(params SignInscriptionRecoveryParams) ([]byte, error) {
+	if params.PrivateKey == nil {
+		return nil, ErrMissingPrivateKey
+	}
+
+	packet, err := parseInputPacket(params.SerializedPSBT, params.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkRecoverySequence(packet.UnsignedTx, params.Input, params.Recovery); err != nil {
+		return nil, err
+	}
+
+	err = params.Inscription.VerifyRecoveryCommitment(params.InscriptionPubKey,
+		packet.Inputs[params.Input].WitnessUtxo.PkScript, params.Recovery)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves, err := params.Inscription.RecoveryTapLeaves(params.InscriptionPubKey, params.Recovery)
+	if err != nil {
+		return nil, err
+	}
+
+	internalKey, err := utils.ParseXOnlyPubKey(params.InscriptionPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.signScriptPath(signScriptPathParams{
+		packet:      packet,
+		input:       params.Input,
+		leaf:        leaves[1],
+		leaves:      leaves,
+		internalKey: internalKey,
+		privateKey:  params.PrivateKey,
+	})
+}
+
+// checkRecoverySequence returns ErrRecoveryLocked if the input relative lock time does not satisfy recovery delay.
+func checkRecoverySequence(tx *wire.MsgTx, input int, recovery inscriptions.RecoveryLeaf) error {
+	sequence := tx.TxIn[input].Sequence
+	switch {
+	case tx.Version < minRecoveryTxVersion:
+		return fmt.Errorf("%w: transaction version %d", ErrRecoveryLocked, tx.Version)
+	case sequence&wire.SequenceLockTimeDisabled != 0, sequence&wire.SequenceLockTimeIsSeconds != 0,
+		sequence&wire.SequenceLockTimeMask < recovery.Sequence():
+		return fmt.Errorf("%w: input %d sequence %d, delay %d blocks", ErrRecoveryLocked, input, sequence, recovery.Delay)
+	}
+
+	return nil
+}
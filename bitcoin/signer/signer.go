@@ -6,19 +6,63 @@ package signer
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"io"
+	"slices"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+var (
+	// ErrInvalidInputIndex defines that input index to sign is out of transaction inputs range.
+	ErrInvalidInputIndex = errors.New("invalid input index")
+	// ErrMissingWitnessUTXO defines that input to sign has no witness utxo data.
+	ErrMissingWitnessUTXO = errors.New("witness utxo is missing")
+	// ErrMissingPrivateKey defines that private key to sign with was not provided.
+	ErrMissingPrivateKey = errors.New("private key is required")
+	// ErrInvalidSignature defines that signed input failed script verification.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrLeafNotFound defines that witness script to sign is not a leaf of the script tree.
+	ErrLeafNotFound = errors.New("leaf is not found in the script tree")
+	// ErrRecoveryLocked defines that recovery input sequence does not satisfy recovery leaf delay.
+	ErrRecoveryLocked = errors.New("recovery is locked")
+	// ErrRefundLocked defines that refund transaction lock time does not satisfy HTLC lock time.
+	ErrRefundLocked = errors.New("refund is locked")
+	// ErrScriptTreeMismatch describes that input witness utxo is not the taproot output committing to the script tree.
+	ErrScriptTreeMismatch = errors.New("output does not commit to the script tree")
 )
 
+// revealInput defines index of the inscription reveal input.
+const revealInput = 0
+
 // SignTaprootParams defines parameters for SignTaproot method.
 type SignTaprootParams struct {
 	SerializedPSBT []byte
 	Inputs         []int // inputs indexes.
 	PrivateKey     *btcec.PrivateKey
+	// LeafHashes defines leaf to satisfy by the input index for the inputs with several leaf scripts,
+	// e.g. utils.TaprootPolicy leaves, optional, the first input leaf script is signed if not set.
+	LeafHashes map[int]chainhash.Hash
+}
+
+// SignTapLeafParams defines parameters for SignTapLeaf method.
+type SignTapLeafParams struct {
+	SerializedPSBT []byte
+	Input          int                // index of the input spending the script tree output.
+	Leaves         []txscript.TapLeaf // script tree leaves.
+	Leaf           int                // index of the leaf to spend.
+	InternalKey    *btcec.PublicKey   // taproot internal key.
+	PrivateKey     *btcec.PrivateKey  // leaf private key.
 }
 
 // signTaprootInputParams defines parameters for signTaprootInput method.
@@ -27,48 +71,113 @@ type signTaprootInputParams struct {
 	input        int
 	inputFetcher txscript.PrevOutputFetcher
 	privateKey   *btcec.PrivateKey
+	// leaves defines script tree leaves, single witness script leaf tree if not set.
+	leaves []txscript.TapLeaf
+	// internalKey defines taproot internal key for the control block, private key public key if not set.
+	internalKey *btcec.PublicKey
+	// leafHash defines input leaf script to sign, the first one if not set.
+	leafHash *chainhash.Hash
+}
+
+// signScriptPathParams defines parameters for signScriptPath method.
+type signScriptPathParams struct {
+	packet      *psbt.Packet
+	input       int
+	leaf        txscript.TapLeaf   // leaf to spend.
+	leaves      []txscript.TapLeaf // script tree leaves, single leaf tree if not set.
+	internalKey *btcec.PublicKey
+	privateKey  *btcec.PrivateKey
+	preimage    []byte // optional, hash lock preimage, signed input is finalized with it.
 }
 
 // Signer provides transaction signing related logic.
+// INFO: Schnorr signatures nonces are derived deterministically (RFC-6979) by default,
+// so the same PSBT signed by the same key results in the same signatures, see WithAuxRand.
 type Signer struct {
-	networkParams *chaincfg.Params
+	networkParams    *chaincfg.Params
+	verifySignatures bool
+	auxRand          io.Reader
+}
+
+// Option defines functional option to configure Signer.
+type Option func(signer *Signer)
+
+// VerifySignatures makes Signer execute scripts of the signed inputs before PSBT serialization,
+// signing fails with ErrInvalidSignature wrapping the script engine error if verification fails.
+// Partially signed script path inputs, e.g. by the first co-signer of the multisig leaf, are verified
+// by their signatures only, scripts are executed once the leaf signatures threshold is met.
+func VerifySignatures() Option {
+	return func(signer *Signer) {
+		signer.verifySignatures = true
+	}
+}
+
+// DeterministicNonces makes Signer derive Schnorr signatures nonces deterministically (RFC-6979),
+// it is the default behaviour, the option resets aux randomness source set by the previous options.
+func DeterministicNonces() Option {
+	return func(signer *Signer) {
+		signer.auxRand = nil
+	}
+}
+
+// WithAuxRand makes Signer derive Schnorr signatures nonces with 32 bytes of aux randomness read from
+// the source for each signature (BIP-340), e.g. crypto/rand.Reader. Seeded source makes signatures reproducible.
+// NOTE: Signing fails if the source can not provide aux randomness.
+func WithAuxRand(source io.Reader) Option {
+	return func(signer *Signer) {
+		signer.auxRand = source
+	}
 }
 
 // NewSigner is a constructor for Signer.
-func NewSigner(networkParams *chaincfg.Params) *Signer {
-	return &Signer{
+func NewSigner(networkParams *chaincfg.Params, opts ...Option) *Signer {
+	signer := &Signer{
 		networkParams: networkParams,
 	}
+	for _, opt := range opts {
+		opt(signer)
+	}
+
+	return signer
 }
 
 // SignTaproot signs taproot inputs by provided indexes, returns updated serialized PSBT.
 func (signer *Signer) SignTaproot(params SignTaprootParams) ([]byte, error) {
+	if params.PrivateKey == nil {
+		return nil, ErrMissingPrivateKey
+	}
+
 	packet, err := psbt.NewFromRawBytes(bytes.NewBuffer(params.SerializedPSBT), false)
 	if err != nil {
 		return nil, err
 	}
 
-	var (
-		tx                   = packet.UnsignedTx
-		prevOutputFetcherMap = make(map[wire.OutPoint]*wire.TxOut, len(tx.TxIn))
-	)
-	for idx, in := range packet.Inputs {
-		prevOutputFetcherMap[tx.TxIn[idx].PreviousOutPoint] = in.WitnessUtxo
-	}
-
-	var prevOutputFetcher = txscript.NewMultiPrevOutFetcher(prevOutputFetcherMap)
+	var prevOutputFetcher = newPrevOutputFetcher(packet)
 	for _, input := range params.Inputs {
-		if len(packet.Inputs) <= input {
-			return nil, errors.New("invalid input index")
+		if input < 0 || len(packet.Inputs) <= input {
+			return nil, fmt.Errorf("%w: %d, inputs: %d", ErrInvalidInputIndex, input, len(packet.Inputs))
+		}
+		if packet.Inputs[input].WitnessUtxo == nil {
+			return nil, fmt.Errorf("%w: input %d", ErrMissingWitnessUTXO, input)
 		}
 
-		err = signer.signTaprootInput(signTaprootInputParams{
+		inputParams := signTaprootInputParams{
 			packet:       packet,
 			input:        input,
 			inputFetcher: prevOutputFetcher,
 			privateKey:   params.PrivateKey,
-		})
-		if err != nil {
+		}
+		if leafHash, ok := params.LeafHashes[input]; ok {
+			inputParams.leafHash = &leafHash
+		}
+
+		if err = signer.signTaprootInput(inputParams); err != nil {
+			return nil, err
+		}
+	}
+
+	if signer.verifySignatures {
+		if err = verifyInputs(packet, params.Inputs); err != nil {
 			return nil, err
 		}
 	}
@@ -82,7 +191,239 @@ func (signer *Signer) SignTaproot(params SignTaprootParams) ([]byte, error) {
 	return w.Bytes(), nil
 }
 
-// signTaprootInput signs taproot input with or without witness script.
+// SignInscriptionReveal signs the first input of the reveal transaction spending inscription commit output
+// by the inscription script path, returns updated serialized PSBT. Taproot internal key and witness script
+// are derived from the inscription and the private key, commit output should be set as input witness utxo.
+func (signer *Signer) SignInscriptionReveal(serializedPSBT []byte, inscription *inscriptions.Inscription,
+	privateKey *btcec.PrivateKey) ([]byte, error) {
+	if privateKey == nil {
+		return nil, ErrMissingPrivateKey
+	}
+
+	packet, err := parseInputPacket(serializedPSBT, revealInput)
+	if err != nil {
+		return nil, err
+	}
+
+	xOnlyPubKey := schnorr.SerializePubKey(privateKey.PubKey())
+	if err = inscription.VerifyCommitment(xOnlyPubKey, packet.Inputs[revealInput].WitnessUtxo.PkScript); err != nil {
+		return nil, err
+	}
+
+	witnessScript, err := inscription.IntoScriptForWitness(xOnlyPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.signScriptPath(signScriptPathParams{
+		packet:      packet,
+		input:       revealInput,
+		leaf:        txscript.NewBaseTapLeaf(witnessScript),
+		internalKey: privateKey.PubKey(),
+		privateKey:  privateKey,
+	})
+}
+
+// SignTapLeaf signs the input by the leaf script path of the script tree, returns updated serialized PSBT.
+// Input witness utxo should be the taproot output of the internal key committing to the script tree,
+// leaf script conditions other than the signature, e.g. lock times, are not checked.
+func (signer *Signer) SignTapLeaf(params SignTapLeafParams) ([]byte, error) {
+	if params.PrivateKey == nil {
+		return nil, ErrMissingPrivateKey
+	}
+	if params.Leaf < 0 || len(params.Leaves) <= params.Leaf {
+		return nil, fmt.Errorf("%w: leaf %d, leaves: %d", ErrLeafNotFound, params.Leaf, len(params.Leaves))
+	}
+	if params.InternalKey == nil {
+		return nil, fmt.Errorf("%w: internal key is required", ErrScriptTreeMismatch)
+	}
+
+	packet, err := parseInputPacket(params.SerializedPSBT, params.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	pkScript := packet.Inputs[params.Input].WitnessUtxo.PkScript
+	scriptRoot := txscript.AssembleTaprootScriptTree(params.Leaves...).RootNode.TapHash()
+	if !txscript.IsPayToTaproot(pkScript) || !utils.VerifyTweak(params.InternalKey, pkScript[2:], scriptRoot[:]) {
+		return nil, fmt.Errorf("%w: input %d", ErrScriptTreeMismatch, params.Input)
+	}
+
+	return signer.signScriptPath(signScriptPathParams{
+		packet:      packet,
+		input:       params.Input,
+		leaf:        params.Leaves[params.Leaf],
+		leaves:      params.Leaves,
+		internalKey: params.InternalKey,
+		privateKey:  params.PrivateKey,
+	})
+}
+
+// signScriptPath signs input by the leaf script path, returns updated serialized PSBT.
+func (signer *Signer) signScriptPath(params signScriptPathParams) ([]byte, error) {
+	input := &params.packet.Inputs[params.input]
+	input.TaprootInternalKey = schnorr.SerializePubKey(params.internalKey)
+	input.WitnessScript = params.leaf.Script
+
+	err := signer.signTaprootInput(signTaprootInputParams{
+		packet:       params.packet,
+		input:        params.input,
+		inputFetcher: newPrevOutputFetcher(params.packet),
+		privateKey:   params.privateKey,
+		leaves:       params.leaves,
+		internalKey:  params.internalKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(params.preimage) != 0 {
+		if err = finalizeWithPreimage(input, params.preimage); err != nil {
+			return nil, err
+		}
+	}
+
+	if signer.verifySignatures {
+		if err = verifyInputs(params.packet, []int{params.input}); err != nil {
+			return nil, err
+		}
+	}
+
+	w := bytes.NewBuffer(nil)
+	if err = params.packet.Serialize(w); err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// parseInputPacket parses PSBT and checks that the input exists and has witness utxo.
+func parseInputPacket(serializedPSBT []byte, input int) (*psbt.Packet, error) {
+	packet, err := psbt.NewFromRawBytes(bytes.NewBuffer(serializedPSBT), false)
+	if err != nil {
+		return nil, err
+	}
+
+	if input < 0 || len(packet.Inputs) <= input {
+		return nil, fmt.Errorf("%w: %d, inputs: %d", ErrInvalidInputIndex, input, len(packet.Inputs))
+	}
+	if packet.Inputs[input].WitnessUtxo == nil {
+		return nil, fmt.Errorf("%w: input %d", ErrMissingWitnessUTXO, input)
+	}
+
+	return packet, nil
+}
+
+// newPrevOutputFetcher returns previous outputs fetcher by inputs witness utxos.
+func newPrevOutputFetcher(packet *psbt.Packet) txscript.PrevOutputFetcher {
+	prevOutputFetcherMap := make(map[wire.OutPoint]*wire.TxOut, len(packet.UnsignedTx.TxIn))
+	for idx, in := range packet.Inputs {
+		prevOutputFetcherMap[packet.UnsignedTx.TxIn[idx].PreviousOutPoint] = in.WitnessUtxo
+	}
+
+	return txscript.NewMultiPrevOutFetcher(prevOutputFetcherMap)
+}
+
+// verifyInputs executes scripts of the signed inputs with witnesses of the finalized packet copy,
+// returns ErrInvalidSignature wrapping the script engine error for the first failed input.
+// Script path signatures are verified one by one, so partially signed inputs, e.g. by the first
+// co-signer of the multisig leaf, are checked without the script execution until threshold is met.
+func verifyInputs(packet *psbt.Packet, inputs []int) error {
+	// INFO: finalization is applied to the copy, signed PSBT is returned not finalized.
+	w := bytes.NewBuffer(nil)
+	if err := packet.Serialize(w); err != nil {
+		return err
+	}
+
+	finalized, err := psbt.NewFromRawBytes(w, false)
+	if err != nil {
+		return err
+	}
+
+	// INFO: signature hashes do not commit to the witnesses, so they are calculated before finalization.
+	tx := finalized.UnsignedTx.Copy()
+	prevOutputFetcher := newPrevOutputFetcher(finalized)
+	sigHashes := txscript.NewTxSigHashes(tx, prevOutputFetcher)
+
+	executed := make([]int, 0, len(inputs))
+	for _, input := range inputs {
+		// INFO: inputs finalized by the signer, e.g. HTLC claim, are verified as is.
+		switch {
+		case len(finalized.Inputs[input].FinalScriptWitness) != 0:
+		case len(finalized.Inputs[input].TaprootScriptSpendSig) != 0:
+			err = verifyScriptSpendSigs(&finalized.Inputs[input], tx, input, sigHashes, prevOutputFetcher)
+			if err == nil {
+				err = finalizeTapscriptInput(&finalized.Inputs[input])
+			}
+			if errors.Is(err, errPartiallySigned) {
+				continue
+			}
+		default:
+			err = psbt.Finalize(finalized, input)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: input %d: %w", ErrInvalidSignature, input, err)
+		}
+
+		tx.TxIn[input].SignatureScript = finalized.Inputs[input].FinalScriptSig
+		tx.TxIn[input].Witness, err = bitcoin.ParseWitness(finalized.Inputs[input].FinalScriptWitness)
+		if err != nil {
+			return fmt.Errorf("%w: input %d: %w", ErrInvalidSignature, input, err)
+		}
+		executed = append(executed, input)
+	}
+
+	for _, input := range executed {
+		prevOut := finalized.Inputs[input].WitnessUtxo
+
+		vm, err := txscript.NewEngine(prevOut.PkScript, tx, input, txscript.StandardVerifyFlags,
+			nil, sigHashes, prevOut.Value, prevOutputFetcher)
+		if err == nil {
+			err = vm.Execute()
+		}
+		if err != nil {
+			return fmt.Errorf("%w: input %d: %w", ErrInvalidSignature, input, err)
+		}
+	}
+
+	return nil
+}
+
+// verifyScriptSpendSigs verifies each script path signature of the input against tapscript signature hash of its leaf.
+func verifyScriptSpendSigs(input *psbt.PInput, tx *wire.MsgTx, index int, sigHashes *txscript.TxSigHashes,
+	prevOutputFetcher txscript.PrevOutputFetcher) error {
+	for _, sig := range input.TaprootScriptSpendSig {
+		leaf, err := psbt.FindLeafScript(input, sig.LeafHash)
+		if err != nil {
+			return fmt.Errorf("%w: %x", ErrLeafNotFound, sig.LeafHash)
+		}
+
+		hash, err := txscript.CalcTapscriptSignaturehash(sigHashes, sig.SigHash, tx, index, prevOutputFetcher,
+			txscript.NewTapLeaf(leaf.LeafVersion, leaf.Script))
+		if err != nil {
+			return err
+		}
+
+		signature, err := schnorr.ParseSignature(sig.Signature)
+		if err != nil {
+			return err
+		}
+
+		pubKey, err := schnorr.ParsePubKey(sig.XOnlyPubKey)
+		if err != nil {
+			return err
+		}
+
+		if !signature.Verify(hash, pubKey) {
+			return fmt.Errorf("signature of the key %x does not match leaf %x", sig.XOnlyPubKey, sig.LeafHash)
+		}
+	}
+
+	return nil
+}
+
+// signTaprootInput signs taproot input by the key path, or by the script path if witness script
+// or leaf script with control block is set.
 func (signer *Signer) signTaprootInput(params signTaprootInputParams) error {
 	var (
 		input       = &params.packet.Inputs[params.input]
@@ -90,60 +431,143 @@ func (signer *Signer) signTaprootInput(params signTaprootInputParams) error {
 		value       = input.WitnessUtxo.Value
 		pkScript    = input.WitnessUtxo.PkScript
 		sigHashType = input.SighashType
-		witness     wire.TxWitness
 		err         error
 	)
 
-	if len(input.WitnessScript) != 0 {
-		var (
-			tapLeaf        = txscript.NewBaseTapLeaf(input.WitnessScript)
-			tapScriptTree  = txscript.AssembleTaprootScriptTree(tapLeaf)
-			ctrlBlock      = tapScriptTree.LeafMerkleProofs[0].ToControlBlock(params.privateKey.PubKey())
-			ctrlBlockBytes []byte
-			sig            []byte
-			leafHash       = tapLeaf.TapHash()
-		)
-
-		ctrlBlockBytes, err = ctrlBlock.ToBytes()
+	prevOutputFetcher := txscript.NewCannedPrevOutputFetcher(pkScript, value)
+
+	if len(input.WitnessScript) == 0 && len(input.TaprootLeafScript) == 0 && params.leafHash == nil {
+		sigHash, err := txscript.CalcTaprootSignatureHash(sigHashes, sigHashType, params.packet.UnsignedTx,
+			params.input, prevOutputFetcher)
 		if err != nil {
 			return err
 		}
 
-		sig, err = txscript.RawTxInTapscriptSignature(
-			params.packet.UnsignedTx, sigHashes, params.input,
-			value, pkScript, tapLeaf, sigHashType, params.privateKey,
-		)
+		// INFO: merkle root is set for the outputs with script tree, key is tweaked without it otherwise (BIP-86).
+		privateKey := txscript.TweakTaprootPrivKey(*params.privateKey, input.TaprootMerkleRoot)
+		input.TaprootKeySpendSig, err = signer.signSchnorr(privateKey, sigHash, sigHashType)
+
+		return err
+	}
+
+	var (
+		tapLeaf        txscript.TapLeaf
+		ctrlBlockBytes []byte
+		sig            []byte
+	)
+	if len(input.WitnessScript) != 0 {
+		tapLeaf = txscript.NewBaseTapLeaf(input.WitnessScript)
+		ctrlBlockBytes, err = controlBlock(tapLeaf, params)
 		if err != nil {
 			return err
 		}
-
-		if len(sig) > 64 {
-			sig = sig[:64]
+	} else {
+		// INFO: leaf script with control block is prepared by the transaction builder.
+		leafScript, err := selectLeafScript(input, params.leafHash)
+		if err != nil {
+			return fmt.Errorf("input %d: %w", params.input, err)
 		}
-		input.TaprootScriptSpendSig = []*psbt.TaprootScriptSpendSig{{
-			XOnlyPubKey: params.privateKey.PubKey().SerializeCompressed()[1:],
-			LeafHash:    leafHash.CloneBytes(),
-			Signature:   sig,
-			SigHash:     sigHashType,
-		}}
 
-		input.TaprootLeafScript = []*psbt.TaprootTapLeafScript{{
+		tapLeaf = txscript.NewTapLeaf(leafScript.LeafVersion, leafScript.Script)
+		ctrlBlockBytes = leafScript.ControlBlock
+	}
+
+	sigHash, err := txscript.CalcTapscriptSignaturehash(sigHashes, sigHashType, params.packet.UnsignedTx,
+		params.input, prevOutputFetcher, tapLeaf)
+	if err != nil {
+		return err
+	}
+
+	// INFO: sighash type is stored separately, see psbt.TaprootScriptSpendSig.
+	sig, err = signer.signSchnorr(params.privateKey, sigHash, txscript.SigHashDefault)
+	if err != nil {
+		return err
+	}
+
+	// INFO: signatures of the other keys of the same leaf are kept for the multisig leaves, see FinalizeTapscript.
+	leafHash := tapLeaf.TapHash()
+	xOnlyPubKey := schnorr.SerializePubKey(params.privateKey.PubKey())
+	input.TaprootScriptSpendSig = slices.DeleteFunc(input.TaprootScriptSpendSig, func(sig *psbt.TaprootScriptSpendSig) bool {
+		return !bytes.Equal(sig.LeafHash, leafHash[:]) || bytes.Equal(sig.XOnlyPubKey, xOnlyPubKey)
+	})
+	input.TaprootScriptSpendSig = append(input.TaprootScriptSpendSig, &psbt.TaprootScriptSpendSig{
+		XOnlyPubKey: xOnlyPubKey,
+		LeafHash:    leafHash.CloneBytes(),
+		Signature:   sig,
+		SigHash:     sigHashType,
+	})
+
+	// INFO: the other leaf scripts are kept, so the next co-signers could select a different leaf.
+	if _, err = psbt.FindLeafScript(input, leafHash[:]); err != nil {
+		input.TaprootLeafScript = append(input.TaprootLeafScript, &psbt.TaprootTapLeafScript{
 			ControlBlock: ctrlBlockBytes,
 			Script:       tapLeaf.Script,
 			LeafVersion:  tapLeaf.LeafVersion,
-		}}
+		})
+	}
+
+	return nil
+}
+
+// selectLeafScript returns input leaf script with the leaf hash, the first one if leaf hash is not set.
+func selectLeafScript(input *psbt.PInput, leafHash *chainhash.Hash) (*psbt.TaprootTapLeafScript, error) {
+	if leafHash == nil {
+		return input.TaprootLeafScript[0], nil
+	}
+
+	for _, leafScript := range input.TaprootLeafScript {
+		if txscript.NewTapLeaf(leafScript.LeafVersion, leafScript.Script).TapHash() == *leafHash {
+			return leafScript, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: leaf %s", ErrLeafNotFound, leafHash)
+}
 
-		return nil
+// signSchnorr returns Schnorr signature of the sighash with sighash type appended if it is not default.
+func (signer *Signer) signSchnorr(privateKey *btcec.PrivateKey, sigHash []byte, sigHashType txscript.SigHashType) ([]byte, error) {
+	var opts []schnorr.SignOption
+	if signer.auxRand != nil {
+		var auxData [32]byte
+		if _, err := io.ReadFull(signer.auxRand, auxData[:]); err != nil {
+			return nil, fmt.Errorf("aux randomness: %w", err)
+		}
+
+		opts = append(opts, schnorr.CustomNonce(auxData))
 	}
 
-	witness, err = txscript.TaprootWitnessSignature(
-		params.packet.UnsignedTx, sigHashes, params.input,
-		value, pkScript, sigHashType, params.privateKey)
+	signature, err := schnorr.Sign(privateKey, sigHash, opts...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	input.TaprootKeySpendSig = witness[0]
+	sig := signature.Serialize()
+	if sigHashType != txscript.SigHashDefault {
+		sig = append(sig, byte(sigHashType))
+	}
 
-	return nil
+	return sig, nil
+}
+
+// controlBlock returns serialized control block of the leaf in the script tree of the params.
+func controlBlock(tapLeaf txscript.TapLeaf, params signTaprootInputParams) ([]byte, error) {
+	leaves := params.leaves
+	if len(leaves) == 0 {
+		leaves = []txscript.TapLeaf{tapLeaf}
+	}
+
+	internalKey := params.internalKey
+	if internalKey == nil {
+		internalKey = params.privateKey.PubKey()
+	}
+
+	tapScriptTree := txscript.AssembleTaprootScriptTree(leaves...)
+	leafIndex, ok := tapScriptTree.LeafProofIndex[tapLeaf.TapHash()]
+	if !ok {
+		return nil, fmt.Errorf("%w: input %d", ErrLeafNotFound, params.input)
+	}
+
+	ctrlBlock := tapScriptTree.LeafMerkleProofs[leafIndex].ToControlBlock(internalKey)
+
+	return ctrlBlock.ToBytes()
 }
@@ -5,11 +5,12 @@ package signer_test
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
+	"io"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec/v2"
-	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -21,6 +22,7 @@ import (
 	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
 	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
 	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
 )
 
 func TestSigner(t *testing.T) {
@@ -88,9 +90,54 @@ func TestSigner(t *testing.T) {
 		require.NoError(t, vm.Execute())
 	})
 
+	t.Run("inscription reveal", func(t *testing.T) {
+		rr, _ := runes.NewRuneFromString("HELLO")
+		insc := &inscriptions.Inscription{Rune: rr, Body: make([]byte, 21)}
+
+		inscriptionAddrStr, err := insc.IntoAddress(hex.EncodeToString(pubKey.SerializeCompressed()), &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		inscriptionAddr, err := btcutil.DecodeAddress(inscriptionAddrStr, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		inscriptionAddrScript, err := txscript.PayToAddrScript(inscriptionAddr)
+		require.NoError(t, err)
+
+		packet, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+
+		packet.Inputs[0].WitnessUtxo = wire.NewTxOut(43000, inscriptionAddrScript)
+
+		packetBytes := bytes.NewBuffer(nil)
+		require.NoError(t, packet.Serialize(packetBytes))
+
+		signedPSBTBytes, err := s.SignInscriptionReveal(packetBytes.Bytes(), insc, privKey)
+		require.NoError(t, err)
+
+		signedPSBT, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBTBytes), false)
+		require.NoError(t, err)
+		require.NoError(t, psbt.Finalize(signedPSBT, 0))
+
+		signedTx, err := psbt.Extract(signedPSBT)
+		require.NoError(t, err)
+
+		prevFetcher := txscript.NewCannedPrevOutputFetcher(copyBytes(inscriptionAddrScript), 43000)
+		vm, err := txscript.NewEngine(
+			inscriptionAddrScript, signedTx, 0, txscript.StandardVerifyFlags,
+			nil, txscript.NewTxSigHashes(signedTx, prevFetcher), 43000, prevFetcher,
+		)
+		require.NoError(t, err)
+		require.NoError(t, vm.Execute())
+
+		otherKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		_, err = s.SignInscriptionReveal(packetBytes.Bytes(), insc, otherKey)
+		require.ErrorIs(t, err, inscriptions.ErrCommitmentMismatch)
+	})
+
 	t.Run("simple taproot", func(t *testing.T) {
-		taprootAddr, err := btcutil.NewAddressTaproot(schnorr.SerializePubKey(txscript.ComputeTaprootKeyNoScript(pubKey)),
-			&chaincfg.MainNetParams)
+		taprootAddr, err := utils.P2TRAddressFromInternalKey(pubKey, nil, &chaincfg.MainNetParams)
 		require.NoError(t, err)
 
 		taprootAddrAddrScript, err := txscript.PayToAddrScript(taprootAddr)
@@ -131,6 +178,488 @@ func TestSigner(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, vm.Execute())
 	})
+
+	t.Run("verify signatures", func(t *testing.T) {
+		verifier := signer.NewSigner(&chaincfg.MainNetParams, signer.VerifySignatures())
+
+		taprootAddr, err := utils.P2TRAddressFromInternalKey(pubKey, nil, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		taprootAddrAddrScript, err := txscript.PayToAddrScript(taprootAddr)
+		require.NoError(t, err)
+
+		packet, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+
+		packet.Inputs[0].WitnessUtxo = wire.NewTxOut(43000, taprootAddrAddrScript)
+		packet.Inputs[0].SighashType = txscript.SigHashAll
+
+		packetBytes := bytes.NewBuffer(nil)
+		err = packet.Serialize(packetBytes)
+		require.NoError(t, err)
+
+		signedPSBTBytes, err := verifier.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: packetBytes.Bytes(),
+			Inputs:         []int{0},
+			PrivateKey:     privKey,
+		})
+		require.NoError(t, err)
+
+		signedPSBT, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBTBytes), false)
+		require.NoError(t, err)
+		require.Nil(t, signedPSBT.Inputs[0].FinalScriptWitness)
+
+		otherKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		_, err = verifier.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: packetBytes.Bytes(),
+			Inputs:         []int{0},
+			PrivateKey:     otherKey,
+		})
+		require.ErrorIs(t, err, signer.ErrInvalidSignature)
+
+		// INFO: not verifying signer returns invalid signature as is.
+		_, err = s.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: packetBytes.Bytes(),
+			Inputs:         []int{0},
+			PrivateKey:     otherKey,
+		})
+		require.NoError(t, err)
+
+		rr, _ := runes.NewRuneFromString("HELLO")
+		insc := &inscriptions.Inscription{Rune: rr, Body: make([]byte, 21)}
+
+		inscriptionAddrStr, err := insc.IntoAddress(hex.EncodeToString(pubKey.SerializeCompressed()), &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		inscriptionAddr, err := btcutil.DecodeAddress(inscriptionAddrStr, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		inscriptionAddrScript, err := txscript.PayToAddrScript(inscriptionAddr)
+		require.NoError(t, err)
+
+		packet.Inputs[0].WitnessUtxo = wire.NewTxOut(43000, inscriptionAddrScript)
+
+		packetBytes.Reset()
+		err = packet.Serialize(packetBytes)
+		require.NoError(t, err)
+
+		_, err = verifier.SignInscriptionReveal(packetBytes.Bytes(), insc, privKey)
+		require.NoError(t, err)
+
+		// INFO: envelope data pushes are larger than the max script element, but within the consensus limit.
+		large := &inscriptions.Inscription{Body: make([]byte, 20000)}
+		largeAddrStr, err := large.IntoAddress(hex.EncodeToString(pubKey.SerializeCompressed()), &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		largeAddr, err := btcutil.DecodeAddress(largeAddrStr, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		largeAddrScript, err := txscript.PayToAddrScript(largeAddr)
+		require.NoError(t, err)
+
+		packet.Inputs[0].WitnessUtxo = wire.NewTxOut(43000, largeAddrScript)
+
+		packetBytes.Reset()
+		require.NoError(t, packet.Serialize(packetBytes))
+
+		_, err = verifier.SignInscriptionReveal(packetBytes.Bytes(), large, privKey)
+		require.NoError(t, err)
+	})
+
+	t.Run("inscription recovery", func(t *testing.T) {
+		verifier := signer.NewSigner(&chaincfg.MainNetParams, signer.VerifySignatures())
+
+		recoveryKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		rr, _ := runes.NewRuneFromString("HELLO")
+		insc := &inscriptions.Inscription{Rune: rr, Body: make([]byte, 21)}
+		recovery := inscriptions.RecoveryLeaf{PubKey: recoveryKey.PubKey().SerializeCompressed(), Delay: 144}
+
+		inscriptionAddrStr, err := insc.RecoveryCommitAddress(pubKey.SerializeCompressed(), recovery, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		inscriptionAddr, err := btcutil.DecodeAddress(inscriptionAddrStr, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		inscriptionAddrScript, err := txscript.PayToAddrScript(inscriptionAddr)
+		require.NoError(t, err)
+
+		serialize := func(t *testing.T, sequence uint32) []byte {
+			recoveryTx := tx.Copy()
+			recoveryTx.TxIn[0].Sequence = sequence
+
+			packet, err := psbt.NewFromUnsignedTx(recoveryTx)
+			require.NoError(t, err)
+
+			packet.Inputs[0].WitnessUtxo = wire.NewTxOut(43000, inscriptionAddrScript)
+			packet.Inputs[0].SighashType = txscript.SigHashAll
+
+			packetBytes := bytes.NewBuffer(nil)
+			require.NoError(t, packet.Serialize(packetBytes))
+
+			return packetBytes.Bytes()
+		}
+
+		_, err = verifier.SignInscriptionRevealWithRecovery(serialize(t, wire.MaxTxInSequenceNum), insc, recovery, privKey)
+		require.NoError(t, err)
+
+		_, err = verifier.SignInscriptionReveal(serialize(t, wire.MaxTxInSequenceNum), insc, privKey)
+		require.ErrorIs(t, err, inscriptions.ErrCommitmentMismatch)
+
+		params := signer.SignInscriptionRecoveryParams{
+			SerializedPSBT:    serialize(t, recovery.Sequence()),
+			Inscription:       insc,
+			InscriptionPubKey: pubKey.SerializeCompressed(),
+			Recovery:          recovery,
+			PrivateKey:        recoveryKey,
+		}
+		signedPSBTBytes, err := verifier.SignInscriptionRecovery(params)
+		require.NoError(t, err)
+
+		signedPSBT, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBTBytes), false)
+		require.NoError(t, err)
+		require.NoError(t, psbt.Finalize(signedPSBT, 0))
+
+		signedTx, err := psbt.Extract(signedPSBT)
+		require.NoError(t, err)
+
+		recoveryScript, err := recovery.Script()
+		require.NoError(t, err)
+		require.Equal(t, recoveryScript, signedTx.TxIn[0].Witness[1])
+
+		params.SerializedPSBT = serialize(t, recovery.Sequence()-1)
+		_, err = verifier.SignInscriptionRecovery(params)
+		require.ErrorIs(t, err, signer.ErrRecoveryLocked)
+
+		params.SerializedPSBT = serialize(t, recovery.Sequence())
+		params.PrivateKey = privKey
+		_, err = verifier.SignInscriptionRecovery(params)
+		require.ErrorIs(t, err, signer.ErrInvalidSignature)
+	})
+
+	t.Run("htlc", func(t *testing.T) {
+		verifier := signer.NewSigner(&chaincfg.MainNetParams, signer.VerifySignatures())
+
+		refundKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		preimage := make([]byte, utils.HTLCPreimageSize)
+		preimage[0] = 1
+		paymentHash := sha256.Sum256(preimage)
+
+		htlc := utils.HTLC{
+			PaymentHash:     paymentHash[:],
+			RecipientPubKey: pubKey.SerializeCompressed(),
+			SenderPubKey:    refundKey.PubKey().SerializeCompressed(),
+			LockTime:        850000,
+		}
+
+		htlcAddress, err := htlc.Address(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		htlcScript, err := txscript.PayToAddrScript(htlcAddress)
+		require.NoError(t, err)
+
+		serialize := func(t *testing.T, lockTime, sequence uint32) []byte {
+			spendTx := tx.Copy()
+			spendTx.LockTime = lockTime
+			spendTx.TxIn[0].Sequence = sequence
+
+			packet, err := psbt.NewFromUnsignedTx(spendTx)
+			require.NoError(t, err)
+
+			packet.Inputs[0].WitnessUtxo = wire.NewTxOut(43000, htlcScript)
+			packet.Inputs[0].SighashType = txscript.SigHashAll
+
+			packetBytes := bytes.NewBuffer(nil)
+			require.NoError(t, packet.Serialize(packetBytes))
+
+			return packetBytes.Bytes()
+		}
+
+		t.Run("claim", func(t *testing.T) {
+			params := signer.SignHTLCParams{
+				SerializedPSBT: serialize(t, 0, wire.MaxTxInSequenceNum),
+				HTLC:           htlc,
+				PrivateKey:     privKey,
+				Preimage:       preimage,
+			}
+			signedPSBTBytes, err := verifier.SignHTLCClaim(params)
+			require.NoError(t, err)
+
+			// INFO: claim input is finalized by the signer.
+			signedPSBT, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBTBytes), false)
+			require.NoError(t, err)
+
+			signedTx, err := psbt.Extract(signedPSBT)
+			require.NoError(t, err)
+
+			claimScript, err := htlc.ClaimScript()
+			require.NoError(t, err)
+			require.Len(t, signedTx.TxIn[0].Witness, 4)
+			require.Equal(t, preimage, signedTx.TxIn[0].Witness[1])
+			require.Equal(t, claimScript, signedTx.TxIn[0].Witness[2])
+
+			params.PrivateKey = refundKey
+			_, err = verifier.SignHTLCClaim(params)
+			require.ErrorIs(t, err, signer.ErrInvalidSignature)
+
+			params.PrivateKey = privKey
+			params.Preimage = make([]byte, utils.HTLCPreimageSize)
+			_, err = verifier.SignHTLCClaim(params)
+			require.ErrorIs(t, err, utils.ErrInvalidPreimage)
+
+			other := htlc
+			other.LockTime++
+			params.HTLC, params.Preimage = other, preimage
+			_, err = verifier.SignHTLCClaim(params)
+			require.ErrorIs(t, err, utils.ErrHTLCMismatch)
+		})
+
+		t.Run("refund", func(t *testing.T) {
+			params := signer.SignHTLCParams{
+				SerializedPSBT: serialize(t, htlc.LockTime, wire.MaxTxInSequenceNum-1),
+				HTLC:           htlc,
+				PrivateKey:     refundKey,
+			}
+			signedPSBTBytes, err := verifier.SignHTLCRefund(params)
+			require.NoError(t, err)
+
+			signedPSBT, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBTBytes), false)
+			require.NoError(t, err)
+			require.NoError(t, psbt.Finalize(signedPSBT, 0))
+
+			signedTx, err := psbt.Extract(signedPSBT)
+			require.NoError(t, err)
+
+			refundScript, err := htlc.RefundScript()
+			require.NoError(t, err)
+			require.Equal(t, refundScript, signedTx.TxIn[0].Witness[1])
+
+			for _, serialized := range [][]byte{
+				serialize(t, htlc.LockTime-1, wire.MaxTxInSequenceNum-1),
+				serialize(t, htlc.LockTime, wire.MaxTxInSequenceNum),
+				serialize(t, txscript.LockTimeThreshold+htlc.LockTime, wire.MaxTxInSequenceNum-1),
+			} {
+				params.SerializedPSBT = serialized
+				_, err = verifier.SignHTLCRefund(params)
+				require.ErrorIs(t, err, signer.ErrRefundLocked)
+			}
+		})
+	})
+
+	t.Run("nonces", func(t *testing.T) {
+		privKey, _ := btcec.PrivKeyFromBytes(mustHex("0b7a1e4c2f1c8a5f3de9a7a4a4c6b3d2f0e1d2c3b4a5968778695a4b3c2d1e0f"))
+		taprootAddr, err := utils.P2TRAddressFromInternalKey(privKey.PubKey(), nil, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		taprootAddrAddrScript, err := txscript.PayToAddrScript(taprootAddr)
+		require.NoError(t, err)
+
+		packet, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+		packet.Inputs[0].WitnessUtxo = wire.NewTxOut(43000, taprootAddrAddrScript)
+
+		packetBytes := bytes.NewBuffer(nil)
+		require.NoError(t, packet.Serialize(packetBytes))
+
+		sign := func(t *testing.T, opts ...signer.Option) []byte {
+			opts = append(opts, signer.VerifySignatures())
+			signedPSBTBytes, err := signer.NewSigner(&chaincfg.MainNetParams, opts...).SignTaproot(signer.SignTaprootParams{
+				SerializedPSBT: packetBytes.Bytes(),
+				Inputs:         []int{0},
+				PrivateKey:     privKey,
+			})
+			require.NoError(t, err)
+
+			signedPSBT, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBTBytes), false)
+			require.NoError(t, err)
+
+			return signedPSBT.Inputs[0].TaprootKeySpendSig
+		}
+		seed := func() *bytes.Reader { return bytes.NewReader(bytes.Repeat([]byte{0x42}, 32)) }
+
+		deterministic := sign(t)
+		// INFO: signature does not change between runs, so signed PSBT can be compared byte by byte.
+		require.Equal(t, "dd61b50a0868f39d85a4f6f4dba9a8539314ac859a8d4beae8c3a57736f729c2d55ce46387834cf222e0d935d9f7e3cd3f3e959d7004475abf68ff81a6506d63",
+			hex.EncodeToString(deterministic))
+		require.Equal(t, deterministic, sign(t, signer.DeterministicNonces()))
+		require.Equal(t, deterministic, sign(t, signer.WithAuxRand(seed()), signer.DeterministicNonces()))
+
+		auxRand := sign(t, signer.WithAuxRand(seed()))
+		require.NotEqual(t, deterministic, auxRand)
+		require.Equal(t, auxRand, sign(t, signer.WithAuxRand(seed())))
+
+		_, err = signer.NewSigner(&chaincfg.MainNetParams, signer.WithAuxRand(bytes.NewReader(nil))).SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: packetBytes.Bytes(),
+			Inputs:         []int{0},
+			PrivateKey:     privKey,
+		})
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("policy", func(t *testing.T) {
+		verifier := signer.NewSigner(&chaincfg.MainNetParams, signer.VerifySignatures())
+
+		keys := make([]*btcec.PrivateKey, 4)
+		for i := range keys {
+			keys[i], err = btcec.NewPrivateKey()
+			require.NoError(t, err)
+		}
+
+		// INFO: 2-of-3 operations leaf and 1-of-1 emergency leaf after 144 blocks.
+		ops, err := utils.NewTaprootThresholdMultiSigLeafTapScript(2, utils.ThresholdExact, keys[0].PubKey(),
+			keys[1].PubKey(), keys[2].PubKey())
+		require.NoError(t, err)
+		emergency, err := utils.NewTaprootDelayedLeafTapScript(144, keys[3].PubKey())
+		require.NoError(t, err)
+		policy := utils.TaprootPolicy{Leaves: []txscript.TapLeaf{ops, emergency}}
+
+		policyAddress, err := policy.Address(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+		policyScript, err := txscript.PayToAddrScript(policyAddress)
+		require.NoError(t, err)
+
+		leafScripts, err := policy.TapLeafScripts()
+		require.NoError(t, err)
+
+		serialize := func(t *testing.T, sequence uint32) []byte {
+			spendTx := tx.Copy()
+			spendTx.TxIn[0].Sequence = sequence
+
+			packet, err := psbt.NewFromUnsignedTx(spendTx)
+			require.NoError(t, err)
+
+			packet.Inputs[0].WitnessUtxo = wire.NewTxOut(43000, policyScript)
+			packet.Inputs[0].TaprootLeafScript = leafScripts
+
+			packetBytes := bytes.NewBuffer(nil)
+			require.NoError(t, packet.Serialize(packetBytes))
+
+			return packetBytes.Bytes()
+		}
+
+		opsHash, err := policy.LeafHash(0)
+		require.NoError(t, err)
+		emergencyHash, err := policy.LeafHash(1)
+		require.NoError(t, err)
+
+		t.Run("emergency", func(t *testing.T) {
+			signedPSBTBytes, err := verifier.SignTaproot(signer.SignTaprootParams{
+				SerializedPSBT: serialize(t, 144),
+				Inputs:         []int{0},
+				PrivateKey:     keys[3],
+				LeafHashes:     map[int]chainhash.Hash{0: emergencyHash},
+			})
+			require.NoError(t, err)
+
+			signedPSBT, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBTBytes), false)
+			require.NoError(t, err)
+			require.Len(t, signedPSBT.Inputs[0].TaprootLeafScript, 2)
+			require.Len(t, signedPSBT.Inputs[0].TaprootScriptSpendSig, 1)
+			require.Equal(t, emergencyHash[:], signedPSBT.Inputs[0].TaprootScriptSpendSig[0].LeafHash)
+
+			_, err = verifier.SignTaproot(signer.SignTaprootParams{
+				SerializedPSBT: serialize(t, 143),
+				Inputs:         []int{0},
+				PrivateKey:     keys[3],
+				LeafHashes:     map[int]chainhash.Hash{0: emergencyHash},
+			})
+			require.ErrorIs(t, err, signer.ErrInvalidSignature)
+		})
+
+		t.Run("other leaf after co-signer", func(t *testing.T) {
+			signedPSBTBytes, err := verifier.SignTaproot(signer.SignTaprootParams{
+				SerializedPSBT: serialize(t, 144),
+				Inputs:         []int{0},
+				PrivateKey:     keys[0],
+				LeafHashes:     map[int]chainhash.Hash{0: opsHash},
+			})
+			require.NoError(t, err)
+
+			// INFO: the operations leaf signature does not hide the emergency leaf script.
+			signedPSBTBytes, err = verifier.SignTaproot(signer.SignTaprootParams{
+				SerializedPSBT: signedPSBTBytes,
+				Inputs:         []int{0},
+				PrivateKey:     keys[3],
+				LeafHashes:     map[int]chainhash.Hash{0: emergencyHash},
+			})
+			require.NoError(t, err)
+
+			signedPSBT, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBTBytes), false)
+			require.NoError(t, err)
+			require.Len(t, signedPSBT.Inputs[0].TaprootLeafScript, 2)
+		})
+
+		t.Run("operations", func(t *testing.T) {
+			signedPSBTBytes := serialize(t, wire.MaxTxInSequenceNum)
+			for _, key := range []*btcec.PrivateKey{keys[2], keys[0]} {
+				signedPSBTBytes, err = verifier.SignTaproot(signer.SignTaprootParams{
+					SerializedPSBT: signedPSBTBytes,
+					Inputs:         []int{0},
+					PrivateKey:     key,
+					LeafHashes:     map[int]chainhash.Hash{0: opsHash},
+				})
+				require.NoError(t, err)
+			}
+
+			finalized, err := signer.FinalizeTapscript(signedPSBTBytes, []int{0})
+			require.NoError(t, err)
+
+			finalizedPSBT, err := psbt.NewFromRawBytes(bytes.NewReader(finalized), false)
+			require.NoError(t, err)
+			signedTx, err := psbt.Extract(finalizedPSBT)
+			require.NoError(t, err)
+
+			fetcher := txscript.NewCannedPrevOutputFetcher(policyScript, 43000)
+			vm, err := txscript.NewEngine(policyScript, signedTx, 0, txscript.StandardVerifyFlags, nil,
+				txscript.NewTxSigHashes(signedTx, fetcher), 43000, fetcher)
+			require.NoError(t, err)
+			require.NoError(t, vm.Execute())
+		})
+
+		t.Run("unknown leaf", func(t *testing.T) {
+			_, err := s.SignTaproot(signer.SignTaprootParams{
+				SerializedPSBT: serialize(t, 144),
+				Inputs:         []int{0},
+				PrivateKey:     keys[3],
+				LeafHashes:     map[int]chainhash.Hash{0: {1}},
+			})
+			require.ErrorIs(t, err, signer.ErrLeafNotFound)
+		})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		packet, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+
+		packetBytes := bytes.NewBuffer(nil)
+		err = packet.Serialize(packetBytes)
+		require.NoError(t, err)
+
+		_, err = s.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: packetBytes.Bytes(),
+			Inputs:         []int{0},
+		})
+		require.ErrorIs(t, err, signer.ErrMissingPrivateKey)
+
+		_, err = s.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: packetBytes.Bytes(),
+			Inputs:         []int{1},
+			PrivateKey:     privKey,
+		})
+		require.ErrorIs(t, err, signer.ErrInvalidInputIndex)
+
+		_, err = s.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: packetBytes.Bytes(),
+			Inputs:         []int{0},
+			PrivateKey:     privKey,
+		})
+		require.ErrorIs(t, err, signer.ErrMissingWitnessUTXO)
+	})
 }
 
 func mustHex(s string) []byte {
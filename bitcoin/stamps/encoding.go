@@ -0,0 +1,235 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package stamps
+
+import (
+	"bytes"
+	"crypto/rc4"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var (
+	// ErrNoStamp defines that transaction has no stamp outputs.
+	ErrNoStamp = errors.New("no stamp outputs")
+	// ErrMalformedStamp defines that stamp outputs data is malformed.
+	ErrMalformedStamp = errors.New("malformed stamp")
+	// ErrPayloadTooLarge defines that payload does not fit into outputs.
+	ErrPayloadTooLarge = errors.New("stamp payload is too large")
+)
+
+const (
+	// MultisigOutputAmount defines amount in satoshi of each bare multisig stamp output,
+	// slightly above 1-of-3 bare multisig dust threshold.
+	MultisigOutputAmount int64 = 810
+	// MaxOpReturnPayloadSize defines maximum payload size which fits into standard OP_RETURN output
+	// with 2 bytes length prefix.
+	MaxOpReturnPayloadSize = txscript.MaxDataCarrierSize - lengthPrefixSize
+
+	// lengthPrefixSize defines size of the big-endian payload length prefix.
+	lengthPrefixSize = 2
+	// keyDataSize defines number of payload bytes carried by each multisig public key.
+	keyDataSize = 31
+	// chunkSize defines number of payload bytes carried by each multisig output with 2 data keys.
+	chunkSize = 2 * keyDataSize
+)
+
+// burnKey defines unspendable third key of the stamp multisig outputs.
+// INFO: [Stamps impl] one of the burn keys of the stampchain indexer.
+var burnKey = bytes.Repeat([]byte{0x02}, btcec.PubKeyBytesLenCompressed)
+
+// EncodeMultisig returns 1-of-3 bare multisig output scripts carrying payload. Payload is prefixed
+// with its length, padded with zeros to the multiple of 62 bytes and encrypted with ARC4 using
+// transaction first input txid as a key. Every 31 bytes are stored into compressed public key with
+// brute-forced sign and last bytes to make the key valid, the third key is burn key.
+func EncodeMultisig(payload []byte, firstInput chainhash.Hash) ([][]byte, error) {
+	data, err := encrypt(payload, firstInput, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	scripts := make([][]byte, 0, len(data)/chunkSize)
+	for start := 0; start < len(data); start += chunkSize {
+		builder := txscript.NewScriptBuilder().AddOp(txscript.OP_1)
+		for _, part := range [][]byte{data[start : start+keyDataSize], data[start+keyDataSize : start+chunkSize]} {
+			key, err := dataKey(part)
+			if err != nil {
+				return nil, err
+			}
+
+			builder.AddData(key)
+		}
+
+		script, err := builder.AddData(burnKey).AddOp(txscript.OP_3).AddOp(txscript.OP_CHECKMULTISIG).Script()
+		if err != nil {
+			return nil, err
+		}
+
+		scripts = append(scripts, script)
+	}
+
+	return scripts, nil
+}
+
+// EncodeOpReturn returns OP_RETURN output script carrying payload. Payload is prefixed with its
+// length and encrypted with ARC4 using transaction first input txid as a key.
+func EncodeOpReturn(payload []byte, firstInput chainhash.Hash) ([]byte, error) {
+	if len(payload) > MaxOpReturnPayloadSize {
+		return nil, fmt.Errorf("%w: %d bytes, max: %d", ErrPayloadTooLarge, len(payload), MaxOpReturnPayloadSize)
+	}
+
+	data, err := encrypt(payload, firstInput, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return txscript.NullDataScript(data)
+}
+
+// Decode returns payload from transaction stamp outputs, bare multisig outputs take precedence
+// over OP_RETURN output. Returns ErrNoStamp if transaction has no stamp outputs.
+func Decode(tx *wire.MsgTx) ([]byte, error) {
+	if len(tx.TxIn) == 0 {
+		return nil, ErrNoStamp
+	}
+
+	var (
+		multisigData []byte
+		opReturnData []byte
+	)
+	for _, output := range tx.TxOut {
+		if keys, ok := stampKeys(output.PkScript); ok {
+			multisigData = append(multisigData, keys[0][1:1+keyDataSize]...)
+			multisigData = append(multisigData, keys[1][1:1+keyDataSize]...)
+			continue
+		}
+
+		if opReturnData == nil && txscript.IsNullData(output.PkScript) {
+			pushes, err := txscript.PushedData(output.PkScript)
+			if err == nil && len(pushes) == 1 && len(pushes[0]) > lengthPrefixSize {
+				opReturnData = pushes[0]
+			}
+		}
+	}
+
+	data := multisigData
+	if data == nil {
+		data = opReturnData
+	}
+
+	if data == nil {
+		return nil, ErrNoStamp
+	}
+
+	return decrypt(data, tx.TxIn[0].PreviousOutPoint.Hash)
+}
+
+// DecodeSRC20 returns SRC-20 payload from transaction stamp outputs.
+func DecodeSRC20(tx *wire.MsgTx) (*SRC20, error) {
+	payload, err := Decode(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSRC20(payload)
+}
+
+// encrypt prefixes payload with its length, pads with zeros to the multiple of
+// blockSize and encrypts it with ARC4.
+func encrypt(payload []byte, firstInput chainhash.Hash, blockSize int) ([]byte, error) {
+	if len(payload) > math.MaxUint16 {
+		return nil, fmt.Errorf("%w: %d bytes, max: %d", ErrPayloadTooLarge, len(payload), math.MaxUint16)
+	}
+
+	size := lengthPrefixSize + len(payload)
+	data := make([]byte, size+(blockSize-size%blockSize)%blockSize)
+	binary.BigEndian.PutUint16(data, uint16(len(payload)))
+	copy(data[lengthPrefixSize:], payload)
+
+	cipher, err := newCipher(firstInput)
+	if err != nil {
+		return nil, err
+	}
+
+	cipher.XORKeyStream(data, data)
+
+	return data, nil
+}
+
+// decrypt decrypts data with ARC4 and returns payload trimmed by its length prefix.
+func decrypt(data []byte, firstInput chainhash.Hash) ([]byte, error) {
+	cipher, err := newCipher(firstInput)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted := make([]byte, len(data))
+	cipher.XORKeyStream(decrypted, data)
+
+	size := int(binary.BigEndian.Uint16(decrypted))
+	if size > len(decrypted)-lengthPrefixSize {
+		return nil, fmt.Errorf("%w: payload length %d exceeds data size %d", ErrMalformedStamp, size, len(decrypted)-lengthPrefixSize)
+	}
+
+	return decrypted[lengthPrefixSize : lengthPrefixSize+size], nil
+}
+
+// newCipher returns ARC4 cipher keyed with txid bytes in the displayed (reversed) order.
+func newCipher(firstInput chainhash.Hash) (*rc4.Cipher, error) {
+	key, err := hex.DecodeString(firstInput.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return rc4.NewCipher(key)
+}
+
+// dataKey returns valid compressed public key carrying 31 bytes of data.
+func dataKey(data []byte) ([]byte, error) {
+	key := make([]byte, btcec.PubKeyBytesLenCompressed)
+	copy(key[1:], data)
+	for _, sign := range []byte{0x02, 0x03} {
+		key[0] = sign
+		for nonce := range 256 {
+			key[len(key)-1] = byte(nonce)
+			if _, err := btcec.ParsePubKey(key); err == nil {
+				return key, nil
+			}
+		}
+	}
+
+	// practically unreachable, half of x coordinates are valid.
+	return nil, fmt.Errorf("%w: no valid public key for data %x", ErrMalformedStamp, data)
+}
+
+// stampKeys returns data keys of the 1-of-3 bare multisig stamp output script.
+func stampKeys(script []byte) ([2][]byte, bool) {
+	var keys [2][]byte
+	if txscript.GetScriptClass(script) != txscript.MultiSigTy {
+		return keys, false
+	}
+
+	pushes, err := txscript.PushedData(script)
+	if err != nil || len(pushes) != 3 || !bytes.Equal(pushes[2], burnKey) {
+		return keys, false
+	}
+
+	for i := range keys {
+		if len(pushes[i]) != btcec.PubKeyBytesLenCompressed {
+			return keys, false
+		}
+
+		keys[i] = pushes[i]
+	}
+
+	return keys, script[0] == txscript.OP_1
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package stamps_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/stamps"
+)
+
+func TestEncoding(t *testing.T) {
+	firstInput, err := chainhash.NewHashFromStr("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746")
+	require.NoError(t, err)
+
+	newTx := func(scripts ...[]byte) *wire.MsgTx {
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(firstInput, 0), nil, nil))
+		tx.AddTxOut(wire.NewTxOut(546, []byte{txscript.OP_1, txscript.OP_DATA_32}))
+		for _, script := range scripts {
+			tx.AddTxOut(wire.NewTxOut(stamps.MultisigOutputAmount, script))
+		}
+
+		return tx
+	}
+
+	t.Run("multisig", func(t *testing.T) {
+		for _, size := range []int{0, 1, 60, 62, 500} {
+			payload := bytes.Repeat([]byte{0xab}, size)
+			scripts, err := stamps.EncodeMultisig(payload, *firstInput)
+			require.NoError(t, err)
+			require.Len(t, scripts, (size+2+61)/62)
+
+			for _, script := range scripts {
+				require.Equal(t, txscript.MultiSigTy, txscript.GetScriptClass(script))
+			}
+
+			decoded, err := stamps.Decode(newTx(scripts...))
+			require.NoError(t, err)
+			require.Equal(t, payload, decoded)
+		}
+	})
+
+	t.Run("op_return", func(t *testing.T) {
+		payload := []byte(`stamp:{"p":"src-20","op":"mint","tick":"KEVIN","amt":"1"}`)
+		script, err := stamps.EncodeOpReturn(payload, *firstInput)
+		require.NoError(t, err)
+		require.True(t, txscript.IsNullData(script))
+
+		decoded, err := stamps.Decode(newTx(script))
+		require.NoError(t, err)
+		require.Equal(t, payload, decoded)
+
+		_, err = stamps.EncodeOpReturn(make([]byte, stamps.MaxOpReturnPayloadSize+1), *firstInput)
+		require.ErrorIs(t, err, stamps.ErrPayloadTooLarge)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		scripts, err := stamps.EncodeMultisig([]byte("stamp:{}"), chainhash.Hash{1})
+		require.NoError(t, err)
+
+		decoded, err := stamps.Decode(newTx(scripts...))
+		if err == nil {
+			require.NotEqual(t, []byte("stamp:{}"), decoded)
+		} else {
+			require.ErrorIs(t, err, stamps.ErrMalformedStamp)
+		}
+	})
+
+	t.Run("no stamp", func(t *testing.T) {
+		_, err := stamps.Decode(newTx())
+		require.ErrorIs(t, err, stamps.ErrNoStamp)
+	})
+}
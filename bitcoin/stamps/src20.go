@@ -0,0 +1,138 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package stamps implements SRC-20 stamps encoding into bare multisig and OP_RETURN outputs.
+package stamps
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidSRC20 defines that SRC-20 payload does not follow the protocol rules.
+var ErrInvalidSRC20 = errors.New("invalid SRC-20 payload")
+
+const (
+	// ProtocolSRC20 defines SRC-20 protocol identifier.
+	ProtocolSRC20 = "src-20"
+	// MaxTickLength defines maximum SRC-20 tick length in characters.
+	MaxTickLength = 5
+	// MaxDecimals defines maximum SRC-20 token decimals.
+	MaxDecimals = 18
+)
+
+// payloadPrefix defines prefix of the stamp payload data.
+const payloadPrefix = "stamp:"
+
+// Operation defines SRC-20 operation type.
+type Operation string
+
+const (
+	// OperationDeploy defines SRC-20 token deployment.
+	OperationDeploy Operation = "deploy"
+	// OperationMint defines SRC-20 token minting.
+	OperationMint Operation = "mint"
+	// OperationTransfer defines SRC-20 token transfer.
+	OperationTransfer Operation = "transfer"
+)
+
+// amountRegexp defines pattern of the SRC-20 amounts.
+var amountRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// SRC20 defines SRC-20 JSON payload.
+type SRC20 struct {
+	P    string    `json:"p"`
+	Op   Operation `json:"op"`
+	Tick string    `json:"tick"`
+	Max  string    `json:"max,omitempty"` // maximum supply, deploy only.
+	Lim  string    `json:"lim,omitempty"` // maximum amount per mint, deploy only.
+	Dec  string    `json:"dec,omitempty"` // decimals, deploy only, optional.
+	Amt  string    `json:"amt,omitempty"` // amount, mint and transfer only.
+}
+
+// NewDeploy returns SRC-20 token deployment payload.
+func NewDeploy(tick, max, lim string) *SRC20 {
+	return &SRC20{P: ProtocolSRC20, Op: OperationDeploy, Tick: tick, Max: max, Lim: lim}
+}
+
+// NewMint returns SRC-20 token minting payload.
+func NewMint(tick, amt string) *SRC20 {
+	return &SRC20{P: ProtocolSRC20, Op: OperationMint, Tick: tick, Amt: amt}
+}
+
+// NewTransfer returns SRC-20 token transfer payload.
+func NewTransfer(tick, amt string) *SRC20 {
+	return &SRC20{P: ProtocolSRC20, Op: OperationTransfer, Tick: tick, Amt: amt}
+}
+
+// Validate checks that payload follows SRC-20 protocol rules.
+func (src20 *SRC20) Validate() error {
+	if !strings.EqualFold(src20.P, ProtocolSRC20) {
+		return fmt.Errorf("%w: unknown protocol %q", ErrInvalidSRC20, src20.P)
+	}
+
+	if length := utf8.RuneCountInString(src20.Tick); length == 0 || length > MaxTickLength {
+		return fmt.Errorf("%w: tick %q length should be from 1 to %d characters", ErrInvalidSRC20, src20.Tick, MaxTickLength)
+	}
+
+	switch Operation(strings.ToLower(string(src20.Op))) {
+	case OperationDeploy:
+		if !amountRegexp.MatchString(src20.Max) || !amountRegexp.MatchString(src20.Lim) {
+			return fmt.Errorf("%w: invalid deploy max %q or lim %q", ErrInvalidSRC20, src20.Max, src20.Lim)
+		}
+
+		if src20.Dec != "" {
+			dec, err := strconv.ParseUint(src20.Dec, 10, 8)
+			if err != nil || dec > MaxDecimals {
+				return fmt.Errorf("%w: dec %q should be from 0 to %d", ErrInvalidSRC20, src20.Dec, MaxDecimals)
+			}
+		}
+	case OperationMint, OperationTransfer:
+		if !amountRegexp.MatchString(src20.Amt) {
+			return fmt.Errorf("%w: invalid amt %q", ErrInvalidSRC20, src20.Amt)
+		}
+	default:
+		return fmt.Errorf("%w: unknown operation %q", ErrInvalidSRC20, src20.Op)
+	}
+
+	return nil
+}
+
+// Payload returns stamp payload data: "stamp:" prefix with compact SRC-20 JSON.
+func (src20 *SRC20) Payload() ([]byte, error) {
+	if err := src20.Validate(); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(src20)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(payloadPrefix), data...), nil
+}
+
+// ParseSRC20 parses and validates SRC-20 payload from stamp payload data.
+func ParseSRC20(payload []byte) (*SRC20, error) {
+	data, ok := bytes.CutPrefix(payload, []byte(payloadPrefix))
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %q prefix", ErrInvalidSRC20, payloadPrefix)
+	}
+
+	src20 := new(SRC20)
+	if err := json.Unmarshal(data, src20); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSRC20, err)
+	}
+
+	if err := src20.Validate(); err != nil {
+		return nil, err
+	}
+
+	return src20, nil
+}
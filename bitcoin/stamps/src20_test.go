@@ -0,0 +1,57 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package stamps_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/stamps"
+)
+
+func TestSRC20(t *testing.T) {
+	t.Run("payload", func(t *testing.T) {
+		payload, err := stamps.NewDeploy("KEVIN", "2100", "1000").Payload()
+		require.NoError(t, err)
+		require.Equal(t, `stamp:{"p":"src-20","op":"deploy","tick":"KEVIN","max":"2100","lim":"1000"}`, string(payload))
+
+		src20, err := stamps.ParseSRC20(payload)
+		require.NoError(t, err)
+		require.Equal(t, stamps.NewDeploy("KEVIN", "2100", "1000"), src20)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		tests := []*stamps.SRC20{
+			stamps.NewMint("🐸", "1.5"),
+			stamps.NewTransfer("stamp", "100"),
+			{P: "SRC-20", Op: "DEPLOY", Tick: "A", Max: "1", Lim: "1", Dec: "18"},
+		}
+		for _, test := range tests {
+			require.NoError(t, test.Validate())
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []*stamps.SRC20{
+			{P: "brc-20", Op: stamps.OperationMint, Tick: "KEVIN", Amt: "1"},
+			stamps.NewMint("", "1"),
+			stamps.NewMint("TOOLONG", "1"),
+			stamps.NewMint("KEVIN", "-1"),
+			stamps.NewTransfer("KEVIN", ""),
+			stamps.NewDeploy("KEVIN", "1e5", "1"),
+			{P: stamps.ProtocolSRC20, Op: stamps.OperationDeploy, Tick: "KEVIN", Max: "1", Lim: "1", Dec: "19"},
+			{P: stamps.ProtocolSRC20, Op: "burn", Tick: "KEVIN", Amt: "1"},
+		}
+		for _, test := range tests {
+			require.ErrorIs(t, test.Validate(), stamps.ErrInvalidSRC20)
+		}
+
+		_, err := stamps.ParseSRC20([]byte(`{"p":"src-20","op":"mint","tick":"KEVIN","amt":"1"}`))
+		require.ErrorIs(t, err, stamps.ErrInvalidSRC20)
+
+		_, err = stamps.ParseSRC20([]byte(`stamp:{"p":"src-20"`))
+		require.ErrorIs(t, err, stamps.ErrInvalidSRC20)
+	})
+}
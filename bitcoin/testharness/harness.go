@@ -0,0 +1,407 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package testharness provides regtest/signet bitcoind harness to broadcast and confirm
+// transactions built by txbuilder in end-to-end integration tests.
+package testharness
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/rpc"
+)
+
+var (
+	// ErrNotConfirmed describes that transaction has not reached required confirmations.
+	ErrNotConfirmed = errors.New("transaction is not confirmed")
+	// ErrOutputNotFound describes that transaction has no output to the address.
+	ErrOutputNotFound = errors.New("output is not found")
+)
+
+const (
+	// coinbaseMaturity defines number of blocks after which coinbase outputs are spendable.
+	coinbaseMaturity = 100
+	// defaultWallet defines bitcoind wallet name used to fund addresses.
+	defaultWallet = "testharness"
+	// startTimeout defines how long to wait for started bitcoind RPC to become available.
+	startTimeout = 30 * time.Second
+)
+
+// Config defines Harness configuration.
+type Config struct {
+	RPCURL       string           // existing node RPC url, e.g. http://127.0.0.1:18443, bitcoind is started if empty.
+	RPCUser      string           // RPC user name.
+	RPCPassword  string           // RPC password.
+	BitcoindPath string           // bitcoind binary to start, optional, "bitcoind" from PATH if not set.
+	DataDir      string           // started bitcoind data directory, optional, temporary directory if not set.
+	Wallet       string           // wallet name to fund addresses from, optional, created or loaded if missing.
+	NetParams    *chaincfg.Params // node network, optional, regtest if not set.
+}
+
+// Harness manages bitcoind node for integration tests.
+// NOTE: Mining helpers work on regtest only, signet nodes should be funded externally.
+type Harness struct {
+	NetParams *chaincfg.Params
+
+	rpc     *rpcClient
+	node    *exec.Cmd
+	dataDir string
+	tempDir bool
+	wallet  string
+}
+
+// New connects to the existing node or starts a new regtest bitcoind, loads the wallet
+// and mines blocks until the wallet has mature coinbase outputs on regtest.
+func New(ctx context.Context, config Config) (_ *Harness, err error) {
+	harness := &Harness{
+		NetParams: config.NetParams,
+		wallet:    config.Wallet,
+	}
+	if harness.NetParams == nil {
+		harness.NetParams = &chaincfg.RegressionNetParams
+	}
+	if harness.wallet == "" {
+		harness.wallet = defaultWallet
+	}
+
+	url := config.RPCURL
+	if url == "" {
+		if url, err = harness.start(config); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err != nil {
+				_ = harness.Close()
+			}
+		}()
+	}
+
+	harness.rpc = &rpcClient{url: url, user: config.RPCUser, password: config.RPCPassword, client: http.DefaultClient}
+	if err = harness.waitReady(ctx); err != nil {
+		return nil, err
+	}
+
+	if err = harness.loadWallet(ctx); err != nil {
+		return nil, err
+	}
+
+	if harness.NetParams.Net != chaincfg.RegressionNetParams.Net {
+		return harness, nil
+	}
+
+	var height int64
+	if err = harness.Call(ctx, "getblockcount", &height); err != nil {
+		return nil, err
+	}
+
+	if height <= coinbaseMaturity {
+		if _, err = harness.Mine(ctx, coinbaseMaturity+1-int(height)); err != nil {
+			return nil, err
+		}
+	}
+
+	return harness, nil
+}
+
+// Close stops started bitcoind and removes its temporary data directory.
+func (h *Harness) Close() error {
+	if h.node == nil {
+		return nil
+	}
+
+	if h.rpc != nil {
+		_ = h.rpc.call(context.Background(), "stop", nil)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- h.node.Wait() }()
+
+	var err error
+	select {
+	case <-done:
+	case <-time.After(startTimeout):
+		err = h.node.Process.Kill()
+	}
+
+	if h.tempDir {
+		err = errors.Join(err, os.RemoveAll(h.dataDir))
+	}
+
+	h.node = nil
+
+	return err
+}
+
+// ensures that Harness implements rpc.Caller.
+var _ rpc.Caller = (*Harness)(nil)
+
+// Call calls node RPC method and decodes its result into the result value, result may be nil.
+func (h *Harness) Call(ctx context.Context, method string, result any, params ...any) error {
+	return h.rpc.call(ctx, method, result, params...)
+}
+
+// NewAddress returns new taproot address of the harness wallet.
+func (h *Harness) NewAddress(ctx context.Context) (string, error) {
+	var address string
+	err := h.walletCall(ctx, "getnewaddress", &address, "", "bech32m")
+
+	return address, err
+}
+
+// Mine mines blocks to the harness wallet address and returns their hashes.
+func (h *Harness) Mine(ctx context.Context, blocks int) ([]string, error) {
+	address, err := h.NewAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []string
+	err = h.Call(ctx, "generatetoaddress", &hashes, blocks, address)
+
+	return hashes, err
+}
+
+// Fund sends amount in satoshi to the address from the harness wallet, confirms
+// the transaction with one block and returns funded UTXO.
+func (h *Harness) Fund(ctx context.Context, address string, amount *big.Int) (*bitcoin.UTXO, error) {
+	decoded, err := btcutil.DecodeAddress(address, h.NetParams)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := txscript.PayToAddrScript(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var txHash string
+	if err = h.walletCall(ctx, "sendtoaddress", &txHash, address, btcutil.Amount(amount.Int64()).ToBTC()); err != nil {
+		return nil, err
+	}
+
+	if err = h.Confirm(ctx, txHash, 1); err != nil {
+		return nil, err
+	}
+
+	tx, err := h.Transaction(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for index, output := range tx.TxOut {
+		if bytes.Equal(output.PkScript, script) && output.Value == amount.Int64() {
+			return &bitcoin.UTXO{
+				Outpoint: bitcoin.Outpoint{Hash: tx.TxHash(), Index: uint32(index)},
+				Amount:   big.NewInt(output.Value),
+				Script:   output.PkScript,
+				Address:  address,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s in %s", ErrOutputNotFound, address, txHash)
+}
+
+// BroadcastPSBT finalizes signed PSBT, extracts and broadcasts the transaction, returns its hash.
+func (h *Harness) BroadcastPSBT(ctx context.Context, signedPSBT []byte) (string, error) {
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBT), false)
+	if err != nil {
+		return "", err
+	}
+
+	if err = psbt.MaybeFinalizeAll(packet); err != nil {
+		return "", err
+	}
+
+	tx, err := psbt.Extract(packet)
+	if err != nil {
+		return "", err
+	}
+
+	return h.BroadcastTx(ctx, tx)
+}
+
+// BroadcastTx broadcasts the transaction and returns its hash.
+func (h *Harness) BroadcastTx(ctx context.Context, tx *wire.MsgTx) (string, error) {
+	var buffer bytes.Buffer
+	if err := tx.Serialize(&buffer); err != nil {
+		return "", err
+	}
+
+	var txHash string
+	err := h.Call(ctx, "sendrawtransaction", &txHash, hex.EncodeToString(buffer.Bytes()))
+
+	return txHash, err
+}
+
+// Confirm mines blocks until the transaction has required confirmations.
+// INFO: Existing nodes should be run with -txindex to confirm transactions not related to the wallet.
+func (h *Harness) Confirm(ctx context.Context, txHash string, confirmations int) error {
+	var info struct {
+		Confirmations int `json:"confirmations"`
+	}
+	if err := h.Call(ctx, "getrawtransaction", &info, txHash, true); err != nil {
+		return err
+	}
+
+	if info.Confirmations < confirmations {
+		if _, err := h.Mine(ctx, confirmations-info.Confirmations); err != nil {
+			return err
+		}
+
+		if err := h.Call(ctx, "getrawtransaction", &info, txHash, true); err != nil {
+			return err
+		}
+	}
+
+	if info.Confirmations < confirmations {
+		return fmt.Errorf("%w: %s has %d of %d confirmations", ErrNotConfirmed, txHash, info.Confirmations, confirmations)
+	}
+
+	return nil
+}
+
+// BroadcastAndConfirmPSBT broadcasts signed PSBT and mines blocks until the transaction has required confirmations.
+func (h *Harness) BroadcastAndConfirmPSBT(ctx context.Context, signedPSBT []byte, confirmations int) (string, error) {
+	txHash, err := h.BroadcastPSBT(ctx, signedPSBT)
+	if err != nil {
+		return "", err
+	}
+
+	return txHash, h.Confirm(ctx, txHash, confirmations)
+}
+
+// Transaction returns transaction by its hash.
+func (h *Harness) Transaction(ctx context.Context, txHash string) (*wire.MsgTx, error) {
+	var rawTx string
+	if err := h.Call(ctx, "getrawtransaction", &rawTx, txHash, false); err != nil {
+		return nil, err
+	}
+
+	data, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := new(wire.MsgTx)
+	if err = tx.Deserialize(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// walletCall calls wallet RPC method of the harness wallet.
+func (h *Harness) walletCall(ctx context.Context, method string, result any, params ...any) error {
+	return h.rpc.callPath(ctx, "/wallet/"+h.wallet, method, result, params...)
+}
+
+// loadWallet loads the harness wallet or creates it if missing.
+func (h *Harness) loadWallet(ctx context.Context) error {
+	var wallets []string
+	if err := h.Call(ctx, "listwallets", &wallets); err != nil {
+		return err
+	}
+
+	for _, wallet := range wallets {
+		if wallet == h.wallet {
+			return nil
+		}
+	}
+
+	err := h.Call(ctx, "loadwallet", nil, h.wallet)
+	if err == nil {
+		return nil
+	}
+
+	return h.Call(ctx, "createwallet", nil, h.wallet)
+}
+
+// start starts regtest bitcoind and returns its RPC url.
+func (h *Harness) start(config Config) (string, error) {
+	binary := config.BitcoindPath
+	if binary == "" {
+		binary = "bitcoind"
+	}
+
+	h.dataDir = config.DataDir
+	if h.dataDir == "" {
+		dir, err := os.MkdirTemp("", "testharness")
+		if err != nil {
+			return "", err
+		}
+
+		h.dataDir, h.tempDir = dir, true
+	}
+
+	rpcPort, err := freePort()
+	if err != nil {
+		return "", err
+	}
+
+	h.node = exec.Command(binary,
+		"-regtest",
+		"-server",
+		"-txindex",
+		"-listen=0",
+		"-fallbackfee=0.0001",
+		"-datadir="+h.dataDir,
+		"-rpcport="+strconv.Itoa(rpcPort),
+		"-rpcuser="+config.RPCUser,
+		"-rpcpassword="+config.RPCPassword,
+	)
+	if err = h.node.Start(); err != nil {
+		h.node = nil
+		return "", err
+	}
+
+	return "http://127.0.0.1:" + strconv.Itoa(rpcPort), nil
+}
+
+// waitReady waits until node RPC becomes available.
+func (h *Harness) waitReady(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+
+	for {
+		err := h.Call(ctx, "getblockchaininfo", nil)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("node is not ready: %w", err)
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+// freePort returns free local TCP port.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = listener.Close() }()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package testharness_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/testharness"
+)
+
+// fakeNode serves subset of bitcoind JSON-RPC methods.
+func fakeNode(t *testing.T, calls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "user" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var request struct {
+			Method string `json:"method"`
+			Params []any  `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		*calls = append(*calls, r.URL.Path+" "+request.Method)
+
+		var response = map[string]any{"error": nil}
+		switch request.Method {
+		case "getblockchaininfo":
+			response["result"] = map[string]any{"chain": "regtest"}
+		case "listwallets":
+			response["result"] = []string{"testharness"}
+		case "getblockcount":
+			response["result"] = 200
+		case "sendrawtransaction":
+			response["result"] = nil
+			response["error"] = map[string]any{"code": -26, "message": "bad-txns-inputs-missingorspent"}
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			response["error"] = map[string]any{"code": -32601, "message": "Method not found"}
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+}
+
+func TestHarness(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("existing node", func(t *testing.T) {
+		var calls []string
+		server := fakeNode(t, &calls)
+		defer server.Close()
+
+		harness, err := testharness.New(ctx, testharness.Config{RPCURL: server.URL, RPCUser: "user", RPCPassword: "password"})
+		require.NoError(t, err)
+		require.NoError(t, harness.Close())
+		require.Equal(t, []string{"/ getblockchaininfo", "/ listwallets", "/ getblockcount"}, calls)
+
+		_, err = harness.BroadcastTx(ctx, wire.NewMsgTx(wire.TxVersion))
+		var rpcErr *testharness.RPCError
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, -26, rpcErr.Code)
+
+		_, err = harness.NewAddress(ctx)
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, -32601, rpcErr.Code)
+		require.Equal(t, "/wallet/testharness getnewaddress", calls[len(calls)-1])
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		var calls []string
+		server := fakeNode(t, &calls)
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := testharness.New(ctx, testharness.Config{RPCURL: server.URL, RPCUser: "user"})
+		require.Error(t, err)
+	})
+
+	t.Run("fund", func(t *testing.T) {
+		harness := testharness.Start(t)
+
+		address, err := harness.NewAddress(ctx)
+		require.NoError(t, err)
+
+		utxo, err := harness.Fund(ctx, address, big.NewInt(100000))
+		require.NoError(t, err)
+		require.Equal(t, address, utxo.Address)
+		require.EqualValues(t, 100000, utxo.Amount.Int64())
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package testharness
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// rpcClient defines minimal bitcoind JSON-RPC client.
+type rpcClient struct {
+	url      string
+	user     string
+	password string
+	client   *http.Client
+	id       atomic.Uint64
+}
+
+// rpcRequest defines JSON-RPC request body.
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// rpcResponse defines JSON-RPC response body.
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// call calls RPC method and decodes its result into the result value, result may be nil.
+func (c *rpcClient) call(ctx context.Context, method string, result any, params ...any) error {
+	return c.callPath(ctx, "", method, result, params...)
+}
+
+// callPath calls RPC method on the url path, e.g. /wallet/<name> for wallet methods.
+func (c *rpcClient) callPath(ctx context.Context, path, method string, result any, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+
+	body, err := json.Marshal(rpcRequest{JSONRPC: "1.0", ID: c.id.Add(1), Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.SetBasicAuth(c.user, c.password)
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	// INFO: bitcoind responds with non-200 status codes for errors, error body is decoded if present.
+	var decoded rpcResponse
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("%s: %s: %s", method, response.Status, bytes.TrimSpace(data))
+	}
+
+	if decoded.Error != nil {
+		return fmt.Errorf("%s: %w", method, decoded.Error)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(decoded.Result, result)
+}
@@ -0,0 +1,19 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package testharness
+
+import (
+	"fmt"
+)
+
+// RPCError is the error type to describe bitcoind JSON-RPC error response.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error returns error description.
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("bitcoind rpc error %d: %s", e.Code, e.Message)
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package testharness
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// Environment variables to configure harness in CI.
+const (
+	EnvRPCURL       = "BITCOIND_RPC_URL"
+	EnvRPCUser      = "BITCOIND_RPC_USER"
+	EnvRPCPassword  = "BITCOIND_RPC_PASSWORD"
+	EnvBitcoindPath = "BITCOIND_PATH"
+)
+
+// ConfigFromEnv returns regtest Config filled from environment variables.
+func ConfigFromEnv() Config {
+	config := Config{
+		RPCURL:       os.Getenv(EnvRPCURL),
+		RPCUser:      os.Getenv(EnvRPCUser),
+		RPCPassword:  os.Getenv(EnvRPCPassword),
+		BitcoindPath: os.Getenv(EnvBitcoindPath),
+	}
+	if config.RPCUser == "" {
+		config.RPCUser, config.RPCPassword = "testharness", "testharness"
+	}
+
+	return config
+}
+
+// Start returns Harness configured from environment variables and stops it on test cleanup.
+// Test is skipped if neither node RPC url is set nor bitcoind binary is found.
+func Start(t testing.TB) *Harness {
+	t.Helper()
+
+	config := ConfigFromEnv()
+	if config.RPCURL == "" {
+		binary := config.BitcoindPath
+		if binary == "" {
+			binary = "bitcoind"
+		}
+
+		if _, err := exec.LookPath(binary); err != nil {
+			t.Skipf("bitcoind is not available, set %s or %s to run integration tests", EnvRPCURL, EnvBitcoindPath)
+		}
+	}
+
+	harness, err := New(context.Background(), config)
+	if err != nil {
+		t.Fatalf("testharness: %v", err)
+	}
+
+	t.Cleanup(func() { _ = harness.Close() })
+
+	return harness
+}
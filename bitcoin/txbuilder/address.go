@@ -0,0 +1,86 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// ErrNoScriptAddress describes that script has no single address representation.
+var ErrNoScriptAddress = errors.New("script has no address")
+
+// knownNetworks defines networks to detect address network on decoding failures.
+var knownNetworks = []*chaincfg.Params{
+	&chaincfg.MainNetParams,
+	&chaincfg.TestNet3Params,
+	&chaincfg.SigNetParams,
+	&chaincfg.RegressionNetParams,
+	&chaincfg.SimNetParams,
+}
+
+// DecodeAddress decodes address and checks that it belongs to the network.
+// Returns WrongNetworkError if address is valid for another known network.
+func DecodeAddress(address string, networkParams *chaincfg.Params) (btcutil.Address, error) {
+	decoded, err := btcutil.DecodeAddress(address, networkParams)
+	if err == nil && decoded.IsForNet(networkParams) {
+		return decoded, nil
+	}
+
+	for _, params := range knownNetworks {
+		if params.Net == networkParams.Net {
+			continue
+		}
+
+		other, otherErr := btcutil.DecodeAddress(address, params)
+		if otherErr == nil && other.IsForNet(params) {
+			return nil, &WrongNetworkError{Address: address, Expected: networkParams.Name, Actual: params.Name}
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// INFO: legacy address with unregistered prefix is decoded without network check.
+	return nil, &WrongNetworkError{Address: address, Expected: networkParams.Name}
+}
+
+// ScriptToAddress returns address of the script pub key for the network.
+func ScriptToAddress(script []byte, networkParams *chaincfg.Params) (string, error) {
+	_, addresses, _, err := txscript.ExtractPkScriptAddrs(script, networkParams)
+	if err != nil {
+		return "", err
+	}
+
+	if len(addresses) != 1 {
+		return "", fmt.Errorf("%w: %x", ErrNoScriptAddress, script)
+	}
+
+	return addresses[0].EncodeAddress(), nil
+}
+
+// ConvertAddress translates address of one network into the address with the same script of another network.
+func ConvertAddress(address string, from, to *chaincfg.Params) (string, error) {
+	decoded, err := DecodeAddress(address, from)
+	if err != nil {
+		return "", err
+	}
+
+	script, err := txscript.PayToAddrScript(decoded)
+	if err != nil {
+		return "", err
+	}
+
+	return ScriptToAddress(script, to)
+}
+
+// DecodeAddress decodes address and checks that it belongs to the builder network.
+func (b *TxBuilder) DecodeAddress(address string) (btcutil.Address, error) {
+	return DecodeAddress(address, b.networkParams)
+}
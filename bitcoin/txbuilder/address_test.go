@@ -0,0 +1,100 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestAddress(t *testing.T) {
+	hash := btcutil.Hash160([]byte("address"))
+	taprootKey := make([]byte, 32)
+	taprootKey[0] = 1
+
+	addresses := func(params *chaincfg.Params) []string {
+		p2pkh, err := btcutil.NewAddressPubKeyHash(hash, params)
+		require.NoError(t, err)
+		p2wpkh, err := btcutil.NewAddressWitnessPubKeyHash(hash, params)
+		require.NoError(t, err)
+		p2tr, err := btcutil.NewAddressTaproot(taprootKey, params)
+		require.NoError(t, err)
+
+		return []string{p2pkh.EncodeAddress(), p2wpkh.EncodeAddress(), p2tr.EncodeAddress()}
+	}
+
+	mainnet, testnet, regtest := addresses(&chaincfg.MainNetParams), addresses(&chaincfg.TestNet3Params),
+		addresses(&chaincfg.RegressionNetParams)
+
+	t.Run("decode", func(t *testing.T) {
+		for _, address := range mainnet {
+			decoded, err := txbuilder.DecodeAddress(address, &chaincfg.MainNetParams)
+			require.NoError(t, err)
+			require.Equal(t, address, decoded.EncodeAddress())
+		}
+
+		builder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params)
+		for _, address := range testnet {
+			_, err := builder.DecodeAddress(address)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("wrong network", func(t *testing.T) {
+		for _, address := range mainnet {
+			_, err := txbuilder.DecodeAddress(address, &chaincfg.TestNet3Params)
+			require.ErrorIs(t, err, txbuilder.ErrWrongNetwork)
+
+			var wrongNetworkErr *txbuilder.WrongNetworkError
+			require.ErrorAs(t, err, &wrongNetworkErr)
+			require.Equal(t, chaincfg.MainNetParams.Name, wrongNetworkErr.Actual)
+			require.Equal(t, chaincfg.TestNet3Params.Name, wrongNetworkErr.Expected)
+		}
+
+		_, err := txbuilder.DecodeAddress(regtest[2], &chaincfg.MainNetParams)
+		require.ErrorIs(t, err, txbuilder.ErrWrongNetwork)
+
+		_, err = txbuilder.DecodeAddress("not an address", &chaincfg.MainNetParams)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, txbuilder.ErrWrongNetwork)
+
+		_, err = txbuilder.NewPSBTInputBuilder("", mainnet[2], &chaincfg.TestNet3Params)
+		require.ErrorIs(t, err, txbuilder.ErrWrongNetwork)
+		require.ErrorIs(t, err, txbuilder.ErrPSBTInputBuilder)
+	})
+
+	t.Run("script to address", func(t *testing.T) {
+		for i, address := range mainnet {
+			decoded, err := txbuilder.DecodeAddress(address, &chaincfg.MainNetParams)
+			require.NoError(t, err)
+
+			script, err := txscript.PayToAddrScript(decoded)
+			require.NoError(t, err)
+
+			converted, err := txbuilder.ScriptToAddress(script, &chaincfg.RegressionNetParams)
+			require.NoError(t, err)
+			require.Equal(t, regtest[i], converted)
+		}
+
+		_, err := txbuilder.ScriptToAddress([]byte{txscript.OP_RETURN, 0x01, 0x01}, &chaincfg.MainNetParams)
+		require.ErrorIs(t, err, txbuilder.ErrNoScriptAddress)
+	})
+
+	t.Run("convert", func(t *testing.T) {
+		for i, address := range testnet {
+			converted, err := txbuilder.ConvertAddress(address, &chaincfg.TestNet3Params, &chaincfg.MainNetParams)
+			require.NoError(t, err)
+			require.Equal(t, mainnet[i], converted)
+		}
+
+		_, err := txbuilder.ConvertAddress(mainnet[0], &chaincfg.TestNet3Params, &chaincfg.MainNetParams)
+		require.ErrorIs(t, err, txbuilder.ErrWrongNetwork)
+	})
+}
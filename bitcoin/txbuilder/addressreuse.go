@@ -0,0 +1,111 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// AddressHistory describes source of the previously used addresses, e.g. wallet history or
+// compliance database, to detect recipient and change addresses reuse.
+type AddressHistory interface {
+	// AddressesUsed reports whether the addresses are already used, in the same order.
+	AddressesUsed(ctx context.Context, addresses []string) ([]bool, error)
+}
+
+// AddressSet is an in-memory AddressHistory of the provided addresses.
+type AddressSet map[string]struct{}
+
+// NewAddressSet is a constructor for AddressSet.
+func NewAddressSet(addresses ...string) AddressSet {
+	set := make(AddressSet, len(addresses))
+	for _, address := range addresses {
+		set[address] = struct{}{}
+	}
+
+	return set
+}
+
+// AddressesUsed reports whether the addresses are in the set, in the same order.
+func (s AddressSet) AddressesUsed(_ context.Context, addresses []string) ([]bool, error) {
+	used := make([]bool, len(addresses))
+	for idx, address := range addresses {
+		_, used[idx] = s[address]
+	}
+
+	return used, nil
+}
+
+// AddressReuseWarning describes transaction output paying to the address found in AddressHistory.
+type AddressReuseWarning struct {
+	Output  int        // index of the transaction output.
+	Role    OutputRole // role of the transaction output.
+	Address string     // reused address.
+}
+
+// String returns human-readable warning description.
+func (w AddressReuseWarning) String() string {
+	return fmt.Sprintf("%s output %d reuses address %s", w.Role, w.Output, w.Address)
+}
+
+// reuseCheckedRoles defines roles of the outputs checked for address reuse.
+var reuseCheckedRoles = map[OutputRole]bool{
+	OutputRoleRecipient:   true,
+	OutputRoleRunesChange: true,
+	OutputRoleChange:      true,
+}
+
+// addressReuseWarnings returns warnings for recipient and change outputs of the transaction
+// paying to the addresses used before, nil if AddressHistory is not configured.
+// NOTE: Address is reported once per output, so the same address might be reported several times.
+func addressReuseWarnings(ctx context.Context, history AddressHistory, tx *wire.MsgTx, roles []OutputRole,
+	networkParams *chaincfg.Params) ([]AddressReuseWarning, error) {
+	if history == nil || tx == nil {
+		return nil, nil
+	}
+
+	var (
+		checked   []AddressReuseWarning
+		addresses []string
+	)
+	for idx, role := range roles {
+		if !reuseCheckedRoles[role] || idx >= len(tx.TxOut) {
+			continue
+		}
+
+		_, outputAddresses, _, err := txscript.ExtractPkScriptAddrs(tx.TxOut[idx].PkScript, networkParams)
+		if err != nil || len(outputAddresses) != 1 {
+			continue // INFO: outputs without a single address are not checked.
+		}
+
+		address := outputAddresses[0].EncodeAddress()
+		checked = append(checked, AddressReuseWarning{Output: idx, Role: role, Address: address})
+		addresses = append(addresses, address)
+	}
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	used, err := history.AddressesUsed(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+	if len(used) != len(addresses) {
+		return nil, fmt.Errorf("address history returned %d results for %d addresses", len(used), len(addresses))
+	}
+
+	var warnings []AddressReuseWarning
+	for idx, warning := range checked {
+		if used[idx] {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+type failingAddressHistory struct{ err error }
+
+func (h failingAddressHistory) AddressesUsed(context.Context, []string) ([]bool, error) {
+	return nil, h.err
+}
+
+func TestAddressReuseWarnings(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+
+	newAddress := func() string { return txbuildertest.NewWallet(t, networkParams).Address }
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	recipient, commissionReceiver := newAddress(), newAddress()
+	params := txbuilder.BaseBTCTransferParams{
+		Sender:                    wallet.PaymentData("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 100000),
+		TransferSatoshiAmount:     big.NewInt(10000),
+		SatoshiPerKVByte:          big.NewInt(10000), // 10 sat/vB.
+		RecipientAddress:          recipient,
+		SatoshiCommissionAmount:   big.NewInt(1000),
+		CommissionReceiverAddress: commissionReceiver,
+	}
+
+	t.Run("no history", func(t *testing.T) {
+		result, err := txbuilder.NewTxBuilder(networkParams).BuildBTCTransferTx(params)
+		require.NoError(t, err)
+		require.Nil(t, result.AddressReuseWarnings)
+	})
+
+	t.Run("fresh addresses", func(t *testing.T) {
+		history := txbuilder.NewAddressSet(newAddress(), newAddress())
+		result, err := txbuilder.NewTxBuilder(networkParams, txbuilder.WithAddressHistory(history)).BuildBTCTransferTx(params)
+		require.NoError(t, err)
+		require.Empty(t, result.AddressReuseWarnings)
+	})
+
+	t.Run("reused recipient and change", func(t *testing.T) {
+		// INFO: commission receiver address is reused by design, so it is not reported.
+		history := txbuilder.NewAddressSet(recipient, wallet.Address, commissionReceiver)
+		result, err := txbuilder.NewTxBuilder(networkParams, txbuilder.WithAddressHistory(history)).BuildBTCTransferTx(params)
+		require.NoError(t, err)
+		require.Equal(t, []txbuilder.AddressReuseWarning{
+			{Output: 0, Role: txbuilder.OutputRoleRecipient, Address: recipient},
+			{Output: 2, Role: txbuilder.OutputRoleChange, Address: wallet.Address},
+		}, result.AddressReuseWarnings)
+		require.Equal(t, "recipient output 0 reuses address "+recipient, result.AddressReuseWarnings[0].String())
+	})
+
+	t.Run("raw transfer", func(t *testing.T) {
+		history := txbuilder.NewAddressSet(wallet.Address)
+		result, err := txbuilder.NewTxBuilder(networkParams, txbuilder.WithAddressHistory(history)).BuildBTCTransferRawTx(params)
+		require.NoError(t, err)
+		require.Equal(t, []txbuilder.AddressReuseWarning{
+			{Output: 2, Role: txbuilder.OutputRoleChange, Address: wallet.Address},
+		}, result.AddressReuseWarnings)
+	})
+
+	t.Run("channel funding", func(t *testing.T) {
+		// INFO: BOLT-3 funding keys.
+		fundingParams := txbuilder.BaseChannelFundingTxParams{
+			Funder:               wallet.PaymentData("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 850000),
+			FundingOutputType:    txbuilder.FundingOutputP2WSH,
+			LocalFundingPubKey:   "023da092f6980e58d2c037173180e9a465476026ee50f96695963e8efe436f54eb",
+			RemoteFundingPubKey:  "030e9f7b623d2ccc7c9bd44d66d5ce21ce504c0acf6385a132cec6d3c39fa711c1",
+			FundingSatoshiAmount: big.NewInt(500000),
+			SatoshiPerKVByte:     big.NewInt(5000),
+		}
+
+		history := txbuilder.NewAddressSet(wallet.Address)
+		result, err := txbuilder.NewTxBuilder(networkParams, txbuilder.WithAddressHistory(history)).BuildChannelFundingTx(fundingParams)
+		require.NoError(t, err)
+		require.Len(t, result.AddressReuseWarnings, 1)
+		require.Equal(t, txbuilder.OutputRoleChange, result.AddressReuseWarnings[0].Role)
+		require.Equal(t, wallet.Address, result.AddressReuseWarnings[0].Address)
+	})
+
+	t.Run("history error", func(t *testing.T) {
+		historyErr := errors.New("history is unavailable")
+		builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithAddressHistory(failingAddressHistory{err: historyErr}))
+		_, err := builder.BuildBTCTransferTx(params)
+		require.ErrorIs(t, err, historyErr)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"github.com/BoostyLabs/blockchain/bitcoin"
+)
+
+// TransferAmount returns TransferSatoshiAmount as btc amount.
+func (params *BaseBTCTransferParams) TransferAmount() (bitcoin.Amount, error) {
+	return bitcoin.AmountFromSatoshi(params.TransferSatoshiAmount)
+}
+
+// SetTransferAmount sets TransferSatoshiAmount from btc amount.
+func (params *BaseBTCTransferParams) SetTransferAmount(amount bitcoin.Amount) {
+	params.TransferSatoshiAmount = amount.Satoshi()
+}
+
+// CommissionAmount returns SatoshiCommissionAmount as btc amount, 0 if commission is not set.
+func (params *BaseBTCTransferParams) CommissionAmount() (bitcoin.Amount, error) {
+	return bitcoin.AmountFromSatoshi(params.SatoshiCommissionAmount)
+}
+
+// SetCommissionAmount sets SatoshiCommissionAmount from btc amount.
+func (params *BaseBTCTransferParams) SetCommissionAmount(amount bitcoin.Amount) {
+	params.SatoshiCommissionAmount = amount.Satoshi()
+}
+
+// CommissionAmount returns SatoshiCommissionAmount as btc amount, 0 if commission is not set.
+func (params *BaseRunesTransferParams) CommissionAmount() (bitcoin.Amount, error) {
+	return bitcoin.AmountFromSatoshi(params.SatoshiCommissionAmount)
+}
+
+// SetCommissionAmount sets SatoshiCommissionAmount from btc amount.
+func (params *BaseRunesTransferParams) SetCommissionAmount(amount bitcoin.Amount) {
+	params.SatoshiCommissionAmount = amount.Satoshi()
+}
+
+// TransferAmount returns TransferSatoshiAmount as btc amount.
+func (params *BaseRunesAndBTCTransferParams) TransferAmount() (bitcoin.Amount, error) {
+	return bitcoin.AmountFromSatoshi(params.TransferSatoshiAmount)
+}
+
+// SetTransferAmount sets TransferSatoshiAmount from btc amount.
+func (params *BaseRunesAndBTCTransferParams) SetTransferAmount(amount bitcoin.Amount) {
+	params.TransferSatoshiAmount = amount.Satoshi()
+}
+
+// FundingAmount returns FundingSatoshiAmount as btc amount.
+func (params *BaseChannelFundingTxParams) FundingAmount() (bitcoin.Amount, error) {
+	return bitcoin.AmountFromSatoshi(params.FundingSatoshiAmount)
+}
+
+// SetFundingAmount sets FundingSatoshiAmount from btc amount.
+func (params *BaseChannelFundingTxParams) SetFundingAmount(amount bitcoin.Amount) {
+	params.FundingSatoshiAmount = amount.Satoshi()
+}
+
+// CommissionAmount returns SatoshiCommissionAmount as btc amount, 0 if commission is not set.
+func (params *BaseRuneMintTxsParams) CommissionAmount() (bitcoin.Amount, error) {
+	return bitcoin.AmountFromSatoshi(params.SatoshiCommissionAmount)
+}
+
+// SetCommissionAmount sets SatoshiCommissionAmount from btc amount.
+func (params *BaseRuneMintTxsParams) SetCommissionAmount(amount bitcoin.Amount) {
+	params.SatoshiCommissionAmount = amount.Satoshi()
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestParamsAmounts(t *testing.T) {
+	amount, err := bitcoin.AmountFromBTCString("0.000295")
+	require.NoError(t, err)
+
+	var params txbuilder.BaseRunesAndBTCTransferParams
+	params.SetTransferAmount(amount)
+	require.Equal(t, big.NewInt(29500), params.TransferSatoshiAmount)
+
+	commission, err := params.CommissionAmount()
+	require.NoError(t, err)
+	require.True(t, commission.IsZero())
+
+	params.SetCommissionAmount(amount)
+	require.Equal(t, big.NewInt(29500), params.SatoshiCommissionAmount)
+
+	transfer, err := params.TransferAmount()
+	require.NoError(t, err)
+	require.Equal(t, "0.00029500", transfer.BTCString())
+
+	params.TransferSatoshiAmount = big.NewInt(-1)
+	_, err = params.TransferAmount()
+	require.ErrorIs(t, err, bitcoin.ErrNegativeAmount)
+}
@@ -0,0 +1,253 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// ErrInvalidAnchor describes that the transaction can not have or does not have pay to anchor (P2A) output.
+var ErrInvalidAnchor = errors.New("invalid ephemeral anchor")
+
+// BuildAnchorSpendTxParams describes data needed to build child transaction spending parent anchor output.
+type BuildAnchorSpendTxParams struct {
+	// ParentSignedPSBT is signed parent transaction with P2A output in PSBT format. Inputs should
+	// hold previous outputs data to calculate the fee.
+	ParentSignedPSBT []byte
+	FeePayer         *PaymentData // fee payer data, its utxos are spent to pay the package fee.
+	SatoshiPerKVByte *big.Int     // target package fee rate in satoshi per kilo virtual byte.
+}
+
+// BuildAnchorSpendTxResult describes result of the BuildAnchorSpendTx.
+type BuildAnchorSpendTxResult struct {
+	// SerializedPSBT is unsigned child transaction, anchor input is already finalized.
+	SerializedPSBT        []byte
+	AnchorInputIndex      int             // index of the child input spending the anchor.
+	UsedFeePayerBaseUTXOs []*bitcoin.UTXO // used fee payer's bitcoin utxos in transaction.
+	Fee                   *big.Int        // fee in satoshi of the child transaction.
+	PackageVSize          int64           // virtual size of the parent and child in vBytes.
+	// PackageSatoshiPerKVByte is fee rate of the parent and child in satoshi per kilo virtual byte.
+	PackageSatoshiPerKVByte *big.Int
+	// AddressReuseWarnings are outputs reusing addresses from AddressHistory, if configured.
+	AddressReuseWarnings []AddressReuseWarning
+}
+
+// BuildAnchorSpendTx constructs CPFP child transaction spending parent pay to anchor (P2A) output
+// and fee payer utxos to bring the package fee rate to the target.
+//
+//	Tx struct
+//	inputs:
+//	┌─────────┬──────────────┬────────────────────────────────────────┐
+//	│  index  │     type     │             description                │
+//	├=========┼==============┼========================================┤
+//	│       0 │ anchor input │ parent P2A output, keyless, spent with │
+//	│         │              │ empty witness.                         │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│   1 - n │ base inputs  │ fee payer's utxos with bitcoin only,   │
+//	│         │              │ to pay parent and child fee.           │
+//	└─────────┴──────────────┴────────────────────────────────────────┘
+//
+//	outputs:
+//	┌─────────┬──────────────┬────────────────────────────────────────┐
+//	│  index  │     type     │             description                │
+//	├=========┼==============┼========================================┤
+//	│       0 │ base output  │ mandatory, output to change fee        │
+//	│         │              │ payer's bitcoins amount.               │
+//	└─────────┴──────────────┴────────────────────────────────────────┘
+//
+// NOTE: Zero fee parent is relayed only as a package with the child, zero value anchor (ephemeral dust)
+// requires Bitcoin Core 29+. Child of the version 3 (TRUC) parent is version 3 as well and limited by 1000 vB.
+func (b *TxBuilder) BuildAnchorSpendTx(params BuildAnchorSpendTxParams) (BuildAnchorSpendTxResult, error) {
+	return b.BuildAnchorSpendTxContext(context.Background(), params)
+}
+
+// BuildAnchorSpendTxContext is like BuildAnchorSpendTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildAnchorSpendTxContext(ctx context.Context, params BuildAnchorSpendTxParams) (result BuildAnchorSpendTxResult, _ error) {
+	builder := b.snapshot()
+
+	if params.SatoshiPerKVByte == nil {
+		return result, fmt.Errorf("%w: target fee rate is required", ErrFeeRateOutOfBounds)
+	}
+	if err := builder.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return result, err
+	}
+	if params.FeePayer == nil || len(params.FeePayer.UTXOs) == 0 {
+		return result, fmt.Errorf("%w: fee payer", ErrNoUTXOs)
+	}
+
+	chain, err := parseChain(ctx, []ChainTx{{SignedPSBT: params.ParentSignedPSBT, ChangeOutputIndex: -1}})
+	if err != nil {
+		return result, err
+	}
+	parent := chain[0]
+
+	anchorIndex := slices.IndexFunc(parent.packet.UnsignedTx.TxOut, func(output *wire.TxOut) bool {
+		return bitcoin.IsP2AScript(output.PkScript)
+	})
+	if anchorIndex < 0 {
+		return result, fmt.Errorf("%w: parent has no P2A output", ErrInvalidAnchor)
+	}
+
+	anchor := parent.packet.UnsignedTx.TxOut[anchorIndex]
+	anchorUTXO := &bitcoin.UTXO{
+		Outpoint: bitcoin.Outpoint{Hash: parent.hash, Index: uint32(anchorIndex)},
+		Amount:   big.NewInt(anchor.Value),
+		Script:   anchor.PkScript,
+	}
+
+	changeAddress, err := builder.DecodeAddress(params.FeePayer.Address)
+	if err != nil {
+		return result, err
+	}
+	changeScript, err := txscript.PayToAddrScript(changeAddress)
+	if err != nil {
+		return result, err
+	}
+
+	tx := wire.NewMsgTx(parent.packet.UnsignedTx.Version)
+	tx.AddTxIn(wire.NewTxIn(anchorUTXO.WireOutPoint(), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(0, changeScript))
+
+	var (
+		total   = new(big.Int).Set(anchorUTXO.Amount)
+		fee     *big.Int
+		vSize   int64
+		covered bool
+	)
+	// INFO: fee payer utxos cover the child fee and non-dust change.
+	err = builder.beforeSelect(ctx, SelectInfo{
+		UTXOs:            params.FeePayer.UTXOs,
+		TransferAmount:   builder.config.DustAmount,
+		SatoshiPerKVByte: params.SatoshiPerKVByte,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for i := range params.FeePayer.UTXOs {
+		if err = ctx.Err(); err != nil {
+			return result, err
+		}
+
+		utxo := &params.FeePayer.UTXOs[i]
+		tx.AddTxIn(wire.NewTxIn(utxo.WireOutPoint(), nil, nil))
+		result.UsedFeePayerBaseUTXOs = append(result.UsedFeePayerBaseUTXOs, utxo)
+		total.Add(total, utxo.Amount)
+
+		weightReport, err := unsignedTxWeight(tx, append([]*bitcoin.UTXO{anchorUTXO}, result.UsedFeePayerBaseUTXOs...))
+		if err != nil {
+			return result, fmt.Errorf("fee payer: %w", err)
+		}
+		vSize = weightReport.VSize
+
+		fee = new(big.Int).Sub(feeForVSize(parent.vSize+vSize, params.SatoshiPerKVByte), parent.fee)
+		if fee.Sign() < 0 {
+			fee.SetInt64(0)
+		}
+
+		if numbers.IsGreater(new(big.Int).Sub(total, fee), builder.config.DustAmount) {
+			covered = true
+			break
+		}
+	}
+	if !covered {
+		return result, NewInsufficientError(InsufficientErrorTypeBitcoin, new(big.Int).Add(fee, builder.config.DustAmount),
+			total).setCauser(CauserFeePayer)
+	}
+
+	tx.TxOut[0].Value = new(big.Int).Sub(total, fee).Int64()
+
+	builder.config.orderInputs(tx)
+
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindAnchorSpend,
+		UnsignedRawTx: tx,
+		UsedUTXOs:     usedUTXOs([]*bitcoin.UTXO{anchorUTXO}, result.UsedFeePayerBaseUTXOs),
+		EstimatedFee:  fee,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.SerializedPSBT, result.AnchorInputIndex, err = builder.buildAnchorSpendPSBT(tx, anchorUTXO,
+		result.UsedFeePayerBaseUTXOs, params.FeePayer)
+	if err != nil {
+		return result, err
+	}
+
+	result.Fee = fee
+	result.PackageVSize = parent.vSize + vSize
+	result.PackageSatoshiPerKVByte = feeRate(new(big.Int).Add(parent.fee, fee), result.PackageVSize)
+	result.AddressReuseWarnings, err = addressReuseWarnings(ctx, builder.config.AddressHistory, tx,
+		[]OutputRole{OutputRoleChange}, builder.networkParams)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// buildAnchorSpendPSBT returns serialised PSBT of the anchor spending transaction and index of the anchor input.
+func (b *TxBuilder) buildAnchorSpendPSBT(tx *wire.MsgTx, anchorUTXO *bitcoin.UTXO, feePayerUTXOs []*bitcoin.UTXO,
+	feePayer *PaymentData) ([]byte, int, error) {
+	p, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	feePayerInputBuilder, err := NewPSBTInputBuilder(feePayer.PubKey, feePayer.Address, b.networkParams)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// INFO: inputs could be reordered, see TxBuilderConfig.InputOrdering.
+	inputs := newInputIndexer(tx)
+	anchorIndex, err := inputs.index(anchorUTXO)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// INFO: anchor is spent with empty witness, serialized as zero items count.
+	p.Inputs[anchorIndex].WitnessUtxo = wire.NewTxOut(anchorUTXO.Amount.Int64(), anchorUTXO.Script)
+	p.Inputs[anchorIndex].FinalScriptWitness = []byte{0x00}
+
+	feePayerIndexes := make([]byte, len(feePayerUTXOs))
+	for i, utxo := range feePayerUTXOs {
+		index, err := inputs.index(utxo)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		feePayerInputBuilder.PrepareInput(&(p.Inputs[index]))
+		p.Inputs[index].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
+		p.Inputs[index].SighashType = signHashType
+		feePayerIndexes[i] = byte(index)
+	}
+
+	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: b.config.inputsHelpingKey(feePayerInputBuilder.InputsHelpingKey(true)), Value: feePayerIndexes})
+
+	if b.config.OutputRoles {
+		setOutputRoles(p, []OutputRole{OutputRoleChange})
+	}
+
+	w := bytes.NewBuffer(nil)
+	if err = p.Serialize(w); err != nil {
+		return nil, 0, err
+	}
+
+	return w.Bytes(), anchorIndex, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestBuildAnchorSpendTx(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	sign := func(t *testing.T, serializedPSBT []byte, inputs ...int) []byte {
+		signed, err := signer.NewSigner(networkParams).SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: serializedPSBT,
+			Inputs:         inputs,
+			PrivateKey:     wallet.PrivateKey,
+		})
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithOutputRoles())
+	params := txbuilder.BaseBTCTransferParams{
+		Sender:                wallet.PaymentData("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 100000),
+		TransferSatoshiAmount: big.NewInt(10000),
+		SatoshiPerKVByte:      big.NewInt(0),
+		RecipientAddress:      wallet.Address,
+		EphemeralAnchor:       true,
+	}
+
+	parent, err := builder.BuildBTCTransferTx(params)
+	require.NoError(t, err)
+	require.Zero(t, parent.EstimatedFee.Sign())
+
+	roles, err := txbuilder.ExtractOutputRolesFromPSBT(parent.SerializedPSBT)
+	require.NoError(t, err)
+	require.Equal(t, []txbuilder.OutputRole{txbuilder.OutputRoleRecipient, txbuilder.OutputRoleAnchor,
+		txbuilder.OutputRoleChange}, roles)
+
+	signedParent := sign(t, parent.SerializedPSBT, 0)
+
+	t.Run("anchor spend", func(t *testing.T) {
+		result, err := builder.BuildAnchorSpendTx(txbuilder.BuildAnchorSpendTxParams{
+			ParentSignedPSBT: signedParent,
+			FeePayer:         wallet.PaymentData("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 500, 20000),
+			SatoshiPerKVByte: big.NewInt(10000), // 10 sat/vB.
+		})
+		require.NoError(t, err)
+		require.Len(t, result.UsedFeePayerBaseUTXOs, 2)
+		require.True(t, result.PackageSatoshiPerKVByte.Cmp(big.NewInt(10000)) >= 0)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxIn, 3)
+		require.Len(t, p.UnsignedTx.TxOut, 1)
+		require.EqualValues(t, 20500-result.Fee.Int64(), p.UnsignedTx.TxOut[0].Value)
+
+		anchorInput := p.UnsignedTx.TxIn[result.AnchorInputIndex].PreviousOutPoint
+		parentPacket, err := psbt.NewFromRawBytes(bytes.NewReader(signedParent), false)
+		require.NoError(t, err)
+		require.Equal(t, parentPacket.UnsignedTx.TxHash(), anchorInput.Hash)
+		require.True(t, bitcoin.IsP2AScript(parentPacket.UnsignedTx.TxOut[anchorInput.Index].PkScript))
+
+		// INFO: signed child is not larger than estimated, so the package pays at least the target rate.
+		p, err = psbt.NewFromRawBytes(bytes.NewReader(sign(t, result.SerializedPSBT, 1, 2)), false)
+		require.NoError(t, err)
+		require.NoError(t, psbt.MaybeFinalizeAll(p))
+		child, err := psbt.Extract(p)
+		require.NoError(t, err)
+		require.Empty(t, child.TxIn[result.AnchorInputIndex].Witness)
+
+		childVSize := (int64(child.SerializeSizeStripped())*3 + int64(child.SerializeSize()) + 3) / 4
+		require.NoError(t, psbt.MaybeFinalizeAll(parentPacket))
+		parentTx, err := psbt.Extract(parentPacket)
+		require.NoError(t, err)
+		parentVSize := (int64(parentTx.SerializeSizeStripped())*3 + int64(parentTx.SerializeSize()) + 3) / 4
+		require.LessOrEqual(t, parentVSize+childVSize, result.PackageVSize)
+	})
+
+	t.Run("insufficient", func(t *testing.T) {
+		_, err := builder.BuildAnchorSpendTx(txbuilder.BuildAnchorSpendTxParams{
+			ParentSignedPSBT: signedParent,
+			FeePayer:         wallet.PaymentData("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 1000),
+			SatoshiPerKVByte: big.NewInt(10000),
+		})
+		require.ErrorAs(t, err, new(*txbuilder.InsufficientError))
+	})
+
+	t.Run("invalid anchor", func(t *testing.T) {
+		withFee := params
+		withFee.SatoshiPerKVByte = big.NewInt(1000)
+		_, err := builder.BuildBTCTransferTx(withFee)
+		require.ErrorIs(t, err, txbuilder.ErrInvalidAnchor)
+
+		withoutAnchor := params
+		withoutAnchor.EphemeralAnchor = false
+		plain, err := builder.BuildBTCTransferTx(withoutAnchor)
+		require.NoError(t, err)
+
+		_, err = builder.BuildAnchorSpendTx(txbuilder.BuildAnchorSpendTxParams{
+			ParentSignedPSBT: sign(t, plain.SerializedPSBT, 0),
+			FeePayer:         wallet.PaymentData("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 20000),
+			SatoshiPerKVByte: big.NewInt(10000),
+		})
+		require.ErrorIs(t, err, txbuilder.ErrInvalidAnchor)
+	})
+}
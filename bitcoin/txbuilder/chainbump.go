@@ -0,0 +1,421 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+var (
+	// ErrInvalidChain describes that provided transactions do not form a single unconfirmed chain.
+	ErrInvalidChain = errors.New("invalid transactions chain")
+	// ErrPackageLimits describes that the bump violates mempool ancestor, descendant or replacement limits.
+	ErrPackageLimits = errors.New("package limits exceeded")
+	// ErrBumpNotPossible describes that the chain can not be bumped by the requested strategy.
+	ErrBumpNotPossible = errors.New("chain bump is not possible")
+)
+
+// BumpStrategy defines the way the chain of unconfirmed transactions is bumped.
+type BumpStrategy string
+
+const (
+	// BumpStrategyAuto defines CPFP if the tip change output can be spent, RBF otherwise.
+	BumpStrategyAuto BumpStrategy = ""
+	// BumpStrategyRBF defines replacement of the lowest ancestor with higher fee, descendants are evicted.
+	BumpStrategyRBF BumpStrategy = "rbf"
+	// BumpStrategyCPFP defines child transaction spending the tip change output.
+	BumpStrategyCPFP BumpStrategy = "cpfp"
+)
+
+// Default mempool policy limits.
+const (
+	// DefaultMaxAncestors defines maximum count of the in-mempool ancestors including the transaction.
+	DefaultMaxAncestors = 25
+	// DefaultMaxDescendants defines maximum count of the in-mempool descendants including the transaction.
+	DefaultMaxDescendants = 25
+	// DefaultMaxPackageVSize defines maximum virtual size of the ancestors or descendants package in vBytes.
+	DefaultMaxPackageVSize int64 = 101_000
+	// DefaultIncrementalSatoshiPerKVByte defines minimum fee rate increase of the replacement.
+	DefaultIncrementalSatoshiPerKVByte int64 = 1000
+
+	// maxReplacementEvictions defines maximum count of transactions evicted by the replacement (BIP-125 rule 5).
+	maxReplacementEvictions = 100
+)
+
+// ChainTx describes our unconfirmed transaction of the chain.
+type ChainTx struct {
+	// SignedPSBT is signed transaction in PSBT format. Inputs should not be finalized
+	// and should hold previous outputs data to calculate the fee.
+	SignedPSBT        []byte
+	ChangeOutputIndex int // our output to decrease (RBF) or to spend (CPFP), -1 if there is no one.
+}
+
+// BuildChainBumpTxParams describes data needed to bump chain of unconfirmed transactions.
+type BuildChainBumpTxParams struct {
+	Chain            []ChainTx    // unconfirmed transactions in any order, all ancestors must be included.
+	SatoshiPerKVByte *big.Int     // target package fee rate in satoshi per kilo virtual byte.
+	Strategy         BumpStrategy // optional, BumpStrategyAuto if not set.
+	ChangePubKey     string       // public key of the change outputs owner, used to prepare child input.
+	// IncrementalSatoshiPerKVByte is a minimum relay fee rate increase, optional, 1000 if not set.
+	IncrementalSatoshiPerKVByte *big.Int
+	MaxAncestors                int   // optional, DefaultMaxAncestors if not set.
+	MaxDescendants              int   // optional, DefaultMaxDescendants if not set.
+	MaxPackageVSize             int64 // optional, DefaultMaxPackageVSize if not set.
+}
+
+// BuildChainBumpTxResult describes result of the BuildChainBumpTx.
+type BuildChainBumpTxResult struct {
+	PackageVSize            int64    // exact virtual size of the chain in vBytes.
+	PackageFee              *big.Int // fee in satoshi paid by the chain.
+	PackageSatoshiPerKVByte *big.Int // fee rate of the chain in satoshi per kilo virtual byte.
+	Strategy                BumpStrategy
+	BumpedTxIndex           int   // chain index of the replaced (RBF) or spent (CPFP) transaction.
+	EvictedTxIndexes        []int // chain indexes of the replaced transaction descendants, RBF only.
+	// SerializedPSBT is unsigned replacement or child transaction, nil if the chain pays target fee rate.
+	SerializedPSBT []byte
+	Fee            *big.Int // fee in satoshi of the replacement or child transaction.
+	// BumpedSatoshiPerKVByte is fee rate of the replacement or of the chain with child after the bump.
+	BumpedSatoshiPerKVByte *big.Int
+}
+
+// chainTx describes parsed chain transaction.
+type chainTx struct {
+	packet      *psbt.Packet
+	hash        chainhash.Hash
+	fee         *big.Int
+	vSize       int64
+	change      int
+	parents     []int
+	children    []int
+	ancestors   int // count of the in-chain ancestors.
+	descendants int // count of the in-chain descendants.
+}
+
+// BuildChainBumpTx analyzes chain of our unconfirmed transactions and constructs a single transaction
+// to bring the package fee rate to the target: either replacement of the lowest ancestor with decreased
+// change (RBF) or child spending the tip change output (CPFP). Mempool ancestor and descendant limits
+// and BIP-125 replacement rules are honored.
+// NOTE: RBF evicts all descendants of the replaced transaction, they should be rebuilt on top of the
+// replacement. Replacement is built without signaling check, since full RBF is the default relay policy.
+func (b *TxBuilder) BuildChainBumpTx(params BuildChainBumpTxParams) (BuildChainBumpTxResult, error) {
+	return b.BuildChainBumpTxContext(context.Background(), params)
+}
+
+// BuildChainBumpTxContext is like BuildChainBumpTx, but stops chain parsing with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildChainBumpTxContext(ctx context.Context, params BuildChainBumpTxParams) (result BuildChainBumpTxResult, _ error) {
+	builder := b.snapshot()
+
+	if params.SatoshiPerKVByte == nil {
+		return result, fmt.Errorf("%w: target fee rate is required", ErrFeeRateOutOfBounds)
+	}
+	if params.IncrementalSatoshiPerKVByte == nil {
+		params.IncrementalSatoshiPerKVByte = big.NewInt(DefaultIncrementalSatoshiPerKVByte)
+	}
+	if params.MaxAncestors <= 0 {
+		params.MaxAncestors = DefaultMaxAncestors
+	}
+	if params.MaxDescendants <= 0 {
+		params.MaxDescendants = DefaultMaxDescendants
+	}
+	if params.MaxPackageVSize <= 0 {
+		params.MaxPackageVSize = DefaultMaxPackageVSize
+	}
+
+	chain, err := parseChain(ctx, params.Chain)
+	if err != nil {
+		return result, err
+	}
+
+	result.PackageFee = big.NewInt(0)
+	for _, tx := range chain {
+		result.PackageFee.Add(result.PackageFee, tx.fee)
+		result.PackageVSize += tx.vSize
+	}
+	result.PackageSatoshiPerKVByte = feeRate(result.PackageFee, result.PackageVSize)
+	result.BumpedTxIndex = -1
+
+	if !numbers.IsLess(result.PackageSatoshiPerKVByte, params.SatoshiPerKVByte) {
+		return result, nil
+	}
+
+	switch params.Strategy {
+	case BumpStrategyCPFP:
+		return builder.buildChildBumpTx(params, chain, result)
+	case BumpStrategyRBF:
+		return builder.buildReplacementBumpTx(params, chain, result)
+	case BumpStrategyAuto:
+		cpfpResult, cpfpErr := builder.buildChildBumpTx(params, chain, result)
+		if cpfpErr == nil {
+			return cpfpResult, nil
+		}
+		if err = ctx.Err(); err != nil {
+			return result, err
+		}
+
+		rbfResult, rbfErr := builder.buildReplacementBumpTx(params, chain, result)
+		if rbfErr != nil {
+			return result, errors.Join(cpfpErr, rbfErr)
+		}
+
+		return rbfResult, nil
+	default:
+		return result, fmt.Errorf("%w: unknown strategy %q", ErrBumpNotPossible, params.Strategy)
+	}
+}
+
+// buildChildBumpTx constructs child transaction spending change output of the tip, which has all other
+// chain transactions as ancestors.
+func (b *TxBuilder) buildChildBumpTx(params BuildChainBumpTxParams, chain []chainTx, result BuildChainBumpTxResult) (BuildChainBumpTxResult, error) {
+	tip := slices.IndexFunc(chain, func(tx chainTx) bool { return tx.ancestors == len(chain)-1 && tx.change >= 0 })
+	if tip < 0 {
+		return result, fmt.Errorf("%w: cpfp: no tip with change output spending the whole chain", ErrBumpNotPossible)
+	}
+
+	// INFO: child is counted as ancestor of itself and as descendant of every chain transaction.
+	if len(chain)+1 > params.MaxAncestors || len(chain)+1 > params.MaxDescendants {
+		return result, fmt.Errorf("%w: cpfp: %d transactions with child", ErrPackageLimits, len(chain)+1)
+	}
+
+	if params.ChangePubKey == "" {
+		return result, fmt.Errorf("%w: cpfp: change public key is required", ErrInvalidPubKey)
+	}
+
+	change := chain[tip].packet.UnsignedTx.TxOut[chain[tip].change]
+	changeUTXO := &bitcoin.UTXO{
+		Outpoint: bitcoin.Outpoint{Hash: chain[tip].hash, Index: uint32(chain[tip].change)},
+		Amount:   big.NewInt(change.Value),
+		Script:   change.PkScript,
+	}
+
+	tx := wire.NewMsgTx(txVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chain[tip].hash, uint32(chain[tip].change)), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(change.Value, change.PkScript))
+
+	weightReport, err := unsignedTxWeight(tx, []*bitcoin.UTXO{changeUTXO})
+	if err != nil {
+		return result, fmt.Errorf("%w: cpfp: change output: %w", ErrBumpNotPossible, err)
+	}
+	vSize := weightReport.VSize
+	if result.PackageVSize+vSize > params.MaxPackageVSize {
+		return result, fmt.Errorf("%w: cpfp: package size %d vB", ErrPackageLimits, result.PackageVSize+vSize)
+	}
+
+	fee := new(big.Int).Sub(feeForVSize(result.PackageVSize+vSize, params.SatoshiPerKVByte), result.PackageFee)
+	fee = numbers.Max(fee, feeForVSize(vSize, params.IncrementalSatoshiPerKVByte))
+
+	tx.TxOut[0].Value -= fee.Int64()
+	if tx.TxOut[0].Value < b.config.DustAmount.Int64() {
+		return result, NewInsufficientError(InsufficientErrorTypeBitcoin, new(big.Int).Add(fee, b.config.DustAmount),
+			changeUTXO.Amount).setCauser(CauserSender)
+	}
+
+	_, addresses, _, err := txscript.ExtractPkScriptAddrs(change.PkScript, b.networkParams)
+	if err != nil || len(addresses) != 1 {
+		return result, fmt.Errorf("%w: cpfp: change output address", ErrBumpNotPossible)
+	}
+
+	serializedPSBT, err := b.buildBTCTransferPSBT(BuildBTCTransferPSBTParams{
+		BaseBTCTransferResult: BaseBTCTransferResult{
+			UnsignedRawTx:       tx,
+			UsedSenderBaseUTXOs: []*bitcoin.UTXO{changeUTXO},
+			OutputRoles:         []OutputRole{OutputRoleChange},
+		},
+		SenderAddress: addresses[0].EncodeAddress(),
+		SenderPubKey:  params.ChangePubKey,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Strategy = BumpStrategyCPFP
+	result.BumpedTxIndex = tip
+	result.SerializedPSBT = serializedPSBT
+	result.Fee = fee
+	result.BumpedSatoshiPerKVByte = feeRate(new(big.Int).Add(result.PackageFee, fee), result.PackageVSize+vSize)
+
+	return result, nil
+}
+
+// buildReplacementBumpTx constructs replacement of the lowest ancestor, which has all other chain
+// transactions as descendants, with the fee taken from its change output.
+func (b *TxBuilder) buildReplacementBumpTx(params BuildChainBumpTxParams, chain []chainTx, result BuildChainBumpTxResult) (BuildChainBumpTxResult, error) {
+	root := slices.IndexFunc(chain, func(tx chainTx) bool { return tx.descendants == len(chain)-1 && tx.change >= 0 })
+	if root < 0 {
+		return result, fmt.Errorf("%w: rbf: no lowest ancestor with change output funding the whole chain", ErrBumpNotPossible)
+	}
+
+	if len(chain) > maxReplacementEvictions {
+		return result, fmt.Errorf("%w: rbf: %d transactions evicted", ErrPackageLimits, len(chain))
+	}
+
+	// INFO: replacement pays for all evicted transactions and for its own relay (BIP-125 rules 3 and 4).
+	vSize := chain[root].vSize
+	fee := numbers.Max(feeForVSize(vSize, params.SatoshiPerKVByte),
+		new(big.Int).Add(result.PackageFee, feeForVSize(vSize, params.IncrementalSatoshiPerKVByte)))
+
+	var serialized bytes.Buffer
+	if err := chain[root].packet.Serialize(&serialized); err != nil {
+		return result, err
+	}
+
+	p, err := psbt.NewFromRawBytes(&serialized, false)
+	if err != nil {
+		return result, err
+	}
+
+	change := p.UnsignedTx.TxOut[chain[root].change]
+	change.Value -= new(big.Int).Sub(fee, chain[root].fee).Int64()
+	if change.Value < b.config.DustAmount.Int64() {
+		have := big.NewInt(chain[root].packet.UnsignedTx.TxOut[chain[root].change].Value)
+		need := new(big.Int).Add(new(big.Int).Sub(fee, chain[root].fee), b.config.DustAmount)
+
+		return result, NewInsufficientError(InsufficientErrorTypeBitcoin, need, have).setCauser(CauserSender)
+	}
+
+	clearSignatures(p)
+
+	w := bytes.NewBuffer(nil)
+	if err = p.Serialize(w); err != nil {
+		return result, err
+	}
+
+	for i := range chain {
+		if i != root {
+			result.EvictedTxIndexes = append(result.EvictedTxIndexes, i)
+		}
+	}
+
+	result.Strategy = BumpStrategyRBF
+	result.BumpedTxIndex = root
+	result.SerializedPSBT = w.Bytes()
+	result.Fee = fee
+	result.BumpedSatoshiPerKVByte = feeRate(fee, vSize)
+
+	return result, nil
+}
+
+// parseChain parses chain transactions, calculates their fees and sizes and links parents with children.
+func parseChain(ctx context.Context, txs []ChainTx) ([]chainTx, error) {
+	if len(txs) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidChain)
+	}
+
+	chain := make([]chainTx, len(txs))
+	byHash := make(map[chainhash.Hash]int, len(txs))
+	for i, tx := range txs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(tx.SignedPSBT), false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: transaction %d: %w", ErrInvalidChain, i, err)
+		}
+
+		if tx.ChangeOutputIndex >= len(p.UnsignedTx.TxOut) {
+			return nil, fmt.Errorf("%w: transaction %d: index %d of %d outputs", ErrInvalidChangeOutput, i,
+				tx.ChangeOutputIndex, len(p.UnsignedTx.TxOut))
+		}
+
+		fee, err := psbtFee(p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: transaction %d: %w", ErrInvalidChain, i, err)
+		}
+
+		// INFO: finalization is applied to the parsed copy to calculate exact size.
+		finalized, err := psbt.NewFromRawBytes(bytes.NewReader(tx.SignedPSBT), false)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = psbt.MaybeFinalizeAll(finalized); err != nil {
+			return nil, fmt.Errorf("%w: transaction %d: %w", ErrInvalidChain, i, err)
+		}
+
+		signedTx, err := psbt.Extract(finalized)
+		if err != nil {
+			return nil, fmt.Errorf("%w: transaction %d: %w", ErrInvalidChain, i, err)
+		}
+		_, vSize := bitcoin.MsgTxWeight(signedTx)
+
+		chain[i] = chainTx{
+			packet: p,
+			hash:   p.UnsignedTx.TxHash(),
+			fee:    fee,
+			vSize:  vSize,
+			change: max(tx.ChangeOutputIndex, -1),
+		}
+
+		if _, ok := byHash[chain[i].hash]; ok {
+			return nil, fmt.Errorf("%w: transaction %d is duplicated", ErrInvalidChain, i)
+		}
+		byHash[chain[i].hash] = i
+	}
+
+	for i := range chain {
+		for _, input := range chain[i].packet.UnsignedTx.TxIn {
+			parent, ok := byHash[input.PreviousOutPoint.Hash]
+			if !ok || slices.Contains(chain[i].parents, parent) {
+				continue
+			}
+
+			chain[i].parents = append(chain[i].parents, parent)
+			chain[parent].children = append(chain[parent].children, i)
+		}
+	}
+
+	for i := range chain {
+		chain[i].ancestors = len(reachable(chain, i, func(tx chainTx) []int { return tx.parents }))
+		chain[i].descendants = len(reachable(chain, i, func(tx chainTx) []int { return tx.children }))
+	}
+
+	return chain, nil
+}
+
+// reachable returns set of the chain transactions reachable from the transaction by the links, excluding itself.
+func reachable(chain []chainTx, from int, links func(tx chainTx) []int) map[int]struct{} {
+	visited := make(map[int]struct{})
+	stack := slices.Clone(links(chain[from]))
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := visited[i]; ok {
+			continue
+		}
+
+		visited[i] = struct{}{}
+		stack = append(stack, links(chain[i])...)
+	}
+
+	return visited
+}
+
+// feeForVSize returns fee in satoshi for the virtual size at the fee rate, rounded up.
+func feeForVSize(vSize int64, satoshiPerKVByte *big.Int) *big.Int {
+	// INFO: vB * ( sat / kvB ) = 1000 sat.
+	fee := new(big.Int).Mul(big.NewInt(vSize), satoshiPerKVByte)
+
+	return fee.Add(fee, big.NewInt(999)).Div(fee, big.NewInt(1000))
+}
+
+// feeRate returns fee rate in satoshi per kilo virtual byte.
+func feeRate(fee *big.Int, vSize int64) *big.Int {
+	rate := new(big.Int).Mul(fee, big.NewInt(1000))
+
+	return rate.Div(rate, big.NewInt(vSize))
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestBuildChainBumpTx(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	builder := txbuilder.NewTxBuilder(networkParams)
+
+	// INFO: airdrop transaction spends change of the previous one, change output is 1.
+	chain := make([]txbuilder.ChainTx, 0, 3)
+	utxo := wallet.UTXO("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2, 850000)
+	for i := 0; i < 3; i++ {
+		result, err := builder.BuildBTCTransferTx(txbuilder.BaseBTCTransferParams{
+			TransferSatoshiAmount: big.NewInt(10000),
+			Sender: &txbuilder.PaymentData{
+				UTXOs:   []bitcoin.UTXO{utxo},
+				Address: wallet.Address,
+				PubKey:  wallet.PubKey,
+			},
+			SatoshiPerKVByte: big.NewInt(1000), // 1 sat/vB.
+			RecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+		})
+		require.NoError(t, err)
+
+		signed, err := signer.NewSigner(networkParams).SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: result.SerializedPSBT,
+			Inputs:         []int{0},
+			PrivateKey:     wallet.PrivateKey,
+		})
+		require.NoError(t, err)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(signed), false)
+		require.NoError(t, err)
+
+		chain = append(chain, txbuilder.ChainTx{SignedPSBT: signed, ChangeOutputIndex: 1})
+		utxo = bitcoin.UTXO{
+			Outpoint: bitcoin.Outpoint{Hash: p.UnsignedTx.TxHash(), Index: 1},
+			Amount:   big.NewInt(p.UnsignedTx.TxOut[1].Value),
+			Script:   wallet.Script,
+			Address:  wallet.Address,
+		}
+	}
+
+	// INFO: chain order does not matter.
+	chain[0], chain[2] = chain[2], chain[0]
+
+	params := txbuilder.BuildChainBumpTxParams{
+		Chain:            chain,
+		SatoshiPerKVByte: big.NewInt(10000), // 10 sat/vB.
+		ChangePubKey:     wallet.PubKey,
+	}
+
+	t.Run("cpfp", func(t *testing.T) {
+		result, err := builder.BuildChainBumpTx(params)
+		require.NoError(t, err)
+		require.Equal(t, txbuilder.BumpStrategyCPFP, result.Strategy)
+		require.Equal(t, 0, result.BumpedTxIndex)
+		require.Empty(t, result.EvictedTxIndexes)
+		require.True(t, result.PackageSatoshiPerKVByte.Cmp(big.NewInt(10000)) < 0)
+		require.True(t, result.BumpedSatoshiPerKVByte.Cmp(big.NewInt(10000)) >= 0)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxIn, 1)
+		require.Equal(t, utxo.Hash, p.UnsignedTx.TxIn[0].PreviousOutPoint.Hash)
+		require.Equal(t, utxo.Amount.Int64()-result.Fee.Int64(), p.UnsignedTx.TxOut[0].Value)
+
+		signed, err := signer.NewSigner(networkParams).SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: result.SerializedPSBT,
+			Inputs:         []int{0},
+			PrivateKey:     wallet.PrivateKey,
+		})
+		require.NoError(t, err)
+
+		// INFO: bumped chain pays target fee rate by exact sizes.
+		bumped, err := builder.BuildChainBumpTx(txbuilder.BuildChainBumpTxParams{
+			Chain:            append(chain, txbuilder.ChainTx{SignedPSBT: signed, ChangeOutputIndex: 0}),
+			SatoshiPerKVByte: params.SatoshiPerKVByte,
+		})
+		require.NoError(t, err)
+		require.Nil(t, bumped.SerializedPSBT)
+		require.Equal(t, -1, bumped.BumpedTxIndex)
+	})
+
+	t.Run("rbf", func(t *testing.T) {
+		params := params
+		params.Strategy = txbuilder.BumpStrategyRBF
+
+		result, err := builder.BuildChainBumpTx(params)
+		require.NoError(t, err)
+		require.Equal(t, txbuilder.BumpStrategyRBF, result.Strategy)
+		require.Equal(t, 2, result.BumpedTxIndex)
+		require.Equal(t, []int{0, 1}, result.EvictedTxIndexes)
+		require.True(t, result.Fee.Cmp(result.PackageFee) > 0)
+
+		root, err := psbt.NewFromRawBytes(bytes.NewReader(chain[2].SignedPSBT), false)
+		require.NoError(t, err)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Nil(t, p.Inputs[0].TaprootKeySpendSig)
+		require.Equal(t, root.UnsignedTx.TxIn, p.UnsignedTx.TxIn)
+		require.Equal(t, root.UnsignedTx.TxOut[0], p.UnsignedTx.TxOut[0])
+		require.Less(t, p.UnsignedTx.TxOut[1].Value, root.UnsignedTx.TxOut[1].Value)
+	})
+
+	t.Run("auto", func(t *testing.T) {
+		params := params
+		params.MaxAncestors = 3
+
+		result, err := builder.BuildChainBumpTx(params)
+		require.NoError(t, err)
+		require.Equal(t, txbuilder.BumpStrategyRBF, result.Strategy)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		params := params
+		params.Strategy = txbuilder.BumpStrategyCPFP
+		params.MaxDescendants = 3
+
+		_, err := builder.BuildChainBumpTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrPackageLimits)
+
+		params.MaxDescendants = 0
+		params.Chain = []txbuilder.ChainTx{chain[0], chain[2]}
+		_, err = builder.BuildChainBumpTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrBumpNotPossible)
+
+		params.Chain = []txbuilder.ChainTx{chain[0], chain[0]}
+		_, err = builder.BuildChainBumpTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrInvalidChain)
+
+		params.Chain = nil
+		_, err = builder.BuildChainBumpTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrInvalidChain)
+
+		params.Chain = chain
+		params.Strategy = txbuilder.BumpStrategyRBF
+		params.SatoshiPerKVByte = big.NewInt(10_000_000)
+		_, err = builder.BuildChainBumpTx(params)
+
+		var errIns *txbuilder.InsufficientError
+		require.ErrorAs(t, err, &errIns)
+		require.Equal(t, txbuilder.InsufficientErrorTypeBitcoin, errIns.Type)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = builder.BuildChainBumpTxContext(ctx, params)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
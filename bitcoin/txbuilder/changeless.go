@@ -0,0 +1,67 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// maxExtraChangelessInputs defines how many inputs more than minimally required are checked to find
+// changeless utxos range, each extra input increases the fee.
+const maxExtraChangelessInputs = 2
+
+// selectChangelessUTXOs looks for the contiguous range of utxos in amount desc order, which covers
+// minimal amount with excess not greater than tolerance. Ranges with the fewest inputs are checked first.
+// Utxos are not reordered, used utxos point to the utxos elements. Returns false if there is no such range.
+func selectChangelessUTXOs(ctx context.Context, utxos []bitcoin.UTXO, amountFn func(*bitcoin.UTXO) *big.Int,
+	minAmountFn func(requiredUTXOs int) *big.Int, tolerance *big.Int) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, _ bool, _ error) {
+	n := len(utxos)
+	sorted := make([]*bitcoin.UTXO, n)
+	for idx := range utxos {
+		sorted[idx] = &utxos[idx]
+	}
+	slices.SortStableFunc(sorted, func(a, b *bitcoin.UTXO) int { return amountFn(b).Cmp(amountFn(a)) })
+
+	prefixSums := make([]*big.Int, n+1)
+	prefixSums[0] = big.NewInt(0)
+	for idx, utxo := range sorted {
+		prefixSums[idx+1] = new(big.Int).Add(prefixSums[idx], amountFn(utxo))
+	}
+
+	rangeSum := func(start, length int) *big.Int {
+		return new(big.Int).Sub(prefixSums[start+length], prefixSums[start])
+	}
+
+	lastInputs := n
+	for i := 1; i <= lastInputs; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, false, err
+		}
+
+		minAmount := minAmountFn(i)
+		if numbers.IsLess(prefixSums[i], minAmount) {
+			continue // the largest utxos do not cover min amount.
+		}
+
+		lastInputs = min(lastInputs, i+maxExtraChangelessInputs)
+
+		// INFO: range sums do not increase with start, since utxos are sorted by amount desc.
+		maxAmount := new(big.Int).Add(minAmount, tolerance)
+		start := sort.Search(n-i+1, func(k int) bool { return !numbers.IsGreater(rangeSum(k, i), maxAmount) })
+		if start > n-i || numbers.IsLess(rangeSum(start, i), minAmount) {
+			continue
+		}
+
+		minAmountFn(i) // keep estimate of the selected inputs number.
+		return sorted[start : start+i], rangeSum(start, i), true, nil
+	}
+
+	return nil, nil, false, nil
+}
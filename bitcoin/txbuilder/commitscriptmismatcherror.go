@@ -0,0 +1,29 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCommitScriptMismatch describes class of errors when inscription commitment utxo is not locked by the
+// taproot output committing to the inscription, so the reveal transaction would be unspendable.
+var ErrCommitScriptMismatch = errors.New("commit utxo script does not match the inscription")
+
+// CommitScriptMismatchError is the error type to describe commitment utxo script mismatch with details.
+type CommitScriptMismatchError struct {
+	Expected []byte // taproot output script committing to the inscription and the public key.
+	Actual   []byte // commitment utxo script pub key.
+}
+
+// Error returns error description.
+func (e *CommitScriptMismatchError) Error() string {
+	return fmt.Sprintf("%s: expected %x, actual %x", ErrCommitScriptMismatch, e.Expected, e.Actual)
+}
+
+// Is implements comparator method for [errors] package.
+func (e *CommitScriptMismatchError) Is(target error) bool {
+	return target == ErrCommitScriptMismatch //nolint: errorlint
+}
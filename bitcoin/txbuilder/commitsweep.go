@@ -0,0 +1,289 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+var (
+	// ErrNoCommits describes that no commitment outputs to sweep are provided.
+	ErrNoCommits = errors.New("no commitment outputs provided")
+	// ErrUneconomicalSweep describes that swept commitment outputs amount does not cover the fee and dust amount.
+	ErrUneconomicalSweep = errors.New("uneconomical sweep")
+)
+
+// schnorrSignatureLen defines length of the schnorr signature with non default signature hash type.
+const schnorrSignatureLen = 65
+
+// CommitSweepInput describes abandoned inscription commitment output to sweep.
+type CommitSweepInput struct {
+	UTXO              bitcoin.UTXO              // commitment output.
+	Inscription       *inscriptions.Inscription // inscription committed to the output. mandatory.
+	InscriptionPubKey string                    // inscription base public key in hex, the output internal key.
+	// Recovery defines recovery leaf of the commitment script tree, optional. If set, the output is spent
+	// by the recovery leaf script path, by the key path with the inscription key otherwise.
+	Recovery *inscriptions.RecoveryLeaf
+}
+
+// BuildCommitSweepTxsParams describes data needed to sweep abandoned inscription commitment outputs.
+type BuildCommitSweepTxsParams struct {
+	Commits          []CommitSweepInput // commitment outputs to sweep.
+	TreasuryAddress  string             // swept bitcoin recipient address.
+	SatoshiPerKVByte *big.Int           // fee rate in satoshi per kilo virtual byte.
+	MaxInputs        int                // maximum inputs per transaction, optional, limited by MaxStandardTxWeight only if not set.
+}
+
+// BuildCommitSweepTxResult describes single sweep transaction in PSBT format.
+type BuildCommitSweepTxResult struct {
+	SerializedPSBT []byte
+	Commits        []int        // indexes of the swept params commits by the transaction inputs indexes.
+	Amount         *big.Int     // swept amount in satoshi sent to the treasury address.
+	EstimatedFee   *big.Int     // estimated transaction fee in satoshi.
+	OutputRoles    []OutputRole // roles of the transaction outputs by their indexes.
+}
+
+// sweepInput describes prepared commitment input of the sweep transaction.
+type sweepInput struct {
+	txIn    *wire.TxIn
+	pInput  psbt.PInput
+	witness wire.TxWitness // placeholder witness with the size of the signed one.
+}
+
+// BuildCommitSweepTxs constructs transactions sweeping abandoned inscription commitment outputs to the treasury
+// address, batching as many commitments per transaction as fits MaxInputs and MaxStandardTxWeight. Each input
+// is prepared for taproot signing: key path inputs have internal key and merkle root of the commitment script
+// tree, recovery inputs have recovery leaf script with control block and recovery delay sequence.
+// Key path inputs are signed by the inscription key, recovery inputs by the recovery key.
+func (b *TxBuilder) BuildCommitSweepTxs(params BuildCommitSweepTxsParams) ([]BuildCommitSweepTxResult, error) {
+	return b.BuildCommitSweepTxsContext(context.Background(), params)
+}
+
+// BuildCommitSweepTxsContext is like BuildCommitSweepTxs, but stops commitments batching with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildCommitSweepTxsContext(ctx context.Context, params BuildCommitSweepTxsParams) ([]BuildCommitSweepTxResult, error) {
+	builder := b.snapshot()
+
+	if params.SatoshiPerKVByte == nil {
+		return nil, fmt.Errorf("%w: sweep fee rate is required", ErrFeeRateOutOfBounds)
+	}
+	if err := builder.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return nil, err
+	}
+	if len(params.Commits) == 0 {
+		return nil, ErrNoCommits
+	}
+
+	address, err := builder.DecodeAddress(params.TreasuryAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	treasuryScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]sweepInput, 0, len(params.Commits))
+	for idx, commit := range params.Commits {
+		input, err := prepareSweepInput(commit)
+		if err != nil {
+			return nil, fmt.Errorf("commit %d: %w", idx, err)
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	var (
+		results []BuildCommitSweepTxResult
+		tx      = wire.NewMsgTx(txVersion)
+		batch   []int
+	)
+	tx.AddTxOut(wire.NewTxOut(0, treasuryScript))
+
+	for idx := range inputs {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		tx.AddTxIn(inputs[idx].txIn)
+		batch = append(batch, idx)
+
+		weight, _ := sweepTxWeight(tx, inputs, batch)
+		if weight <= MaxStandardTxWeight && (params.MaxInputs <= 0 || len(batch) <= params.MaxInputs) {
+			continue
+		}
+		if len(batch) == 1 {
+			return nil, &NonStandardTxWeightError{
+				Weight:    weight,
+				MaxWeight: MaxStandardTxWeight,
+				Overshoot: weight - MaxStandardTxWeight,
+			}
+		}
+
+		tx.TxIn, batch = tx.TxIn[:len(tx.TxIn)-1], batch[:len(batch)-1]
+
+		result, err := builder.buildCommitSweepTx(tx, inputs, batch, params)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+
+		tx = wire.NewMsgTx(txVersion)
+		tx.AddTxOut(wire.NewTxOut(0, treasuryScript))
+		tx.AddTxIn(inputs[idx].txIn)
+		batch = []int{idx}
+	}
+
+	result, err := builder.buildCommitSweepTx(tx, inputs, batch, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(results, result), nil
+}
+
+// buildCommitSweepTx sets treasury output amount of the sweep transaction and serializes it to PSBT.
+func (b *TxBuilder) buildCommitSweepTx(tx *wire.MsgTx, inputs []sweepInput, batch []int, params BuildCommitSweepTxsParams) (result BuildCommitSweepTxResult, err error) {
+	amount := big.NewInt(0)
+	for _, idx := range batch {
+		amount.Add(amount, big.NewInt(inputs[idx].pInput.WitnessUtxo.Value))
+	}
+
+	_, vSize := sweepTxWeight(tx, inputs, batch)
+	result.EstimatedFee = feeForVSize(vSize, params.SatoshiPerKVByte)
+	result.Amount = new(big.Int).Sub(amount, result.EstimatedFee)
+	if numbers.IsLess(result.Amount, b.config.DustAmount) {
+		return result, fmt.Errorf("%w: %d inputs amount %s, fee %s", ErrUneconomicalSweep, len(batch),
+			amount, result.EstimatedFee)
+	}
+
+	unsignedTx := tx.Copy()
+	unsignedTx.TxOut[0].Value = result.Amount.Int64()
+
+	p, err := psbt.NewFromUnsignedTx(unsignedTx)
+	if err != nil {
+		return result, err
+	}
+
+	for i, idx := range batch {
+		p.Inputs[i] = inputs[idx].pInput
+	}
+
+	result.OutputRoles = []OutputRole{OutputRoleRecipient}
+	if b.config.OutputRoles {
+		setOutputRoles(p, result.OutputRoles)
+	}
+
+	var buff bytes.Buffer
+	if err = p.Serialize(&buff); err != nil {
+		return result, err
+	}
+
+	result.SerializedPSBT = buff.Bytes()
+	result.Commits = append([]int(nil), batch...)
+
+	return result, nil
+}
+
+// prepareSweepInput verifies that the utxo commits to the inscription and prepares its taproot signing data.
+func prepareSweepInput(commit CommitSweepInput) (input sweepInput, err error) {
+	if commit.Inscription == nil {
+		return input, ErrMissingInscription
+	}
+	if commit.UTXO.Amount == nil || commit.UTXO.Amount.Sign() <= 0 {
+		return input, fmt.Errorf("%w: %v", ErrInvalidUTXOAmount, commit.UTXO.Amount)
+	}
+
+	pubKey, err := hex.DecodeString(commit.InscriptionPubKey)
+	if err != nil {
+		return input, fmt.Errorf("%w: %w", ErrInvalidPubKey, err)
+	}
+
+	internalKey, err := utils.ParseXOnlyPubKey(pubKey)
+	if err != nil {
+		return input, fmt.Errorf("%w: %w", ErrInvalidPubKey, err)
+	}
+
+	input.txIn = wire.NewTxIn(commit.UTXO.WireOutPoint(), nil, nil)
+	input.pInput = psbt.PInput{
+		WitnessUtxo:        wire.NewTxOut(commit.UTXO.Amount.Int64(), commit.UTXO.Script),
+		SighashType:        signHashType,
+		TaprootInternalKey: schnorr.SerializePubKey(internalKey),
+	}
+
+	if commit.Recovery == nil {
+		if err = commit.Inscription.VerifyCommitment(pubKey, commit.UTXO.Script); err != nil {
+			return input, err
+		}
+
+		leafHash, err := commit.Inscription.TapLeafHash(pubKey)
+		if err != nil {
+			return input, err
+		}
+
+		// INFO: merkle root of the single leaf script tree is the leaf hash.
+		input.pInput.TaprootMerkleRoot = leafHash.CloneBytes()
+		input.witness = wire.TxWitness{make([]byte, schnorrSignatureLen)}
+
+		return input, nil
+	}
+
+	if err = commit.Inscription.VerifyRecoveryCommitment(pubKey, commit.UTXO.Script, *commit.Recovery); err != nil {
+		return input, err
+	}
+
+	leaves, err := commit.Inscription.RecoveryTapLeaves(pubKey, *commit.Recovery)
+	if err != nil {
+		return input, err
+	}
+
+	var (
+		tree      = txscript.AssembleTaprootScriptTree(leaves...)
+		root      = tree.RootNode.TapHash()
+		recovery  = leaves[1]
+		ctrlBlock = tree.LeafMerkleProofs[tree.LeafProofIndex[recovery.TapHash()]].ToControlBlock(internalKey)
+	)
+
+	ctrlBlockBytes, err := ctrlBlock.ToBytes()
+	if err != nil {
+		return input, err
+	}
+
+	input.txIn.Sequence = commit.Recovery.Sequence()
+	input.pInput.TaprootMerkleRoot = root.CloneBytes()
+	input.pInput.TaprootLeafScript = []*psbt.TaprootTapLeafScript{{
+		ControlBlock: ctrlBlockBytes,
+		Script:       recovery.Script,
+		LeafVersion:  recovery.LeafVersion,
+	}}
+	input.witness = wire.TxWitness{make([]byte, schnorrSignatureLen), recovery.Script, ctrlBlockBytes}
+
+	return input, nil
+}
+
+// sweepTxWeight returns weight and virtual size of the sweep transaction with the placeholder witnesses of the batch inputs.
+func sweepTxWeight(tx *wire.MsgTx, inputs []sweepInput, batch []int) (weight, vSize int64) {
+	withWitness := tx.Copy()
+	for i, idx := range batch {
+		withWitness.TxIn[i].Witness = inputs[idx].witness
+	}
+
+	return bitcoin.MsgTxWeight(withWitness)
+}
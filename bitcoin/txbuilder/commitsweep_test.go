@@ -0,0 +1,171 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestBuildCommitSweepTxs(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+	builder := txbuilder.NewTxBuilder(networkParams)
+	verifier := signer.NewSigner(networkParams, signer.VerifySignatures())
+
+	inscriptionKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	recoveryKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	treasuryAddress, err := utils.P2TRAddressFromInternalKey(recoveryKey.PubKey(), nil, networkParams)
+	require.NoError(t, err)
+
+	pubKey := inscriptionKey.PubKey().SerializeCompressed()
+	recovery := &inscriptions.RecoveryLeaf{PubKey: recoveryKey.PubKey().SerializeCompressed(), Delay: 144}
+
+	commit := func(t *testing.T, body string, recovery *inscriptions.RecoveryLeaf) txbuilder.CommitSweepInput {
+		inscription := &inscriptions.Inscription{ContentType: "text/plain;charset=utf-8", Body: []byte(body)}
+
+		var address string
+		if recovery != nil {
+			address, err = inscription.RecoveryCommitAddress(pubKey, *recovery, networkParams)
+		} else {
+			address, err = inscription.IntoAddress(hex.EncodeToString(pubKey), networkParams)
+		}
+		require.NoError(t, err)
+
+		decoded, err := btcutil.DecodeAddress(address, networkParams)
+		require.NoError(t, err)
+
+		script, err := txscript.PayToAddrScript(decoded)
+		require.NoError(t, err)
+
+		return txbuilder.CommitSweepInput{
+			UTXO: bitcoin.UTXO{
+				Outpoint: bitcoin.MustOutpoint("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", uint32(len(body))),
+				Amount:   big.NewInt(10000),
+				Script:   script,
+				Address:  address,
+			},
+			Inscription:       inscription,
+			InscriptionPubKey: hex.EncodeToString(pubKey),
+			Recovery:          recovery,
+		}
+	}
+
+	t.Run("key path and recovery", func(t *testing.T) {
+		params := txbuilder.BuildCommitSweepTxsParams{
+			Commits: []txbuilder.CommitSweepInput{
+				commit(t, "a", nil),
+				commit(t, "bb", recovery),
+				commit(t, "ccc", nil),
+			},
+			TreasuryAddress:  treasuryAddress.EncodeAddress(),
+			SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+		}
+
+		results, err := builder.BuildCommitSweepTxs(params)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, []int{0, 1, 2}, results[0].Commits)
+		require.Equal(t, new(big.Int).Sub(big.NewInt(30000), results[0].EstimatedFee), results[0].Amount)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(results[0].SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 1)
+		require.Equal(t, results[0].Amount.Int64(), p.UnsignedTx.TxOut[0].Value)
+		require.Equal(t, recovery.Sequence(), p.UnsignedTx.TxIn[1].Sequence)
+		require.Len(t, p.Inputs[1].TaprootLeafScript, 1)
+
+		signed, err := verifier.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: results[0].SerializedPSBT,
+			Inputs:         []int{0, 2},
+			PrivateKey:     inscriptionKey,
+		})
+		require.NoError(t, err)
+
+		_, err = verifier.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: signed,
+			Inputs:         []int{1},
+			PrivateKey:     recoveryKey,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("batching", func(t *testing.T) {
+		params := txbuilder.BuildCommitSweepTxsParams{
+			Commits: []txbuilder.CommitSweepInput{
+				commit(t, "a", nil),
+				commit(t, "bb", nil),
+				commit(t, "ccc", nil),
+			},
+			TreasuryAddress:  treasuryAddress.EncodeAddress(),
+			SatoshiPerKVByte: big.NewInt(5000),
+			MaxInputs:        2,
+		}
+
+		results, err := builder.BuildCommitSweepTxs(params)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, []int{0, 1}, results[0].Commits)
+		require.Equal(t, []int{2}, results[1].Commits)
+		require.Equal(t, 1, results[0].EstimatedFee.Cmp(results[1].EstimatedFee))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := builder.BuildCommitSweepTxs(txbuilder.BuildCommitSweepTxsParams{
+			TreasuryAddress:  treasuryAddress.EncodeAddress(),
+			SatoshiPerKVByte: big.NewInt(5000),
+		})
+		require.ErrorIs(t, err, txbuilder.ErrNoCommits)
+
+		mismatched := commit(t, "a", nil)
+		mismatched.Recovery = recovery
+		_, err = builder.BuildCommitSweepTxs(txbuilder.BuildCommitSweepTxsParams{
+			Commits:          []txbuilder.CommitSweepInput{mismatched},
+			TreasuryAddress:  treasuryAddress.EncodeAddress(),
+			SatoshiPerKVByte: big.NewInt(5000),
+		})
+		require.ErrorIs(t, err, inscriptions.ErrCommitmentMismatch)
+
+		_, err = builder.BuildCommitSweepTxs(txbuilder.BuildCommitSweepTxsParams{
+			Commits:          []txbuilder.CommitSweepInput{commit(t, "a", nil)},
+			TreasuryAddress:  treasuryAddress.EncodeAddress(),
+			SatoshiPerKVByte: big.NewInt(100000),
+		})
+		require.ErrorIs(t, err, txbuilder.ErrUneconomicalSweep)
+
+		_, err = builder.BuildCommitSweepTxs(txbuilder.BuildCommitSweepTxsParams{
+			Commits:         []txbuilder.CommitSweepInput{commit(t, "a", nil)},
+			TreasuryAddress: treasuryAddress.EncodeAddress(),
+		})
+		require.ErrorIs(t, err, txbuilder.ErrFeeRateOutOfBounds)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = builder.BuildCommitSweepTxsContext(ctx, txbuilder.BuildCommitSweepTxsParams{
+			Commits:          []txbuilder.CommitSweepInput{commit(t, "a", nil)},
+			TreasuryAddress:  treasuryAddress.EncodeAddress(),
+			SatoshiPerKVByte: big.NewInt(5000),
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/btcsuite/btcd/txscript"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+)
+
+// CommitScript returns taproot output script of the inscription commitment: the inscription leaf
+// (and the recovery leaf if set) tweaked with the public key, the same as BuildInscriptionTx pays to.
+func (b *TxBuilder) CommitScript(inscription *inscriptions.Inscription, pubKey string, recovery *inscriptions.RecoveryLeaf) ([]byte, error) {
+	var (
+		address string
+		err     error
+	)
+	if recovery != nil {
+		serializedPubKey, err := hex.DecodeString(pubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		address, err = inscription.RecoveryCommitAddress(serializedPubKey, *recovery, b.networkParams)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if address, err = inscription.IntoAddress(pubKey, b.networkParams); err != nil {
+			return nil, err
+		}
+	}
+
+	decoded, err := b.DecodeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return txscript.PayToAddrScript(decoded)
+}
+
+// VerifyCommitUTXO returns CommitScriptMismatchError if the inscription commitment utxo script pub key
+// is not the taproot output committing to the inscription and the public key, see CommitScript.
+func (b *TxBuilder) VerifyCommitUTXO(utxo bitcoin.UTXO, inscription *inscriptions.Inscription, pubKey string,
+	recovery *inscriptions.RecoveryLeaf) error {
+	expected, err := b.CommitScript(inscription, pubKey, recovery)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(expected, utxo.Script) {
+		return &CommitScriptMismatchError{Expected: expected, Actual: utxo.Script}
+	}
+
+	return nil
+}
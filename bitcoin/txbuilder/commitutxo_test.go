@@ -0,0 +1,94 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestVerifyCommitUTXO(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+	builder := txbuilder.NewTxBuilder(networkParams)
+
+	privateKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pubKey := hex.EncodeToString(privateKey.PubKey().SerializeCompressed())
+
+	inscription := &inscriptions.Inscription{Body: []byte("test data")}
+	address, err := inscription.IntoAddress(pubKey, networkParams)
+	require.NoError(t, err)
+	decoded, err := builder.DecodeAddress(address)
+	require.NoError(t, err)
+	script, err := txscript.PayToAddrScript(decoded)
+	require.NoError(t, err)
+
+	t.Run("match", func(t *testing.T) {
+		commitScript, err := builder.CommitScript(inscription, pubKey, nil)
+		require.NoError(t, err)
+		require.Equal(t, script, commitScript)
+		require.NoError(t, builder.VerifyCommitUTXO(bitcoin.UTXO{Script: script}, inscription, pubKey, nil))
+	})
+
+	t.Run("recovery", func(t *testing.T) {
+		recovery := inscriptions.RecoveryLeaf{PubKey: privateKey.PubKey().SerializeCompressed(), Delay: 144}
+		recoveryAddress, err := inscription.RecoveryCommitAddress(privateKey.PubKey().SerializeCompressed(), recovery, networkParams)
+		require.NoError(t, err)
+		decoded, err := builder.DecodeAddress(recoveryAddress)
+		require.NoError(t, err)
+		recoveryScript, err := txscript.PayToAddrScript(decoded)
+		require.NoError(t, err)
+
+		require.NoError(t, builder.VerifyCommitUTXO(bitcoin.UTXO{Script: recoveryScript}, inscription, pubKey, &recovery))
+		require.ErrorIs(t, builder.VerifyCommitUTXO(bitcoin.UTXO{Script: script}, inscription, pubKey, &recovery),
+			txbuilder.ErrCommitScriptMismatch)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		other := &inscriptions.Inscription{Body: []byte("other data")}
+		err := builder.VerifyCommitUTXO(bitcoin.UTXO{Script: script}, other, pubKey, nil)
+		require.ErrorIs(t, err, txbuilder.ErrCommitScriptMismatch)
+
+		var mismatchErr *txbuilder.CommitScriptMismatchError
+		require.True(t, errors.As(err, &mismatchErr))
+		require.Equal(t, script, mismatchErr.Actual)
+		require.NotEqual(t, script, mismatchErr.Expected)
+	})
+
+	t.Run("BuildRuneEtchTx", func(t *testing.T) {
+		params := txbuilder.BaseRuneEtchTxParams{
+			InscriptionReveal: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0),
+					Amount:   big.NewInt(20000),
+					Script:   []byte("_bitcoin_transaction_script_"),
+				}},
+				PubKey: pubKey,
+			},
+			Inscription:           inscription,
+			Rune:                  &runes.Etching{Premine: big.NewInt(1000)},
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: address,
+			SatoshiChangeAddress:  address,
+		}
+		_, err := builder.BuildRuneEtchTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrCommitScriptMismatch)
+
+		params.InscriptionReveal.UTXOs[0].Script = script
+		_, err = builder.BuildRuneEtchTx(params)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,354 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// ErrFeeRateOutOfBounds describes that fee rate is out of configured bounds.
+var ErrFeeRateOutOfBounds = errors.New("fee rate is out of bounds")
+
+// SizeEstimator describes rough transaction size estimation used for fee calculation.
+type SizeEstimator interface {
+	// TxSize returns transaction size estimate in vBytes by number of inputs and outputs.
+	TxSize(inputs, outputs int) *big.Int
+	// InscriptionInputSize returns inscription reveal input size estimate in vBytes
+	// with signature, but without witness script data size.
+	InscriptionInputSize() *big.Int
+}
+
+// RoughSizeEstimator is a SizeEstimator with static sizes of the transaction parts.
+type RoughSizeEstimator struct {
+	HeaderSize               *big.Int // tx header size in vBytes.
+	InputSize                *big.Int // tx input size in vBytes.
+	OutputSize               *big.Int // tx output size in vBytes.
+	InscriptionInputBaseSize *big.Int // inscription input size in vBytes without witness script data.
+}
+
+// TxSize returns transaction size estimate in vBytes by number of inputs and outputs.
+func (e *RoughSizeEstimator) TxSize(inputs, outputs int) *big.Int {
+	size := new(big.Int).Set(e.HeaderSize)
+	size.Add(size, new(big.Int).Mul(e.InputSize, big.NewInt(int64(inputs))))
+	size.Add(size, new(big.Int).Mul(e.OutputSize, big.NewInt(int64(outputs))))
+
+	return size
+}
+
+// InscriptionInputSize returns inscription reveal input size estimate in vBytes.
+func (e *RoughSizeEstimator) InscriptionInputSize() *big.Int {
+	return new(big.Int).Set(e.InscriptionInputBaseSize)
+}
+
+// CoinSelector describes utxos selection algorithm, see SelectUTXO for default implementation.
+type CoinSelector func(utxos []bitcoin.UTXO, amountFn func(*bitcoin.UTXO) *big.Int, minAmount *big.Int, requiredUTXOs int,
+	insufficientBalanceError *InsufficientError) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, _ error)
+
+// RuneStateOracle describes source of the actual rune balances, e.g. runes indexer, to detect sender rune utxos
+// which are stale since they were fetched: runes are burned by a cenotaph or the utxo is already spent.
+type RuneStateOracle interface {
+	// RuneBalances returns actual balances of the rune held by the utxos in the same order, zero for spent utxos.
+	RuneBalances(ctx context.Context, runeID runes.RuneID, utxos []*bitcoin.UTXO) ([]*big.Int, error)
+}
+
+// OutputOrdering defines policy of outputs ordering.
+type OutputOrdering int
+
+const (
+	// OutputOrderingAsBuilt keeps outputs in the order they are documented for each transaction type.
+	OutputOrderingAsBuilt OutputOrdering = iota
+	// OutputOrderingBIP69 sorts outputs by amount and script (BIP-69) to avoid change output detection.
+	// NOTE: Applied to btc transfer transactions only, runes transactions rely on outputs indexes.
+	OutputOrderingBIP69
+)
+
+// InputOrdering defines policy of inputs ordering.
+type InputOrdering int
+
+const (
+	// InputOrderingAsSelected keeps inputs in the order utxos are selected for each transaction type.
+	InputOrderingAsSelected InputOrdering = iota
+	// InputOrderingBIP69 sorts inputs by previous transaction hash and output index (BIP-69).
+	InputOrderingBIP69
+)
+
+// InputComparator defines caller-specified inputs ordering, returns a negative number if a
+// should precede b, a positive number if b should precede a, and zero to keep their order.
+type InputComparator func(a, b wire.OutPoint) int
+
+// TxBuilderConfig defines TxBuilder behaviour configuration.
+type TxBuilderConfig struct {
+	DustAmount          *big.Int       // the smallest amount in satoshi for runes and change outputs.
+	MinSatoshiPerKVByte *big.Int       // minimum allowed fee rate in satoshi per kilo virtual byte, optional.
+	MaxSatoshiPerKVByte *big.Int       // maximum allowed fee rate in satoshi per kilo virtual byte, optional.
+	SizeEstimator       SizeEstimator  // transaction size estimator for fee calculation.
+	CoinSelector        CoinSelector   // utxos selection algorithm, optional, single pass SelectUTXO based selection if not set.
+	OutputOrdering      OutputOrdering // outputs ordering policy.
+	// InputOrdering is an inputs ordering policy, ignored if InputComparator is set.
+	// NOTE: Applied to btc transfer, inscription commitment and channel funding transactions only,
+	// runes transactions rely on inputs order to keep sats flow.
+	InputOrdering   InputOrdering
+	InputComparator InputComparator // caller-specified inputs ordering, optional.
+	// ConsolidateRuneChange is a maximum number of extra rune utxos of the transferring rune
+	// to sweep into the runes change output if fee payer covers extra inputs, 0 disables consolidation.
+	ConsolidateRuneChange int
+	// AllowChangelessWithinTolerance is a maximum excess in satoshi which is paid as fee instead of creating
+	// the change output, optional, utxos covering transfer within tolerance are preferred if set.
+	// NOTE: Applied to btc transfer transactions only.
+	AllowChangelessWithinTolerance *big.Int
+	// StrictEdicts makes runes transfers fail with InvalidEdictError instead of adjusting negative amounts,
+	// if transfer and burn amounts exceed sender holdings or rune supply of the provided rune info.
+	StrictEdicts bool
+	// OutputRoles labels outputs of the built PSBT with their roles in proprietary fields, see OutputRole.
+	OutputRoles bool
+	// RuneStateOracle confirms balances of the selected sender rune utxos before runes transfer edicts are
+	// constructed, optional, rune utxos are trusted if not set. See StaleRuneUTXOsError.
+	RuneStateOracle RuneStateOracle
+	// AddressHistory reports previously used addresses to warn about recipient and change addresses reuse,
+	// optional, reuse is not checked if not set. See AddressReuseWarning.
+	// NOTE: BuildChainBumpTx, BuildCommitSweepTxs and BuildRuneSellOfferPSBT do not report addresses reuse.
+	AddressHistory AddressHistory
+	// MaxOpReturnDataSize is a maximum data size in bytes of each OP_RETURN output attached to btc transfer.
+	MaxOpReturnDataSize int
+	// VersionedInputsHelpingKeys writes inputs helping keys of the built PSBT as versioned proprietary keys
+	// instead of the legacy single byte keys, see InputsHelpingKey.ProprietaryKey.
+	VersionedInputsHelpingKeys bool
+	// Hooks are called around utxos selection and after transactions building in the configured order,
+	// optional, see BuildHooks.
+	Hooks []BuildHooks
+}
+
+// Option defines functional option to configure TxBuilder.
+type Option func(config *TxBuilderConfig)
+
+// DefaultTxBuilderConfig returns default TxBuilder configuration.
+func DefaultTxBuilderConfig() TxBuilderConfig {
+	return TxBuilderConfig{
+		DustAmount:     big.NewInt(nonDustBitcoinAmount),
+		SizeEstimator:  DefaultSizeEstimator(),
+		OutputOrdering: OutputOrderingAsBuilt,
+		InputOrdering:  InputOrderingAsSelected,
+
+		MaxOpReturnDataSize: defaultMaxOpReturnDataSize,
+	}
+}
+
+// DefaultSizeEstimator returns default rough size estimator.
+func DefaultSizeEstimator() *RoughSizeEstimator {
+	return &RoughSizeEstimator{
+		HeaderSize:               big.NewInt(headerSizeVBytes),
+		InputSize:                big.NewInt(inputSizeVBytes),
+		OutputSize:               big.NewInt(outputSizeVBytes),
+		InscriptionInputBaseSize: big.NewInt(inscriptionInputSizeVBytes),
+	}
+}
+
+// WithDustAmount sets the smallest amount in satoshi for runes and change outputs, nil is ignored.
+func WithDustAmount(amount *big.Int) Option {
+	return func(config *TxBuilderConfig) {
+		if amount != nil {
+			config.DustAmount = new(big.Int).Set(amount)
+		}
+	}
+}
+
+// WithFeeRateBounds sets allowed fee rate bounds in satoshi per kilo virtual byte, nil means no bound.
+func WithFeeRateBounds(minSatoshiPerKVByte, maxSatoshiPerKVByte *big.Int) Option {
+	return func(config *TxBuilderConfig) {
+		config.MinSatoshiPerKVByte = minSatoshiPerKVByte
+		config.MaxSatoshiPerKVByte = maxSatoshiPerKVByte
+	}
+}
+
+// WithSizeEstimator sets transaction size estimator.
+func WithSizeEstimator(estimator SizeEstimator) Option {
+	return func(config *TxBuilderConfig) {
+		config.SizeEstimator = estimator
+	}
+}
+
+// WithCoinSelector sets utxos selection algorithm.
+func WithCoinSelector(selector CoinSelector) Option {
+	return func(config *TxBuilderConfig) {
+		config.CoinSelector = selector
+	}
+}
+
+// WithOutputOrdering sets outputs ordering policy.
+func WithOutputOrdering(ordering OutputOrdering) Option {
+	return func(config *TxBuilderConfig) {
+		config.OutputOrdering = ordering
+	}
+}
+
+// WithInputOrdering sets inputs ordering policy.
+func WithInputOrdering(ordering InputOrdering) Option {
+	return func(config *TxBuilderConfig) {
+		config.InputOrdering = ordering
+	}
+}
+
+// WithInputComparator sets caller-specified inputs ordering.
+func WithInputComparator(comparator InputComparator) Option {
+	return func(config *TxBuilderConfig) {
+		config.InputComparator = comparator
+	}
+}
+
+// WithConsolidateRuneChange sets maximum number of extra rune utxos to sweep into the runes change output.
+func WithConsolidateRuneChange(maxUTXOs int) Option {
+	return func(config *TxBuilderConfig) {
+		config.ConsolidateRuneChange = maxUTXOs
+	}
+}
+
+// WithAllowChangelessWithinTolerance allows to drop the change output if selected utxos
+// exceed transfer amount with fee by not more than tolerance in satoshi, nil disables changeless selection.
+func WithAllowChangelessWithinTolerance(tolerance *big.Int) Option {
+	return func(config *TxBuilderConfig) {
+		config.AllowChangelessWithinTolerance = nil
+		if tolerance != nil {
+			config.AllowChangelessWithinTolerance = new(big.Int).Set(tolerance)
+		}
+	}
+}
+
+// WithStrictEdicts enables strict validation of runes transfer amounts.
+func WithStrictEdicts() Option {
+	return func(config *TxBuilderConfig) {
+		config.StrictEdicts = true
+	}
+}
+
+// WithOutputRoles enables output roles labeling in the built PSBT, see ExtractOutputRolesFromPSBT.
+func WithOutputRoles() Option {
+	return func(config *TxBuilderConfig) {
+		config.OutputRoles = true
+	}
+}
+
+// WithRuneStateOracle sets source of the actual rune balances to confirm sender rune utxos.
+func WithRuneStateOracle(oracle RuneStateOracle) Option {
+	return func(config *TxBuilderConfig) {
+		config.RuneStateOracle = oracle
+	}
+}
+
+// WithAddressHistory sets source of the previously used addresses to warn about addresses reuse.
+func WithAddressHistory(history AddressHistory) Option {
+	return func(config *TxBuilderConfig) {
+		config.AddressHistory = history
+	}
+}
+
+// WithMaxOpReturnDataSize sets maximum data size in bytes of each OP_RETURN output attached to btc transfer,
+// e.g. to follow relay policy of the nodes accepting larger data carrier outputs.
+func WithMaxOpReturnDataSize(size int) Option {
+	return func(config *TxBuilderConfig) {
+		config.MaxOpReturnDataSize = size
+	}
+}
+
+// WithVersionedInputsHelpingKeys enables versioned proprietary inputs helping keys in the built PSBT.
+// NOTE: Readers should support versioned keys, see ParseInputsHelpingKey.
+func WithVersionedInputsHelpingKeys() Option {
+	return func(config *TxBuilderConfig) {
+		config.VersionedInputsHelpingKeys = true
+	}
+}
+
+// WithHooks appends build hooks, e.g. logging, metrics or policy checks, called in the appended order.
+func WithHooks(hooks ...BuildHooks) Option {
+	return func(config *TxBuilderConfig) {
+		config.Hooks = append(config.Hooks, hooks...)
+	}
+}
+
+// inputsHelpingKey returns PSBT unknown field key of the inputs helping key in the configured format.
+func (config *TxBuilderConfig) inputsHelpingKey(key InputsHelpingKey) []byte {
+	if config.VersionedInputsHelpingKeys {
+		return key.ProprietaryKey()
+	}
+
+	return key.Bytes()
+}
+
+// checkFeeRate returns ErrFeeRateOutOfBounds if fee rate is out of configured bounds.
+func (config *TxBuilderConfig) checkFeeRate(satoshiPerKVByte *big.Int) error {
+	if satoshiPerKVByte == nil {
+		return nil
+	}
+
+	if config.MinSatoshiPerKVByte != nil && numbers.IsLess(satoshiPerKVByte, config.MinSatoshiPerKVByte) {
+		return fmt.Errorf("%w: %s is less than %s", ErrFeeRateOutOfBounds, satoshiPerKVByte, config.MinSatoshiPerKVByte)
+	}
+
+	if config.MaxSatoshiPerKVByte != nil && numbers.IsGreater(satoshiPerKVByte, config.MaxSatoshiPerKVByte) {
+		return fmt.Errorf("%w: %s is greater than %s", ErrFeeRateOutOfBounds, satoshiPerKVByte, config.MaxSatoshiPerKVByte)
+	}
+
+	return nil
+}
+
+// orderOutputs sorts transaction outputs according to the ordering policy.
+func (ordering OutputOrdering) orderOutputs(tx *wire.MsgTx) {
+	if ordering != OutputOrderingBIP69 {
+		return
+	}
+
+	sort.SliceStable(tx.TxOut, func(i, j int) bool {
+		if tx.TxOut[i].Value != tx.TxOut[j].Value {
+			return tx.TxOut[i].Value < tx.TxOut[j].Value
+		}
+
+		return bytes.Compare(tx.TxOut[i].PkScript, tx.TxOut[j].PkScript) < 0
+	})
+}
+
+// orderInputs sorts transaction inputs according to the inputs ordering configuration.
+func (config *TxBuilderConfig) orderInputs(tx *wire.MsgTx) {
+	comparator := config.InputComparator
+	if comparator == nil {
+		if config.InputOrdering != InputOrderingBIP69 {
+			return
+		}
+
+		comparator = compareOutPointsBIP69
+	}
+
+	sort.SliceStable(tx.TxIn, func(i, j int) bool {
+		return comparator(tx.TxIn[i].PreviousOutPoint, tx.TxIn[j].PreviousOutPoint) < 0
+	})
+}
+
+// compareOutPointsBIP69 compares outpoints by previous transaction hash in reversed
+// byte order, and then by output index, as defined in BIP-69.
+func compareOutPointsBIP69(a, b wire.OutPoint) int {
+	for i := chainhash.HashSize - 1; i >= 0; i-- {
+		if a.Hash[i] != b.Hash[i] {
+			return int(a.Hash[i]) - int(b.Hash[i])
+		}
+	}
+
+	switch {
+	case a.Index < b.Index:
+		return -1
+	case a.Index > b.Index:
+		return 1
+	default:
+		return 0
+	}
+}
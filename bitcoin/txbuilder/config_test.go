@@ -0,0 +1,544 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestTxBuilderConfig(t *testing.T) {
+	params := txbuilder.BaseBTCTransferParams{
+		TransferSatoshiAmount: big.NewInt(29500), // 0.000295 BTC.
+		Sender: &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{
+				{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+					Amount:   big.NewInt(850000), // 0.0085 BTC.
+					Script:   []byte("_bitcoin_transaction_script_"),
+					Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+				},
+			},
+			Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+		},
+		SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+		RecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+	}
+
+	build := func(t *testing.T, opts ...txbuilder.Option) (txbuilder.BuildBTCTransferTxResult, *psbt.Packet, error) {
+		result, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params, opts...).BuildBTCTransferTx(params)
+		if err != nil {
+			return result, nil, err
+		}
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+
+		return result, p, nil
+	}
+
+	t.Run("default", func(t *testing.T) {
+		_, p, err := build(t)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 2)
+		require.EqualValues(t, 29500, p.UnsignedTx.TxOut[0].Value)
+
+		config := txbuilder.DefaultTxBuilderConfig()
+		require.Nil(t, config.CoinSelector) // single pass SelectUTXO based selection.
+		require.EqualValues(t, 546, config.DustAmount.Int64())
+	})
+
+	t.Run("WithDustAmount", func(t *testing.T) {
+		_, p, err := build(t, txbuilder.WithDustAmount(big.NewInt(1_000_000)))
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 1) // change is dust.
+
+		// INFO: nil keeps the default dust amount.
+		_, p, err = build(t, txbuilder.WithDustAmount(nil))
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 2)
+		require.EqualValues(t, 546, txbuilder.NewTxBuilder(&chaincfg.TestNet3Params, txbuilder.WithDustAmount(nil)).DustAmount().Int64())
+	})
+
+	t.Run("WithFeeRateBounds", func(t *testing.T) {
+		_, _, err := build(t, txbuilder.WithFeeRateBounds(big.NewInt(10000), nil))
+		require.ErrorIs(t, err, txbuilder.ErrFeeRateOutOfBounds)
+
+		_, _, err = build(t, txbuilder.WithFeeRateBounds(nil, big.NewInt(1000)))
+		require.ErrorIs(t, err, txbuilder.ErrFeeRateOutOfBounds)
+
+		_, _, err = build(t, txbuilder.WithFeeRateBounds(big.NewInt(1000), big.NewInt(10000)))
+		require.NoError(t, err)
+	})
+
+	t.Run("WithSizeEstimator", func(t *testing.T) {
+		estimator := txbuilder.DefaultSizeEstimator()
+		estimator.InputSize = big.NewInt(200)
+
+		defaultResult, _, err := build(t)
+		require.NoError(t, err)
+
+		result, _, err := build(t, txbuilder.WithSizeEstimator(estimator))
+		require.NoError(t, err)
+		require.EqualValues(t, defaultResult.EstimatedFee.Int64()+(200-90)*5, result.EstimatedFee.Int64())
+	})
+
+	t.Run("WithCoinSelector", func(t *testing.T) {
+		var called bool
+		selector := func(utxos []bitcoin.UTXO, amountFn func(*bitcoin.UTXO) *big.Int, minAmount *big.Int, requiredUTXOs int,
+			insufficientBalanceError *txbuilder.InsufficientError) ([]*bitcoin.UTXO, *big.Int, error) {
+			called = true
+			return txbuilder.SelectUTXO(utxos, amountFn, minAmount, requiredUTXOs, insufficientBalanceError)
+		}
+
+		_, _, err := build(t, txbuilder.WithCoinSelector(selector))
+		require.NoError(t, err)
+		require.True(t, called)
+	})
+
+	t.Run("WithOutputOrdering", func(t *testing.T) {
+		_, p, err := build(t, txbuilder.WithOutputOrdering(txbuilder.OutputOrderingBIP69))
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 2)
+		require.Less(t, p.UnsignedTx.TxOut[0].Value, p.UnsignedTx.TxOut[1].Value)
+
+		require.True(t, txbuilder.NewTxBuilder(&chaincfg.TestNet3Params).KeepsOrder())
+		require.False(t, txbuilder.NewTxBuilder(&chaincfg.TestNet3Params,
+			txbuilder.WithOutputOrdering(txbuilder.OutputOrderingBIP69)).KeepsOrder())
+	})
+
+	t.Run("WithInputOrdering", func(t *testing.T) {
+		feePayerParams := params
+		feePayerParams.Sender = &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{{
+				Outpoint: bitcoin.MustOutpoint("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0),
+				Amount:   big.NewInt(29500),
+				Script:   []byte("_bitcoin_transaction_sender_script_"),
+				Address:  params.Sender.Address,
+			}},
+			Address: params.Sender.Address,
+			PubKey:  params.Sender.PubKey,
+		}
+		feePayerParams.FeePayer = params.Sender
+
+		build := func(t *testing.T, opts ...txbuilder.Option) (*psbt.Packet, map[txbuilder.InputsHelpingKey][]int) {
+			result, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params, opts...).BuildBTCTransferTx(feePayerParams)
+			require.NoError(t, err)
+
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+			require.NoError(t, err)
+
+			indexes, err := txbuilder.ExtractAddressTypeInputIndexesFromPSBT(result.SerializedPSBT)
+			require.NoError(t, err)
+
+			return p, indexes
+		}
+
+		p, indexes := build(t)
+		require.Equal(t, []int{0}, indexes[txbuilder.PaymentInputsHelpingKey])
+		require.Equal(t, []int{1}, indexes[txbuilder.FeePayerPaymentInputsHelpingKey])
+
+		p, indexes = build(t, txbuilder.WithInputOrdering(txbuilder.InputOrderingBIP69))
+		require.Equal(t, "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
+			p.UnsignedTx.TxIn[0].PreviousOutPoint.Hash.String())
+		require.Equal(t, []int{1}, indexes[txbuilder.PaymentInputsHelpingKey])
+		require.Equal(t, []int{0}, indexes[txbuilder.FeePayerPaymentInputsHelpingKey])
+		require.Equal(t, []byte("_bitcoin_transaction_sender_script_"), p.Inputs[1].WitnessUtxo.PkScript)
+
+		p, indexes = build(t, txbuilder.WithInputOrdering(txbuilder.InputOrderingBIP69),
+			txbuilder.WithInputComparator(func(a, b wire.OutPoint) int {
+				return bytes.Compare(b.Hash[:], a.Hash[:])
+			}))
+		require.Equal(t, []int{0}, indexes[txbuilder.PaymentInputsHelpingKey])
+		require.Equal(t, []int{1}, indexes[txbuilder.FeePayerPaymentInputsHelpingKey])
+		require.Equal(t, []byte("_bitcoin_transaction_sender_script_"), p.Inputs[0].WitnessUtxo.PkScript)
+	})
+
+	t.Run("WithConsolidateRuneChange", func(t *testing.T) {
+		runeID := runes.RuneID{Block: 1122, TxID: 77}
+		runeUTXO := func(index uint32, amount int64, runeUTXOs ...bitcoin.RuneUTXO) bitcoin.UTXO {
+			return bitcoin.UTXO{
+				Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", index),
+				Amount:   big.NewInt(546),
+				Script:   []byte("_bitcoin_transaction_rune_script_"),
+				Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+				Runes:    append([]bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(amount)}}, runeUTXOs...),
+			}
+		}
+
+		runesParams := txbuilder.BaseRunesTransferParams{
+			RuneID:             runeID,
+			TransferRuneAmount: big.NewInt(1000),
+			RunesSender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					runeUTXO(0, 5000),
+					runeUTXO(1, 300),
+					runeUTXO(2, 200, bitcoin.RuneUTXO{RuneID: runes.RuneID{Block: 1, TxID: 1}, Amount: big.NewInt(1)}),
+					runeUTXO(3, 50),
+					runeUTXO(4, 100),
+				},
+				Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+				PubKey:  "29fa611c361355b082ee593feb368009aa9c6bd1ed36c9983edcd113fb8da33f",
+			},
+			FeePayer:              params.Sender,
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+		}
+
+		result, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params).BuildRunesTransferTx(runesParams)
+		require.NoError(t, err)
+		require.Len(t, result.UsedRuneUTXOs, 1)
+
+		txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params, txbuilder.WithConsolidateRuneChange(2))
+		result, err = txBuilder.BuildRunesTransferTx(runesParams)
+		require.NoError(t, err)
+		// INFO: smallest rune utxos are consolidated first regardless of their order.
+		require.Equal(t, []*bitcoin.UTXO{&runesParams.RunesSender.UTXOs[0], &runesParams.RunesSender.UTXOs[3],
+			&runesParams.RunesSender.UTXOs[4]}, result.UsedRuneUTXOs)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxIn, 4)
+
+		runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[0].PkScript)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, *runestone.Pointer)
+
+		t.Run("skipped without fee headroom", func(t *testing.T) {
+			runesParams := runesParams
+			runesParams.FeePayer = &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 5),
+					Amount:   big.NewInt(3000), // covers transaction without extra inputs only.
+					Script:   []byte("_bitcoin_transaction_script_"),
+				}},
+				Address: params.Sender.Address,
+				PubKey:  params.Sender.PubKey,
+			}
+
+			result, err := txBuilder.BuildRunesTransferTx(runesParams)
+			require.NoError(t, err)
+			require.Len(t, result.UsedRuneUTXOs, 1)
+		})
+	})
+
+	t.Run("WithAllowChangelessWithinTolerance", func(t *testing.T) {
+		btcParams := params
+		btcParams.Sender = &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{
+				params.Sender.UTXOs[0],
+				{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 3),
+					Amount:   big.NewInt(31000),
+					Script:   []byte("_bitcoin_transaction_script_"),
+					Address:  params.Sender.Address,
+				},
+			},
+			Address: params.Sender.Address,
+			PubKey:  params.Sender.PubKey,
+		}
+
+		tests := []struct {
+			name    string
+			opts    []txbuilder.Option
+			outputs int
+		}{
+			{"disabled", nil, 2},
+			{"excess exceeds tolerance", []txbuilder.Option{txbuilder.WithAllowChangelessWithinTolerance(big.NewInt(500))}, 2},
+			{"excess within tolerance", []txbuilder.Option{txbuilder.WithAllowChangelessWithinTolerance(big.NewInt(1000))}, 1},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				result, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params, test.opts...).BuildBTCTransferTx(btcParams)
+				require.NoError(t, err)
+
+				p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+				require.NoError(t, err)
+				require.Len(t, p.UnsignedTx.TxOut, test.outputs)
+				require.EqualValues(t, 29500, p.UnsignedTx.TxOut[0].Value)
+
+				if test.outputs == 1 {
+					require.Equal(t, []*bitcoin.UTXO{&btcParams.Sender.UTXOs[1]}, result.UsedSenderBaseUTXOs)
+					require.EqualValues(t, 31000-29500, result.EstimatedFee.Int64())
+				}
+			})
+		}
+
+		t.Run("unsorted utxos", func(t *testing.T) {
+			unsortedParams := btcParams
+			unsortedParams.Sender = &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+						Amount:   big.NewInt(10000),
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  params.Sender.Address,
+					},
+					btcParams.Sender.UTXOs[1],
+					btcParams.Sender.UTXOs[0],
+				},
+				Address: params.Sender.Address,
+				PubKey:  params.Sender.PubKey,
+			}
+
+			txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params, txbuilder.WithAllowChangelessWithinTolerance(big.NewInt(1000)))
+			result, err := txBuilder.BuildBTCTransferTx(unsortedParams)
+			require.NoError(t, err)
+
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+			require.NoError(t, err)
+			require.Len(t, p.UnsignedTx.TxOut, 1)
+			require.Equal(t, []*bitcoin.UTXO{&unsortedParams.Sender.UTXOs[1]}, result.UsedSenderBaseUTXOs)
+			require.EqualValues(t, 10000, unsortedParams.Sender.UTXOs[0].Amount.Int64())
+		})
+	})
+
+	t.Run("WithStrictEdicts", func(t *testing.T) {
+		runeID := runes.RuneID{Block: 1122, TxID: 77}
+		runesParams := txbuilder.BaseRunesTransferParams{
+			RuneID:             runeID,
+			TransferRuneAmount: big.NewInt(1000),
+			BurnRuneAmount:     big.NewInt(100),
+			RunesSender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0),
+					Amount:   big.NewInt(546),
+					Script:   []byte("_bitcoin_transaction_rune_script_"),
+					Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+					Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(1050)}},
+				}},
+				Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+				PubKey:  "29fa611c361355b082ee593feb368009aa9c6bd1ed36c9983edcd113fb8da33f",
+			},
+			FeePayer:              params.Sender,
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+		}
+		txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params, txbuilder.WithStrictEdicts())
+
+		_, err := txBuilder.BuildRunesTransferTx(runesParams)
+		require.ErrorIs(t, err, txbuilder.ErrInvalidEdict)
+		require.EqualError(t, err, "invalid edict: 1122:77 transfer+burn amount 1100 exceeds holdings 1050")
+
+		runesParams.BurnRuneAmount = big.NewInt(50)
+		_, err = txBuilder.BuildRunesTransferTx(runesParams)
+		require.NoError(t, err)
+
+		t.Run("negative amount", func(t *testing.T) {
+			runesParams := runesParams
+			runesParams.BurnRuneAmount = big.NewInt(-1)
+
+			_, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params).BuildRunesTransferTx(runesParams)
+			require.NoError(t, err)
+
+			_, err = txBuilder.BuildRunesTransferTx(runesParams)
+			var edictErr *txbuilder.InvalidEdictError
+			require.ErrorAs(t, err, &edictErr)
+			require.Equal(t, "burn", edictErr.Field)
+			require.Nil(t, edictErr.Limit)
+		})
+
+		t.Run("uint128 overflow", func(t *testing.T) {
+			maxAmount := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+			runesSender := *runesParams.RunesSender
+			runesSender.UTXOs = append([]bitcoin.UTXO(nil), runesSender.UTXOs...)
+			runesSender.UTXOs[0].Runes = []bitcoin.RuneUTXO{{RuneID: runeID, Amount: maxAmount}}
+			runesSender.UTXOs = append(runesSender.UTXOs, runesSender.UTXOs[0])
+			runesSender.UTXOs[1].Outpoint = bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 1)
+
+			runesParams := runesParams
+			runesParams.RunesSender = &runesSender
+			runesParams.TransferRuneAmount = new(big.Int).Add(maxAmount, big.NewInt(1))
+			runesParams.BurnRuneAmount = nil
+
+			_, err := txBuilder.BuildRunesTransferTx(runesParams)
+			var edictErr *txbuilder.InvalidEdictError
+			require.ErrorAs(t, err, &edictErr)
+			require.Equal(t, "transfer", edictErr.Field)
+			require.Equal(t, "uint128", edictErr.LimitName)
+			require.Equal(t, maxAmount, edictErr.Limit)
+		})
+
+		t.Run("rune info", func(t *testing.T) {
+			runesParams := runesParams
+			runesParams.RuneInfo = &bitcoin.Rune{
+				ID:            runeID,
+				Divisibility:  2,
+				Premine:       big.NewInt(500),
+				MintAmount:    big.NewInt(100),
+				MintCapAmount: big.NewInt(5),
+			}
+
+			_, err := txBuilder.BuildRunesTransferTx(runesParams)
+			require.EqualError(t, err, "invalid edict: 1122:77 transfer+burn amount 10.5 exceeds supply 10")
+
+			runesParams.TransferRuneAmount = big.NewInt(999)
+			runesParams.BurnRuneAmount = big.NewInt(1)
+			_, err = txBuilder.BuildRunesTransferTx(runesParams)
+			require.NoError(t, err)
+
+			runesParams.RuneInfo = &bitcoin.Rune{ID: runes.RuneID{Block: 1, TxID: 1}}
+			_, err = txBuilder.BuildRunesTransferTx(runesParams)
+			require.ErrorIs(t, err, txbuilder.ErrRuneInfoMismatch)
+		})
+	})
+
+	t.Run("WithRuneStateOracle", func(t *testing.T) {
+		runeID := runes.RuneID{Block: 1122, TxID: 77}
+		runeUTXO := func(txHash string, amount int64) bitcoin.UTXO {
+			return bitcoin.UTXO{
+				Outpoint: bitcoin.MustOutpoint(txHash, 0),
+				Amount:   big.NewInt(546),
+				Script:   []byte("_bitcoin_transaction_rune_script_"),
+				Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+				Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(amount)}},
+			}
+		}
+
+		runesParams := txbuilder.BaseRunesTransferParams{
+			RuneID:             runeID,
+			TransferRuneAmount: big.NewInt(1500),
+			RunesSender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					runeUTXO("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 1000),
+					runeUTXO("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 1000),
+				},
+				Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+				PubKey:  "29fa611c361355b082ee593feb368009aa9c6bd1ed36c9983edcd113fb8da33f",
+			},
+			FeePayer:              params.Sender,
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+		}
+
+		// INFO: runes of the second utxo are burned by a cenotaph.
+		balances := map[string]int64{"d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746": 1000}
+		oracle := runeStateOracleFunc(func(_ context.Context, id runes.RuneID, utxos []*bitcoin.UTXO) ([]*big.Int, error) {
+			require.Equal(t, runeID, id)
+
+			result := make([]*big.Int, 0, len(utxos))
+			for _, utxo := range utxos {
+				result = append(result, big.NewInt(balances[utxo.TxHash()]))
+			}
+
+			return result, nil
+		})
+
+		_, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params).BuildRunesTransferTx(runesParams)
+		require.NoError(t, err)
+
+		txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params, txbuilder.WithRuneStateOracle(oracle))
+		_, err = txBuilder.BuildRunesTransferTx(runesParams)
+		require.ErrorIs(t, err, txbuilder.ErrStaleRuneUTXOs)
+
+		var staleErr *txbuilder.StaleRuneUTXOsError
+		require.ErrorAs(t, err, &staleErr)
+		require.Len(t, staleErr.UTXOs, 1)
+		require.Equal(t, "f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", staleErr.UTXOs[0].UTXO.TxHash())
+		require.EqualValues(t, 1000, staleErr.UTXOs[0].Expected.Int64())
+		require.EqualValues(t, 0, staleErr.UTXOs[0].Actual.Int64())
+
+		runesParams.TransferRuneAmount = big.NewInt(500)
+		runesParams.RunesSender.UTXOs = runesParams.RunesSender.UTXOs[:1]
+		_, err = txBuilder.BuildRunesTransferTx(runesParams)
+		require.NoError(t, err)
+	})
+
+	t.Run("WithVersionedInputsHelpingKeys", func(t *testing.T) {
+		result, p, err := build(t, txbuilder.WithVersionedInputsHelpingKeys())
+		require.NoError(t, err)
+		require.Len(t, p.Unknowns, 1)
+		require.Equal(t, txbuilder.PaymentInputsHelpingKey.ProprietaryKey(), p.Unknowns[0].Key)
+
+		indexes, err := txbuilder.ExtractAddressTypeInputIndexesFromPSBT(result.SerializedPSBT)
+		require.NoError(t, err)
+		require.Equal(t, map[txbuilder.InputsHelpingKey][]int{txbuilder.PaymentInputsHelpingKey: {0}}, indexes)
+	})
+
+	t.Run("getters and setters", func(t *testing.T) {
+		txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params)
+		require.EqualValues(t, 546, txBuilder.DustAmount().Int64())
+
+		dust := big.NewInt(1_000_000)
+		txBuilder.SetDustAmount(dust)
+		dust.SetInt64(1)
+		require.EqualValues(t, 1_000_000, txBuilder.DustAmount().Int64())
+		txBuilder.SetDustAmount(nil)
+		require.EqualValues(t, 1_000_000, txBuilder.DustAmount().Int64())
+
+		result, err := txBuilder.BuildBTCTransferTx(params)
+		require.NoError(t, err)
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 1) // change is dust.
+
+		estimator := txbuilder.DefaultSizeEstimator()
+		estimator.HeaderSize = big.NewInt(100)
+		txBuilder.SetSizeEstimator(estimator)
+		require.Equal(t, estimator, txBuilder.SizeEstimator())
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params)
+		_, err := txBuilder.BuildBTCTransferTxContext(ctx, params)
+		require.ErrorIs(t, err, context.Canceled)
+
+		_, err = txbuilder.PrepareUTXOsContext(ctx, txbuilder.PrepareUTXOsParams{
+			Utxos:          params.Sender.UTXOs,
+			TransferAmount: params.TransferSatoshiAmount,
+		})
+		require.ErrorIs(t, err, context.Canceled)
+
+		_, err = txBuilder.BuildBTCTransferTxContext(context.Background(), params)
+		require.NoError(t, err)
+	})
+
+	// NOTE: run with -race flag to detect data races.
+	t.Run("concurrent builds", func(t *testing.T) {
+		txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 16; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+
+				_, err := txBuilder.BuildBTCTransferTx(params)
+				require.NoError(t, err)
+			}()
+			go func(i int) {
+				defer wg.Done()
+
+				txBuilder.SetDustAmount(big.NewInt(int64(546 + i)))
+				txBuilder.SetSizeEstimator(txbuilder.DefaultSizeEstimator())
+				_ = txBuilder.DustAmount()
+			}(i)
+		}
+		wg.Wait()
+	})
+}
+
+// runeStateOracleFunc is an adapter to use function as txbuilder.RuneStateOracle.
+type runeStateOracleFunc func(ctx context.Context, runeID runes.RuneID, utxos []*bitcoin.UTXO) ([]*big.Int, error)
+
+// RuneBalances calls f(ctx, runeID, utxos).
+func (f runeStateOracleFunc) RuneBalances(ctx context.Context, runeID runes.RuneID, utxos []*bitcoin.UTXO) ([]*big.Int, error) {
+	return f(ctx, runeID, utxos)
+}
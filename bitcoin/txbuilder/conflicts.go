@@ -0,0 +1,51 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Conflict describes outpoint spent by several inputs of the PSBTs.
+type Conflict struct {
+	OutPoint wire.OutPoint
+	// PSBTs are indexes of the PSBTs spending the outpoint, one per spending input in ascending order,
+	// so the same index is repeated if a PSBT spends the outpoint by several inputs.
+	PSBTs []int
+}
+
+// DetectConflicts returns outpoints spent by more than one input of the PSBTs, e.g. to detect that pending
+// transactions double-spend each other before broadcasting. Conflicts are ordered by the first spending input.
+func DetectConflicts(psbts ...[]byte) ([]Conflict, error) {
+	var (
+		spends = make(map[wire.OutPoint][]int)
+		order  []wire.OutPoint
+	)
+	for i, serializedPSBT := range psbts {
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: psbt %d: %w", ErrInvalidPSBT, i, err)
+		}
+
+		for _, in := range p.UnsignedTx.TxIn {
+			if _, ok := spends[in.PreviousOutPoint]; !ok {
+				order = append(order, in.PreviousOutPoint)
+			}
+			spends[in.PreviousOutPoint] = append(spends[in.PreviousOutPoint], i)
+		}
+	}
+
+	var conflicts []Conflict
+	for _, outPoint := range order {
+		if len(spends[outPoint]) > 1 {
+			conflicts = append(conflicts, Conflict{OutPoint: outPoint, PSBTs: spends[outPoint]})
+		}
+	}
+
+	return conflicts, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestDetectConflicts(t *testing.T) {
+	newPSBT := func(t *testing.T, outPoints ...wire.OutPoint) []byte {
+		tx := wire.NewMsgTx(2)
+		for i := range outPoints {
+			tx.AddTxIn(wire.NewTxIn(&outPoints[i], nil, nil))
+		}
+		tx.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+
+		p, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+
+		var serialized bytes.Buffer
+		require.NoError(t, p.Serialize(&serialized))
+
+		return serialized.Bytes()
+	}
+
+	a := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+	b := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}
+	c := wire.OutPoint{Hash: chainhash.Hash{2}, Index: 0}
+
+	t.Run("no conflicts", func(t *testing.T) {
+		conflicts, err := txbuilder.DetectConflicts(newPSBT(t, a), newPSBT(t, b), newPSBT(t, c))
+		require.NoError(t, err)
+		require.Empty(t, conflicts)
+
+		conflicts, err = txbuilder.DetectConflicts()
+		require.NoError(t, err)
+		require.Empty(t, conflicts)
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		conflicts, err := txbuilder.DetectConflicts(newPSBT(t, c, a), newPSBT(t, b), newPSBT(t, a, c), newPSBT(t, b, b))
+		require.NoError(t, err)
+		require.Equal(t, []txbuilder.Conflict{
+			{OutPoint: c, PSBTs: []int{0, 2}},
+			{OutPoint: a, PSBTs: []int{0, 2}},
+			{OutPoint: b, PSBTs: []int{1, 3, 3}},
+		}, conflicts)
+	})
+
+	t.Run("invalid psbt", func(t *testing.T) {
+		_, err := txbuilder.DetectConflicts(newPSBT(t, a), []byte("psbt"))
+		require.ErrorIs(t, err, txbuilder.ErrInvalidPSBT)
+	})
+}
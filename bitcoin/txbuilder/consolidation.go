@@ -0,0 +1,142 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+var (
+	// ErrInvalidConsolidationTarget describes that consolidation target utxos count is not positive.
+	ErrInvalidConsolidationTarget = errors.New("invalid consolidation target")
+	// ErrConsolidationWeightCap describes that weight cap does not fit consolidation transaction with two inputs.
+	ErrConsolidationWeightCap = errors.New("weight cap is too small for consolidation")
+	// ErrUneconomicalConsolidation describes that consolidated amount does not cover the fee and dust amount.
+	ErrUneconomicalConsolidation = errors.New("uneconomical consolidation")
+)
+
+// ConsolidationPlanParams describes data needed to plan utxos consolidation.
+type ConsolidationPlanParams struct {
+	UTXOs            []bitcoin.UTXO // wallet btc utxos.
+	MaxUTXOs         int            // target maximum utxos count after consolidation.
+	MaxWeight        int64          // weight cap of each transaction in weight units, optional, MaxStandardTxWeight if not set.
+	SatoshiPerKVByte *big.Int       // fee rate in satoshi per kilo virtual byte.
+	SizeEstimator    SizeEstimator  // optional, DefaultSizeEstimator is used if not set.
+	DustAmount       *big.Int       // the smallest consolidated output amount, optional, 546 satoshi if not set.
+}
+
+// ConsolidationTx describes planned consolidation transaction with single output.
+type ConsolidationTx struct {
+	// Inputs are wallet utxos or planned outputs of the previous transactions, which have zero outpoint hash.
+	Inputs       []*bitcoin.UTXO
+	DependsOn    []int    // indexes of the plan transactions which outputs are spent.
+	InputAmount  *big.Int // total inputs amount in satoshi.
+	OutputAmount *big.Int // consolidated output amount in satoshi.
+	EstimatedFee *big.Int // estimated transaction fee in satoshi.
+	Weight       int64    // rough estimated transaction weight in weight units.
+}
+
+// ConsolidationPlan describes sequence of consolidation transactions.
+type ConsolidationPlan struct {
+	Transactions []ConsolidationTx
+	TotalFee     *big.Int // total estimated fee in satoshi.
+	UTXOs        int      // utxos count after consolidation.
+}
+
+// PlanConsolidation proposes sequence of consolidation transactions to reduce the number of utxos
+// to MaxUTXOs. The smallest utxos are consolidated first, each transaction has as many inputs as fits
+// the weight cap and a single output, which may be spent by the next transactions of the plan.
+// Transactions should be broadcast in the plan order.
+func PlanConsolidation(params ConsolidationPlanParams) (plan ConsolidationPlan, _ error) {
+	if params.MaxUTXOs < 1 {
+		return plan, fmt.Errorf("%w: %d", ErrInvalidConsolidationTarget, params.MaxUTXOs)
+	}
+	if params.MaxWeight <= 0 {
+		params.MaxWeight = MaxStandardTxWeight
+	}
+	if params.SizeEstimator == nil {
+		params.SizeEstimator = DefaultSizeEstimator()
+	}
+	if params.SatoshiPerKVByte == nil {
+		params.SatoshiPerKVByte = big.NewInt(0)
+	}
+	if params.DustAmount == nil {
+		params.DustAmount = big.NewInt(nonDustBitcoinAmount)
+	}
+
+	weightFn := func(inputs int) int64 {
+		return params.SizeEstimator.TxSize(inputs, 1).Int64() * witnessScaleFactor
+	}
+
+	maxInputs := 1
+	for maxInputs < len(params.UTXOs) && weightFn(maxInputs+1) <= params.MaxWeight {
+		maxInputs++
+	}
+
+	plan.TotalFee = big.NewInt(0)
+	plan.UTXOs = len(params.UTXOs)
+	if len(params.UTXOs) <= params.MaxUTXOs {
+		return plan, nil
+	}
+
+	if maxInputs < 2 {
+		return plan, fmt.Errorf("%w: %d WU", ErrConsolidationWeightCap, params.MaxWeight)
+	}
+
+	pool := make([]*bitcoin.UTXO, 0, len(params.UTXOs))
+	for i := range params.UTXOs {
+		pool = append(pool, &params.UTXOs[i])
+	}
+
+	byAmount := func(a, b *bitcoin.UTXO) int { return a.Amount.Cmp(b.Amount) }
+	slices.SortStableFunc(pool, byAmount)
+
+	plannedOutputs := make(map[*bitcoin.UTXO]int)
+	for len(pool) > params.MaxUTXOs {
+		inputs := min(maxInputs, len(pool)-params.MaxUTXOs+1)
+
+		tx := ConsolidationTx{
+			Inputs:      slices.Clone(pool[:inputs]),
+			InputAmount: big.NewInt(0),
+			Weight:      weightFn(inputs),
+		}
+		for _, utxo := range tx.Inputs {
+			tx.InputAmount.Add(tx.InputAmount, utxo.Amount)
+			if idx, ok := plannedOutputs[utxo]; ok {
+				tx.DependsOn = append(tx.DependsOn, idx)
+			}
+		}
+		slices.Sort(tx.DependsOn)
+
+		// INFO: vB * ( sat / kvB ) = 1000 sat.
+		tx.EstimatedFee = new(big.Int).Mul(params.SizeEstimator.TxSize(inputs, 1), params.SatoshiPerKVByte)
+		tx.EstimatedFee.Div(tx.EstimatedFee, big.NewInt(1000)) // sat.
+
+		tx.OutputAmount = new(big.Int).Sub(tx.InputAmount, tx.EstimatedFee)
+		if numbers.IsLess(tx.OutputAmount, params.DustAmount) {
+			return plan, fmt.Errorf("%w: %d inputs amount %s, fee %s", ErrUneconomicalConsolidation, inputs,
+				tx.InputAmount, tx.EstimatedFee)
+		}
+
+		output := &bitcoin.UTXO{Amount: tx.OutputAmount}
+		plannedOutputs[output] = len(plan.Transactions)
+
+		pool = pool[inputs:]
+		idx, _ := slices.BinarySearchFunc(pool, output, byAmount)
+		pool = slices.Insert(pool, idx, output)
+
+		plan.Transactions = append(plan.Transactions, tx)
+		plan.TotalFee.Add(plan.TotalFee, tx.EstimatedFee)
+	}
+
+	plan.UTXOs = len(pool)
+
+	return plan, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestPlanConsolidation(t *testing.T) {
+	utxos := func(amounts ...int64) []bitcoin.UTXO {
+		result := make([]bitcoin.UTXO, 0, len(amounts))
+		for i, amount := range amounts {
+			result = append(result, bitcoin.UTXO{
+				Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", uint32(i)),
+				Amount:   big.NewInt(amount),
+			})
+		}
+
+		return result
+	}
+
+	t.Run("nothing to consolidate", func(t *testing.T) {
+		plan, err := txbuilder.PlanConsolidation(txbuilder.ConsolidationPlanParams{
+			UTXOs:    utxos(10000, 20000),
+			MaxUTXOs: 2,
+		})
+		require.NoError(t, err)
+		require.Empty(t, plan.Transactions)
+		require.EqualValues(t, 0, plan.TotalFee.Int64())
+		require.Equal(t, 2, plan.UTXOs)
+	})
+
+	t.Run("single transaction", func(t *testing.T) {
+		params := txbuilder.ConsolidationPlanParams{
+			UTXOs:            utxos(50000, 10000, 30000, 20000, 40000),
+			MaxUTXOs:         2,
+			SatoshiPerKVByte: big.NewInt(10000), // 10 sat/vB.
+		}
+		plan, err := txbuilder.PlanConsolidation(params)
+		require.NoError(t, err)
+		require.Len(t, plan.Transactions, 1)
+		require.Equal(t, 2, plan.UTXOs)
+
+		tx := plan.Transactions[0]
+		require.Equal(t, []*bitcoin.UTXO{&params.UTXOs[1], &params.UTXOs[3], &params.UTXOs[2], &params.UTXOs[4]}, tx.Inputs)
+		require.Empty(t, tx.DependsOn)
+		require.EqualValues(t, 100000, tx.InputAmount.Int64())
+		require.EqualValues(t, (11+4*90+30)*10, tx.EstimatedFee.Int64())
+		require.EqualValues(t, 100000-(11+4*90+30)*10, tx.OutputAmount.Int64())
+		require.EqualValues(t, (11+4*90+30)*4, tx.Weight)
+		require.Equal(t, tx.EstimatedFee, plan.TotalFee)
+	})
+
+	t.Run("weight cap", func(t *testing.T) {
+		params := txbuilder.ConsolidationPlanParams{
+			UTXOs:            utxos(10000, 10000, 10000, 10000, 10000, 10000, 10000),
+			MaxUTXOs:         1,
+			MaxWeight:        (11 + 3*90 + 30) * 4, // 3 inputs.
+			SatoshiPerKVByte: big.NewInt(1000),     // 1 sat/vB.
+		}
+		plan, err := txbuilder.PlanConsolidation(params)
+		require.NoError(t, err)
+		require.Equal(t, 1, plan.UTXOs)
+		require.Len(t, plan.Transactions, 3)
+
+		totalFee := int64(0)
+		for _, tx := range plan.Transactions {
+			require.LessOrEqual(t, tx.Weight, params.MaxWeight)
+			totalFee += tx.EstimatedFee.Int64()
+		}
+		require.EqualValues(t, totalFee, plan.TotalFee.Int64())
+		require.EqualValues(t, 70000-totalFee, plan.Transactions[2].OutputAmount.Int64())
+		require.Equal(t, []int{0, 1}, plan.Transactions[2].DependsOn)
+		require.NotZero(t, plan.Transactions[2].Inputs[0].Hash)
+		require.Zero(t, plan.Transactions[2].Inputs[1].Hash)
+		require.Zero(t, plan.Transactions[2].Inputs[2].Hash)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := txbuilder.PlanConsolidation(txbuilder.ConsolidationPlanParams{UTXOs: utxos(1000), MaxUTXOs: 0})
+		require.ErrorIs(t, err, txbuilder.ErrInvalidConsolidationTarget)
+
+		_, err = txbuilder.PlanConsolidation(txbuilder.ConsolidationPlanParams{UTXOs: utxos(1000, 1000), MaxUTXOs: 1, MaxWeight: 100})
+		require.ErrorIs(t, err, txbuilder.ErrConsolidationWeightCap)
+
+		_, err = txbuilder.PlanConsolidation(txbuilder.ConsolidationPlanParams{
+			UTXOs:            utxos(600, 600),
+			MaxUTXOs:         1,
+			SatoshiPerKVByte: big.NewInt(10000),
+		})
+		require.ErrorIs(t, err, txbuilder.ErrUneconomicalConsolidation)
+	})
+}
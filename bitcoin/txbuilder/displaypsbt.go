@@ -0,0 +1,172 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ErrDisplayPSBTMismatch describes that display PSBT is not produced from the full PSBT.
+var ErrDisplayPSBTMismatch = errors.New("display psbt does not match the psbt")
+
+const (
+	// displayBindingSubtype defines proprietary key subtype of the display PSBT binding.
+	displayBindingSubtype byte = 0x03
+	// displayBindingTag defines tag of the display PSBT binding hash.
+	displayBindingTag = "txbuilder/display-psbt"
+)
+
+// DisplayPSBT describes redacted PSBT to be shown by untrusted front-ends.
+type DisplayPSBT struct {
+	// SerializedPSBT is a PSBT with the unsigned transaction, spent outputs, output roles and the binding,
+	// without witness and redeem scripts, keys, derivation paths, signatures and inputs helping keys.
+	SerializedPSBT []byte
+	Fee            *big.Int       // transaction fee in satoshi.
+	Binding        chainhash.Hash // binding of the full PSBT, see DisplayBinding.
+}
+
+// DisplayBindingKey returns PSBT global proprietary key of the display PSBT binding:
+// 0xFC | identifier length | "txbuilder" | 0x03.
+func DisplayBindingKey() []byte {
+	key := OutputRoleKey()
+	key[len(key)-1] = displayBindingSubtype
+
+	return key
+}
+
+// RedactPSBT returns display PSBT of the serialized PSBT, which contains outputs, amounts and fee only,
+// so it could be sent to the front-end, while the full PSBT is kept by the service. Output roles are kept
+// to label recipient, change and commission outputs. The display PSBT carries DisplayBinding of the full
+// PSBT in the global proprietary field, see VerifyDisplayPSBT to match the PSBT approved by the user.
+// NOTE: Spent outputs are kept as witness and non-witness utxos of the PSBT, so the fee could be checked
+// by the front-end.
+func RedactPSBT(serializedPSBT []byte) (display DisplayPSBT, err error) {
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+	if err != nil {
+		return display, fmt.Errorf("%w: %w", ErrInvalidPSBT, err)
+	}
+
+	prevOuts, err := psbtPrevOuts(p)
+	if err != nil {
+		return display, err
+	}
+
+	display.Binding, err = displayBinding(p.UnsignedTx, prevOuts)
+	if err != nil {
+		return display, err
+	}
+
+	redacted, err := psbt.NewFromUnsignedTx(p.UnsignedTx.Copy())
+	if err != nil {
+		return display, err
+	}
+
+	display.Fee = big.NewInt(0)
+	for i, prevOut := range prevOuts {
+		input := p.Inputs[i]
+		if input.WitnessUtxo != nil {
+			redacted.Inputs[i].WitnessUtxo = wire.NewTxOut(input.WitnessUtxo.Value, input.WitnessUtxo.PkScript)
+		}
+		if input.NonWitnessUtxo != nil {
+			redacted.Inputs[i].NonWitnessUtxo = input.NonWitnessUtxo.Copy()
+		}
+		display.Fee.Add(display.Fee, big.NewInt(prevOut.Value))
+	}
+
+	key := OutputRoleKey()
+	for i, output := range p.Outputs {
+		display.Fee.Sub(display.Fee, big.NewInt(p.UnsignedTx.TxOut[i].Value))
+		for _, unknown := range output.Unknowns {
+			if bytes.Equal(unknown.Key, key) {
+				redacted.Outputs[i].Unknowns = append(redacted.Outputs[i].Unknowns, unknown)
+			}
+		}
+	}
+
+	redacted.Unknowns = []*psbt.Unknown{{Key: DisplayBindingKey(), Value: display.Binding[:]}}
+
+	w := bytes.NewBuffer(nil)
+	if err = redacted.Serialize(w); err != nil {
+		return display, err
+	}
+	display.SerializedPSBT = w.Bytes()
+
+	return display, nil
+}
+
+// DisplayBinding returns binding of the PSBT: tagged hash of the unsigned transaction and its spent outputs.
+// Binding does not depend on signatures and other input data, so it matches the PSBT after signing.
+func DisplayBinding(serializedPSBT []byte) (chainhash.Hash, error) {
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+	if err != nil {
+		return chainhash.Hash{}, fmt.Errorf("%w: %w", ErrInvalidPSBT, err)
+	}
+
+	prevOuts, err := psbtPrevOuts(p)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	return displayBinding(p.UnsignedTx, prevOuts)
+}
+
+// VerifyDisplayPSBT returns ErrDisplayPSBTMismatch if display PSBT, e.g. approved by the user,
+// is not the redacted serialized PSBT, see RedactPSBT.
+func VerifyDisplayPSBT(serializedPSBT, displayPSBT []byte) error {
+	display, err := RedactPSBT(serializedPSBT)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(display.SerializedPSBT, displayPSBT) {
+		return ErrDisplayPSBTMismatch
+	}
+
+	return nil
+}
+
+// psbtPrevOuts returns outputs spent by the PSBT inputs.
+func psbtPrevOuts(p *psbt.Packet) ([]*wire.TxOut, error) {
+	prevOuts := make([]*wire.TxOut, len(p.Inputs))
+	for i, input := range p.Inputs {
+		switch {
+		case input.WitnessUtxo != nil:
+			prevOuts[i] = input.WitnessUtxo
+		case input.NonWitnessUtxo != nil:
+			outIndex := p.UnsignedTx.TxIn[i].PreviousOutPoint.Index
+			if int(outIndex) >= len(input.NonWitnessUtxo.TxOut) {
+				return nil, fmt.Errorf("%w: input %d previous output %d is missing", ErrInvalidPSBT, i, outIndex)
+			}
+
+			prevOuts[i] = input.NonWitnessUtxo.TxOut[outIndex]
+		default:
+			return nil, fmt.Errorf("%w: input %d previous output is missing", ErrInvalidPSBT, i)
+		}
+	}
+
+	return prevOuts, nil
+}
+
+// displayBinding returns tagged hash of the unsigned transaction without witness and the spent outputs.
+func displayBinding(tx *wire.MsgTx, prevOuts []*wire.TxOut) (chainhash.Hash, error) {
+	w := bytes.NewBuffer(nil)
+	if err := tx.SerializeNoWitness(w); err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	for _, prevOut := range prevOuts {
+		if err := wire.WriteTxOut(w, 0, 0, prevOut); err != nil {
+			return chainhash.Hash{}, err
+		}
+	}
+
+	return *chainhash.TaggedHash([]byte(displayBindingTag), w.Bytes()), nil
+}
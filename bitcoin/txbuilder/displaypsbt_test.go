@@ -0,0 +1,112 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestRedactPSBT(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	params := txbuilder.BaseBTCTransferParams{
+		Sender:                wallet.PaymentData("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 100000),
+		TransferSatoshiAmount: big.NewInt(10000),
+		SatoshiPerKVByte:      big.NewInt(10000), // 10 sat/vB.
+		RecipientAddress:      wallet.Address,
+	}
+
+	builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithOutputRoles())
+	result, err := builder.BuildBTCTransferTx(params)
+	require.NoError(t, err)
+
+	t.Run("redacted", func(t *testing.T) {
+		display, err := txbuilder.RedactPSBT(result.SerializedPSBT)
+		require.NoError(t, err)
+		require.Equal(t, result.EstimatedFee, display.Fee)
+
+		full, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.NotEmpty(t, full.Inputs[0].TaprootInternalKey)
+		require.NotEmpty(t, full.Unknowns)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(display.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Equal(t, full.UnsignedTx.TxHash(), p.UnsignedTx.TxHash())
+		require.Empty(t, p.Inputs[0].TaprootInternalKey)
+		require.Empty(t, p.Inputs[0].TaprootBip32Derivation)
+		require.Equal(t, full.Inputs[0].WitnessUtxo, p.Inputs[0].WitnessUtxo)
+		require.Zero(t, p.Inputs[0].SighashType)
+		require.Equal(t, []*psbt.Unknown{{Key: txbuilder.DisplayBindingKey(), Value: display.Binding[:]}}, p.Unknowns)
+
+		roles, err := txbuilder.ExtractOutputRolesFromPSBT(display.SerializedPSBT)
+		require.NoError(t, err)
+		require.Equal(t, []txbuilder.OutputRole{txbuilder.OutputRoleRecipient, txbuilder.OutputRoleChange}, roles)
+
+		binding, err := txbuilder.DisplayBinding(result.SerializedPSBT)
+		require.NoError(t, err)
+		require.Equal(t, display.Binding, binding)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		display, err := txbuilder.RedactPSBT(result.SerializedPSBT)
+		require.NoError(t, err)
+		require.NoError(t, txbuilder.VerifyDisplayPSBT(result.SerializedPSBT, display.SerializedPSBT))
+
+		other := params
+		other.TransferSatoshiAmount = big.NewInt(20000)
+		otherResult, err := builder.BuildBTCTransferTx(other)
+		require.NoError(t, err)
+		require.ErrorIs(t, txbuilder.VerifyDisplayPSBT(otherResult.SerializedPSBT, display.SerializedPSBT),
+			txbuilder.ErrDisplayPSBTMismatch)
+
+		otherBinding, err := txbuilder.DisplayBinding(otherResult.SerializedPSBT)
+		require.NoError(t, err)
+		require.NotEqual(t, display.Binding, otherBinding)
+	})
+
+	t.Run("non-witness utxo", func(t *testing.T) {
+		prevTx := wire.NewMsgTx(wire.TxVersion)
+		prevTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+		prevTx.AddTxOut(wire.NewTxOut(50000, wallet.Script))
+
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: prevTx.TxHash(), Index: 0}, nil, nil))
+		tx.AddTxOut(wire.NewTxOut(49000, wallet.Script))
+
+		full, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+		full.Inputs[0].NonWitnessUtxo = prevTx
+
+		w := bytes.NewBuffer(nil)
+		require.NoError(t, full.Serialize(w))
+
+		display, err := txbuilder.RedactPSBT(w.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1000), display.Fee)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(display.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Nil(t, p.Inputs[0].WitnessUtxo)
+		require.Equal(t, prevTx.TxHash(), p.Inputs[0].NonWitnessUtxo.TxHash())
+		require.NoError(t, txbuilder.VerifyDisplayPSBT(w.Bytes(), display.SerializedPSBT))
+	})
+
+	t.Run("invalid psbt", func(t *testing.T) {
+		_, err := txbuilder.RedactPSBT([]byte("psbt"))
+		require.ErrorIs(t, err, txbuilder.ErrInvalidPSBT)
+	})
+}
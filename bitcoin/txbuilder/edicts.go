@@ -0,0 +1,136 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// ErrRuneInfoMismatch describes that provided rune info does not describe transferring rune.
+var ErrRuneInfoMismatch = errors.New("rune info does not match rune id")
+
+// validateEdicts checks runes transfer amounts in strict mode: amounts are valid rune amounts of the rune
+// divisibility (see runes.ValidateAmount), transfer and burn amounts are covered by sender holdings and
+// do not exceed rune supply if rune info is provided.
+func validateEdicts(params BaseRunesTransferParams) error {
+	var divisibility byte
+	if params.RuneInfo != nil {
+		if params.RuneInfo.ID != params.RuneID {
+			return fmt.Errorf("%w: %s, expected %s", ErrRuneInfoMismatch, params.RuneInfo.ID.String(), params.RuneID.String())
+		}
+
+		if params.RuneInfo.Divisibility > runes.MaxDivisibility {
+			return fmt.Errorf("%w: divisibility %d is greater than %d", ErrRuneInfoMismatch, params.RuneInfo.Divisibility, runes.MaxDivisibility)
+		}
+
+		divisibility = params.RuneInfo.Divisibility
+	}
+
+	invalidEdict := func(field string, amount, limit *big.Int, limitName string) error {
+		return &InvalidEdictError{
+			RuneID:       params.RuneID,
+			Field:        field,
+			Amount:       amount,
+			Limit:        limit,
+			LimitName:    limitName,
+			Divisibility: divisibility,
+		}
+	}
+
+	transfer, burn := big.NewInt(0), big.NewInt(0)
+	if params.TransferRuneAmount != nil {
+		transfer = params.TransferRuneAmount
+	}
+	if params.BurnRuneAmount != nil {
+		burn = params.BurnRuneAmount
+	}
+
+	// INFO: amounts are validated the same way as parsed rune amounts, edict amount is uint128.
+	for _, amount := range []struct {
+		field string
+		value *big.Int
+	}{{"transfer", transfer}, {"burn", burn}} {
+		if err := runes.ValidateAmount(amount.value, divisibility); err != nil {
+			if numbers.IsNegative(amount.value) {
+				return invalidEdict(amount.field, amount.value, nil, "")
+			}
+
+			return invalidEdict(amount.field, amount.value, numbers.MaxUInt128Value, "uint128")
+		}
+	}
+
+	total := new(big.Int).Add(transfer, burn)
+	if params.RuneInfo != nil {
+		supply := runeSupply(params.RuneInfo)
+		for _, amount := range []struct {
+			field string
+			value *big.Int
+		}{{"transfer", transfer}, {"burn", burn}, {"transfer+burn", total}} {
+			if numbers.IsGreater(amount.value, supply) {
+				return invalidEdict(amount.field, amount.value, supply, "supply")
+			}
+		}
+	}
+
+	if holdings := bitcoin.TotalRune(params.RunesSender.UTXOs, params.RuneID); numbers.IsGreater(total, holdings) {
+		return invalidEdict("transfer+burn", total, holdings, "holdings")
+	}
+
+	return nil
+}
+
+// runeSupply returns maximum rune supply: premine and all mints.
+func runeSupply(rune_ *bitcoin.Rune) *big.Int {
+	supply := big.NewInt(0)
+	if rune_.Premine != nil {
+		supply.Add(supply, rune_.Premine)
+	}
+
+	if rune_.MintAmount != nil && rune_.MintCapAmount != nil {
+		supply.Add(supply, new(big.Int).Mul(rune_.MintAmount, rune_.MintCapAmount))
+	}
+
+	return supply
+}
+
+// checkRuneUTXOs returns StaleRuneUTXOsError if actual rune balances of the utxos reported by the oracle
+// differ from the provided ones, so edicts would transfer runes the sender does not hold.
+func checkRuneUTXOs(ctx context.Context, oracle RuneStateOracle, runeID runes.RuneID, utxos []*bitcoin.UTXO) error {
+	if oracle == nil || len(utxos) == 0 {
+		return nil
+	}
+
+	balances, err := oracle.RuneBalances(ctx, runeID, utxos)
+	if err != nil {
+		return err
+	}
+	if len(balances) != len(utxos) {
+		return fmt.Errorf("rune state oracle returned %d balances for %d utxos", len(balances), len(utxos))
+	}
+
+	staleErr := &StaleRuneUTXOsError{RuneID: runeID}
+	for idx, utxo := range utxos {
+		expected, actual := utxo.RuneAmount(runeID), balances[idx]
+		if actual == nil {
+			actual = big.NewInt(0)
+		}
+
+		if !numbers.IsEqual(expected, actual) {
+			staleErr.UTXOs = append(staleErr.UTXOs, StaleRuneUTXO{UTXO: utxo, Expected: expected, Actual: actual})
+		}
+	}
+
+	if len(staleErr.UTXOs) != 0 {
+		return staleErr
+	}
+
+	return nil
+}
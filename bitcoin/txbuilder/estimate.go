@@ -0,0 +1,122 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// EstimateResult describes transaction estimation made by the same utxos selection and fee
+// calculation as the builders do, but without PSBT construction.
+type EstimateResult struct {
+	EstimatedFee  *big.Int     // estimated transaction fee in Satoshi, equal to the builder result one.
+	VSize         *big.Int     // estimated transaction size in vBytes by the configured SizeEstimator.
+	Inputs        int          // number of the transaction inputs.
+	Outputs       int          // number of the transaction outputs.
+	ChangeAmounts []*big.Int   // amounts in Satoshi of the bitcoin change outputs in outputs order.
+	OutputRoles   []OutputRole // roles of the transaction outputs by their indexes.
+}
+
+// EstimateRunesTransfer estimates rune transferring transaction built by BuildRunesTransferTx.
+// Public keys of the payment data are not required.
+func (b *TxBuilder) EstimateRunesTransfer(params BaseRunesTransferParams) (EstimateResult, error) {
+	return b.EstimateRunesTransferContext(context.Background(), params)
+}
+
+// EstimateRunesTransferContext is like EstimateRunesTransfer, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) EstimateRunesTransferContext(ctx context.Context, params BaseRunesTransferParams) (EstimateResult, error) {
+	return b.EstimateRunesAndBTCTransferContext(ctx, BaseRunesAndBTCTransferParams{BaseRunesTransferParams: params})
+}
+
+// EstimateRunesAndBTCTransfer estimates runes and btc transferring transaction built by
+// BuildRunesAndBTCTransferTx. Public keys of the payment data are not required.
+func (b *TxBuilder) EstimateRunesAndBTCTransfer(params BaseRunesAndBTCTransferParams) (EstimateResult, error) {
+	return b.EstimateRunesAndBTCTransferContext(context.Background(), params)
+}
+
+// EstimateRunesAndBTCTransferContext is like EstimateRunesAndBTCTransfer, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) EstimateRunesAndBTCTransferContext(ctx context.Context, params BaseRunesAndBTCTransferParams) (EstimateResult, error) {
+	builder := b.snapshot()
+
+	result, err := builder.buildBaseTransferRuneTx(ctx, params)
+	if err != nil {
+		return EstimateResult{}, err
+	}
+
+	return newEstimateResult(result.UnsignedRawTx, result.EstimatedFee, result.OutputRoles,
+		builder.config.SizeEstimator.TxSize(len(result.UnsignedRawTx.TxIn), len(result.UnsignedRawTx.TxOut))), nil
+}
+
+// EstimateBTCTransfer estimates btc transferring transaction built by BuildBTCTransferTx.
+// Public keys of the payment data are not required.
+func (b *TxBuilder) EstimateBTCTransfer(params BaseBTCTransferParams) (EstimateResult, error) {
+	return b.EstimateBTCTransferContext(context.Background(), params)
+}
+
+// EstimateBTCTransferContext is like EstimateBTCTransfer, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) EstimateBTCTransferContext(ctx context.Context, params BaseBTCTransferParams) (EstimateResult, error) {
+	builder := b.snapshot()
+
+	result, err := builder.buildBaseTransferBTCTx(ctx, params)
+	if err != nil {
+		return EstimateResult{}, err
+	}
+
+	return newEstimateResult(result.UnsignedRawTx, result.EstimatedFee, result.OutputRoles,
+		builder.config.SizeEstimator.TxSize(len(result.UnsignedRawTx.TxIn), len(result.UnsignedRawTx.TxOut))), nil
+}
+
+// EstimateEtch estimates inscription reveal - etch transaction built by BuildRuneEtchTx.
+// Public keys of the payment data are not required.
+func (b *TxBuilder) EstimateEtch(params BaseRuneEtchTxParams) (EstimateResult, error) {
+	return b.EstimateEtchContext(context.Background(), params)
+}
+
+// EstimateEtchContext is like EstimateEtch, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) EstimateEtchContext(ctx context.Context, params BaseRuneEtchTxParams) (EstimateResult, error) {
+	builder := b.snapshot()
+
+	result, err := builder.buildRuneEtchTx(ctx, params)
+	if err != nil {
+		return EstimateResult{}, err
+	}
+
+	// INFO: the first input is inscription script path spending, its witness is estimated separately.
+	inscriptionWitnessSize, err := inscriptionWitnessVBytes(params.Inscription, params.Recovery)
+	if err != nil {
+		return EstimateResult{}, err
+	}
+
+	vSize := builder.config.SizeEstimator.TxSize(len(result.UnsignedRawTx.TxIn)-1, len(result.UnsignedRawTx.TxOut))
+	vSize.Add(vSize, builder.config.SizeEstimator.InscriptionInputSize())
+	vSize.Add(vSize, big.NewInt(int64(inscriptionWitnessSize)))
+
+	return newEstimateResult(result.UnsignedRawTx, result.EstimatedFee, result.OutputRoles, vSize), nil
+}
+
+// newEstimateResult returns estimation of the unsigned transaction.
+func newEstimateResult(tx *wire.MsgTx, fee *big.Int, roles []OutputRole, vSize *big.Int) EstimateResult {
+	result := EstimateResult{
+		EstimatedFee: fee,
+		VSize:        vSize,
+		Inputs:       len(tx.TxIn),
+		Outputs:      len(tx.TxOut),
+		OutputRoles:  roles,
+	}
+
+	for index, role := range roles {
+		if role == OutputRoleChange {
+			result.ChangeAmounts = append(result.ChangeAmounts, big.NewInt(tx.TxOut[index].Value))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestEstimate(t *testing.T) {
+	txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params)
+
+	sender := &txbuilder.PaymentData{
+		UTXOs: []bitcoin.UTXO{
+			{
+				Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+				Amount:   big.NewInt(850000), // 0.0085 BTC.
+				Script:   []byte("_bitcoin_transaction_script_"),
+				Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			},
+		},
+		Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+		PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+	}
+
+	changes := func(t *testing.T, serializedPSBT []byte, roles []txbuilder.OutputRole) []*big.Int {
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+		require.NoError(t, err)
+
+		var amounts []*big.Int
+		for index, role := range roles {
+			if role == txbuilder.OutputRoleChange {
+				amounts = append(amounts, big.NewInt(p.UnsignedTx.TxOut[index].Value))
+			}
+		}
+
+		return amounts
+	}
+
+	t.Run("btc transfer", func(t *testing.T) {
+		params := txbuilder.BaseBTCTransferParams{
+			Sender:                sender,
+			TransferSatoshiAmount: big.NewInt(29500), // 0.000295 BTC.
+			SatoshiPerKVByte:      big.NewInt(5000),  // 5 sat/vB.
+			RecipientAddress:      "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+		}
+
+		estimate, err := txBuilder.EstimateBTCTransfer(params)
+		require.NoError(t, err)
+
+		result, err := txBuilder.BuildBTCTransferTx(params)
+		require.NoError(t, err)
+
+		require.Equal(t, result.EstimatedFee, estimate.EstimatedFee)
+		require.Equal(t, 1, estimate.Inputs)
+		require.Equal(t, 2, estimate.Outputs)
+		require.Equal(t, []txbuilder.OutputRole{txbuilder.OutputRoleRecipient, txbuilder.OutputRoleChange}, estimate.OutputRoles)
+		require.Equal(t, changes(t, result.SerializedPSBT, estimate.OutputRoles), estimate.ChangeAmounts)
+		require.Equal(t, txBuilder.SizeEstimator().TxSize(1, 2), estimate.VSize)
+
+		t.Run("without public keys", func(t *testing.T) {
+			params.Sender = &txbuilder.PaymentData{UTXOs: sender.UTXOs, Address: sender.Address}
+
+			withoutKeys, err := txBuilder.EstimateBTCTransfer(params)
+			require.NoError(t, err)
+			require.Equal(t, estimate, withoutKeys)
+		})
+
+		t.Run("insufficient", func(t *testing.T) {
+			params.TransferSatoshiAmount = big.NewInt(1000000)
+
+			_, err := txBuilder.EstimateBTCTransfer(params)
+			var errIns *txbuilder.InsufficientError
+			require.ErrorAs(t, err, &errIns)
+		})
+	})
+
+	t.Run("runes transfer", func(t *testing.T) {
+		runeID := runes.RuneID{Block: 1122, TxID: 77}
+		params := txbuilder.BaseRunesTransferParams{
+			RuneID:             runeID,
+			TransferRuneAmount: big.NewInt(1000),
+			RunesSender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0),
+					Amount:   big.NewInt(546),
+					Script:   []byte("_bitcoin_transaction_rune_script_"),
+					Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+					Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(5000)}},
+				}},
+				Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+				PubKey:  "29fa611c361355b082ee593feb368009aa9c6bd1ed36c9983edcd113fb8da33f",
+			},
+			FeePayer:              sender,
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+		}
+
+		estimate, err := txBuilder.EstimateRunesTransfer(params)
+		require.NoError(t, err)
+
+		result, err := txBuilder.BuildRunesTransferTx(params)
+		require.NoError(t, err)
+
+		require.Equal(t, result.EstimatedFee, estimate.EstimatedFee)
+		require.Equal(t, 2, estimate.Inputs)
+		require.Equal(t, 4, estimate.Outputs)
+		require.Equal(t, changes(t, result.SerializedPSBT, estimate.OutputRoles), estimate.ChangeAmounts)
+		require.Len(t, estimate.ChangeAmounts, 1)
+	})
+
+	t.Run("etch", func(t *testing.T) {
+		rune_, err := runes.NewRuneFromString("HELLO")
+		require.NoError(t, err)
+
+		inscription := &inscriptions.Inscription{Rune: rune_, Body: []byte("test data")}
+		params := txbuilder.BaseRuneEtchTxParams{
+			InscriptionReveal: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0),
+					Amount:   big.NewInt(20000),
+					Script:   []byte("_bitcoin_transaction_script_"),
+					Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+				}},
+				Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+				PubKey:  "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+			},
+			Inscription: inscription,
+			Rune: &runes.Etching{
+				Divisibility: toPointer(byte(5)),
+				Premine:      big.NewInt(1000000000),
+				Rune:         rune_,
+			},
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+			SatoshiChangeAddress:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+		}
+
+		params.InscriptionReveal.UTXOs[0].Script, err = txBuilder.CommitScript(inscription, params.InscriptionReveal.PubKey, nil)
+		require.NoError(t, err)
+
+		estimate, err := txBuilder.EstimateEtch(params)
+		require.NoError(t, err)
+
+		result, err := txBuilder.BuildRuneEtchTx(params)
+		require.NoError(t, err)
+
+		inscriptionSize, err := inscription.VBytesSize()
+		require.NoError(t, err)
+
+		require.Equal(t, result.EstimatedFee, estimate.EstimatedFee)
+		require.Equal(t, 1, estimate.Inputs)
+		require.Equal(t, 3, estimate.Outputs)
+		require.Equal(t, []txbuilder.OutputRole{
+			txbuilder.OutputRoleRunestone, txbuilder.OutputRoleRecipient, txbuilder.OutputRoleChange,
+		}, estimate.OutputRoles)
+		require.Equal(t, changes(t, result.SerializedPSBT, estimate.OutputRoles), estimate.ChangeAmounts)
+
+		vSize := new(big.Int).Add(txBuilder.SizeEstimator().TxSize(0, 3), txBuilder.SizeEstimator().InscriptionInputSize())
+		require.Equal(t, vSize.Add(vSize, big.NewInt(int64(inscriptionSize))), estimate.VSize)
+	})
+}
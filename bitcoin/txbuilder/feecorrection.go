@@ -0,0 +1,152 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// ErrMissingInputAmount describes that PSBT input has no previous output data to calculate the fee.
+var ErrMissingInputAmount = errors.New("input amount is unknown")
+
+// CorrectFeeParams describes data needed to check signed transaction fee rate and correct the change.
+type CorrectFeeParams struct {
+	// SignedPSBT is signed transaction in PSBT format. Inputs should not be finalized to keep
+	// signing data, e.g. sighash type, for the corrected transaction.
+	SignedPSBT        []byte
+	ChangeOutputIndex int      // output which receives overpaid fee.
+	SatoshiPerKVByte  *big.Int // target fee rate in satoshi per kilo virtual byte.
+	// ToleranceSatoshiPerKVByte is an allowed fee rate overpayment in satoshi per kilo virtual byte, optional.
+	ToleranceSatoshiPerKVByte *big.Int
+}
+
+// CorrectFeeResult describes result of the CorrectFee.
+type CorrectFeeResult struct {
+	VSize                    int64    // exact signed transaction size in vBytes.
+	Fee                      *big.Int // fee in satoshi paid by the signed transaction, based on rough estimate.
+	ExactFee                 *big.Int // fee in satoshi for the exact size at target fee rate.
+	AchievedSatoshiPerKVByte *big.Int // fee rate of the signed transaction in satoshi per kilo virtual byte.
+	// SerializedPSBT is unsigned PSBT with overpaid fee moved to change output, nil if fee is within tolerance.
+	// NOTE: it should be signed again, since the change output amount is committed by signatures.
+	SerializedPSBT []byte
+}
+
+// CorrectFee calculates exact virtual size of the signed transaction and its achieved fee rate.
+// If the transaction overpays target fee rate beyond the tolerance, returns unsigned copy of the
+// transaction with overpaid amount added to the change output. Changing amount does not change
+// transaction size, so the corrected transaction pays exact fee after signing.
+func CorrectFee(params CorrectFeeParams) (result CorrectFeeResult, _ error) {
+	if params.SatoshiPerKVByte == nil {
+		return result, fmt.Errorf("%w: target fee rate is required", ErrFeeRateOutOfBounds)
+	}
+
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(params.SignedPSBT), false)
+	if err != nil {
+		return result, err
+	}
+
+	if params.ChangeOutputIndex < 0 || params.ChangeOutputIndex >= len(p.UnsignedTx.TxOut) {
+		return result, fmt.Errorf("%w: index %d of %d outputs", ErrInvalidChangeOutput, params.ChangeOutputIndex, len(p.UnsignedTx.TxOut))
+	}
+
+	result.Fee, err = psbtFee(p)
+	if err != nil {
+		return result, err
+	}
+
+	// INFO: finalization is applied to the parsed copy, the original PSBT is not changed.
+	if err = psbt.MaybeFinalizeAll(p); err != nil {
+		return result, err
+	}
+
+	signedTx, err := psbt.Extract(p)
+	if err != nil {
+		return result, err
+	}
+
+	_, result.VSize = bitcoin.MsgTxWeight(signedTx)
+
+	// INFO: vB * ( sat / kvB ) = 1000 sat.
+	result.ExactFee = new(big.Int).Mul(big.NewInt(result.VSize), params.SatoshiPerKVByte)
+	result.ExactFee.Add(result.ExactFee, big.NewInt(999)).Div(result.ExactFee, big.NewInt(1000)) // sat, rounded up.
+	result.AchievedSatoshiPerKVByte = new(big.Int).Mul(result.Fee, big.NewInt(1000))
+	result.AchievedSatoshiPerKVByte.Div(result.AchievedSatoshiPerKVByte, big.NewInt(result.VSize))
+
+	maxSatoshiPerKVByte := new(big.Int).Set(params.SatoshiPerKVByte)
+	if params.ToleranceSatoshiPerKVByte != nil {
+		maxSatoshiPerKVByte.Add(maxSatoshiPerKVByte, params.ToleranceSatoshiPerKVByte)
+	}
+
+	if !numbers.IsGreater(result.AchievedSatoshiPerKVByte, maxSatoshiPerKVByte) {
+		return result, nil
+	}
+
+	// rebuild unsigned transaction, since finalized copy has no partial signatures.
+	p, err = psbt.NewFromRawBytes(bytes.NewReader(params.SignedPSBT), false)
+	if err != nil {
+		return result, err
+	}
+
+	change := p.UnsignedTx.TxOut[params.ChangeOutputIndex]
+	change.Value += new(big.Int).Sub(result.Fee, result.ExactFee).Int64()
+	clearSignatures(p)
+
+	w := bytes.NewBuffer(nil)
+	if err = p.Serialize(w); err != nil {
+		return result, err
+	}
+
+	result.SerializedPSBT = w.Bytes()
+
+	return result, nil
+}
+
+// psbtFee returns fee in satoshi paid by the PSBT transaction, all inputs must have previous output data.
+func psbtFee(p *psbt.Packet) (*big.Int, error) {
+	fee := big.NewInt(0)
+	for idx, input := range p.Inputs {
+		switch {
+		case input.WitnessUtxo != nil:
+			fee.Add(fee, big.NewInt(input.WitnessUtxo.Value))
+		case input.NonWitnessUtxo != nil:
+			outPoint := p.UnsignedTx.TxIn[idx].PreviousOutPoint
+			if int(outPoint.Index) >= len(input.NonWitnessUtxo.TxOut) {
+				return nil, fmt.Errorf("%w: input %d", ErrMissingInputAmount, idx)
+			}
+			fee.Add(fee, big.NewInt(input.NonWitnessUtxo.TxOut[outPoint.Index].Value))
+		default:
+			return nil, fmt.Errorf("%w: input %d", ErrMissingInputAmount, idx)
+		}
+	}
+	for _, output := range p.UnsignedTx.TxOut {
+		fee.Sub(fee, big.NewInt(output.Value))
+	}
+
+	return fee, nil
+}
+
+// clearSignatures removes signatures and final scripts of all PSBT inputs.
+func clearSignatures(p *psbt.Packet) {
+	for idx := range p.Inputs {
+		ClearInputSignatures(&p.Inputs[idx])
+	}
+}
+
+// ClearInputSignatures removes signatures and final scripts of the PSBT input, e.g. to sign it again
+// after the transaction is changed.
+func ClearInputSignatures(input *psbt.PInput) {
+	input.PartialSigs = nil
+	input.TaprootKeySpendSig = nil
+	input.TaprootScriptSpendSig = nil
+	input.FinalScriptSig = nil
+	input.FinalScriptWitness = nil
+}
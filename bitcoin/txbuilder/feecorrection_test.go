@@ -0,0 +1,98 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestCorrectFee(t *testing.T) {
+	wallet := txbuildertest.NewWallet(t, &chaincfg.TestNet3Params)
+
+	result, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params).BuildBTCTransferTx(txbuilder.BaseBTCTransferParams{
+		TransferSatoshiAmount: big.NewInt(29500),
+		Sender: &txbuilder.PaymentData{
+			UTXOs:   []bitcoin.UTXO{wallet.UTXO("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2, 850000)},
+			Address: wallet.Address,
+			PubKey:  wallet.PubKey,
+		},
+		SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+		RecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+	})
+	require.NoError(t, err)
+
+	sign := func(serializedPSBT []byte) []byte {
+		signed, err := signer.NewSigner(&chaincfg.TestNet3Params).SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: serializedPSBT,
+			Inputs:         []int{0},
+			PrivateKey:     wallet.PrivateKey,
+		})
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	signed := sign(result.SerializedPSBT)
+
+	t.Run("within tolerance", func(t *testing.T) {
+		correction, err := txbuilder.CorrectFee(txbuilder.CorrectFeeParams{
+			SignedPSBT:                signed,
+			ChangeOutputIndex:         1,
+			SatoshiPerKVByte:          big.NewInt(5000),
+			ToleranceSatoshiPerKVByte: big.NewInt(1_000_000),
+		})
+		require.NoError(t, err)
+		require.Nil(t, correction.SerializedPSBT)
+		require.Equal(t, result.EstimatedFee, correction.Fee)
+		require.Less(t, correction.VSize, int64(11+90+2*30)) // rough estimate is greater than exact size.
+		require.EqualValues(t, (correction.VSize*5000+999)/1000, correction.ExactFee.Int64())
+		require.EqualValues(t, correction.Fee.Int64()*1000/correction.VSize, correction.AchievedSatoshiPerKVByte.Int64())
+	})
+
+	t.Run("overpaid", func(t *testing.T) {
+		correction, err := txbuilder.CorrectFee(txbuilder.CorrectFeeParams{
+			SignedPSBT:        signed,
+			ChangeOutputIndex: 1,
+			SatoshiPerKVByte:  big.NewInt(5000),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, correction.SerializedPSBT)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(correction.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Nil(t, p.Inputs[0].TaprootKeySpendSig)
+
+		corrected, err := txbuilder.CorrectFee(txbuilder.CorrectFeeParams{
+			SignedPSBT:        sign(correction.SerializedPSBT),
+			ChangeOutputIndex: 1,
+			SatoshiPerKVByte:  big.NewInt(5000),
+		})
+		require.NoError(t, err)
+		require.Nil(t, corrected.SerializedPSBT)
+		require.Equal(t, correction.VSize, corrected.VSize)
+		require.Equal(t, correction.ExactFee, corrected.Fee)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := txbuilder.CorrectFee(txbuilder.CorrectFeeParams{SignedPSBT: signed, ChangeOutputIndex: 2, SatoshiPerKVByte: big.NewInt(5000)})
+		require.ErrorIs(t, err, txbuilder.ErrInvalidChangeOutput)
+
+		_, err = txbuilder.CorrectFee(txbuilder.CorrectFeeParams{SignedPSBT: signed, ChangeOutputIndex: 1})
+		require.ErrorIs(t, err, txbuilder.ErrFeeRateOutOfBounds)
+
+		_, err = txbuilder.CorrectFee(txbuilder.CorrectFeeParams{SignedPSBT: result.SerializedPSBT, SatoshiPerKVByte: big.NewInt(5000)})
+		require.Error(t, err) // not signed.
+	})
+}
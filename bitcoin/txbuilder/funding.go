@@ -0,0 +1,323 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+var (
+	// ErrInvalidFundingAmount describes that channel funding amount is not set or less than the dust amount.
+	ErrInvalidFundingAmount = errors.New("invalid funding amount")
+	// ErrUnknownFundingOutputType describes that funding output type is not supported.
+	ErrUnknownFundingOutputType = errors.New("unknown funding output type")
+)
+
+// FundingOutputType defines type of the lightning channel funding output.
+type FundingOutputType int
+
+const (
+	// FundingOutputP2WSH defines 2-of-2 multisig P2WSH funding output (BOLT-3).
+	FundingOutputP2WSH FundingOutputType = iota
+	// FundingOutputP2TRMuSig2 defines P2TR funding output with MuSig2 aggregated key of the funding keys
+	// tweaked without script tree (BIP-86), as used by simple taproot channels.
+	FundingOutputP2TRMuSig2
+)
+
+// FundingOutput describes lightning channel funding output.
+type FundingOutput struct {
+	Type          FundingOutputType
+	Address       string // funding output address.
+	PkScript      []byte // funding output script.
+	WitnessScript []byte // 2-of-2 multisig script, P2WSH only.
+	InternalKey   []byte // x-only MuSig2 aggregated key, P2TR only.
+}
+
+// BaseChannelFundingTxParams describes data needed to build lightning channel funding transaction.
+// NOTE: Funder utxos should be segwit ones, otherwise funding txid is malleable and commitment
+// transactions signed before broadcast may become invalid.
+type BaseChannelFundingTxParams struct {
+	Funder               *PaymentData      // funder payment data. mandatory.
+	FundingOutputType    FundingOutputType // funding output type.
+	LocalFundingPubKey   string            // local node funding public key, compressed hex.
+	RemoteFundingPubKey  string            // remote node funding public key, compressed hex.
+	FundingSatoshiAmount *big.Int          // channel capacity in satoshi.
+	SatoshiPerKVByte     *big.Int          // fee rate in satoshi per kilo virtual byte.
+}
+
+// BuildChannelFundingTxResult describes result of BuildChannelFundingTx method.
+type BuildChannelFundingTxResult struct {
+	SerializedPSBT     []byte          // serialised unsigned funding transaction in PSBT format.
+	FundingOutput      FundingOutput   // funding output data.
+	FundingOutputIndex uint32          // funding output index in the transaction.
+	UsedBaseUTXOs      []*bitcoin.UTXO // used funder's bitcoin utxos in transaction.
+	EstimatedFee       *big.Int        // estimated transaction fee in Satoshi.
+	// AddressReuseWarnings are outputs reusing addresses from AddressHistory, if configured.
+	AddressReuseWarnings []AddressReuseWarning
+}
+
+// FundingWitnessScript returns 2-of-2 multisig script of the funding keys sorted
+// lexicographically by their compressed serialization (BOLT-3).
+func FundingWitnessScript(localPubKey, remotePubKey *btcec.PublicKey) ([]byte, error) {
+	keys := utils.SortKeys([]*btcec.PublicKey{localPubKey, remotePubKey})
+
+	return txscript.NewScriptBuilder().
+		AddOp(txscript.OP_2).
+		AddData(keys[0].SerializeCompressed()).
+		AddData(keys[1].SerializeCompressed()).
+		AddOp(txscript.OP_2).
+		AddOp(txscript.OP_CHECKMULTISIG).
+		Script()
+}
+
+// NewFundingOutput returns lightning channel funding output of the compressed hex funding public keys.
+func NewFundingOutput(outputType FundingOutputType, localPubKey, remotePubKey string, networkParams *chaincfg.Params) (output FundingOutput, _ error) {
+	localKey, err := parseFundingPubKey(localPubKey)
+	if err != nil {
+		return output, err
+	}
+
+	remoteKey, err := parseFundingPubKey(remotePubKey)
+	if err != nil {
+		return output, err
+	}
+
+	var address btcutil.Address
+	switch outputType {
+	case FundingOutputP2WSH:
+		output.WitnessScript, err = FundingWitnessScript(localKey, remoteKey)
+		if err != nil {
+			return output, err
+		}
+
+		scriptHash := chainhash.HashB(output.WitnessScript)
+		address, err = btcutil.NewAddressWitnessScriptHash(scriptHash, networkParams)
+	case FundingOutputP2TRMuSig2:
+		var internalKey *btcec.PublicKey
+		internalKey, err = utils.AggregateKeys(utils.SortKeys([]*btcec.PublicKey{localKey, remoteKey})...)
+		if err != nil {
+			return output, err
+		}
+
+		output.InternalKey = schnorr.SerializePubKey(internalKey)
+		address, err = utils.P2TRAddressFromInternalKey(internalKey, nil, networkParams)
+	default:
+		return output, fmt.Errorf("%w: %d", ErrUnknownFundingOutputType, outputType)
+	}
+	if err != nil {
+		return output, err
+	}
+
+	output.PkScript, err = txscript.PayToAddrScript(address)
+	if err != nil {
+		return output, err
+	}
+
+	output.Type = outputType
+	output.Address = address.EncodeAddress()
+
+	return output, nil
+}
+
+// BuildChannelFundingTx constructs lightning channel funding transaction in PSBT format
+// with inputs indexes assigned in unknown fields. Returns serialized PSBT transaction
+// with funding output data, used base outputs, estimated fee in satoshi, and error if any.
+func (b *TxBuilder) BuildChannelFundingTx(params BaseChannelFundingTxParams) (BuildChannelFundingTxResult, error) {
+	return b.BuildChannelFundingTxContext(context.Background(), params)
+}
+
+// BuildChannelFundingTxContext is like BuildChannelFundingTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildChannelFundingTxContext(ctx context.Context, params BaseChannelFundingTxParams) (result BuildChannelFundingTxResult, _ error) {
+	builder := b.snapshot()
+
+	fundingOutput, err := NewFundingOutput(params.FundingOutputType, params.LocalFundingPubKey, params.RemoteFundingPubKey, builder.networkParams)
+	if err != nil {
+		return result, err
+	}
+
+	baseResult, err := builder.buildChannelFundingTx(ctx, params, fundingOutput)
+	if err != nil {
+		return result, err
+	}
+
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindChannelFunding,
+		UnsignedRawTx: baseResult.UnsignedRawTx,
+		UsedUTXOs:     baseResult.UsedSenderBaseUTXOs,
+		EstimatedFee:  baseResult.EstimatedFee,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for index, output := range baseResult.UnsignedRawTx.TxOut {
+		if bytes.Equal(output.PkScript, fundingOutput.PkScript) {
+			result.FundingOutputIndex = uint32(index)
+		}
+	}
+
+	serializedPSBT, err := builder.buildBTCTransferPSBT(BuildBTCTransferPSBTParams{
+		BaseBTCTransferResult: baseResult,
+		SenderAddress:         params.Funder.Address,
+		SenderPubKey:          params.Funder.PubKey,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.SerializedPSBT, err = setFundingOutputData(serializedPSBT, result.FundingOutputIndex, fundingOutput)
+	if err != nil {
+		return result, err
+	}
+
+	result.FundingOutput = fundingOutput
+	result.UsedBaseUTXOs = baseResult.UsedSenderBaseUTXOs
+	result.EstimatedFee = baseResult.EstimatedFee
+	result.AddressReuseWarnings, err = addressReuseWarnings(ctx, builder.config.AddressHistory,
+		baseResult.UnsignedRawTx, baseResult.OutputRoles, builder.networkParams)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// buildChannelFundingTx constructs base lightning channel funding transaction.
+//
+//	Tx struct
+//	inputs:
+//	┌─────────┬──────────────┬────────────────────────────────────────┐
+//	│  index  │     type     │             description                │
+//	├=========┼==============┼========================================┤
+//	│   0 - n │ base inputs  │ funder's segwit utxos with bitcoin     │
+//	│         │              │ only, possibly many.                   │
+//	└─────────┴──────────────┴────────────────────────────────────────┘
+//
+//	outputs:
+//	┌─────────┬──────────────┬────────────────────────────────────────┐
+//	│  index  │     type     │             description                │
+//	├=========┼==============┼========================================┤
+//	│       0 │ base output  │ mandatory, channel funding output.     │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│       1 │ base output  │ outputs to change funder's bitcoins    │
+//	│         │              │ amount. 99% mandatory, in case         │
+//	│         │              │ any non-dust btc left.                 │
+//	└─────────┴──────────────┴────────────────────────────────────────┘
+func (b *TxBuilder) buildChannelFundingTx(ctx context.Context, params BaseChannelFundingTxParams,
+	fundingOutput FundingOutput) (result BaseBTCTransferResult, _ error) {
+	if err := b.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return result, err
+	}
+	if params.Funder == nil {
+		return result, fmt.Errorf("%w: funder", ErrMissingSender)
+	}
+	if len(params.Funder.UTXOs) == 0 {
+		return result, fmt.Errorf("%w: funder", ErrNoUTXOs)
+	}
+	if params.FundingSatoshiAmount == nil || numbers.IsLess(params.FundingSatoshiAmount, b.config.DustAmount) {
+		return result, fmt.Errorf("%w: must not be less than %s", ErrInvalidFundingAmount, b.config.DustAmount.String())
+	}
+
+	prepareUTXOsResult, err := b.prepareUTXOs(ctx, PrepareUTXOsParams{
+		Utxos:            params.Funder.UTXOs,
+		Outputs:          2, // funding + funder btc change.
+		TransferAmount:   params.FundingSatoshiAmount,
+		SatoshiPerKVByte: params.SatoshiPerKVByte,
+	})
+	if err != nil {
+		if errIns := new(InsufficientError); errors.As(err, &errIns) {
+			return result, errIns.setCauser(CauserSender)
+		}
+
+		return result, err
+	}
+
+	tx := wire.NewMsgTx(txVersion)
+	for _, i := range prepareUTXOsResult.UsedUTXOs {
+		tx.AddTxIn(wire.NewTxIn(i.WireOutPoint(), nil, nil))
+	}
+
+	bitcoinAmount := new(big.Int).Sub(prepareUTXOsResult.TotalAmount, prepareUTXOsResult.RoughEstimate)
+	roles := make(outputRoles)
+
+	// channel funding output (#0).
+	if err = b.addOutput(tx, params.FundingSatoshiAmount, bitcoinAmount, fundingOutput.Address); err != nil {
+		return result, err
+	}
+	roles.add(tx, OutputRoleRecipient)
+
+	// funder's change btc output (#1).
+	if numbers.IsGreater(bitcoinAmount, b.config.DustAmount) {
+		if err = b.addOutput(tx, new(big.Int).Set(bitcoinAmount), bitcoinAmount, params.Funder.Address); err != nil {
+			return result, err
+		}
+		roles.add(tx, OutputRoleChange)
+	}
+
+	b.config.orderInputs(tx)
+	b.config.OutputOrdering.orderOutputs(tx)
+
+	result.UnsignedRawTx = tx
+	result.UsedSenderBaseUTXOs = prepareUTXOsResult.UsedUTXOs
+	result.EstimatedFee = prepareUTXOsResult.RoughEstimate
+	result.OutputRoles = roles.list(tx)
+
+	return result, nil
+}
+
+// setFundingOutputData sets funding witness script or taproot internal key to the PSBT funding output.
+func setFundingOutputData(serializedPSBT []byte, index uint32, fundingOutput FundingOutput) ([]byte, error) {
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Outputs[index].WitnessScript = fundingOutput.WitnessScript
+	p.Outputs[index].TaprootInternalKey = fundingOutput.InternalKey
+
+	w := bytes.NewBuffer(nil)
+	if err = p.Serialize(w); err != nil {
+		return nil, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// parseFundingPubKey parses compressed hex funding public key.
+func parseFundingPubKey(pubKey string) (*btcec.PublicKey, error) {
+	pubKeyBytes, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPubKey, err)
+	}
+
+	if len(pubKeyBytes) != btcec.PubKeyBytesLenCompressed {
+		return nil, fmt.Errorf("%w: compressed funding public key is required", ErrInvalidPubKey)
+	}
+
+	key, err := btcec.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPubKey, err)
+	}
+
+	return key, nil
+}
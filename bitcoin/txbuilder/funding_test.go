@@ -0,0 +1,109 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestChannelFunding(t *testing.T) {
+	// INFO: BOLT-3 funding keys.
+	const (
+		localPubKey  = "023da092f6980e58d2c037173180e9a465476026ee50f96695963e8efe436f54eb"
+		remotePubKey = "030e9f7b623d2ccc7c9bd44d66d5ce21ce504c0acf6385a132cec6d3c39fa711c1"
+	)
+
+	t.Run("P2WSH funding output", func(t *testing.T) {
+		output, err := txbuilder.NewFundingOutput(txbuilder.FundingOutputP2WSH, remotePubKey, localPubKey, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.Equal(t, "5221023da092f6980e58d2c037173180e9a465476026ee50f96695963e8efe436f54eb21030e9f7b623d2ccc7c9bd44d66d5ce21ce504c0acf6385a132cec6d3c39fa711c152ae",
+			hex.EncodeToString(output.WitnessScript))
+		require.Equal(t, txscript.WitnessV0ScriptHashTy, txscript.GetScriptClass(output.PkScript))
+		require.Nil(t, output.InternalKey)
+	})
+
+	t.Run("P2TR MuSig2 funding output", func(t *testing.T) {
+		output, err := txbuilder.NewFundingOutput(txbuilder.FundingOutputP2TRMuSig2, localPubKey, remotePubKey, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.Equal(t, txscript.WitnessV1TaprootTy, txscript.GetScriptClass(output.PkScript))
+		require.Len(t, output.InternalKey, 32)
+		require.Nil(t, output.WitnessScript)
+
+		swapped, err := txbuilder.NewFundingOutput(txbuilder.FundingOutputP2TRMuSig2, remotePubKey, localPubKey, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.Equal(t, output, swapped)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, err := txbuilder.NewFundingOutput(txbuilder.FundingOutputP2WSH, localPubKey[2:], remotePubKey, &chaincfg.MainNetParams)
+		require.ErrorIs(t, err, txbuilder.ErrInvalidPubKey)
+
+		_, err = txbuilder.NewFundingOutput(txbuilder.FundingOutputType(5), localPubKey, remotePubKey, &chaincfg.MainNetParams)
+		require.ErrorIs(t, err, txbuilder.ErrUnknownFundingOutputType)
+	})
+
+	t.Run("BuildChannelFundingTx", func(t *testing.T) {
+		builder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params, txbuilder.WithOutputOrdering(txbuilder.OutputOrderingBIP69))
+		params := txbuilder.BaseChannelFundingTxParams{
+			Funder: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+						Amount:   big.NewInt(850000),
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+					},
+				},
+				Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+				PubKey:  "29fa611c361355b082ee593feb368009aa9c6bd1ed36c9983edcd113fb8da33f",
+			},
+			FundingOutputType:    txbuilder.FundingOutputP2WSH,
+			LocalFundingPubKey:   localPubKey,
+			RemoteFundingPubKey:  remotePubKey,
+			FundingSatoshiAmount: big.NewInt(500000),
+			SatoshiPerKVByte:     big.NewInt(5000),
+		}
+
+		result, err := builder.BuildChannelFundingTx(params)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, result.FundingOutputIndex)
+		require.EqualValues(t, 805, result.EstimatedFee.Int64())
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.EqualValues(t, 500000, p.UnsignedTx.TxOut[1].Value)
+		require.Equal(t, result.FundingOutput.PkScript, p.UnsignedTx.TxOut[1].PkScript)
+		require.Equal(t, result.FundingOutput.WitnessScript, p.Outputs[1].WitnessScript)
+		require.EqualValues(t, 850000-500000-805, p.UnsignedTx.TxOut[0].Value)
+
+		params.FundingOutputType = txbuilder.FundingOutputP2TRMuSig2
+		result, err = builder.BuildChannelFundingTx(params)
+		require.NoError(t, err)
+
+		p, err = psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Equal(t, result.FundingOutput.InternalKey, p.Outputs[result.FundingOutputIndex].TaprootInternalKey)
+
+		params.FundingSatoshiAmount = big.NewInt(100)
+		_, err = builder.BuildChannelFundingTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrInvalidFundingAmount)
+
+		params.FundingSatoshiAmount = big.NewInt(900000)
+		_, err = builder.BuildChannelFundingTx(params)
+		var errIns *txbuilder.InsufficientError
+		require.ErrorAs(t, err, &errIns)
+		require.Equal(t, txbuilder.InsufficientErrorTypeBitcoin, errIns.Type)
+	})
+}
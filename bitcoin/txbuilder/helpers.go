@@ -5,12 +5,13 @@ package txbuilder
 
 import (
 	"bytes"
-	"errors"
+	"fmt"
 
 	"github.com/btcsuite/btcd/btcutil/psbt"
 )
 
 // ExtractAddressTypeInputIndexesFromPSBT returns map with address types and indexes to sign.
+// Both legacy single byte and versioned proprietary inputs helping keys are read.
 func ExtractAddressTypeInputIndexesFromPSBT(data []byte) (map[InputsHelpingKey][]int, error) {
 	var result = make(map[InputsHelpingKey][]int, 2)
 	p, err := psbt.NewFromRawBytes(bytes.NewBuffer(data), false)
@@ -19,22 +20,13 @@ func ExtractAddressTypeInputIndexesFromPSBT(data []byte) (map[InputsHelpingKey][
 	}
 
 	for _, unknown := range p.Unknowns {
-		if len(unknown.Key) != 1 {
+		if !IsInputsHelpingKey(unknown.Key) {
 			continue
 		}
 
-		var key InputsHelpingKey
-		switch unknown.Key[0] {
-		case TaprootInputsHelpingKey.Byte():
-			key = TaprootInputsHelpingKey
-		case PaymentInputsHelpingKey.Byte():
-			key = PaymentInputsHelpingKey
-		case FeePayerTaprootInputsHelpingKey.Byte():
-			key = FeePayerTaprootInputsHelpingKey
-		case FeePayerPaymentInputsHelpingKey.Byte():
-			key = FeePayerPaymentInputsHelpingKey
-		default:
-			return nil, errors.New("unknown input key")
+		key, _, err := ParseInputsHelpingKey(unknown.Key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %x", err, unknown.Key)
 		}
 
 		result[key] = make([]int, len(unknown.Value))
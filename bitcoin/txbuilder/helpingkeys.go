@@ -4,11 +4,18 @@
 package txbuilder
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 )
 
-// ErrUnknownInputsHelpingKey defines that inputs help keys is unknown.
-var ErrUnknownInputsHelpingKey = errors.New("unknown inputs help keys")
+var (
+	// ErrUnknownInputsHelpingKey defines that inputs help keys is unknown.
+	ErrUnknownInputsHelpingKey = errors.New("unknown inputs help keys")
+	// ErrUnsupportedInputsHelpingKeyVersion defines that versioned inputs helping key has unsupported version,
+	// so its value semantics is unknown.
+	ErrUnsupportedInputsHelpingKeyVersion = errors.New("unsupported inputs helping key version")
+)
 
 // InputsHelpingKey defines type for additional data in PSBT Unknowns field
 // to distinguish input types and their indexes.
@@ -25,24 +32,62 @@ const (
 	FeePayerPaymentInputsHelpingKey InputsHelpingKey = 0x21
 )
 
+const (
+	// InputsHelpingKeyLegacyVersion defines version of the single byte inputs helping keys without version byte.
+	InputsHelpingKeyLegacyVersion byte = 0x00
+	// InputsHelpingKeyVersion defines current version of the proprietary inputs helping keys,
+	// value is a list of the single byte input indexes.
+	InputsHelpingKeyVersion byte = 0x01
+	// InputsHelpingKeySubtype defines PSBT proprietary key subtype of the versioned inputs helping keys.
+	InputsHelpingKeySubtype byte = 0x02
+)
+
 // InputsHelpingKeyFromBytes parses bytes array into InputsHelpingKey if any.
+// Both legacy single byte and versioned proprietary keys are accepted, see ParseInputsHelpingKey.
 func InputsHelpingKeyFromBytes(b []byte) (InputsHelpingKey, error) {
+	key, _, err := ParseInputsHelpingKey(b)
+	return key, err
+}
+
+// ParseInputsHelpingKey parses legacy single byte or versioned proprietary PSBT key into InputsHelpingKey,
+// returns the key with its version, InputsHelpingKeyLegacyVersion for the single byte keys.
+// Returns ErrUnsupportedInputsHelpingKeyVersion if the key is written by the newer format version.
+func ParseInputsHelpingKey(b []byte) (InputsHelpingKey, byte, error) {
+	version := InputsHelpingKeyLegacyVersion
+	if prefix := inputsHelpingKeyPrefix(); bytes.HasPrefix(b, prefix) {
+		b = b[len(prefix):]
+		if len(b) == 0 {
+			return 0, 0, ErrUnknownInputsHelpingKey
+		}
+		if b[0] != InputsHelpingKeyVersion {
+			return 0, 0, fmt.Errorf("%w: %d", ErrUnsupportedInputsHelpingKeyVersion, b[0])
+		}
+
+		version, b = b[0], b[1:]
+	}
+
 	if len(b) != 1 {
-		return 0, ErrUnknownInputsHelpingKey
+		return 0, 0, ErrUnknownInputsHelpingKey
 	}
 
 	switch b[0] {
 	case TaprootInputsHelpingKey.Byte():
-		return TaprootInputsHelpingKey, nil
+		return TaprootInputsHelpingKey, version, nil
 	case PaymentInputsHelpingKey.Byte():
-		return PaymentInputsHelpingKey, nil
+		return PaymentInputsHelpingKey, version, nil
 	case FeePayerTaprootInputsHelpingKey.Byte():
-		return FeePayerTaprootInputsHelpingKey, nil
+		return FeePayerTaprootInputsHelpingKey, version, nil
 	case FeePayerPaymentInputsHelpingKey.Byte():
-		return FeePayerPaymentInputsHelpingKey, nil
+		return FeePayerPaymentInputsHelpingKey, version, nil
 	}
 
-	return 0, ErrUnknownInputsHelpingKey
+	return 0, 0, ErrUnknownInputsHelpingKey
+}
+
+// IsInputsHelpingKey returns true if PSBT unknown field key is legacy single byte or versioned
+// proprietary inputs helping key, the key itself might be unknown or have unsupported version.
+func IsInputsHelpingKey(b []byte) bool {
+	return len(b) == 1 || bytes.HasPrefix(b, inputsHelpingKeyPrefix())
 }
 
 // Byte returns InputsHelpingKey as byte.
@@ -54,3 +99,17 @@ func (k InputsHelpingKey) Byte() byte {
 func (k InputsHelpingKey) Bytes() []byte {
 	return []byte{byte(k)}
 }
+
+// ProprietaryKey returns InputsHelpingKey as versioned PSBT proprietary key (BIP-174):
+// 0xFC | identifier length | "txbuilder" | 0x02 | version | key.
+func (k InputsHelpingKey) ProprietaryKey() []byte {
+	return append(inputsHelpingKeyPrefix(), InputsHelpingKeyVersion, byte(k))
+}
+
+// inputsHelpingKeyPrefix returns proprietary key prefix of the versioned inputs helping keys.
+func inputsHelpingKeyPrefix() []byte {
+	key := OutputRoleKey()
+	key[len(key)-1] = InputsHelpingKeySubtype
+
+	return key
+}
@@ -33,6 +33,38 @@ func TestInputsHelpingKey(t *testing.T) {
 		}
 	})
 
+	t.Run("ParseInputsHelpingKey", func(t *testing.T) {
+		versioned := func(data ...byte) []byte {
+			key := append([]byte{0xFC, 9}, "txbuilder"...)
+			return append(append(key, txbuilder.InputsHelpingKeySubtype), data...)
+		}
+
+		tests := []struct {
+			bytes   []byte
+			key     txbuilder.InputsHelpingKey
+			version byte
+			err     error
+		}{
+			{[]byte{txbuilder.TaprootInputsHelpingKey.Byte()}, txbuilder.TaprootInputsHelpingKey, txbuilder.InputsHelpingKeyLegacyVersion, nil},
+			{versioned(0x01, 0x21), txbuilder.FeePayerPaymentInputsHelpingKey, txbuilder.InputsHelpingKeyVersion, nil},
+			{txbuilder.PaymentInputsHelpingKey.ProprietaryKey(), txbuilder.PaymentInputsHelpingKey, txbuilder.InputsHelpingKeyVersion, nil},
+			{versioned(0x02, 0x21), 0, 0, txbuilder.ErrUnsupportedInputsHelpingKeyVersion},
+			{versioned(0x01, 0x50), 0, 0, txbuilder.ErrUnknownInputsHelpingKey},
+			{versioned(0x01), 0, 0, txbuilder.ErrUnknownInputsHelpingKey},
+			{versioned(), 0, 0, txbuilder.ErrUnknownInputsHelpingKey},
+			{txbuilder.OutputRoleKey(), 0, 0, txbuilder.ErrUnknownInputsHelpingKey},
+		}
+		for _, test := range tests {
+			key, version, err := txbuilder.ParseInputsHelpingKey(test.bytes)
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, test.key, key)
+			require.Equal(t, test.version, version)
+		}
+
+		require.True(t, txbuilder.IsInputsHelpingKey(versioned(0x02, 0x21)))
+		require.False(t, txbuilder.IsInputsHelpingKey(txbuilder.OutputRoleKey()))
+	})
+
 	t.Run("Byte&Bytes", func(t *testing.T) {
 		tests := []struct {
 			key   txbuilder.InputsHelpingKey
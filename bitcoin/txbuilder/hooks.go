@@ -0,0 +1,130 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+// ErrRejectedByHook describes that transaction building is aborted by the build hook error.
+var ErrRejectedByHook = errors.New("rejected by build hook")
+
+// BuildKind defines type of the built transaction reported to BuildHooks.
+type BuildKind string
+
+const (
+	// BuildKindRunesTransfer defines runes (and btc) transfer transaction.
+	BuildKindRunesTransfer BuildKind = "runes_transfer"
+	// BuildKindBTCTransfer defines btc transfer transaction.
+	BuildKindBTCTransfer BuildKind = "btc_transfer"
+	// BuildKindInscription defines inscription commitment transaction.
+	BuildKindInscription BuildKind = "inscription"
+	// BuildKindRuneEtch defines inscription reveal - etch transaction.
+	BuildKindRuneEtch BuildKind = "rune_etch"
+	// BuildKindRuneMint defines rune mint transaction.
+	BuildKindRuneMint BuildKind = "rune_mint"
+	// BuildKindChannelFunding defines lightning channel funding transaction.
+	BuildKindChannelFunding BuildKind = "channel_funding"
+	// BuildKindRuneOfferAccept defines transaction accepting rune sell offers.
+	BuildKindRuneOfferAccept BuildKind = "rune_offer_accept"
+	// BuildKindAnchorSpend defines CPFP child transaction spending pay to anchor output.
+	BuildKindAnchorSpend BuildKind = "anchor_spend"
+)
+
+// SelectInfo describes utxos selection reported to BuildHooks.BeforeSelect.
+type SelectInfo struct {
+	UTXOs []bitcoin.UTXO // candidate utxos.
+	// TransferAmount is an amount the selected utxos must cover: satoshi without fee or runes amount if RuneID is set.
+	TransferAmount   *big.Int
+	SatoshiPerKVByte *big.Int      // fee rate in satoshi per kilo virtual byte, nil for runes selection.
+	RuneID           *runes.RuneID // selected rune, nil for bitcoin selection.
+}
+
+// BuildInfo describes built transaction reported to BuildHooks.AfterBuild.
+type BuildInfo struct {
+	Kind          BuildKind
+	UnsignedRawTx *wire.MsgTx     // unsigned built transaction, must not be mutated.
+	UsedUTXOs     []*bitcoin.UTXO // spent utxos: selected ones and the provided inscription or anchor utxo.
+	EstimatedFee  *big.Int        // estimated transaction fee in Satoshi.
+}
+
+// BuildHooks describes callbacks around transactions building, e.g. to log, collect metrics (fee totals,
+// utxos counts) or apply policy checks without wrapping each Build* method. Returned error aborts the build,
+// the Build* method returns it wrapped with ErrRejectedByHook.
+// NOTE: Hooks are shared between concurrent builds. BeforeSelect is called by Estimate* methods as well,
+// since they select utxos the same way. Builders which do not select utxos: BuildChainBumpTx,
+// BuildCommitSweepTxs and BuildRuneSellOfferPSBT, do not call hooks.
+type BuildHooks interface {
+	// BeforeSelect is called before each utxos selection, e.g. rune utxos and fee payer utxos separately.
+	BeforeSelect(ctx context.Context, info SelectInfo) error
+	// AfterBuild is called with each built transaction before its serialization.
+	AfterBuild(ctx context.Context, info BuildInfo) error
+}
+
+// HookFuncs is a BuildHooks implementation calling the set functions, nil functions are skipped.
+type HookFuncs struct {
+	BeforeSelectFunc func(ctx context.Context, info SelectInfo) error
+	AfterBuildFunc   func(ctx context.Context, info BuildInfo) error
+}
+
+// ensures that HookFuncs implements BuildHooks.
+var _ BuildHooks = HookFuncs{}
+
+// BeforeSelect calls BeforeSelectFunc if set.
+func (hooks HookFuncs) BeforeSelect(ctx context.Context, info SelectInfo) error {
+	if hooks.BeforeSelectFunc == nil {
+		return nil
+	}
+
+	return hooks.BeforeSelectFunc(ctx, info)
+}
+
+// AfterBuild calls AfterBuildFunc if set.
+func (hooks HookFuncs) AfterBuild(ctx context.Context, info BuildInfo) error {
+	if hooks.AfterBuildFunc == nil {
+		return nil
+	}
+
+	return hooks.AfterBuildFunc(ctx, info)
+}
+
+// beforeSelect calls configured hooks in order before utxos selection.
+func (b *TxBuilder) beforeSelect(ctx context.Context, info SelectInfo) error {
+	for _, hooks := range b.config.Hooks {
+		if err := hooks.BeforeSelect(ctx, info); err != nil {
+			return fmt.Errorf("%w: before select: %w", ErrRejectedByHook, err)
+		}
+	}
+
+	return nil
+}
+
+// afterBuild calls configured hooks in order with the built transaction.
+func (b *TxBuilder) afterBuild(ctx context.Context, info BuildInfo) error {
+	for _, hooks := range b.config.Hooks {
+		if err := hooks.AfterBuild(ctx, info); err != nil {
+			return fmt.Errorf("%w: after %s build: %w", ErrRejectedByHook, info.Kind, err)
+		}
+	}
+
+	return nil
+}
+
+// usedUTXOs returns concatenation of the used utxos lists.
+func usedUTXOs(lists ...[]*bitcoin.UTXO) []*bitcoin.UTXO {
+	var used []*bitcoin.UTXO
+	for _, list := range lists {
+		used = append(used, list...)
+	}
+
+	return used
+}
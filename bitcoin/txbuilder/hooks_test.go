@@ -0,0 +1,123 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestBuildHooks(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	params := txbuilder.BaseBTCTransferParams{
+		Sender:                wallet.PaymentData("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 100000, 50000),
+		TransferSatoshiAmount: big.NewInt(10000),
+		SatoshiPerKVByte:      big.NewInt(10000), // 10 sat/vB.
+		RecipientAddress:      wallet.Address,
+	}
+
+	t.Run("called in order", func(t *testing.T) {
+		var calls []string
+		recorder := func(name string) txbuilder.HookFuncs {
+			return txbuilder.HookFuncs{
+				BeforeSelectFunc: func(_ context.Context, info txbuilder.SelectInfo) error {
+					require.Len(t, info.UTXOs, 2)
+					require.Nil(t, info.RuneID)
+					require.EqualValues(t, 10000, info.TransferAmount.Int64())
+					require.EqualValues(t, 10000, info.SatoshiPerKVByte.Int64())
+					calls = append(calls, name+" before select")
+
+					return nil
+				},
+				AfterBuildFunc: func(_ context.Context, info txbuilder.BuildInfo) error {
+					require.Equal(t, txbuilder.BuildKindBTCTransfer, info.Kind)
+					require.Len(t, info.UsedUTXOs, len(info.UnsignedRawTx.TxIn))
+					calls = append(calls, name+" after build")
+
+					return nil
+				},
+			}
+		}
+
+		builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithHooks(recorder("first")),
+			txbuilder.WithHooks(recorder("second")))
+
+		result, err := builder.BuildBTCTransferTx(params)
+		require.NoError(t, err)
+		require.Equal(t, []string{"first before select", "second before select", "first after build",
+			"second after build"}, calls)
+
+		// INFO: estimation selects utxos the same way, but does not build the transaction.
+		calls = nil
+		estimate, err := builder.EstimateBTCTransfer(params)
+		require.NoError(t, err)
+		require.Equal(t, []string{"first before select", "second before select"}, calls)
+		require.Equal(t, result.EstimatedFee, estimate.EstimatedFee)
+
+		calls = nil
+		_, err = builder.BuildBTCTransferRawTx(params)
+		require.NoError(t, err)
+		require.Len(t, calls, 4)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		var (
+			fees  = big.NewInt(0)
+			utxos int
+		)
+		builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithHooks(txbuilder.HookFuncs{
+			AfterBuildFunc: func(_ context.Context, info txbuilder.BuildInfo) error {
+				fees.Add(fees, info.EstimatedFee)
+				utxos += len(info.UsedUTXOs)
+
+				return nil
+			},
+		}))
+
+		result, err := builder.BuildBTCTransferTx(params)
+		require.NoError(t, err)
+		require.Equal(t, result.EstimatedFee, fees)
+		require.Equal(t, len(result.UsedSenderBaseUTXOs), utxos)
+	})
+
+	t.Run("policy rejection", func(t *testing.T) {
+		policyErr := errors.New("fee is too high")
+		builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithHooks(txbuilder.HookFuncs{
+			AfterBuildFunc: func(_ context.Context, info txbuilder.BuildInfo) error {
+				if info.EstimatedFee.Int64() > 1000 {
+					return policyErr
+				}
+
+				return nil
+			},
+		}))
+
+		_, err := builder.BuildBTCTransferTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrRejectedByHook)
+		require.ErrorIs(t, err, policyErr)
+
+		selectErr := errors.New("too many utxos")
+		builder = txbuilder.NewTxBuilder(networkParams, txbuilder.WithHooks(txbuilder.HookFuncs{
+			BeforeSelectFunc: func(context.Context, txbuilder.SelectInfo) error { return selectErr },
+		}))
+
+		_, err = builder.BuildBTCTransferTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrRejectedByHook)
+		require.ErrorIs(t, err, selectErr)
+
+		_, err = builder.EstimateBTCTransfer(params)
+		require.ErrorIs(t, err, selectErr)
+	})
+}
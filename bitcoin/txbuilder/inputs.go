@@ -0,0 +1,45 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+)
+
+// ErrInputNotFound describes that transaction has no input spending the utxo.
+var ErrInputNotFound = errors.New("input not found")
+
+// inputIndexer matches utxos with the transaction inputs spending them.
+type inputIndexer struct {
+	tx      *wire.MsgTx
+	matched []bool
+}
+
+// newInputIndexer is a constructor for inputIndexer.
+func newInputIndexer(tx *wire.MsgTx) *inputIndexer {
+	return &inputIndexer{
+		tx:      tx,
+		matched: make([]bool, len(tx.TxIn)),
+	}
+}
+
+// index returns index of the first not yet matched input spending the utxo.
+func (indexer *inputIndexer) index(utxo *bitcoin.UTXO) (int, error) {
+	for i, in := range indexer.tx.TxIn {
+		if indexer.matched[i] || in.PreviousOutPoint.Index != utxo.Index || in.PreviousOutPoint.Hash != utxo.Hash {
+			continue
+		}
+
+		indexer.matched[i] = true
+
+		return i, nil
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrInputNotFound, utxo.Outpoint.String())
+}
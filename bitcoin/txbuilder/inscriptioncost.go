@@ -0,0 +1,129 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// InscriptionCostParams describes data needed to calculate inscription cost before the commitment
+// transaction is built, it matches BaseInscriptionTxParams without payment data.
+type InscriptionCostParams struct {
+	Inscription             *inscriptions.Inscription // inscription data to commit.
+	SatoshiPerKVByte        *big.Int                  // fee rate in satoshi per kilo virtual byte.
+	SatoshiCommissionAmount *big.Int                  // additional commission in satoshi, optional.
+	PremineSplittingFactor  uint                      // for more details see [BaseRuneEtchTxParams.PremineSplittingFactor].
+	Postage                 *big.Int                  // for more details see [BaseRuneEtchTxParams.Postage].
+	Recovery                *inscriptions.RecoveryLeaf
+	// ContentRules defines allowed inscription content types with size limits, optional.
+	ContentRules []inscriptions.ContentRule
+	// CommitInputs is an expected number of the commitment transaction inputs, optional, 1 if not set.
+	CommitInputs int
+	// EtchingRune is a rune name to be etched, for more details see [BaseInscriptionTxParams.EtchingRune].
+	EtchingRune *runes.Rune
+}
+
+// InscriptionCost describes per-component cost of the inscription in satoshi and sizes in vBytes.
+type InscriptionCost struct {
+	WitnessVBytes *big.Int // inscription envelope witness size.
+	RevealVBytes  *big.Int // reveal transaction size estimate, including the witness.
+	RevealFee     *big.Int // reveal transaction fee, paid from the commitment output.
+	Postage       *big.Int // total value of the reveal recipient outputs.
+	CommitAmount  *big.Int // commitment output value, reveal fee and postage.
+	CommitVBytes  *big.Int // commitment transaction size estimate with CommitInputs inputs.
+	CommitFee     *big.Int // commitment transaction fee.
+	Commission    *big.Int // service commission, zero if not charged.
+	Total         *big.Int // total amount spent by the sender: commitment amount, its fee and commission.
+}
+
+// InscriptionCost returns inscription cost calculated by the same estimation as BuildInscriptionTx
+// and BuildRuneEtchTx do, so it could be shown to the user before the content is uploaded.
+// NOTE: Commitment fee depends on the number of the selected sender utxos, see CommitInputs.
+func (b *TxBuilder) InscriptionCost(params InscriptionCostParams) (cost InscriptionCost, err error) {
+	builder := b.snapshot()
+
+	if params.SatoshiPerKVByte == nil {
+		return cost, fmt.Errorf("%w: fee rate is required", ErrFeeRateOutOfBounds)
+	}
+	if err = builder.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return cost, err
+	}
+	if params.CommitInputs <= 0 {
+		params.CommitInputs = 1
+	}
+	if params.EtchingRune != nil {
+		if params.Inscription, err = commitEtchingRune(params.Inscription, params.EtchingRune); err != nil {
+			return cost, err
+		}
+	}
+
+	cost, err = builder.inscriptionRevealCost(BaseInscriptionTxParams{
+		SatoshiPerKVByte:       params.SatoshiPerKVByte,
+		Inscription:            params.Inscription,
+		PremineSplittingFactor: params.PremineSplittingFactor,
+		Postage:                params.Postage,
+		ContentRules:           params.ContentRules,
+		Recovery:               params.Recovery,
+	})
+	if err != nil {
+		return cost, err
+	}
+
+	outputs := 2 // inscription commitment + sender btc change.
+	cost.Commission = big.NewInt(0)
+	if params.SatoshiCommissionAmount != nil && numbers.IsPositive(params.SatoshiCommissionAmount) {
+		outputs++ // internal commission.
+		cost.Commission.Set(params.SatoshiCommissionAmount)
+	}
+
+	// INFO: vB * ( sat / kvB ) = 1000 sat.
+	cost.CommitVBytes = builder.config.SizeEstimator.TxSize(params.CommitInputs, outputs)
+	cost.CommitFee = new(big.Int).Mul(cost.CommitVBytes, params.SatoshiPerKVByte)
+	cost.CommitFee.Div(cost.CommitFee, big.NewInt(1000)) // sat.
+
+	cost.Total = new(big.Int).Add(cost.CommitAmount, cost.CommitFee)
+	cost.Total.Add(cost.Total, cost.Commission)
+
+	return cost, nil
+}
+
+// inscriptionRevealCost validates inscription and returns its reveal cost and commitment amount.
+func (b *TxBuilder) inscriptionRevealCost(params BaseInscriptionTxParams) (cost InscriptionCost, err error) {
+	if params.PremineSplittingFactor == 0 {
+		params.PremineSplittingFactor = 1 // INFO: set to default.
+	}
+	if params.ContentRules != nil {
+		if err = params.Inscription.ValidateContent(params.ContentRules); err != nil {
+			return cost, err
+		}
+	}
+	if err = checkRevealTxWeight(revealTxSkeleton(int(params.PremineSplittingFactor)), params.Inscription,
+		params.Recovery); err != nil {
+		return cost, err
+	}
+
+	postage, err := b.postage(params.Postage)
+	if err != nil {
+		return cost, err
+	}
+
+	witnessSize, err := inscriptionWitnessVBytes(params.Inscription, params.Recovery)
+	if err != nil {
+		return cost, err
+	}
+
+	factor := int(params.PremineSplittingFactor)
+	cost.WitnessVBytes = big.NewInt(int64(witnessSize))
+	cost.RevealVBytes = etchVBytesEstimate(b.config.SizeEstimator, cost.WitnessVBytes, factor)
+	cost.RevealFee = etchFeeEstimate(b.config.SizeEstimator, cost.WitnessVBytes, params.SatoshiPerKVByte, factor)
+	cost.Postage = new(big.Int).Mul(postage, big.NewInt(int64(factor))) // INFO: runes recipient outputs.
+	cost.CommitAmount = new(big.Int).Add(cost.RevealFee, cost.Postage)
+
+	return cost, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestInscriptionCost(t *testing.T) {
+	txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params)
+	inscription := &inscriptions.Inscription{ContentType: "text/plain;charset=utf-8", Body: bytes.Repeat([]byte("a"), 1000)}
+
+	params := txbuilder.BaseInscriptionTxParams{
+		Sender: &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{{
+				Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+				Amount:   big.NewInt(100000),
+				Script:   []byte("_bitcoin_transaction_script_"),
+				Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			}},
+			Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+		},
+		SatoshiPerKVByte:          big.NewInt(5000), // 5 sat/vB.
+		SatoshiCommissionAmount:   big.NewInt(1000),
+		CommissionReceiverAddress: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+		Inscription:               inscription,
+		InscriptionBasePubKey:     "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+		PremineSplittingFactor:    2,
+	}
+
+	t.Run("matches commitment", func(t *testing.T) {
+		cost, err := txBuilder.InscriptionCost(txbuilder.InscriptionCostParams{
+			Inscription:             inscription,
+			SatoshiPerKVByte:        params.SatoshiPerKVByte,
+			SatoshiCommissionAmount: params.SatoshiCommissionAmount,
+			PremineSplittingFactor:  params.PremineSplittingFactor,
+		})
+		require.NoError(t, err)
+
+		result, err := txBuilder.BuildInscriptionTx(params)
+		require.NoError(t, err)
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+
+		require.EqualValues(t, p.UnsignedTx.TxOut[0].Value, cost.CommitAmount.Int64())
+		require.Equal(t, result.EstimatedFee, cost.CommitFee)
+		require.Equal(t, new(big.Int).Sub(big.NewInt(100000), big.NewInt(p.UnsignedTx.TxOut[2].Value)), cost.Total)
+
+		witnessSize, err := inscription.VBytesSize()
+		require.NoError(t, err)
+		require.EqualValues(t, witnessSize, cost.WitnessVBytes.Int64())
+		require.EqualValues(t, 2*546, cost.Postage.Int64())
+		require.EqualValues(t, 1000, cost.Commission.Int64())
+		require.Equal(t, new(big.Int).Div(new(big.Int).Mul(cost.RevealVBytes, big.NewInt(5000)), big.NewInt(1000)), cost.RevealFee)
+		require.Equal(t, new(big.Int).Add(cost.RevealFee, cost.Postage), cost.CommitAmount)
+	})
+
+	t.Run("commit inputs", func(t *testing.T) {
+		one, err := txBuilder.InscriptionCost(txbuilder.InscriptionCostParams{Inscription: inscription, SatoshiPerKVByte: big.NewInt(5000)})
+		require.NoError(t, err)
+		require.Zero(t, one.Commission.Sign())
+
+		three, err := txBuilder.InscriptionCost(txbuilder.InscriptionCostParams{Inscription: inscription,
+			SatoshiPerKVByte: big.NewInt(5000), CommitInputs: 3})
+		require.NoError(t, err)
+		require.Equal(t, one.CommitAmount, three.CommitAmount)
+		require.Equal(t, 1, three.CommitFee.Cmp(one.CommitFee))
+	})
+
+	t.Run("etching rune", func(t *testing.T) {
+		rune_, err := runes.NewRuneFromString("HELLOWORLD")
+		require.NoError(t, err)
+
+		plain, err := txBuilder.InscriptionCost(txbuilder.InscriptionCostParams{Inscription: inscription, SatoshiPerKVByte: big.NewInt(5000)})
+		require.NoError(t, err)
+		committed, err := txBuilder.InscriptionCost(txbuilder.InscriptionCostParams{Inscription: inscription,
+			SatoshiPerKVByte: big.NewInt(5000), EtchingRune: rune_})
+		require.NoError(t, err)
+		require.Equal(t, 1, committed.WitnessVBytes.Cmp(plain.WitnessVBytes))
+		require.Nil(t, inscription.Rune)
+	})
+
+	t.Run("content rules", func(t *testing.T) {
+		_, err := txBuilder.InscriptionCost(txbuilder.InscriptionCostParams{
+			Inscription:      &inscriptions.Inscription{ContentType: "application/x-msdownload", Body: []byte("test")},
+			SatoshiPerKVByte: big.NewInt(5000),
+			ContentRules:     inscriptions.DefaultContentRules,
+		})
+		require.ErrorIs(t, err, inscriptions.ErrUnsupportedContentType)
+	})
+
+	t.Run("missing fee rate", func(t *testing.T) {
+		_, err := txBuilder.InscriptionCost(txbuilder.InscriptionCostParams{Inscription: inscription})
+		require.ErrorIs(t, err, txbuilder.ErrFeeRateOutOfBounds)
+	})
+}
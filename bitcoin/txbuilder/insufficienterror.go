@@ -4,6 +4,7 @@
 package txbuilder
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 )
@@ -32,6 +33,25 @@ type InsufficientError struct {
 	Causer causerSign
 }
 
+// InsufficientErrorDomain defines logical grouping of the InsufficientError reason in the ErrorInfo.
+const InsufficientErrorDomain = "txbuilder.blockchain.boostylabs"
+
+// insufficientErrorJSON defines stable wire format of the InsufficientError, amounts are decimal strings.
+type insufficientErrorJSON struct {
+	Type   string `json:"type"`
+	Need   string `json:"need,omitempty"`
+	Have   string `json:"have,omitempty"`
+	Causer string `json:"causer,omitempty"`
+}
+
+// ErrorInfo describes machine-readable error details, mirrors google.rpc.ErrorInfo
+// message to be attached to the gRPC status without dependency on gRPC packages.
+type ErrorInfo struct {
+	Reason   string            // UPPER_SNAKE_CASE error reason.
+	Domain   string            // logical grouping of the reason.
+	Metadata map[string]string // additional structured details.
+}
+
 // NewInsufficientError is a constructor for InsufficientError.
 func NewInsufficientError(type_ balanceErrorType, need, have *big.Int) *InsufficientError {
 	return &InsufficientError{type_, need, have, ""}
@@ -57,6 +77,111 @@ func (e *InsufficientError) Is(target error) bool {
 	return e.Error() == target.Error()
 }
 
+// GetType returns balance type which is insufficient.
+func (e *InsufficientError) GetType() string {
+	if e == nil {
+		return ""
+	}
+
+	return string(e.Type)
+}
+
+// GetNeed returns copy of the needed amount, nil if not set.
+func (e *InsufficientError) GetNeed() *big.Int {
+	if e == nil || e.Need == nil {
+		return nil
+	}
+
+	return new(big.Int).Set(e.Need)
+}
+
+// GetHave returns copy of the available amount, nil if not set.
+func (e *InsufficientError) GetHave() *big.Int {
+	if e == nil || e.Have == nil {
+		return nil
+	}
+
+	return new(big.Int).Set(e.Have)
+}
+
+// GetCauser returns side which caused the error, empty if not set.
+func (e *InsufficientError) GetCauser() string {
+	if e == nil {
+		return ""
+	}
+
+	return string(e.Causer)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (e *InsufficientError) MarshalJSON() ([]byte, error) {
+	data := insufficientErrorJSON{Type: string(e.Type), Causer: string(e.Causer)}
+	if e.Need != nil {
+		data.Need = e.Need.String()
+	}
+	if e.Have != nil {
+		data.Have = e.Have.String()
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (e *InsufficientError) UnmarshalJSON(b []byte) error {
+	var data insufficientErrorJSON
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	var (
+		need, have *big.Int
+		ok         bool
+	)
+	if data.Need != "" {
+		if need, ok = new(big.Int).SetString(data.Need, 10); !ok {
+			return fmt.Errorf("invalid need amount: %q", data.Need)
+		}
+	}
+	if data.Have != "" {
+		if have, ok = new(big.Int).SetString(data.Have, 10); !ok {
+			return fmt.Errorf("invalid have amount: %q", data.Have)
+		}
+	}
+
+	*e = InsufficientError{
+		Type:   balanceErrorType(data.Type),
+		Need:   need,
+		Have:   have,
+		Causer: causerSign(data.Causer),
+	}
+
+	return nil
+}
+
+// ErrorInfo returns error details to be attached to the gRPC status, e.g.:
+//
+//	info := insufficientErr.ErrorInfo()
+//	st, err := status.New(codes.FailedPrecondition, insufficientErr.Error()).
+//		WithDetails(&errdetails.ErrorInfo{Reason: info.Reason, Domain: info.Domain, Metadata: info.Metadata})
+func (e *InsufficientError) ErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		Reason:   "INSUFFICIENT_BALANCE",
+		Domain:   InsufficientErrorDomain,
+		Metadata: map[string]string{"type": string(e.Type)},
+	}
+	if e.Need != nil {
+		info.Metadata["need"] = e.Need.String()
+	}
+	if e.Have != nil {
+		info.Metadata["have"] = e.Have.String()
+	}
+	if e.Causer != "" {
+		info.Metadata["causer"] = string(e.Causer)
+	}
+
+	return info
+}
+
 // clarify returns formed error with Need and Have values set.
 func (e *InsufficientError) clarify(need, have *big.Int) *InsufficientError {
 	return &InsufficientError{e.Type, need, have, e.Causer}
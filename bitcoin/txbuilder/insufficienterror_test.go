@@ -0,0 +1,60 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestInsufficientError(t *testing.T) {
+	insufficientErr := txbuilder.NewInsufficientError(txbuilder.InsufficientErrorTypeRune, big.NewInt(1000), big.NewInt(999))
+	insufficientErr.Causer = txbuilder.CauserFeePayer
+
+	t.Run("accessors", func(t *testing.T) {
+		require.Equal(t, "rune", insufficientErr.GetType())
+		require.EqualValues(t, 1000, insufficientErr.GetNeed().Int64())
+		require.EqualValues(t, 999, insufficientErr.GetHave().Int64())
+		require.Equal(t, "fee-payer", insufficientErr.GetCauser())
+
+		insufficientErr.GetNeed().SetInt64(0)
+		require.EqualValues(t, 1000, insufficientErr.Need.Int64())
+
+		var nilErr *txbuilder.InsufficientError
+		require.Empty(t, nilErr.GetType())
+		require.Nil(t, nilErr.GetNeed())
+		require.Nil(t, nilErr.GetHave())
+		require.Empty(t, nilErr.GetCauser())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(insufficientErr)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type":"rune","need":"1000","have":"999","causer":"fee-payer"}`, string(data))
+
+		var decoded txbuilder.InsufficientError
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, insufficientErr, &decoded)
+		require.True(t, errors.Is(&decoded, insufficientErr))
+
+		data, err = json.Marshal(txbuilder.InsufficientNativeBalanceError)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type":"bitcoin"}`, string(data))
+
+		require.Error(t, json.Unmarshal([]byte(`{"type":"rune","need":"1.5"}`), &decoded))
+	})
+
+	t.Run("ErrorInfo", func(t *testing.T) {
+		info := insufficientErr.ErrorInfo()
+		require.Equal(t, "INSUFFICIENT_BALANCE", info.Reason)
+		require.Equal(t, txbuilder.InsufficientErrorDomain, info.Domain)
+		require.Equal(t, map[string]string{"type": "rune", "need": "1000", "have": "999", "causer": "fee-payer"}, info.Metadata)
+	})
+}
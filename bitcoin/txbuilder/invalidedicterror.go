@@ -0,0 +1,41 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+// ErrInvalidEdict describes class of errors when edict amounts are not valid in strict mode.
+var ErrInvalidEdict = errors.New("invalid edict")
+
+// InvalidEdictError is the error type to describe invalid edict amounts with details.
+type InvalidEdictError struct {
+	RuneID       runes.RuneID
+	Field        string   // validated amount name, e.g. "transfer", "burn" or "transfer+burn".
+	Amount       *big.Int // validated amount in rune units.
+	Limit        *big.Int // exceeded limit in rune units, nil for negative amounts.
+	LimitName    string   // exceeded limit name, e.g. "holdings" or "supply".
+	Divisibility byte     // rune divisibility to format amounts, 0 if rune info is not provided.
+}
+
+// Error returns error description.
+func (e *InvalidEdictError) Error() string {
+	amount := runes.FormatAmount(e.Amount, e.Divisibility)
+	if e.Limit == nil {
+		return fmt.Sprintf("%s: %s %s amount %s is negative", ErrInvalidEdict, e.RuneID.String(), e.Field, amount)
+	}
+
+	return fmt.Sprintf("%s: %s %s amount %s exceeds %s %s", ErrInvalidEdict, e.RuneID.String(), e.Field, amount,
+		e.LimitName, runes.FormatAmount(e.Limit, e.Divisibility))
+}
+
+// Is implements comparator method for [errors] package.
+func (e *InvalidEdictError) Is(target error) bool {
+	return target == ErrInvalidEdict //nolint: errorlint
+}
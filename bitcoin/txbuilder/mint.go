@@ -0,0 +1,260 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// ErrInvalidMintCount describes that requested number of mint transactions is not positive.
+var ErrInvalidMintCount = errors.New("invalid mint transactions count")
+
+// BaseRuneMintTxsParams describes data needed to build parallel open mint transactions.
+type BaseRuneMintTxsParams struct {
+	RuneID                    runes.RuneID   // minting rune, etched in RuneID.Block.
+	Etching                   *runes.Etching // minting rune etching with mint terms. mandatory.
+	Height                    uint64         // block height the mint transactions are expected to be included in.
+	Mints                     *big.Int       // number of already done mints, optional.
+	Count                     int            // number of mint transactions to build, one mint per transaction.
+	FeePayer                  *PaymentData   // mandatory. must be sorted by btc amount desc.
+	SatoshiPerKVByte          *big.Int       // fee rate in satoshi per kilo virtual byte.
+	RecipientAddress          string         // minted runes recipient address.
+	SatoshiCommissionAmount   *big.Int       // additional commission in satoshi to be charged per transaction, optional.
+	CommissionReceiverAddress string         // recipient commission address, optional.
+}
+
+// BuildRuneMintTxResult describes single mint transaction in PSBT format.
+type BuildRuneMintTxResult struct {
+	SerializedPSBT       []byte
+	UsedBaseUTXOs        []*bitcoin.UTXO       // used fee payer's bitcoin utxos in transaction.
+	EstimatedFee         *big.Int              // estimated transaction fee in Satoshi.
+	AddressReuseWarnings []AddressReuseWarning // outputs reusing addresses from AddressHistory, if configured.
+}
+
+// BuildRuneMintTxs validates that the rune is mintable Count times at Height and constructs Count independent
+// mint transactions in PSBT format with inputs indexes assigned in unknown fields. Fee payer utxos are
+// distributed across transactions, so each utxo is spent at most once and transactions do not conflict.
+func (b *TxBuilder) BuildRuneMintTxs(params BaseRuneMintTxsParams) ([]BuildRuneMintTxResult, error) {
+	return b.BuildRuneMintTxsContext(context.Background(), params)
+}
+
+// BuildRuneMintTxsContext is like BuildRuneMintTxs, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildRuneMintTxsContext(ctx context.Context, params BaseRuneMintTxsParams) ([]BuildRuneMintTxResult, error) {
+	builder := b.snapshot()
+
+	if err := builder.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return nil, err
+	}
+	if params.Etching == nil {
+		return nil, ErrMissingRuneEtching
+	}
+	if params.FeePayer == nil {
+		return nil, ErrMissingFeePayer
+	}
+	if params.Count < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidMintCount, params.Count)
+	}
+
+	if err := checkMintable(params); err != nil {
+		return nil, err
+	}
+
+	// INFO: pool keeps not used fee payer utxos in the original order, origins point to the params utxos.
+	pool := make([]bitcoin.UTXO, len(params.FeePayer.UTXOs))
+	origins := make([]*bitcoin.UTXO, len(params.FeePayer.UTXOs))
+	for i := range params.FeePayer.UTXOs {
+		pool[i], origins[i] = params.FeePayer.UTXOs[i], &params.FeePayer.UTXOs[i]
+	}
+
+	results := make([]BuildRuneMintTxResult, 0, params.Count)
+	for i := 0; i < params.Count; i++ {
+		baseResult, err := builder.buildRuneMintTx(ctx, params, pool)
+		if err != nil {
+			return nil, fmt.Errorf("mint transaction %d: %w", i, err)
+		}
+
+		used := make(map[*bitcoin.UTXO]struct{}, len(baseResult.UsedSenderBaseUTXOs))
+		for _, utxo := range baseResult.UsedSenderBaseUTXOs {
+			used[utxo] = struct{}{}
+		}
+
+		var (
+			restPool    = make([]bitcoin.UTXO, 0, len(pool))
+			restOrigins = make([]*bitcoin.UTXO, 0, len(origins))
+		)
+		baseResult.UsedSenderBaseUTXOs = baseResult.UsedSenderBaseUTXOs[:0]
+		for j := range pool {
+			if _, ok := used[&pool[j]]; ok {
+				baseResult.UsedSenderBaseUTXOs = append(baseResult.UsedSenderBaseUTXOs, origins[j])
+				continue
+			}
+
+			restPool, restOrigins = append(restPool, pool[j]), append(restOrigins, origins[j])
+		}
+		pool, origins = restPool, restOrigins
+
+		err = builder.afterBuild(ctx, BuildInfo{
+			Kind:          BuildKindRuneMint,
+			UnsignedRawTx: baseResult.UnsignedRawTx,
+			UsedUTXOs:     baseResult.UsedSenderBaseUTXOs,
+			EstimatedFee:  baseResult.EstimatedFee,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("mint transaction %d: %w", i, err)
+		}
+
+		warnings, err := addressReuseWarnings(ctx, builder.config.AddressHistory, baseResult.UnsignedRawTx,
+			baseResult.OutputRoles, builder.networkParams)
+		if err != nil {
+			return nil, fmt.Errorf("mint transaction %d: %w", i, err)
+		}
+
+		serializedPSBT, err := builder.buildBTCTransferPSBT(BuildBTCTransferPSBTParams{
+			BaseBTCTransferResult: baseResult,
+			SenderAddress:         params.FeePayer.Address,
+			SenderPubKey:          params.FeePayer.PubKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, BuildRuneMintTxResult{
+			SerializedPSBT:       serializedPSBT,
+			UsedBaseUTXOs:        baseResult.UsedSenderBaseUTXOs,
+			EstimatedFee:         baseResult.EstimatedFee,
+			AddressReuseWarnings: warnings,
+		})
+	}
+
+	return results, nil
+}
+
+// checkMintable returns runes mint error if the rune can not be minted Count times at Height.
+func checkMintable(params BaseRuneMintTxsParams) error {
+	mints := big.NewInt(0)
+	if params.Mints != nil {
+		mints.Set(params.Mints)
+	}
+
+	if err := params.Etching.IsMintable(params.RuneID.Block, params.Height, mints); err != nil {
+		return err
+	}
+
+	// INFO: IsMintable guarantees that cap is set and greater than done mints.
+	left := new(big.Int).Sub(params.Etching.Terms.Cap, mints)
+	if numbers.IsLess(left, big.NewInt(int64(params.Count))) {
+		return fmt.Errorf("%w: %d mints requested, %s left", runes.ErrMintCapReached, params.Count, left.String())
+	}
+
+	return nil
+}
+
+// buildRuneMintTx constructs mint transaction paid by fee payer utxos from the pool.
+// Returns transaction with fee payer utxos as sender utxos pointing to the pool.
+//
+//	Tx struct
+//	inputs:
+//	┌─────────┬──────────────┬────────────────────────────────────────┐
+//	│  index  │     type     │             description                │
+//	├=========┼==============┼========================================┤
+//	│   0 - n │ base inputs  │ utxos with bitcoin only, possibly many │
+//	└─────────┴──────────────┴────────────────────────────────────────┘
+//
+//	outputs:
+//	┌─────────┬──────────────┬────────────────────────────────────────┐
+//	│  index  │     type     │             description                │
+//	├=========┼==============┼========================================┤
+//	│       0 │ runestone    │ rune protocol main output with mint.   │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│       1 │ rune output  │ output to link minted runes to         │
+//	│         │              │ recipient.                             │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│       2 │ base output  │ service native commission. optional,   │
+//	│         │              │ charge commission from fee payer if    │
+//	│         │              │ satoshi commission amount is not 0.    │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│       3 │ base output  │ outputs to change bitcoin amount.      │
+//	│         │              │ 99% mandatory, if any left.            │
+//	└─────────┴──────────────┴────────────────────────────────────────┘
+func (b *TxBuilder) buildRuneMintTx(ctx context.Context, params BaseRuneMintTxsParams, pool []bitcoin.UTXO) (result BaseBTCTransferResult, _ error) {
+	outputs := 3 // runestone + runes recipient + change.
+	satTransferAmount := new(big.Int).Set(b.config.DustAmount)
+	hasCommission := params.SatoshiCommissionAmount != nil && numbers.IsPositive(params.SatoshiCommissionAmount)
+	if hasCommission {
+		outputs++
+		satTransferAmount.Add(satTransferAmount, params.SatoshiCommissionAmount)
+	}
+
+	prepareUTXOsResult, err := b.prepareUTXOs(ctx, PrepareUTXOsParams{
+		Utxos:            pool,
+		Outputs:          outputs,
+		TransferAmount:   satTransferAmount,
+		SatoshiPerKVByte: params.SatoshiPerKVByte,
+	})
+	if err != nil {
+		if errIns := new(InsufficientError); errors.As(err, &errIns) {
+			return result, errIns.setCauser(CauserFeePayer)
+		}
+
+		return result, err
+	}
+
+	runestone := &runes.Runestone{Mint: &params.RuneID}
+	runestoneData, err := runestone.IntoScript()
+	if err != nil {
+		return result, err
+	}
+
+	tx := wire.NewMsgTx(txVersion)
+	for _, i := range prepareUTXOsResult.UsedUTXOs {
+		tx.AddTxIn(wire.NewTxIn(i.WireOutPoint(), nil, nil))
+	}
+
+	bitcoinAmount := new(big.Int).Sub(prepareUTXOsResult.TotalAmount, prepareUTXOsResult.RoughEstimate)
+
+	roles := make(outputRoles)
+
+	// runestone output (#0).
+	tx.AddTxOut(wire.NewTxOut(0, runestoneData))
+	roles.add(tx, OutputRoleRunestone)
+
+	// runes recipient output (#1).
+	if err = b.addOutput(tx, b.config.DustAmount, bitcoinAmount, params.RecipientAddress); err != nil {
+		return result, err
+	}
+	roles.add(tx, OutputRoleRecipient)
+
+	// service commission output (#2).
+	if hasCommission {
+		if err = b.addOutput(tx, params.SatoshiCommissionAmount, bitcoinAmount, params.CommissionReceiverAddress); err != nil {
+			return result, err
+		}
+		roles.add(tx, OutputRoleCommission)
+	}
+
+	// fee payer's change btc output (#3).
+	if numbers.IsGreater(bitcoinAmount, b.config.DustAmount) {
+		if err = b.addOutput(tx, new(big.Int).Set(bitcoinAmount), bitcoinAmount, params.FeePayer.Address); err != nil {
+			return result, err
+		}
+		roles.add(tx, OutputRoleChange)
+	}
+
+	result.UnsignedRawTx = tx
+	result.UsedSenderBaseUTXOs = prepareUTXOsResult.UsedUTXOs
+	result.EstimatedFee = prepareUTXOsResult.RoughEstimate
+	result.OutputRoles = roles.list(tx)
+
+	return result, nil
+}
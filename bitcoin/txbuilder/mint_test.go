@@ -0,0 +1,120 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestBuildRuneMintTxs(t *testing.T) {
+	heightEnd := uint64(1000)
+	runeID := runes.RuneID{Block: 800, TxID: 12}
+	feePayerUTXO := func(index uint32, amount int64) bitcoin.UTXO {
+		return bitcoin.UTXO{
+			Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", index),
+			Amount:   big.NewInt(amount),
+			Script:   []byte("_bitcoin_transaction_script_"),
+			Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+		}
+	}
+
+	params := txbuilder.BaseRuneMintTxsParams{
+		RuneID: runeID,
+		Etching: &runes.Etching{
+			Terms: &runes.Terms{
+				Amount:    big.NewInt(1000),
+				Cap:       big.NewInt(10),
+				HeightEnd: &heightEnd,
+			},
+		},
+		Height: 900,
+		Mints:  big.NewInt(7),
+		Count:  3,
+		FeePayer: &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{
+				feePayerUTXO(0, 50000),
+				feePayerUTXO(1, 20000),
+				feePayerUTXO(2, 10000),
+				feePayerUTXO(3, 1000),
+			},
+			Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+		},
+		SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+		RecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+	}
+	txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params)
+
+	t.Run("parallel mints", func(t *testing.T) {
+		results, err := txBuilder.BuildRuneMintTxs(params)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		// INFO: the smallest sufficient utxo is selected for each transaction.
+		spent := make(map[*bitcoin.UTXO]struct{})
+		for i, result := range results {
+			index := len(results) - 1 - i
+			require.Equal(t, []*bitcoin.UTXO{&params.FeePayer.UTXOs[index]}, result.UsedBaseUTXOs)
+			for _, utxo := range result.UsedBaseUTXOs {
+				require.NotContains(t, spent, utxo)
+				spent[utxo] = struct{}{}
+			}
+
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+			require.NoError(t, err)
+			require.Len(t, p.UnsignedTx.TxIn, 1)
+			require.Len(t, p.UnsignedTx.TxOut, 3)
+			require.EqualValues(t, index, p.UnsignedTx.TxIn[0].PreviousOutPoint.Index)
+
+			runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[0].PkScript)
+			require.NoError(t, err)
+			require.Equal(t, &runeID, runestone.Mint)
+			require.EqualValues(t, 546, p.UnsignedTx.TxOut[1].Value)
+		}
+	})
+
+	t.Run("not mintable", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(params *txbuilder.BaseRuneMintTxsParams)
+			err    error
+		}{
+			{"cap exceeded", func(params *txbuilder.BaseRuneMintTxsParams) { params.Count = 4 }, runes.ErrMintCapReached},
+			{"ended", func(params *txbuilder.BaseRuneMintTxsParams) { params.Height = heightEnd }, runes.ErrMintEnded},
+			{"no terms", func(params *txbuilder.BaseRuneMintTxsParams) { params.Etching = &runes.Etching{} }, runes.ErrUnmintable},
+			{"invalid count", func(params *txbuilder.BaseRuneMintTxsParams) { params.Count = 0 }, txbuilder.ErrInvalidMintCount},
+			{"no etching", func(params *txbuilder.BaseRuneMintTxsParams) { params.Etching = nil }, txbuilder.ErrMissingRuneEtching},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				params := params
+				test.modify(&params)
+
+				_, err := txBuilder.BuildRuneMintTxs(params)
+				require.ErrorIs(t, err, test.err)
+			})
+		}
+	})
+
+	t.Run("insufficient fee payer utxos", func(t *testing.T) {
+		params := params
+		params.Mints = nil
+		params.Count = 4
+
+		_, err := txBuilder.BuildRuneMintTxs(params)
+		var errIns *txbuilder.InsufficientError
+		require.ErrorAs(t, err, &errIns)
+		require.Equal(t, txbuilder.InsufficientErrorTypeBitcoin, errIns.Type)
+	})
+}
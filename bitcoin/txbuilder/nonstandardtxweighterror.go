@@ -0,0 +1,29 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNonStandardTxWeight describes class of errors when transaction weight exceeds standardness limit.
+var ErrNonStandardTxWeight = errors.New("transaction weight exceeds standard limit")
+
+// NonStandardTxWeightError is the error type to describe non-standard transaction weight with details.
+type NonStandardTxWeightError struct {
+	Weight    int64 // estimated transaction weight in weight units.
+	MaxWeight int64 // standardness limit in weight units.
+	Overshoot int64 // weight units to be cut to fit the limit.
+}
+
+// Error returns error description.
+func (e *NonStandardTxWeightError) Error() string {
+	return fmt.Sprintf("%s: %d WU, max: %d WU, overshoot: %d WU", ErrNonStandardTxWeight, e.Weight, e.MaxWeight, e.Overshoot)
+}
+
+// Is implements comparator method for [errors] package.
+func (e *NonStandardTxWeightError) Is(target error) bool {
+	return target == ErrNonStandardTxWeight //nolint: errorlint
+}
@@ -0,0 +1,435 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// ErrInvalidOffer describes that rune sell offer is malformed or not signed.
+var ErrInvalidOffer = errors.New("invalid rune sell offer")
+
+const (
+	// offerSigHashType defines signature hash type of the seller offer input: the signature commits to the
+	// seller input and the price output with the same index only, so the buyer can add own inputs and outputs.
+	offerSigHashType = txscript.SigHashSingle | txscript.SigHashAnyOneCanPay
+	// offerRuneSubtype defines proprietary key subtype of the offered rune in the seller input.
+	offerRuneSubtype byte = 0x01
+)
+
+// BuildRuneSellOfferParams describes data needed to build rune sell offer.
+type BuildRuneSellOfferParams struct {
+	RuneID         runes.RuneID
+	RuneUTXO       bitcoin.UTXO // seller utxo with the offered runes, must hold the offered rune only.
+	SellerAddress  string       // rune utxo owner address.
+	SellerPubKey   string       // rune utxo owner public key.
+	PriceSatoshi   *big.Int     // price in satoshi paid to the payment address.
+	PaymentAddress string       // seller address to receive the price, optional, SellerAddress if not set.
+}
+
+// BuildRuneSellOfferResult describes rune sell offer in PSBT format.
+type BuildRuneSellOfferResult struct {
+	SerializedPSBT []byte   // unsigned offer with seller input (#0) and price output (#0).
+	RuneAmount     *big.Int // offered rune amount.
+}
+
+// BuildRuneOfferAcceptTxParams describes data needed to accept rune sell offers.
+type BuildRuneOfferAcceptTxParams struct {
+	Offers                [][]byte     // signed sell offers in PSBT format, see BuildRuneSellOfferPSBT.
+	Buyer                 *PaymentData // pays offers prices and fee. mandatory.
+	RunesRecipientAddress string       // bought runes recipient address, optional, buyer address if not set.
+	SatoshiPerKVByte      *big.Int     // fee rate in satoshi per kilo virtual byte.
+}
+
+// BuildRuneOfferAcceptTxResult describes offers accepting transaction in PSBT format.
+type BuildRuneOfferAcceptTxResult struct {
+	SerializedPSBT []byte          // transaction with signed seller inputs and unsigned buyer inputs.
+	UsedBaseUTXOs  []*bitcoin.UTXO // used buyer's bitcoin utxos in transaction.
+	EstimatedFee   *big.Int        // estimated transaction fee in satoshi.
+	OutputRoles    []OutputRole    // roles of the transaction outputs by their indexes.
+	// RuneAmounts are bought rune amounts transferred to the buyer, as declared by the sellers in the offers.
+	RuneAmounts []bitcoin.RuneUTXO
+	// RunesVerified reports that offered rune utxos are confirmed by RuneStateOracle. Otherwise RuneAmounts
+	// are trusted as declared, so the seller may overstate them, and the buyer has to check them before signing.
+	RunesVerified bool
+	// AddressReuseWarnings are outputs reusing addresses from AddressHistory, if configured.
+	// NOTE: Offer price outputs are not checked, since they pay to the seller addresses.
+	AddressReuseWarnings []AddressReuseWarning
+}
+
+// runeOffer describes parsed signed sell offer.
+type runeOffer struct {
+	txIn   *wire.TxIn
+	input  psbt.PInput
+	price  *wire.TxOut
+	utxo   *bitcoin.UTXO // seller utxo with the offered rune to check its state.
+	runeID runes.RuneID
+}
+
+// OfferRuneKey returns PSBT input proprietary key of the offered rune:
+// 0xFC | identifier length | "txbuilder" | 0x01.
+func OfferRuneKey() []byte {
+	key := OutputRoleKey()
+	key[len(key)-1] = offerRuneSubtype
+
+	return key
+}
+
+// BuildRuneSellOfferPSBT constructs rune sell offer: PSBT with seller rune input (#0) and price output (#0),
+// the input is signed by the seller with SIGHASH_SINGLE | SIGHASH_ANYONECANPAY. Offered rune and amount are
+// stored in the input proprietary field, see OfferRuneKey. Signed offer is accepted by BuildRuneOfferAcceptTx.
+func (b *TxBuilder) BuildRuneSellOfferPSBT(params BuildRuneSellOfferParams) (result BuildRuneSellOfferResult, _ error) {
+	builder := b.snapshot()
+
+	if params.RuneUTXO.Amount == nil || !numbers.IsPositive(params.RuneUTXO.Amount) {
+		return result, fmt.Errorf("%w: %v", ErrInvalidUTXOAmount, params.RuneUTXO.Amount)
+	}
+
+	result.RuneAmount = params.RuneUTXO.RuneAmount(params.RuneID)
+	if !numbers.IsPositive(result.RuneAmount) {
+		return result, fmt.Errorf("%w: utxo does not hold %s", ErrInvalidOffer, params.RuneID.String())
+	}
+	for _, rune_ := range params.RuneUTXO.Runes {
+		if rune_.RuneID != params.RuneID && rune_.Amount != nil && numbers.IsPositive(rune_.Amount) {
+			return result, fmt.Errorf("%w: utxo holds other rune %s", ErrInvalidOffer, rune_.RuneID.String())
+		}
+	}
+
+	if params.PriceSatoshi == nil || numbers.IsLess(params.PriceSatoshi, builder.config.DustAmount) {
+		return result, fmt.Errorf("%w: price %v is less than dust amount", ErrInvalidOffer, params.PriceSatoshi)
+	}
+
+	paymentAddress := params.PaymentAddress
+	if paymentAddress == "" {
+		paymentAddress = params.SellerAddress
+	}
+
+	tx := wire.NewMsgTx(txVersion)
+	tx.AddTxIn(wire.NewTxIn(params.RuneUTXO.WireOutPoint(), nil, nil))
+	if err := builder.addOutput(tx, params.PriceSatoshi, new(big.Int).Set(params.PriceSatoshi), paymentAddress); err != nil {
+		return result, err
+	}
+
+	p, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return result, err
+	}
+
+	inputBuilder, err := NewPSBTInputBuilder(params.SellerPubKey, params.SellerAddress, builder.networkParams)
+	if err != nil {
+		return result, err
+	}
+
+	offerRune, err := runes.IntSequenceIntoPayload(append(params.RuneID.ToIntSeq(), result.RuneAmount))
+	if err != nil {
+		return result, err
+	}
+
+	inputBuilder.PrepareInput(&p.Inputs[0])
+	p.Inputs[0].WitnessUtxo = wire.NewTxOut(params.RuneUTXO.Amount.Int64(), params.RuneUTXO.Script)
+	p.Inputs[0].SighashType = offerSigHashType
+	p.Inputs[0].Unknowns = append(p.Inputs[0].Unknowns, &psbt.Unknown{Key: OfferRuneKey(), Value: offerRune})
+	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: b.config.inputsHelpingKey(inputBuilder.InputsHelpingKey(false)), Value: []byte{0}})
+
+	var buff bytes.Buffer
+	if err = p.Serialize(&buff); err != nil {
+		return result, err
+	}
+
+	result.SerializedPSBT = buff.Bytes()
+
+	return result, nil
+}
+
+// BuildRuneOfferAcceptTx constructs transaction accepting signed rune sell offers in PSBT format
+// with buyer inputs indexes assigned in unknown fields, see BuildRuneOfferAcceptTxContext.
+func (b *TxBuilder) BuildRuneOfferAcceptTx(params BuildRuneOfferAcceptTxParams) (BuildRuneOfferAcceptTxResult, error) {
+	return b.BuildRuneOfferAcceptTxContext(context.Background(), params)
+}
+
+// BuildRuneOfferAcceptTxContext constructs transaction accepting signed rune sell offers. Seller inputs and
+// price outputs keep the same indexes as SIGHASH_SINGLE requires, runestone transfers offered runes to the buyer.
+// Offered rune utxos are confirmed by RuneStateOracle if configured, otherwise rune amounts declared by
+// the sellers are not verified, see BuildRuneOfferAcceptTxResult.RunesVerified.
+//
+//	Outputs:
+//	┌─────────┬──────────────┬────────────────────────────────────────┐
+//	│ Index   │ Output       │ Description                            │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│ 0..n-1  │ price output │ offers prices to the sellers.          │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│ n       │ OP_RETURN    │ runestone with edicts to the buyer.    │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│ n + 1   │ runes output │ bought runes to the buyer.             │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│ n + 2   │ base output  │ buyer change, if any non-dust left.    │
+//	└─────────┴──────────────┴────────────────────────────────────────┘
+func (b *TxBuilder) BuildRuneOfferAcceptTxContext(ctx context.Context, params BuildRuneOfferAcceptTxParams) (result BuildRuneOfferAcceptTxResult, _ error) {
+	builder := b.snapshot()
+
+	if err := builder.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return result, err
+	}
+	if params.Buyer == nil {
+		return result, ErrMissingFeePayer
+	}
+	if len(params.Offers) == 0 {
+		return result, fmt.Errorf("%w: no offers provided", ErrInvalidOffer)
+	}
+
+	recipientAddress := params.RunesRecipientAddress
+	if recipientAddress == "" {
+		recipientAddress = params.Buyer.Address
+	}
+
+	var (
+		offers   = make([]runeOffer, 0, len(params.Offers))
+		version  int32
+		lockTime uint32
+	)
+	for idx, serialized := range params.Offers {
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(serialized), false)
+		if err != nil {
+			return result, fmt.Errorf("%w: offer %d: %w", ErrInvalidOffer, idx, err)
+		}
+
+		offer, err := parseRuneOffer(p)
+		if err != nil {
+			return result, fmt.Errorf("offer %d: %w", idx, err)
+		}
+
+		// INFO: transaction version and lock time are committed to the offer signature.
+		if idx == 0 {
+			version, lockTime = p.UnsignedTx.Version, p.UnsignedTx.LockTime
+		} else if p.UnsignedTx.Version != version || p.UnsignedTx.LockTime != lockTime {
+			return result, fmt.Errorf("%w: offer %d: version and lock time differ from the offer 0", ErrInvalidOffer, idx)
+		}
+
+		offers = append(offers, offer)
+	}
+
+	runestone, offered := offersRunestone(offers)
+	for _, runeID := range offered {
+		var utxos []*bitcoin.UTXO
+		for _, offer := range offers {
+			if offer.runeID == runeID {
+				utxos = append(utxos, offer.utxo)
+			}
+		}
+
+		if err := checkRuneUTXOs(ctx, builder.config.RuneStateOracle, runeID, utxos); err != nil {
+			return result, err
+		}
+	}
+
+	runestoneData, err := runestone.IntoScript()
+	if err != nil {
+		return result, err
+	}
+
+	result.RunesVerified = builder.config.RuneStateOracle != nil
+	for _, edict := range runestone.Edicts {
+		result.RuneAmounts = append(result.RuneAmounts, bitcoin.RuneUTXO{RuneID: edict.RuneID, Amount: edict.Amount})
+	}
+
+	transferAmount := new(big.Int).Set(builder.config.DustAmount)
+	for _, offer := range offers {
+		transferAmount.Add(transferAmount, big.NewInt(offer.price.Value))
+	}
+
+	prepareUTXOsResult, err := builder.prepareUTXOs(ctx, PrepareUTXOsParams{
+		Utxos:            params.Buyer.UTXOs,
+		Inputs:           len(offers),
+		Outputs:          len(offers) + 3,
+		TransferAmount:   transferAmount,
+		SatoshiPerKVByte: params.SatoshiPerKVByte,
+	})
+	if err != nil {
+		if errIns := new(InsufficientError); errors.As(err, &errIns) {
+			return result, errIns.setCauser(CauserFeePayer)
+		}
+
+		return result, err
+	}
+
+	tx := wire.NewMsgTx(version)
+	tx.LockTime = lockTime
+	roles := make(outputRoles)
+	unallocated := new(big.Int).Sub(prepareUTXOsResult.TotalAmount, prepareUTXOsResult.RoughEstimate)
+
+	// sellers inputs and price outputs (#0..n-1).
+	for _, offer := range offers {
+		tx.AddTxIn(offer.txIn)
+		tx.AddTxOut(offer.price)
+		roles.add(tx, OutputRoleOfferPrice)
+
+		unallocated.Add(unallocated, big.NewInt(offer.input.WitnessUtxo.Value-offer.price.Value))
+	}
+
+	for _, utxo := range prepareUTXOsResult.UsedUTXOs {
+		tx.AddTxIn(wire.NewTxIn(utxo.WireOutPoint(), nil, nil))
+	}
+
+	// runestone output (#n).
+	tx.AddTxOut(wire.NewTxOut(0, runestoneData))
+	roles.add(tx, OutputRoleRunestone)
+
+	// buyer runes output (#n + 1).
+	if err = builder.addOutput(tx, builder.config.DustAmount, unallocated, recipientAddress); err != nil {
+		return result, err
+	}
+	roles.add(tx, OutputRoleRecipient)
+
+	// buyer change output (#n + 2).
+	if numbers.IsGreater(unallocated, builder.config.DustAmount) {
+		if err = builder.addOutput(tx, new(big.Int).Set(unallocated), unallocated, params.Buyer.Address); err != nil {
+			return result, err
+		}
+		roles.add(tx, OutputRoleChange)
+	}
+
+	used := make([]*bitcoin.UTXO, 0, len(offers)+len(prepareUTXOsResult.UsedUTXOs))
+	for _, offer := range offers {
+		used = append(used, offer.utxo)
+	}
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindRuneOfferAccept,
+		UnsignedRawTx: tx,
+		UsedUTXOs:     append(used, prepareUTXOsResult.UsedUTXOs...),
+		EstimatedFee:  prepareUTXOsResult.RoughEstimate,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	p, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return result, err
+	}
+
+	for i, offer := range offers {
+		p.Inputs[i] = offer.input
+	}
+
+	buyerInputBuilder, err := NewPSBTInputBuilder(params.Buyer.PubKey, params.Buyer.Address, builder.networkParams)
+	if err != nil {
+		return result, err
+	}
+
+	buyerIndexes := make([]byte, len(prepareUTXOsResult.UsedUTXOs))
+	for i, utxo := range prepareUTXOsResult.UsedUTXOs {
+		idx := len(offers) + i
+		buyerInputBuilder.PrepareInput(&p.Inputs[idx])
+		p.Inputs[idx].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
+		p.Inputs[idx].SighashType = signHashType
+		buyerIndexes[i] = byte(idx)
+	}
+
+	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: b.config.inputsHelpingKey(buyerInputBuilder.InputsHelpingKey(true)), Value: buyerIndexes})
+
+	result.OutputRoles = roles.list(tx)
+	if builder.config.OutputRoles {
+		setOutputRoles(p, result.OutputRoles)
+	}
+
+	result.AddressReuseWarnings, err = addressReuseWarnings(ctx, builder.config.AddressHistory, tx, result.OutputRoles,
+		builder.networkParams)
+	if err != nil {
+		return result, err
+	}
+
+	var buff bytes.Buffer
+	if err = p.Serialize(&buff); err != nil {
+		return result, err
+	}
+
+	result.SerializedPSBT = buff.Bytes()
+	result.UsedBaseUTXOs = prepareUTXOsResult.UsedUTXOs
+	result.EstimatedFee = prepareUTXOsResult.RoughEstimate
+
+	return result, nil
+}
+
+// parseRuneOffer validates signed sell offer and returns its seller input, price output and offered rune.
+func parseRuneOffer(p *psbt.Packet) (offer runeOffer, _ error) {
+	if len(p.UnsignedTx.TxIn) != 1 || len(p.UnsignedTx.TxOut) != 1 {
+		return offer, fmt.Errorf("%w: %d inputs, %d outputs, must be 1 and 1", ErrInvalidOffer,
+			len(p.UnsignedTx.TxIn), len(p.UnsignedTx.TxOut))
+	}
+
+	input := p.Inputs[0]
+	switch {
+	case input.WitnessUtxo == nil:
+		return offer, fmt.Errorf("%w: witness utxo is missing", ErrInvalidOffer)
+	case input.SighashType != offerSigHashType:
+		return offer, fmt.Errorf("%w: signature hash type %#x", ErrInvalidOffer, uint32(input.SighashType))
+	case input.TaprootKeySpendSig == nil && len(input.TaprootScriptSpendSig) == 0 && len(input.PartialSigs) == 0 &&
+		input.FinalScriptWitness == nil && input.FinalScriptSig == nil:
+		return offer, fmt.Errorf("%w: seller input is not signed", ErrInvalidOffer)
+	}
+
+	idx := slices.IndexFunc(input.Unknowns, func(unknown *psbt.Unknown) bool {
+		return bytes.Equal(unknown.Key, OfferRuneKey())
+	})
+	if idx < 0 {
+		return offer, fmt.Errorf("%w: offered rune is missing", ErrInvalidOffer)
+	}
+
+	sequence, err := runes.PayloadIntoIntSequence(input.Unknowns[idx].Value)
+	if err != nil || len(sequence) != 3 || !sequence[0].IsUint64() || !sequence[1].IsUint64() ||
+		sequence[1].Uint64() > uint64(^uint32(0)) || !numbers.IsPositive(sequence[2]) {
+		return offer, fmt.Errorf("%w: malformed offered rune", ErrInvalidOffer)
+	}
+
+	offer.runeID = runes.RuneID{Block: sequence[0].Uint64(), TxID: uint32(sequence[1].Uint64())}
+	offer.txIn = p.UnsignedTx.TxIn[0]
+	offer.input = input
+	offer.price = p.UnsignedTx.TxOut[0]
+	offer.utxo = &bitcoin.UTXO{
+		Outpoint: bitcoin.Outpoint{Hash: offer.txIn.PreviousOutPoint.Hash, Index: offer.txIn.PreviousOutPoint.Index},
+		Amount:   big.NewInt(input.WitnessUtxo.Value),
+		Script:   input.WitnessUtxo.PkScript,
+		Runes:    []bitcoin.RuneUTXO{{RuneID: offer.runeID, Amount: sequence[2]}},
+	}
+
+	return offer, nil
+}
+
+// offersRunestone returns runestone transferring offered runes to the buyer runes output and offered rune ids.
+// INFO: pointer sends any runes not covered by edicts to the buyer too, instead of the first price output.
+func offersRunestone(offers []runeOffer) (*runes.Runestone, []runes.RuneID) {
+	var (
+		output  = uint32(len(offers) + 1)
+		amounts = make(map[runes.RuneID]*big.Int)
+		ids     []runes.RuneID
+	)
+	for _, offer := range offers {
+		if _, ok := amounts[offer.runeID]; !ok {
+			amounts[offer.runeID] = big.NewInt(0)
+			ids = append(ids, offer.runeID)
+		}
+
+		amounts[offer.runeID].Add(amounts[offer.runeID], offer.utxo.RuneAmount(offer.runeID))
+	}
+
+	runestone := &runes.Runestone{Pointer: &output}
+	for _, runeID := range ids {
+		runestone.Edicts = append(runestone.Edicts, runes.Edict{RuneID: runeID, Amount: amounts[runeID], Output: output})
+	}
+
+	return runestone, ids
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestRuneOffers(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+	builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithOutputRoles())
+	verifier := signer.NewSigner(networkParams, signer.VerifySignatures())
+	runeID := runes.RuneID{Block: 1122, TxID: 77}
+
+	seller, buyer := txbuildertest.NewWallet(t, networkParams), txbuildertest.NewWallet(t, networkParams)
+
+	offer := func(t *testing.T, txHash string, runeAmount, price int64) []byte {
+		result, err := builder.BuildRuneSellOfferPSBT(txbuilder.BuildRuneSellOfferParams{
+			RuneID: runeID,
+			RuneUTXO: bitcoin.UTXO{
+				Outpoint: bitcoin.MustOutpoint(txHash, 1),
+				Amount:   big.NewInt(546),
+				Script:   seller.Script,
+				Address:  seller.Address,
+				Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(runeAmount)}},
+			},
+			SellerAddress: seller.Address,
+			SellerPubKey:  seller.PubKey,
+			PriceSatoshi:  big.NewInt(price),
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, runeAmount, result.RuneAmount.Int64())
+
+		signed, err := verifier.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: result.SerializedPSBT,
+			Inputs:         []int{0},
+			PrivateKey:     seller.PrivateKey,
+		})
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	acceptParams := txbuilder.BuildRuneOfferAcceptTxParams{
+		Buyer:            buyer.PaymentData("a78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 100000),
+		SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+	}
+
+	t.Run("accept offers", func(t *testing.T) {
+		params := acceptParams
+		params.Offers = [][]byte{
+			offer(t, "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 1000, 10000),
+			offer(t, "f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 500, 20000),
+		}
+
+		result, err := builder.BuildRuneOfferAcceptTx(params)
+		require.NoError(t, err)
+		require.Equal(t, []txbuilder.OutputRole{
+			txbuilder.OutputRoleOfferPrice,
+			txbuilder.OutputRoleOfferPrice,
+			txbuilder.OutputRoleRunestone,
+			txbuilder.OutputRoleRecipient,
+			txbuilder.OutputRoleChange,
+		}, result.OutputRoles)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxIn, 3)
+		require.EqualValues(t, 10000, p.UnsignedTx.TxOut[0].Value)
+		require.EqualValues(t, 20000, p.UnsignedTx.TxOut[1].Value)
+
+		runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[2].PkScript)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, *runestone.Pointer)
+		require.Len(t, runestone.Edicts, 1)
+		require.EqualValues(t, 1500, runestone.Edicts[0].Amount.Int64())
+		require.EqualValues(t, 3, runestone.Edicts[0].Output)
+		require.Equal(t, []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(1500)}}, result.RuneAmounts)
+		require.False(t, result.RunesVerified)
+
+		signed, err := verifier.SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: result.SerializedPSBT,
+			Inputs:         []int{2},
+			PrivateKey:     buyer.PrivateKey,
+		})
+		require.NoError(t, err)
+
+		// INFO: seller signatures commit to the offer transaction, but stay valid in the accepting one.
+		p, err = psbt.NewFromRawBytes(bytes.NewReader(signed), false)
+		require.NoError(t, err)
+		require.NoError(t, psbt.MaybeFinalizeAll(p))
+
+		tx, err := psbt.Extract(p)
+		require.NoError(t, err)
+
+		prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+		for i, in := range tx.TxIn {
+			prevOuts.AddPrevOut(in.PreviousOutPoint, p.Inputs[i].WitnessUtxo)
+		}
+
+		sigHashes := txscript.NewTxSigHashes(tx, prevOuts)
+		for i := range tx.TxIn {
+			vm, err := txscript.NewEngine(p.Inputs[i].WitnessUtxo.PkScript, tx, i, txscript.StandardVerifyFlags,
+				nil, sigHashes, p.Inputs[i].WitnessUtxo.Value, prevOuts)
+			require.NoError(t, err)
+			require.NoError(t, vm.Execute())
+		}
+
+		t.Run("tampered price", func(t *testing.T) {
+			tampered := tx.Copy()
+			tampered.TxOut[0] = wire.NewTxOut(1000, tampered.TxOut[0].PkScript)
+
+			sigHashes := txscript.NewTxSigHashes(tampered, prevOuts)
+			vm, err := txscript.NewEngine(p.Inputs[0].WitnessUtxo.PkScript, tampered, 0, txscript.StandardVerifyFlags,
+				nil, sigHashes, p.Inputs[0].WitnessUtxo.Value, prevOuts)
+			require.NoError(t, err)
+			require.Error(t, vm.Execute())
+		})
+	})
+
+	t.Run("verified runes", func(t *testing.T) {
+		params := acceptParams
+		params.Offers = [][]byte{offer(t, "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 1000, 10000)}
+
+		oracle := runeStateOracleFunc(func(context.Context, runes.RuneID, []*bitcoin.UTXO) ([]*big.Int, error) {
+			return []*big.Int{big.NewInt(1000)}, nil
+		})
+		result, err := txbuilder.NewTxBuilder(networkParams, txbuilder.WithRuneStateOracle(oracle)).BuildRuneOfferAcceptTx(params)
+		require.NoError(t, err)
+		require.True(t, result.RunesVerified)
+		require.Equal(t, []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(1000)}}, result.RuneAmounts)
+
+		// INFO: seller declares more runes than the utxo holds.
+		oracle = func(context.Context, runes.RuneID, []*bitcoin.UTXO) ([]*big.Int, error) {
+			return []*big.Int{big.NewInt(10)}, nil
+		}
+		_, err = txbuilder.NewTxBuilder(networkParams, txbuilder.WithRuneStateOracle(oracle)).BuildRuneOfferAcceptTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrStaleRuneUTXOs)
+	})
+
+	t.Run("invalid offers", func(t *testing.T) {
+		unsigned, err := builder.BuildRuneSellOfferPSBT(txbuilder.BuildRuneSellOfferParams{
+			RuneID: runeID,
+			RuneUTXO: bitcoin.UTXO{
+				Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 1),
+				Amount:   big.NewInt(546),
+				Script:   seller.Script,
+				Address:  seller.Address,
+				Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(1000)}},
+			},
+			SellerAddress: seller.Address,
+			SellerPubKey:  seller.PubKey,
+			PriceSatoshi:  big.NewInt(10000),
+		})
+		require.NoError(t, err)
+
+		params := acceptParams
+		params.Offers = [][]byte{unsigned.SerializedPSBT}
+		_, err = builder.BuildRuneOfferAcceptTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrInvalidOffer)
+
+		params.Offers = nil
+		_, err = builder.BuildRuneOfferAcceptTx(params)
+		require.ErrorIs(t, err, txbuilder.ErrInvalidOffer)
+
+		_, err = builder.BuildRuneSellOfferPSBT(txbuilder.BuildRuneSellOfferParams{
+			RuneID: runes.RuneID{Block: 1, TxID: 1},
+			RuneUTXO: bitcoin.UTXO{
+				Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0),
+				Amount:   big.NewInt(546),
+				Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(1000)}},
+			},
+			SellerAddress: seller.Address,
+			SellerPubKey:  seller.PubKey,
+			PriceSatoshi:  big.NewInt(10000),
+		})
+		require.ErrorIs(t, err, txbuilder.ErrInvalidOffer)
+	})
+}
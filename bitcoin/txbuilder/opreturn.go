@@ -0,0 +1,52 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ErrInvalidOpReturnData describes that OP_RETURN data exceeds configured maximum size.
+var ErrInvalidOpReturnData = errors.New("invalid op_return data")
+
+// defaultMaxOpReturnDataSize defines maximum OP_RETURN data size relayed by default policy in bytes.
+const defaultMaxOpReturnDataSize = 80
+
+// opReturnOutputs returns zero value OP_RETURN outputs pushing the data items and the fee
+// for their size in satoshi, which is not covered by the size estimator.
+func (b *TxBuilder) opReturnOutputs(data [][]byte, satoshiPerKVByte *big.Int) ([]*wire.TxOut, *big.Int, error) {
+	var (
+		outputs = make([]*wire.TxOut, 0, len(data))
+		size    int64
+	)
+	for i, item := range data {
+		if len(item) > b.config.MaxOpReturnDataSize {
+			return nil, nil, fmt.Errorf("%w: item %d size %d exceeds %d bytes", ErrInvalidOpReturnData, i,
+				len(item), b.config.MaxOpReturnDataSize)
+		}
+
+		script, err := txscript.NewScriptBuilder().AddOp(txscript.OP_RETURN).AddFullData(item).Script()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: item %d: %w", ErrInvalidOpReturnData, i, err)
+		}
+
+		output := wire.NewTxOut(0, script)
+		outputs = append(outputs, output)
+		size += int64(output.SerializeSize())
+	}
+
+	fee := big.NewInt(0)
+	if satoshiPerKVByte != nil {
+		// INFO: vB * ( sat / kvB ) = 1000 sat.
+		fee.Mul(big.NewInt(size), satoshiPerKVByte)
+		fee.Div(fee, big.NewInt(1000)) // sat.
+	}
+
+	return outputs, fee, nil
+}
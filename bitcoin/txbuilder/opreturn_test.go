@@ -0,0 +1,104 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestOpReturnData(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	sender := wallet.PaymentData("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 100000)
+	params := txbuilder.BaseBTCTransferParams{
+		Sender:                sender,
+		TransferSatoshiAmount: big.NewInt(10000),
+		SatoshiPerKVByte:      big.NewInt(10000), // 10 sat/vB.
+		RecipientAddress:      wallet.Address,
+	}
+	proof := bytes.Repeat([]byte{0xAB}, 80)
+
+	builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithOutputRoles())
+
+	t.Run("data outputs", func(t *testing.T) {
+		plain, err := builder.BuildBTCTransferTx(params)
+		require.NoError(t, err)
+
+		withData := params
+		withData.OpReturnData = [][]byte{proof}
+		result, err := builder.BuildBTCTransferTx(withData)
+		require.NoError(t, err)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 3)
+
+		output := p.UnsignedTx.TxOut[1]
+		require.Zero(t, output.Value)
+		pushes, err := txscript.PushedData(output.PkScript[1:])
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{proof}, pushes)
+		require.Equal(t, txscript.NullDataTy, txscript.GetScriptClass(output.PkScript))
+
+		roles, err := txbuilder.ExtractOutputRolesFromPSBT(result.SerializedPSBT)
+		require.NoError(t, err)
+		require.Equal(t, []txbuilder.OutputRole{txbuilder.OutputRoleRecipient, txbuilder.OutputRoleData,
+			txbuilder.OutputRoleChange}, roles)
+
+		// INFO: data output size is paid on top of the estimated fee.
+		extraFee := int64(output.SerializeSize()) * 10
+		require.Equal(t, plain.EstimatedFee.Int64()+extraFee, result.EstimatedFee.Int64())
+		require.Equal(t, p.UnsignedTx.TxOut[2].Value+extraFee, mustChangeValue(t, plain.SerializedPSBT))
+	})
+
+	t.Run("fee payer", func(t *testing.T) {
+		withData := params
+		withData.Sender = &txbuilder.PaymentData{UTXOs: sender.UTXOs, Address: sender.Address, PubKey: sender.PubKey}
+		withData.TransferSatoshiAmount = big.NewInt(100000)
+		withData.FeePayer = &txbuilder.PaymentData{
+			UTXOs:   []bitcoin.UTXO{wallet.UTXO("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0, 5000)},
+			Address: wallet.Address,
+			PubKey:  sender.PubKey,
+		}
+		withData.OpReturnData = [][]byte{proof, {0x01}}
+
+		result, err := builder.BuildBTCTransferTx(withData)
+		require.NoError(t, err)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 4)
+		require.EqualValues(t, 5000-result.EstimatedFee.Int64(), p.UnsignedTx.TxOut[3].Value)
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		withData := params
+		withData.OpReturnData = [][]byte{append(proof, 0x00)}
+		_, err := builder.BuildBTCTransferTx(withData)
+		require.ErrorIs(t, err, txbuilder.ErrInvalidOpReturnData)
+
+		_, err = txbuilder.NewTxBuilder(networkParams, txbuilder.WithMaxOpReturnDataSize(1000)).BuildBTCTransferTx(withData)
+		require.NoError(t, err)
+	})
+}
+
+func mustChangeValue(t *testing.T, serializedPSBT []byte) int64 {
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+	require.NoError(t, err)
+
+	return p.UnsignedTx.TxOut[len(p.UnsignedTx.TxOut)-1].Value
+}
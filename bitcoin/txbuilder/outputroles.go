@@ -0,0 +1,112 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// OutputRole defines role of the transaction output, stored in PSBT output proprietary field.
+type OutputRole string
+
+const (
+	// OutputRoleRunestone defines rune protocol OP_RETURN output.
+	OutputRoleRunestone OutputRole = "runestone"
+	// OutputRoleRecipient defines output to the recipient of runes or bitcoin.
+	OutputRoleRecipient OutputRole = "recipient"
+	// OutputRoleRunesChange defines output to return runes change to the sender.
+	OutputRoleRunesChange OutputRole = "runes-change"
+	// OutputRoleChange defines output to return bitcoin change to the sender or fee payer.
+	OutputRoleChange OutputRole = "change"
+	// OutputRoleCommission defines service native commission output.
+	OutputRoleCommission OutputRole = "commission"
+	// OutputRoleInscriptionCommit defines output to the inscription commitment address.
+	OutputRoleInscriptionCommit OutputRole = "inscription-commit"
+	// OutputRoleStamp defines output carrying stamp payload.
+	OutputRoleStamp OutputRole = "stamp"
+	// OutputRoleOfferPrice defines output paying rune sell offer price to the seller.
+	OutputRoleOfferPrice OutputRole = "offer-price"
+	// OutputRoleData defines OP_RETURN output carrying arbitrary data, e.g. payment proof.
+	OutputRoleData OutputRole = "data"
+	// OutputRoleAnchor defines zero value pay to anchor (P2A) output to bump the transaction by the child.
+	OutputRoleAnchor OutputRole = "anchor"
+)
+
+const (
+	// proprietaryKeyType defines PSBT proprietary key type (BIP-174).
+	proprietaryKeyType byte = 0xFC
+	// proprietaryIdentifier defines identifier of the txbuilder proprietary keys.
+	proprietaryIdentifier = "txbuilder"
+	// outputRoleSubtype defines proprietary key subtype of the output role.
+	outputRoleSubtype byte = 0x00
+)
+
+// OutputRoleKey returns PSBT output proprietary key of the output role:
+// 0xFC | identifier length | "txbuilder" | 0x00.
+// INFO: identifier is shorter than 0xFD bytes, so its length is a single byte compact size.
+func OutputRoleKey() []byte {
+	key := make([]byte, 0, 3+len(proprietaryIdentifier))
+	key = append(key, proprietaryKeyType, byte(len(proprietaryIdentifier)))
+	key = append(key, proprietaryIdentifier...)
+
+	return append(key, outputRoleSubtype)
+}
+
+// ExtractOutputRolesFromPSBT returns roles of the PSBT outputs by their indexes,
+// role is empty if output is not labeled.
+func ExtractOutputRolesFromPSBT(data []byte) ([]OutputRole, error) {
+	p, err := psbt.NewFromRawBytes(bytes.NewBuffer(data), false)
+	if err != nil {
+		return nil, err
+	}
+
+	key := OutputRoleKey()
+	roles := make([]OutputRole, len(p.Outputs))
+	for i, output := range p.Outputs {
+		for _, unknown := range output.Unknowns {
+			if bytes.Equal(unknown.Key, key) {
+				roles[i] = OutputRole(unknown.Value)
+			}
+		}
+	}
+
+	return roles, nil
+}
+
+// outputRoles keeps roles of the transaction outputs while outputs are added and reordered.
+type outputRoles map[*wire.TxOut]OutputRole
+
+// add labels the last transaction output with the role.
+func (roles outputRoles) add(tx *wire.MsgTx, role OutputRole) {
+	roles[tx.TxOut[len(tx.TxOut)-1]] = role
+}
+
+// list returns roles of the transaction outputs in their current order.
+func (roles outputRoles) list(tx *wire.MsgTx) []OutputRole {
+	list := make([]OutputRole, len(tx.TxOut))
+	for i, output := range tx.TxOut {
+		list[i] = roles[output]
+	}
+
+	return list
+}
+
+// setOutputRoles stores roles in PSBT outputs proprietary fields, empty roles are skipped.
+func setOutputRoles(p *psbt.Packet, roles []OutputRole) {
+	for i, role := range roles {
+		if role == "" || i >= len(p.Outputs) {
+			continue
+		}
+
+		setOutputRole(&p.Outputs[i], role)
+	}
+}
+
+// setOutputRole stores role in PSBT output proprietary field.
+func setOutputRole(output *psbt.POutput, role OutputRole) {
+	output.Unknowns = append(output.Unknowns, &psbt.Unknown{Key: OutputRoleKey(), Value: []byte(role)})
+}
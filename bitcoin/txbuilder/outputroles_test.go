@@ -0,0 +1,97 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/stamps"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestOutputRoles(t *testing.T) {
+	params := txbuilder.BaseBTCTransferParams{
+		TransferSatoshiAmount: big.NewInt(29500),
+		Sender: &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{
+				{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+					Amount:   big.NewInt(850000),
+					Script:   []byte("_bitcoin_transaction_script_"),
+					Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+				},
+			},
+			Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+		},
+		SatoshiPerKVByte:          big.NewInt(5000),
+		RecipientAddress:          "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+		SatoshiCommissionAmount:   big.NewInt(1000),
+		CommissionReceiverAddress: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		result, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params).BuildBTCTransferTx(params)
+		require.NoError(t, err)
+
+		roles, err := txbuilder.ExtractOutputRolesFromPSBT(result.SerializedPSBT)
+		require.NoError(t, err)
+		require.Equal(t, []txbuilder.OutputRole{"", "", ""}, roles)
+	})
+
+	t.Run("follow outputs ordering", func(t *testing.T) {
+		builder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params,
+			txbuilder.WithOutputRoles(), txbuilder.WithOutputOrdering(txbuilder.OutputOrderingBIP69))
+
+		result, err := builder.BuildBTCTransferTx(params)
+		require.NoError(t, err)
+
+		roles, err := txbuilder.ExtractOutputRolesFromPSBT(result.SerializedPSBT)
+		require.NoError(t, err)
+		require.Equal(t, []txbuilder.OutputRole{
+			txbuilder.OutputRoleCommission,
+			txbuilder.OutputRoleRecipient,
+			txbuilder.OutputRoleChange,
+		}, roles)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.EqualValues(t, 1000, p.UnsignedTx.TxOut[0].Value)
+		require.EqualValues(t, 29500, p.UnsignedTx.TxOut[1].Value)
+		require.Equal(t, txbuilder.OutputRoleKey(), p.Outputs[0].Unknowns[0].Key)
+
+		indexes, err := txbuilder.ExtractAddressTypeInputIndexesFromPSBT(result.SerializedPSBT)
+		require.NoError(t, err)
+		require.Equal(t, []int{0}, indexes[txbuilder.PaymentInputsHelpingKey])
+
+		t.Run("stamp outputs", func(t *testing.T) {
+			payload, err := stamps.NewTransfer("KEVIN", "100000").Payload()
+			require.NoError(t, err)
+
+			stamped, err := builder.AttachStampOutputs(txbuilder.AttachStampOutputsParams{
+				SerializedPSBT:    result.SerializedPSBT,
+				Payload:           payload,
+				ChangeOutputIndex: 2,
+				OpReturn:          true,
+			})
+			require.NoError(t, err)
+
+			roles, err := txbuilder.ExtractOutputRolesFromPSBT(stamped.SerializedPSBT)
+			require.NoError(t, err)
+			require.Equal(t, []txbuilder.OutputRole{
+				txbuilder.OutputRoleCommission,
+				txbuilder.OutputRoleRecipient,
+				txbuilder.OutputRoleChange,
+				txbuilder.OutputRoleStamp,
+			}, roles)
+		})
+	})
+}
@@ -0,0 +1,1041 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// INFO: JSON formats below are stable wire formats to persist pending build requests (Base*Params)
+// and their results (Build*Result), e.g. to rebuild the transaction with a fresh fee rate at broadcast time.
+// Amounts are decimal strings, byte fields are hex strings, empty values are omitted.
+
+// runeUTXOJSON defines stable wire format of the bitcoin.RuneUTXO.
+type runeUTXOJSON struct {
+	RuneID string `json:"runeId"`
+	Amount string `json:"amount,omitempty"`
+}
+
+// utxoJSON defines stable wire format of the bitcoin.UTXO.
+type utxoJSON struct {
+	TxHash  string         `json:"txHash"`
+	Index   uint32         `json:"index"`
+	Amount  string         `json:"amount,omitempty"`
+	Script  string         `json:"script,omitempty"`
+	Address string         `json:"address,omitempty"`
+	Runes   []runeUTXOJSON `json:"runes,omitempty"`
+}
+
+// paymentDataJSON defines stable wire format of the PaymentData.
+type paymentDataJSON struct {
+	UTXOs   []utxoJSON `json:"utxos"`
+	Address string     `json:"address"`
+	PubKey  string     `json:"pubKey"`
+}
+
+// runeJSON defines stable wire format of the bitcoin.Rune.
+type runeJSON struct {
+	ID            string `json:"id"`
+	Divisibility  byte   `json:"divisibility,omitempty"`
+	Premine       string `json:"premine,omitempty"`
+	Name          string `json:"name,omitempty"` // rune name numeric value.
+	Spacers       uint32 `json:"spacers,omitempty"`
+	Symbol        rune   `json:"symbol,omitempty"`
+	Turbo         bool   `json:"turbo,omitempty"`
+	MintAmount    string `json:"mintAmount,omitempty"`
+	MintCapAmount string `json:"mintCapAmount,omitempty"`
+	HeightStart   uint64 `json:"heightStart,omitempty"`
+	HeightEnd     uint64 `json:"heightEnd,omitempty"`
+	OffsetStart   uint64 `json:"offsetStart,omitempty"`
+	OffsetEnd     uint64 `json:"offsetEnd,omitempty"`
+}
+
+// runeRecipientJSON defines stable wire format of the RuneRecipient.
+type runeRecipientJSON struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount,omitempty"`
+}
+
+// inscriptionJSON defines stable wire format of the inscriptions.Inscription.
+type inscriptionJSON struct {
+	ID              string   `json:"id,omitempty"`
+	Body            string   `json:"body,omitempty"`
+	ContentEncoding string   `json:"contentEncoding,omitempty"`
+	ContentType     string   `json:"contentType,omitempty"`
+	Delegate        string   `json:"delegate,omitempty"`
+	Metadata        string   `json:"metadata,omitempty"`
+	Metaprotocol    string   `json:"metaprotocol,omitempty"`
+	Parents         []string `json:"parents,omitempty"`
+	Pointer         string   `json:"pointer,omitempty"`
+	Rune            string   `json:"rune,omitempty"` // rune name numeric value.
+	Unbound         bool     `json:"unbound,omitempty"`
+}
+
+// contentRuleJSON defines stable wire format of the inscriptions.ContentRule.
+type contentRuleJSON struct {
+	ContentType string `json:"contentType"`
+	MaxSize     int    `json:"maxSize,omitempty"`
+}
+
+// recoveryLeafJSON defines stable wire format of the inscriptions.RecoveryLeaf.
+type recoveryLeafJSON struct {
+	PubKey string `json:"pubKey"`
+	Delay  uint16 `json:"delay"`
+}
+
+// termsJSON defines stable wire format of the runes.Terms.
+type termsJSON struct {
+	Amount      string  `json:"amount,omitempty"`
+	Cap         string  `json:"cap,omitempty"`
+	HeightStart *uint64 `json:"heightStart,omitempty"`
+	HeightEnd   *uint64 `json:"heightEnd,omitempty"`
+	OffsetStart *uint64 `json:"offsetStart,omitempty"`
+	OffsetEnd   *uint64 `json:"offsetEnd,omitempty"`
+}
+
+// etchingJSON defines stable wire format of the runes.Etching.
+type etchingJSON struct {
+	Divisibility *byte      `json:"divisibility,omitempty"`
+	Premine      string     `json:"premine,omitempty"`
+	Rune         string     `json:"rune,omitempty"` // rune name numeric value.
+	Spacers      *uint32    `json:"spacers,omitempty"`
+	Symbol       *rune      `json:"symbol,omitempty"`
+	Terms        *termsJSON `json:"terms,omitempty"`
+	Turbo        bool       `json:"turbo,omitempty"`
+}
+
+// btcTransferParamsJSON defines stable wire format of the BaseBTCTransferParams.
+type btcTransferParamsJSON struct {
+	Sender                    *paymentDataJSON `json:"sender,omitempty"`
+	FeePayer                  *paymentDataJSON `json:"feePayer,omitempty"`
+	TransferSatoshiAmount     string           `json:"transferSatoshiAmount,omitempty"`
+	SatoshiPerKVByte          string           `json:"satoshiPerKVByte,omitempty"`
+	RecipientAddress          string           `json:"recipientAddress"`
+	SatoshiCommissionAmount   string           `json:"satoshiCommissionAmount,omitempty"`
+	CommissionReceiverAddress string           `json:"commissionReceiverAddress,omitempty"`
+	OpReturnData              []string         `json:"opReturnData,omitempty"`
+	EphemeralAnchor           bool             `json:"ephemeralAnchor,omitempty"`
+}
+
+// runesTransferParamsJSON defines stable wire format of the BaseRunesTransferParams
+// and BaseRunesAndBTCTransferParams.
+type runesTransferParamsJSON struct {
+	RuneID                     string              `json:"runeId"`
+	TransferRuneAmount         string              `json:"transferRuneAmount,omitempty"`
+	BurnRuneAmount             string              `json:"burnRuneAmount,omitempty"`
+	RunesSender                *paymentDataJSON    `json:"runesSender,omitempty"`
+	FeePayer                   *paymentDataJSON    `json:"feePayer,omitempty"`
+	SatoshiPerKVByte           string              `json:"satoshiPerKVByte,omitempty"`
+	RunesRecipientAddress      string              `json:"runesRecipientAddress,omitempty"`
+	SatoshiCommissionAmount    string              `json:"satoshiCommissionAmount,omitempty"`
+	CommissionRecipientAddress string              `json:"commissionRecipientAddress,omitempty"`
+	RuneInfo                   *runeJSON           `json:"runeInfo,omitempty"`
+	RunesRecipients            []runeRecipientJSON `json:"runesRecipients,omitempty"`
+	TransferSatoshiAmount      string              `json:"transferSatoshiAmount,omitempty"` // runes and btc transfer only.
+	BTCRecipientAddress        string              `json:"btcRecipientAddress,omitempty"`   // runes and btc transfer only.
+}
+
+// inscriptionTxParamsJSON defines stable wire format of the BaseInscriptionTxParams.
+type inscriptionTxParamsJSON struct {
+	Sender                    *paymentDataJSON  `json:"sender,omitempty"`
+	SatoshiPerKVByte          string            `json:"satoshiPerKVByte,omitempty"`
+	SatoshiCommissionAmount   string            `json:"satoshiCommissionAmount,omitempty"`
+	CommissionReceiverAddress string            `json:"commissionReceiverAddress,omitempty"`
+	Inscription               *inscriptionJSON  `json:"inscription,omitempty"`
+	InscriptionBasePubKey     string            `json:"inscriptionBasePubKey,omitempty"`
+	PremineSplittingFactor    uint              `json:"premineSplittingFactor,omitempty"`
+	Postage                   string            `json:"postage,omitempty"`
+	ContentRules              []contentRuleJSON `json:"contentRules,omitempty"`
+	Recovery                  *recoveryLeafJSON `json:"recovery,omitempty"`
+	EtchingRune               string            `json:"etchingRune,omitempty"` // rune name numeric value.
+}
+
+// runeEtchTxParamsJSON defines stable wire format of the BaseRuneEtchTxParams.
+type runeEtchTxParamsJSON struct {
+	InscriptionReveal      *paymentDataJSON    `json:"inscriptionReveal,omitempty"`
+	Inscription            *inscriptionJSON    `json:"inscription,omitempty"`
+	Rune                   *etchingJSON        `json:"rune,omitempty"`
+	AdditionalPayments     *paymentDataJSON    `json:"additionalPayments,omitempty"`
+	SatoshiPerKVByte       string              `json:"satoshiPerKVByte,omitempty"`
+	RunesRecipientAddress  string              `json:"runesRecipientAddress,omitempty"`
+	SatoshiChangeAddress   string              `json:"satoshiChangeAddress,omitempty"`
+	PremineSplittingFactor uint                `json:"premineSplittingFactor,omitempty"`
+	PremineDistribution    []runeRecipientJSON `json:"premineDistribution,omitempty"`
+	CurrentBlockHeight     uint64              `json:"currentBlockHeight,omitempty"`
+	RunesPointer           *uint32             `json:"runesPointer,omitempty"`
+	CommitBlockHeight      uint64              `json:"commitBlockHeight,omitempty"`
+	Postage                string              `json:"postage,omitempty"`
+	Recovery               *recoveryLeafJSON   `json:"recovery,omitempty"`
+}
+
+// addressReuseWarningJSON defines stable wire format of the AddressReuseWarning.
+type addressReuseWarningJSON struct {
+	Output  int    `json:"output"`
+	Role    string `json:"role"`
+	Address string `json:"address"`
+}
+
+// buildResultJSON defines stable wire format of the Build*Result, unused fields are omitted.
+type buildResultJSON struct {
+	SerializedPSBT          string                    `json:"psbt"`
+	UsedRuneUTXOs           []utxoJSON                `json:"usedRuneUtxos,omitempty"`
+	UsedBaseUTXOs           []utxoJSON                `json:"usedBaseUtxos,omitempty"`
+	UsedSenderBaseUTXOs     []utxoJSON                `json:"usedSenderBaseUtxos,omitempty"`
+	UsedFeePayerBaseUTXOs   []utxoJSON                `json:"usedFeePayerBaseUtxos,omitempty"`
+	UsedAdditionalBaseUTXOs []utxoJSON                `json:"usedAdditionalBaseUtxos,omitempty"`
+	EstimatedFee            string                    `json:"estimatedFee,omitempty"`
+	AddressReuseWarnings    []addressReuseWarningJSON `json:"addressReuseWarnings,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface.
+// NOTE: SilentPaymentKeys is not persisted and must be set again before the rebuild.
+func (params BaseBTCTransferParams) MarshalJSON() ([]byte, error) {
+	data := btcTransferParamsJSON{
+		Sender:                    paymentDataToJSON(params.Sender),
+		FeePayer:                  paymentDataToJSON(params.FeePayer),
+		TransferSatoshiAmount:     amountToJSON(params.TransferSatoshiAmount),
+		SatoshiPerKVByte:          amountToJSON(params.SatoshiPerKVByte),
+		RecipientAddress:          params.RecipientAddress,
+		SatoshiCommissionAmount:   amountToJSON(params.SatoshiCommissionAmount),
+		CommissionReceiverAddress: params.CommissionReceiverAddress,
+		EphemeralAnchor:           params.EphemeralAnchor,
+	}
+	for _, item := range params.OpReturnData {
+		data.OpReturnData = append(data.OpReturnData, hex.EncodeToString(item))
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (params *BaseBTCTransferParams) UnmarshalJSON(b []byte) (err error) {
+	var data btcTransferParamsJSON
+	if err = json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	parsed := BaseBTCTransferParams{
+		RecipientAddress:          data.RecipientAddress,
+		CommissionReceiverAddress: data.CommissionReceiverAddress,
+		EphemeralAnchor:           data.EphemeralAnchor,
+	}
+	if parsed.Sender, err = paymentDataFromJSON(data.Sender); err != nil {
+		return fmt.Errorf("sender: %w", err)
+	}
+	if parsed.FeePayer, err = paymentDataFromJSON(data.FeePayer); err != nil {
+		return fmt.Errorf("fee payer: %w", err)
+	}
+	if parsed.TransferSatoshiAmount, err = amountFromJSON("transfer satoshi", data.TransferSatoshiAmount); err != nil {
+		return err
+	}
+	if parsed.SatoshiPerKVByte, err = amountFromJSON("fee rate", data.SatoshiPerKVByte); err != nil {
+		return err
+	}
+	if parsed.SatoshiCommissionAmount, err = amountFromJSON("commission", data.SatoshiCommissionAmount); err != nil {
+		return err
+	}
+	for idx, item := range data.OpReturnData {
+		decoded, err := hex.DecodeString(item)
+		if err != nil {
+			return fmt.Errorf("op_return data %d: %w", idx, err)
+		}
+
+		parsed.OpReturnData = append(parsed.OpReturnData, decoded)
+	}
+
+	*params = parsed
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (params BaseRunesTransferParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(runesTransferParamsToJSON(BaseRunesAndBTCTransferParams{BaseRunesTransferParams: params}))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (params *BaseRunesTransferParams) UnmarshalJSON(b []byte) error {
+	var parsed BaseRunesAndBTCTransferParams
+	if err := parsed.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	*params = parsed.BaseRunesTransferParams
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (params BaseRunesAndBTCTransferParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(runesTransferParamsToJSON(params))
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (params *BaseRunesAndBTCTransferParams) UnmarshalJSON(b []byte) (err error) {
+	var data runesTransferParamsJSON
+	if err = json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	parsed := BaseRunesAndBTCTransferParams{
+		BaseRunesTransferParams: BaseRunesTransferParams{
+			RunesRecipientAddress:      data.RunesRecipientAddress,
+			CommissionRecipientAddress: data.CommissionRecipientAddress,
+		},
+		BTCRecipientAddress: data.BTCRecipientAddress,
+	}
+	if parsed.RuneID, err = runes.NewRuneIDFromString(data.RuneID); err != nil {
+		return err
+	}
+	if parsed.TransferRuneAmount, err = amountFromJSON("transfer rune", data.TransferRuneAmount); err != nil {
+		return err
+	}
+	if parsed.BurnRuneAmount, err = amountFromJSON("burn rune", data.BurnRuneAmount); err != nil {
+		return err
+	}
+	if parsed.RunesSender, err = paymentDataFromJSON(data.RunesSender); err != nil {
+		return fmt.Errorf("runes sender: %w", err)
+	}
+	if parsed.FeePayer, err = paymentDataFromJSON(data.FeePayer); err != nil {
+		return fmt.Errorf("fee payer: %w", err)
+	}
+	if parsed.SatoshiPerKVByte, err = amountFromJSON("fee rate", data.SatoshiPerKVByte); err != nil {
+		return err
+	}
+	if parsed.SatoshiCommissionAmount, err = amountFromJSON("commission", data.SatoshiCommissionAmount); err != nil {
+		return err
+	}
+	if parsed.RuneInfo, err = runeFromJSON(data.RuneInfo); err != nil {
+		return fmt.Errorf("rune info: %w", err)
+	}
+	for _, recipient := range data.RunesRecipients {
+		amount, err := amountFromJSON("recipient", recipient.Amount)
+		if err != nil {
+			return err
+		}
+
+		parsed.RunesRecipients = append(parsed.RunesRecipients, RuneRecipient{Address: recipient.Address, Amount: amount})
+	}
+	if parsed.TransferSatoshiAmount, err = amountFromJSON("transfer satoshi", data.TransferSatoshiAmount); err != nil {
+		return err
+	}
+
+	*params = parsed
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (params BaseInscriptionTxParams) MarshalJSON() ([]byte, error) {
+	data := inscriptionTxParamsJSON{
+		Sender:                    paymentDataToJSON(params.Sender),
+		SatoshiPerKVByte:          amountToJSON(params.SatoshiPerKVByte),
+		SatoshiCommissionAmount:   amountToJSON(params.SatoshiCommissionAmount),
+		CommissionReceiverAddress: params.CommissionReceiverAddress,
+		Inscription:               inscriptionToJSON(params.Inscription),
+		InscriptionBasePubKey:     params.InscriptionBasePubKey,
+		PremineSplittingFactor:    params.PremineSplittingFactor,
+		Postage:                   amountToJSON(params.Postage),
+		Recovery:                  recoveryLeafToJSON(params.Recovery),
+		EtchingRune:               runeNameToJSON(params.EtchingRune),
+	}
+	for _, rule := range params.ContentRules {
+		data.ContentRules = append(data.ContentRules, contentRuleJSON{ContentType: rule.ContentType, MaxSize: rule.MaxSize})
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (params *BaseInscriptionTxParams) UnmarshalJSON(b []byte) (err error) {
+	var data inscriptionTxParamsJSON
+	if err = json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	parsed := BaseInscriptionTxParams{
+		CommissionReceiverAddress: data.CommissionReceiverAddress,
+		InscriptionBasePubKey:     data.InscriptionBasePubKey,
+		PremineSplittingFactor:    data.PremineSplittingFactor,
+	}
+	if parsed.Sender, err = paymentDataFromJSON(data.Sender); err != nil {
+		return fmt.Errorf("sender: %w", err)
+	}
+	if parsed.SatoshiPerKVByte, err = amountFromJSON("fee rate", data.SatoshiPerKVByte); err != nil {
+		return err
+	}
+	if parsed.SatoshiCommissionAmount, err = amountFromJSON("commission", data.SatoshiCommissionAmount); err != nil {
+		return err
+	}
+	if parsed.Inscription, err = inscriptionFromJSON(data.Inscription); err != nil {
+		return fmt.Errorf("inscription: %w", err)
+	}
+	if parsed.Postage, err = amountFromJSON("postage", data.Postage); err != nil {
+		return err
+	}
+	for _, rule := range data.ContentRules {
+		parsed.ContentRules = append(parsed.ContentRules, inscriptions.ContentRule{ContentType: rule.ContentType, MaxSize: rule.MaxSize})
+	}
+	if parsed.Recovery, err = recoveryLeafFromJSON(data.Recovery); err != nil {
+		return err
+	}
+	if parsed.EtchingRune, err = runeNameFromJSON(data.EtchingRune); err != nil {
+		return fmt.Errorf("etching rune: %w", err)
+	}
+
+	*params = parsed
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (params BaseRuneEtchTxParams) MarshalJSON() ([]byte, error) {
+	data := runeEtchTxParamsJSON{
+		InscriptionReveal:      paymentDataToJSON(params.InscriptionReveal),
+		Inscription:            inscriptionToJSON(params.Inscription),
+		Rune:                   etchingToJSON(params.Rune),
+		AdditionalPayments:     paymentDataToJSON(params.AdditionalPayments),
+		SatoshiPerKVByte:       amountToJSON(params.SatoshiPerKVByte),
+		RunesRecipientAddress:  params.RunesRecipientAddress,
+		SatoshiChangeAddress:   params.SatoshiChangeAddress,
+		PremineSplittingFactor: params.PremineSplittingFactor,
+		CurrentBlockHeight:     params.CurrentBlockHeight,
+		RunesPointer:           params.RunesPointer,
+		CommitBlockHeight:      params.CommitBlockHeight,
+		Postage:                amountToJSON(params.Postage),
+		Recovery:               recoveryLeafToJSON(params.Recovery),
+	}
+	for _, allocation := range params.PremineDistribution {
+		data.PremineDistribution = append(data.PremineDistribution, runeRecipientJSON{
+			Address: allocation.Address,
+			Amount:  amountToJSON(allocation.Amount),
+		})
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (params *BaseRuneEtchTxParams) UnmarshalJSON(b []byte) (err error) {
+	var data runeEtchTxParamsJSON
+	if err = json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	parsed := BaseRuneEtchTxParams{
+		RunesRecipientAddress:  data.RunesRecipientAddress,
+		SatoshiChangeAddress:   data.SatoshiChangeAddress,
+		PremineSplittingFactor: data.PremineSplittingFactor,
+		CurrentBlockHeight:     data.CurrentBlockHeight,
+		RunesPointer:           data.RunesPointer,
+		CommitBlockHeight:      data.CommitBlockHeight,
+	}
+	if parsed.InscriptionReveal, err = paymentDataFromJSON(data.InscriptionReveal); err != nil {
+		return fmt.Errorf("inscription reveal: %w", err)
+	}
+	if parsed.Inscription, err = inscriptionFromJSON(data.Inscription); err != nil {
+		return fmt.Errorf("inscription: %w", err)
+	}
+	if parsed.Rune, err = etchingFromJSON(data.Rune); err != nil {
+		return fmt.Errorf("rune: %w", err)
+	}
+	if parsed.AdditionalPayments, err = paymentDataFromJSON(data.AdditionalPayments); err != nil {
+		return fmt.Errorf("additional payments: %w", err)
+	}
+	if parsed.SatoshiPerKVByte, err = amountFromJSON("fee rate", data.SatoshiPerKVByte); err != nil {
+		return err
+	}
+	for _, allocation := range data.PremineDistribution {
+		amount, err := amountFromJSON("premine allocation", allocation.Amount)
+		if err != nil {
+			return err
+		}
+
+		parsed.PremineDistribution = append(parsed.PremineDistribution, PremineAllocation{Address: allocation.Address, Amount: amount})
+	}
+	if parsed.Postage, err = amountFromJSON("postage", data.Postage); err != nil {
+		return err
+	}
+	if parsed.Recovery, err = recoveryLeafFromJSON(data.Recovery); err != nil {
+		return err
+	}
+
+	*params = parsed
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (result BuildRunesTransferTxResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(buildResultJSON{
+		SerializedPSBT:       hex.EncodeToString(result.SerializedPSBT),
+		UsedRuneUTXOs:        utxosToJSON(result.UsedRuneUTXOs),
+		UsedBaseUTXOs:        utxosToJSON(result.UsedBaseUTXOs),
+		EstimatedFee:         amountToJSON(result.EstimatedFee),
+		AddressReuseWarnings: warningsToJSON(result.AddressReuseWarnings),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (result *BuildRunesTransferTxResult) UnmarshalJSON(b []byte) (err error) {
+	data, parsed := new(buildResultJSON), BuildRunesTransferTxResult{}
+	if parsed.SerializedPSBT, parsed.EstimatedFee, parsed.AddressReuseWarnings, err = unmarshalBuildResult(b, data); err != nil {
+		return err
+	}
+	if parsed.UsedRuneUTXOs, err = utxosFromJSON(data.UsedRuneUTXOs); err != nil {
+		return fmt.Errorf("used rune utxos: %w", err)
+	}
+	if parsed.UsedBaseUTXOs, err = utxosFromJSON(data.UsedBaseUTXOs); err != nil {
+		return fmt.Errorf("used base utxos: %w", err)
+	}
+
+	*result = parsed
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (result BuildBTCTransferTxResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(buildResultJSON{
+		SerializedPSBT:        hex.EncodeToString(result.SerializedPSBT),
+		UsedSenderBaseUTXOs:   utxosToJSON(result.UsedSenderBaseUTXOs),
+		UsedFeePayerBaseUTXOs: utxosToJSON(result.UsedFeePayerBaseUTXOs),
+		EstimatedFee:          amountToJSON(result.EstimatedFee),
+		AddressReuseWarnings:  warningsToJSON(result.AddressReuseWarnings),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (result *BuildBTCTransferTxResult) UnmarshalJSON(b []byte) (err error) {
+	data, parsed := new(buildResultJSON), BuildBTCTransferTxResult{}
+	if parsed.SerializedPSBT, parsed.EstimatedFee, parsed.AddressReuseWarnings, err = unmarshalBuildResult(b, data); err != nil {
+		return err
+	}
+	if parsed.UsedSenderBaseUTXOs, err = utxosFromJSON(data.UsedSenderBaseUTXOs); err != nil {
+		return fmt.Errorf("used sender base utxos: %w", err)
+	}
+	if parsed.UsedFeePayerBaseUTXOs, err = utxosFromJSON(data.UsedFeePayerBaseUTXOs); err != nil {
+		return fmt.Errorf("used fee payer base utxos: %w", err)
+	}
+
+	*result = parsed
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (result BuildInscriptionTxPSBTResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(buildResultJSON{
+		SerializedPSBT:       hex.EncodeToString(result.SerializedPSBT),
+		UsedBaseUTXOs:        utxosToJSON(result.UsedBaseUTXOs),
+		EstimatedFee:         amountToJSON(result.EstimatedFee),
+		AddressReuseWarnings: warningsToJSON(result.AddressReuseWarnings),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (result *BuildInscriptionTxPSBTResult) UnmarshalJSON(b []byte) (err error) {
+	data, parsed := new(buildResultJSON), BuildInscriptionTxPSBTResult{}
+	if parsed.SerializedPSBT, parsed.EstimatedFee, parsed.AddressReuseWarnings, err = unmarshalBuildResult(b, data); err != nil {
+		return err
+	}
+	if parsed.UsedBaseUTXOs, err = utxosFromJSON(data.UsedBaseUTXOs); err != nil {
+		return fmt.Errorf("used base utxos: %w", err)
+	}
+
+	*result = parsed
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (result BuildRuneEtchTxPSBTResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(buildResultJSON{
+		SerializedPSBT:          hex.EncodeToString(result.SerializedPSBT),
+		UsedAdditionalBaseUTXOs: utxosToJSON(result.UsedAdditionalBaseUTXOs),
+		EstimatedFee:            amountToJSON(result.EstimatedFee),
+		AddressReuseWarnings:    warningsToJSON(result.AddressReuseWarnings),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (result *BuildRuneEtchTxPSBTResult) UnmarshalJSON(b []byte) (err error) {
+	data, parsed := new(buildResultJSON), BuildRuneEtchTxPSBTResult{}
+	if parsed.SerializedPSBT, parsed.EstimatedFee, parsed.AddressReuseWarnings, err = unmarshalBuildResult(b, data); err != nil {
+		return err
+	}
+	if parsed.UsedAdditionalBaseUTXOs, err = utxosFromJSON(data.UsedAdditionalBaseUTXOs); err != nil {
+		return fmt.Errorf("used additional base utxos: %w", err)
+	}
+
+	*result = parsed
+
+	return nil
+}
+
+// unmarshalBuildResult decodes build result into data and returns its common fields.
+func unmarshalBuildResult(b []byte, data *buildResultJSON) (serializedPSBT []byte, fee *big.Int,
+	warnings []AddressReuseWarning, err error) {
+	if err = json.Unmarshal(b, data); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if serializedPSBT, err = hex.DecodeString(data.SerializedPSBT); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", ErrInvalidPSBT, err)
+	}
+	if fee, err = amountFromJSON("estimated fee", data.EstimatedFee); err != nil {
+		return nil, nil, nil, err
+	}
+	for _, warning := range data.AddressReuseWarnings {
+		warnings = append(warnings, AddressReuseWarning{
+			Output:  warning.Output,
+			Role:    OutputRole(warning.Role),
+			Address: warning.Address,
+		})
+	}
+
+	return serializedPSBT, fee, warnings, nil
+}
+
+// runesTransferParamsToJSON converts runes transfer params into the wire format.
+func runesTransferParamsToJSON(params BaseRunesAndBTCTransferParams) runesTransferParamsJSON {
+	data := runesTransferParamsJSON{
+		RuneID:                     params.RuneID.String(),
+		TransferRuneAmount:         amountToJSON(params.TransferRuneAmount),
+		BurnRuneAmount:             amountToJSON(params.BurnRuneAmount),
+		RunesSender:                paymentDataToJSON(params.RunesSender),
+		FeePayer:                   paymentDataToJSON(params.FeePayer),
+		SatoshiPerKVByte:           amountToJSON(params.SatoshiPerKVByte),
+		RunesRecipientAddress:      params.RunesRecipientAddress,
+		SatoshiCommissionAmount:    amountToJSON(params.SatoshiCommissionAmount),
+		CommissionRecipientAddress: params.CommissionRecipientAddress,
+		RuneInfo:                   runeToJSON(params.RuneInfo),
+		TransferSatoshiAmount:      amountToJSON(params.TransferSatoshiAmount),
+		BTCRecipientAddress:        params.BTCRecipientAddress,
+	}
+	for _, recipient := range params.RunesRecipients {
+		data.RunesRecipients = append(data.RunesRecipients, runeRecipientJSON{
+			Address: recipient.Address,
+			Amount:  amountToJSON(recipient.Amount),
+		})
+	}
+
+	return data
+}
+
+// amountToJSON returns amount as decimal string, empty string if amount is not set.
+func amountToJSON(amount *big.Int) string {
+	if amount == nil {
+		return ""
+	}
+
+	return amount.String()
+}
+
+// amountFromJSON parses decimal string amount, nil if amount is empty.
+func amountFromJSON(name, amount string) (*big.Int, error) {
+	if amount == "" {
+		return nil, nil
+	}
+
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s amount: %q", name, amount)
+	}
+
+	return value, nil
+}
+
+// utxoToJSON converts utxo into the wire format.
+func utxoToJSON(utxo *bitcoin.UTXO) utxoJSON {
+	data := utxoJSON{
+		TxHash:  utxo.TxHash(),
+		Index:   utxo.Index,
+		Amount:  amountToJSON(utxo.Amount),
+		Script:  hex.EncodeToString(utxo.Script),
+		Address: utxo.Address,
+	}
+	for _, rune_ := range utxo.Runes {
+		data.Runes = append(data.Runes, runeUTXOJSON{RuneID: rune_.RuneID.String(), Amount: amountToJSON(rune_.Amount)})
+	}
+
+	return data
+}
+
+// utxoFromJSON parses utxo from the wire format.
+func utxoFromJSON(data utxoJSON) (utxo bitcoin.UTXO, err error) {
+	utxo = bitcoin.UTXO{Address: data.Address}
+	if utxo.Outpoint, err = bitcoin.NewOutpoint(data.TxHash, data.Index); err != nil {
+		return utxo, err
+	}
+	if utxo.Amount, err = amountFromJSON("utxo", data.Amount); err != nil {
+		return utxo, err
+	}
+	if data.Script != "" {
+		if utxo.Script, err = hex.DecodeString(data.Script); err != nil {
+			return utxo, fmt.Errorf("invalid utxo script: %w", err)
+		}
+	}
+	for _, rune_ := range data.Runes {
+		runeUTXO := bitcoin.RuneUTXO{}
+		if runeUTXO.RuneID, err = runes.NewRuneIDFromString(rune_.RuneID); err != nil {
+			return utxo, err
+		}
+		if runeUTXO.Amount, err = amountFromJSON("utxo rune", rune_.Amount); err != nil {
+			return utxo, err
+		}
+
+		utxo.Runes = append(utxo.Runes, runeUTXO)
+	}
+
+	return utxo, nil
+}
+
+// utxosToJSON converts utxos into the wire format.
+func utxosToJSON(utxos []*bitcoin.UTXO) []utxoJSON {
+	var data []utxoJSON
+	for _, utxo := range utxos {
+		data = append(data, utxoToJSON(utxo))
+	}
+
+	return data
+}
+
+// utxosFromJSON parses utxos from the wire format.
+func utxosFromJSON(data []utxoJSON) ([]*bitcoin.UTXO, error) {
+	var utxos []*bitcoin.UTXO
+	for idx, item := range data {
+		utxo, err := utxoFromJSON(item)
+		if err != nil {
+			return nil, fmt.Errorf("utxo %d: %w", idx, err)
+		}
+
+		utxos = append(utxos, &utxo)
+	}
+
+	return utxos, nil
+}
+
+// paymentDataToJSON converts payment data into the wire format, nil if payment data is not set.
+func paymentDataToJSON(payment *PaymentData) *paymentDataJSON {
+	if payment == nil {
+		return nil
+	}
+
+	data := &paymentDataJSON{UTXOs: make([]utxoJSON, 0, len(payment.UTXOs)), Address: payment.Address, PubKey: payment.PubKey}
+	for i := range payment.UTXOs {
+		data.UTXOs = append(data.UTXOs, utxoToJSON(&payment.UTXOs[i]))
+	}
+
+	return data
+}
+
+// paymentDataFromJSON parses payment data from the wire format, nil if payment data is not set.
+func paymentDataFromJSON(data *paymentDataJSON) (*PaymentData, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	payment := &PaymentData{UTXOs: make([]bitcoin.UTXO, 0, len(data.UTXOs)), Address: data.Address, PubKey: data.PubKey}
+	for idx, item := range data.UTXOs {
+		utxo, err := utxoFromJSON(item)
+		if err != nil {
+			return nil, fmt.Errorf("utxo %d: %w", idx, err)
+		}
+
+		payment.UTXOs = append(payment.UTXOs, utxo)
+	}
+
+	return payment, nil
+}
+
+// runeToJSON converts rune data into the wire format, nil if rune data is not set.
+func runeToJSON(rune_ *bitcoin.Rune) *runeJSON {
+	if rune_ == nil {
+		return nil
+	}
+
+	return &runeJSON{
+		ID:            rune_.ID.String(),
+		Divisibility:  rune_.Divisibility,
+		Premine:       amountToJSON(rune_.Premine),
+		Name:          amountToJSON(rune_.Name.Value()),
+		Spacers:       rune_.Spacers,
+		Symbol:        rune_.Symbol,
+		Turbo:         rune_.Turbo,
+		MintAmount:    amountToJSON(rune_.MintAmount),
+		MintCapAmount: amountToJSON(rune_.MintCapAmount),
+		HeightStart:   rune_.HeightStart,
+		HeightEnd:     rune_.HeightEnd,
+		OffsetStart:   rune_.OffsetStart,
+		OffsetEnd:     rune_.OffsetEnd,
+	}
+}
+
+// runeFromJSON parses rune data from the wire format, nil if rune data is not set.
+func runeFromJSON(data *runeJSON) (rune_ *bitcoin.Rune, err error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	rune_ = &bitcoin.Rune{
+		Divisibility: data.Divisibility,
+		Spacers:      data.Spacers,
+		Symbol:       data.Symbol,
+		Turbo:        data.Turbo,
+		HeightStart:  data.HeightStart,
+		HeightEnd:    data.HeightEnd,
+		OffsetStart:  data.OffsetStart,
+		OffsetEnd:    data.OffsetEnd,
+	}
+	if rune_.ID, err = runes.NewRuneIDFromString(data.ID); err != nil {
+		return nil, err
+	}
+	if rune_.Premine, err = amountFromJSON("premine", data.Premine); err != nil {
+		return nil, err
+	}
+	if rune_.MintAmount, err = amountFromJSON("mint", data.MintAmount); err != nil {
+		return nil, err
+	}
+	if rune_.MintCapAmount, err = amountFromJSON("mint cap", data.MintCapAmount); err != nil {
+		return nil, err
+	}
+
+	name, err := amountFromJSON("name", data.Name)
+	if err != nil {
+		return nil, err
+	}
+	switch reserved := runes.RuneReserve(rune_.ID); {
+	case name == nil:
+	case numbers.IsEqual(name, reserved.Value()): // INFO: reserved names are not accepted by NewRuneFromNumber.
+		rune_.Name = *reserved
+	default:
+		parsedName, err := runes.NewRuneFromNumber(name)
+		if err != nil {
+			return nil, err
+		}
+
+		rune_.Name = *parsedName
+	}
+
+	return rune_, nil
+}
+
+// runeNameToJSON returns rune name numeric value as decimal string, empty string if rune name is not set.
+func runeNameToJSON(name *runes.Rune) string {
+	if name == nil {
+		return ""
+	}
+
+	return amountToJSON(name.Value())
+}
+
+// runeNameFromJSON parses rune name from numeric value decimal string, nil if it is empty.
+func runeNameFromJSON(data string) (*runes.Rune, error) {
+	value, err := amountFromJSON("rune name", data)
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	return runes.NewRuneFromNumber(value)
+}
+
+// inscriptionToJSON converts inscription into the wire format, nil if inscription is not set.
+func inscriptionToJSON(inscription *inscriptions.Inscription) *inscriptionJSON {
+	if inscription == nil {
+		return nil
+	}
+
+	data := &inscriptionJSON{
+		Body:            hex.EncodeToString(inscription.Body),
+		ContentEncoding: inscription.ContentEncoding,
+		ContentType:     inscription.ContentType,
+		Metadata:        hex.EncodeToString(inscription.Metadata),
+		Metaprotocol:    hex.EncodeToString(inscription.Metaprotocol),
+		Pointer:         amountToJSON(inscription.Pointer),
+		Rune:            runeNameToJSON(inscription.Rune),
+		Unbound:         inscription.Unbound,
+	}
+	if inscription.ID.TxID != nil {
+		data.ID = inscription.ID.String()
+	}
+	if inscription.Delegate != nil {
+		data.Delegate = inscription.Delegate.String()
+	}
+	for _, parent := range inscription.Parents {
+		data.Parents = append(data.Parents, parent.String())
+	}
+
+	return data
+}
+
+// inscriptionFromJSON parses inscription from the wire format, nil if inscription is not set.
+func inscriptionFromJSON(data *inscriptionJSON) (inscription *inscriptions.Inscription, err error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	inscription = &inscriptions.Inscription{
+		ContentEncoding: data.ContentEncoding,
+		ContentType:     data.ContentType,
+		Unbound:         data.Unbound,
+	}
+	if data.ID != "" {
+		id, err := inscriptions.NewIDFromString(data.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		inscription.ID = *id
+	}
+	if inscription.Body, err = bytesFromJSON("body", data.Body); err != nil {
+		return nil, err
+	}
+	if data.Delegate != "" {
+		if inscription.Delegate, err = inscriptions.NewIDFromString(data.Delegate); err != nil {
+			return nil, fmt.Errorf("delegate: %w", err)
+		}
+	}
+	if inscription.Metadata, err = bytesFromJSON("metadata", data.Metadata); err != nil {
+		return nil, err
+	}
+	if inscription.Metaprotocol, err = bytesFromJSON("metaprotocol", data.Metaprotocol); err != nil {
+		return nil, err
+	}
+	for idx, parent := range data.Parents {
+		id, err := inscriptions.NewIDFromString(parent)
+		if err != nil {
+			return nil, fmt.Errorf("parent %d: %w", idx, err)
+		}
+
+		inscription.Parents = append(inscription.Parents, id)
+	}
+	if inscription.Pointer, err = amountFromJSON("pointer", data.Pointer); err != nil {
+		return nil, err
+	}
+	if inscription.Rune, err = runeNameFromJSON(data.Rune); err != nil {
+		return nil, fmt.Errorf("rune: %w", err)
+	}
+
+	return inscription, nil
+}
+
+// recoveryLeafToJSON converts recovery leaf into the wire format, nil if recovery leaf is not set.
+func recoveryLeafToJSON(leaf *inscriptions.RecoveryLeaf) *recoveryLeafJSON {
+	if leaf == nil {
+		return nil
+	}
+
+	return &recoveryLeafJSON{PubKey: hex.EncodeToString(leaf.PubKey), Delay: leaf.Delay}
+}
+
+// recoveryLeafFromJSON parses recovery leaf from the wire format, nil if recovery leaf is not set.
+func recoveryLeafFromJSON(data *recoveryLeafJSON) (*inscriptions.RecoveryLeaf, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	pubKey, err := bytesFromJSON("recovery public key", data.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inscriptions.RecoveryLeaf{PubKey: pubKey, Delay: data.Delay}, nil
+}
+
+// etchingToJSON converts rune etching into the wire format, nil if etching is not set.
+func etchingToJSON(etching *runes.Etching) *etchingJSON {
+	if etching == nil {
+		return nil
+	}
+
+	data := &etchingJSON{
+		Divisibility: etching.Divisibility,
+		Premine:      amountToJSON(etching.Premine),
+		Rune:         runeNameToJSON(etching.Rune),
+		Spacers:      etching.Spacers,
+		Symbol:       etching.Symbol,
+		Turbo:        etching.Turbo,
+	}
+	if terms := etching.Terms; terms != nil {
+		data.Terms = &termsJSON{
+			Amount:      amountToJSON(terms.Amount),
+			Cap:         amountToJSON(terms.Cap),
+			HeightStart: terms.HeightStart,
+			HeightEnd:   terms.HeightEnd,
+			OffsetStart: terms.OffsetStart,
+			OffsetEnd:   terms.OffsetEnd,
+		}
+	}
+
+	return data
+}
+
+// etchingFromJSON parses rune etching from the wire format, nil if etching is not set.
+func etchingFromJSON(data *etchingJSON) (etching *runes.Etching, err error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	etching = &runes.Etching{
+		Divisibility: data.Divisibility,
+		Spacers:      data.Spacers,
+		Symbol:       data.Symbol,
+		Turbo:        data.Turbo,
+	}
+	if etching.Premine, err = amountFromJSON("premine", data.Premine); err != nil {
+		return nil, err
+	}
+	if etching.Rune, err = runeNameFromJSON(data.Rune); err != nil {
+		return nil, err
+	}
+	if data.Terms != nil {
+		etching.Terms = &runes.Terms{
+			HeightStart: data.Terms.HeightStart,
+			HeightEnd:   data.Terms.HeightEnd,
+			OffsetStart: data.Terms.OffsetStart,
+			OffsetEnd:   data.Terms.OffsetEnd,
+		}
+		if etching.Terms.Amount, err = amountFromJSON("mint", data.Terms.Amount); err != nil {
+			return nil, err
+		}
+		if etching.Terms.Cap, err = amountFromJSON("mint cap", data.Terms.Cap); err != nil {
+			return nil, err
+		}
+	}
+
+	return etching, nil
+}
+
+// bytesFromJSON parses hex encoded bytes, nil if data is empty.
+func bytesFromJSON(name, data string) ([]byte, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	decoded, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return decoded, nil
+}
+
+// warningsToJSON converts address reuse warnings into the wire format.
+func warningsToJSON(warnings []AddressReuseWarning) []addressReuseWarningJSON {
+	var data []addressReuseWarningJSON
+	for _, warning := range warnings {
+		data = append(data, addressReuseWarningJSON{Output: warning.Output, Role: string(warning.Role), Address: warning.Address})
+	}
+
+	return data
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestPersistence(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	sender := &txbuilder.PaymentData{
+		UTXOs:   []bitcoin.UTXO{wallet.UTXO("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 1, 100000)},
+		Address: wallet.Address,
+		PubKey:  wallet.PubKey,
+	}
+	btcParams := txbuilder.BaseBTCTransferParams{
+		Sender:                sender,
+		TransferSatoshiAmount: big.NewInt(10000),
+		SatoshiPerKVByte:      big.NewInt(10000), // 10 sat/vB.
+		RecipientAddress:      wallet.Address,
+		OpReturnData:          [][]byte{{0xAB, 0xCD}},
+	}
+
+	t.Run("btc transfer params", func(t *testing.T) {
+		data, err := json.Marshal(btcParams)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"script":"`+hex.EncodeToString(wallet.Script)+`"`)
+		require.Contains(t, string(data), `"satoshiPerKVByte":"10000"`)
+		require.Contains(t, string(data), `"opReturnData":["abcd"]`)
+
+		var parsed txbuilder.BaseBTCTransferParams
+		require.NoError(t, json.Unmarshal(data, &parsed))
+		require.Equal(t, btcParams, parsed)
+	})
+
+	t.Run("rebuild with fresh fee rate", func(t *testing.T) {
+		builder := txbuilder.NewTxBuilder(networkParams)
+		result, err := builder.BuildBTCTransferTx(btcParams)
+		require.NoError(t, err)
+
+		data, err := json.Marshal(result)
+		require.NoError(t, err)
+
+		var parsedResult txbuilder.BuildBTCTransferTxResult
+		require.NoError(t, json.Unmarshal(data, &parsedResult))
+		require.Equal(t, result, parsedResult)
+
+		data, err = json.Marshal(btcParams)
+		require.NoError(t, err)
+
+		var stored txbuilder.BaseBTCTransferParams
+		require.NoError(t, json.Unmarshal(data, &stored))
+		rebuilt, err := builder.BuildBTCTransferTx(stored)
+		require.NoError(t, err)
+		require.Equal(t, result.SerializedPSBT, rebuilt.SerializedPSBT)
+
+		stored.SatoshiPerKVByte = big.NewInt(20000)
+		rebuilt, err = builder.BuildBTCTransferTx(stored)
+		require.NoError(t, err)
+		require.Greater(t, rebuilt.EstimatedFee.Int64(), result.EstimatedFee.Int64())
+	})
+
+	t.Run("runes transfer params", func(t *testing.T) {
+		runeID := runes.RuneID{Block: 2584592, TxID: 58}
+		name, err := runes.NewRuneFromString("HELLO")
+		require.NoError(t, err)
+
+		runesSender := &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{{
+				Outpoint: bitcoin.Outpoint{Hash: sender.UTXOs[0].Hash},
+				Amount:   big.NewInt(546),
+				Script:   wallet.Script,
+				Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(1000)}},
+			}},
+			Address: sender.Address,
+			PubKey:  sender.PubKey,
+		}
+		params := txbuilder.BaseRunesAndBTCTransferParams{
+			BaseRunesTransferParams: txbuilder.BaseRunesTransferParams{
+				RuneID:             runeID,
+				TransferRuneAmount: big.NewInt(300),
+				RunesSender:        runesSender,
+				FeePayer:           sender,
+				SatoshiPerKVByte:   big.NewInt(10000),
+				RuneInfo: &bitcoin.Rune{
+					ID:         runeID,
+					Premine:    big.NewInt(1000),
+					Name:       *name,
+					Symbol:     'H',
+					MintAmount: big.NewInt(10),
+				},
+				RunesRecipients: []txbuilder.RuneRecipient{
+					{Address: wallet.Address, Amount: big.NewInt(100)},
+					{Address: wallet.Address, Amount: big.NewInt(200)},
+				},
+			},
+			TransferSatoshiAmount: big.NewInt(5000),
+			BTCRecipientAddress:   wallet.Address,
+		}
+
+		data, err := json.Marshal(params)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"runeId":"2584592:58"`)
+
+		var parsed txbuilder.BaseRunesAndBTCTransferParams
+		require.NoError(t, json.Unmarshal(data, &parsed))
+		require.Equal(t, params, parsed)
+
+		data, err = json.Marshal(params.BaseRunesTransferParams)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "btcRecipientAddress")
+
+		var parsedRunes txbuilder.BaseRunesTransferParams
+		require.NoError(t, json.Unmarshal(data, &parsedRunes))
+		require.Equal(t, params.BaseRunesTransferParams, parsedRunes)
+	})
+
+	t.Run("silent payment keys", func(t *testing.T) {
+		params := btcParams
+		params.SilentPaymentKeys = func(utxo *bitcoin.UTXO) (*btcec.PrivateKey, error) {
+			t.Fatal("silent payment keys are resolved")
+			return nil, nil
+		}
+
+		data, err := json.Marshal(params)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "silentPaymentKeys")
+		require.NotContains(t, string(data), hex.EncodeToString(wallet.PrivateKey.Serialize()))
+
+		var parsed txbuilder.BaseBTCTransferParams
+		require.NoError(t, json.Unmarshal(data, &parsed))
+		require.Nil(t, parsed.SilentPaymentKeys)
+	})
+
+	parentID, err := inscriptions.NewIDFromString("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746i0")
+	require.NoError(t, err)
+	runeName, err := runes.NewRuneFromString("HELLOWORLD")
+	require.NoError(t, err)
+	recovery := &inscriptions.RecoveryLeaf{PubKey: schnorr.SerializePubKey(wallet.PrivateKey.PubKey()), Delay: 144}
+
+	t.Run("inscription params", func(t *testing.T) {
+		params := txbuilder.BaseInscriptionTxParams{
+			Sender:                    sender,
+			SatoshiPerKVByte:          big.NewInt(10000),
+			SatoshiCommissionAmount:   big.NewInt(1000),
+			CommissionReceiverAddress: wallet.Address,
+			Inscription: &inscriptions.Inscription{
+				Body:         []byte("hello"),
+				ContentType:  "text/plain",
+				Delegate:     parentID,
+				Metadata:     []byte{0xA0},
+				Metaprotocol: []byte("brc-20"),
+				Parents:      []*inscriptions.ID{parentID},
+				Pointer:      big.NewInt(546),
+				Rune:         runeName,
+			},
+			InscriptionBasePubKey: wallet.PubKey,
+			Postage:               big.NewInt(1000),
+			ContentRules:          []inscriptions.ContentRule{{ContentType: "text/plain", MaxSize: 1024}},
+			Recovery:              recovery,
+			EtchingRune:           runeName,
+		}
+
+		data, err := json.Marshal(params)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"body":"68656c6c6f"`)
+		require.Contains(t, string(data), `"parents":["`+parentID.String()+`"]`)
+
+		var parsed txbuilder.BaseInscriptionTxParams
+		require.NoError(t, json.Unmarshal(data, &parsed))
+		require.Equal(t, params, parsed)
+	})
+
+	t.Run("rune etch params", func(t *testing.T) {
+		divisibility, spacers, symbol := byte(2), uint32(1), 'H'
+		heightEnd, pointer := uint64(2600000), uint32(1)
+		params := txbuilder.BaseRuneEtchTxParams{
+			InscriptionReveal: sender,
+			Inscription:       &inscriptions.Inscription{Body: []byte("hello"), ContentType: "text/plain", Rune: runeName},
+			Rune: &runes.Etching{
+				Divisibility: &divisibility,
+				Premine:      big.NewInt(1000),
+				Rune:         runeName,
+				Spacers:      &spacers,
+				Symbol:       &symbol,
+				Terms:        &runes.Terms{Amount: big.NewInt(10), Cap: big.NewInt(100), HeightEnd: &heightEnd},
+				Turbo:        true,
+			},
+			SatoshiPerKVByte:      big.NewInt(10000),
+			RunesRecipientAddress: wallet.Address,
+			SatoshiChangeAddress:  wallet.Address,
+			PremineDistribution: []txbuilder.PremineAllocation{
+				{Address: wallet.Address, Amount: big.NewInt(400)},
+				{Address: wallet.Address, Amount: big.NewInt(600)},
+			},
+			CurrentBlockHeight: 2584592,
+			RunesPointer:       &pointer,
+			CommitBlockHeight:  2584590,
+			Postage:            big.NewInt(546),
+			Recovery:           recovery,
+		}
+
+		data, err := json.Marshal(params)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"premine":"1000"`)
+
+		var parsed txbuilder.BaseRuneEtchTxParams
+		require.NoError(t, json.Unmarshal(data, &parsed))
+		require.Equal(t, params, parsed)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var params txbuilder.BaseBTCTransferParams
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"satoshiPerKVByte":"10 sat"}`), &params), "invalid fee rate amount")
+		require.Error(t, json.Unmarshal([]byte(`{"sender":{"utxos":[{"script":"zz"}]}}`), &params))
+
+		var result txbuilder.BuildRuneEtchTxPSBTResult
+		require.ErrorIs(t, json.Unmarshal([]byte(`{"psbt":"zz"}`), &result), txbuilder.ErrInvalidPSBT)
+
+		var etchParams txbuilder.BaseRuneEtchTxParams
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"rune":{"terms":{"cap":"x"}}}`), &etchParams), "invalid mint cap amount")
+		require.Error(t, json.Unmarshal([]byte(`{"inscription":{"parents":["zz"]}}`), &etchParams))
+	})
+}
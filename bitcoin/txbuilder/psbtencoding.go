@@ -0,0 +1,198 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+)
+
+// ErrInvalidPSBT describes that encoded data is not a valid PSBT.
+var ErrInvalidPSBT = errors.New("invalid psbt")
+
+// PSBTFromBase64 decodes base64 encoded PSBT, e.g. signed by the wallet, into serialized PSBT
+// accepted by the builders and the signer.
+func PSBTFromBase64(encoded string) ([]byte, error) {
+	serialized, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPSBT, err)
+	}
+
+	return serialized, validatePSBT(serialized)
+}
+
+// PSBTFromHex decodes hex encoded PSBT, e.g. returned by bitcoind RPC, into serialized PSBT
+// accepted by the builders and the signer.
+func PSBTFromHex(encoded string) ([]byte, error) {
+	serialized, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPSBT, err)
+	}
+
+	return serialized, validatePSBT(serialized)
+}
+
+// validatePSBT returns ErrInvalidPSBT if serialized data can not be parsed as PSBT.
+func validatePSBT(serialized []byte) error {
+	if _, err := psbt.NewFromRawBytes(bytes.NewReader(serialized), false); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPSBT, err)
+	}
+
+	return nil
+}
+
+// psbtBase64 returns base64 encoded PSBT, empty string if PSBT is not set.
+func psbtBase64(serialized []byte) string {
+	return base64.StdEncoding.EncodeToString(serialized)
+}
+
+// psbtHex returns hex encoded PSBT, empty string if PSBT is not set.
+func psbtHex(serialized []byte) string {
+	return hex.EncodeToString(serialized)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildRunesTransferTxResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildRunesTransferTxResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildBTCTransferTxResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildBTCTransferTxResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildInscriptionTxPSBTResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildInscriptionTxPSBTResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildRuneEtchTxPSBTResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildRuneEtchTxPSBTResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildRuneMintTxResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildRuneMintTxResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildChannelFundingTxResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildChannelFundingTxResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result AttachStampOutputsResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result AttachStampOutputsResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT, empty string if fee is not corrected.
+func (result CorrectFeeResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT, empty string if fee is not corrected.
+func (result CorrectFeeResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT, empty string if chain is not bumped.
+func (result BuildChainBumpTxResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT, empty string if chain is not bumped.
+func (result BuildChainBumpTxResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildCommitSweepTxResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildCommitSweepTxResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildRuneSellOfferResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildRuneSellOfferResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildRuneOfferAcceptTxResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildRuneOfferAcceptTxResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildAnchorSpendTxResult) PSBTBase64() string {
+	return psbtBase64(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildAnchorSpendTxResult) PSBTHex() string {
+	return psbtHex(result.SerializedPSBT)
+}
+
+// PSBTBase64 returns base64 encoded display SerializedPSBT.
+func (display DisplayPSBT) PSBTBase64() string {
+	return psbtBase64(display.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded display SerializedPSBT.
+func (display DisplayPSBT) PSBTHex() string {
+	return psbtHex(display.SerializedPSBT)
+}
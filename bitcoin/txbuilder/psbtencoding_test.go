@@ -0,0 +1,76 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestPSBTEncoding(t *testing.T) {
+	wallet := txbuildertest.NewWallet(t, &chaincfg.TestNet3Params)
+
+	result, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params).BuildBTCTransferTx(txbuilder.BaseBTCTransferParams{
+		TransferSatoshiAmount: big.NewInt(29500),
+		Sender: &txbuilder.PaymentData{
+			UTXOs:   []bitcoin.UTXO{wallet.UTXO("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2, 850000)},
+			Address: wallet.Address,
+			PubKey:  wallet.PubKey,
+		},
+		SatoshiPerKVByte: big.NewInt(5000),
+		RecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+	})
+	require.NoError(t, err)
+
+	t.Run("base64", func(t *testing.T) {
+		encoded := result.PSBTBase64()
+		require.Equal(t, base64.StdEncoding.EncodeToString(result.SerializedPSBT), encoded)
+
+		decoded, err := txbuilder.PSBTFromBase64(encoded)
+		require.NoError(t, err)
+		require.Equal(t, result.SerializedPSBT, decoded)
+	})
+
+	t.Run("hex", func(t *testing.T) {
+		encoded := result.PSBTHex()
+		require.Equal(t, hex.EncodeToString(result.SerializedPSBT), encoded)
+
+		decoded, err := txbuilder.PSBTFromHex(encoded)
+		require.NoError(t, err)
+		require.Equal(t, result.SerializedPSBT, decoded)
+	})
+
+	t.Run("display", func(t *testing.T) {
+		display, err := txbuilder.RedactPSBT(result.SerializedPSBT)
+		require.NoError(t, err)
+
+		decoded, err := txbuilder.PSBTFromBase64(display.PSBTBase64())
+		require.NoError(t, err)
+		require.Equal(t, display.SerializedPSBT, decoded)
+		require.Equal(t, hex.EncodeToString(display.SerializedPSBT), display.PSBTHex())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := txbuilder.PSBTFromBase64("not base64")
+		require.ErrorIs(t, err, txbuilder.ErrInvalidPSBT)
+
+		_, err = txbuilder.PSBTFromHex(hex.EncodeToString([]byte("not psbt")))
+		require.ErrorIs(t, err, txbuilder.ErrInvalidPSBT)
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		require.Empty(t, txbuilder.CorrectFeeResult{}.PSBTBase64())
+		require.Empty(t, txbuilder.BuildChainBumpTxResult{}.PSBTHex())
+		require.Empty(t, txbuilder.BuildCommitSweepTxResult{}.PSBTBase64())
+	})
+}
@@ -6,6 +6,7 @@ package txbuilder
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
@@ -14,8 +15,14 @@ import (
 	"github.com/btcsuite/btcd/txscript"
 )
 
-// ErrPSBTInputBuilder defines errors class for prepare address data method.
-var ErrPSBTInputBuilder = errors.New("prepare address data")
+var (
+	// ErrPSBTInputBuilder defines errors class for prepare address data method.
+	ErrPSBTInputBuilder = errors.New("prepare address data")
+	// ErrUnsupportedAddressType defines that address type is not supported for psbt input.
+	ErrUnsupportedAddressType = errors.New("unsupported address type")
+	// ErrInvalidPubKey defines that public key is not suitable for the address type.
+	ErrInvalidPubKey = errors.New("invalid public key")
+)
 
 const (
 	// P2PK defines P2PK (public key) script type over which the address is built.
@@ -69,7 +76,7 @@ func NewPSBTInputBuilder(pubKey, address string, networkParams *chaincfg.Params)
 		pib.xOnlyPubKey = pib.publicKeyBytes
 	}
 
-	pib.address, err = btcutil.DecodeAddress(address, pib.params)
+	pib.address, err = DecodeAddress(address, pib.params)
 	if err != nil {
 		return pib, err
 	}
@@ -88,13 +95,17 @@ func NewPSBTInputBuilder(pubKey, address string, networkParams *chaincfg.Params)
 	case *btcutil.AddressScriptHash:
 		pib.scriptType = P2SH
 	default:
-		return pib, btcutil.ErrUnknownAddressType
+		return pib, fmt.Errorf("%w: %T: %w", ErrUnsupportedAddressType, pib.address, btcutil.ErrUnknownAddressType)
 	}
 
 	switch pib.scriptType {
 	case P2PK, P2PKH:
 		pib.redeemScript, err = txscript.PayToAddrScript(pib.address)
 	case P2SH:
+		if pib.publicKey == nil {
+			return pib, fmt.Errorf("%w: compressed public key is required for %s", ErrInvalidPubKey, P2SH)
+		}
+
 		var nested *btcutil.AddressWitnessPubKeyHash
 		nested, err = btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(pib.publicKey.SerializeCompressed()), pib.params)
 		if err != nil {
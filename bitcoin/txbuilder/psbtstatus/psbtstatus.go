@@ -0,0 +1,231 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package psbtstatus reports signing progress of the PSBT inputs, so services orchestrating
+// many signers know when the PSBT is ready to finalize.
+package psbtstatus
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+// State defines signing state of the PSBT input.
+type State int
+
+const (
+	// StateUnsigned defines input without signatures.
+	StateUnsigned State = iota
+	// StatePartiallySigned defines input with some, but not all required signatures.
+	StatePartiallySigned
+	// StateSigned defines input with all required signatures, ready to finalize.
+	StateSigned
+	// StateFinalized defines input with final script witness or script sig.
+	StateFinalized
+)
+
+// String returns state description.
+func (state State) String() string {
+	switch state {
+	case StateUnsigned:
+		return "unsigned"
+	case StatePartiallySigned:
+		return "partially signed"
+	case StateSigned:
+		return "signed"
+	case StateFinalized:
+		return "finalized"
+	default:
+		return fmt.Sprintf("unknown state %d", int(state))
+	}
+}
+
+// InputStatus describes signing progress of the PSBT input.
+type InputStatus struct {
+	State      State
+	Signatures int // collected signatures count.
+	// Required is signatures count needed to finalize the input, 0 if it can not be determined
+	// by the spent script, such input is never reported as signed.
+	Required int
+}
+
+// String returns input status description, e.g. "partially signed (1 of 2)".
+func (status InputStatus) String() string {
+	if status.State == StateFinalized || status.Required == 0 {
+		return status.State.String()
+	}
+
+	return fmt.Sprintf("%s (%d of %d)", status.State.String(), status.Signatures, status.Required)
+}
+
+// Status describes signing progress of the PSBT.
+type Status struct {
+	Inputs []InputStatus // in the transaction inputs order.
+}
+
+// ReadyToFinalize returns true if every input is signed or already finalized.
+func (status *Status) ReadyToFinalize() bool {
+	for _, input := range status.Inputs {
+		if input.State != StateSigned && input.State != StateFinalized {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Finalized returns true if every input is finalized.
+func (status *Status) Finalized() bool {
+	for _, input := range status.Inputs {
+		if input.State != StateFinalized {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Inspect returns signing progress of the serialized PSBT inputs. Required signatures count is
+// determined by the spent output script: P2TR key path or the tapscript leaf with CHECKSIG,
+// CHECKSIGVERIFY and CHECKSIGADD multisig, P2WPKH, P2PKH, P2PK, nested P2WPKH and P2WSH/P2SH multisig.
+// Taproot script path inputs are reported by the leaf closest to completion.
+// NOTE: Signatures are counted, but not verified.
+func Inspect(serializedPSBT []byte) (status Status, _ error) {
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+	if err != nil {
+		return status, err
+	}
+
+	status.Inputs = make([]InputStatus, len(p.Inputs))
+	for i := range p.Inputs {
+		status.Inputs[i] = inspectInput(p, i)
+	}
+
+	return status, nil
+}
+
+// inspectInput returns signing progress of the PSBT input.
+func inspectInput(p *psbt.Packet, index int) InputStatus {
+	input := &p.Inputs[index]
+	if len(input.FinalScriptWitness) != 0 || len(input.FinalScriptSig) != 0 {
+		return InputStatus{State: StateFinalized}
+	}
+
+	var status InputStatus
+	utxo := bitcoin.UTXO{Script: prevOutScript(p, index)}
+	switch utxo.ScriptType() {
+	case bitcoin.ScriptTypeP2TR:
+		status = taprootStatus(input)
+	case bitcoin.ScriptTypeP2WPKH, bitcoin.ScriptTypeP2PKH, bitcoin.ScriptTypeP2PK:
+		status = InputStatus{Signatures: len(input.PartialSigs), Required: 1}
+	case bitcoin.ScriptTypeP2SH:
+		switch {
+		case len(input.WitnessScript) != 0:
+			status = multisigStatus(input, input.WitnessScript)
+		case txscript.IsPayToWitnessPubKeyHash(input.RedeemScript):
+			status = InputStatus{Signatures: len(input.PartialSigs), Required: 1}
+		default:
+			status = multisigStatus(input, input.RedeemScript)
+		}
+	case bitcoin.ScriptTypeP2WSH:
+		status = multisigStatus(input, input.WitnessScript)
+	default:
+		status = InputStatus{Signatures: len(input.PartialSigs)}
+	}
+
+	switch {
+	case status.Signatures == 0:
+		status.State = StateUnsigned
+	case status.Required != 0 && status.Signatures >= status.Required:
+		status.State = StateSigned
+	default:
+		status.State = StatePartiallySigned
+	}
+
+	return status
+}
+
+// prevOutScript returns script pub key of the output spent by the input, nil if unknown.
+func prevOutScript(p *psbt.Packet, index int) []byte {
+	input := &p.Inputs[index]
+	switch {
+	case input.WitnessUtxo != nil:
+		return input.WitnessUtxo.PkScript
+	case input.NonWitnessUtxo != nil:
+		prevIndex := p.UnsignedTx.TxIn[index].PreviousOutPoint.Index
+		if int(prevIndex) < len(input.NonWitnessUtxo.TxOut) {
+			return input.NonWitnessUtxo.TxOut[prevIndex].PkScript
+		}
+	}
+
+	return nil
+}
+
+// multisigStatus returns signing progress of the input spending CHECKMULTISIG script.
+func multisigStatus(input *psbt.PInput, script []byte) InputStatus {
+	status := InputStatus{Signatures: len(input.PartialSigs)}
+	if _, required, err := txscript.CalcMultiSigStats(script); err == nil {
+		status.Required = required
+	}
+
+	return status
+}
+
+// taprootStatus returns signing progress of the taproot input, key path signature completes the input,
+// otherwise the leaf closest to completion is reported.
+func taprootStatus(input *psbt.PInput) InputStatus {
+	if len(input.TaprootKeySpendSig) != 0 {
+		return InputStatus{Signatures: 1, Required: 1}
+	}
+
+	var best *InputStatus
+	for _, leaf := range input.TaprootLeafScript {
+		leafHash := txscript.NewBaseTapLeaf(leaf.Script).TapHash()
+		// INFO: required signatures count is unknown for the scripts which can not be parsed.
+		script, _ := utils.ParseLeafScript(leaf.Script)
+
+		status := InputStatus{Required: script.RequiredSignatures()}
+		for _, key := range script.Keys {
+			for _, sig := range input.TaprootScriptSpendSig {
+				if bytes.Equal(sig.LeafHash, leafHash[:]) && bytes.Equal(sig.XOnlyPubKey, key.XOnlyPubKey) {
+					status.Signatures++
+
+					break
+				}
+			}
+		}
+
+		if best == nil || closerToCompletion(status, *best) {
+			best = &status
+		}
+	}
+	if best != nil {
+		return *best
+	}
+
+	if len(input.TaprootScriptSpendSig) != 0 {
+		// INFO: script path signatures without leaf scripts, required count is unknown.
+		return InputStatus{Signatures: len(input.TaprootScriptSpendSig)}
+	}
+
+	return InputStatus{Required: 1}
+}
+
+// closerToCompletion returns true if status a needs less signatures to complete than status b,
+// statuses with unknown required signatures count are the last.
+func closerToCompletion(a, b InputStatus) bool {
+	switch {
+	case a.Required == 0:
+		return false
+	case b.Required == 0:
+		return true
+	}
+
+	return max(a.Required-a.Signatures, 0) < max(b.Required-b.Signatures, 0)
+}
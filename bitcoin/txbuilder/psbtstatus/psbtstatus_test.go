@@ -0,0 +1,248 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package psbtstatus_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/psbtstatus"
+)
+
+func TestInspect(t *testing.T) {
+	keys := make([]*btcec.PrivateKey, 3)
+	for i := range keys {
+		var err error
+		keys[i], err = btcec.NewPrivateKey()
+		require.NoError(t, err)
+	}
+	xOnly := func(i int) []byte { return schnorr.SerializePubKey(keys[i].PubKey()) }
+
+	p2trScript, err := txscript.PayToTaprootScript(txscript.ComputeTaprootKeyNoScript(keys[0].PubKey()))
+	require.NoError(t, err)
+
+	// INFO: 2 of 3 tapscript multisig.
+	checkSigAdd, err := txscript.NewScriptBuilder().
+		AddData(xOnly(0)).AddOp(txscript.OP_CHECKSIG).
+		AddData(xOnly(1)).AddOp(txscript.OP_CHECKSIGADD).
+		AddData(xOnly(2)).AddOp(txscript.OP_CHECKSIGADD).
+		AddInt64(2).AddOp(txscript.OP_NUMEQUAL).Script()
+	require.NoError(t, err)
+
+	// INFO: 2 of 2 tapscript multisig.
+	checkSigVerify, err := txscript.NewScriptBuilder().
+		AddData(xOnly(0)).AddOp(txscript.OP_CHECKSIGVERIFY).
+		AddData(xOnly(1)).AddOp(txscript.OP_CHECKSIG).Script()
+	require.NoError(t, err)
+
+	// INFO: inscription envelope leaf.
+	envelope, err := txscript.NewScriptBuilder().
+		AddData(xOnly(0)).AddOp(txscript.OP_CHECKSIG).
+		AddOp(txscript.OP_FALSE).AddOp(txscript.OP_IF).AddData([]byte("ord")).AddOp(txscript.OP_ENDIF).Script()
+	require.NoError(t, err)
+
+	// INFO: recovery leaf.
+	recovery, err := txscript.NewScriptBuilder().
+		AddInt64(144).AddOp(txscript.OP_CHECKSEQUENCEVERIFY).AddOp(txscript.OP_DROP).
+		AddData(xOnly(2)).AddOp(txscript.OP_CHECKSIG).Script()
+	require.NoError(t, err)
+
+	leaf := func(script []byte) *psbt.TaprootTapLeafScript {
+		// INFO: control blocks must differ, leaf hash is used as the merkle path.
+		leafHash := txscript.NewBaseTapLeaf(script).TapHash()
+		controlBlock := append(append([]byte{byte(txscript.BaseLeafVersion)}, xOnly(0)...), leafHash[:]...)
+
+		return &psbt.TaprootTapLeafScript{ControlBlock: controlBlock, Script: script, LeafVersion: txscript.BaseLeafVersion}
+	}
+	scriptSig := func(script []byte, key int) *psbt.TaprootScriptSpendSig {
+		leafHash := txscript.NewBaseTapLeaf(script).TapHash()
+
+		return &psbt.TaprootScriptSpendSig{XOnlyPubKey: xOnly(key), LeafHash: leafHash[:], Signature: make([]byte, 64)}
+	}
+	partialSig := func(key int) *psbt.PartialSig {
+		signature := ecdsa.Sign(keys[key], chainhash.HashB([]byte("message")))
+
+		return &psbt.PartialSig{
+			PubKey:    keys[key].PubKey().SerializeCompressed(),
+			Signature: append(signature.Serialize(), byte(txscript.SigHashAll)),
+		}
+	}
+
+	p2wpkhScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(keys[0].PubKey().SerializeCompressed())).Script()
+	require.NoError(t, err)
+
+	multisig, err := txscript.NewScriptBuilder().AddOp(txscript.OP_2).
+		AddData(keys[0].PubKey().SerializeCompressed()).
+		AddData(keys[1].PubKey().SerializeCompressed()).
+		AddData(keys[2].PubKey().SerializeCompressed()).
+		AddOp(txscript.OP_3).AddOp(txscript.OP_CHECKMULTISIG).Script()
+	require.NoError(t, err)
+	witnessScriptHash := chainhash.HashB(multisig)
+	p2wshScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(witnessScriptHash).Script()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		script   []byte
+		input    psbt.PInput
+		expected psbtstatus.InputStatus
+		str      string
+	}{
+		{
+			name:     "taproot key path unsigned",
+			script:   p2trScript,
+			expected: psbtstatus.InputStatus{State: psbtstatus.StateUnsigned, Required: 1},
+			str:      "unsigned (0 of 1)",
+		},
+		{
+			name:     "taproot key path signed",
+			script:   p2trScript,
+			input:    psbt.PInput{TaprootKeySpendSig: make([]byte, 64)},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StateSigned, Signatures: 1, Required: 1},
+			str:      "signed (1 of 1)",
+		},
+		{
+			name:   "checksigadd partially signed",
+			script: p2trScript,
+			input: psbt.PInput{
+				TaprootLeafScript:     []*psbt.TaprootTapLeafScript{leaf(checkSigAdd)},
+				TaprootScriptSpendSig: []*psbt.TaprootScriptSpendSig{scriptSig(checkSigAdd, 1), scriptSig(checkSigVerify, 0)},
+			},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StatePartiallySigned, Signatures: 1, Required: 2},
+			str:      "partially signed (1 of 2)",
+		},
+		{
+			name:   "checksigadd signed",
+			script: p2trScript,
+			input: psbt.PInput{
+				TaprootLeafScript:     []*psbt.TaprootTapLeafScript{leaf(checkSigAdd)},
+				TaprootScriptSpendSig: []*psbt.TaprootScriptSpendSig{scriptSig(checkSigAdd, 2), scriptSig(checkSigAdd, 0)},
+			},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StateSigned, Signatures: 2, Required: 2},
+			str:      "signed (2 of 2)",
+		},
+		{
+			name:   "checksigverify partially signed",
+			script: p2trScript,
+			input: psbt.PInput{
+				TaprootLeafScript:     []*psbt.TaprootTapLeafScript{leaf(checkSigVerify)},
+				TaprootScriptSpendSig: []*psbt.TaprootScriptSpendSig{scriptSig(checkSigVerify, 1), scriptSig(checkSigVerify, 2)},
+			},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StatePartiallySigned, Signatures: 1, Required: 2},
+			str:      "partially signed (1 of 2)",
+		},
+		{
+			name:   "inscription envelope signed",
+			script: p2trScript,
+			input: psbt.PInput{
+				TaprootLeafScript:     []*psbt.TaprootTapLeafScript{leaf(envelope)},
+				TaprootScriptSpendSig: []*psbt.TaprootScriptSpendSig{scriptSig(envelope, 0)},
+			},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StateSigned, Signatures: 1, Required: 1},
+			str:      "signed (1 of 1)",
+		},
+		{
+			name:   "closest leaf",
+			script: p2trScript,
+			input: psbt.PInput{
+				TaprootLeafScript:     []*psbt.TaprootTapLeafScript{leaf(checkSigAdd), leaf(recovery)},
+				TaprootScriptSpendSig: []*psbt.TaprootScriptSpendSig{scriptSig(recovery, 2)},
+			},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StateSigned, Signatures: 1, Required: 1},
+			str:      "signed (1 of 1)",
+		},
+		{
+			name:   "unknown leaf",
+			script: p2trScript,
+			input: psbt.PInput{
+				TaprootScriptSpendSig: []*psbt.TaprootScriptSpendSig{scriptSig(recovery, 2)},
+			},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StatePartiallySigned, Signatures: 1},
+			str:      "partially signed",
+		},
+		{
+			name:     "p2wpkh signed",
+			script:   p2wpkhScript,
+			input:    psbt.PInput{PartialSigs: []*psbt.PartialSig{partialSig(0)}},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StateSigned, Signatures: 1, Required: 1},
+			str:      "signed (1 of 1)",
+		},
+		{
+			name:     "p2wsh multisig partially signed",
+			script:   p2wshScript,
+			input:    psbt.PInput{WitnessScript: multisig, PartialSigs: []*psbt.PartialSig{partialSig(2)}},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StatePartiallySigned, Signatures: 1, Required: 2},
+			str:      "partially signed (1 of 2)",
+		},
+		{
+			name:     "p2wsh without witness script",
+			script:   p2wshScript,
+			expected: psbtstatus.InputStatus{State: psbtstatus.StateUnsigned},
+			str:      "unsigned",
+		},
+		{
+			name:     "finalized",
+			script:   p2trScript,
+			input:    psbt.PInput{FinalScriptWitness: []byte{0x01, 0x40}},
+			expected: psbtstatus.InputStatus{State: psbtstatus.StateFinalized},
+			str:      "finalized",
+		},
+	}
+
+	tx := wire.NewMsgTx(2)
+	for i := range tests {
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{byte(i)}, 0), nil, nil))
+	}
+	tx.AddTxOut(wire.NewTxOut(1000, p2trScript))
+
+	p, err := psbt.NewFromUnsignedTx(tx)
+	require.NoError(t, err)
+	for i, test := range tests {
+		p.Inputs[i] = test.input
+		p.Inputs[i].WitnessUtxo = wire.NewTxOut(10000, test.script)
+	}
+
+	var serialized bytes.Buffer
+	require.NoError(t, p.Serialize(&serialized))
+
+	status, err := psbtstatus.Inspect(serialized.Bytes())
+	require.NoError(t, err)
+	require.Len(t, status.Inputs, len(tests))
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, status.Inputs[i])
+			require.Equal(t, test.str, status.Inputs[i].String())
+		})
+	}
+	require.False(t, status.ReadyToFinalize())
+	require.False(t, status.Finalized())
+
+	t.Run("ready to finalize", func(t *testing.T) {
+		status := psbtstatus.Status{Inputs: []psbtstatus.InputStatus{
+			{State: psbtstatus.StateSigned, Signatures: 2, Required: 2},
+			{State: psbtstatus.StateFinalized},
+		}}
+		require.True(t, status.ReadyToFinalize())
+		require.False(t, status.Finalized())
+
+		status.Inputs[0] = psbtstatus.InputStatus{State: psbtstatus.StateFinalized}
+		require.True(t, status.Finalized())
+	})
+
+	t.Run("invalid psbt", func(t *testing.T) {
+		_, err := psbtstatus.Inspect([]byte("psbt"))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+)
+
+// PrevOut describes output spent by the raw transaction input along with the owner data to sign the input.
+type PrevOut struct {
+	UTXO    *bitcoin.UTXO // spent utxo with amount and script.
+	Address string        // utxo owner address.
+	PubKey  string        // utxo owner public key.
+}
+
+// BuildRawTxResult describes unsigned transaction in wire format, for signers which do not accept PSBT.
+type BuildRawTxResult struct {
+	UnsignedRawTx        *wire.MsgTx           // unsigned transaction.
+	PrevOuts             []PrevOut             // outputs spent by the transaction inputs by their indexes.
+	EstimatedFee         *big.Int              // estimated transaction fee in Satoshi.
+	OutputRoles          []OutputRole          // roles of the transaction outputs by their indexes.
+	AddressReuseWarnings []AddressReuseWarning // outputs reusing addresses from AddressHistory, if configured.
+}
+
+// prevOutOwner describes owner of the utxos spent by the raw transaction.
+type prevOutOwner struct {
+	utxos   []*bitcoin.UTXO
+	address string
+	pubKey  string
+}
+
+// BuildBTCTransferRawTx is like BuildBTCTransferTx, but returns unsigned transaction
+// with spent outputs instead of PSBT.
+func (b *TxBuilder) BuildBTCTransferRawTx(params BaseBTCTransferParams) (BuildRawTxResult, error) {
+	return b.BuildBTCTransferRawTxContext(context.Background(), params)
+}
+
+// BuildBTCTransferRawTxContext is like BuildBTCTransferRawTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildBTCTransferRawTxContext(ctx context.Context, params BaseBTCTransferParams) (BuildRawTxResult, error) {
+	builder := b.snapshot()
+
+	baseResult, err := builder.buildBaseTransferBTCTx(ctx, params)
+	if err != nil {
+		return BuildRawTxResult{}, err
+	}
+
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindBTCTransfer,
+		UnsignedRawTx: baseResult.UnsignedRawTx,
+		UsedUTXOs:     usedUTXOs(baseResult.UsedSenderBaseUTXOs, baseResult.UsedFeePayerBaseUTXOs),
+		EstimatedFee:  baseResult.EstimatedFee,
+	})
+	if err != nil {
+		return BuildRawTxResult{}, err
+	}
+
+	owners := []prevOutOwner{{utxos: baseResult.UsedSenderBaseUTXOs, address: params.Sender.Address, pubKey: params.Sender.PubKey}}
+	if params.FeePayer != nil {
+		owners = append(owners, prevOutOwner{
+			utxos:   baseResult.UsedFeePayerBaseUTXOs,
+			address: params.FeePayer.Address,
+			pubKey:  params.FeePayer.PubKey,
+		})
+	}
+
+	return builder.newBuildRawTxResult(ctx, baseResult.UnsignedRawTx, baseResult.EstimatedFee, baseResult.OutputRoles, owners...)
+}
+
+// BuildRunesTransferRawTx is like BuildRunesTransferTx, but returns unsigned transaction
+// with spent outputs instead of PSBT.
+func (b *TxBuilder) BuildRunesTransferRawTx(params BaseRunesTransferParams) (BuildRawTxResult, error) {
+	return b.BuildRunesAndBTCTransferRawTxContext(context.Background(), BaseRunesAndBTCTransferParams{BaseRunesTransferParams: params})
+}
+
+// BuildRunesTransferRawTxContext is like BuildRunesTransferRawTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildRunesTransferRawTxContext(ctx context.Context, params BaseRunesTransferParams) (BuildRawTxResult, error) {
+	return b.BuildRunesAndBTCTransferRawTxContext(ctx, BaseRunesAndBTCTransferParams{BaseRunesTransferParams: params})
+}
+
+// BuildRunesAndBTCTransferRawTx is like BuildRunesAndBTCTransferTx, but returns unsigned transaction
+// with spent outputs instead of PSBT.
+func (b *TxBuilder) BuildRunesAndBTCTransferRawTx(params BaseRunesAndBTCTransferParams) (BuildRawTxResult, error) {
+	return b.BuildRunesAndBTCTransferRawTxContext(context.Background(), params)
+}
+
+// BuildRunesAndBTCTransferRawTxContext is like BuildRunesAndBTCTransferRawTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildRunesAndBTCTransferRawTxContext(ctx context.Context, params BaseRunesAndBTCTransferParams) (BuildRawTxResult, error) {
+	builder := b.snapshot()
+
+	baseResult, err := builder.buildBaseTransferRuneTx(ctx, params)
+	if err != nil {
+		return BuildRawTxResult{}, err
+	}
+
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindRunesTransfer,
+		UnsignedRawTx: baseResult.UnsignedRawTx,
+		UsedUTXOs:     usedUTXOs(baseResult.UsedRuneUTXOs, baseResult.UsedBaseUTXOs),
+		EstimatedFee:  baseResult.EstimatedFee,
+	})
+	if err != nil {
+		return BuildRawTxResult{}, err
+	}
+
+	return builder.newBuildRawTxResult(ctx, baseResult.UnsignedRawTx, baseResult.EstimatedFee, baseResult.OutputRoles,
+		prevOutOwner{utxos: baseResult.UsedRuneUTXOs, address: params.RunesSender.Address, pubKey: params.RunesSender.PubKey},
+		prevOutOwner{utxos: baseResult.UsedBaseUTXOs, address: params.FeePayer.Address, pubKey: params.FeePayer.PubKey},
+	)
+}
+
+// BuildInscriptionRawTx is like BuildInscriptionTx, but returns unsigned inscription commitment
+// transaction with spent outputs instead of PSBT.
+func (b *TxBuilder) BuildInscriptionRawTx(params BaseInscriptionTxParams) (BuildRawTxResult, error) {
+	return b.BuildInscriptionRawTxContext(context.Background(), params)
+}
+
+// BuildInscriptionRawTxContext is like BuildInscriptionRawTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildInscriptionRawTxContext(ctx context.Context, params BaseInscriptionTxParams) (BuildRawTxResult, error) {
+	builder := b.snapshot()
+
+	baseResult, err := builder.buildBaseInscriptionTx(ctx, params)
+	if err != nil {
+		return BuildRawTxResult{}, err
+	}
+
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindInscription,
+		UnsignedRawTx: baseResult.UnsignedRawTx,
+		UsedUTXOs:     baseResult.UsedBaseUTXOs,
+		EstimatedFee:  baseResult.EstimatedFee,
+	})
+	if err != nil {
+		return BuildRawTxResult{}, err
+	}
+
+	return builder.newBuildRawTxResult(ctx, baseResult.UnsignedRawTx, baseResult.EstimatedFee, baseResult.OutputRoles,
+		prevOutOwner{utxos: baseResult.UsedBaseUTXOs, address: params.Sender.Address, pubKey: params.Sender.PubKey})
+}
+
+// newBuildRawTxResult returns raw transaction result with prevouts matched to the inputs.
+// INFO: inputs could be reordered, see TxBuilderConfig.InputOrdering.
+func (b *TxBuilder) newBuildRawTxResult(ctx context.Context, tx *wire.MsgTx, fee *big.Int, roles []OutputRole,
+	owners ...prevOutOwner) (_ BuildRawTxResult, err error) {
+	result := BuildRawTxResult{
+		UnsignedRawTx: tx,
+		PrevOuts:      make([]PrevOut, len(tx.TxIn)),
+		EstimatedFee:  fee,
+		OutputRoles:   roles,
+	}
+
+	inputs := newInputIndexer(tx)
+	for _, owner := range owners {
+		for _, utxo := range owner.utxos {
+			index, err := inputs.index(utxo)
+			if err != nil {
+				return BuildRawTxResult{}, err
+			}
+
+			result.PrevOuts[index] = PrevOut{UTXO: utxo, Address: owner.address, PubKey: owner.pubKey}
+		}
+	}
+
+	result.AddressReuseWarnings, err = addressReuseWarnings(ctx, b.config.AddressHistory, tx, roles, b.networkParams)
+	if err != nil {
+		return BuildRawTxResult{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestRawTx(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+	builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithInputOrdering(txbuilder.InputOrderingBIP69))
+	runeID := runes.RuneID{Block: 840000, TxID: 3}
+
+	sender := txbuildertest.NewWallet(t, networkParams).PaymentData("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
+		546, 20000)
+	sender.UTXOs[0].Runes = []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(1000)}}
+	feePayer := txbuildertest.NewWallet(t, networkParams).PaymentData("078a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
+		50000)
+
+	// requirePrevOuts checks that raw result matches PSBT built by the same params.
+	requirePrevOuts := func(t *testing.T, result txbuilder.BuildRawTxResult, serializedPSBT []byte) {
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+		require.NoError(t, err)
+		require.Equal(t, p.UnsignedTx.TxHash(), result.UnsignedRawTx.TxHash())
+		require.Len(t, result.PrevOuts, len(result.UnsignedRawTx.TxIn))
+
+		for i, prevOut := range result.PrevOuts {
+			require.Equal(t, result.UnsignedRawTx.TxIn[i].PreviousOutPoint.Hash.String(), prevOut.UTXO.TxHash())
+			require.Equal(t, result.UnsignedRawTx.TxIn[i].PreviousOutPoint.Index, prevOut.UTXO.Index)
+			require.Equal(t, p.Inputs[i].WitnessUtxo.PkScript, prevOut.UTXO.Script)
+			require.Equal(t, p.Inputs[i].WitnessUtxo.Value, prevOut.UTXO.Amount.Int64())
+			require.Equal(t, prevOut.UTXO.Address, prevOut.Address)
+		}
+	}
+
+	t.Run("btc", func(t *testing.T) {
+		params := txbuilder.BaseBTCTransferParams{
+			Sender:                &txbuilder.PaymentData{UTXOs: sender.UTXOs[1:], Address: sender.Address, PubKey: sender.PubKey},
+			FeePayer:              feePayer,
+			TransferSatoshiAmount: big.NewInt(15000),
+			SatoshiPerKVByte:      big.NewInt(5000),
+			RecipientAddress:      feePayer.Address,
+		}
+
+		result, err := builder.BuildBTCTransferRawTx(params)
+		require.NoError(t, err)
+		require.Len(t, result.PrevOuts, 2)
+		require.NotEmpty(t, result.OutputRoles)
+
+		psbtResult, err := builder.BuildBTCTransferTx(params)
+		require.NoError(t, err)
+		require.Equal(t, psbtResult.EstimatedFee, result.EstimatedFee)
+		requirePrevOuts(t, result, psbtResult.SerializedPSBT)
+	})
+
+	t.Run("runes", func(t *testing.T) {
+		params := txbuilder.BaseRunesTransferParams{
+			RuneID:                runeID,
+			TransferRuneAmount:    big.NewInt(400),
+			RunesSender:           &txbuilder.PaymentData{UTXOs: sender.UTXOs[:1], Address: sender.Address, PubKey: sender.PubKey},
+			FeePayer:              feePayer,
+			SatoshiPerKVByte:      big.NewInt(5000),
+			RunesRecipientAddress: feePayer.Address,
+		}
+
+		result, err := builder.BuildRunesTransferRawTx(params)
+		require.NoError(t, err)
+		require.Len(t, result.PrevOuts, 2)
+
+		psbtResult, err := builder.BuildRunesTransferTx(params)
+		require.NoError(t, err)
+		requirePrevOuts(t, result, psbtResult.SerializedPSBT)
+
+		params.TransferRuneAmount = big.NewInt(2000)
+		_, err = builder.BuildRunesTransferRawTx(params)
+		require.ErrorAs(t, err, new(*txbuilder.InsufficientError))
+	})
+}
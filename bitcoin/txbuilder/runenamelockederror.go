@@ -0,0 +1,35 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrRuneNameLocked describes class of errors when etching rune name is not unlocked yet.
+	ErrRuneNameLocked = errors.New("rune name is locked")
+	// ErrReservedRuneName describes that etching rune name is in the reserved names range.
+	ErrReservedRuneName = errors.New("rune name is reserved")
+)
+
+// RuneNameLockedError is the error type to describe locked rune name errors with details.
+type RuneNameLockedError struct {
+	Rune          string // locked rune name.
+	Height        uint64 // block height the name was checked at.
+	UnlockHeight  uint64 // the earliest block height the name can be etched at.
+	MinNameLength int    // minimum unlocked name length at Height.
+}
+
+// Error returns error description.
+func (e *RuneNameLockedError) Error() string {
+	return fmt.Sprintf("%s: %s at height %d (min name length %d), unlocks at height %d",
+		ErrRuneNameLocked, e.Rune, e.Height, e.MinNameLength, e.UnlockHeight)
+}
+
+// Is implements comparator method for [errors] package.
+func (e *RuneNameLockedError) Is(target error) bool {
+	return target == ErrRuneNameLocked //nolint: errorlint
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+func TestSelectUTXOGolden(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	satFn := func(utxo *bitcoin.UTXO) *big.Int { return utxo.Amount }
+
+	for i := 0; i < 500; i++ {
+		utxos := randomUTXOs(rnd, 1+rnd.Intn(40))
+		minAmount := big.NewInt(rnd.Int63n(2_000_000))
+		requiredUTXOs := 1 + rnd.Intn(len(utxos)+1)
+
+		expectedUTXOs, expectedTotal, expectedErr := referenceSelectUTXO(utxos, satFn, minAmount, requiredUTXOs,
+			txbuilder.InsufficientNativeBalanceError)
+		usedUTXOs, totalAmount, err := txbuilder.SelectUTXO(utxos, satFn, minAmount, requiredUTXOs,
+			txbuilder.InsufficientNativeBalanceError)
+		require.Equal(t, expectedErr, err)
+		require.Equal(t, expectedUTXOs, usedUTXOs)
+		require.Equal(t, expectedTotal, totalAmount)
+
+		snapshot := cloneUTXOs(utxos)
+		indices, indicesTotal, err := txbuilder.SelectUTXOIndices(utxos, satFn, minAmount, requiredUTXOs,
+			txbuilder.InsufficientNativeBalanceError)
+		require.Equal(t, expectedErr, err)
+		require.Equal(t, expectedTotal, indicesTotal)
+		require.Equal(t, snapshot, utxos)
+		require.Len(t, indices, len(usedUTXOs))
+		for j, idx := range indices {
+			require.Same(t, usedUTXOs[j], &utxos[idx])
+		}
+	}
+}
+
+func TestPrepareUTXOsCopies(t *testing.T) {
+	utxos := randomUTXOs(rand.New(rand.NewSource(42)), 10)
+	params := txbuilder.PrepareUTXOsParams{
+		Utxos:          utxos,
+		TransferAmount: new(big.Int).Add(utxos[0].Amount, big.NewInt(1)),
+	}
+
+	aliased, err := txbuilder.PrepareUTXOs(params)
+	require.NoError(t, err)
+
+	params.CopyUTXOs = true
+	copied, err := txbuilder.PrepareUTXOs(params)
+	require.NoError(t, err)
+	require.Equal(t, aliased, copied)
+
+	// INFO: sorting the slice moves utxos under the aliased pointers, but not under the copies.
+	expected := cloneUTXOs(utxos)
+	sort.Slice(utxos, func(i, j int) bool { return utxos[i].Amount.Cmp(utxos[j].Amount) < 0 })
+	for i, used := range copied.UsedUTXOs {
+		require.NotSame(t, aliased.UsedUTXOs[i], used)
+		require.Contains(t, expected, *used)
+	}
+	require.NotEqual(t, aliased.UsedUTXOs, copied.UsedUTXOs)
+}
+
+func TestPrepareUTXOsGolden(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 500; i++ {
+		utxos := randomUTXOs(rnd, rnd.Intn(40))
+		if i%5 == 0 { // not sorted utxos.
+			rnd.Shuffle(len(utxos), func(i, j int) { utxos[i], utxos[j] = utxos[j], utxos[i] })
+		}
+
+		params := txbuilder.PrepareUTXOsParams{
+			Utxos:          utxos,
+			TransferAmount: big.NewInt(rnd.Int63n(3_000_000)),
+		}
+		if i%2 == 0 {
+			params.Inputs = rnd.Intn(3)
+			params.Outputs = 1 + rnd.Intn(3)
+			params.SatoshiPerKVByte = big.NewInt(1000 + rnd.Int63n(500_000))
+		}
+
+		iterativeParams := params
+		iterativeParams.CoinSelector = txbuilder.SelectUTXO
+
+		expected, expectedErr := txbuilder.PrepareUTXOs(iterativeParams)
+		result, err := txbuilder.PrepareUTXOs(params)
+		require.Equal(t, expectedErr, err)
+		require.Equal(t, expected, result)
+	}
+}
+
+func BenchmarkPrepareUTXOs(b *testing.B) {
+	for _, n := range []int{1000, 5000} {
+		utxos := randomUTXOs(rand.New(rand.NewSource(42)), n)
+		params := txbuilder.PrepareUTXOsParams{
+			Utxos:            utxos,
+			Inputs:           1,
+			Outputs:          3,
+			TransferAmount:   new(big.Int).Div(sumAmounts(utxos), big.NewInt(2)), // takes about half of utxos.
+			SatoshiPerKVByte: big.NewInt(5000),
+		}
+
+		b.Run("single pass "+strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = txbuilder.PrepareUTXOs(params)
+			}
+		})
+
+		iterativeParams := params
+		iterativeParams.CoinSelector = txbuilder.SelectUTXO
+		b.Run("iterative "+strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = txbuilder.PrepareUTXOs(iterativeParams)
+			}
+		})
+	}
+}
+
+func BenchmarkSelectUTXO(b *testing.B) {
+	var (
+		utxos     = randomUTXOs(rand.New(rand.NewSource(42)), 50_000)
+		satFn     = func(utxo *bitcoin.UTXO) *big.Int { return utxo.Amount }
+		minAmount = big.NewInt(1_000_000_000)
+	)
+
+	for _, requiredUTXOs := range []int{1, 100, 10_000} {
+		b.Run(strconv.Itoa(requiredUTXOs), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _, _ = txbuilder.SelectUTXO(utxos, satFn, minAmount, requiredUTXOs, txbuilder.InsufficientNativeBalanceError)
+			}
+		})
+	}
+}
+
+// randomUTXOs returns utxos with random amounts sorted by amount desc.
+func randomUTXOs(rnd *rand.Rand, n int) []bitcoin.UTXO {
+	utxos := make([]bitcoin.UTXO, n)
+	for i := range utxos {
+		utxos[i].Amount = big.NewInt(546 + rnd.Int63n(500_000))
+	}
+
+	sort.Slice(utxos, func(i, j int) bool { return utxos[i].Amount.Cmp(utxos[j].Amount) > 0 })
+
+	return utxos
+}
+
+// sumAmounts returns total amount of utxos.
+func cloneUTXOs(utxos []bitcoin.UTXO) []bitcoin.UTXO {
+	clones := make([]bitcoin.UTXO, 0, len(utxos))
+	for i := range utxos {
+		clones = append(clones, *utxos[i].Clone())
+	}
+
+	return clones
+}
+
+func sumAmounts(utxos []bitcoin.UTXO) *big.Int {
+	sum := big.NewInt(0)
+	for _, utxo := range utxos {
+		sum.Add(sum, utxo.Amount)
+	}
+
+	return sum
+}
+
+// referenceSelectUTXO is the original SelectUTXO implementation with linear scans of used indexes,
+// used to guarantee identical selection results.
+func referenceSelectUTXO(utxos []bitcoin.UTXO, amountFn func(*bitcoin.UTXO) *big.Int, minAmount *big.Int, requiredUTXOs int,
+	insufficientBalanceError *txbuilder.InsufficientError) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, _ error) {
+	if len(utxos) == 0 || len(utxos) < requiredUTXOs {
+		return nil, nil, txbuilder.ErrInvalidUTXOAmount
+	}
+
+	usedUTXOs = make([]*bitcoin.UTXO, 0, requiredUTXOs)
+	totalAmount = big.NewInt(0)
+	var startIdx = 0
+	var usedIdxs = make([]int, 0)
+
+	for idx, utxo := range utxos {
+		if numbers.IsGreater(minAmount, amountFn(&utxo)) {
+			break
+		}
+
+		startIdx = idx
+	}
+
+	usedIdxs = append(usedIdxs, startIdx)
+	totalAmount.Add(totalAmount, amountFn(&utxos[startIdx]))
+	usedUTXOs = append(usedUTXOs, &utxos[startIdx])
+	requiredUTXOs--
+
+	isUsed := func(idx int) bool {
+		for _, used := range usedIdxs {
+			if used == idx {
+				return true
+			}
+		}
+
+		return false
+	}
+	selectUnused := func(reversed bool) int {
+		if reversed {
+			for idx := len(utxos) - 1; idx >= startIdx; idx-- {
+				if !isUsed(idx) {
+					return idx
+				}
+			}
+		} else {
+			for idx := startIdx; idx < len(utxos); idx++ {
+				if !isUsed(idx) {
+					return idx
+				}
+			}
+		}
+
+		return -1
+	}
+
+	for ; requiredUTXOs > 0; requiredUTXOs-- {
+		idx := selectUnused(!numbers.IsGreater(minAmount, totalAmount))
+		if idx == -1 {
+			return nil, nil, txbuilder.ErrInvalidUTXOAmount
+		}
+
+		usedIdxs = append(usedIdxs, idx)
+		totalAmount.Add(totalAmount, amountFn(&utxos[idx]))
+		usedUTXOs = append(usedUTXOs, &utxos[idx])
+	}
+
+	if numbers.IsGreater(minAmount, totalAmount) {
+		return nil, nil, txbuilder.NewInsufficientError(txbuilder.InsufficientErrorTypeBitcoin, minAmount, totalAmount)
+	}
+
+	return usedUTXOs, totalAmount, nil
+}
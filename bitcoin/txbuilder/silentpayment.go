@@ -0,0 +1,62 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/bip352"
+)
+
+// ErrMissingSilentPaymentKeys describes that inputs private keys are required to pay silent payment address.
+var ErrMissingSilentPaymentKeys = errors.New("silent payment keys are required")
+
+// SilentPaymentKeys returns private key of the utxo to derive silent payment output,
+// nil key means the utxo is not eligible for silent payments, e.g. P2WSH one.
+type SilentPaymentKeys func(utxo *bitcoin.UTXO) (*btcec.PrivateKey, error)
+
+// silentPaymentOutputAddress returns taproot address of the silent payment output
+// derived from the transaction inputs spending utxos in the same order.
+func (b *TxBuilder) silentPaymentOutputAddress(address string, keys SilentPaymentKeys, tx *wire.MsgTx, utxos []*bitcoin.UTXO) (string, error) {
+	if keys == nil {
+		return "", fmt.Errorf("%w: %s", ErrMissingSilentPaymentKeys, address)
+	}
+
+	recipient, err := bip352.DecodeAddress(address, b.networkParams)
+	if err != nil {
+		return "", err
+	}
+
+	inputs := make([]bip352.Input, len(utxos))
+	for i, utxo := range utxos {
+		privateKey, err := keys(utxo)
+		if err != nil {
+			return "", err
+		}
+
+		inputs[i] = bip352.Input{
+			Outpoint:   tx.TxIn[i].PreviousOutPoint,
+			PrivateKey: privateKey,
+			Taproot:    utxo.ScriptType() == bitcoin.ScriptTypeP2TR,
+		}
+	}
+
+	outputKeys, err := bip352.SenderOutputKeys(inputs, []*bip352.Address{recipient})
+	if err != nil {
+		return "", err
+	}
+
+	outputAddress, err := btcutil.NewAddressTaproot(outputKeys[0], b.networkParams)
+	if err != nil {
+		return "", err
+	}
+
+	return outputAddress.EncodeAddress(), nil
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/bip352"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestSilentPayment(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+
+	senderKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	senderAddress, err := utils.P2TRAddressFromInternalKey(senderKey.PubKey(), nil, networkParams)
+	require.NoError(t, err)
+
+	senderScript, err := txscript.PayToAddrScript(senderAddress)
+	require.NoError(t, err)
+
+	scanKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	spendKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	recipient, err := bip352.NewAddress(scanKey.PubKey(), spendKey.PubKey()).Encode(networkParams)
+	require.NoError(t, err)
+
+	params := txbuilder.BaseBTCTransferParams{
+		TransferSatoshiAmount: big.NewInt(29500),
+		Sender: &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{
+				{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+					Amount:   big.NewInt(850000),
+					Script:   senderScript,
+					Address:  senderAddress.EncodeAddress(),
+				},
+			},
+			Address: senderAddress.EncodeAddress(),
+			PubKey:  hex.EncodeToString(schnorr.SerializePubKey(senderKey.PubKey())),
+		},
+		SatoshiPerKVByte: big.NewInt(5000),
+		RecipientAddress: recipient,
+	}
+
+	builder := txbuilder.NewTxBuilder(networkParams)
+
+	_, err = builder.BuildBTCTransferTx(params)
+	require.ErrorIs(t, err, txbuilder.ErrMissingSilentPaymentKeys)
+
+	params.SilentPaymentKeys = func(utxo *bitcoin.UTXO) (*btcec.PrivateKey, error) {
+		return senderKey, nil
+	}
+
+	result, err := builder.BuildBTCTransferTx(params)
+	require.NoError(t, err)
+
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+	require.NoError(t, err)
+
+	outputKeys := make([][]byte, len(p.UnsignedTx.TxOut))
+	for i, output := range p.UnsignedTx.TxOut {
+		outputKeys[i] = output.PkScript[2:]
+	}
+
+	inputsKey, err := schnorr.ParsePubKey(schnorr.SerializePubKey(senderKey.PubKey()))
+	require.NoError(t, err)
+
+	inputHash, err := bip352.InputHash([]wire.OutPoint{p.UnsignedTx.TxIn[0].PreviousOutPoint}, inputsKey)
+	require.NoError(t, err)
+
+	found, err := bip352.Scan(scanKey, spendKey.PubKey(), inputsKey, inputHash, outputKeys)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, 0, found[0].Index)
+	require.EqualValues(t, 29500, p.UnsignedTx.TxOut[0].Value)
+}
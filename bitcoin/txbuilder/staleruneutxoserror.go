@@ -0,0 +1,47 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+// ErrStaleRuneUTXOs describes class of errors when sender rune utxos balances are not confirmed by RuneStateOracle.
+var ErrStaleRuneUTXOs = errors.New("stale rune utxos")
+
+// StaleRuneUTXO describes sender rune utxo which actual rune balance differs from the provided one,
+// e.g. runes were burned by a cenotaph or the utxo is already spent.
+type StaleRuneUTXO struct {
+	UTXO     *bitcoin.UTXO
+	Expected *big.Int // rune amount provided in the utxo.
+	Actual   *big.Int // rune amount reported by the oracle.
+}
+
+// StaleRuneUTXOsError is the error type to describe stale sender rune utxos with details.
+type StaleRuneUTXOsError struct {
+	RuneID runes.RuneID
+	UTXOs  []StaleRuneUTXO
+}
+
+// Error returns error description.
+func (e *StaleRuneUTXOsError) Error() string {
+	utxos := make([]string, 0, len(e.UTXOs))
+	for _, stale := range e.UTXOs {
+		utxos = append(utxos, fmt.Sprintf("%s has %s, expected %s", stale.UTXO.Outpoint.String(),
+			stale.Actual.String(), stale.Expected.String()))
+	}
+
+	return fmt.Sprintf("%s: %s %s", ErrStaleRuneUTXOs, e.RuneID.String(), strings.Join(utxos, ", "))
+}
+
+// Is implements comparator method for [errors] package.
+func (e *StaleRuneUTXOsError) Is(target error) bool {
+	return target == ErrStaleRuneUTXOs //nolint: errorlint
+}
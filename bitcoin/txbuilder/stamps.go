@@ -0,0 +1,122 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/stamps"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// ErrInvalidChangeOutput describes that change output index is out of transaction outputs range.
+var ErrInvalidChangeOutput = errors.New("invalid change output")
+
+// AttachStampOutputsParams describes data needed to attach stamp outputs to funded transaction.
+type AttachStampOutputsParams struct {
+	SerializedPSBT    []byte   // funded transaction with at least one input.
+	Payload           []byte   // stamp payload data, see stamps.SRC20 Payload.
+	ChangeOutputIndex int      // output to cover stamp outputs amount and additional fee from.
+	SatoshiPerKVByte  *big.Int // fee rate to cover stamp outputs size, optional.
+	OpReturn          bool     // encode payload into OP_RETURN output instead of bare multisig outputs.
+}
+
+// AttachStampOutputsResult describes result of the AttachStampOutputs.
+type AttachStampOutputsResult struct {
+	SerializedPSBT []byte
+	AdditionalFee  *big.Int // fee in satoshi for stamp outputs size.
+}
+
+// AttachStampOutputs appends stamp outputs carrying payload to the end of funded transaction outputs.
+// Stamp outputs amount and their size fee are subtracted from the change output, which should stay
+// above dust amount. Payload is encrypted with the first input txid, so inputs should not be changed later.
+func (b *TxBuilder) AttachStampOutputs(params AttachStampOutputsParams) (result AttachStampOutputsResult, _ error) {
+	builder := b.snapshot()
+	if err := builder.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return result, err
+	}
+
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(params.SerializedPSBT), false)
+	if err != nil {
+		return result, err
+	}
+
+	tx := p.UnsignedTx
+	if len(tx.TxIn) == 0 {
+		return result, fmt.Errorf("%w: stamp transaction", ErrNoUTXOs)
+	}
+
+	if params.ChangeOutputIndex < 0 || params.ChangeOutputIndex >= len(tx.TxOut) {
+		return result, fmt.Errorf("%w: index %d of %d outputs", ErrInvalidChangeOutput, params.ChangeOutputIndex, len(tx.TxOut))
+	}
+
+	var outputs []*wire.TxOut
+	if params.OpReturn {
+		script, err := stamps.EncodeOpReturn(params.Payload, tx.TxIn[0].PreviousOutPoint.Hash)
+		if err != nil {
+			return result, err
+		}
+
+		outputs = append(outputs, wire.NewTxOut(0, script))
+	} else {
+		scripts, err := stamps.EncodeMultisig(params.Payload, tx.TxIn[0].PreviousOutPoint.Hash)
+		if err != nil {
+			return result, err
+		}
+
+		for _, script := range scripts {
+			outputs = append(outputs, wire.NewTxOut(stamps.MultisigOutputAmount, script))
+		}
+	}
+
+	var (
+		size          int64
+		outputsAmount = big.NewInt(0)
+	)
+	for _, output := range outputs {
+		size += int64(output.SerializeSize())
+		outputsAmount.Add(outputsAmount, big.NewInt(output.Value))
+	}
+	// INFO: outputs count varint grows when the count crosses 0xfc.
+	size += int64(wire.VarIntSerializeSize(uint64(len(tx.TxOut)+len(outputs))) - wire.VarIntSerializeSize(uint64(len(tx.TxOut))))
+
+	result.AdditionalFee = big.NewInt(0)
+	if params.SatoshiPerKVByte != nil {
+		// INFO: vB * ( sat / kvB ) = 1000 sat.
+		result.AdditionalFee.Mul(big.NewInt(size), params.SatoshiPerKVByte)
+		result.AdditionalFee.Add(result.AdditionalFee, big.NewInt(999)).Div(result.AdditionalFee, big.NewInt(1000)) // sat, rounded up.
+	}
+
+	change := tx.TxOut[params.ChangeOutputIndex]
+	rest := new(big.Int).Sub(big.NewInt(change.Value), outputsAmount)
+	rest.Sub(rest, result.AdditionalFee)
+	if numbers.IsLess(rest, builder.config.DustAmount) {
+		return result, fmt.Errorf("%w: change amount (%d) does not cover stamp outputs (%s) and fee (%s)", ErrUnallocatedAmountExceeded,
+			change.Value, outputsAmount, result.AdditionalFee)
+	}
+
+	change.Value = rest.Int64()
+	for _, output := range outputs {
+		tx.AddTxOut(output)
+		p.Outputs = append(p.Outputs, psbt.POutput{})
+		if builder.config.OutputRoles {
+			setOutputRole(&p.Outputs[len(p.Outputs)-1], OutputRoleStamp)
+		}
+	}
+
+	w := bytes.NewBuffer(nil)
+	if err = p.Serialize(w); err != nil {
+		return result, err
+	}
+
+	result.SerializedPSBT = w.Bytes()
+
+	return result, nil
+}
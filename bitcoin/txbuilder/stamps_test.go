@@ -0,0 +1,122 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/stamps"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestAttachStampOutputs(t *testing.T) {
+	funded, err := base64.StdEncoding.DecodeString("cHNidP8BAH4CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AjxzAAAAAAAAIlEgLur7v5KvLR9eCGbL69YXuZViXzdiemOMkE3dJoVEwxDvgQwAAAAAABepFKpYjpRh5/yszRC1NNtHIt1yMSLBhwAAAAABIAEAAAEBJVD4DAAAAAAAHF9iaXRjb2luX3RyYW5zYWN0aW9uX3NjcmlwdF8BAwQBAAAAAQQWABTz6zxFOwEUHmAr6y0TNfa+UHuBOAAAAA==")
+	require.NoError(t, err)
+
+	payload, err := stamps.NewTransfer("KEVIN", "100000").Payload()
+	require.NoError(t, err)
+
+	builder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params)
+
+	t.Run("multisig", func(t *testing.T) {
+		result, err := builder.AttachStampOutputs(txbuilder.AttachStampOutputsParams{
+			SerializedPSBT:    funded,
+			Payload:           payload,
+			ChangeOutputIndex: 1,
+			SatoshiPerKVByte:  big.NewInt(10000),
+		})
+		require.NoError(t, err)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.Outputs, len(p.UnsignedTx.TxOut))
+		require.Len(t, p.UnsignedTx.TxOut, 4)
+
+		stampsAmount := 2 * stamps.MultisigOutputAmount
+		require.EqualValues(t, 819695-stampsAmount-result.AdditionalFee.Int64(), p.UnsignedTx.TxOut[1].Value)
+		require.EqualValues(t, 2*(8+1+105)*10, result.AdditionalFee.Int64())
+
+		src20, err := stamps.DecodeSRC20(p.UnsignedTx)
+		require.NoError(t, err)
+		require.Equal(t, stamps.NewTransfer("KEVIN", "100000"), src20)
+	})
+
+	t.Run("fee rounding and outputs count", func(t *testing.T) {
+		result, err := builder.AttachStampOutputs(txbuilder.AttachStampOutputsParams{
+			SerializedPSBT:    funded,
+			Payload:           payload,
+			ChangeOutputIndex: 1,
+			SatoshiPerKVByte:  big.NewInt(10001),
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 2281, result.AdditionalFee.Int64()) // 2280.228 sat rounded up.
+
+		// INFO: outputs count varint grows from 1 to 3 bytes, when the count crosses 0xfc.
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+		tx.AddTxOut(wire.NewTxOut(100_000, []byte("_change_script_")))
+		for len(tx.TxOut) < 0xfc {
+			tx.AddTxOut(wire.NewTxOut(546, []byte("_recipient_script_")))
+		}
+
+		p, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+		w := bytes.NewBuffer(nil)
+		require.NoError(t, p.Serialize(w))
+
+		result, err = builder.AttachStampOutputs(txbuilder.AttachStampOutputsParams{
+			SerializedPSBT:    w.Bytes(),
+			Payload:           payload,
+			ChangeOutputIndex: 0,
+			SatoshiPerKVByte:  big.NewInt(10000),
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, (2*(8+1+105)+2)*10, result.AdditionalFee.Int64())
+	})
+
+	t.Run("op_return", func(t *testing.T) {
+		result, err := builder.AttachStampOutputs(txbuilder.AttachStampOutputsParams{
+			SerializedPSBT:    funded,
+			Payload:           payload,
+			ChangeOutputIndex: 1,
+			OpReturn:          true,
+		})
+		require.NoError(t, err)
+		require.Zero(t, result.AdditionalFee.Sign())
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 3)
+		require.Zero(t, p.UnsignedTx.TxOut[2].Value)
+
+		decoded, err := stamps.Decode(p.UnsignedTx)
+		require.NoError(t, err)
+		require.Equal(t, payload, decoded)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := builder.AttachStampOutputs(txbuilder.AttachStampOutputsParams{
+			SerializedPSBT:    funded,
+			Payload:           payload,
+			ChangeOutputIndex: 2,
+		})
+		require.ErrorIs(t, err, txbuilder.ErrInvalidChangeOutput)
+
+		_, err = builder.AttachStampOutputs(txbuilder.AttachStampOutputsParams{
+			SerializedPSBT:    funded,
+			Payload:           payload,
+			ChangeOutputIndex: 0,
+			SatoshiPerKVByte:  big.NewInt(200000),
+		})
+		require.ErrorIs(t, err, txbuilder.ErrUnallocatedAmountExceeded)
+	})
+}
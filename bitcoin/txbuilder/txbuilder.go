@@ -5,18 +5,22 @@ package txbuilder
 
 import (
 	"bytes"
+	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
+	"sort"
+	"sync"
 
-	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
-	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 
 	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/bip352"
 	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
 	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
 	"github.com/BoostyLabs/blockchain/internal/numbers"
@@ -29,6 +33,42 @@ var (
 	InsufficientRuneBalanceError = &InsufficientError{Type: InsufficientErrorTypeRune}
 	// ErrInvalidUTXOAmount describes that there was invalid UTXO amount transmitted.
 	ErrInvalidUTXOAmount = errors.New("invalid UTXO amount")
+	// ErrMissingSender describes that sender payment data was not provided.
+	ErrMissingSender = errors.New("sender data is required")
+	// ErrMissingFeePayer describes that fee payer payment data was not provided.
+	ErrMissingFeePayer = errors.New("fee payer data is required")
+	// ErrNoUTXOs describes that payment data contains no utxos.
+	ErrNoUTXOs = errors.New("no utxos provided")
+	// ErrMissingInscription describes that inscription data was not provided.
+	ErrMissingInscription = errors.New("inscription data is required")
+	// ErrMissingInscriptionReveal describes that inscription reveal data was not provided.
+	ErrMissingInscriptionReveal = errors.New("inscription reveal data is required")
+	// ErrInvalidInscriptionUTXOs describes that inscription reveal data contains invalid number of utxos.
+	ErrInvalidInscriptionUTXOs = errors.New("invalid inscription utxos")
+	// ErrMissingRuneEtching describes that rune etching data was not provided.
+	ErrMissingRuneEtching = errors.New("rune etching data is required")
+	// ErrInvalidPremineSplittingFactor describes that premine can not be split into requested number of outputs.
+	ErrInvalidPremineSplittingFactor = errors.New("premine splitting factor is greater than premine")
+	// ErrMissingRuneCommitment describes that inscription reveal script does not commit to the etching rune name.
+	ErrMissingRuneCommitment = errors.New("rune commitment is missing")
+	// ErrInvalidEtchingTerms describes that etching open mint terms would not allow any mint.
+	ErrInvalidEtchingTerms = errors.New("invalid etching terms")
+	// ErrInvalidRunesPointer describes that runestone pointer does not refer to the non OP_RETURN output.
+	ErrInvalidRunesPointer = errors.New("invalid runes pointer")
+	// ErrImmatureRuneCommitment describes that inscription commitment has not enough confirmations to etch the rune.
+	ErrImmatureRuneCommitment = errors.New("rune commitment is immature")
+	// ErrInvalidPremineDistribution describes that premine can not be distributed by the requested allocations.
+	ErrInvalidPremineDistribution = errors.New("invalid premine distribution")
+	// ErrUnallocatedAmountExceeded describes that output amount exceeds the rest of the unallocated btc amount.
+	ErrUnallocatedAmountExceeded = errors.New("unallocated amount exceeded")
+	// ErrPostageTooLow describes that postage amount is less than the dust amount.
+	ErrPostageTooLow = errors.New("postage is less than dust amount")
+	// ErrInvalidRunesRecipients describes that runes can not be transferred to the requested recipients.
+	ErrInvalidRunesRecipients = errors.New("invalid runes recipients")
+	// ErrRunestoneTooLarge describes that runestone payload does not fit into the standard OP_RETURN output.
+	ErrRunestoneTooLarge = errors.New("runestone exceeds standard op_return size")
+	// ErrTransferAmountTooLow describes that separate btc recipient output amount is less than the dust amount.
+	ErrTransferAmountTooLow = errors.New("transfer amount is less than dust amount")
 )
 
 const (
@@ -38,25 +78,20 @@ const (
 	signHashType = txscript.SigHashAll
 )
 
-var (
+const (
 	// headerSizeVBytes defined rough tx header size in vBytes.
-	headerSizeVBytes = big.NewInt(11)
+	headerSizeVBytes int64 = 11
 	// inputSizeVBytes defined rough tx input size in vBytes.
-	inputSizeVBytes = big.NewInt(90)
+	inputSizeVBytes int64 = 90
 	// outputSizeVBytes defined rough tx output size in vBytes.
-	outputSizeVBytes = big.NewInt(30)
+	outputSizeVBytes int64 = 30
 
 	// inscriptionInputSizeVBytes defined rough tx input size in vBytes
 	// with signature, but without witness script data size.
-	inscriptionInputSizeVBytes = big.NewInt(61)
+	inscriptionInputSizeVBytes int64 = 61
 
 	// nonDustBitcoinAmount defined the smallest needed amount in satoshi to link to rune output.
-	nonDustBitcoinAmount = big.NewInt(546)
-
-	// recipientOutput defines runes output for recipient (transferring) by base rune tx.
-	recipientOutput uint32 = 1
-	// returnOutput defines runes output for sender (change) by base rune tx.
-	returnOutput uint32 = 2
+	nonDustBitcoinAmount int64 = 546
 )
 
 // BaseRunesTransferParams describes basic data needed to build rune transfer transaction.
@@ -73,6 +108,28 @@ type BaseRunesTransferParams struct {
 	RunesRecipientAddress      string       // recipient runes address.
 	SatoshiCommissionAmount    *big.Int     // additional commission in satoshi to be charged from user.
 	CommissionRecipientAddress string       // recipient commission address.
+	// RuneInfo is a transferring rune data to validate amounts against rune supply in strict edicts mode, optional.
+	RuneInfo *bitcoin.Rune
+	// RunesRecipients defines runes transfer to many recipients, one dust output and edict per recipient, optional.
+	// If set, RunesRecipientAddress is not used and TransferRuneAmount must be empty or equal to the amounts sum.
+	RunesRecipients []RuneRecipient
+}
+
+// RuneRecipient describes runes amount transferred to the address.
+type RuneRecipient struct {
+	Address string
+	Amount  *big.Int
+}
+
+// BaseRunesAndBTCTransferParams describes basic data needed to build transaction which transfers
+// runes and btc in one transaction. Transferring satoshi amount is paid by fee payer.
+type BaseRunesAndBTCTransferParams struct {
+	BaseRunesTransferParams
+	TransferSatoshiAmount *big.Int // amount to transfer in satoshi.
+	// BTCRecipientAddress is a recipient btc address. If it is the same as RunesRecipientAddress
+	// and runes are transferred, satoshi are added to the runes recipient output, otherwise
+	// TransferSatoshiAmount must not be less than the dust amount.
+	BTCRecipientAddress string
 }
 
 // BaseRunesTransferResult describes result of buildBaseTransferRuneTx method.
@@ -81,14 +138,16 @@ type BaseRunesTransferResult struct {
 	UsedRuneUTXOs []*bitcoin.UTXO // used rune utxos in transaction.
 	UsedBaseUTXOs []*bitcoin.UTXO // used bitcoin utxos in transaction.
 	EstimatedFee  *big.Int        // estimated transaction fee in Satoshi.
+	OutputRoles   []OutputRole    // roles of the transaction outputs by their indexes.
 }
 
 // BuildRunesTransferTxResult describes result of BuildRunesTransferTx method.
 type BuildRunesTransferTxResult struct {
-	SerializedPSBT []byte          // serialised unsigned rune transfer transaction in PSBT format.
-	UsedRuneUTXOs  []*bitcoin.UTXO // used rune utxos in transaction.
-	UsedBaseUTXOs  []*bitcoin.UTXO // used bitcoin utxos in transaction.
-	EstimatedFee   *big.Int        // estimated transaction fee in Satoshi.
+	SerializedPSBT       []byte                // serialised unsigned rune transfer transaction in PSBT format.
+	UsedRuneUTXOs        []*bitcoin.UTXO       // used rune utxos in transaction.
+	UsedBaseUTXOs        []*bitcoin.UTXO       // used bitcoin utxos in transaction.
+	EstimatedFee         *big.Int              // estimated transaction fee in Satoshi.
+	AddressReuseWarnings []AddressReuseWarning // outputs reusing addresses from AddressHistory, if configured.
 }
 
 // BuildRunesTransferPSBTParams describes data needed to convert unsigned rune transfer transaction
@@ -118,6 +177,17 @@ type BaseBTCTransferParams struct {
 	RecipientAddress          string       // recipient btc address.
 	SatoshiCommissionAmount   *big.Int     // additional commission in satoshi to be charged from user, optional.
 	CommissionReceiverAddress string       // recipient commission address, optional.
+	// SilentPaymentKeys returns private keys of the used sender and fee payer utxos.
+	// Mandatory if RecipientAddress is a silent payment address (BIP-352).
+	SilentPaymentKeys SilentPaymentKeys
+	// OpReturnData is a data to attach in OP_RETURN outputs, one output per item, optional.
+	// Each item is limited by TxBuilderConfig.MaxOpReturnDataSize.
+	// NOTE: Default relay policy of the nodes before Bitcoin Core 30 accepts a single OP_RETURN output only.
+	OpReturnData [][]byte
+	// EphemeralAnchor adds zero value pay to anchor (P2A) output, so the transaction could be bumped by
+	// anyone with BuildAnchorSpendTx child, optional. SatoshiPerKVByte must be zero, see ErrInvalidAnchor.
+	// NOTE: Ephemeral dust is relayed by the nodes since Bitcoin Core 29.
+	EphemeralAnchor bool
 }
 
 // BaseBTCTransferResult describes result of buildBaseTransferBTCTx method.
@@ -126,14 +196,16 @@ type BaseBTCTransferResult struct {
 	UsedSenderBaseUTXOs   []*bitcoin.UTXO // used sender's bitcoin utxos in transaction.
 	UsedFeePayerBaseUTXOs []*bitcoin.UTXO // used fee payer's bitcoin utxos in transaction.
 	EstimatedFee          *big.Int        // estimated transaction fee in Satoshi.
+	OutputRoles           []OutputRole    // roles of the transaction outputs by their indexes.
 }
 
 // BuildBTCTransferTxResult describes result of BuildBTCTransferTx method.
 type BuildBTCTransferTxResult struct {
-	SerializedPSBT        []byte          // serialised unsigned btc transfer transaction in PSBT format.
-	UsedSenderBaseUTXOs   []*bitcoin.UTXO // used sender's bitcoin utxos in transaction.
-	UsedFeePayerBaseUTXOs []*bitcoin.UTXO // used fee payer's bitcoin utxos in transaction.
-	EstimatedFee          *big.Int        // estimated transaction fee in Satoshi.
+	SerializedPSBT        []byte                // serialised unsigned btc transfer transaction in PSBT format.
+	UsedSenderBaseUTXOs   []*bitcoin.UTXO       // used sender's bitcoin utxos in transaction.
+	UsedFeePayerBaseUTXOs []*bitcoin.UTXO       // used fee payer's bitcoin utxos in transaction.
+	EstimatedFee          *big.Int              // estimated transaction fee in Satoshi.
+	AddressReuseWarnings  []AddressReuseWarning // outputs reusing addresses from AddressHistory, if configured.
 }
 
 // BuildBTCTransferPSBTParams describes data needed to convert unsigned btc transfer transaction
@@ -156,6 +228,19 @@ type BaseInscriptionTxParams struct {
 	Inscription               *inscriptions.Inscription // inscription data to commit.
 	InscriptionBasePubKey     string                    // public key needed to create inscription address.
 	PremineSplittingFactor    uint                      // for more details see [BaseRuneEtchTxParams.PremineSplittingFactor].
+	Postage                   *big.Int                  // for more details see [BaseRuneEtchTxParams.Postage].
+	// ContentRules defines allowed inscription content types with size limits. optional.
+	// If set, inscription content is validated before building, see [inscriptions.DefaultContentRules].
+	ContentRules []inscriptions.ContentRule
+	// Recovery defines recovery leaf to commit along with the inscription. optional.
+	// If set, commitment output can be spent by the recovery key if reveal is never performed,
+	// see [inscriptions.Inscription.RecoveryCommitAddress].
+	Recovery *inscriptions.RecoveryLeaf
+	// EtchingRune defines rune name to be etched by the reveal transaction. optional.
+	// If set, inscription is committed with the rune commitment, see [inscriptions.Inscription.CommitRune].
+	// NOTE: The same inscription with Rune field set must be passed to BuildRuneEtchTx, which
+	// could be mined after commitment gets [runes.CommitConfirmations] confirmations only.
+	EtchingRune *runes.Rune
 }
 
 // BaseInscriptionTxResult describes result of buildBaseInscriptionTx method.
@@ -163,6 +248,7 @@ type BaseInscriptionTxResult struct {
 	UnsignedRawTx *wire.MsgTx     // unsigned inscription commitment transaction.
 	UsedBaseUTXOs []*bitcoin.UTXO // used sender's bitcoin utxos in transaction.
 	EstimatedFee  *big.Int        // estimated transaction fee in Satoshi.
+	OutputRoles   []OutputRole    // roles of the transaction outputs by their indexes.
 }
 
 // BuildInscriptionTxPSBTParams describes data needed to convert unsigned
@@ -175,9 +261,10 @@ type BuildInscriptionTxPSBTParams struct {
 
 // BuildInscriptionTxPSBTResult describes result of buildInscriptionTxPSBT method.
 type BuildInscriptionTxPSBTResult struct {
-	SerializedPSBT []byte          // serialised unsigned inscription commitment transaction in PSBT format.
-	UsedBaseUTXOs  []*bitcoin.UTXO // used sender's bitcoin utxos in transaction.
-	EstimatedFee   *big.Int        // estimated transaction fee in Satoshi.
+	SerializedPSBT       []byte                // serialised unsigned inscription commitment transaction in PSBT format.
+	UsedBaseUTXOs        []*bitcoin.UTXO       // used sender's bitcoin utxos in transaction.
+	EstimatedFee         *big.Int              // estimated transaction fee in Satoshi.
+	AddressReuseWarnings []AddressReuseWarning // outputs reusing addresses from AddressHistory, if configured.
 }
 
 // BaseRuneEtchTxParams describes basic data needed to build inscription reveal - etch transaction.
@@ -185,7 +272,7 @@ type BuildInscriptionTxPSBTResult struct {
 type BaseRuneEtchTxParams struct {
 	InscriptionReveal     *PaymentData              // inscription commitment data. mandatory. must contain one utxo only. address can be omitted.
 	Inscription           *inscriptions.Inscription // used inscription data.
-	Rune                  *runes.Etching            // rune etching data.
+	Rune                  *runes.Etching            // rune etching data. mandatory. name can be omitted, see [runes.ResolveReservedRune].
 	AdditionalPayments    *PaymentData              // sender payment data. mandatory.
 	SatoshiPerKVByte      *big.Int                  // fee rate in satoshi per kilo virtual byte.
 	RunesRecipientAddress string                    // recipient address to receive etched runes.
@@ -208,6 +295,38 @@ type BaseRuneEtchTxParams struct {
 	//  As a result there will be: 0 output - Runestone, 1 output - 2000 + 5 runes, 2-7 outputs, each containing 2000 runes,
 	//  8 - optional change output.
 	PremineSplittingFactor uint
+	// PremineDistribution defines premine outputs with arbitrary amounts or recipients, one output and edict
+	// per allocation, the first output receives the inscription. optional, can not be used with PremineSplittingFactor.
+	// Allocations without amount equally split premine rest after allocations with amount, the remainder goes
+	// to the first of them. Resulting amounts must be positive and their sum must equal [Rune.Premine],
+	// RunesRecipientAddress is not used.
+	// NOTE: Inscription commitment transaction must be built with PremineSplittingFactor equal to allocations count.
+	PremineDistribution []PremineAllocation
+	// CurrentBlockHeight defines current chain tip height. optional.
+	// If set, etching rune name is checked to be unlocked in the next block, see [runes.MinAtHeight].
+	CurrentBlockHeight uint64
+	// RunesPointer defines output index to receive runes not allocated by edicts: premine if it is not split
+	// and runes of the joined utxos. optional, the first runes recipient output if premine is not split,
+	// the first non OP_RETURN output otherwise. Output 0 is the runestone, so it can not be used.
+	RunesPointer *uint32
+	// CommitBlockHeight defines height of the block with inscription commitment transaction. optional.
+	// If set along with CurrentBlockHeight, commitment of the named rune is checked to be mature in the next block,
+	// see [runes.CommitMaturityHeight].
+	CommitBlockHeight uint64
+	// Postage defines amount in satoshi of each runes recipient output, the first one receives the inscription.
+	// optional, builder dust amount is used if not set, must not be less than the dust amount.
+	// NOTE: The same postage must be used to build inscription commitment transaction.
+	Postage *big.Int
+	// Recovery defines recovery leaf the inscription commitment is built with. optional.
+	// NOTE: The same recovery leaf must be used to build inscription commitment transaction,
+	// reveal input should be signed by [signer.Signer.SignInscriptionRevealWithRecovery].
+	Recovery *inscriptions.RecoveryLeaf
+}
+
+// PremineAllocation describes premine runes amount etched to the address.
+type PremineAllocation struct {
+	Address string
+	Amount  *big.Int // optional, equal split of the premine rest if not set.
 }
 
 // BaseRuneEtchTxResult describes result of buildBaseRuneEtchTx method.
@@ -217,6 +336,7 @@ type BaseRuneEtchTxResult struct {
 	InscriptionReveal       *inscriptions.Inscription // used inscription data.
 	UsedAdditionalBaseUTXOs []*bitcoin.UTXO           // used additional payment bitcoin utxos in transaction.
 	EstimatedFee            *big.Int                  // estimated transaction fee in Satoshi.
+	OutputRoles             []OutputRole              // roles of the transaction outputs by their indexes.
 }
 
 // BuildRuneEtchTxPSBTParams describes data needed to convert unsigned inscription
@@ -231,20 +351,93 @@ type BuildRuneEtchTxPSBTParams struct {
 
 // BuildRuneEtchTxPSBTResult describes result of BuildRuneEtchTx method.
 type BuildRuneEtchTxPSBTResult struct {
-	SerializedPSBT          []byte          // serialised unsigned inscription reveal - etch transaction in PSBT format.
-	UsedAdditionalBaseUTXOs []*bitcoin.UTXO // used additional payment bitcoin utxos in transaction.
-	EstimatedFee            *big.Int        // estimated transaction fee in Satoshi.
+	SerializedPSBT          []byte                // serialised unsigned inscription reveal - etch transaction in PSBT format.
+	UsedAdditionalBaseUTXOs []*bitcoin.UTXO       // used additional payment bitcoin utxos in transaction.
+	EstimatedFee            *big.Int              // estimated transaction fee in Satoshi.
+	AddressReuseWarnings    []AddressReuseWarning // outputs reusing addresses from AddressHistory, if configured.
 }
 
 // TxBuilder provides transaction building related logic.
+// TxBuilder is safe for concurrent use, configuration changes
+// are applied to the builds started after the change only.
 type TxBuilder struct {
 	networkParams *chaincfg.Params
+
+	mu     sync.RWMutex
+	config TxBuilderConfig
 }
 
 // NewTxBuilder is a constructor for TxBuilder.
-func NewTxBuilder(networkParams *chaincfg.Params) *TxBuilder {
+// Default configuration is used if no options provided, see DefaultTxBuilderConfig.
+func NewTxBuilder(networkParams *chaincfg.Params, opts ...Option) *TxBuilder {
+	config := DefaultTxBuilderConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+
 	return &TxBuilder{
 		networkParams: networkParams,
+		config:        config,
+	}
+}
+
+// DustAmount returns the smallest amount in satoshi for runes and change outputs.
+func (b *TxBuilder) DustAmount() *big.Int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return new(big.Int).Set(b.config.DustAmount)
+}
+
+// SetDustAmount sets the smallest amount in satoshi for runes and change outputs, nil is ignored.
+func (b *TxBuilder) SetDustAmount(amount *big.Int) {
+	if amount == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.config.DustAmount = new(big.Int).Set(amount)
+}
+
+// SizeEstimator returns transaction size estimator used for fee calculation.
+func (b *TxBuilder) SizeEstimator() SizeEstimator {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.config.SizeEstimator
+}
+
+// SetSizeEstimator sets transaction size estimator used for fee calculation,
+// e.g. RoughSizeEstimator with custom header, input and output sizes.
+// NOTE: estimator is shared between concurrent builds, so it must not be mutated after set.
+func (b *TxBuilder) SetSizeEstimator(estimator SizeEstimator) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.config.SizeEstimator = estimator
+}
+
+// KeepsOrder reports whether inputs and outputs are kept in the order they are documented for
+// each transaction type, i.e. no inputs or outputs ordering options are set.
+func (b *TxBuilder) KeepsOrder() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.config.OutputOrdering == OutputOrderingAsBuilt && b.config.InputOrdering == InputOrderingAsSelected &&
+		b.config.InputComparator == nil
+}
+
+// snapshot returns builder copy with current configuration, used to keep
+// configuration consistent during the whole build.
+func (b *TxBuilder) snapshot() *TxBuilder {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return &TxBuilder{
+		networkParams: b.networkParams,
+		config:        b.config,
 	}
 }
 
@@ -252,8 +445,40 @@ func NewTxBuilder(networkParams *chaincfg.Params) *TxBuilder {
 // format with inputs indexes assigned in unknown fields. Returns serialized
 // PSBT transaction with used rune and base outputs, estimated fee in satoshi,
 // and error if any.
-func (b *TxBuilder) BuildRunesTransferTx(params BaseRunesTransferParams) (result BuildRunesTransferTxResult, _ error) {
-	buildBaseTransferRuneTxResult, err := b.buildBaseTransferRuneTx(params)
+func (b *TxBuilder) BuildRunesTransferTx(params BaseRunesTransferParams) (BuildRunesTransferTxResult, error) {
+	return b.BuildRunesTransferTxContext(context.Background(), params)
+}
+
+// BuildRunesTransferTxContext is like BuildRunesTransferTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildRunesTransferTxContext(ctx context.Context, params BaseRunesTransferParams) (BuildRunesTransferTxResult, error) {
+	return b.BuildRunesAndBTCTransferTxContext(ctx, BaseRunesAndBTCTransferParams{BaseRunesTransferParams: params})
+}
+
+// BuildRunesAndBTCTransferTx constructs transaction which transfers runes and btc
+// in PSBT format with inputs indexes assigned in unknown fields. Returns serialized
+// PSBT transaction with used rune and base outputs, estimated fee in satoshi,
+// and error if any.
+func (b *TxBuilder) BuildRunesAndBTCTransferTx(params BaseRunesAndBTCTransferParams) (BuildRunesTransferTxResult, error) {
+	return b.BuildRunesAndBTCTransferTxContext(context.Background(), params)
+}
+
+// BuildRunesAndBTCTransferTxContext is like BuildRunesAndBTCTransferTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildRunesAndBTCTransferTxContext(ctx context.Context, params BaseRunesAndBTCTransferParams) (result BuildRunesTransferTxResult, _ error) {
+	builder := b.snapshot()
+
+	buildBaseTransferRuneTxResult, err := builder.buildBaseTransferRuneTx(ctx, params)
+	if err != nil {
+		return result, err
+	}
+
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindRunesTransfer,
+		UnsignedRawTx: buildBaseTransferRuneTxResult.UnsignedRawTx,
+		UsedUTXOs:     usedUTXOs(buildBaseTransferRuneTxResult.UsedRuneUTXOs, buildBaseTransferRuneTxResult.UsedBaseUTXOs),
+		EstimatedFee:  buildBaseTransferRuneTxResult.EstimatedFee,
+	})
 	if err != nil {
 		return result, err
 	}
@@ -261,8 +486,13 @@ func (b *TxBuilder) BuildRunesTransferTx(params BaseRunesTransferParams) (result
 	result.UsedRuneUTXOs = buildBaseTransferRuneTxResult.UsedRuneUTXOs
 	result.UsedBaseUTXOs = buildBaseTransferRuneTxResult.UsedBaseUTXOs
 	result.EstimatedFee = buildBaseTransferRuneTxResult.EstimatedFee
+	result.AddressReuseWarnings, err = addressReuseWarnings(ctx, builder.config.AddressHistory,
+		buildBaseTransferRuneTxResult.UnsignedRawTx, buildBaseTransferRuneTxResult.OutputRoles, b.networkParams)
+	if err != nil {
+		return result, err
+	}
 
-	result.SerializedPSBT, err = b.buildRunesTransferPSBT(BuildRunesTransferPSBTParams{
+	result.SerializedPSBT, err = builder.buildRunesTransferPSBT(BuildRunesTransferPSBTParams{
 		BaseRunesTransferResult: buildBaseTransferRuneTxResult,
 		RunesSenderPubKey:       params.RunesSender.PubKey,
 		RunesSenderAddress:      params.RunesSender.Address,
@@ -296,26 +526,48 @@ func (b *TxBuilder) BuildRunesTransferTx(params BaseRunesTransferParams) (result
 //	├=========┼==============┼========================================┤
 //	│       0 │ runestone    │ rune protocol main output              │
 //	├─────────┼──────────────┼────────────────────────────────────────┤
-//	│       1 │ rune output  │ optional, output to link runes         │
-//	│         │              │ to recipient, present if rune transfer │
-//	│         │              │ is positive.                           │
+//	│   1 - r │ rune outputs │ optional, outputs to link runes to     │
+//	│         │              │ recipients, one per recipient, present │
+//	│         │              │ if rune transfer is positive.          │
 //	├─────────┼──────────────┼────────────────────────────────────────┤
-//	│       2 │ rune output  │ optional, output to return runes       │
+//	│     r+1 │ rune output  │ optional, output to return runes       │
 //	│         │              │ change to sender.                      │
 //	├─────────┼──────────────┼────────────────────────────────────────┤
-//	│       3 │ base output  │ service native commission. optional,   │
+//	│     r+2 │ base output  │ optional, output to transfer bitcoin,  │
+//	│         │              │ present if satoshi transfer is         │
+//	│         │              │ positive and btc recipient differs     │
+//	│         │              │ from runes recipients, otherwise       │
+//	│         │              │ satoshi are added to the first runes   │
+//	│         │              │ recipient output with btc address.     │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│     r+3 │ base output  │ service native commission. optional,   │
 //	│         │              │ charge commission from sender if       │
 //	│         │              │ satoshi commission amount is not 0.    │
 //	├─────────┼──────────────┼────────────────────────────────────────┤
-//	│       4 │ base output  │ outputs to change bitcoin amount.      │
+//	│     r+4 │ base output  │ outputs to change bitcoin amount.      │
 //	│         │              │ 99% mandatory, if any left.            │
 //	└─────────┴──────────────┴────────────────────────────────────────┘
-func (b *TxBuilder) buildBaseTransferRuneTx(params BaseRunesTransferParams) (result BaseRunesTransferResult, _ error) {
+func (b *TxBuilder) buildBaseTransferRuneTx(ctx context.Context, params BaseRunesAndBTCTransferParams) (result BaseRunesTransferResult, _ error) {
+	if err := b.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return result, err
+	}
 	if params.RunesSender == nil {
-		return result, errors.New("runes sender data required")
+		return result, fmt.Errorf("%w: runes sender", ErrMissingSender)
 	}
 	if params.FeePayer == nil {
-		return result, errors.New("fee payer data required")
+		return result, ErrMissingFeePayer
+	}
+
+	recipients, transferRuneAmount, err := runesRecipients(params.BaseRunesTransferParams)
+	if err != nil {
+		return result, err
+	}
+	params.RunesRecipients, params.TransferRuneAmount = recipients, transferRuneAmount
+
+	if b.config.StrictEdicts {
+		if err := validateEdicts(params.BaseRunesTransferParams); err != nil {
+			return result, err
+		}
 	}
 	if params.TransferRuneAmount == nil || numbers.IsNegative(params.TransferRuneAmount) {
 		params.TransferRuneAmount = big.NewInt(0)
@@ -323,9 +575,21 @@ func (b *TxBuilder) buildBaseTransferRuneTx(params BaseRunesTransferParams) (res
 	if params.BurnRuneAmount == nil || numbers.IsNegative(params.BurnRuneAmount) {
 		params.BurnRuneAmount = big.NewInt(0)
 	}
+	if params.TransferSatoshiAmount == nil || numbers.IsNegative(params.TransferSatoshiAmount) {
+		params.TransferSatoshiAmount = big.NewInt(0)
+	}
 
 	totalAllocatingRuneAmount := new(big.Int).Add(params.TransferRuneAmount, params.BurnRuneAmount)
-	runeUTXOs, totalRuneAmount, err := PrepareRuneUTXOs(params.RunesSender.UTXOs, totalAllocatingRuneAmount, params.RuneID)
+	err = b.beforeSelect(ctx, SelectInfo{
+		UTXOs:          params.RunesSender.UTXOs,
+		TransferAmount: totalAllocatingRuneAmount,
+		RuneID:         &params.RuneID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	runeUTXOs, totalRuneAmount, err := prepareRuneUTXOs(ctx, b.config.CoinSelector, params.RunesSender.UTXOs, totalAllocatingRuneAmount, params.RuneID)
 	if err != nil {
 		if errIns := new(InsufficientError); errors.As(err, &errIns) {
 			return result, errIns.setCauser(CauserSender)
@@ -334,21 +598,75 @@ func (b *TxBuilder) buildBaseTransferRuneTx(params BaseRunesTransferParams) (res
 		return result, err
 	}
 
+	extraRuneUTXOs := consolidationRuneUTXOs(params.RunesSender.UTXOs, runeUTXOs, params.RuneID, b.config.ConsolidateRuneChange)
+	// INFO: extra utxos are checked too, since stale consolidated utxo breaks the runes change amount.
+	inputRuneUTXOs := append(runeUTXOs[:len(runeUTXOs):len(runeUTXOs)], extraRuneUTXOs...)
+	if err = checkRuneUTXOs(ctx, b.config.RuneStateOracle, params.RuneID, inputRuneUTXOs); err != nil {
+		return result, err
+	}
+
+	if len(extraRuneUTXOs) != 0 {
+		totalWithExtra := new(big.Int).Set(totalRuneAmount)
+		for _, utxo := range extraRuneUTXOs {
+			totalWithExtra.Add(totalWithExtra, utxo.RuneAmount(params.RuneID))
+		}
+
+		// INFO: consolidation is opportunistic, it is skipped if fee payer can not cover extra inputs.
+		result, err = b.composeRunesTransferTx(ctx, params, append(runeUTXOs[:len(runeUTXOs):len(runeUTXOs)], extraRuneUTXOs...), totalWithExtra)
+		if errIns := new(InsufficientError); err == nil || !errors.As(err, &errIns) {
+			return result, err
+		}
+	}
+
+	return b.composeRunesTransferTx(ctx, params, runeUTXOs, totalRuneAmount)
+}
+
+// runesRecipients returns runes recipients of the transfer and total transferring amount,
+// RunesRecipientAddress receives TransferRuneAmount if RunesRecipients is not set.
+func runesRecipients(params BaseRunesTransferParams) ([]RuneRecipient, *big.Int, error) {
+	if len(params.RunesRecipients) == 0 {
+		if params.TransferRuneAmount == nil || !numbers.IsPositive(params.TransferRuneAmount) {
+			return nil, params.TransferRuneAmount, nil
+		}
+
+		return []RuneRecipient{{Address: params.RunesRecipientAddress, Amount: params.TransferRuneAmount}}, params.TransferRuneAmount, nil
+	}
+
+	total := big.NewInt(0)
+	for i, recipient := range params.RunesRecipients {
+		if recipient.Amount == nil || !numbers.IsPositive(recipient.Amount) {
+			return nil, nil, fmt.Errorf("%w: recipient %d amount must be positive", ErrInvalidRunesRecipients, i)
+		}
+
+		total.Add(total, recipient.Amount)
+	}
+
+	if params.TransferRuneAmount != nil && params.TransferRuneAmount.Sign() != 0 && !numbers.IsEqual(total, params.TransferRuneAmount) {
+		return nil, nil, fmt.Errorf("%w: amounts sum %s does not match transfer amount %s", ErrInvalidRunesRecipients,
+			total.String(), params.TransferRuneAmount.String())
+	}
+
+	return params.RunesRecipients, total, nil
+}
+
+// composeRunesTransferTx constructs rune transferring transaction from selected rune utxos,
+// selects fee payer utxos to cover satoshi outputs and transaction fee.
+func (b *TxBuilder) composeRunesTransferTx(ctx context.Context, params BaseRunesAndBTCTransferParams, runeUTXOs []*bitcoin.UTXO,
+	totalRuneAmount *big.Int) (result BaseRunesTransferResult, _ error) {
+	totalAllocatingRuneAmount := new(big.Int).Add(params.TransferRuneAmount, params.BurnRuneAmount)
 	outputs := 2
 	satTransferAmount := big.NewInt(0)
 	runestone := &runes.Runestone{}
-	isRunesTransferred := false
 
-	// runes transfer output + edict.
-	if numbers.IsPositive(params.TransferRuneAmount) {
-		isRunesTransferred = true
+	// runes transfer outputs + edicts.
+	for idx, recipient := range params.RunesRecipients {
 		outputs++
-		satTransferAmount.Add(satTransferAmount, nonDustBitcoinAmount)
+		satTransferAmount.Add(satTransferAmount, b.config.DustAmount)
 
 		runestone.Edicts = append(runestone.Edicts, runes.Edict{
 			RuneID: params.RuneID,
-			Amount: params.TransferRuneAmount,
-			Output: recipientOutput,
+			Amount: recipient.Amount,
+			Output: uint32(idx + 1), // INFO: runestone is the first output.
 		})
 	}
 	if numbers.IsPositive(params.BurnRuneAmount) {
@@ -362,10 +680,41 @@ func (b *TxBuilder) buildBaseTransferRuneTx(params BaseRunesTransferParams) (res
 	// runes return output.
 	if numbers.IsGreater(totalRuneAmount, totalAllocatingRuneAmount) {
 		outputs++
-		satTransferAmount.Add(satTransferAmount, nonDustBitcoinAmount)
-		runestone.Pointer = &returnOutput
-		if !isRunesTransferred {
-			*runestone.Pointer--
+		satTransferAmount.Add(satTransferAmount, b.config.DustAmount)
+		pointer := uint32(len(params.RunesRecipients) + 1)
+		runestone.Pointer = &pointer
+	}
+
+	// INFO: runestone payload is pushed by a single data push opcode, see [runes.Runestone.IntoScript].
+	runestonePayload, err := runestone.Serialize()
+	if err != nil {
+		return result, err
+	}
+	if len(runestonePayload) > txscript.OP_DATA_75 {
+		return result, fmt.Errorf("%w: payload %d bytes, maximum %d bytes", ErrRunestoneTooLarge, len(runestonePayload), txscript.OP_DATA_75)
+	}
+
+	runestoneData, err := runestone.IntoScript()
+	if err != nil {
+		return result, err
+	}
+
+	// btc transfer output.
+	btcRecipientIdx := -1
+	isBTCTransferred := numbers.IsPositive(params.TransferSatoshiAmount)
+	if isBTCTransferred {
+		satTransferAmount.Add(satTransferAmount, params.TransferSatoshiAmount)
+		btcRecipientIdx = slices.IndexFunc(params.RunesRecipients, func(recipient RuneRecipient) bool {
+			return recipient.Address == params.BTCRecipientAddress
+		})
+		if btcRecipientIdx >= 0 {
+			isBTCTransferred = false
+		} else {
+			if numbers.IsLess(params.TransferSatoshiAmount, b.config.DustAmount) {
+				return result, fmt.Errorf("%w: %s is less than %s", ErrTransferAmountTooLow,
+					params.TransferSatoshiAmount.String(), b.config.DustAmount.String())
+			}
+			outputs++
 		}
 	}
 
@@ -375,7 +724,7 @@ func (b *TxBuilder) buildBaseTransferRuneTx(params BaseRunesTransferParams) (res
 		satTransferAmount.Add(satTransferAmount, params.SatoshiCommissionAmount)
 	}
 
-	prepareUTXOsResult, err := PrepareUTXOs(PrepareUTXOsParams{
+	prepareUTXOsResult, err := b.prepareUTXOs(ctx, PrepareUTXOsParams{
 		Utxos:            params.FeePayer.UTXOs,
 		Inputs:           len(runeUTXOs),
 		Outputs:          outputs,
@@ -390,72 +739,79 @@ func (b *TxBuilder) buildBaseTransferRuneTx(params BaseRunesTransferParams) (res
 		return result, err
 	}
 
-	runestoneData, err := runestone.IntoScript()
-	if err != nil {
-		return result, err
-	}
-
 	tx := wire.NewMsgTx(txVersion)
 	for _, i := range runeUTXOs {
-		utxoHash, err := chainhash.NewHashFromStr(i.TxHash)
-		if err != nil {
-			return result, err
-		}
-
-		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(utxoHash, i.Index), nil, nil))
+		tx.AddTxIn(wire.NewTxIn(i.WireOutPoint(), nil, nil))
 		prepareUTXOsResult.TotalAmount.Add(prepareUTXOsResult.TotalAmount, i.Amount)
 	}
 	for _, i := range prepareUTXOsResult.UsedUTXOs {
-		utxoHash, err := chainhash.NewHashFromStr(i.TxHash)
-		if err != nil {
-			return result, err
-		}
-
-		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(utxoHash, i.Index), nil, nil))
+		tx.AddTxIn(wire.NewTxIn(i.WireOutPoint(), nil, nil))
 	}
 
 	// subtract fee.
 	prepareUTXOsResult.TotalAmount.Sub(prepareUTXOsResult.TotalAmount, prepareUTXOsResult.RoughEstimate)
 
+	roles := make(outputRoles)
+
 	// runestone output (#0).
 	tx.AddTxOut(wire.NewTxOut(0, runestoneData))
+	roles.add(tx, OutputRoleRunestone)
 
-	// recipient runes output (#1).
-	if isRunesTransferred {
-		err = b.addOutput(tx, nonDustBitcoinAmount, prepareUTXOsResult.TotalAmount, params.RunesRecipientAddress)
+	// recipients runes outputs (#1 - #r).
+	for idx, recipient := range params.RunesRecipients {
+		recipientSatoshiAmount := new(big.Int).Set(b.config.DustAmount)
+		if idx == btcRecipientIdx {
+			recipientSatoshiAmount.Add(recipientSatoshiAmount, params.TransferSatoshiAmount)
+		}
+
+		err = b.addOutput(tx, recipientSatoshiAmount, prepareUTXOsResult.TotalAmount, recipient.Address)
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleRecipient)
 	}
 
-	// change runes output (#2).
+	// change runes output (#r+1).
 	if runestone.Pointer != nil {
-		err = b.addOutput(tx, nonDustBitcoinAmount, prepareUTXOsResult.TotalAmount, params.RunesSender.Address)
+		err = b.addOutput(tx, b.config.DustAmount, prepareUTXOsResult.TotalAmount, params.RunesSender.Address)
+		if err != nil {
+			return result, err
+		}
+		roles.add(tx, OutputRoleRunesChange)
+	}
+
+	// recipient btc output (#r+2).
+	if isBTCTransferred {
+		err = b.addOutput(tx, params.TransferSatoshiAmount, prepareUTXOsResult.TotalAmount, params.BTCRecipientAddress)
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleRecipient)
 	}
 
-	// service commission output (#3).
+	// service commission output (#r+3).
 	if params.SatoshiCommissionAmount != nil && numbers.IsPositive(params.SatoshiCommissionAmount) {
 		err = b.addOutput(tx, params.SatoshiCommissionAmount, prepareUTXOsResult.TotalAmount, params.CommissionRecipientAddress)
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleCommission)
 	}
 
-	// change btc output (#4).
-	if numbers.IsPositive(prepareUTXOsResult.TotalAmount) && numbers.IsGreater(prepareUTXOsResult.TotalAmount, nonDustBitcoinAmount) {
+	// change btc output (#r+4).
+	if numbers.IsPositive(prepareUTXOsResult.TotalAmount) && numbers.IsGreater(prepareUTXOsResult.TotalAmount, b.config.DustAmount) {
 		err = b.addOutput(tx, prepareUTXOsResult.TotalAmount, prepareUTXOsResult.TotalAmount, params.FeePayer.Address)
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleChange)
 	}
 
 	result.UnsignedRawTx = tx
 	result.UsedRuneUTXOs = runeUTXOs
 	result.UsedBaseUTXOs = prepareUTXOsResult.UsedUTXOs
 	result.EstimatedFee = prepareUTXOsResult.RoughEstimate
+	result.OutputRoles = roles.list(tx)
 
 	return result, nil
 }
@@ -486,7 +842,7 @@ func (b *TxBuilder) buildRunesTransferPSBT(params BuildRunesTransferPSBTParams)
 		senderIndexes[i] = byte(i)
 	}
 
-	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: runesSenderInputBuilder.InputsHelpingKey(false).Bytes(), Value: senderIndexes})
+	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: b.config.inputsHelpingKey(runesSenderInputBuilder.InputsHelpingKey(false)), Value: senderIndexes})
 
 	shift := len(params.UsedRuneUTXOs) // sender runes utxos inputs shift.
 	feePayerIndexes := make([]byte, len(params.UsedBaseUTXOs))
@@ -497,7 +853,11 @@ func (b *TxBuilder) buildRunesTransferPSBT(params BuildRunesTransferPSBTParams)
 		feePayerIndexes[i] = byte(shift + i)
 	}
 
-	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: feePayerAddressInputBuilder.InputsHelpingKey(true).Bytes(), Value: feePayerIndexes})
+	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: b.config.inputsHelpingKey(feePayerAddressInputBuilder.InputsHelpingKey(true)), Value: feePayerIndexes})
+
+	if b.config.OutputRoles {
+		setOutputRoles(p, params.OutputRoles)
+	}
 
 	w := bytes.NewBuffer(nil)
 	err = p.Serialize(w)
@@ -511,14 +871,37 @@ func (b *TxBuilder) buildRunesTransferPSBT(params BuildRunesTransferPSBTParams)
 // BuildBTCTransferTx constructs btc transferring transaction in PSBT
 // format with inputs indexes assigned in unknown fields. Returns serialized
 // PSBT transaction with used base outputs, estimated fee in satoshi, and error if any.
-func (b *TxBuilder) BuildBTCTransferTx(params BaseBTCTransferParams) (result BuildBTCTransferTxResult, _ error) {
-	buildBaseTransferRuneTxResult, err := b.buildBaseTransferBTCTx(params)
+func (b *TxBuilder) BuildBTCTransferTx(params BaseBTCTransferParams) (BuildBTCTransferTxResult, error) {
+	return b.BuildBTCTransferTxContext(context.Background(), params)
+}
+
+// BuildBTCTransferTxContext is like BuildBTCTransferTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildBTCTransferTxContext(ctx context.Context, params BaseBTCTransferParams) (result BuildBTCTransferTxResult, _ error) {
+	builder := b.snapshot()
+
+	buildBaseTransferRuneTxResult, err := builder.buildBaseTransferBTCTx(ctx, params)
+	if err != nil {
+		return result, err
+	}
+
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindBTCTransfer,
+		UnsignedRawTx: buildBaseTransferRuneTxResult.UnsignedRawTx,
+		UsedUTXOs:     usedUTXOs(buildBaseTransferRuneTxResult.UsedSenderBaseUTXOs, buildBaseTransferRuneTxResult.UsedFeePayerBaseUTXOs),
+		EstimatedFee:  buildBaseTransferRuneTxResult.EstimatedFee,
+	})
 	if err != nil {
 		return result, err
 	}
 
 	result.UsedSenderBaseUTXOs = buildBaseTransferRuneTxResult.UsedSenderBaseUTXOs
 	result.EstimatedFee = buildBaseTransferRuneTxResult.EstimatedFee
+	result.AddressReuseWarnings, err = addressReuseWarnings(ctx, builder.config.AddressHistory,
+		buildBaseTransferRuneTxResult.UnsignedRawTx, buildBaseTransferRuneTxResult.OutputRoles, b.networkParams)
+	if err != nil {
+		return result, err
+	}
 
 	psbtParams := BuildBTCTransferPSBTParams{
 		BaseBTCTransferResult: buildBaseTransferRuneTxResult,
@@ -530,7 +913,7 @@ func (b *TxBuilder) BuildBTCTransferTx(params BaseBTCTransferParams) (result Bui
 		psbtParams.FeePayerAddress = params.FeePayer.Address
 		psbtParams.FeePayerPubKey = params.FeePayer.PubKey
 	}
-	result.SerializedPSBT, err = b.buildBTCTransferPSBT(psbtParams)
+	result.SerializedPSBT, err = builder.buildBTCTransferPSBT(psbtParams)
 	if err != nil {
 		return result, err
 	}
@@ -570,21 +953,39 @@ func (b *TxBuilder) BuildBTCTransferTx(params BaseBTCTransferParams) (result Bui
 //	│         │              │ charge commission from sender if       │
 //	│         │              │ satoshi commission amount is not 0.    │
 //	├─────────┼──────────────┼────────────────────────────────────────┤
-//	│       2 │ base output  │ outputs to change sender's bitcoins    │
+//	│   2 - m │ OP_RETURN    │ optional, data outputs, one per        │
+//	│         │              │ OpReturnData item.                     │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│     m+1 │ P2A          │ optional, zero value ephemeral anchor  │
+//	│         │              │ output, if EphemeralAnchor is set.     │
+//	├─────────┼──────────────┼────────────────────────────────────────┤
+//	│     m+2 │ base output  │ outputs to change sender's bitcoins    │
 //	│         │              │ amount. 99% mandatory, in case         │
 //	│         │              │ any non-dust btc left.                 │
 //	├─────────┼──────────────┼────────────────────────────────────────┤
-//	│       3 │ base output  │ outputs to change fee payer's bitcoins │
+//	│     m+3 │ base output  │ outputs to change fee payer's bitcoins │
 //	│         │              │ amount. optional, in case any non-dust │
 //	│         │              │ btc left and the fee payer data was    │
 //	│         │              │ provided.                              │
 //	└─────────┴──────────────┴────────────────────────────────────────┘
-func (b *TxBuilder) buildBaseTransferBTCTx(params BaseBTCTransferParams) (result BaseBTCTransferResult, _ error) {
+func (b *TxBuilder) buildBaseTransferBTCTx(ctx context.Context, params BaseBTCTransferParams) (result BaseBTCTransferResult, _ error) {
+	if err := b.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return result, err
+	}
 	if params.Sender == nil {
-		return result, errors.New("sender data is required")
+		return result, ErrMissingSender
 	}
 	if len(params.Sender.UTXOs) == 0 {
-		return result, errors.New("sender utxos len: 0")
+		return result, fmt.Errorf("%w: sender", ErrNoUTXOs)
+	}
+
+	opReturnOutputs, opReturnFee, err := b.opReturnOutputs(params.OpReturnData, params.SatoshiPerKVByte)
+	if err != nil {
+		return result, err
+	}
+	if params.EphemeralAnchor && params.SatoshiPerKVByte != nil && params.SatoshiPerKVByte.Sign() != 0 {
+		return result, fmt.Errorf("%w: transaction with anchor must pay zero fee, got %s sat/kvB", ErrInvalidAnchor,
+			params.SatoshiPerKVByte)
 	}
 
 	var (
@@ -605,7 +1006,7 @@ func (b *TxBuilder) buildBaseTransferBTCTx(params BaseBTCTransferParams) (result
 
 	if differentFeePayer {
 		outputs++ // fee payer btc change.
-		senderUTXOsResult, err := PrepareUTXOs(PrepareUTXOsParams{
+		senderUTXOsResult, err := b.prepareUTXOs(ctx, PrepareUTXOsParams{
 			Utxos:          params.Sender.UTXOs,
 			TransferAmount: satTransferAmount,
 		})
@@ -617,12 +1018,13 @@ func (b *TxBuilder) buildBaseTransferBTCTx(params BaseBTCTransferParams) (result
 			return result, err
 		}
 
-		feePayerUTXOsResult, err := PrepareUTXOs(PrepareUTXOsParams{
-			Utxos:            params.FeePayer.UTXOs,
-			Inputs:           len(senderUTXOsResult.UsedUTXOs),
-			Outputs:          outputs,
-			TransferAmount:   big.NewInt(0), // calculate tx fee only.
-			SatoshiPerKVByte: params.SatoshiPerKVByte,
+		feePayerUTXOsResult, err := b.prepareUTXOs(ctx, PrepareUTXOsParams{
+			Utxos:               params.FeePayer.UTXOs,
+			Inputs:              len(senderUTXOsResult.UsedUTXOs),
+			Outputs:             outputs,
+			TransferAmount:      opReturnFee, // calculate tx fee and data outputs fee only.
+			SatoshiPerKVByte:    params.SatoshiPerKVByte,
+			ChangelessTolerance: b.config.AllowChangelessWithinTolerance,
 		})
 		if err != nil {
 			if errIns := new(InsufficientError); errors.As(err, &errIns) {
@@ -635,16 +1037,21 @@ func (b *TxBuilder) buildBaseTransferBTCTx(params BaseBTCTransferParams) (result
 		senderUsedUTXOs = senderUTXOsResult.UsedUTXOs
 		feePayerUsedUTXOs = feePayerUTXOsResult.UsedUTXOs
 		bitcoinAmount = new(big.Int).Add(senderUTXOsResult.TotalAmount, feePayerUTXOsResult.TotalAmount)
-		fee = feePayerUTXOsResult.RoughEstimate
+		fee = new(big.Int).Add(feePayerUTXOsResult.RoughEstimate, opReturnFee)
 		senderChange = new(big.Int).Sub(senderUTXOsResult.TotalAmount, satTransferAmount)
 		feePayerChange = new(big.Int).Sub(feePayerUTXOsResult.TotalAmount, fee)
+		if feePayerUTXOsResult.Changeless {
+			fee.Add(fee, feePayerChange)
+			feePayerChange.SetInt64(0)
+		}
 	} else {
-		senderUTXOsResult, err := PrepareUTXOs(PrepareUTXOsParams{
-			Utxos:            params.Sender.UTXOs,
-			Inputs:           0,
-			Outputs:          outputs,
-			TransferAmount:   satTransferAmount,
-			SatoshiPerKVByte: params.SatoshiPerKVByte,
+		senderUTXOsResult, err := b.prepareUTXOs(ctx, PrepareUTXOsParams{
+			Utxos:               params.Sender.UTXOs,
+			Inputs:              0,
+			Outputs:             outputs,
+			TransferAmount:      new(big.Int).Add(satTransferAmount, opReturnFee),
+			SatoshiPerKVByte:    params.SatoshiPerKVByte,
+			ChangelessTolerance: b.config.AllowChangelessWithinTolerance,
 		})
 		if err != nil {
 			if errIns := new(InsufficientError); errors.As(err, &errIns) {
@@ -656,37 +1063,44 @@ func (b *TxBuilder) buildBaseTransferBTCTx(params BaseBTCTransferParams) (result
 
 		senderUsedUTXOs = senderUTXOsResult.UsedUTXOs
 		bitcoinAmount = senderUTXOsResult.TotalAmount
-		fee = senderUTXOsResult.RoughEstimate
+		fee = new(big.Int).Add(senderUTXOsResult.RoughEstimate, opReturnFee)
 		senderChange = new(big.Int).Sub(senderUTXOsResult.TotalAmount, satTransferAmount)
 		senderChange.Sub(senderChange, fee)
+		if senderUTXOsResult.Changeless {
+			fee.Add(fee, senderChange)
+			senderChange.SetInt64(0)
+		}
 	}
 
 	tx := wire.NewMsgTx(txVersion)
 	for _, i := range senderUsedUTXOs {
-		utxoHash, err := chainhash.NewHashFromStr(i.TxHash)
-		if err != nil {
-			return result, err
-		}
-
-		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(utxoHash, i.Index), nil, nil))
+		tx.AddTxIn(wire.NewTxIn(i.WireOutPoint(), nil, nil))
 	}
 	for _, i := range feePayerUsedUTXOs {
-		utxoHash, err := chainhash.NewHashFromStr(i.TxHash)
-		if err != nil {
-			return result, err
-		}
-
-		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(utxoHash, i.Index), nil, nil))
+		tx.AddTxIn(wire.NewTxIn(i.WireOutPoint(), nil, nil))
 	}
 
 	// subtract fee.
 	bitcoinAmount.Sub(bitcoinAmount, fee)
 
+	roles := make(outputRoles)
+
+	recipientAddress := params.RecipientAddress
+	if bip352.IsAddress(recipientAddress) {
+		var err error
+		recipientAddress, err = b.silentPaymentOutputAddress(recipientAddress, params.SilentPaymentKeys, tx,
+			append(senderUsedUTXOs[:len(senderUsedUTXOs):len(senderUsedUTXOs)], feePayerUsedUTXOs...))
+		if err != nil {
+			return result, err
+		}
+	}
+
 	// recipient btc output (#0).
-	err := b.addOutput(tx, params.TransferSatoshiAmount, bitcoinAmount, params.RecipientAddress)
+	err = b.addOutput(tx, params.TransferSatoshiAmount, bitcoinAmount, recipientAddress)
 	if err != nil {
 		return result, err
 	}
+	roles.add(tx, OutputRoleRecipient)
 
 	// service commission output (#1).
 	if params.SatoshiCommissionAmount != nil && numbers.IsPositive(params.SatoshiCommissionAmount) {
@@ -694,28 +1108,47 @@ func (b *TxBuilder) buildBaseTransferBTCTx(params BaseBTCTransferParams) (result
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleCommission)
 	}
 
-	// sender's change btc output (#2).
-	if numbers.IsGreater(senderChange, nonDustBitcoinAmount) {
+	// data outputs (#2 - #m).
+	for _, output := range opReturnOutputs {
+		tx.AddTxOut(output)
+		roles.add(tx, OutputRoleData)
+	}
+
+	// ephemeral anchor output (#m+1).
+	if params.EphemeralAnchor {
+		tx.AddTxOut(wire.NewTxOut(0, bitcoin.P2AScript()))
+		roles.add(tx, OutputRoleAnchor)
+	}
+
+	// sender's change btc output (#m+2).
+	if numbers.IsGreater(senderChange, b.config.DustAmount) {
 		err = b.addOutput(tx, senderChange, bitcoinAmount, params.Sender.Address)
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleChange)
 	}
 
-	// fee payer's change btc output (#3).
-	if differentFeePayer && numbers.IsGreater(feePayerChange, nonDustBitcoinAmount) {
+	// fee payer's change btc output (#m+3).
+	if differentFeePayer && numbers.IsGreater(feePayerChange, b.config.DustAmount) {
 		err = b.addOutput(tx, feePayerChange, bitcoinAmount, params.FeePayer.Address)
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleChange)
 	}
 
+	b.config.orderInputs(tx)
+	b.config.OutputOrdering.orderOutputs(tx)
+
 	result.UnsignedRawTx = tx
 	result.UsedSenderBaseUTXOs = senderUsedUTXOs
 	result.UsedFeePayerBaseUTXOs = feePayerUsedUTXOs
 	result.EstimatedFee = fee
+	result.OutputRoles = roles.list(tx)
 
 	return result, nil
 }
@@ -744,27 +1177,42 @@ func (b *TxBuilder) buildBTCTransferPSBT(params BuildBTCTransferPSBTParams) ([]b
 		}
 	}
 
+	// INFO: inputs could be reordered, see TxBuilderConfig.InputOrdering.
+	inputs := newInputIndexer(params.UnsignedRawTx)
 	senderIndexes := make([]byte, len(params.UsedSenderBaseUTXOs))
 	for i, utxo := range params.UsedSenderBaseUTXOs {
-		senderInputBuilder.PrepareInput(&(p.Inputs[i]))
-		p.Inputs[i].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
-		p.Inputs[i].SighashType = signHashType
-		senderIndexes[i] = byte(i)
+		index, err := inputs.index(utxo)
+		if err != nil {
+			return nil, err
+		}
+
+		senderInputBuilder.PrepareInput(&(p.Inputs[index]))
+		p.Inputs[index].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
+		p.Inputs[index].SighashType = signHashType
+		senderIndexes[i] = byte(index)
 	}
 
-	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: senderInputBuilder.InputsHelpingKey(false).Bytes(), Value: senderIndexes})
+	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: b.config.inputsHelpingKey(senderInputBuilder.InputsHelpingKey(false)), Value: senderIndexes})
 
 	if len(params.UsedFeePayerBaseUTXOs) != 0 {
-		shift := len(params.UsedSenderBaseUTXOs) // sender utxos inputs shift.
 		feePayerIndexes := make([]byte, len(params.UsedFeePayerBaseUTXOs))
 		for i, utxo := range params.UsedFeePayerBaseUTXOs {
-			feePayerInputBuilder.PrepareInput(&(p.Inputs[shift+i]))
-			p.Inputs[shift+i].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
-			p.Inputs[shift+i].SighashType = signHashType
-			feePayerIndexes[i] = byte(shift + i)
+			index, err := inputs.index(utxo)
+			if err != nil {
+				return nil, err
+			}
+
+			feePayerInputBuilder.PrepareInput(&(p.Inputs[index]))
+			p.Inputs[index].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
+			p.Inputs[index].SighashType = signHashType
+			feePayerIndexes[i] = byte(index)
 		}
 
-		p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: feePayerInputBuilder.InputsHelpingKey(true).Bytes(), Value: feePayerIndexes})
+		p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: b.config.inputsHelpingKey(feePayerInputBuilder.InputsHelpingKey(true)), Value: feePayerIndexes})
+	}
+
+	if b.config.OutputRoles {
+		setOutputRoles(p, params.OutputRoles)
 	}
 
 	w := bytes.NewBuffer(nil)
@@ -780,16 +1228,39 @@ func (b *TxBuilder) buildBTCTransferPSBT(params BuildBTCTransferPSBTParams) ([]b
 // format with inputs indexes assigned in unknown fields. Includes estimated
 // transaction fee for inscription reveal - etching transaction. Returns serialized
 // PSBT transaction with used base outputs, estimated fee in satoshi, and error if any.
-func (b *TxBuilder) BuildInscriptionTx(params BaseInscriptionTxParams) (result BuildInscriptionTxPSBTResult, _ error) {
-	buildBaseInscriptionTxResult, err := b.buildBaseInscriptionTx(params)
+func (b *TxBuilder) BuildInscriptionTx(params BaseInscriptionTxParams) (BuildInscriptionTxPSBTResult, error) {
+	return b.BuildInscriptionTxContext(context.Background(), params)
+}
+
+// BuildInscriptionTxContext is like BuildInscriptionTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildInscriptionTxContext(ctx context.Context, params BaseInscriptionTxParams) (result BuildInscriptionTxPSBTResult, _ error) {
+	builder := b.snapshot()
+
+	buildBaseInscriptionTxResult, err := builder.buildBaseInscriptionTx(ctx, params)
+	if err != nil {
+		return result, err
+	}
+
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindInscription,
+		UnsignedRawTx: buildBaseInscriptionTxResult.UnsignedRawTx,
+		UsedUTXOs:     buildBaseInscriptionTxResult.UsedBaseUTXOs,
+		EstimatedFee:  buildBaseInscriptionTxResult.EstimatedFee,
+	})
 	if err != nil {
 		return result, err
 	}
 
 	result.UsedBaseUTXOs = buildBaseInscriptionTxResult.UsedBaseUTXOs
 	result.EstimatedFee = buildBaseInscriptionTxResult.EstimatedFee
+	result.AddressReuseWarnings, err = addressReuseWarnings(ctx, builder.config.AddressHistory,
+		buildBaseInscriptionTxResult.UnsignedRawTx, buildBaseInscriptionTxResult.OutputRoles, b.networkParams)
+	if err != nil {
+		return result, err
+	}
 
-	result.SerializedPSBT, err = b.buildInscriptionTxPSBT(BuildInscriptionTxPSBTParams{
+	result.SerializedPSBT, err = builder.buildInscriptionTxPSBT(BuildInscriptionTxPSBTParams{
 		BaseInscriptionTxResult: buildBaseInscriptionTxResult,
 		SenderAddress:           params.Sender.Address,
 		SenderPubKey:            params.Sender.PubKey,
@@ -832,47 +1303,44 @@ func (b *TxBuilder) BuildInscriptionTx(params BaseInscriptionTxParams) (result B
 //	│         │              │ amount. 99% mandatory, in case         │
 //	│         │              │ any non-dust btc left.                 │
 //	└─────────┴──────────────┴────────────────────────────────────────┘
-func (b *TxBuilder) buildBaseInscriptionTx(params BaseInscriptionTxParams) (result BaseInscriptionTxResult, err error) {
+func (b *TxBuilder) buildBaseInscriptionTx(ctx context.Context, params BaseInscriptionTxParams) (result BaseInscriptionTxResult, err error) {
+	if err := b.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return result, err
+	}
 	if params.Sender == nil {
-		return result, errors.New("sender data is required")
+		return result, ErrMissingSender
 	}
 	if len(params.Sender.UTXOs) == 0 {
-		return result, errors.New("sender utxos len: 0")
+		return result, fmt.Errorf("%w: sender", ErrNoUTXOs)
+	}
+	if params.EtchingRune != nil {
+		if params.Inscription, err = commitEtchingRune(params.Inscription, params.EtchingRune); err != nil {
+			return result, err
+		}
 	}
-	if params.PremineSplittingFactor == 0 {
-		params.PremineSplittingFactor = 1 // INFO: set to default.
+
+	revealCost, err := b.inscriptionRevealCost(params)
+	if err != nil {
+		return result, err
 	}
 
 	var (
-		outputs                = 2 // inscription commitment + sender btc change.
-		satTransferAmount      = big.NewInt(0)
-		inscriptionAddress     string
-		inscriptionWitnessSize int
-		depositAmount          = big.NewInt(0)
+		outputs           = 2 // inscription commitment + sender btc change.
+		satTransferAmount = big.NewInt(0)
+		depositAmount     = revealCost.CommitAmount // INFO: reveal fee and runes recipient outputs.
 	)
 	if params.SatoshiCommissionAmount != nil && numbers.IsPositive(params.SatoshiCommissionAmount) {
 		outputs++ // internal commission.
 		satTransferAmount.Add(satTransferAmount, params.SatoshiCommissionAmount)
 	}
 
-	inscriptionAddress, err = params.Inscription.IntoAddress(params.InscriptionBasePubKey, b.networkParams)
+	inscriptionAddress, err := inscriptionCommitAddress(params.Inscription, params.InscriptionBasePubKey, params.Recovery, b.networkParams)
 	if err != nil {
 		return result, err
 	}
 
-	inscriptionWitnessSize, err = params.Inscription.VBytesSize()
-	if err != nil {
-		return result, err
-	}
-
-	etchTransactionFee := RoughEtchFeeEstimate(big.NewInt(int64(inscriptionWitnessSize)),
-		params.SatoshiPerKVByte, int(params.PremineSplittingFactor))
-	depositAmount.Add(depositAmount, etchTransactionFee)
-	depositAmount.Add(depositAmount, new(big.Int).Mul(nonDustBitcoinAmount,
-		big.NewInt(int64(params.PremineSplittingFactor)))) // INFO: add runes recipient output.
-
 	satTransferAmount.Add(satTransferAmount, depositAmount)
-	senderUTXOsResult, err := PrepareUTXOs(PrepareUTXOsParams{
+	senderUTXOsResult, err := b.prepareUTXOs(ctx, PrepareUTXOsParams{
 		Utxos:            params.Sender.UTXOs,
 		Inputs:           0,
 		Outputs:          outputs,
@@ -891,22 +1359,20 @@ func (b *TxBuilder) buildBaseInscriptionTx(params BaseInscriptionTxParams) (resu
 
 	tx := wire.NewMsgTx(txVersion)
 	for _, i := range senderUTXOsResult.UsedUTXOs {
-		utxoHash, err := chainhash.NewHashFromStr(i.TxHash)
-		if err != nil {
-			return result, err
-		}
-
-		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(utxoHash, i.Index), nil, nil))
+		tx.AddTxIn(wire.NewTxIn(i.WireOutPoint(), nil, nil))
 	}
 
 	// subtract fee.
 	bitcoinAmount.Sub(bitcoinAmount, senderUTXOsResult.RoughEstimate)
 
+	roles := make(outputRoles)
+
 	// inscription commitment output (#0).
 	err = b.addOutput(tx, depositAmount, bitcoinAmount, inscriptionAddress)
 	if err != nil {
 		return result, err
 	}
+	roles.add(tx, OutputRoleInscriptionCommit)
 
 	// service commission output (#1).
 	if params.SatoshiCommissionAmount != nil && numbers.IsPositive(params.SatoshiCommissionAmount) {
@@ -914,19 +1380,24 @@ func (b *TxBuilder) buildBaseInscriptionTx(params BaseInscriptionTxParams) (resu
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleCommission)
 	}
 
 	// sender's change btc output (#2).
-	if numbers.IsGreater(bitcoinAmount, nonDustBitcoinAmount) {
+	if numbers.IsGreater(bitcoinAmount, b.config.DustAmount) {
 		err = b.addOutput(tx, bitcoinAmount, bitcoinAmount, params.Sender.Address)
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleChange)
 	}
 
+	b.config.orderInputs(tx)
+
 	result.UnsignedRawTx = tx
 	result.UsedBaseUTXOs = senderUTXOsResult.UsedUTXOs
 	result.EstimatedFee = senderUTXOsResult.RoughEstimate
+	result.OutputRoles = roles.list(tx)
 
 	return result, nil
 }
@@ -944,15 +1415,26 @@ func (b *TxBuilder) buildInscriptionTxPSBT(params BuildInscriptionTxPSBTParams)
 		return nil, err
 	}
 
+	// INFO: inputs could be reordered, see TxBuilderConfig.InputOrdering.
+	inputs := newInputIndexer(params.UnsignedRawTx)
 	senderIndexes := make([]byte, len(params.UsedBaseUTXOs))
 	for i, utxo := range params.UsedBaseUTXOs {
-		senderInputBuilder.PrepareInput(&(p.Inputs[i]))
-		p.Inputs[i].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
-		p.Inputs[i].SighashType = signHashType
-		senderIndexes[i] = byte(i)
+		index, err := inputs.index(utxo)
+		if err != nil {
+			return nil, err
+		}
+
+		senderInputBuilder.PrepareInput(&(p.Inputs[index]))
+		p.Inputs[index].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
+		p.Inputs[index].SighashType = signHashType
+		senderIndexes[i] = byte(index)
 	}
 
-	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: senderInputBuilder.InputsHelpingKey(false).Bytes(), Value: senderIndexes})
+	p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: b.config.inputsHelpingKey(senderInputBuilder.InputsHelpingKey(false)), Value: senderIndexes})
+
+	if b.config.OutputRoles {
+		setOutputRoles(p, params.OutputRoles)
+	}
 
 	w := bytes.NewBuffer(nil)
 	err = p.Serialize(w)
@@ -968,14 +1450,37 @@ func (b *TxBuilder) buildInscriptionTxPSBT(params BuildInscriptionTxPSBTParams)
 // charged from inscription commitment utxo, if there won't be enough, the additional
 // payment data will be used to cover transaction fee. Returns serialized
 // PSBT transaction with used base outputs, estimated fee in satoshi, and error if any.
-func (b *TxBuilder) BuildRuneEtchTx(params BaseRuneEtchTxParams) (result BuildRuneEtchTxPSBTResult, _ error) {
-	buildBaseTransferRuneTxResult, err := b.buildRuneEtchTx(params)
+func (b *TxBuilder) BuildRuneEtchTx(params BaseRuneEtchTxParams) (BuildRuneEtchTxPSBTResult, error) {
+	return b.BuildRuneEtchTxContext(context.Background(), params)
+}
+
+// BuildRuneEtchTxContext is like BuildRuneEtchTx, but stops utxos selection with
+// context error if the context is canceled or its deadline is exceeded.
+func (b *TxBuilder) BuildRuneEtchTxContext(ctx context.Context, params BaseRuneEtchTxParams) (result BuildRuneEtchTxPSBTResult, _ error) {
+	builder := b.snapshot()
+
+	buildBaseTransferRuneTxResult, err := builder.buildRuneEtchTx(ctx, params)
+	if err != nil {
+		return result, err
+	}
+
+	err = builder.afterBuild(ctx, BuildInfo{
+		Kind:          BuildKindRuneEtch,
+		UnsignedRawTx: buildBaseTransferRuneTxResult.UnsignedRawTx,
+		UsedUTXOs:     usedUTXOs([]*bitcoin.UTXO{&buildBaseTransferRuneTxResult.InscriptionUTXO}, buildBaseTransferRuneTxResult.UsedAdditionalBaseUTXOs),
+		EstimatedFee:  buildBaseTransferRuneTxResult.EstimatedFee,
+	})
 	if err != nil {
 		return result, err
 	}
 
 	result.UsedAdditionalBaseUTXOs = buildBaseTransferRuneTxResult.UsedAdditionalBaseUTXOs
 	result.EstimatedFee = buildBaseTransferRuneTxResult.EstimatedFee
+	result.AddressReuseWarnings, err = addressReuseWarnings(ctx, builder.config.AddressHistory,
+		buildBaseTransferRuneTxResult.UnsignedRawTx, buildBaseTransferRuneTxResult.OutputRoles, b.networkParams)
+	if err != nil {
+		return result, err
+	}
 
 	inscriptionAddress, err := params.Inscription.IntoAddress(params.InscriptionReveal.PubKey, b.networkParams)
 	if err != nil {
@@ -992,7 +1497,7 @@ func (b *TxBuilder) BuildRuneEtchTx(params BaseRuneEtchTxParams) (result BuildRu
 		buildRuneEtchTxPSBTParams.AdditionalPaymentsPubKey = params.AdditionalPayments.PubKey
 	}
 
-	result.SerializedPSBT, err = b.buildRuneEtchTxPSBT(buildRuneEtchTxPSBTParams)
+	result.SerializedPSBT, err = builder.buildRuneEtchTxPSBT(buildRuneEtchTxPSBTParams)
 	if err != nil {
 		return result, err
 	}
@@ -1027,23 +1532,73 @@ func (b *TxBuilder) BuildRuneEtchTx(params BaseRuneEtchTxParams) (result BuildRu
 //	│ 1 - psf │ rune output  │ mandatory, output to link runes        │
 //	│         │              │ to recipient.                          │
 //	│         │              │       (psf - premine slpitting factor) │
+//	│         │              │ or premine distribution allocations    │
+//	│         │              │ count.                                 │
 //	├─────────┼──────────────┼────────────────────────────────────────┤
 //	│ psf + 1 │ base output  │ outputs to change bitcoin amount.      │
 //	│         │              │ 99% mandatory, if any non-dust left.   │
 //	└─────────┴──────────────┴────────────────────────────────────────┘
-func (b *TxBuilder) buildRuneEtchTx(params BaseRuneEtchTxParams) (result BaseRuneEtchTxResult, err error) {
+func (b *TxBuilder) buildRuneEtchTx(ctx context.Context, params BaseRuneEtchTxParams) (result BaseRuneEtchTxResult, err error) {
+	if err := b.config.checkFeeRate(params.SatoshiPerKVByte); err != nil {
+		return result, err
+	}
 	if params.InscriptionReveal == nil {
-		return result, errors.New("inscription reveal data is required")
+		return result, ErrMissingInscriptionReveal
 	}
 	if params.Inscription == nil {
-		return result, errors.New("inscription data is required")
+		return result, ErrMissingInscription
 	}
-	if params.Rune != nil && params.Rune.Premine != nil && numbers.IsPositive(params.Rune.Premine) &&
+	if params.Rune == nil {
+		return result, ErrMissingRuneEtching
+	}
+	if params.Rune.Premine != nil && numbers.IsPositive(params.Rune.Premine) &&
 		params.PremineSplittingFactor > 1 && numbers.IsGreater(big.NewInt(int64(params.PremineSplittingFactor)), params.Rune.Premine) {
-		return result, errors.New("premine splitting factor is grater then premine")
+		return result, ErrInvalidPremineSplittingFactor
+	}
+	var premineAmounts []*big.Int
+	if len(params.PremineDistribution) != 0 {
+		premineAmounts, err = distributePremine(params.Rune.Premine, params.PremineSplittingFactor, params.PremineDistribution)
+		if err != nil {
+			return result, err
+		}
 	}
 	if len(params.InscriptionReveal.UTXOs) != 1 {
-		return result, fmt.Errorf("invalid inscription utxo data len: %d, must be: 1", len(params.InscriptionReveal.UTXOs))
+		return result, fmt.Errorf("%w: len: %d, must be: 1", ErrInvalidInscriptionUTXOs, len(params.InscriptionReveal.UTXOs))
+	}
+	if params.Rune.Rune != nil {
+		if err = validateEtchingRuneName(params.Rune.Rune, params.CurrentBlockHeight); err != nil {
+			return result, err
+		}
+		if err = checkRuneCommitment(params.Inscription, params.Rune.Rune); err != nil {
+			return result, err
+		}
+		if params.CommitBlockHeight != 0 && params.CurrentBlockHeight != 0 {
+			// INFO: Reveal transaction can not be included earlier than in the next block.
+			if height := runes.CommitMaturityHeight(params.CommitBlockHeight); params.CurrentBlockHeight+1 < height {
+				return result, fmt.Errorf("%w: reveal can be included at %d, next block is %d", ErrImmatureRuneCommitment,
+					height, params.CurrentBlockHeight+1)
+			}
+		}
+		if params.Rune.Spacers != nil {
+			if err = runes.ValidateSpacers(len(params.Rune.Rune.String()), *params.Rune.Spacers); err != nil {
+				return result, err
+			}
+		}
+	}
+	if params.Rune.Terms != nil {
+		if err = validateEtchingTerms(params.Rune, params.CurrentBlockHeight); err != nil {
+			return result, err
+		}
+	}
+	// INFO: Reveal of the utxo not committing to the inscription is unspendable.
+	if err = b.VerifyCommitUTXO(params.InscriptionReveal.UTXOs[0], params.Inscription, params.InscriptionReveal.PubKey,
+		params.Recovery); err != nil {
+		return result, err
+	}
+
+	postage, err := b.postage(params.Postage)
+	if err != nil {
+		return result, err
 	}
 
 	var (
@@ -1057,18 +1612,21 @@ func (b *TxBuilder) buildRuneEtchTx(params BaseRuneEtchTxParams) (result BaseRun
 	if params.Rune.Premine != nil && numbers.IsPositive(params.Rune.Premine) && params.PremineSplittingFactor > 1 {
 		runeOutputs = int(params.PremineSplittingFactor)
 	}
+	if len(params.PremineDistribution) != 0 {
+		runeOutputs = len(params.PremineDistribution)
+	}
 
 	totalOutputs += runeOutputs
 
 	bitcoinAmount := new(big.Int).Set(params.InscriptionReveal.UTXOs[0].Amount)
 
-	inscriptionWitnessSize, err = params.Inscription.VBytesSize()
+	inscriptionWitnessSize, err = inscriptionWitnessVBytes(params.Inscription, params.Recovery)
 	if err != nil {
 		return result, err
 	}
 
-	etchTransactionFee := RoughEtchFeeEstimate(big.NewInt(int64(inscriptionWitnessSize)), params.SatoshiPerKVByte, runeOutputs)
-	transferAmount := new(big.Int).Add(etchTransactionFee, new(big.Int).Mul(nonDustBitcoinAmount, big.NewInt(int64(runeOutputs))))
+	etchTransactionFee := etchFeeEstimate(b.config.SizeEstimator, big.NewInt(int64(inscriptionWitnessSize)), params.SatoshiPerKVByte, runeOutputs)
+	transferAmount := new(big.Int).Add(etchTransactionFee, new(big.Int).Mul(postage, big.NewInt(int64(runeOutputs))))
 	if numbers.IsGreater(transferAmount, params.InscriptionReveal.UTXOs[0].Amount) {
 		if params.AdditionalPayments == nil {
 			return result, InsufficientNativeBalanceError.
@@ -1076,7 +1634,7 @@ func (b *TxBuilder) buildRuneEtchTx(params BaseRuneEtchTxParams) (result BaseRun
 				setCauser(CauserSender)
 		}
 
-		prepareUTXOsResult, err = PrepareUTXOs(PrepareUTXOsParams{
+		prepareUTXOsResult, err = b.prepareUTXOs(ctx, PrepareUTXOsParams{
 			Utxos:            params.AdditionalPayments.UTXOs,
 			Inputs:           1,
 			Outputs:          0,
@@ -1097,31 +1655,35 @@ func (b *TxBuilder) buildRuneEtchTx(params BaseRuneEtchTxParams) (result BaseRun
 
 	tx := wire.NewMsgTx(txVersion)
 	for _, i := range append([]*bitcoin.UTXO{&params.InscriptionReveal.UTXOs[0]}, prepareUTXOsResult.UsedUTXOs...) {
-		utxoHash, err := chainhash.NewHashFromStr(i.TxHash)
-		if err != nil {
-			return result, err
-		}
-
-		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(utxoHash, i.Index), nil, nil))
+		tx.AddTxIn(wire.NewTxIn(i.WireOutPoint(), nil, nil))
 	}
 
 	// subtract fee.
 	bitcoinAmount.Sub(bitcoinAmount, etchTransactionFee)
 
+	roles := make(outputRoles)
+
 	// recipient runes output (#1 - psf).
 	for i := 0; i < runeOutputs; i++ {
-		err = b.addOutput(tx, nonDustBitcoinAmount, bitcoinAmount, params.RunesRecipientAddress)
+		recipientAddress := params.RunesRecipientAddress
+		if len(params.PremineDistribution) != 0 {
+			recipientAddress = params.PremineDistribution[i].Address
+		}
+
+		err = b.addOutput(tx, postage, bitcoinAmount, recipientAddress)
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleRecipient)
 	}
 
 	// change btc output (#psf+1).
-	if numbers.IsPositive(bitcoinAmount) && numbers.IsGreater(bitcoinAmount, nonDustBitcoinAmount) {
+	if numbers.IsPositive(bitcoinAmount) && numbers.IsGreater(bitcoinAmount, b.config.DustAmount) {
 		err = b.addOutput(tx, bitcoinAmount, bitcoinAmount, params.SatoshiChangeAddress)
 		if err != nil {
 			return result, err
 		}
+		roles.add(tx, OutputRoleChange)
 
 		totalOutputs++
 	}
@@ -1130,7 +1692,18 @@ func (b *TxBuilder) buildRuneEtchTx(params BaseRuneEtchTxParams) (result BaseRun
 		Etching: params.Rune,
 		Pointer: &pointerValue,
 	}
-	if runeOutputs > 1 {
+	switch {
+	case len(params.PremineDistribution) != 0:
+		// INFO: runestone output is prepended, so recipient outputs start from 1.
+		runestone.Pointer = nil
+		for i, amount := range premineAmounts {
+			runestone.Edicts = append(runestone.Edicts, runes.Edict{
+				RuneID: runes.RuneID{},
+				Amount: amount,
+				Output: uint32(i + 1),
+			})
+		}
+	case runeOutputs > 1:
 		runestone.Pointer = nil
 		quo, rem := new(big.Int).QuoRem(params.Rune.Premine, big.NewInt(int64(runeOutputs)), new(big.Int))
 		if !numbers.IsZero(rem) {
@@ -1146,6 +1719,13 @@ func (b *TxBuilder) buildRuneEtchTx(params BaseRuneEtchTxParams) (result BaseRun
 			Output: uint32(totalOutputs),
 		})
 	}
+	if params.RunesPointer != nil {
+		// INFO: runestone output is prepended, so it is included into totalOutputs.
+		if *params.RunesPointer == 0 || int(*params.RunesPointer) >= totalOutputs {
+			return result, fmt.Errorf("%w: %d, outputs: %d", ErrInvalidRunesPointer, *params.RunesPointer, totalOutputs)
+		}
+		runestone.Pointer = params.RunesPointer
+	}
 
 	runestoneData, err := runestone.IntoScript()
 	if err != nil {
@@ -1154,16 +1734,177 @@ func (b *TxBuilder) buildRuneEtchTx(params BaseRuneEtchTxParams) (result BaseRun
 
 	// runestone output (#0).
 	tx.TxOut = append([]*wire.TxOut{wire.NewTxOut(0, runestoneData)}, tx.TxOut...)
+	roles[tx.TxOut[0]] = OutputRoleRunestone
+
+	if err = checkRevealTxWeight(tx, params.Inscription, params.Recovery); err != nil {
+		return result, err
+	}
 
 	result.UnsignedRawTx = tx
 	result.InscriptionReveal = params.Inscription
 	result.InscriptionUTXO = params.InscriptionReveal.UTXOs[0]
 	result.UsedAdditionalBaseUTXOs = prepareUTXOsResult.UsedUTXOs
 	result.EstimatedFee = etchTransactionFee
+	result.OutputRoles = roles.list(tx)
 
 	return result, nil
 }
 
+// postage returns inscription and runes outputs amount, dust amount if postage is not set.
+// Returns ErrPostageTooLow if postage is less than the dust amount.
+func (b *TxBuilder) postage(postage *big.Int) (*big.Int, error) {
+	if postage == nil {
+		return b.config.DustAmount, nil
+	}
+
+	if numbers.IsLess(postage, b.config.DustAmount) {
+		return nil, fmt.Errorf("%w: %s is less than %s", ErrPostageTooLow, postage.String(), b.config.DustAmount.String())
+	}
+
+	return postage, nil
+}
+
+// distributePremine checks that premine distribution is not combined with splitting factor and returns
+// amounts of the allocations: set ones or equal split of the premine rest, the remainder goes to the first
+// of the allocations without amount. Amounts must be positive and their sum must equal premine.
+// INFO: zero edict amount allocates all remaining runes, so it is not allowed.
+func distributePremine(premine *big.Int, premineSplittingFactor uint, distribution []PremineAllocation) ([]*big.Int, error) {
+	if premineSplittingFactor > 1 {
+		return nil, fmt.Errorf("%w: can not be used with premine splitting factor", ErrInvalidPremineDistribution)
+	}
+	if premine == nil {
+		return nil, fmt.Errorf("%w: premine is not set", ErrInvalidPremineDistribution)
+	}
+
+	var (
+		amounts = make([]*big.Int, len(distribution))
+		sum     = big.NewInt(0)
+		split   []int
+	)
+	for i, allocation := range distribution {
+		if allocation.Amount == nil {
+			split = append(split, i)
+			continue
+		}
+		if !numbers.IsPositive(allocation.Amount) {
+			return nil, fmt.Errorf("%w: allocation %d amount must be positive", ErrInvalidPremineDistribution, i)
+		}
+
+		amounts[i] = new(big.Int).Set(allocation.Amount)
+		sum.Add(sum, allocation.Amount)
+	}
+
+	if len(split) != 0 {
+		rest := new(big.Int).Sub(premine, sum)
+		quo, rem := new(big.Int).QuoRem(rest, big.NewInt(int64(len(split))), new(big.Int))
+		if !numbers.IsPositive(quo) {
+			return nil, fmt.Errorf("%w: premine rest %s can not be split between %d allocations",
+				ErrInvalidPremineDistribution, rest, len(split))
+		}
+
+		for _, i := range split {
+			amounts[i] = new(big.Int).Set(quo)
+		}
+		amounts[split[0]].Add(amounts[split[0]], rem)
+		sum.Set(premine)
+	}
+
+	if !numbers.IsEqual(sum, premine) {
+		return nil, fmt.Errorf("%w: allocations sum %s is not equal to premine %v", ErrInvalidPremineDistribution, sum, premine)
+	}
+
+	return amounts, nil
+}
+
+// validateEtchingTerms returns ErrInvalidEtchingTerms if the etching max supply overflows, so indexers
+// treat runestone as a cenotaph, or open mint terms would not allow any mint after the next block.
+func validateEtchingTerms(etching *runes.Etching, currentBlockHeight uint64) error {
+	if _, err := etching.MaxSupply(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEtchingTerms, err)
+	}
+
+	terms := etching.Terms
+	switch {
+	case terms.Amount == nil || !numbers.IsPositive(terms.Amount):
+		return fmt.Errorf("%w: mint amount must be positive", ErrInvalidEtchingTerms)
+	case terms.Cap == nil || !numbers.IsPositive(terms.Cap):
+		return fmt.Errorf("%w: mint cap must be positive", ErrInvalidEtchingTerms)
+	case terms.HeightStart != nil && terms.HeightEnd != nil && *terms.HeightStart >= *terms.HeightEnd:
+		return fmt.Errorf("%w: height start %d is not less than end %d", ErrInvalidEtchingTerms,
+			*terms.HeightStart, *terms.HeightEnd)
+	case terms.OffsetEnd != nil && *terms.OffsetEnd == 0:
+		return fmt.Errorf("%w: offset end must be positive", ErrInvalidEtchingTerms)
+	case terms.OffsetStart != nil && terms.OffsetEnd != nil && *terms.OffsetStart >= *terms.OffsetEnd:
+		return fmt.Errorf("%w: offset start %d is not less than end %d", ErrInvalidEtchingTerms,
+			*terms.OffsetStart, *terms.OffsetEnd)
+	}
+
+	// INFO: etching could be included in the next block the earliest.
+	if currentBlockHeight != 0 {
+		etchingBlock := currentBlockHeight + 1
+		if end, ok := terms.End(etchingBlock); ok && end <= etchingBlock {
+			return fmt.Errorf("%w: mint ends at %d, etching block is %d", ErrInvalidEtchingTerms, end, etchingBlock)
+		}
+	}
+
+	return nil
+}
+
+// commitEtchingRune returns copy of the inscription committing to the etching rune, returns
+// ErrMissingRuneCommitment if the inscription already commits to another rune.
+func commitEtchingRune(inscription *inscriptions.Inscription, rune_ *runes.Rune) (*inscriptions.Inscription, error) {
+	if inscription == nil {
+		return nil, ErrMissingInscription
+	}
+	if inscription.Rune != nil && !numbers.IsEqual(inscription.Rune.Value(), rune_.Value()) {
+		return nil, fmt.Errorf("%w: %s, inscription commits to %s", ErrMissingRuneCommitment, rune_.String(),
+			inscription.Rune.String())
+	}
+
+	return inscription.CommitRune(rune_), nil
+}
+
+// checkRuneCommitment returns ErrMissingRuneCommitment if inscription reveal script does not push
+// the rune commitment, so indexers would not accept etching of the rune name, see [inscriptions.Inscription.Rune].
+func checkRuneCommitment(inscription *inscriptions.Inscription, rune_ *runes.Rune) error {
+	// INFO: x-only public key is used in witness script, its value does not affect the commitment.
+	script, err := inscription.IntoScriptForWitness(make([]byte, 32))
+	if err != nil {
+		return err
+	}
+
+	if !runes.ScriptCommitsToRune(script, rune_) {
+		return fmt.Errorf("%w: %s", ErrMissingRuneCommitment, rune_.String())
+	}
+
+	return nil
+}
+
+// validateEtchingRuneName checks that rune name is not reserved and, if currentBlockHeight
+// is set, that the name is unlocked for etching in the next block.
+func validateEtchingRuneName(rune_ *runes.Rune, currentBlockHeight uint64) error {
+	if rune_.IsReserved() {
+		return fmt.Errorf("%w: %s", ErrReservedRuneName, rune_.String())
+	}
+
+	if currentBlockHeight == 0 {
+		return nil
+	}
+
+	// INFO: Reveal transaction can not be included earlier than in the next block.
+	height := currentBlockHeight + 1
+	if !rune_.IsUnlockedAt(height) {
+		return &RuneNameLockedError{
+			Rune:          rune_.String(),
+			Height:        height,
+			UnlockHeight:  rune_.UnlockHeight(),
+			MinNameLength: runes.MinNameLength(height),
+		}
+	}
+
+	return nil
+}
+
 // buildRuneEtchTxPSBT returns serialised PSBT from unsigned inscription reveal - etch transaction
 // with indexes provided in Unknowns field defining indexes of inputs with different types.
 func (b *TxBuilder) buildRuneEtchTxPSBT(params BuildRuneEtchTxPSBTParams) ([]byte, error) {
@@ -1217,7 +1958,11 @@ func (b *TxBuilder) buildRuneEtchTxPSBT(params BuildRuneEtchTxPSBTParams) ([]byt
 			indexes[i] = byte(i + 1)
 		}
 
-		p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: additionalPaymentInputBuilder.InputsHelpingKey(true).Bytes(), Value: indexes})
+		p.Unknowns = append(p.Unknowns, &psbt.Unknown{Key: b.config.inputsHelpingKey(additionalPaymentInputBuilder.InputsHelpingKey(true)), Value: indexes})
+	}
+
+	if b.config.OutputRoles {
+		setOutputRoles(p, params.OutputRoles)
 	}
 
 	w := bytes.NewBuffer(nil)
@@ -1231,41 +1976,67 @@ func (b *TxBuilder) buildRuneEtchTxPSBT(params BuildRuneEtchTxPSBTParams) ([]byt
 
 // PrepareUTXOs selects utxos to cover rough estimated fee.
 // Returns used utxos, total satoshi amount of utxos, rough estimation in satoshi and error if any.
-func PrepareUTXOs(params PrepareUTXOsParams) (result PrepareUTXOsResult, err error) {
+func PrepareUTXOs(params PrepareUTXOsParams) (PrepareUTXOsResult, error) {
+	return PrepareUTXOsContext(context.Background(), params)
+}
+
+// PrepareUTXOsContext is like PrepareUTXOs, but returns context error
+// if the context is canceled or its deadline is exceeded during selection.
+func PrepareUTXOsContext(ctx context.Context, params PrepareUTXOsParams) (result PrepareUTXOsResult, err error) {
 	satFn := func(u *bitcoin.UTXO) *big.Int { return u.Amount }
+	if params.SizeEstimator == nil {
+		params.SizeEstimator = DefaultSizeEstimator()
+	}
 
 	var fullParams = !(params.SatoshiPerKVByte == nil && params.Inputs == 0 && params.Outputs == 0)
-	for i := 1; i <= len(params.Utxos); i++ {
-		if fullParams {
+	minAmountFnByOutputs := func(outputs int) func(inputs int) *big.Int {
+		return func(inputs int) *big.Int {
+			if !fullParams {
+				return new(big.Int).Set(params.TransferAmount)
+			}
+
 			// INFO: vB * ( sat / kvB ) = 1000 sat.
-			result.RoughEstimate = new(big.Int).Mul(RoughTxSizeEstimate(i+params.Inputs, params.Outputs),
+			result.RoughEstimate = new(big.Int).Mul(params.SizeEstimator.TxSize(inputs+params.Inputs, outputs),
 				params.SatoshiPerKVByte)
 			result.RoughEstimate.Div(result.RoughEstimate, big.NewInt(1000)) // sat.
 
-			result.UsedUTXOs, result.TotalAmount, err = SelectUTXO(params.Utxos, satFn,
-				new(big.Int).Add(result.RoughEstimate, params.TransferAmount), i, InsufficientNativeBalanceError)
-		} else {
-			result.UsedUTXOs, result.TotalAmount, err = SelectUTXO(params.Utxos, satFn,
-				new(big.Int).Set(params.TransferAmount), i, InsufficientNativeBalanceError)
+			return new(big.Int).Add(result.RoughEstimate, params.TransferAmount)
 		}
-		if err != nil {
-			if errors.As(err, new(*InsufficientError)) && i != len(params.Utxos) {
-				continue
+	}
+	minAmountFn := minAmountFnByOutputs(params.Outputs)
+
+	// INFO: the last output is considered as change output.
+	if params.ChangelessTolerance != nil && fullParams && params.Outputs > 1 {
+		var ok bool
+		result.UsedUTXOs, result.TotalAmount, ok, err = selectChangelessUTXOs(ctx, params.Utxos, satFn,
+			minAmountFnByOutputs(params.Outputs-1), params.ChangelessTolerance)
+		if err != nil || ok {
+			result.Changeless = ok
+			if err == nil && params.CopyUTXOs {
+				result.UsedUTXOs = cloneUTXOs(result.UsedUTXOs)
 			}
 
 			return result, err
 		}
+	}
 
-		return result, nil
+	result.UsedUTXOs, result.TotalAmount, err = selectUTXOs(ctx, params.CoinSelector, params.Utxos, satFn, minAmountFn,
+		InsufficientNativeBalanceError)
+	if err == nil && params.CopyUTXOs {
+		result.UsedUTXOs = cloneUTXOs(result.UsedUTXOs)
 	}
 
-	// INFO: vB * ( sat / kvB ) = 1000 sat.
-	result.RoughEstimate = new(big.Int).Mul(RoughTxSizeEstimate(1+params.Inputs, params.Outputs),
-		params.SatoshiPerKVByte)
-	result.RoughEstimate.Div(result.RoughEstimate, big.NewInt(1000)) // sat.
-	need := new(big.Int).Add(result.RoughEstimate, params.TransferAmount)
+	return result, err
+}
+
+// cloneUTXOs returns deep copies of the utxos.
+func cloneUTXOs(utxos []*bitcoin.UTXO) []*bitcoin.UTXO {
+	clones := make([]*bitcoin.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		clones = append(clones, utxo.Clone())
+	}
 
-	return result, InsufficientNativeBalanceError.clarify(need, big.NewInt(0))
+	return clones
 }
 
 // PrepareUTXOsParams defines parameters for PrepareUTXOs function.
@@ -1279,6 +2050,14 @@ type PrepareUTXOsParams struct {
 	Outputs          int
 	TransferAmount   *big.Int
 	SatoshiPerKVByte *big.Int
+	SizeEstimator    SizeEstimator // optional, DefaultSizeEstimator is used if not set.
+	CoinSelector     CoinSelector  // optional, single pass SelectUTXO based selection is used if not set.
+	// ChangelessTolerance is a maximum excess in satoshi paid as fee instead of the change output,
+	// optional. The last of Outputs is considered as change output.
+	ChangelessTolerance *big.Int
+	// CopyUTXOs makes UsedUTXOs point to deep copies of the selected utxos instead of the Utxos elements,
+	// so the result stays valid after the Utxos slice is sorted, appended or its elements are modified.
+	CopyUTXOs bool
 }
 
 // PrepareUTXOsResult describes result of the PrepareUTXOs function.
@@ -1286,53 +2065,108 @@ type PrepareUTXOsParams struct {
 // all values of the PrepareUTXOsResult will be created. Otherwise,
 // RoughEstimate will be zero on nil.
 type PrepareUTXOsResult struct {
-	UsedUTXOs     []*bitcoin.UTXO
+	UsedUTXOs     []*bitcoin.UTXO // pointers to the params Utxos elements, unless CopyUTXOs is set.
 	TotalAmount   *big.Int
 	RoughEstimate *big.Int
+	Changeless    bool // utxos cover transfer within ChangelessTolerance, RoughEstimate is without change output.
 }
 
 // PrepareRuneUTXOs selects utxos to cover rune transfer amount.
 // Returns used utxos, total rune amount of utxos and error if any.
+// NOTE: Used utxos alias the utxos slice elements, see SelectUTXO for details.
 func PrepareRuneUTXOs(utxos []bitcoin.UTXO, transferAmount *big.Int, runeID runes.RuneID) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, err error) {
-	runeFn := func(u *bitcoin.UTXO) *big.Int {
-		for _, rune_ := range u.Runes {
-			if rune_.RuneID == runeID {
-				return rune_.Amount
-			}
-		}
+	return prepareRuneUTXOs(context.Background(), nil, utxos, transferAmount, runeID)
+}
 
-		return big.NewInt(0)
+// PrepareRuneUTXOsContext is like PrepareRuneUTXOs, but returns context error
+// if the context is canceled or its deadline is exceeded during selection.
+func PrepareRuneUTXOsContext(ctx context.Context, utxos []bitcoin.UTXO, transferAmount *big.Int,
+	runeID runes.RuneID) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, err error) {
+	return prepareRuneUTXOs(ctx, nil, utxos, transferAmount, runeID)
+}
+
+// prepareRuneUTXOs selects utxos to cover rune transfer amount with provided selection algorithm,
+// single pass SelectUTXO based selection is used if selector is nil.
+func prepareRuneUTXOs(ctx context.Context, selector CoinSelector, utxos []bitcoin.UTXO, transferAmount *big.Int,
+	runeID runes.RuneID) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, err error) {
+	runeFn := func(u *bitcoin.UTXO) *big.Int { return u.RuneAmount(runeID) }
+	minAmountFn := func(int) *big.Int { return transferAmount }
+
+	return selectUTXOs(ctx, selector, utxos, runeFn, minAmountFn, InsufficientRuneBalanceError)
+}
+
+// consolidationRuneUTXOs returns up to limit unused utxos linked to the rune only, starting from
+// the smallest rune amount ones, to sweep them into the runes change output.
+func consolidationRuneUTXOs(utxos []bitcoin.UTXO, used []*bitcoin.UTXO, runeID runes.RuneID, limit int) []*bitcoin.UTXO {
+	if limit <= 0 {
+		return nil
 	}
 
-	for i := 1; i <= len(utxos); i++ {
-		usedUTXOs, totalAmount, err = SelectUTXO(utxos, runeFn, transferAmount, i, InsufficientRuneBalanceError)
-		if err != nil {
-			if errors.As(err, new(*InsufficientError)) && i != len(utxos) {
-				continue
-			}
+	isUsed := make(map[*bitcoin.UTXO]struct{}, len(used))
+	for _, utxo := range used {
+		isUsed[utxo] = struct{}{}
+	}
 
-			return nil, nil, err
+	var extra []*bitcoin.UTXO
+	for i := range utxos {
+		utxo := &utxos[i]
+		if _, ok := isUsed[utxo]; ok || len(utxo.Runes) != 1 || utxo.Runes[0].RuneID != runeID ||
+			!numbers.IsPositive(utxo.Runes[0].Amount) {
+			continue
 		}
 
-		return usedUTXOs, totalAmount, nil
+		extra = append(extra, utxo)
 	}
+	slices.SortStableFunc(extra, func(a, b *bitcoin.UTXO) int { return a.Runes[0].Amount.Cmp(b.Runes[0].Amount) })
 
-	return nil, nil, InsufficientRuneBalanceError.clarify(transferAmount, big.NewInt(0))
+	return extra[:min(limit, len(extra))]
 }
 
 // RoughTxSizeEstimate returns Tx rough estimated size in vBytes.
 // TODO: increase precision.
 func RoughTxSizeEstimate(inputs, outputs int) *big.Int {
-	size := new(big.Int).Set(headerSizeVBytes)
-	size.Add(size, new(big.Int).Mul(inputSizeVBytes, big.NewInt(int64(inputs))))
-	size.Add(size, new(big.Int).Mul(outputSizeVBytes, big.NewInt(int64(outputs))))
-
-	return size
+	return DefaultSizeEstimator().TxSize(inputs, outputs)
 }
 
 // RoughEtchFeeEstimate returns etch transaction rough estimate in satoshi.
 // TODO: increase precision.
 func RoughEtchFeeEstimate(inscriptionWitnessSize, satoshiPerKVByte *big.Int, premineSplittingFactor int) (etchTransactionFee *big.Int) {
+	return etchFeeEstimate(DefaultSizeEstimator(), inscriptionWitnessSize, satoshiPerKVByte, premineSplittingFactor)
+}
+
+// inscriptionCommitAddress returns inscription commitment address, committing to the recovery leaf if set.
+func inscriptionCommitAddress(inscription *inscriptions.Inscription, pubKey string, recovery *inscriptions.RecoveryLeaf,
+	networkParams *chaincfg.Params) (string, error) {
+	if recovery == nil {
+		return inscription.IntoAddress(pubKey, networkParams)
+	}
+
+	pubKeyBytes, err := hex.DecodeString(pubKey)
+	if err != nil {
+		return "", err
+	}
+
+	return inscription.RecoveryCommitAddress(pubKeyBytes, *recovery, networkParams)
+}
+
+// inscriptionWitnessVBytes returns inscription reveal witness size in vBytes,
+// including recovery leaf hash of the control block if recovery leaf is set.
+func inscriptionWitnessVBytes(inscription *inscriptions.Inscription, recovery *inscriptions.RecoveryLeaf) (int, error) {
+	size, err := inscription.VBytesSize()
+	if err != nil {
+		return 0, err
+	}
+
+	if recovery != nil {
+		size += inscriptions.RecoveryControlBlockVBytes
+	}
+
+	return size, nil
+}
+
+// etchFeeEstimate returns etch transaction estimate in satoshi with provided size estimator.
+func etchFeeEstimate(estimator SizeEstimator, inscriptionWitnessSize, satoshiPerKVByte *big.Int,
+	premineSplittingFactor int) (etchTransactionFee *big.Int) {
 	// INFO:
 	// header: static value [vB]
 	// inputs: inscription witness data + raw inscription input size [vB]
@@ -1341,68 +2175,207 @@ func RoughEtchFeeEstimate(inscriptionWitnessSize, satoshiPerKVByte *big.Int, pre
 	// [vB] * 1000 [sat/vB] / 1000 = sat.
 	//
 	// estimate runes protocol as maximum possible (3 * simple output ~ 80-90 vB).
-	etchTransactionFee = new(big.Int).Add(inscriptionInputSizeVBytes, inscriptionWitnessSize)      // inputs [vB].
-	etchTransactionFee.Add(etchTransactionFee, RoughTxSizeEstimate(0, 2+2+premineSplittingFactor)) // outputs + header [vB].
-	etchTransactionFee.Mul(etchTransactionFee, satoshiPerKVByte)                                   // multiply by fee rate [vB * 1000(sat/vB)].
-	etchTransactionFee.Div(etchTransactionFee, big.NewInt(1000))                                   // reduce kilo value [sat].
+	etchTransactionFee = etchVBytesEstimate(estimator, inscriptionWitnessSize, premineSplittingFactor) // [vB].
+	etchTransactionFee.Mul(etchTransactionFee, satoshiPerKVByte)                                       // multiply by fee rate [vB * 1000(sat/vB)].
+	etchTransactionFee.Div(etchTransactionFee, big.NewInt(1000))                                       // reduce kilo value [sat].
 
 	return etchTransactionFee
 }
 
+// etchVBytesEstimate returns etch transaction size estimate in vBytes with provided size estimator.
+func etchVBytesEstimate(estimator SizeEstimator, inscriptionWitnessSize *big.Int, premineSplittingFactor int) *big.Int {
+	size := new(big.Int).Add(estimator.InscriptionInputSize(), inscriptionWitnessSize) // inputs [vB].
+
+	return size.Add(size, estimator.TxSize(0, 2+2+premineSplittingFactor)) // outputs + header [vB].
+}
+
 // SelectUTXO is a partly greedy selection algorithm for UTXOs with 'requiredUTXOs' parameter.
 // Returns list of selected by algorithm UTXOs with total amount, counted by passed amount function.
+//
+// NOTE: Returned pointers alias elements of the utxos slice: they observe any later mutation of the elements,
+// e.g. sorting the slice swaps utxos under the pointers, and appending to the slice may reallocate it,
+// so the pointers keep referencing the stale array. Use SelectUTXOIndices or clone the used utxos to keep
+// selection result independent of the slice.
 func SelectUTXO(utxos []bitcoin.UTXO, amountFn func(*bitcoin.UTXO) *big.Int, minAmount *big.Int, requiredUTXOs int,
 	insufficientBalanceError *InsufficientError) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, _ error) {
+	indices, totalAmount, err := SelectUTXOIndices(utxos, amountFn, minAmount, requiredUTXOs, insufficientBalanceError)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usedUTXOs = make([]*bitcoin.UTXO, 0, len(indices))
+	for _, idx := range indices {
+		usedUTXOs = append(usedUTXOs, &utxos[idx])
+	}
+
+	return usedUTXOs, totalAmount, nil
+}
+
+// SelectUTXOIndices is like SelectUTXO, but returns indexes of the selected utxos in the utxos slice
+// in selection order. The utxos slice and its elements are never modified, amountFn receives pointers
+// to the elements and must not modify them either.
+func SelectUTXOIndices(utxos []bitcoin.UTXO, amountFn func(*bitcoin.UTXO) *big.Int, minAmount *big.Int, requiredUTXOs int,
+	insufficientBalanceError *InsufficientError) (indices []int, totalAmount *big.Int, _ error) {
 	if len(utxos) == 0 || len(utxos) < requiredUTXOs {
 		return nil, nil, ErrInvalidUTXOAmount
 	}
 
-	usedUTXOs = make([]*bitcoin.UTXO, 0, requiredUTXOs)
+	indices = make([]int, 0, requiredUTXOs)
 	totalAmount = big.NewInt(0)
 	var startIdx = 0
-	var usedIdxs = make([]int, 0)
 
 	// find the closest by amount UTXO that is grater then minAmount or take the biggest possible.
-	for idx, utxo := range utxos {
-		if numbers.IsGreater(minAmount, amountFn(&utxo)) {
+	for idx := range utxos {
+		if numbers.IsGreater(minAmount, amountFn(&utxos[idx])) {
 			break
 		}
 
 		startIdx = idx
 	}
 
-	usedIdxs = append(usedIdxs, startIdx)
 	totalAmount.Add(totalAmount, amountFn(&utxos[startIdx]))
-	usedUTXOs = append(usedUTXOs, &utxos[startIdx])
+	indices = append(indices, startIdx)
 	requiredUTXOs--
 
 	// pick bigger amount if total amount do not cover minAmount, otherwise - the smallest to pass requiredUTXOs.
+	// INFO: used utxos always form two contiguous ranges [startIdx, front) and (back, len(utxos)),
+	// so the next unused one is taken from the range edges instead of scanning for unused indexes.
+	front, back := startIdx+1, len(utxos)-1
 	for ; requiredUTXOs > 0; requiredUTXOs-- {
-		idx := selectUnused(startIdx, len(utxos), usedIdxs, !numbers.IsGreater(minAmount, totalAmount))
-		if idx == -1 {
+		if front > back {
 			return nil, nil, ErrInvalidUTXOAmount
 		}
 
-		usedIdxs = append(usedIdxs, idx)
+		var idx int
+		if numbers.IsGreater(minAmount, totalAmount) {
+			idx, front = front, front+1
+		} else {
+			idx, back = back, back-1
+		}
+
 		totalAmount.Add(totalAmount, amountFn(&utxos[idx]))
-		usedUTXOs = append(usedUTXOs, &utxos[idx])
+		indices = append(indices, idx)
 	}
 
 	if numbers.IsGreater(minAmount, totalAmount) {
 		return nil, nil, insufficientBalanceError.clarify(minAmount, totalAmount)
 	}
 
-	return usedUTXOs, totalAmount, nil
+	return indices, totalAmount, nil
+}
+
+// selectUTXOs selects utxos by the selector with increasing number of required utxos, SelectUTXO based
+// selection is done in a single pass if selector is nil, see selectUTXOIncrementally.
+func selectUTXOs(ctx context.Context, selector CoinSelector, utxos []bitcoin.UTXO, amountFn func(*bitcoin.UTXO) *big.Int,
+	minAmountFn func(requiredUTXOs int) *big.Int, insufficientBalanceError *InsufficientError) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, err error) {
+	if selector == nil {
+		return selectUTXOIncrementally(ctx, utxos, amountFn, minAmountFn, insufficientBalanceError)
+	}
+
+	return selectUTXOIteratively(ctx, selector, utxos, amountFn, minAmountFn, insufficientBalanceError)
+}
+
+// selectUTXOIteratively selects utxos by the selector with increasing number of required utxos,
+// until selected utxos cover min amount for this number of utxos.
+func selectUTXOIteratively(ctx context.Context, selector CoinSelector, utxos []bitcoin.UTXO, amountFn func(*bitcoin.UTXO) *big.Int,
+	minAmountFn func(requiredUTXOs int) *big.Int, insufficientBalanceError *InsufficientError) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, err error) {
+	for i := 1; i <= len(utxos); i++ {
+		if err = ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		usedUTXOs, totalAmount, err = selector(utxos, amountFn, minAmountFn(i), i, insufficientBalanceError)
+		if err != nil {
+			if errors.As(err, new(*InsufficientError)) && i != len(utxos) {
+				continue
+			}
+
+			return nil, nil, err
+		}
+
+		return usedUTXOs, totalAmount, nil
+	}
+
+	return nil, nil, insufficientBalanceError.clarify(minAmountFn(1), big.NewInt(0))
+}
+
+// selectUTXOIncrementally returns the same result as selectUTXOIteratively with SelectUTXO selector in a single pass.
+// Outcome of SelectUTXO for each number of required utxos is predicted with prefix sums and prefix minimums
+// of utxos amounts, so SelectUTXO is called only once for the number of utxos which covers min amount.
+func selectUTXOIncrementally(ctx context.Context, utxos []bitcoin.UTXO, amountFn func(*bitcoin.UTXO) *big.Int,
+	minAmountFn func(requiredUTXOs int) *big.Int, insufficientBalanceError *InsufficientError) (usedUTXOs []*bitcoin.UTXO, totalAmount *big.Int, err error) {
+	n := len(utxos)
+	if n == 0 {
+		return nil, nil, insufficientBalanceError.clarify(minAmountFn(1), big.NewInt(0))
+	}
+
+	// prefixSums[k] is total amount of utxos[:k], prefixMins[k] is minimal amount of utxos[:k+1].
+	prefixSums, prefixMins := make([]*big.Int, n+1), make([]*big.Int, n)
+	prefixSums[0] = big.NewInt(0)
+	for idx := range utxos {
+		amount := amountFn(&utxos[idx])
+		prefixSums[idx+1] = new(big.Int).Add(prefixSums[idx], amount)
+
+		prefixMins[idx] = amount
+		if idx > 0 && numbers.IsLess(prefixMins[idx-1], amount) {
+			prefixMins[idx] = prefixMins[idx-1]
+		}
+	}
+
+	covered := new(big.Int)
+	for i := 1; i <= n; i++ {
+		if err = ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		minAmount := minAmountFn(i)
+
+		// INFO: SelectUTXO starts with the last utxo of the leading ones with amount not less than min amount
+		// and takes next utxos until min amount is covered, so i utxos cover it if the first i from start do.
+		startIdx := sort.Search(n, func(k int) bool { return numbers.IsGreater(minAmount, prefixMins[k]) }) - 1
+		if startIdx < 0 {
+			startIdx = 0
+		}
+
+		if i > n-startIdx {
+			// not enough utxos after start, SelectUTXO returns ErrInvalidUTXOAmount.
+			return SelectUTXO(utxos, amountFn, minAmount, i, insufficientBalanceError)
+		}
+
+		covered.Sub(prefixSums[startIdx+i], prefixSums[startIdx])
+		if !numbers.IsLess(covered, minAmount) || i == n {
+			return SelectUTXO(utxos, amountFn, minAmount, i, insufficientBalanceError)
+		}
+	}
+
+	return nil, nil, insufficientBalanceError.clarify(minAmountFn(1), big.NewInt(0))
+}
+
+// prepareUTXOs selects utxos to cover rough estimated fee with builder size estimator and coin selector.
+func (b *TxBuilder) prepareUTXOs(ctx context.Context, params PrepareUTXOsParams) (PrepareUTXOsResult, error) {
+	params.SizeEstimator = b.config.SizeEstimator
+	params.CoinSelector = b.config.CoinSelector
+
+	err := b.beforeSelect(ctx, SelectInfo{
+		UTXOs:            params.Utxos,
+		TransferAmount:   params.TransferAmount,
+		SatoshiPerKVByte: params.SatoshiPerKVByte,
+	})
+	if err != nil {
+		return PrepareUTXOsResult{}, err
+	}
+
+	return PrepareUTXOsContext(ctx, params)
 }
 
 // addOutput adds output to transaction, subtracts amount from unallocated amount.
 func (b *TxBuilder) addOutput(tx *wire.MsgTx, amount, unallocatedAmount *big.Int, address string) error {
 	if numbers.IsLess(unallocatedAmount, amount) {
-		return fmt.Errorf("the rest of the unallocated btc amount (%s) is less than the output allocating amount (%s)",
+		return fmt.Errorf("%w: the rest of the unallocated btc amount (%s) is less than the output allocating amount (%s)", ErrUnallocatedAmountExceeded,
 			unallocatedAmount.String(), amount.String())
 	}
 
-	recipientAddress, err := btcutil.DecodeAddress(address, b.networkParams)
+	recipientAddress, err := b.DecodeAddress(address)
 	if err != nil {
 		return err
 	}
@@ -1417,33 +2390,3 @@ func (b *TxBuilder) addOutput(tx *wire.MsgTx, amount, unallocatedAmount *big.Int
 
 	return nil
 }
-
-// selectUnused returns first unused idx depending on search direction.
-func selectUnused(start, end int, usedIdxs []int, reversed bool) int {
-	if reversed {
-		for idx := end - 1; idx >= start; idx-- {
-			if !isUsed(idx, usedIdxs) {
-				return idx
-			}
-		}
-	} else {
-		for idx := start; idx < end; idx++ {
-			if !isUsed(idx, usedIdxs) {
-				return idx
-			}
-		}
-	}
-
-	return -1
-}
-
-// isUsed returns true id idx is in usedIdxs.
-func isUsed(idx int, usedIdxs []int) bool {
-	for _, used := range usedIdxs {
-		if used == idx {
-			return true
-		}
-	}
-
-	return false
-}
@@ -6,14 +6,18 @@ package txbuilder_test
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/stretchr/testify/require"
 
 	"github.com/BoostyLabs/blockchain/bitcoin"
@@ -117,12 +121,11 @@ func TestTxBuilder(t *testing.T) {
 					RunesSender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(546),
-								Script:  []byte("_bitcoin_transaction_rune_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
-								Runes:   []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(546),
+								Script:   []byte("_bitcoin_transaction_rune_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -131,11 +134,10 @@ func TestTxBuilder(t *testing.T) {
 					FeePayer: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -155,12 +157,11 @@ func TestTxBuilder(t *testing.T) {
 					RunesSender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(546),
-								Script:  []byte("_bitcoin_transaction_rune_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
-								Runes:   []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(546),
+								Script:   []byte("_bitcoin_transaction_rune_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -169,11 +170,10 @@ func TestTxBuilder(t *testing.T) {
 					FeePayer: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -193,12 +193,11 @@ func TestTxBuilder(t *testing.T) {
 					RunesSender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(546),
-								Script:  []byte("_bitcoin_transaction_rune_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
-								Runes:   []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(546),
+								Script:   []byte("_bitcoin_transaction_rune_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -207,11 +206,10 @@ func TestTxBuilder(t *testing.T) {
 					FeePayer: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -231,12 +229,11 @@ func TestTxBuilder(t *testing.T) {
 					RunesSender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(546),
-								Script:  []byte("_bitcoin_transaction_rune_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
-								Runes:   []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(546),
+								Script:   []byte("_bitcoin_transaction_rune_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -245,11 +242,10 @@ func TestTxBuilder(t *testing.T) {
 					FeePayer: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -270,12 +266,11 @@ func TestTxBuilder(t *testing.T) {
 					RunesSender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(546),
-								Script:  []byte("_bitcoin_transaction_rune_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
-								Runes:   []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(546),
+								Script:   []byte("_bitcoin_transaction_rune_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -284,11 +279,10 @@ func TestTxBuilder(t *testing.T) {
 					FeePayer: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -313,6 +307,159 @@ func TestTxBuilder(t *testing.T) {
 		}
 	})
 
+	t.Run("BuildRunesAndBTCTransferTx", func(t *testing.T) {
+		runeID := runes.RuneID{Block: 1122, TxID: 77}
+		base := txbuilder.BaseRunesTransferParams{
+			RuneID:             runeID,
+			TransferRuneAmount: big.NewInt(1000),
+			RunesSender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+						Amount:   big.NewInt(546),
+						Script:   []byte("_bitcoin_transaction_rune_script_"),
+						Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+						Runes:    []bitcoin.RuneUTXO{{RuneID: runeID, Amount: big.NewInt(7726)}},
+					},
+				},
+				Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+				PubKey:  "29fa611c361355b082ee593feb368009aa9c6bd1ed36c9983edcd113fb8da33f",
+			},
+			FeePayer: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+						Amount:   big.NewInt(850000), // 0.0085 BTC.
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+					},
+				},
+				Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+				PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+			},
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+		}
+
+		tests := []struct {
+			name             string
+			btcRecipient     string
+			outputs          int
+			recipientOutputs map[int]int64
+		}{
+			{"different recipients", "2N8hwP1WmJrFF5QWABn38y63uYLhnJYJYTF", 5, map[int]int64{1: 546, 3: 29500}},
+			{"same recipient", base.RunesRecipientAddress, 4, map[int]int64{1: 546 + 29500}},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				result, err := txBuilder.BuildRunesAndBTCTransferTx(txbuilder.BaseRunesAndBTCTransferParams{
+					BaseRunesTransferParams: base,
+					TransferSatoshiAmount:   big.NewInt(29500),
+					BTCRecipientAddress:     test.btcRecipient,
+				})
+				require.NoError(t, err)
+
+				p, err := psbt.NewFromRawBytes(bytes.NewBuffer(result.SerializedPSBT), false)
+				require.NoError(t, err)
+				require.Len(t, p.UnsignedTx.TxOut, test.outputs)
+				for idx, amount := range test.recipientOutputs {
+					require.EqualValues(t, amount, p.UnsignedTx.TxOut[idx].Value)
+				}
+
+				var outputsAmount int64
+				for _, output := range p.UnsignedTx.TxOut {
+					outputsAmount += output.Value
+				}
+				require.EqualValues(t, 850000+546-outputsAmount, result.EstimatedFee.Int64())
+
+				runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[0].PkScript)
+				require.NoError(t, err)
+				require.Equal(t, []runes.Edict{{RuneID: runeID, Amount: big.NewInt(1000), Output: 1}}, runestone.Edicts)
+				require.EqualValues(t, 2, *runestone.Pointer)
+			})
+		}
+
+		t.Run("same as BuildRunesTransferTx without btc", func(t *testing.T) {
+			expected, err := txBuilder.BuildRunesTransferTx(base)
+			require.NoError(t, err)
+
+			result, err := txBuilder.BuildRunesAndBTCTransferTx(txbuilder.BaseRunesAndBTCTransferParams{BaseRunesTransferParams: base})
+			require.NoError(t, err)
+			require.Equal(t, expected, result)
+		})
+
+		t.Run("dust btc amount", func(t *testing.T) {
+			params := txbuilder.BaseRunesAndBTCTransferParams{
+				BaseRunesTransferParams: base,
+				TransferSatoshiAmount:   big.NewInt(545),
+				BTCRecipientAddress:     "2N8hwP1WmJrFF5QWABn38y63uYLhnJYJYTF",
+			}
+			_, err := txBuilder.BuildRunesAndBTCTransferTx(params)
+			require.ErrorIs(t, err, txbuilder.ErrTransferAmountTooLow)
+
+			// INFO: dust amount is allowed if it is added to the runes recipient output.
+			params.BTCRecipientAddress = base.RunesRecipientAddress
+			_, err = txBuilder.BuildRunesAndBTCTransferTx(params)
+			require.NoError(t, err)
+		})
+
+		t.Run("many recipients", func(t *testing.T) {
+			params := base
+			params.TransferRuneAmount = nil
+			params.RunesRecipientAddress = ""
+			params.RunesRecipients = []txbuilder.RuneRecipient{
+				{Address: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0", Amount: big.NewInt(100)},
+				{Address: "2N8hwP1WmJrFF5QWABn38y63uYLhnJYJYTF", Amount: big.NewInt(200)},
+				{Address: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0", Amount: big.NewInt(300)},
+			}
+
+			result, err := txBuilder.BuildRunesAndBTCTransferTx(txbuilder.BaseRunesAndBTCTransferParams{
+				BaseRunesTransferParams: params,
+				TransferSatoshiAmount:   big.NewInt(29500),
+				BTCRecipientAddress:     "2N8hwP1WmJrFF5QWABn38y63uYLhnJYJYTF",
+			})
+			require.NoError(t, err)
+
+			p, err := psbt.NewFromRawBytes(bytes.NewBuffer(result.SerializedPSBT), false)
+			require.NoError(t, err)
+			require.Len(t, p.UnsignedTx.TxOut, 6) // runestone, 3 recipients, runes change, btc change.
+			require.EqualValues(t, []int64{546, 546 + 29500, 546, 546},
+				[]int64{p.UnsignedTx.TxOut[1].Value, p.UnsignedTx.TxOut[2].Value, p.UnsignedTx.TxOut[3].Value, p.UnsignedTx.TxOut[4].Value})
+
+			runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[0].PkScript)
+			require.NoError(t, err)
+			require.Equal(t, []runes.Edict{
+				{RuneID: runeID, Amount: big.NewInt(100), Output: 1},
+				{RuneID: runeID, Amount: big.NewInt(200), Output: 2},
+				{RuneID: runeID, Amount: big.NewInt(300), Output: 3},
+			}, runestone.Edicts)
+			require.EqualValues(t, 4, *runestone.Pointer)
+
+			params.TransferRuneAmount = big.NewInt(1000)
+			_, err = txBuilder.BuildRunesTransferTx(params)
+			require.ErrorIs(t, err, txbuilder.ErrInvalidRunesRecipients)
+
+			params.TransferRuneAmount = big.NewInt(600)
+			_, err = txBuilder.BuildRunesTransferTx(params)
+			require.NoError(t, err)
+
+			params.RunesRecipients = append(params.RunesRecipients[:2:2], txbuilder.RuneRecipient{Address: "2N8hwP1WmJrFF5QWABn38y63uYLhnJYJYTF"})
+			_, err = txBuilder.BuildRunesTransferTx(params)
+			require.ErrorIs(t, err, txbuilder.ErrInvalidRunesRecipients)
+
+			params.TransferRuneAmount = nil
+			params.RunesRecipients = nil
+			for i := 0; i < 30; i++ {
+				params.RunesRecipients = append(params.RunesRecipients, txbuilder.RuneRecipient{
+					Address: "2N8hwP1WmJrFF5QWABn38y63uYLhnJYJYTF",
+					Amount:  big.NewInt(100),
+				})
+			}
+			_, err = txBuilder.BuildRunesTransferTx(params)
+			require.ErrorIs(t, err, txbuilder.ErrRunestoneTooLarge)
+		})
+	})
+
 	t.Run("BuildBTCTransferTx", func(t *testing.T) {
 		tests := []struct {
 			expectedTxB64 string
@@ -325,18 +472,16 @@ func TestTxBuilder(t *testing.T) {
 					Sender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(27000), // 0.00027 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(27000), // 0.00027 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 						},
 						Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
@@ -353,18 +498,16 @@ func TestTxBuilder(t *testing.T) {
 					Sender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
 							},
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(27000), // 0.00027 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(27000), // 0.00027 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -381,18 +524,16 @@ func TestTxBuilder(t *testing.T) {
 					Sender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(3500), // 0.000025 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(3500), // 0.000025 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(27000), // 0.00027 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(27000), // 0.00027 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 						},
 						Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
@@ -401,18 +542,16 @@ func TestTxBuilder(t *testing.T) {
 					FeePayer: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
 							},
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(800000), // 0.008 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(800000), // 0.008 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
 							},
 						},
 						Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
@@ -448,11 +587,10 @@ func TestTxBuilder(t *testing.T) {
 					Sender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(27000), // 0.00027 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(27000), // 0.00027 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 						},
 						Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
@@ -474,11 +612,10 @@ func TestTxBuilder(t *testing.T) {
 					Sender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(27000), // 0.00027 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(27000), // 0.00027 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 						},
 						Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
@@ -501,18 +638,16 @@ func TestTxBuilder(t *testing.T) {
 					Sender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(27000), // 0.00027 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(27000), // 0.00027 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 						},
 						Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
@@ -535,11 +670,10 @@ func TestTxBuilder(t *testing.T) {
 					Sender: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(27000), // 0.00027 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(27000), // 0.00027 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 						},
 						Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
@@ -554,6 +688,57 @@ func TestTxBuilder(t *testing.T) {
 					PremineSplittingFactor: 3,
 				},
 			},
+			{
+				"",
+				inscriptions.ErrUnsupportedContentType,
+				txbuilder.BaseInscriptionTxParams{
+					Sender: &txbuilder.PaymentData{
+						UTXOs: []bitcoin.UTXO{
+							{
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(27000), // 0.00027 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+							},
+						},
+						Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+						PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+					},
+					SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+					Inscription: &inscriptions.Inscription{
+						ContentType: "application/x-msdownload",
+						Body:        []byte("test data"),
+					},
+					InscriptionBasePubKey: "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+					ContentRules:          inscriptions.DefaultContentRules,
+				},
+			},
+			{
+				"",
+				txbuilder.ErrMissingSender,
+				txbuilder.BaseInscriptionTxParams{
+					SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+					Inscription: &inscriptions.Inscription{
+						Body: []byte("test data"),
+					},
+					InscriptionBasePubKey: "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+				},
+			},
+			{
+				"",
+				txbuilder.ErrNoUTXOs,
+				txbuilder.BaseInscriptionTxParams{
+					Sender: &txbuilder.PaymentData{
+						Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+						PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+					},
+					SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+					Inscription: &inscriptions.Inscription{
+						Body: []byte("test data"),
+					},
+					InscriptionBasePubKey: "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+				},
+			},
 		}
 		for i, test := range tests {
 			t.Run(fmt.Sprintf("test %d", i), func(t *testing.T) {
@@ -564,6 +749,72 @@ func TestTxBuilder(t *testing.T) {
 		}
 	})
 
+	t.Run("BuildInscriptionTx with recovery", func(t *testing.T) {
+		inscription := &inscriptions.Inscription{ContentType: "text/plain;charset=utf-8", Body: []byte("test data")}
+		recoveryPubKey, err := hex.DecodeString("03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be")
+		require.NoError(t, err)
+
+		recovery := &inscriptions.RecoveryLeaf{PubKey: recoveryPubKey, Delay: 144}
+		params := txbuilder.BaseInscriptionTxParams{
+			Sender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+						Amount:   big.NewInt(27000), // 0.00027 BTC.
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+					},
+				},
+				Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+				PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+			},
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			Inscription:           inscription,
+			InscriptionBasePubKey: "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+		}
+
+		commitOutput := func(t *testing.T, params txbuilder.BaseInscriptionTxParams) *wire.TxOut {
+			result, err := txBuilder.BuildInscriptionTx(params)
+			require.NoError(t, err)
+
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+			require.NoError(t, err)
+
+			return p.UnsignedTx.TxOut[0]
+		}
+
+		withoutRecovery := commitOutput(t, params)
+
+		params.Recovery = recovery
+		withRecovery := commitOutput(t, params)
+
+		pubKey, err := hex.DecodeString(params.InscriptionBasePubKey)
+		require.NoError(t, err)
+		require.NoError(t, inscription.VerifyRecoveryCommitment(pubKey, withRecovery.PkScript, *recovery))
+
+		// INFO: control block of the reveal input contains recovery leaf hash, 8 vB at 5 sat/vB.
+		require.EqualValues(t, inscriptions.RecoveryControlBlockVBytes*5, withRecovery.Value-withoutRecovery.Value)
+
+		t.Run("etching rune", func(t *testing.T) {
+			rune_, err := runes.NewRuneFromString("HELLOWORLD")
+			require.NoError(t, err)
+
+			params.Recovery = nil
+			params.EtchingRune = rune_
+			committed := commitOutput(t, params)
+			require.Nil(t, inscription.Rune)
+			require.ErrorIs(t, inscription.VerifyCommitment(pubKey, committed.PkScript), inscriptions.ErrCommitmentMismatch)
+			require.NoError(t, inscription.CommitRune(rune_).VerifyCommitment(pubKey, committed.PkScript))
+
+			params.Inscription = inscription.CommitRune(rune_)
+			require.Equal(t, committed, commitOutput(t, params))
+
+			params.Inscription = inscription.CommitRune(runes.RuneReserve(runes.RuneID{Block: 840000, TxID: 1}))
+			_, err = txBuilder.BuildInscriptionTx(params)
+			require.ErrorIs(t, err, txbuilder.ErrMissingRuneCommitment)
+		})
+	})
+
 	t.Run("BuildRuneEtchTx", func(t *testing.T) {
 		rune_, err := runes.NewRuneFromString("HELLO")
 		require.NoError(t, err)
@@ -575,23 +826,22 @@ func TestTxBuilder(t *testing.T) {
 		require.True(t, ok)
 
 		symbol := rune(129297)
-		spacers := uint32(8226)
+		spacers := uint32(162)
 
 		tests := []struct {
 			expectedTxB64 string
 			params        txbuilder.BaseRuneEtchTxParams
 		}{
 			{
-				"cHNidP8BAJ8CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AwAAAAAAAAAAGGpdFQEFAgEDJQS+geUBBV0GgJTr3AMWASICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmSN8QwAAAAAABepFKpYjpRh5/yszRC1NNtHIt1yMSLBhwAAAAAAAQElUPgMAAAAAAAcX2JpdGNvaW5fdHJhbnNhY3Rpb25fc2NyaXB0XwEDBAEAAAABBTog9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/qsAGMDb3JkAQ0DvkA5AAl0ZXN0IGRhdGFoARcg9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/oAAAAA",
+				"cHNidP8BAJ8CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AwAAAAAAAAAAGGpdFQEFAgEDBQS+geUBBV0GgJTr3AMWASICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmSN8QwAAAAAABepFKpYjpRh5/yszRC1NNtHIt1yMSLBhwAAAAAAAQErUPgMAAAAAAAiUSCjkWSo/qAf9oBwDaOvc+Zy6npj9h4pIscM3eEwhwKJlQEDBAEAAAABBTog9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/qsAGMDb3JkAQ0DvkA5AAl0ZXN0IGRhdGFoARcg9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/oAAAAA",
 				txbuilder.BaseRuneEtchTxParams{
 					InscriptionReveal: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(850000), // 0.0085 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(850000), // 0.0085 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
 							},
 						},
 						Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
@@ -605,17 +855,16 @@ func TestTxBuilder(t *testing.T) {
 						Divisibility: toPointer(byte(5)),
 						Premine:      big.NewInt(1000000000),
 						Rune:         rune_,
-						Spacers:      toPointer(uint32(37)),
+						Spacers:      toPointer(uint32(5)),
 						Symbol:       toPointer(']'),
 					},
 					AdditionalPayments: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   4,
-								Amount:  big.NewInt(27000), // 0.00027 BTC.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+								Amount:   big.NewInt(27000), // 0.00027 BTC.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
 							},
 						},
 						Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
@@ -627,16 +876,15 @@ func TestTxBuilder(t *testing.T) {
 				},
 			},
 			{
-				"cHNidP8BAOwCAAAAAq6V20f0qai87sqrY5zA3ubZpjgPM5n+b7J3ozxfRL2EAAAAAAD/////XHgKXBsP1r/EbXOKQpHCSEKyk/5DMVZVn7lFZAEHeVUBAAAAAP////8DAAAAAAAAAAAxal0uASYCAQOiQATcqYXt3+DCuRQFkfIHBoCAgICAgKiRi8Ciu6+cz9yGwb+7zQUWASICAAAAAAAAIlEg5aLj+ttIbun6sth40Iz+ok3PsqGS4Be9+bwYk6BACxAYEAAAAAAAACJRIOWi4/rbSG7p+rLYeNCM/qJNz7KhkuAXvfm8GJOgQAsQAAAAAAERAQEAAQE5CBwAAAAAAAAwVVNBSHh3ZTlPdUsxdFRpcXR4SkxkVWd4eklPUUI5a2xOd0pObXA4NWlwVUtaZz09AQMEAQAAAAEF/UASIBVku0l57bXXTn7tOuomXXW3PJ5idYN12RjneMPtPrwPrABjA29yZAENCNxUof0FC3MUAE0IAmlWQk9SdzBLR2dvQUFBQU5TVWhFVWdBQUFBc0FBQUFLQ0FZQUFBQmk4S1NEQUFBS3NHbERRMUJKUTBNZ1VISnZabWxzWlFBQVNJbVZsd2RVazlrU2dPLy9wNGVFbG9CMFFtK0NkQUpJQ1QzVTBJdW9oQ1NRVUVJTUJCVXJ5T0lLcmdVVkVWUVdaRlZFd2JVQXN0aFF4TUlpWUFFVlhaQkZRVjBYQzZLaThuN2dFSGIzbmZmZWVaTXpaNzUvL3JsejU5NXo3MzhtQUpDcGJKRW9EWllISUYyWUpRN3o5YURGeE1iUmNDTUFDekFBQnFwQWs4M0pGREZZckVDQXlKejl1M3k0RDZCcGU4ZDhPdGUvdi8rdm9zRGxaWElBZ0ZnSUozSXpPZWtJbjBGMGpDTVNad0dBcWtiOGVpdXpSTk44SFdHcUdDa1E0ZjVwVHA3bHNXbE9uR0UwZWlZbUlzd1RZUlVBOENRMlc1d01BRWtmOGRPeU9jbElIcElYd3BaQ3JrQ0lNUElNWE5QVE03Z0lJL01DWXlSR2hQQjBmbnJpWC9Jay95MW5valFubTUwczVkbTF6QWplUzVBcFNtT3YvaiszNDM5TGVwcGtiZzVEUkVsOHNWOFlZcEc2b0w3VWpBQXBDeE9EUStaWXdKMkpuMkcreEM5eWpqbVpubkZ6ekdWN0JVakhNCAJwZ1VIem5HU3dJY3B6WlBGakpoalhxWjMrQnlMTThLa2N5V0pQUmx6ekJiUHp5dEpqWlQ2K1R5bU5IOE9QeUo2anJNRlVjRnpuSmthSGpBZjR5bjFpeVZoMHZwNVFsK1ArWGw5cEd0UHovekxlZ1ZNNmRnc2ZvU2ZkTzNzK2ZwNVFzWjh6c3dZYVcxY25wZjNmRXlrTkY2VTVTR2RTNVRHa3NiejBueWwvc3pzY09uWUxPUkF6bzlsU2Zjd2hlM1BtbVBnQmJ4QklQS2pBUmF3QnJhSVdnTS80SjNGV3pWOVJvRm5obWkxV0pETXo2SXhrRnZHb3pHRkhJdUZOR3RMYTFzQXB1L3M3SkY0MXpkekZ5RmwvTHhQdUJ3QXUrbTlYRC92NDB3QWNFNGRBTVVYOHo3OVhPUTZsZ0Z3c1kwakVXZlArcWF2RS9JbElBSTVRRVcrQmxwQUR4Z0RjNlF5ZStBTTNKR0svVUVJaUFDeFlCbmdBRDVJQjJLd0Vxd0Z1YUFBRklFZFlBOG9BeFhnRURnS1RvQlRvQkcwZ012Z0dyZ0Z1c0E5OEFnTWdHSHdFb3lCRDJBU2dpQWNSSVlva0Nxa0RSbEFacEExUklkY0lXOG9FQXFEWXFFRUtCa1NRaEpvTGJRSktvS0tvVEtvRXFxQmZvYk9RWmVoRzFBMzlBQWFoRWFoTQgCdDlCbkdBV1RZQ3FzQ1J2Q2kyQTZ6SUFENEFoNEtad01yNEJ6NEh4NEcxd0tWOEhINFFiNE1ud0x2Z2NQd0MvaGNSUkF5YUNVVVRvb2N4UWQ1WWtLUWNXaGtsQmkxSHBVSWFvRVZZV3FReldqMmxGM1VBT29WNmhQYUN5YWdxYWh6ZEhPYUQ5MEpKcURYb0Zlajk2S0xrTWZSVGVncjZMdm9BZlJZK2h2R0RKR0EyT0djY0l3TVRHWVpNeEtUQUdtQkhNWWN4YlRocm1IR2NaOHdHS3h5bGdqckFQV0R4dUxUY0d1d1c3RkhzRFdZeTlodTdGRDJIRWNEcWVLTThPNTRFSndiRndXcmdDM0QzY2NkeEhYZ3h2R2ZjVEw0TFh4MW5nZmZCeGVpTS9EbCtDUDRTL2dlL0RQOFpNRWVZSUJ3WWtRUXVBU1ZoTzJFNm9KellUYmhHSENKRkdCYUVSMElVWVFVNGk1eEZKaUhiR04yRTk4SnlNam95dmpLQk1xSTVEWktGTXFjMUxtdXN5Z3pDZVNJc21VNUVtS0owbEkyMGhIU0pkSUQwanZ5R1N5SWRtZEhFZk9JbThqMTVDdmtKK1FQOHBTWkMxa21iSmMyUTJ5NWJJTnNqMnlyK1VJY2daeURMbGxjamx5SlhLbjVXN0x2WklueUJ2S2U4cXo1ZGZMbDh1Zk0IAmsrK1ZIMWVnS0ZncGhDaWtLMnhWT0tad1EyRkVFYWRvcU9pdHlGWE1WenlrZUVWeGlJS2k2RkU4S1J6S0prbzFwWTB5VE1WU2phaE1hZ3ExaUhxQzJra2RVMUpVc2xXS1VscWxWSzUwWG1sQUdhVnNxTXhVVGxQZXJueEsrYjd5NXdXYUN4Z0xlQXUyTEtoYjBMTmdRa1ZkeFYyRnAxS29VcTl5VCtXektrM1ZXelZWZGFkcW8rcGpOYlNhcVZxbzJrcTFnMnB0YXEvVXFlck82aHoxUXZWVDZnODFZQTFUalRDTk5ScUhORG8weGpXMU5IMDFSWnI3Tks5b3Z0SlMxbkxYU3RIYXJYVkJhMVNib3UycUxkRGVyWDFSK3dWTmljYWdwZEZLYVZkcFl6b2FPbjQ2RXAxS25VNmRTVjBqM1VqZFBOMTYzY2Q2UkQyNlhwTGVicjFXdlRGOWJmMGcvYlg2dGZvUERRZ0dkQU8rd1Y2RGRvTUpReVBEYU1QTmhvMkdJMFlxUmt5akhLTmFvMzVqc3JHYjhRcmpLdU83SmxnVHVrbXF5UUdUTGxQWTFNNlViMXB1ZXRzTU5yTTNFNWdkTU90ZWlGbm91RkM0c0dwaHJ6bkpuR0dlYlY1clBtaWhiQkZva1dmUmFQRjZrZjZpdUVVN0Y3VXYrbVpwWjVsbVdXMzVNCAJ5RXJSeXQ4cXo2clo2cTIxcVRYSHV0ejZyZzNaeHNkbWcwMlR6UnRiTTF1ZTdVSGJQanVLWFpEZFpydFd1Ni8yRHZaaSt6cjdVUWQ5aHdTSC9RNjlkQ3FkUmQ5S3YrNkljZlJ3M09EWTR2akp5ZDRweSttVTA1L081czZwenNlY1J4WWJMZVl0cmw0ODVLTHJ3bmFwZEJsd3Bia211UDdvT3VDbTQ4WjJxM0o3NnE3bnpuVS83UDZjWWNKSVlSeG52UGF3OUJCN25QV1k4SFR5WE9kNXlRdmw1ZXRWNk5YcHJlZ2Q2VjNtL2NSSDF5ZlpwOVpuek5mT2Q0M3ZKVCtNWDREZlRyOWVwaWFUdzZ4aGp2azcrSy96dnhwQUNnZ1BLQXQ0R21nYUtBNXNEb0tEL0lOMkJmVUhHd1FMZ3h0RFFBZ3paRmZJWTVZUmF3WHJsMUJzS0N1MFBQUlptRlhZMnJEMmNFcjQ4dkJqNFI4aVBDSzJSenlLTkk2VVJMWkd5VVhGUjlWRVRVUjdSUmRIRDhRc2lsa1hjeXRXTFZZUTJ4U0hpNHVLT3h3M3ZzUjd5WjRsdy9GMjhRWHg5NWNhTFYyMTlNWXl0V1ZweTg0dmwxdk9YbjQ2QVpNUW5YQXM0UXM3aEYzRkhrOWtKdTVQSE9ONGN2WnlYbkxkdWJ1NW96d1hYakh2TQgCZVpKTFVuSFNTTEpMOHE3a1ViNGJ2NFQvU3VBcEtCTzhTZkZMcVVpWlNBMUpQWkk2bFJhZFZwK09UMDlJUHlkVUZLWUtyMlpvWmF6SzZCYVppUXBFQXl1Y1Z1eFpNU1lPRUIvT2hES1haalpsVVpIbXFFTmlMUGxPTXBqdG1sMmUvWEZsMU1yVHF4UldDVmQxckRaZHZXWDE4eHlmbkovV29OZHcxclN1MVZtYnUzWndIV05kNVhwb2ZlTDYxZzE2Ry9JM0RHLzAzWGcwbDVpYm12dHJubVZlY2Q3N1RkR2Jtdk0xOHpmbUQzM24rMTF0Z1d5QnVLQjNzL1BtaXUvUjN3dSs3OXhpczJYZmxtK0YzTUtiUlpaRkpVVmZ0bksyM3Z6QjZvZlNINmEySlczcjNHNi8vZUFPN0E3aGp2czczWFllTFZZb3ppa2UyaFcwcTJFM2JYZmg3dmQ3bHUrNVVXSmJVckdYdUZleWQ2QTBzTFJwbi82K0hmdStsUEhMN3BWN2xOZnYxOWkvWmYvRUFlNkJub1B1QitzcU5DdUtLajcvS1BpeHI5SzNzcUhLc0tya0VQWlE5cUZuMVZIVjdUL1JmNm81ckhhNDZQRFhJOElqQTBmRGpsNnRjYWlwT2FaeGJIc3RYQ3VwSFQwZWY3enJoTmVKcGpyenVzcDY1ZnFpaytDa00IAjVPU0xueE4rdm44cTRGVHJhZnJwdWpNR1ovYWZwWnd0YklBYVZqZU1OZkliQjVwaW03clArWjlyYlhadVB2dUx4UzlIV25SYXlzOHJuZDkrZ1hnaC84TFV4WnlMNDVkRWwxNWRUcjQ4MUxxODlkR1ZtQ3QzcjRaZTdXd0xhTHQremVmYWxYWkcrOFhyTHRkYmJqamRPSGVUZnJQeGx2MnRoZzY3anJPLzJ2MTZ0dE8rcytHMncrMm1Mc2V1NXU3RjNSZDYzSG91My9HNmMrMHU4KzZ0ZThIM3V1OUgzdS9yamU4ZDZPUDJqVHhJZS9EbVlmYkR5VWNiK3pIOWhZL2xINWM4MFhoUzladkpiL1VEOWdQbkI3MEdPNTZHUDMwMHhCbDYrWHZtNzErRzg1K1JuNVU4MTM1ZU0ySTkwakxxTTlyMVlzbUw0WmVpbDVPdkN2NVErR1AvYStQWFovNTAvN05qTEdacytJMzR6ZFRicmU5VTN4MTViL3UrZFp3MS91UkQrb2ZKaWNLUHFoK1BmcUovYXY4Yy9mbjU1TW92dUMrbFgwMitObjhMK05ZL2xUNDFKV0tMMlRPdEFBcFJPQ2tKZ0xkSEFDREhBa0RwQW9DNFpMYW5uaEZvOW4vQURJSC94TE45OTR6WUExRHJEa0E0b2lHSUh0Z0lnQUhpbGtjc0MzbU9NCAJjQWV3alkxVTUvcmZtVjU5V3VTUEExQjV6ZHJCeCtOeFN3VU4vRU5tKy9pLzFQMVBDNlJaLzJiL0JWcUxCakg1elRYQ0FBQUFWbVZZU1daTlRRQXFBQUFBQ0FBQmgya0FCQUFBQUFFQUFBQWFBQUFBQUFBRGtvWUFCd0FBQUJJQUFBQkVvQUlBQkFBQUFBRUFBQUFMb0FNQUJBQUFBQUVBQUFBS0FBQUFBRUZUUTBsSkFBQUFVMk55WldWdWMyaHZkTlU0blRVQUFBSFVhVlJZZEZoTlREcGpiMjB1WVdSdlltVXVlRzF3QUFBQUFBQThlRHA0YlhCdFpYUmhJSGh0Ykc1ek9uZzlJbUZrYjJKbE9tNXpPbTFsZEdFdklpQjRPbmh0Y0hSclBTSllUVkFnUTI5eVpTQTJMakF1TUNJK0NpQWdJRHh5WkdZNlVrUkdJSGh0Ykc1ek9uSmtaajBpYUhSMGNEb3ZMM2QzZHk1M015NXZjbWN2TVRrNU9TOHdNaTh5TWkxeVpHWXRjM2x1ZEdGNExXNXpJeUkrQ2lBZ0lDQWdJRHh5WkdZNlJHVnpZM0pwY0hScGIyNGdjbVJtT21GaWIzVjBQU0lpQ2lBZ0lDQWdJQ0FnSUNBZ0lIaHRiRzV6T21WNGFXWTlJbWgwZEhBNkx5OXVjeTVoWkc5aVpTNWpiMjB2TbABWlhocFppOHhMakF2SWo0S0lDQWdJQ0FnSUNBZ1BHVjRhV1k2VUdsNFpXeFpSR2x0Wlc1emFXOXVQakV3UEM5bGVHbG1PbEJwZUdWc1dVUnBiV1Z1YzJsdmJqNEtJQ0FnSUNBZ0lDQWdQR1Y0YVdZNlVHbDRaV3hZUkdsdFpXNXphVzl1UGpFeFBDOWxlR2xtT2xCcGVHVnNXRVJwYldWdWMybHZiajRLSUNBZ0lDQWdJQ0FnUEdWNGFXWTZWWE5sY2tOdmJXMWxiblErVTJOeVpXVnVjMmh2ZER3dlpYaHBaanBWYzJWeVEyOXRiV1Z1ZEQ0S0lDQWdJQ0FnUEM5eVpHWTZSR1Z6WTNKcGNIUnBiMjQrQ2lBZ0lEd3ZjbVJtT2xKRVJqNEtQQzk0T25odGNHMWxkR0UrQ2xUajBvY0FBQUE5U1VSQlZCZ1pZMlJpWmYzUFFDUmdJbElkV05sZ1ZBenpLVG9OOHhjTFRBSW1BT09qMHlCNUZrYVlLaUpvSkpOQlp1SFhpbVF5SXdPNmNuUStBS1FKRENLSGM4cmpBQUFBQUVsRlRrU3VRbUNDaAEXIBVku0l57bXXTn7tOuomXXW3PJ5idYN12RjneMPtPrwPAAEBOUAbAAAAAAAAMFVTRGxvdVA2MjBodTZmcXkySGpRalA2aVRjK3lvWkxnRjczNXZCaVRvRUFMRUE9PQEDBAEAAAABFyAVZLtJee21105+7TrqJl11tzyeYnWDddkY53jD7T68DwAAAAA=",
+				"cHNidP8BAOwCAAAAAq6V20f0qai87sqrY5zA3ubZpjgPM5n+b7J3ozxfRL2EAAAAAAD/////XHgKXBsP1r/EbXOKQpHCSEKyk/5DMVZVn7lFZAEHeVUBAAAAAP////8DAAAAAAAAAAAxal0uASYCAQOiAQTcqYXt3+DCuRQFkfIHBoCAgICAgKiRi8Ciu6+cz9yGwb+7zQUWASICAAAAAAAAIlEg5aLj+ttIbun6sth40Iz+ok3PsqGS4Be9+bwYk6BACxAYEAAAAAAAACJRIOWi4/rbSG7p+rLYeNCM/qJNz7KhkuAXvfm8GJOgQAsQAAAAAAERAQEAAQErCBwAAAAAAAAiUSDpAG4hHi3apon1T/+pwXjFvpOPD/3FBpCV7PyK2Yw3OwEDBAEAAAABBf1AEiAVZLtJee21105+7TrqJl11tzyeYnWDddkY53jD7T68D6wAYwNvcmQBDQjcVKH9BQtzFABNCAJpVkJPUncwS0dnb0FBQUFOU1VoRVVnQUFBQXNBQUFBS0NBWUFBQUJpOEtTREFBQUtzR2xEUTFCSlEwTWdVSEp2Wm1sc1pRQUFTSW1WbHdkVWs5a1NnTy8vcDRlRWxvQjBRbStDZEFKSUNUM1UwSXVvaENTUVVFSU1CQlVyeU9JS3JnVVZFVlFXWkZWRXdiVUFzdGhReE1JaVlBRVZYWkJGUVYwWEM2S2k4bjdnRUhiM25mZmVlWk16Wjc1Ly9ybHo1OTV6NzM4bUFKQ3BiSkVvRFpZSElGMllKUTd6OWFERnhNYlJjQ01BQ3pBQUJxcEFrODNKRkRGWXJFQ0F5Sno5dTN5NEQ2QnBlOGQ4T3RlL3YvK3Zvc0RsWlhJQWdGZ0lKM0l6T2VrSW4wRjBqQ01TWndHQXFrYjhlaXV6Uk5OOEhXR3FHQ2tRNGY1cFRwN2xzV2xPbkdFMGVpWW1Jc3dUWVJVQThDUTJXNXdNQUVrZjhkT3lPY2xJSHBJWHdwWkNya0NJTVBJTVhOUFRNN2dJSS9NQ1l5UkdoUEIwZm5yaVgvSWsveTFub2pRbm01MHM1ZG0xekFqZVM1QXBTbU92L2orMzQzOUxlcHBrYmc1RFJFbDhzVjhZWXBHNm9MN1VqQUFwQ3hPRFErWll3SjJKbjJHK3hDOXlqam1abm5GenpHVjdCVWpITQgCcGdVSHpuR1N3SWNwelpQRmpKaGpYcVozK0J5TE04S2tjeVdKUFJsenpCYlB6eXRKalpUNitUeW1OSDhPUHlKNmpyTUZVY0Z6bkprYUhqQWY0eW4xaXlWaDB2cDVRbCtQK1hsOXBHdFB6L3pMZWdWTTZkZ3Nmb1NmZE8zcytmcDVRc1o4enN3WWFXMWNucGYzZkV5a05GNlU1U0dkUzVUR2tzYnowbnlsL3N6c2NPbllMT1JBem85bFNmY3doZTNQbW1QZ0JieEJJUEtqQVJhd0JyYUlXZ00vNEozRld6VjlSb0ZuaG1pMVdKRE16Nkl4a0Z2R296R0ZISXVGTkd0TGExc0FwdS9zN0pGNDF6ZHpGeUZsL0x4UHVCd0F1K205WEQvdjQwd0FjRTRkQU1VWDh6NzlYT1E2bGdGd3NZMGpFV2ZQK3FhdkUvSWxJQUk1UUVXK0JscEFEeGdEYzZReWUrQU0zSkdLL1VFSWlBQ3hZQm5nQUQ1SUIyS3dFcXdGdWFBQUZJRWRZQThvQXhYZ0VEZ0tUb0JUb0JHMGdNdmdHcmdGdXNBOThBZ01nR0h3RW95QkQyQVNnaUFjUklZb2tDcWtEUmxBWnBBMVJJZGNJVzhvRUFxRFlxRUVLQmtTUWhKb0xiUUpLb0tLb1RLb0VxcUJmb2JPUVplaEcxQTM5QUFhaEVhaE0IAnQ5Qm5HQVdUWUNxc0NSdkNpMkE2eklBRDRBaDRLWndNcjRCejRIeDRHMXdLVjhISDRRYjRNbndMdmdjUHdDL2hjUlJBeWFDVVVUb29jeFFkNVlrS1FjV2hrbEJpMUhwVUlhb0VWWVdxUXpXajJsRjNVQU9vVjZoUGFDeWFncWFoemRIT2FEOTBKSnFEWG9GZWo5NktMa01mUlRlZ3I2THZvQWZSWStodkdESkdBMk9HY2NJd01UR1laTXhLVEFHbUJITVljeGJUaHJtSEdjWjh3R0t4eWxnanJBUFdEeHVMVGNHdXdXN0ZIc0RXWXk5aHU3RkQySEVjRHFlS004TzU0RUp3YkZ3V3JnQzNEM2NjZHhIWGd4dkdmY1RMNExYeDFuZ2ZmQnhlaU0vRGwrQ1A0Uy9nZS9EUDhaTUVlWUlCd1lrUVF1QVNWaE8yRTZvSnpZVGJoR0hDSkZHQmFFUjBJVVlRVTRpNXhGSmlIYkdOMkU5OEp5TWpveXZqS0JNcUk1RFpLRk1xYzFMbXVzeWd6Q2VTSXNtVTVFbUtKMGxJMjBoSFNKZElEMGp2eUdTeUlkbWRIRWZPSW04ajE1Q3ZrSitRUDhwU1pDMWttYkpjMlEyeTViSU5zajJ5citVSWNnWnlETGxsY2pseUpYS241VzdMdlpJbnlCdktlOHF6NWRmTGw4dWZNCAJrKytWSDFlZ0tGZ3BoQ2lrSzJ4Vk9LWndRMkZFRWFkb3FPaXR5RlhNVnp5a2VFVnhpSUtpNkZFOEtSektKa28xcFkweVRNVlNqYWhNYWdxMWlIcUMya2tkVTFKVXNsV0tVbHFsVks1MFhtbEFHYVZzcU14VVRsUGVybnhLK2I3eTV3V2FDeGdMZUF1MkxLaGIwTE5nUWtWZHhWMkZwMUtvVXE5eVQrV3pLazNWV3pWVmRhZHFvK3BqTmJTYXFWcW8ya3ExZzJwdGFxL1VxZXJPNmh6MVF2VlQ2ZzgxWUExVGpUQ05OUnFITkRvMHhqVzFOSDAxUlpyN05LOW92dEpTMW5MWFN0SGFyWFZCYTFTYm91MnFMZERlclgxUit3Vk5pY2FncGRGS2FWZHBZem9hT240NkVwMUtuVTZkU1YwajNVamRQTjE2M2NkNlJEMjZYcExlYnIxV3ZURjliZjBnL2JYNnRmb1BEUWdHZEFPK3dWNkRkb01KUXlQRGFNUE5obzJHSTBZcVJreWpIS05hbzM1anNyR2I4UXJqS3VPN0psZ1R1a21xeVFHVExsUFkxTTZVYjFwdWV0c01Ock0zRTVnZE1PdGVpRm5vdUZDNHNHcGhyem5KbkdHZWJWNXJQbWloYkJGb2tXZlJhUEY2a2Y2aXVFVTdGN1V2K21acFo1bG1XVzM1TQgCeUVyUnl0OHF6NnJaNnEyMXFUWEh1dHo2cmczWnhzZG1nMDJUelJ0Yk0xdWU3VUhiUGp1S1haRGRacnRXdTYvMkR2WmkrenI3VVFkOWh3U0gvUTY5ZENxZFJkOUt2KzZJY2ZSdzNPRFk0dmpKeWQ0cHkrbVUwNS9PNXM2cHpzZWNSeFliTGVZdHJsNDg1S0xyd25hcGRCbHdwYmttdVA3b091Q200OFoycTNKNzZxN256blUvN1A2Y1ljSklZUnhudlBhdzlCQjduUFdZOEhUeVhPZDV5UXZsNWV0VjZOWHByZWdkNlYzbS9jUkgxeWZacDlabnpOZk9kNDN2SlQrTVg0RGZUcjllcGlhVHc2eGhqdms3K0svenZ4cEFDZ2dQS0F0NEdtZ2FLQTVzRG9LRC9JTjJCZlVIR3dRTGd4dERRQWd6WkZmSVk1WVJhd1hybDFCc0tDdTBQUFJabUZYWTJyRDJjRXI0OHZCajRSOGlQQ0syUnp5S05JNlVSTFpHeVVYRlI5VkVUVVI3UlJkSEQ4UXNpbGtYY3l0V0xWWVEyeFNIaTR1S094dzN2c1I3eVo0bHcvRjI4UVh4OTVjYUxWMjE5TVl5dFdWcHk4NHZsMXZPWG40NkFaTVFuWEFzNFFzN2hGM0ZIazlrSnU1UEhPTjRjdlp5WG5MZHVidTVvendYWGpIdk0IAmVaSkxVbkhTU0xKTDhxN2tVYjRidjRUL1N1QXBLQk84U2ZGTHFVaVpTQTFKUFpJNmxSYWRWcCtPVDA5SVB5ZFVGS1lLcjJab1pheks2QmFaaVFwRUF5dWNWdXhaTVNZT0VCL09oREtYWmpabFVaSG1xRU5pTFBsT01wanRtbDJlL1hGbDFNclRxeFJXQ1ZkMXJEWmR2V1gxOHh5Zm5KL1dvTmR3MXJTdTFWbWJ1M1p3SFdOZDVYcG9mZUw2MWcxNkcvSTNERy8wM1hnMGw1aWJtdnRybm1WZWNkNzdUZEdibXZNMTh6Zm1EMzNuKzExdGdXeUJ1S0Izcy9QbWl1L1Izd3UrNzl4aXMyWGZsbStGM01LYlJaWkZKVVZmdG5LMjN2ekI2b2ZTSDZhMkpXM3IzRzYvL2VBTzdBN2hqdnM3M1hZZUxWWW96aWtlMmhXMHEyRTNiWGZoN3ZkN2x1KzVVV0piVXJHWHVGZXlkNkEwc0xScG4vNitIZnUrbFBITDdwVjdsTmZ2MTlpL1pmL0VBZTZCbm9QdUIrc3FOQ3VLS2o3L0tQaXhyOUszc3FIS3NLcmtFUFpROXFGbjFWSFY3VC9SZjZvNXJIYTQ2UERYSThJakEwZkRqbDZ0Y2FpcE9hWnhiSHN0WEN1cEhUMGVmN3pyaE5lSnBqcnp1c3A2NWZxaWsrQ2tNCAI1T1NMbnhOK3ZuOHE0RlRyYWZycHVqTUdaL2FmcFp3dGJJQWFWamVNTmZJYkI1cGltN3JQK1o5cmJYWnVQdnVMeFM5SFduUmF5czhybmQ5K2dYZ2gvOExVeFp5TDQ1ZEVsMTVkVHI0ODFMcTg5ZEdWbUN0M3I0WmU3V3dMYUx0K3plZmFsWFpHKzhYckx0ZGJiampkT0hlVGZyUHhsdjJ0aGc2N2pyTy8ydjE2dHRPK3MrRzJ3KzJtTHNldTV1N0YzUmQ2M0hvdTMvRzZjKzB1OCs2dGU4SDN1dTlIM3UvcmplOGQ2T1AyalR4SWUvRG1ZZmJEeVVjYit6SDloWS9sSDVjODBYaFM5WnZKYi9VRDlnUG5CNzBHTzU2R1AzMDB4Qmw2K1h2bTcxK0c4NStSbjVVODEzNWVNMkk5MGpMcU05cjFZc21MNFplaWw1T3ZDdjVRK0dQL2ErUFhaLzUwLzdOakxHWnMrSTM0emRUYnJlOVUzeDE1Yi91K2RadzEvdVJEK29mSmljS1BxaCtQZnFKL2F2OGMvZm41NU1vdnVDK2xYMDIrTm44TCtOWS9sVDQxSldLTDJUT3RBQXBST0NrSmdMZEhBQ0RIQWtEcEFvQzRaTGFubmhGbzluL0FESUgveExOOTk0ellBMURyRGtBNG9pR0lIdGdJZ0FIaWxrY3NDM21PTQgCY0Fld2pZMVU1L3JmbVY1OVd1U1BBMUI1emRyQngrTnhTd1VOL0VObSsvaS8xUDFQQzZSWi8yYi9CVnFMQmpINXpUWENBQUFBVm1WWVNXWk5UUUFxQUFBQUNBQUJoMmtBQkFBQUFBRUFBQUFhQUFBQUFBQURrb1lBQndBQUFCSUFBQUJFb0FJQUJBQUFBQUVBQUFBTG9BTUFCQUFBQUFFQUFBQUtBQUFBQUVGVFEwbEpBQUFBVTJOeVpXVnVjMmh2ZE5VNG5UVUFBQUhVYVZSWWRGaE5URHBqYjIwdVlXUnZZbVV1ZUcxd0FBQUFBQUE4ZURwNGJYQnRaWFJoSUhodGJHNXpPbmc5SW1Ga2IySmxPbTV6T20xbGRHRXZJaUI0T25odGNIUnJQU0pZVFZBZ1EyOXlaU0EyTGpBdU1DSStDaUFnSUR4eVpHWTZVa1JHSUhodGJHNXpPbkprWmowaWFIUjBjRG92TDNkM2R5NTNNeTV2Y21jdk1UazVPUzh3TWk4eU1pMXlaR1l0YzNsdWRHRjRMVzV6SXlJK0NpQWdJQ0FnSUR4eVpHWTZSR1Z6WTNKcGNIUnBiMjRnY21SbU9tRmliM1YwUFNJaUNpQWdJQ0FnSUNBZ0lDQWdJSGh0Ykc1ek9tVjRhV1k5SW1oMGRIQTZMeTl1Y3k1aFpHOWlaUzVqYjIwdk2wAVpYaHBaaTh4TGpBdklqNEtJQ0FnSUNBZ0lDQWdQR1Y0YVdZNlVHbDRaV3haUkdsdFpXNXphVzl1UGpFd1BDOWxlR2xtT2xCcGVHVnNXVVJwYldWdWMybHZiajRLSUNBZ0lDQWdJQ0FnUEdWNGFXWTZVR2w0Wld4WVJHbHRaVzV6YVc5dVBqRXhQQzlsZUdsbU9sQnBlR1ZzV0VScGJXVnVjMmx2Ymo0S0lDQWdJQ0FnSUNBZ1BHVjRhV1k2VlhObGNrTnZiVzFsYm5RK1UyTnlaV1Z1YzJodmREd3ZaWGhwWmpwVmMyVnlRMjl0YldWdWRENEtJQ0FnSUNBZ1BDOXlaR1k2UkdWelkzSnBjSFJwYjI0K0NpQWdJRHd2Y21SbU9sSkVSajRLUEM5NE9uaHRjRzFsZEdFK0NsVGowb2NBQUFBOVNVUkJWQmdaWTJSaVpmM1BRQ1JnSWxJZFdObGdWQXp6S1RvTjh4Y0xUQUltQU9PajB5QjVGa2FZS2lKb0pKTkJadUhYaW1ReUl3TzZjblErQUtRSkRDS0hjOHJqQUFBQUFFbEZUa1N1UW1DQ2gBFyAVZLtJee21105+7TrqJl11tzyeYnWDddkY53jD7T68DwABATlAGwAAAAAAADBVU0Rsb3VQNjIwaHU2ZnF5MkhqUWpQNmlUYyt5b1pMZ0Y3MzV2QmlUb0VBTEVBPT0BAwQBAAAAARcgFWS7SXnttddOfu066iZddbc8nmJ1g3XZGOd4w+0+vA8AAAAA",
 				txbuilder.BaseRuneEtchTxParams{
 					InscriptionReveal: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "84bd445f3ca377b26ffe99330f38a6d9e6dec09c63abcaeebca8a9f447db95ae",
-								Index:   0,
-								Amount:  big.NewInt(7176), // 0.00007176 BTC.
-								Script:  []byte("USAHxwe9OuK1tTiqtxJLdUgxzIOQB9klNwJNmp85ipUKZg=="),
-								Address: "tb1pqlrs00f6u26m2w92kufyka2gx8xg8yq8myjnwqjdn20nnz54pfnq6jx4ad",
+								Outpoint: bitcoin.MustOutpoint("84bd445f3ca377b26ffe99330f38a6d9e6dec09c63abcaeebca8a9f447db95ae", 0),
+								Amount:   big.NewInt(7176), // 0.00007176 BTC.
+								Script:   []byte("USAHxwe9OuK1tTiqtxJLdUgxzIOQB9klNwJNmp85ipUKZg=="),
+								Address:  "tb1pqlrs00f6u26m2w92kufyka2gx8xg8yq8myjnwqjdn20nnz54pfnq6jx4ad",
 							},
 						},
 						Address: "tb1pqlrs00f6u26m2w92kufyka2gx8xg8yq8myjnwqjdn20nnz54pfnq6jx4ad",
@@ -656,95 +904,82 @@ func TestTxBuilder(t *testing.T) {
 					AdditionalPayments: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d498489a8ac7832c545ca983f3b62c98d0796e3a90931fe1c0a5775692d15182",
-								Index:   0,
-								Amount:  big.NewInt(500000000),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("d498489a8ac7832c545ca983f3b62c98d0796e3a90931fe1c0a5775692d15182", 0),
+								Amount:   big.NewInt(500000000),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "6e890e44f9f79e556d0eb7c52eefa3f286f5cbfa981b80b2a7c18f8165b33ea1",
-								Index:   1,
-								Amount:  big.NewInt(99886770),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("6e890e44f9f79e556d0eb7c52eefa3f286f5cbfa981b80b2a7c18f8165b33ea1", 1),
+								Amount:   big.NewInt(99886770),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "5cd6a0a0ba4e14c2e97b8c48f1929f6c7ec902640ebfaa3e4586e8bd30675fa6",
-								Index:   1,
-								Amount:  big.NewInt(99333840),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("5cd6a0a0ba4e14c2e97b8c48f1929f6c7ec902640ebfaa3e4586e8bd30675fa6", 1),
+								Amount:   big.NewInt(99333840),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "2c5ef2311c95a140442cfa29b8fdfd1221d61213b38758091a62d0cd8615a9d3",
-								Index:   6,
-								Amount:  big.NewInt(972446),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("2c5ef2311c95a140442cfa29b8fdfd1221d61213b38758091a62d0cd8615a9d3", 6),
+								Amount:   big.NewInt(972446),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "9e1d53d91e13d6174cdaaa55edc0c2f83b6d74ad72c2846178f308893b4c21c5",
-								Index:   7,
-								Amount:  big.NewInt(183848),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("9e1d53d91e13d6174cdaaa55edc0c2f83b6d74ad72c2846178f308893b4c21c5", 7),
+								Amount:   big.NewInt(183848),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "84bd445f3ca377b26ffe99330f38a6d9e6dec09c63abcaeebca8a9f447db95ae",
-								Index:   1,
-								Amount:  big.NewInt(7072),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("84bd445f3ca377b26ffe99330f38a6d9e6dec09c63abcaeebca8a9f447db95ae", 1),
+								Amount:   big.NewInt(7072),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "557907016445b99f55563143fe93b24248c291428a736dc4bfd60f1b5c0a785c",
-								Index:   1,
-								Amount:  big.NewInt(6976),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("557907016445b99f55563143fe93b24248c291428a736dc4bfd60f1b5c0a785c", 1),
+								Amount:   big.NewInt(6976),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "89fc9847c6f3c466e28dbe40f7ce963b7201227f5359058a090ece1e85c5837e",
-								Index:   4,
-								Amount:  big.NewInt(2500),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("89fc9847c6f3c466e28dbe40f7ce963b7201227f5359058a090ece1e85c5837e", 4),
+								Amount:   big.NewInt(2500),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "90718612bfcfdb06f1583f87e65079a1e3d4fdeced46aa847444b40560313d78",
-								Index:   4,
-								Amount:  big.NewInt(2500),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("90718612bfcfdb06f1583f87e65079a1e3d4fdeced46aa847444b40560313d78", 4),
+								Amount:   big.NewInt(2500),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "6ff6aae5a2ed339810946eca6eeb30739ea5028d2250732e576cdb0addf82527",
-								Index:   4,
-								Amount:  big.NewInt(2500),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("6ff6aae5a2ed339810946eca6eeb30739ea5028d2250732e576cdb0addf82527", 4),
+								Amount:   big.NewInt(2500),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "2a52e2efb33a0377906545a030fe778c333d6922cf1085ea9a7ca4dc6bc5c03c",
-								Index:   4,
-								Amount:  big.NewInt(2500),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("2a52e2efb33a0377906545a030fe778c333d6922cf1085ea9a7ca4dc6bc5c03c", 4),
+								Amount:   big.NewInt(2500),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "82736c9620e828a3871ad92ea769bd43aeac7d62ac0a8aade7992a5c6adf677f",
-								Index:   6,
-								Amount:  big.NewInt(600),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("82736c9620e828a3871ad92ea769bd43aeac7d62ac0a8aade7992a5c6adf677f", 6),
+								Amount:   big.NewInt(600),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 							{
-								TxHash:  "9e1d53d91e13d6174cdaaa55edc0c2f83b6d74ad72c2846178f308893b4c21c5",
-								Index:   6,
-								Amount:  big.NewInt(600),
-								Script:  []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
-								Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
+								Outpoint: bitcoin.MustOutpoint("9e1d53d91e13d6174cdaaa55edc0c2f83b6d74ad72c2846178f308893b4c21c5", 6),
+								Amount:   big.NewInt(600),
+								Script:   []byte("USDlouP620hu6fqy2HjQjP6iTc+yoZLgF735vBiToEALEA=="),
+								Address:  "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
 							},
 						},
 						Address: "tb1puk3w87kmfphwn74jmpudpr875fxulv4pjtsp000ehsvf8gzqpvgqvmvx99",
@@ -758,13 +993,336 @@ func TestTxBuilder(t *testing.T) {
 		}
 		for i, test := range tests {
 			t.Run(fmt.Sprintf("test %d", i), func(t *testing.T) {
-				result, err := txBuilder.BuildRuneEtchTx(test.params)
+				result, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, test.params))
 				require.NoError(t, err)
 				require.EqualValues(t, test.expectedTxB64, base64.StdEncoding.EncodeToString(result.SerializedPSBT))
 			})
 		}
 	})
 
+	t.Run("BuildRuneEtchTx rune name validation", func(t *testing.T) {
+		rune_, err := runes.NewRuneFromString("HELLO")
+		require.NoError(t, err)
+
+		params := txbuilder.BaseRuneEtchTxParams{
+			InscriptionReveal: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+						Amount:   big.NewInt(850000), // 0.0085 BTC.
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+					},
+				},
+				Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+				PubKey:  "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+			},
+			Inscription: &inscriptions.Inscription{
+				Rune: rune_,
+				Body: []byte("test data"),
+			},
+			Rune: &runes.Etching{
+				Divisibility: toPointer(byte(5)),
+				Premine:      big.NewInt(1000000000),
+				Rune:         rune_,
+				Symbol:       toPointer(']'),
+			},
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+			SatoshiChangeAddress:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+		}
+
+		t.Run("locked", func(t *testing.T) {
+			params.CurrentBlockHeight = 840000
+			_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.ErrorIs(t, err, txbuilder.ErrRuneNameLocked)
+
+			var lockedErr *txbuilder.RuneNameLockedError
+			require.True(t, errors.As(err, &lockedErr))
+			require.EqualValues(t, "HELLO", lockedErr.Rune)
+			require.EqualValues(t, 840001, lockedErr.Height)
+			require.EqualValues(t, rune_.UnlockHeight(), lockedErr.UnlockHeight)
+			require.EqualValues(t, 12, lockedErr.MinNameLength)
+		})
+
+		t.Run("unlocked", func(t *testing.T) {
+			params.CurrentBlockHeight = rune_.UnlockHeight() - 1
+			_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+
+			params.CurrentBlockHeight = 0
+			_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+		})
+
+		t.Run("spacers", func(t *testing.T) {
+			params.CurrentBlockHeight = 0
+			params.Rune.Spacers = toPointer(uint32(0b1111))
+			_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+
+			params.Rune.Spacers = toPointer(uint32(0b10000))
+			_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.ErrorIs(t, err, runes.ErrInvalidSpacers)
+			params.Rune.Spacers = nil
+		})
+
+		t.Run("commitment", func(t *testing.T) {
+			params.CurrentBlockHeight = 0
+			params.Inscription.Rune = nil
+			_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.ErrorIs(t, err, txbuilder.ErrMissingRuneCommitment)
+
+			params.Inscription.Rune, err = runes.NewRuneFromString("HELLOWORLD")
+			require.NoError(t, err)
+			_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.ErrorIs(t, err, txbuilder.ErrMissingRuneCommitment)
+
+			params.Inscription.Rune = rune_
+			_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+		})
+
+		t.Run("commitment maturity", func(t *testing.T) {
+			params.CurrentBlockHeight = rune_.UnlockHeight()
+			params.CommitBlockHeight = rune_.UnlockHeight() - 3
+			_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.ErrorIs(t, err, txbuilder.ErrImmatureRuneCommitment)
+
+			// INFO: reveal tx in the next block gets the 6th confirmation of the commitment.
+			params.CommitBlockHeight = rune_.UnlockHeight() - 4
+			_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+			params.CommitBlockHeight = 0
+		})
+
+		t.Run("reserved", func(t *testing.T) {
+			params.CurrentBlockHeight = 0
+			params.Rune.Rune = runes.RuneReserve(runes.RuneID{Block: 840000, TxID: 1})
+			_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.ErrorIs(t, err, txbuilder.ErrReservedRuneName)
+		})
+
+		t.Run("omitted name", func(t *testing.T) {
+			params.CurrentBlockHeight = 840000
+			params.Rune.Rune = nil
+			params.Inscription.Rune = nil
+			result, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+			require.NoError(t, err)
+
+			runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[0].PkScript)
+			require.NoError(t, err)
+			require.NotNil(t, runestone.Etching)
+			require.Nil(t, runestone.Etching.Rune)
+		})
+	})
+
+	t.Run("BuildRuneEtchTx turbo with open mint terms", func(t *testing.T) {
+		rune_, err := runes.NewRuneFromString("HELLO")
+		require.NoError(t, err)
+
+		terms := func() *runes.Terms {
+			return &runes.Terms{
+				Amount:      big.NewInt(1000),
+				Cap:         big.NewInt(21000),
+				HeightStart: toPointer(uint64(840010)),
+				HeightEnd:   toPointer(uint64(850000)),
+				OffsetEnd:   toPointer(uint64(5000)),
+			}
+		}
+		params := txbuilder.BaseRuneEtchTxParams{
+			InscriptionReveal: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+						Amount:   big.NewInt(850000), // 0.0085 BTC.
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+					},
+				},
+				Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+				PubKey:  "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+			},
+			Inscription: &inscriptions.Inscription{
+				Rune: rune_,
+				Body: []byte("test data"),
+			},
+			Rune: &runes.Etching{
+				Divisibility: toPointer(byte(2)),
+				Premine:      big.NewInt(1000000),
+				Rune:         rune_,
+				Symbol:       toPointer(']'),
+				Terms:        terms(),
+				Turbo:        true,
+			},
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+			SatoshiChangeAddress:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+		}
+		parseRunestone := func(t *testing.T, serializedPSBT []byte) *runes.Runestone {
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+			require.NoError(t, err)
+			runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[0].PkScript)
+			require.NoError(t, err)
+
+			return runestone
+		}
+
+		t.Run("golden", func(t *testing.T) {
+			result, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+			require.EqualValues(t, "cHNidP8BAK0CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AwAAAAAAAAAAJmpdIwECAgcEvoHlAQVdBsCEPQiIpAEK6AcMyqIzDtDwMxKIJxYBIgIAAAAAAAAiUSDJNteVAzZwcCPLnRgIbT6Xk34xxXH/zsdw2IQLjiBaZI3xDAAAAAAAF6kUqliOlGHn/KzNELU020ci3XIxIsGHAAAAAAABAStQ+AwAAAAAACJRIKORZKj+oB/2gHANo69z5nLqemP2Hikixwzd4TCHAomVAQMEAQAAAAEFOiD1iiqYZYL/1oDlcvJBP+6mzgXa2L7QBP5aJiGYMShn+qwAYwNvcmQBDQO+QDkACXRlc3QgZGF0YWgBFyD1iiqYZYL/1oDlcvJBP+6mzgXa2L7QBP5aJiGYMShn+gAAAAA=",
+				base64.StdEncoding.EncodeToString(result.SerializedPSBT))
+
+			runestone := parseRunestone(t, result.SerializedPSBT)
+			require.True(t, runestone.Etching.Turbo)
+			require.Equal(t, terms(), runestone.Etching.Terms)
+			require.EqualValues(t, 1, *runestone.Pointer)
+		})
+
+		t.Run("pointer", func(t *testing.T) {
+			// INFO: outputs: runestone, runes recipient, btc change.
+			params.RunesPointer = toPointer(uint32(2))
+			result, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+			require.EqualValues(t, 2, *parseRunestone(t, result.SerializedPSBT).Pointer)
+
+			params.PremineSplittingFactor = 4
+			result, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+			runestone := parseRunestone(t, result.SerializedPSBT)
+			require.EqualValues(t, 2, *runestone.Pointer)
+			require.Len(t, runestone.Edicts, 1)
+			params.PremineSplittingFactor = 0
+
+			for _, pointer := range []uint32{0, 3} {
+				params.RunesPointer = toPointer(pointer)
+				_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+				require.ErrorIs(t, err, txbuilder.ErrInvalidRunesPointer)
+			}
+			params.RunesPointer = nil
+		})
+
+		t.Run("invalid terms", func(t *testing.T) {
+			tests := []func(terms *runes.Terms){
+				func(terms *runes.Terms) { terms.Amount = nil },
+				func(terms *runes.Terms) { terms.Cap = big.NewInt(0) },
+				func(terms *runes.Terms) { terms.HeightStart = toPointer(uint64(850000)) },
+				func(terms *runes.Terms) { terms.OffsetEnd = toPointer(uint64(0)) },
+				func(terms *runes.Terms) {
+					terms.OffsetStart, terms.OffsetEnd = toPointer(uint64(10)), toPointer(uint64(10))
+				},
+				func(terms *runes.Terms) { terms.Cap = numbers.MaxUInt128Value },
+			}
+			for i, test := range tests {
+				params.Rune.Terms = terms()
+				test(params.Rune.Terms)
+				_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+				require.ErrorIs(t, err, txbuilder.ErrInvalidEtchingTerms, i)
+			}
+
+			// INFO: omitted name is not locked at any height.
+			params.Rune.Rune = nil
+			params.Rune.Terms = terms()
+			params.CurrentBlockHeight = 849999
+			_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.ErrorIs(t, err, txbuilder.ErrInvalidEtchingTerms)
+
+			params.CurrentBlockHeight = 849998
+			_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+			params.CurrentBlockHeight = 0
+			params.Rune.Rune = rune_
+		})
+	})
+
+	t.Run("postage", func(t *testing.T) {
+		rune_, err := runes.NewRuneFromString("HELLO")
+		require.NoError(t, err)
+
+		inscription := &inscriptions.Inscription{
+			Rune: rune_,
+			Body: []byte("test data"),
+		}
+		commitParams := txbuilder.BaseInscriptionTxParams{
+			Sender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+						Amount:   big.NewInt(100000), // 0.001 BTC.
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+					},
+				},
+				Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+				PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+			},
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			Inscription:           inscription,
+			InscriptionBasePubKey: "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+		}
+
+		commitOutput := func(t *testing.T, params txbuilder.BaseInscriptionTxParams) int64 {
+			result, err := txBuilder.BuildInscriptionTx(params)
+			require.NoError(t, err)
+
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+			require.NoError(t, err)
+
+			return p.UnsignedTx.TxOut[0].Value
+		}
+
+		defaultDeposit := commitOutput(t, commitParams)
+		commitParams.Postage = big.NewInt(10000)
+		deposit := commitOutput(t, commitParams)
+		require.EqualValues(t, 10000-546, deposit-defaultDeposit)
+
+		revealParams := txbuilder.BaseRuneEtchTxParams{
+			InscriptionReveal: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0),
+						Amount:   big.NewInt(deposit),
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+					},
+				},
+				Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+				PubKey:  "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+			},
+			Inscription: inscription,
+			Rune: &runes.Etching{
+				Divisibility: toPointer(byte(5)),
+				Premine:      big.NewInt(1000000000),
+				Rune:         rune_,
+			},
+			SatoshiPerKVByte:      big.NewInt(5000), // 5 sat/vB.
+			RunesRecipientAddress: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg",
+			SatoshiChangeAddress:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			Postage:               big.NewInt(10000),
+		}
+
+		result, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, revealParams))
+		require.NoError(t, err)
+		require.Empty(t, result.UsedAdditionalBaseUTXOs)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.Len(t, p.UnsignedTx.TxOut, 2)
+		require.EqualValues(t, 10000, p.UnsignedTx.TxOut[1].Value)
+
+		commitParams.Postage = big.NewInt(545)
+		_, err = txBuilder.BuildInscriptionTx(commitParams)
+		require.ErrorIs(t, err, txbuilder.ErrPostageTooLow)
+
+		revealParams.Postage = big.NewInt(100)
+		_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, revealParams))
+		require.ErrorIs(t, err, txbuilder.ErrPostageTooLow)
+	})
+
 	t.Run("BuildRuneEtchTx with primine splitting factor", func(t *testing.T) {
 		rune_, err := runes.NewRuneFromString("HELLO")
 		require.NoError(t, err)
@@ -780,7 +1338,7 @@ func TestTxBuilder(t *testing.T) {
 		}{
 			{
 				name:            "psf - 0, no change",
-				expectedTxB64:   "cHNidP8BAH8CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AgAAAAAAAAAAGGpdFQEFAgEDJQS+geUBBV0GgJTr3AMWASICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQAAAAAAAEBJcMGAAAAAAAAHF9iaXRjb2luX3RyYW5zYWN0aW9uX3NjcmlwdF8BAwQBAAAAAQU6IPWKKphlgv/WgOVy8kE/7qbOBdrYvtAE/lomIZgxKGf6rABjA29yZAENA75AOQAJdGVzdCBkYXRhaAEXIPWKKphlgv/WgOVy8kE/7qbOBdrYvtAE/lomIZgxKGf6AAAA",
+				expectedTxB64:   "cHNidP8BAH8CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AgAAAAAAAAAAGGpdFQEFAgEDBQS+geUBBV0GgJTr3AMWASICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQAAAAAAAEBK8MGAAAAAAAAIlEgo5FkqP6gH/aAcA2jr3Pmcup6Y/YeKSLHDN3hMIcCiZUBAwQBAAAAAQU6IPWKKphlgv/WgOVy8kE/7qbOBdrYvtAE/lomIZgxKGf6rABjA29yZAENA75AOQAJdGVzdCBkYXRhaAEXIPWKKphlgv/WgOVy8kE/7qbOBdrYvtAE/lomIZgxKGf6AAAA",
 				expectedOutputs: 2,
 				edictsSize:      0,
 				pointer:         toPointer[uint32](1),
@@ -789,11 +1347,10 @@ func TestTxBuilder(t *testing.T) {
 					InscriptionReveal: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(1731), // no change.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(1731), // no change.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
 							},
 						},
 						Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
@@ -807,7 +1364,7 @@ func TestTxBuilder(t *testing.T) {
 						Divisibility: toPointer(byte(5)),
 						Premine:      big.NewInt(1000000000),
 						Rune:         rune_,
-						Spacers:      toPointer(uint32(37)),
+						Spacers:      toPointer(uint32(5)),
 						Symbol:       toPointer(']'),
 					},
 					SatoshiPerKVByte:       big.NewInt(5000), // 5 sat/vB.
@@ -818,7 +1375,7 @@ func TestTxBuilder(t *testing.T) {
 			},
 			{
 				name:            "psf - 0 + change",
-				expectedTxB64:   "cHNidP8BAJ8CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AwAAAAAAAAAAGGpdFQEFAgEDJQS+geUBBV0GgJTr3AMWASICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQjAgAAAAAAABepFKpYjpRh5/yszRC1NNtHIt1yMSLBhwAAAAAAAQEl5ggAAAAAAAAcX2JpdGNvaW5fdHJhbnNhY3Rpb25fc2NyaXB0XwEDBAEAAAABBTog9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/qsAGMDb3JkAQ0DvkA5AAl0ZXN0IGRhdGFoARcg9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/oAAAAA",
+				expectedTxB64:   "cHNidP8BAJ8CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AwAAAAAAAAAAGGpdFQEFAgEDBQS+geUBBV0GgJTr3AMWASICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQjAgAAAAAAABepFKpYjpRh5/yszRC1NNtHIt1yMSLBhwAAAAAAAQEr5ggAAAAAAAAiUSCjkWSo/qAf9oBwDaOvc+Zy6npj9h4pIscM3eEwhwKJlQEDBAEAAAABBTog9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/qsAGMDb3JkAQ0DvkA5AAl0ZXN0IGRhdGFoARcg9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/oAAAAA",
 				expectedOutputs: 3,
 				edictsSize:      0,
 				pointer:         toPointer[uint32](1),
@@ -827,11 +1384,10 @@ func TestTxBuilder(t *testing.T) {
 					InscriptionReveal: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(2278), // 546 change.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(2278), // 546 change.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
 							},
 						},
 						Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
@@ -845,7 +1401,7 @@ func TestTxBuilder(t *testing.T) {
 						Divisibility: toPointer(byte(5)),
 						Premine:      big.NewInt(1000000000),
 						Rune:         rune_,
-						Spacers:      toPointer(uint32(37)),
+						Spacers:      toPointer(uint32(5)),
 						Symbol:       toPointer(']'),
 					},
 					SatoshiPerKVByte:       big.NewInt(5000), // 5 sat/vB.
@@ -856,7 +1412,7 @@ func TestTxBuilder(t *testing.T) {
 			},
 			{
 				name:            "psf - 1, no change",
-				expectedTxB64:   "cHNidP8BAH8CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AgAAAAAAAAAAGGpdFQEFAgEDJQS+geUBBV0GgJTr3AMWASICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQAAAAAAAEBJcMGAAAAAAAAHF9iaXRjb2luX3RyYW5zYWN0aW9uX3NjcmlwdF8BAwQBAAAAAQU6IPWKKphlgv/WgOVy8kE/7qbOBdrYvtAE/lomIZgxKGf6rABjA29yZAENA75AOQAJdGVzdCBkYXRhaAEXIPWKKphlgv/WgOVy8kE/7qbOBdrYvtAE/lomIZgxKGf6AAAA",
+				expectedTxB64:   "cHNidP8BAH8CAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AgAAAAAAAAAAGGpdFQEFAgEDBQS+geUBBV0GgJTr3AMWASICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQAAAAAAAEBK8MGAAAAAAAAIlEgo5FkqP6gH/aAcA2jr3Pmcup6Y/YeKSLHDN3hMIcCiZUBAwQBAAAAAQU6IPWKKphlgv/WgOVy8kE/7qbOBdrYvtAE/lomIZgxKGf6rABjA29yZAENA75AOQAJdGVzdCBkYXRhaAEXIPWKKphlgv/WgOVy8kE/7qbOBdrYvtAE/lomIZgxKGf6AAAA",
 				expectedOutputs: 2,
 				edictsSize:      0,
 				pointer:         toPointer[uint32](1),
@@ -865,11 +1421,10 @@ func TestTxBuilder(t *testing.T) {
 					InscriptionReveal: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(1731), // no change.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(1731), // no change.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
 							},
 						},
 						Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
@@ -883,7 +1438,7 @@ func TestTxBuilder(t *testing.T) {
 						Divisibility: toPointer(byte(5)),
 						Premine:      big.NewInt(1000000000),
 						Rune:         rune_,
-						Spacers:      toPointer(uint32(37)),
+						Spacers:      toPointer(uint32(5)),
 						Symbol:       toPointer(']'),
 					},
 					SatoshiPerKVByte:       big.NewInt(5000), // 5 sat/vB.
@@ -894,7 +1449,7 @@ func TestTxBuilder(t *testing.T) {
 			},
 			{
 				name:            "psf - 2, no change, divisible",
-				expectedTxB64:   "cHNidP8BALECAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AwAAAAAAAAAAH2pdHAEFAgEDJQS+geUBBV0GgJTr3AMAAACAyrXuAQMiAgAAAAAAACJRIMk215UDNnBwI8udGAhtPpeTfjHFcf/Ox3DYhAuOIFpkIgIAAAAAAAAiUSDJNteVAzZwcCPLnRgIbT6Xk34xxXH/zsdw2IQLjiBaZAAAAAAAAQElewkAAAAAAAAcX2JpdGNvaW5fdHJhbnNhY3Rpb25fc2NyaXB0XwEDBAEAAAABBTog9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/qsAGMDb3JkAQ0DvkA5AAl0ZXN0IGRhdGFoARcg9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/oAAAAA",
+				expectedTxB64:   "cHNidP8BALECAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////AwAAAAAAAAAAH2pdHAEFAgEDBQS+geUBBV0GgJTr3AMAAACAyrXuAQMiAgAAAAAAACJRIMk215UDNnBwI8udGAhtPpeTfjHFcf/Ox3DYhAuOIFpkIgIAAAAAAAAiUSDJNteVAzZwcCPLnRgIbT6Xk34xxXH/zsdw2IQLjiBaZAAAAAAAAQErewkAAAAAAAAiUSCjkWSo/qAf9oBwDaOvc+Zy6npj9h4pIscM3eEwhwKJlQEDBAEAAAABBTog9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/qsAGMDb3JkAQ0DvkA5AAl0ZXN0IGRhdGFoARcg9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/oAAAAA",
 				expectedOutputs: 3,
 				edictsSize:      1,
 				pointer:         nil,
@@ -903,11 +1458,10 @@ func TestTxBuilder(t *testing.T) {
 					InscriptionReveal: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(2427), // no change.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(2427), // no change.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
 							},
 						},
 						Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
@@ -921,7 +1475,7 @@ func TestTxBuilder(t *testing.T) {
 						Divisibility: toPointer(byte(5)),
 						Premine:      big.NewInt(1000000000),
 						Rune:         rune_,
-						Spacers:      toPointer(uint32(37)),
+						Spacers:      toPointer(uint32(5)),
 						Symbol:       toPointer(']'),
 					},
 					SatoshiPerKVByte:       big.NewInt(5000), // 5 sat/vB.
@@ -932,7 +1486,7 @@ func TestTxBuilder(t *testing.T) {
 			},
 			{
 				name:            "psf - 3, no change, not divisible",
-				expectedTxB64:   "cHNidP8BAOACAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////BAAAAAAAAAAAI2pdIAEFAgEDJQS+geUBBV0GgJTr3AMAAAABAQAA1Yb5ngEEIgIAAAAAAAAiUSDJNteVAzZwcCPLnRgIbT6Xk34xxXH/zsdw2IQLjiBaZCICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQiAgAAAAAAACJRIMk215UDNnBwI8udGAhtPpeTfjHFcf/Ox3DYhAuOIFpkAAAAAAABASUzDAAAAAAAABxfYml0Y29pbl90cmFuc2FjdGlvbl9zY3JpcHRfAQMEAQAAAAEFOiD1iiqYZYL/1oDlcvJBP+6mzgXa2L7QBP5aJiGYMShn+qwAYwNvcmQBDQO+QDkACXRlc3QgZGF0YWgBFyD1iiqYZYL/1oDlcvJBP+6mzgXa2L7QBP5aJiGYMShn+gAAAAAA",
+				expectedTxB64:   "cHNidP8BAOACAAAAAUZXKFP369ZOSUKg4F+781Lp64ePDidu1UPsQxzWUorXAgAAAAD/////BAAAAAAAAAAAI2pdIAEFAgEDBQS+geUBBV0GgJTr3AMAAAABAQAA1Yb5ngEEIgIAAAAAAAAiUSDJNteVAzZwcCPLnRgIbT6Xk34xxXH/zsdw2IQLjiBaZCICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQiAgAAAAAAACJRIMk215UDNnBwI8udGAhtPpeTfjHFcf/Ox3DYhAuOIFpkAAAAAAABASszDAAAAAAAACJRIKORZKj+oB/2gHANo69z5nLqemP2Hikixwzd4TCHAomVAQMEAQAAAAEFOiD1iiqYZYL/1oDlcvJBP+6mzgXa2L7QBP5aJiGYMShn+qwAYwNvcmQBDQO+QDkACXRlc3QgZGF0YWgBFyD1iiqYZYL/1oDlcvJBP+6mzgXa2L7QBP5aJiGYMShn+gAAAAAA",
 				expectedOutputs: 4,
 				edictsSize:      2,
 				pointer:         nil,
@@ -941,11 +1495,10 @@ func TestTxBuilder(t *testing.T) {
 					InscriptionReveal: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(3123), // no change.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(3123), // no change.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
 							},
 						},
 						Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
@@ -959,7 +1512,7 @@ func TestTxBuilder(t *testing.T) {
 						Divisibility: toPointer(byte(5)),
 						Premine:      big.NewInt(1000000000),
 						Rune:         rune_,
-						Spacers:      toPointer(uint32(37)),
+						Spacers:      toPointer(uint32(5)),
 						Symbol:       toPointer(']'),
 					},
 					SatoshiPerKVByte:       big.NewInt(5000), // 5 sat/vB.
@@ -970,7 +1523,7 @@ func TestTxBuilder(t *testing.T) {
 			},
 			{
 				name:            "psf - 3, change, not divisible",
-				expectedTxB64:   "cHNidP8BAP0AAQIAAAABRlcoU/fr1k5JQqDgX7vzUunrh48OJ27VQ+xDHNZSitcCAAAAAP////8FAAAAAAAAAAAjal0gAQUCAQMlBL6B5QEFXQaAlOvcAwAAAAEBAADVhvmeAQUiAgAAAAAAACJRIMk215UDNnBwI8udGAhtPpeTfjHFcf/Ox3DYhAuOIFpkIgIAAAAAAAAiUSDJNteVAzZwcCPLnRgIbT6Xk34xxXH/zsdw2IQLjiBaZCICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQjAgAAAAAAABepFKpYjpRh5/yszRC1NNtHIt1yMSLBhwAAAAAAAQElVg4AAAAAAAAcX2JpdGNvaW5fdHJhbnNhY3Rpb25fc2NyaXB0XwEDBAEAAAABBTog9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/qsAGMDb3JkAQ0DvkA5AAl0ZXN0IGRhdGFoARcg9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/oAAAAAAAA=",
+				expectedTxB64:   "cHNidP8BAP0AAQIAAAABRlcoU/fr1k5JQqDgX7vzUunrh48OJ27VQ+xDHNZSitcCAAAAAP////8FAAAAAAAAAAAjal0gAQUCAQMFBL6B5QEFXQaAlOvcAwAAAAEBAADVhvmeAQUiAgAAAAAAACJRIMk215UDNnBwI8udGAhtPpeTfjHFcf/Ox3DYhAuOIFpkIgIAAAAAAAAiUSDJNteVAzZwcCPLnRgIbT6Xk34xxXH/zsdw2IQLjiBaZCICAAAAAAAAIlEgyTbXlQM2cHAjy50YCG0+l5N+McVx/87HcNiEC44gWmQjAgAAAAAAABepFKpYjpRh5/yszRC1NNtHIt1yMSLBhwAAAAAAAQErVg4AAAAAAAAiUSCjkWSo/qAf9oBwDaOvc+Zy6npj9h4pIscM3eEwhwKJlQEDBAEAAAABBTog9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/qsAGMDb3JkAQ0DvkA5AAl0ZXN0IGRhdGFoARcg9YoqmGWC/9aA5XLyQT/ups4F2ti+0AT+WiYhmDEoZ/oAAAAAAAA=",
 				expectedOutputs: 5,
 				edictsSize:      2,
 				pointer:         nil,
@@ -979,11 +1532,10 @@ func TestTxBuilder(t *testing.T) {
 					InscriptionReveal: &txbuilder.PaymentData{
 						UTXOs: []bitcoin.UTXO{
 							{
-								TxHash:  "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746",
-								Index:   2,
-								Amount:  big.NewInt(3670), // change 546.
-								Script:  []byte("_bitcoin_transaction_script_"),
-								Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+								Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+								Amount:   big.NewInt(3670), // change 546.
+								Script:   []byte("_bitcoin_transaction_script_"),
+								Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
 							},
 						},
 						Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
@@ -997,7 +1549,7 @@ func TestTxBuilder(t *testing.T) {
 						Divisibility: toPointer(byte(5)),
 						Premine:      big.NewInt(1000000000),
 						Rune:         rune_,
-						Spacers:      toPointer(uint32(37)),
+						Spacers:      toPointer(uint32(5)),
 						Symbol:       toPointer(']'),
 					},
 					SatoshiPerKVByte:       big.NewInt(5000), // 5 sat/vB.
@@ -1009,7 +1561,7 @@ func TestTxBuilder(t *testing.T) {
 		}
 		for _, test := range tests {
 			t.Run(test.name, func(t *testing.T) {
-				result, err := txBuilder.BuildRuneEtchTx(test.params)
+				result, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, test.params))
 				require.NoError(t, err)
 				require.EqualValues(t, test.expectedTxB64, base64.StdEncoding.EncodeToString(result.SerializedPSBT))
 
@@ -1041,12 +1593,198 @@ func TestTxBuilder(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("BuildRuneEtchTx with premine distribution", func(t *testing.T) {
+		rune_, err := runes.NewRuneFromString("HELLO")
+		require.NoError(t, err)
+
+		distribution := []txbuilder.PremineAllocation{
+			{Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg", Amount: big.NewInt(700000000)},
+			{Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt", Amount: big.NewInt(200000000)},
+			{Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1", Amount: big.NewInt(100000000)},
+		}
+		params := txbuilder.BaseRuneEtchTxParams{
+			InscriptionReveal: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+						Amount:   big.NewInt(10000),
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+					},
+				},
+				Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+				PubKey:  "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+			},
+			Inscription: &inscriptions.Inscription{
+				Rune: rune_,
+				Body: []byte("test data"),
+			},
+			Rune: &runes.Etching{
+				Premine: big.NewInt(1000000000),
+				Rune:    rune_,
+			},
+			SatoshiPerKVByte:     big.NewInt(5000), // 5 sat/vB.
+			SatoshiChangeAddress: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			PremineDistribution:  distribution,
+		}
+
+		t.Run("valid", func(t *testing.T) {
+			result, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+			require.NoError(t, err)
+			require.Len(t, p.UnsignedTx.TxOut, 5) // runestone, allocations and change.
+
+			runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[0].PkScript)
+			require.NoError(t, err)
+			require.Nil(t, runestone.Pointer)
+			require.Len(t, runestone.Edicts, len(distribution))
+
+			for i, allocation := range distribution {
+				require.EqualValues(t, i+1, runestone.Edicts[i].Output)
+				require.True(t, numbers.IsEqual(allocation.Amount, runestone.Edicts[i].Amount))
+
+				address, err := txbuilder.DecodeAddress(allocation.Address, &chaincfg.TestNet3Params)
+				require.NoError(t, err)
+				script, err := txscript.PayToAddrScript(address)
+				require.NoError(t, err)
+				require.Equal(t, script, p.UnsignedTx.TxOut[i+1].PkScript)
+			}
+		})
+
+		t.Run("invalid", func(t *testing.T) {
+			invalid := params
+			invalid.PremineDistribution = distribution[:2]
+			_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, invalid))
+			require.ErrorIs(t, err, txbuilder.ErrInvalidPremineDistribution)
+
+			invalid.PremineDistribution = append(slices.Clone(distribution[:2]),
+				txbuilder.PremineAllocation{Address: distribution[2].Address, Amount: big.NewInt(0)})
+			_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, invalid))
+			require.ErrorIs(t, err, txbuilder.ErrInvalidPremineDistribution)
+
+			invalid.PremineDistribution = distribution
+			invalid.PremineSplittingFactor = 3
+			_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, invalid))
+			require.ErrorIs(t, err, txbuilder.ErrInvalidPremineDistribution)
+		})
+	})
+
+	t.Run("BuildRuneEtchTx with premine split between recipients", func(t *testing.T) {
+		rune_, err := runes.NewRuneFromString("HELLO")
+		require.NoError(t, err)
+
+		distribution := []txbuilder.PremineAllocation{
+			{Address: "tb1peymd09grxec8qg7tn5vqsmf7j7fhuvw9w8lua3msmzzqhr3qtfjqlj50zg"},
+			{Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt"},
+			{Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1"},
+		}
+		params := txbuilder.BaseRuneEtchTxParams{
+			InscriptionReveal: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+						Amount:   big.NewInt(10000),
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+					},
+				},
+				Address: "tb1p5wgkf2875q0ldqrspk367ulxwt485clkrc5j93cvmhsnppcz3x2srcptmt",
+				PubKey:  "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+			},
+			Inscription: &inscriptions.Inscription{
+				Rune: rune_,
+				Body: []byte("test data"),
+			},
+			Rune: &runes.Etching{
+				Premine: big.NewInt(1000000001),
+				Rune:    rune_,
+			},
+			SatoshiPerKVByte:     big.NewInt(5000), // 5 sat/vB.
+			SatoshiChangeAddress: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			PremineDistribution:  distribution,
+		}
+
+		edicts := func(t *testing.T, params txbuilder.BaseRuneEtchTxParams) []int64 {
+			result, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, params))
+			require.NoError(t, err)
+
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+			require.NoError(t, err)
+			require.Len(t, p.UnsignedTx.TxOut, 5) // runestone, recipients and change.
+
+			for i, allocation := range params.PremineDistribution {
+				address, err := txbuilder.DecodeAddress(allocation.Address, &chaincfg.TestNet3Params)
+				require.NoError(t, err)
+				script, err := txscript.PayToAddrScript(address)
+				require.NoError(t, err)
+				require.Equal(t, script, p.UnsignedTx.TxOut[i+1].PkScript)
+			}
+
+			runestone, err := runes.ParseRunestone(p.UnsignedTx.TxOut[0].PkScript)
+			require.NoError(t, err)
+			require.Nil(t, runestone.Pointer)
+
+			amounts := make([]int64, 0, len(runestone.Edicts))
+			for i, edict := range runestone.Edicts {
+				require.EqualValues(t, i+1, edict.Output)
+				amounts = append(amounts, edict.Amount.Int64())
+			}
+
+			return amounts
+		}
+
+		t.Run("equal split", func(t *testing.T) {
+			// INFO: remainder goes to the first recipient.
+			require.Equal(t, []int64{333333335, 333333333, 333333333}, edicts(t, params))
+		})
+
+		t.Run("split of the rest", func(t *testing.T) {
+			mixed := params
+			mixed.PremineDistribution = slices.Clone(distribution)
+			mixed.PremineDistribution[1].Amount = big.NewInt(1)
+			require.Equal(t, []int64{500000000, 1, 500000000}, edicts(t, mixed))
+		})
+
+		t.Run("invalid", func(t *testing.T) {
+			invalid := params
+			invalid.Rune = &runes.Etching{Premine: big.NewInt(2), Rune: rune_}
+			_, err := txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, invalid))
+			require.ErrorIs(t, err, txbuilder.ErrInvalidPremineDistribution)
+
+			invalid = params
+			invalid.PremineDistribution = slices.Clone(distribution)
+			invalid.PremineDistribution[0].Amount = big.NewInt(1000000000)
+			_, err = txBuilder.BuildRuneEtchTx(withCommitScript(t, txBuilder, invalid))
+			require.ErrorIs(t, err, txbuilder.ErrInvalidPremineDistribution)
+		})
+	})
 }
 
 func toPointer[T any](val T) *T {
 	return &val
 }
 
+// withCommitScript returns params with the inscription commitment utxo locked by the inscription commit script.
+
+func withCommitScript(t *testing.T, builder *txbuilder.TxBuilder, params txbuilder.BaseRuneEtchTxParams) txbuilder.BaseRuneEtchTxParams {
+	if params.Inscription == nil || params.InscriptionReveal == nil || len(params.InscriptionReveal.UTXOs) == 0 {
+		return params
+	}
+
+	script, err := builder.CommitScript(params.Inscription, params.InscriptionReveal.PubKey, params.Recovery)
+	require.NoError(t, err)
+
+	reveal := *params.InscriptionReveal
+	reveal.UTXOs = append([]bitcoin.UTXO(nil), reveal.UTXOs...)
+	reveal.UTXOs[0].Script = script
+	params.InscriptionReveal = &reveal
+
+	return params
+}
+
 func insufficientErrWithCauserSender(err *txbuilder.InsufficientError) error {
 	err.Causer = txbuilder.CauserSender
 	return err
@@ -0,0 +1,316 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package txbuildertest provides helpers to compare PSBTs built by txbuilder and test wallet fixtures.
+package txbuildertest
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+// TestingT describes subset of testing.TB used by assertions.
+type TestingT interface {
+	Errorf(format string, args ...any)
+	FailNow()
+}
+
+// Option defines functional option to configure PSBTs comparison.
+type Option func(config *compareConfig)
+
+// compareConfig defines PSBTs comparison configuration.
+type compareConfig struct {
+	ignoreInputOrder  bool
+	ignoreOutputOrder bool
+}
+
+// IgnoreInputOrder makes comparison independent of inputs order, inputs indexes
+// stored by txbuilder in the inputs helping keys are remapped accordingly.
+func IgnoreInputOrder() Option {
+	return func(config *compareConfig) {
+		config.ignoreInputOrder = true
+	}
+}
+
+// IgnoreOutputOrder makes comparison independent of outputs order.
+func IgnoreOutputOrder() Option {
+	return func(config *compareConfig) {
+		config.ignoreOutputOrder = true
+	}
+}
+
+// AssertPSBTEqual checks that serialized PSBTs are equal modulo ignored fields,
+// reports human-readable diff otherwise. Returns true if PSBTs are equal.
+func AssertPSBTEqual(t TestingT, expected, actual []byte, opts ...Option) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	diff, err := Diff(expected, actual, opts...)
+	if err != nil {
+		t.Errorf("compare psbt: %v", err)
+		return false
+	}
+
+	if diff != "" {
+		t.Errorf("psbt mismatch (-expected +actual):\n%s", diff)
+		return false
+	}
+
+	return true
+}
+
+// RequirePSBTEqual is like AssertPSBTEqual, but stops test execution on mismatch.
+func RequirePSBTEqual(t TestingT, expected, actual []byte, opts ...Option) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if !AssertPSBTEqual(t, expected, actual, opts...) {
+		t.FailNow()
+	}
+}
+
+// Diff returns line diff of human-readable serialized PSBTs representation,
+// lines prefixed with "-" are expected only, with "+" are actual only.
+// Returns empty string if PSBTs are equal modulo ignored fields.
+func Diff(expected, actual []byte, opts ...Option) (string, error) {
+	var config compareConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	expectedPacket, err := psbt.NewFromRawBytes(bytes.NewReader(expected), false)
+	if err != nil {
+		return "", fmt.Errorf("expected: %w", err)
+	}
+
+	actualPacket, err := psbt.NewFromRawBytes(bytes.NewReader(actual), false)
+	if err != nil {
+		return "", fmt.Errorf("actual: %w", err)
+	}
+
+	normalize(expectedPacket, config)
+	normalize(actualPacket, config)
+
+	return diffLines(strings.Split(Render(expectedPacket), "\n"), strings.Split(Render(actualPacket), "\n")), nil
+}
+
+// Render returns human-readable PSBT representation, one field per line.
+func Render(p *psbt.Packet) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "version: %d\n", p.UnsignedTx.Version)
+	fmt.Fprintf(&b, "locktime: %d\n", p.UnsignedTx.LockTime)
+
+	for idx, txIn := range p.UnsignedTx.TxIn {
+		fmt.Fprintf(&b, "input %d: %s sequence: %d\n", idx, txIn.PreviousOutPoint, txIn.Sequence)
+		if idx < len(p.Inputs) {
+			renderInput(&b, &p.Inputs[idx])
+		}
+	}
+
+	for idx, txOut := range p.UnsignedTx.TxOut {
+		fmt.Fprintf(&b, "output %d: %d %x\n", idx, txOut.Value, txOut.PkScript)
+		if idx < len(p.Outputs) {
+			renderUnknowns(&b, "  ", p.Outputs[idx].Unknowns)
+		}
+	}
+
+	renderUnknowns(&b, "", p.Unknowns)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderInput writes non-empty input fields.
+func renderInput(b *strings.Builder, in *psbt.PInput) {
+	if in.NonWitnessUtxo != nil {
+		fmt.Fprintf(b, "  non witness utxo: %s\n", in.NonWitnessUtxo.TxHash())
+	}
+	if in.WitnessUtxo != nil {
+		fmt.Fprintf(b, "  witness utxo: %d %x\n", in.WitnessUtxo.Value, in.WitnessUtxo.PkScript)
+	}
+	if in.SighashType != 0 {
+		fmt.Fprintf(b, "  sighash type: %d\n", in.SighashType)
+	}
+	for _, sig := range in.PartialSigs {
+		fmt.Fprintf(b, "  partial sig: %x %x\n", sig.PubKey, sig.Signature)
+	}
+	if len(in.RedeemScript) != 0 {
+		fmt.Fprintf(b, "  redeem script: %x\n", in.RedeemScript)
+	}
+	if len(in.WitnessScript) != 0 {
+		fmt.Fprintf(b, "  witness script: %x\n", in.WitnessScript)
+	}
+	if len(in.FinalScriptSig) != 0 {
+		fmt.Fprintf(b, "  final script sig: %x\n", in.FinalScriptSig)
+	}
+	if len(in.FinalScriptWitness) != 0 {
+		fmt.Fprintf(b, "  final script witness: %x\n", in.FinalScriptWitness)
+	}
+	if len(in.TaprootKeySpendSig) != 0 {
+		fmt.Fprintf(b, "  taproot key spend sig: %x\n", in.TaprootKeySpendSig)
+	}
+	for _, sig := range in.TaprootScriptSpendSig {
+		fmt.Fprintf(b, "  taproot script spend sig: %x %x %x %d\n", sig.XOnlyPubKey, sig.LeafHash, sig.Signature, sig.SigHash)
+	}
+	for _, leaf := range in.TaprootLeafScript {
+		fmt.Fprintf(b, "  taproot leaf script: %x %x %d\n", leaf.ControlBlock, leaf.Script, leaf.LeafVersion)
+	}
+	if len(in.TaprootInternalKey) != 0 {
+		fmt.Fprintf(b, "  taproot internal key: %x\n", in.TaprootInternalKey)
+	}
+	if len(in.TaprootMerkleRoot) != 0 {
+		fmt.Fprintf(b, "  taproot merkle root: %x\n", in.TaprootMerkleRoot)
+	}
+
+	renderUnknowns(b, "  ", in.Unknowns)
+}
+
+// renderUnknowns writes unknown fields with provided indent.
+func renderUnknowns(b *strings.Builder, indent string, unknowns []*psbt.Unknown) {
+	for _, unknown := range unknowns {
+		fmt.Fprintf(b, "%sunknown %x: %x\n", indent, unknown.Key, unknown.Value)
+	}
+}
+
+// normalize sorts PSBT fields which order does not matter or is ignored by config.
+func normalize(p *psbt.Packet, config compareConfig) {
+	if config.ignoreInputOrder {
+		normalizeInputOrder(p)
+	}
+
+	if config.ignoreOutputOrder {
+		order := make([]int, len(p.UnsignedTx.TxOut))
+		for idx := range order {
+			order[idx] = idx
+		}
+
+		txOuts := p.UnsignedTx.TxOut
+		sort.SliceStable(order, func(i, j int) bool {
+			if txOuts[order[i]].Value != txOuts[order[j]].Value {
+				return txOuts[order[i]].Value < txOuts[order[j]].Value
+			}
+
+			return bytes.Compare(txOuts[order[i]].PkScript, txOuts[order[j]].PkScript) < 0
+		})
+
+		sortedTxOuts, sortedOutputs := make([]*wire.TxOut, len(order)), make([]psbt.POutput, len(p.Outputs))
+		for newIdx, oldIdx := range order {
+			sortedTxOuts[newIdx] = txOuts[oldIdx]
+			if oldIdx < len(p.Outputs) {
+				sortedOutputs[newIdx] = p.Outputs[oldIdx]
+			}
+		}
+		p.UnsignedTx.TxOut, p.Outputs = sortedTxOuts, sortedOutputs
+	}
+
+	sortUnknowns(p.Unknowns)
+	for idx := range p.Inputs {
+		sortUnknowns(p.Inputs[idx].Unknowns)
+	}
+	for idx := range p.Outputs {
+		sortUnknowns(p.Outputs[idx].Unknowns)
+	}
+}
+
+// normalizeInputOrder sorts inputs by previous outpoint and remaps indexes of the inputs helping keys.
+func normalizeInputOrder(p *psbt.Packet) {
+	order := make([]int, len(p.UnsignedTx.TxIn))
+	for idx := range order {
+		order[idx] = idx
+	}
+
+	txIns := p.UnsignedTx.TxIn
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := txIns[order[i]].PreviousOutPoint, txIns[order[j]].PreviousOutPoint
+		if cmp := bytes.Compare(a.Hash[:], b.Hash[:]); cmp != 0 {
+			return cmp < 0
+		}
+
+		return a.Index < b.Index
+	})
+
+	newIdxs := make([]byte, len(order))
+	sortedTxIns, sortedInputs := make([]*wire.TxIn, len(order)), make([]psbt.PInput, len(p.Inputs))
+	for newIdx, oldIdx := range order {
+		newIdxs[oldIdx] = byte(newIdx)
+		sortedTxIns[newIdx] = txIns[oldIdx]
+		if oldIdx < len(p.Inputs) {
+			sortedInputs[newIdx] = p.Inputs[oldIdx]
+		}
+	}
+	p.UnsignedTx.TxIn, p.Inputs = sortedTxIns, sortedInputs
+
+	for _, unknown := range p.Unknowns {
+		if _, err := txbuilder.InputsHelpingKeyFromBytes(unknown.Key); err != nil {
+			continue
+		}
+
+		value := make([]byte, len(unknown.Value))
+		for idx, oldIdx := range unknown.Value {
+			value[idx] = oldIdx
+			if int(oldIdx) < len(newIdxs) {
+				value[idx] = newIdxs[oldIdx]
+			}
+		}
+		sort.Slice(value, func(i, j int) bool { return value[i] < value[j] })
+		unknown.Value = value
+	}
+}
+
+// sortUnknowns sorts unknown fields by key.
+func sortUnknowns(unknowns []*psbt.Unknown) {
+	sort.SliceStable(unknowns, func(i, j int) bool {
+		return bytes.Compare(unknowns[i].Key, unknowns[j].Key) < 0
+	})
+}
+
+// diffLines returns line diff based on the longest common subsequence, empty if lines are equal.
+func diffLines(expected, actual []string) string {
+	// lcs[i][j] is the longest common subsequence length of expected[i:] and actual[j:].
+	lcs := make([][]int, len(expected)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if expected[i] == actual[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var (
+		b       strings.Builder
+		changed bool
+		i, j    int
+	)
+	for i < len(expected) || j < len(actual) {
+		switch {
+		case i < len(expected) && j < len(actual) && expected[i] == actual[j]:
+			b.WriteString("  " + expected[i] + "\n")
+			i, j = i+1, j+1
+		case i < len(expected) && (j == len(actual) || lcs[i+1][j] >= lcs[i][j+1]):
+			b.WriteString("- " + expected[i] + "\n")
+			i, changed = i+1, true
+		default:
+			b.WriteString("+ " + actual[j] + "\n")
+			j, changed = j+1, true
+		}
+	}
+
+	if !changed {
+		return ""
+	}
+
+	return b.String()
+}
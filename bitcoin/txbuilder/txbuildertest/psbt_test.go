@@ -0,0 +1,102 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuildertest_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+// recorder is TestingT which records failures.
+type recorder struct {
+	errors []string
+	failed bool
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) FailNow() { r.failed = true }
+
+func TestPSBTComparison(t *testing.T) {
+	params := txbuilder.BaseBTCTransferParams{
+		TransferSatoshiAmount: big.NewInt(29500), // 0.000295 BTC.
+		Sender: &txbuilder.PaymentData{
+			UTXOs: []bitcoin.UTXO{
+				{
+					Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2),
+					Amount:   big.NewInt(20000), // 0.0002 BTC.
+					Script:   []byte("_bitcoin_transaction_script_"),
+					Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+				},
+				{
+					Outpoint: bitcoin.MustOutpoint("a78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 0),
+					Amount:   big.NewInt(15000), // 0.00015 BTC.
+					Script:   []byte("_bitcoin_transaction_script_"),
+					Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+				},
+			},
+			Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+			PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+		},
+		SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+		RecipientAddress: "tb1p9m40h0uj4uk37hsgvm97h4shhx2kyhehvfax8rysfhwjdp2ycvgqtxqsu0",
+	}
+
+	asBuilt, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params).BuildBTCTransferTx(params)
+	require.NoError(t, err)
+
+	sorted, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params,
+		txbuilder.WithOutputOrdering(txbuilder.OutputOrderingBIP69)).BuildBTCTransferTx(params)
+	require.NoError(t, err)
+
+	params.Sender.UTXOs[0], params.Sender.UTXOs[1] = params.Sender.UTXOs[1], params.Sender.UTXOs[0]
+	reversedInputs, err := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params).BuildBTCTransferTx(params)
+	require.NoError(t, err)
+
+	t.Run("equal", func(t *testing.T) {
+		diff, err := txbuildertest.Diff(asBuilt.SerializedPSBT, asBuilt.SerializedPSBT)
+		require.NoError(t, err)
+		require.Empty(t, diff)
+
+		txbuildertest.RequirePSBTEqual(t, asBuilt.SerializedPSBT, asBuilt.SerializedPSBT)
+	})
+
+	t.Run("output order", func(t *testing.T) {
+		diff, err := txbuildertest.Diff(asBuilt.SerializedPSBT, sorted.SerializedPSBT)
+		require.NoError(t, err)
+		require.Contains(t, diff, "- output 0: 29500")
+		require.Contains(t, diff, "+ output 1: 29500")
+
+		txbuildertest.RequirePSBTEqual(t, asBuilt.SerializedPSBT, sorted.SerializedPSBT, txbuildertest.IgnoreOutputOrder())
+	})
+
+	t.Run("input order", func(t *testing.T) {
+		diff, err := txbuildertest.Diff(asBuilt.SerializedPSBT, reversedInputs.SerializedPSBT)
+		require.NoError(t, err)
+		require.NotEmpty(t, diff)
+
+		txbuildertest.RequirePSBTEqual(t, asBuilt.SerializedPSBT, reversedInputs.SerializedPSBT, txbuildertest.IgnoreInputOrder())
+	})
+
+	t.Run("mismatch reporting", func(t *testing.T) {
+		r := new(recorder)
+		require.False(t, txbuildertest.AssertPSBTEqual(r, asBuilt.SerializedPSBT, sorted.SerializedPSBT))
+		require.Len(t, r.errors, 1)
+		require.False(t, r.failed)
+
+		txbuildertest.RequirePSBTEqual(r, asBuilt.SerializedPSBT, []byte("invalid"))
+		require.Len(t, r.errors, 2)
+		require.True(t, r.failed)
+	})
+}
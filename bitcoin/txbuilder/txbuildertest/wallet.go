@@ -0,0 +1,70 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuildertest
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+// Wallet defines test wallet with random private key and key path spendable P2TR address.
+type Wallet struct {
+	PrivateKey *btcec.PrivateKey
+	Address    string
+	PubKey     string // hex encoded x-only public key.
+	Script     []byte // ScriptPubKey of the address.
+}
+
+// NewWallet returns Wallet with P2TR address of the network.
+func NewWallet(t TestingT, networkParams *chaincfg.Params) Wallet {
+	privateKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	address, err := utils.P2TRAddressFromInternalKey(privateKey.PubKey(), nil, networkParams)
+	require.NoError(t, err)
+
+	script, err := txscript.PayToAddrScript(address)
+	require.NoError(t, err)
+
+	return Wallet{
+		PrivateKey: privateKey,
+		Address:    address.EncodeAddress(),
+		PubKey:     hex.EncodeToString(schnorr.SerializePubKey(privateKey.PubKey())),
+		Script:     script,
+	}
+}
+
+// UTXO returns wallet UTXO of the transaction output with amount in Satoshi.
+func (wallet Wallet) UTXO(txHash string, index uint32, amount int64) bitcoin.UTXO {
+	return bitcoin.UTXO{
+		Outpoint: bitcoin.MustOutpoint(txHash, index),
+		Amount:   big.NewInt(amount),
+		Script:   wallet.Script,
+		Address:  wallet.Address,
+	}
+}
+
+// PaymentData returns wallet PaymentData with UTXOs of the transaction outputs
+// indexed in amounts order.
+func (wallet Wallet) PaymentData(txHash string, amounts ...int64) *txbuilder.PaymentData {
+	data := &txbuilder.PaymentData{
+		Address: wallet.Address,
+		PubKey:  wallet.PubKey,
+	}
+	for i, amount := range amounts {
+		data.UTXOs = append(data.UTXOs, wallet.UTXO(txHash, uint32(i), amount))
+	}
+
+	return data
+}
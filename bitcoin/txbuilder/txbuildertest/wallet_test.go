@@ -0,0 +1,33 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuildertest_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestWallet(t *testing.T) {
+	const txHash = "d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746"
+
+	wallet := txbuildertest.NewWallet(t, &chaincfg.TestNet3Params)
+
+	address, err := btcutil.DecodeAddress(wallet.Address, &chaincfg.TestNet3Params)
+	require.NoError(t, err)
+	require.IsType(t, &btcutil.AddressTaproot{}, address)
+
+	data := wallet.PaymentData(txHash, 1000, 2000)
+	require.Equal(t, wallet.Address, data.Address)
+	require.Equal(t, wallet.PubKey, data.PubKey)
+	require.Len(t, data.UTXOs, 2)
+	require.Equal(t, wallet.UTXO(txHash, 1, 2000), data.UTXOs[1])
+	require.Equal(t, bitcoin.MustOutpoint(txHash, 1), data.UTXOs[1].Outpoint)
+	require.Equal(t, bitcoin.ScriptTypeP2TR, data.UTXOs[1].ScriptType())
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ErrMalleableTxID describes that transaction id depends on input signatures and can not be pre-computed.
+var ErrMalleableTxID = errors.New("transaction id depends on signatures")
+
+// PrecomputeTxID returns transaction id of the unsigned PSBT as it will be after signing, e.g. to persist
+// inscription commitment transaction id for the reveal - etch transaction before the commitment is signed.
+// Witness is not committed to the transaction id, so segwit inputs are left with empty witness placeholders,
+// nested segwit inputs get deterministic signature script with redeem script push.
+// Returns ErrMalleableTxID if any legacy input requires signature in the signature script.
+func PrecomputeTxID(serializedPSBT []byte) (chainhash.Hash, error) {
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	tx := p.UnsignedTx.Copy()
+	for i := range tx.TxIn {
+		tx.TxIn[i].Witness = nil
+		tx.TxIn[i].SignatureScript, err = inputSignatureScript(p, i)
+		if err != nil {
+			return chainhash.Hash{}, fmt.Errorf("input %d: %w", i, err)
+		}
+	}
+
+	return tx.TxHash(), nil
+}
+
+// inputSignatureScript returns final signature script of the PSBT input if it does not depend on signatures.
+func inputSignatureScript(p *psbt.Packet, index int) ([]byte, error) {
+	input := p.Inputs[index]
+	if input.FinalScriptSig != nil || input.FinalScriptWitness != nil {
+		return input.FinalScriptSig, nil
+	}
+
+	var prevOut *wire.TxOut
+	switch {
+	case input.WitnessUtxo != nil:
+		prevOut = input.WitnessUtxo
+	case input.NonWitnessUtxo != nil:
+		outIndex := p.UnsignedTx.TxIn[index].PreviousOutPoint.Index
+		if int(outIndex) >= len(input.NonWitnessUtxo.TxOut) {
+			return nil, fmt.Errorf("%w: previous output %d is missing", ErrMalleableTxID, outIndex)
+		}
+
+		prevOut = input.NonWitnessUtxo.TxOut[outIndex]
+	default:
+		return nil, fmt.Errorf("%w: previous output is missing", ErrMalleableTxID)
+	}
+
+	switch {
+	case txscript.IsWitnessProgram(prevOut.PkScript):
+		return nil, nil
+	case txscript.IsPayToScriptHash(prevOut.PkScript) && txscript.IsWitnessProgram(input.RedeemScript):
+		return txscript.NewScriptBuilder().AddData(input.RedeemScript).Script()
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrMalleableTxID, txscript.GetScriptClass(prevOut.PkScript))
+	}
+}
+
+// TxID returns pre-computed transaction id of SerializedPSBT, see PrecomputeTxID.
+func (result BuildInscriptionTxPSBTResult) TxID() (chainhash.Hash, error) {
+	return PrecomputeTxID(result.SerializedPSBT)
+}
+
+// TxID returns pre-computed transaction id of SerializedPSBT, see PrecomputeTxID.
+func (result BuildBTCTransferTxResult) TxID() (chainhash.Hash, error) {
+	return PrecomputeTxID(result.SerializedPSBT)
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestPrecomputeTxID(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+	builder := txbuilder.NewTxBuilder(networkParams, txbuilder.WithInputOrdering(txbuilder.InputOrderingBIP69))
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	commitParams := func(address string, script []byte, pubKey string) txbuilder.BaseInscriptionTxParams {
+		utxo := func(txHash string, amount int64) bitcoin.UTXO {
+			return bitcoin.UTXO{Outpoint: bitcoin.MustOutpoint(txHash, 1), Amount: big.NewInt(amount), Script: script, Address: address}
+		}
+
+		return txbuilder.BaseInscriptionTxParams{
+			Sender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					utxo("f78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2000),
+					utxo("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 2000),
+				},
+				Address: address,
+				PubKey:  pubKey,
+			},
+			SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+			Inscription: &inscriptions.Inscription{
+				ContentType: "text/plain;charset=utf-8",
+				Body:        []byte("test data"),
+			},
+			InscriptionBasePubKey: "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+		}
+	}
+
+	t.Run("taproot", func(t *testing.T) {
+		result, err := builder.BuildInscriptionTx(commitParams(wallet.Address, wallet.Script, wallet.PubKey))
+		require.NoError(t, err)
+
+		txID, err := result.TxID()
+		require.NoError(t, err)
+
+		signed, err := signer.NewSigner(networkParams).SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: result.SerializedPSBT,
+			Inputs:         []int{0, 1},
+			PrivateKey:     wallet.PrivateKey,
+		})
+		require.NoError(t, err)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(signed), false)
+		require.NoError(t, err)
+		require.NoError(t, psbt.MaybeFinalizeAll(p))
+
+		signedTx, err := psbt.Extract(p)
+		require.NoError(t, err)
+		require.Equal(t, signedTx.TxHash(), txID)
+		require.True(t, signedTx.HasWitness())
+
+		precomputed, err := txbuilder.PrecomputeTxID(signed)
+		require.NoError(t, err)
+		require.Equal(t, txID, precomputed)
+	})
+
+	t.Run("nested segwit", func(t *testing.T) {
+		pubKey := wallet.PrivateKey.PubKey().SerializeCompressed()
+		redeemScript, err := txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(btcutil.Hash160(pubKey)).Script()
+		require.NoError(t, err)
+
+		address, err := btcutil.NewAddressScriptHash(redeemScript, networkParams)
+		require.NoError(t, err)
+
+		script, err := txscript.PayToAddrScript(address)
+		require.NoError(t, err)
+
+		result, err := builder.BuildInscriptionTx(commitParams(address.EncodeAddress(), script, hex.EncodeToString(pubKey)))
+		require.NoError(t, err)
+
+		txID, err := result.TxID()
+		require.NoError(t, err)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(result.SerializedPSBT), false)
+		require.NoError(t, err)
+		require.NotEqual(t, p.UnsignedTx.TxHash(), txID)
+
+		scriptSig, err := txscript.NewScriptBuilder().AddData(redeemScript).Script()
+		require.NoError(t, err)
+
+		for _, in := range p.UnsignedTx.TxIn {
+			in.SignatureScript = scriptSig
+		}
+		require.Equal(t, p.UnsignedTx.TxHash(), txID)
+	})
+
+	t.Run("legacy", func(t *testing.T) {
+		pubKey := wallet.PrivateKey.PubKey().SerializeCompressed()
+		address, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(pubKey), networkParams)
+		require.NoError(t, err)
+
+		script, err := txscript.PayToAddrScript(address)
+		require.NoError(t, err)
+
+		result, err := builder.BuildInscriptionTx(commitParams(address.EncodeAddress(), script, hex.EncodeToString(pubKey)))
+		require.NoError(t, err)
+
+		_, err = result.TxID()
+		require.ErrorIs(t, err, txbuilder.ErrMalleableTxID)
+	})
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+)
+
+const (
+	// MaxStandardTxWeight defines maximum weight of the transaction relayed by default policy.
+	MaxStandardTxWeight int64 = 400_000
+
+	// witnessScaleFactor defines how many times non-witness data costs more than witness data.
+	witnessScaleFactor = 4
+	// schnorrSignatureSize defines schnorr signature size with non-default sighash type in bytes.
+	schnorrSignatureSize = 65
+	// tapControlBlockSize defines control block size of the single leaf script tree in bytes.
+	tapControlBlockSize = 33
+	// maxSignatureSize defines the largest DER encoded ecdsa signature size with sighash type in bytes.
+	maxSignatureSize = 72
+	// compressedPubKeySize defines compressed public key size in bytes.
+	compressedPubKeySize = 33
+	// maxRunestoneScriptSize defines maximum standard OP_RETURN script size in bytes.
+	maxRunestoneScriptSize = 83
+	// taprootScriptSize defines P2TR output script size in bytes.
+	taprootScriptSize = 34
+)
+
+// RevealTxWeight returns estimated weight in weight units of the signed reveal transaction.
+// The first input is considered as inscription script path spending of the commitment with optional
+// recovery leaf, other inputs are estimated as the heaviest standard single key inputs.
+func RevealTxWeight(tx *wire.MsgTx, inscription *inscriptions.Inscription, recovery *inscriptions.RecoveryLeaf) (int64, error) {
+	// INFO: x-only public key is used in witness script, its value does not affect the size.
+	script, err := inscription.IntoScriptForWitness(make([]byte, 32))
+	if err != nil {
+		return 0, err
+	}
+
+	controlBlockSize := tapControlBlockSize
+	if recovery != nil {
+		controlBlockSize += inscriptions.RecoveryControlBlockVBytes * witnessScaleFactor
+	}
+
+	// INFO: placeholder witnesses, inscription script path spending: signature, inscription script, control block,
+	// other inputs: the heaviest standard single key witness (P2WPKH): ecdsa signature, compressed public key.
+	withWitness := tx.Copy()
+	for i := range withWitness.TxIn {
+		withWitness.TxIn[i].Witness = wire.TxWitness{make([]byte, maxSignatureSize), make([]byte, compressedPubKeySize)}
+		if i == 0 {
+			withWitness.TxIn[i].Witness = wire.TxWitness{make([]byte, schnorrSignatureSize), script, make([]byte, controlBlockSize)}
+		}
+	}
+
+	weight, _ := bitcoin.MsgTxWeight(withWitness)
+
+	return weight, nil
+}
+
+// unsignedTxWeight returns expected weight of the unsigned transaction after signing, utxos are spent by the
+// inputs in the same order, see bitcoin.PacketWeight.
+func unsignedTxWeight(tx *wire.MsgTx, utxos []*bitcoin.UTXO) (bitcoin.TxWeightReport, error) {
+	p, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return bitcoin.TxWeightReport{}, err
+	}
+
+	for i, utxo := range utxos {
+		p.Inputs[i].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
+	}
+
+	report, err := bitcoin.PacketWeight(p)
+	if err != nil {
+		return report, err
+	}
+
+	if len(report.UnknownInputs) != 0 {
+		utxo := utxos[report.UnknownInputs[0]]
+		return report, fmt.Errorf("%w: utxo %s script type %s", ErrUnsupportedAddressType, utxo.Outpoint.String(), utxo.ScriptType())
+	}
+
+	return report, nil
+}
+
+// checkRevealTxWeight returns NonStandardTxWeightError if reveal transaction exceeds MaxStandardTxWeight.
+func checkRevealTxWeight(tx *wire.MsgTx, inscription *inscriptions.Inscription, recovery *inscriptions.RecoveryLeaf) error {
+	weight, err := RevealTxWeight(tx, inscription, recovery)
+	if err != nil {
+		return err
+	}
+
+	if weight > MaxStandardTxWeight {
+		return &NonStandardTxWeightError{
+			Weight:    weight,
+			MaxWeight: MaxStandardTxWeight,
+			Overshoot: weight - MaxStandardTxWeight,
+		}
+	}
+
+	return nil
+}
+
+// revealTxSkeleton returns unsigned reveal transaction with maximum expected outputs sizes:
+// runestone, runes recipients and change outputs, to check weight before commitment.
+func revealTxSkeleton(runeOutputs int) *wire.MsgTx {
+	tx := wire.NewMsgTx(txVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(0, make([]byte, maxRunestoneScriptSize)))
+	for i := 0; i < runeOutputs+1; i++ {
+		tx.AddTxOut(wire.NewTxOut(0, make([]byte, taprootScriptSize)))
+	}
+
+	return tx
+}
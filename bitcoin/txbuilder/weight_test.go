@@ -0,0 +1,78 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+)
+
+func TestRevealTxWeight(t *testing.T) {
+	t.Run("RevealTxWeight", func(t *testing.T) {
+		inscription := &inscriptions.Inscription{ContentType: "text/plain", Body: []byte("hi")}
+		script, err := inscription.IntoScriptForWitness(make([]byte, 32))
+		require.NoError(t, err)
+		require.Len(t, script, 58)
+
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, nil, nil))
+		tx.AddTxOut(wire.NewTxOut(546, make([]byte, 34)))
+
+		// stripped: 94 bytes * 4, witness: marker and flag 2 + items 1 + signature 66 + script 59 + control block 34.
+		weight, err := txbuilder.RevealTxWeight(tx, inscription, nil)
+		require.NoError(t, err)
+		require.EqualValues(t, 94*4+2+1+66+59+34, weight)
+
+		// INFO: control block contains the recovery leaf hash.
+		recovery := &inscriptions.RecoveryLeaf{PubKey: make([]byte, 32), Delay: 144}
+		recoveryWeight, err := txbuilder.RevealTxWeight(tx, inscription, recovery)
+		require.NoError(t, err)
+		require.EqualValues(t, weight+32, recoveryWeight)
+
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, nil, nil))
+		additionalWeight, err := txbuilder.RevealTxWeight(tx, inscription, nil)
+		require.NoError(t, err)
+		require.EqualValues(t, weight+41*4+108, additionalWeight)
+	})
+
+	t.Run("BuildInscriptionTx exceeds standard weight", func(t *testing.T) {
+		txBuilder := txbuilder.NewTxBuilder(&chaincfg.TestNet3Params)
+		_, err := txBuilder.BuildInscriptionTx(txbuilder.BaseInscriptionTxParams{
+			Sender: &txbuilder.PaymentData{
+				UTXOs: []bitcoin.UTXO{
+					{
+						Outpoint: bitcoin.MustOutpoint("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 4),
+						Amount:   big.NewInt(100_000_000), // 1 BTC.
+						Script:   []byte("_bitcoin_transaction_script_"),
+						Address:  "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+					},
+				},
+				Address: "2N8mvwwUPfXt8FczXvE1UvM8ioVTW9LQLj1",
+				PubKey:  "03d17661b814dfaf3f7d6e70e8d4c8f5e6fdbe780a2c0373dd06ca7d75dc19f8be",
+			},
+			SatoshiPerKVByte: big.NewInt(5000), // 5 sat/vB.
+			Inscription: &inscriptions.Inscription{
+				ContentType: "image/png",
+				Body:        make([]byte, 400_000),
+			},
+			InscriptionBasePubKey: "02f58a2a986582ffd680e572f2413feea6ce05dad8bed004fe5a262198312867fa",
+		})
+		require.ErrorIs(t, err, txbuilder.ErrNonStandardTxWeight)
+
+		var weightErr *txbuilder.NonStandardTxWeightError
+		require.True(t, errors.As(err, &weightErr))
+		require.EqualValues(t, txbuilder.MaxStandardTxWeight, weightErr.MaxWeight)
+		require.EqualValues(t, weightErr.Weight-txbuilder.MaxStandardTxWeight, weightErr.Overshoot)
+		require.Positive(t, weightErr.Overshoot)
+	})
+}
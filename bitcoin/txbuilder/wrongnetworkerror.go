@@ -0,0 +1,33 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package txbuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWrongNetwork describes class of errors when address belongs to another network than expected.
+var ErrWrongNetwork = errors.New("address belongs to the wrong network")
+
+// WrongNetworkError is the error type to describe wrong network address errors with details.
+type WrongNetworkError struct {
+	Address  string // decoded address.
+	Expected string // expected network name.
+	Actual   string // network name the address belongs to, empty if unknown.
+}
+
+// Error returns error description.
+func (e *WrongNetworkError) Error() string {
+	if e.Actual == "" {
+		return fmt.Sprintf("%s: %s is not a %s address", ErrWrongNetwork, e.Address, e.Expected)
+	}
+
+	return fmt.Sprintf("%s: %s is a %s address, expected %s", ErrWrongNetwork, e.Address, e.Actual, e.Expected)
+}
+
+// Is implements comparator method for [errors] package.
+func (e *WrongNetworkError) Is(target error) bool {
+	return target == ErrWrongNetwork //nolint: errorlint
+}
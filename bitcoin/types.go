@@ -10,9 +10,12 @@ import (
 )
 
 // UTXO describes unspent transaction output data.
+// The embedded Outpoint provides TxHash and Index of the output.
+//
+// NOTE: Outpoint replaced former TxHash string and Index fields, which is a breaking change for
+// UTXO literals: set Outpoint via NewOutpoint or MustOutpoint instead, TxHash is a method now.
 type UTXO struct {
-	TxHash  string
-	Index   uint32   // output index in transaction outputs.
+	Outpoint
 	Amount  *big.Int // in Satoshi.
 	Script  []byte   // ScriptPubKey.
 	Address string   // output recipient address.
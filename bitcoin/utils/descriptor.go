@@ -0,0 +1,229 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// ErrNoDescriptor describes that leaf script has no output descriptor (miniscript) expression.
+var ErrNoDescriptor = errors.New("leaf script has no descriptor expression")
+
+const (
+	// descriptorInputCharset defines characters allowed in the descriptor, ordered for the checksum (BIP-380).
+	descriptorInputCharset = "0123456789()[],'/*abcdefgh@:$%{}IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	// descriptorChecksumCharset defines characters of the descriptor checksum (BIP-380).
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// descriptorGenerator defines generator of the descriptor checksum code (BIP-380).
+var descriptorGenerator = [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+
+// TaprootDescriptor defines taproot output descriptor of the script tree, e.g. to import it into
+// Bitcoin Core (importdescriptors) or Sparrow for independent verification of the address.
+type TaprootDescriptor struct {
+	// Descriptor is tr(KEY,TREE) output descriptor with checksum (BIP-386).
+	Descriptor  string          `json:"descriptor"`
+	InternalKey string          `json:"internalKey"` // x-only internal key in hex.
+	OutputKey   string          `json:"outputKey"`   // x-only tweaked output key in hex.
+	Tree        TaprootTreeNode `json:"tree"`
+}
+
+// TaprootTreeNode defines node of the taproot script tree: either leaf or branch of two nodes.
+type TaprootTreeNode struct {
+	Hash        string            `json:"hash"`                  // leaf or branch tagged hash in hex.
+	Script      string            `json:"script,omitempty"`      // leaf script in hex.
+	LeafVersion *uint8            `json:"leafVersion,omitempty"` // leaf version, nil for branch.
+	Expression  string            `json:"expression,omitempty"`  // leaf descriptor expression.
+	Branches    []TaprootTreeNode `json:"branches,omitempty"`    // branch children, nil for leaf.
+}
+
+// Descriptor returns tr() output descriptor of the policy and its script tree structure.
+// Returns ErrNoDescriptor if a leaf is not one of: pk(KEY), multi_a(M,KEYS...), and_v(v:pk(KEY),older(N)),
+// and_v(v:pk(KEY),after(N)) or and_v(v:sha256(HASH),pk(KEY)), e.g. the leaves built by
+// NewTaprootThresholdMultiSigLeafTapScript with ThresholdExact, NewTaprootDelayedLeafTapScript and HTLC.
+func (policy TaprootPolicy) Descriptor() (descriptor TaprootDescriptor, _ error) {
+	tree, err := policy.scriptTree()
+	if err != nil {
+		return descriptor, err
+	}
+
+	treeExpression, node, err := describeTapNode(tree.RootNode)
+	if err != nil {
+		return descriptor, err
+	}
+
+	rootHash := tree.RootNode.TapHash()
+	internalKey := policy.TaprootInternalKey()
+
+	descriptor.InternalKey = hex.EncodeToString(schnorr.SerializePubKey(internalKey))
+	descriptor.OutputKey = hex.EncodeToString(schnorr.SerializePubKey(TweakedOutputKey(internalKey, rootHash[:])))
+	descriptor.Descriptor, err = DescriptorWithChecksum("tr(" + descriptor.InternalKey + "," + treeExpression + ")")
+	descriptor.Tree = node
+
+	return descriptor, err
+}
+
+// DescriptorWithChecksum returns the descriptor with appended checksum (BIP-380): "<descriptor>#<checksum>".
+func DescriptorWithChecksum(descriptor string) (string, error) {
+	symbols := make([]uint64, 0, len(descriptor)*4/3+8)
+	groups := make([]uint64, 0, 3)
+	for _, char := range descriptor {
+		position := strings.IndexRune(descriptorInputCharset, char)
+		if position < 0 {
+			return "", fmt.Errorf("invalid descriptor character %q", char)
+		}
+
+		symbols = append(symbols, uint64(position&31))
+		groups = append(groups, uint64(position>>5))
+		if len(groups) == 3 {
+			symbols = append(symbols, groups[0]*9+groups[1]*3+groups[2])
+			groups = groups[:0]
+		}
+	}
+
+	switch len(groups) {
+	case 1:
+		symbols = append(symbols, groups[0])
+	case 2:
+		symbols = append(symbols, groups[0]*3+groups[1])
+	}
+	symbols = append(symbols, 0, 0, 0, 0, 0, 0, 0, 0)
+
+	checksum := descriptorPolymod(symbols) ^ 1
+	var encoded [8]byte
+	for i := range encoded {
+		encoded[i] = descriptorChecksumCharset[(checksum>>(5*(7-i)))&31]
+	}
+
+	return descriptor + "#" + string(encoded[:]), nil
+}
+
+// descriptorPolymod returns BCH code of the descriptor symbols (BIP-380).
+func descriptorPolymod(symbols []uint64) uint64 {
+	checksum := uint64(1)
+	for _, symbol := range symbols {
+		top := checksum >> 35
+		checksum = (checksum&0x7ffffffff)<<5 ^ symbol
+		for i, generator := range descriptorGenerator {
+			if (top>>i)&1 != 0 {
+				checksum ^= generator
+			}
+		}
+	}
+
+	return checksum
+}
+
+// describeTapNode returns descriptor tree expression and structure of the script tree node.
+func describeTapNode(node txscript.TapNode) (string, TaprootTreeNode, error) {
+	hash := node.TapHash()
+
+	switch node := node.(type) {
+	case txscript.TapLeaf:
+		expression, err := leafExpression(node)
+		if err != nil {
+			return "", TaprootTreeNode{}, err
+		}
+
+		leafVersion := uint8(node.LeafVersion)
+
+		return expression, TaprootTreeNode{
+			Hash:        hex.EncodeToString(hash[:]),
+			Script:      hex.EncodeToString(node.Script),
+			LeafVersion: &leafVersion,
+			Expression:  expression,
+		}, nil
+	case txscript.TapBranch:
+		left, leftNode, err := describeTapNode(node.Left())
+		if err != nil {
+			return "", TaprootTreeNode{}, err
+		}
+
+		right, rightNode, err := describeTapNode(node.Right())
+		if err != nil {
+			return "", TaprootTreeNode{}, err
+		}
+
+		return "{" + left + "," + right + "}", TaprootTreeNode{
+			Hash:     hex.EncodeToString(hash[:]),
+			Branches: []TaprootTreeNode{leftNode, rightNode},
+		}, nil
+	default:
+		return "", TaprootTreeNode{}, fmt.Errorf("%w: unknown tree node %T", ErrNoDescriptor, node)
+	}
+}
+
+// leafExpression returns descriptor expression of the leaf script, see TaprootPolicy.Descriptor.
+func leafExpression(leaf txscript.TapLeaf) (string, error) {
+	if leaf.LeafVersion != txscript.BaseLeafVersion {
+		return "", fmt.Errorf("%w: leaf version %d", ErrNoDescriptor, leaf.LeafVersion)
+	}
+
+	parsed, err := ParseLeafScript(leaf.Script)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNoDescriptor, err)
+	}
+
+	ops := parsed.Ops
+	isKey := func(op LeafScriptOp) bool { return len(op.Data) == schnorr.PubKeyBytesLen }
+	matches := func(opcodes ...byte) bool {
+		if len(ops) != len(opcodes) {
+			return false
+		}
+		for i, opcode := range opcodes {
+			// INFO: zero opcode matches any data push or number.
+			if opcode != 0 && ops[i].Opcode != opcode {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	switch {
+	case matches(0, txscript.OP_CHECKSIG) && isKey(ops[0]):
+		return "pk(" + hex.EncodeToString(ops[0].Data) + ")", nil
+	case matches(0, txscript.OP_CHECKSIGVERIFY, 0, txscript.OP_CHECKSEQUENCEVERIFY) && isKey(ops[0]):
+		delay, ok := scriptNumber(ops[2].Opcode, ops[2].Data)
+		if ok && delay > 0 {
+			return "and_v(v:pk(" + hex.EncodeToString(ops[0].Data) + "),older(" + strconv.Itoa(delay) + "))", nil
+		}
+	case matches(0, txscript.OP_CHECKSIGVERIFY, 0, txscript.OP_CHECKLOCKTIMEVERIFY) && isKey(ops[0]):
+		lockTime, ok := scriptNumber(ops[2].Opcode, ops[2].Data)
+		if ok && lockTime > 0 {
+			return "and_v(v:pk(" + hex.EncodeToString(ops[0].Data) + "),after(" + strconv.Itoa(lockTime) + "))", nil
+		}
+	case matches(txscript.OP_SIZE, 0, txscript.OP_EQUALVERIFY, txscript.OP_SHA256, 0, txscript.OP_EQUALVERIFY, 0,
+		txscript.OP_CHECKSIG) && len(ops[4].Data) == sha256.Size && isKey(ops[6]):
+		if size, ok := scriptNumber(ops[1].Opcode, ops[1].Data); ok && size == HTLCPreimageSize {
+			return "and_v(v:sha256(" + hex.EncodeToString(ops[4].Data) + "),pk(" +
+				hex.EncodeToString(ops[6].Data) + "))", nil
+		}
+	case len(parsed.Thresholds) == 1 && len(ops) == 2*len(parsed.Keys)+2 && ops[len(ops)-1].Opcode == txscript.OP_NUMEQUAL:
+		// INFO: the only CHECKSIGADD chain of all keys, compared by OP_NUMEQUAL at the end of the script.
+		threshold := parsed.Thresholds[0]
+		if threshold.From != 0 || threshold.To != len(parsed.Keys) || threshold.Required == 0 ||
+			threshold.Required > len(parsed.Keys) {
+			break
+		}
+
+		keys := make([]string, 0, len(parsed.Keys))
+		for _, key := range parsed.Keys {
+			keys = append(keys, hex.EncodeToString(key.XOnlyPubKey))
+		}
+
+		return "multi_a(" + strconv.Itoa(threshold.Required) + "," + strings.Join(keys, ",") + ")", nil
+	}
+
+	return "", fmt.Errorf("%w: %x", ErrNoDescriptor, leaf.Script)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestDescriptorWithChecksum(t *testing.T) {
+	descriptor, err := utils.DescriptorWithChecksum("raw(deadbeef)")
+	require.NoError(t, err)
+	require.Equal(t, "raw(deadbeef)#89f8spxm", descriptor)
+
+	_, err = utils.DescriptorWithChecksum("raw(deadbeef)\n")
+	require.Error(t, err)
+}
+
+func TestTaprootPolicy_Descriptor(t *testing.T) {
+	keys := make([]*btcec.PublicKey, 4)
+	xOnly := make([]string, len(keys))
+	for i := range keys {
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		keys[i] = key.PubKey()
+		xOnly[i] = hex.EncodeToString(schnorr.SerializePubKey(keys[i]))
+	}
+
+	ops, err := utils.NewTaprootThresholdMultiSigLeafTapScript(2, utils.ThresholdExact, keys[:3]...)
+	require.NoError(t, err)
+	emergency, err := utils.NewTaprootDelayedLeafTapScript(144, keys[3])
+	require.NoError(t, err)
+	single, err := txscript.NewScriptBuilder().AddData(schnorr.SerializePubKey(keys[0])).AddOp(txscript.OP_CHECKSIG).Script()
+	require.NoError(t, err)
+
+	paymentHash := sha256.Sum256([]byte("preimage"))
+	htlc := utils.HTLC{
+		PaymentHash:     paymentHash[:],
+		RecipientPubKey: schnorr.SerializePubKey(keys[1]),
+		SenderPubKey:    schnorr.SerializePubKey(keys[2]),
+		LockTime:        850000,
+	}
+	claim, err := htlc.ClaimScript()
+	require.NoError(t, err)
+
+	policy := utils.TaprootPolicy{
+		Leaves: []txscript.TapLeaf{ops, emergency, txscript.NewBaseTapLeaf(single), txscript.NewBaseTapLeaf(claim)},
+	}
+
+	t.Run("descriptor", func(t *testing.T) {
+		descriptor, err := policy.Descriptor()
+		require.NoError(t, err)
+
+		multiA := "multi_a(2," + strings.Join(xOnly[:3], ",") + ")"
+		older := "and_v(v:pk(" + xOnly[3] + "),older(144))"
+		pk := "pk(" + xOnly[0] + ")"
+		sha := "and_v(v:sha256(" + hex.EncodeToString(paymentHash[:]) + "),pk(" + xOnly[1] + "))"
+
+		numsKey := hex.EncodeToString(schnorr.SerializePubKey(utils.NUMSInternalKey()))
+		expected, err := utils.DescriptorWithChecksum("tr(" + numsKey + ",{{" + multiA + "," + older + "},{" + pk + "," + sha + "}})")
+		require.NoError(t, err)
+		require.Equal(t, expected, descriptor.Descriptor)
+		require.Equal(t, numsKey, descriptor.InternalKey)
+
+		address, err := policy.Address(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(address.ScriptAddress()), descriptor.OutputKey)
+
+		scriptRoot, err := policy.ScriptRoot()
+		require.NoError(t, err)
+		require.Len(t, descriptor.Tree.Branches, 2)
+		require.Equal(t, hex.EncodeToString(scriptRoot), descriptor.Tree.Hash)
+		require.Nil(t, descriptor.Tree.LeafVersion)
+
+		leaf := descriptor.Tree.Branches[0].Branches[1]
+		require.Empty(t, leaf.Branches)
+		require.Equal(t, older, leaf.Expression)
+		require.Equal(t, hex.EncodeToString(emergency.Script), leaf.Script)
+		require.EqualValues(t, txscript.BaseLeafVersion, *leaf.LeafVersion)
+		emergencyHash := emergency.TapHash()
+		require.Equal(t, hex.EncodeToString(emergencyHash[:]), leaf.Hash)
+
+		serialized, err := json.Marshal(descriptor)
+		require.NoError(t, err)
+		require.Contains(t, string(serialized), `"expression":"`+older+`"`)
+	})
+
+	t.Run("htlc", func(t *testing.T) {
+		descriptor, err := htlc.Descriptor()
+		require.NoError(t, err)
+
+		sha := "and_v(v:sha256(" + hex.EncodeToString(paymentHash[:]) + "),pk(" + xOnly[1] + "))"
+		after := "and_v(v:pk(" + xOnly[2] + "),after(850000))"
+		numsKey := hex.EncodeToString(schnorr.SerializePubKey(utils.NUMSInternalKey()))
+		expected, err := utils.DescriptorWithChecksum("tr(" + numsKey + ",{" + sha + "," + after + "})")
+		require.NoError(t, err)
+		require.Equal(t, expected, descriptor.Descriptor)
+
+		address, err := htlc.Address(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(address.ScriptAddress()), descriptor.OutputKey)
+	})
+
+	t.Run("internal key", func(t *testing.T) {
+		withKey := utils.TaprootPolicy{Leaves: []txscript.TapLeaf{emergency}, InternalKey: keys[0]}
+
+		descriptor, err := withKey.Descriptor()
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(descriptor.Descriptor, "tr("+xOnly[0]+",and_v(v:pk("+xOnly[3]+"),older(144)))#"))
+		require.Equal(t, xOnly[0], descriptor.InternalKey)
+	})
+
+	t.Run("no descriptor", func(t *testing.T) {
+		atLeast, err := utils.NewTaprootThresholdMultiSigLeafTapScript(2, utils.ThresholdAtLeast, keys[:3]...)
+		require.NoError(t, err)
+		// INFO: relative lock time before the key has no miniscript form.
+		dropped, err := txscript.NewScriptBuilder().AddInt64(144).AddOp(txscript.OP_CHECKSEQUENCEVERIFY).
+			AddOp(txscript.OP_DROP).AddData(schnorr.SerializePubKey(keys[0])).AddOp(txscript.OP_CHECKSIG).Script()
+		require.NoError(t, err)
+
+		for _, leaf := range []txscript.TapLeaf{atLeast, txscript.NewBaseTapLeaf(dropped)} {
+			_, err = utils.TaprootPolicy{Leaves: []txscript.TapLeaf{ops, leaf}}.Descriptor()
+			require.ErrorIs(t, err, utils.ErrNoDescriptor)
+		}
+
+		_, err = utils.TaprootPolicy{}.Descriptor()
+		require.ErrorIs(t, err, utils.ErrInvalidPolicy)
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+var (
+	// ErrInvalidHTLC describes that HTLC payment hash or public keys are malformed.
+	ErrInvalidHTLC = errors.New("invalid htlc")
+	// ErrHTLCMismatch describes that output script pub key is not the taproot output committing to the HTLC.
+	ErrHTLCMismatch = errors.New("output does not commit to the htlc")
+	// ErrInvalidPreimage describes that preimage does not match the HTLC payment hash.
+	ErrInvalidPreimage = errors.New("invalid preimage")
+)
+
+// HTLCPreimageSize defines size of the HTLC preimage, enforced by the claim script, so the same secret
+// is accepted by the counterparty chain contract, e.g. EVM HTLC with sha256 hash lock.
+const HTLCPreimageSize = 32
+
+// HTLC defines hash time locked contract of the taproot output for cross-chain atomic swaps: the recipient
+// claims the output by the claim leaf revealing the preimage of the payment hash, the sender refunds it
+// by the refund leaf after the absolute lock time (BIP-65).
+type HTLC struct {
+	PaymentHash     []byte // SHA-256 hash of the swap secret (32 bytes).
+	RecipientPubKey []byte // claim public key, either compressed (33 bytes) or x-only (32 bytes).
+	SenderPubKey    []byte // refund public key, either compressed (33 bytes) or x-only (32 bytes).
+	LockTime        uint32 // refund lock time, block height or unix timestamp, as transaction lock time.
+	// InternalKey is taproot internal key, e.g. both parties aggregated key for cooperative key path
+	// spending, optional, key path spending is disabled if not set.
+	InternalKey *btcec.PublicKey
+}
+
+// ClaimScript returns claim leaf script:
+// OP_SIZE 32 OP_EQUALVERIFY OP_SHA256 <payment hash> OP_EQUALVERIFY <x-only recipient pubkey> OP_CHECKSIG.
+func (htlc HTLC) ClaimScript() ([]byte, error) {
+	if len(htlc.PaymentHash) != sha256.Size {
+		return nil, fmt.Errorf("%w: payment hash size %d", ErrInvalidHTLC, len(htlc.PaymentHash))
+	}
+
+	recipient, err := ParseXOnlyPubKey(htlc.RecipientPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: recipient public key: %w", ErrInvalidHTLC, err)
+	}
+
+	return txscript.NewScriptBuilder().
+		AddOp(txscript.OP_SIZE).
+		AddInt64(HTLCPreimageSize).
+		AddOp(txscript.OP_EQUALVERIFY).
+		AddOp(txscript.OP_SHA256).
+		AddData(htlc.PaymentHash).
+		AddOp(txscript.OP_EQUALVERIFY).
+		AddData(schnorr.SerializePubKey(recipient)).
+		AddOp(txscript.OP_CHECKSIG).
+		Script()
+}
+
+// RefundScript returns refund leaf script: <x-only sender pubkey> OP_CHECKSIGVERIFY <lock time> OP_CHECKLOCKTIMEVERIFY,
+// miniscript and_v(v:pk(KEY),after(LOCKTIME)), so the leaf has the descriptor expression, see HTLC.Descriptor.
+func (htlc HTLC) RefundScript() ([]byte, error) {
+	if htlc.LockTime == 0 {
+		return nil, fmt.Errorf("%w: lock time is not set", ErrInvalidHTLC)
+	}
+
+	sender, err := ParseXOnlyPubKey(htlc.SenderPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sender public key: %w", ErrInvalidHTLC, err)
+	}
+
+	return txscript.NewScriptBuilder().
+		AddData(schnorr.SerializePubKey(sender)).
+		AddOp(txscript.OP_CHECKSIGVERIFY).
+		AddInt64(int64(htlc.LockTime)).
+		AddOp(txscript.OP_CHECKLOCKTIMEVERIFY).
+		Script()
+}
+
+// TapLeaves returns leaves of the HTLC script tree: claim leaf (#0) and refund leaf (#1).
+func (htlc HTLC) TapLeaves() ([]txscript.TapLeaf, error) {
+	claimScript, err := htlc.ClaimScript()
+	if err != nil {
+		return nil, err
+	}
+
+	refundScript, err := htlc.RefundScript()
+	if err != nil {
+		return nil, err
+	}
+
+	return []txscript.TapLeaf{txscript.NewBaseTapLeaf(claimScript), txscript.NewBaseTapLeaf(refundScript)}, nil
+}
+
+// Descriptor returns tr() output descriptor of the HTLC, e.g. to watch the HTLC address by Bitcoin Core,
+// see TaprootPolicy.Descriptor.
+func (htlc HTLC) Descriptor() (TaprootDescriptor, error) {
+	leaves, err := htlc.TapLeaves()
+	if err != nil {
+		return TaprootDescriptor{}, err
+	}
+
+	return TaprootPolicy{Leaves: leaves, InternalKey: htlc.InternalKey}.Descriptor()
+}
+
+// TaprootInternalKey returns HTLC internal key, NUMS point if InternalKey is not set.
+func (htlc HTLC) TaprootInternalKey() *btcec.PublicKey {
+	if htlc.InternalKey != nil {
+		return htlc.InternalKey
+	}
+
+	return NUMSInternalKey()
+}
+
+// ScriptRoot returns merkle root of the HTLC script tree.
+func (htlc HTLC) ScriptRoot() ([]byte, error) {
+	leaves, err := htlc.TapLeaves()
+	if err != nil {
+		return nil, err
+	}
+
+	root := txscript.AssembleTaprootScriptTree(leaves...).RootNode.TapHash()
+
+	return root[:], nil
+}
+
+// Address returns taproot address of the HTLC output.
+func (htlc HTLC) Address(networkParams *chaincfg.Params) (*btcutil.AddressTaproot, error) {
+	scriptRoot, err := htlc.ScriptRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return P2TRAddressFromInternalKey(htlc.TaprootInternalKey(), scriptRoot, networkParams)
+}
+
+// VerifyOutput returns ErrHTLCMismatch if output script pub key is not the taproot output committing to the HTLC.
+func (htlc HTLC) VerifyOutput(pkScript []byte) error {
+	scriptRoot, err := htlc.ScriptRoot()
+	if err != nil {
+		return err
+	}
+
+	if !txscript.IsPayToTaproot(pkScript) || !VerifyTweak(htlc.TaprootInternalKey(), pkScript[2:], scriptRoot) {
+		return fmt.Errorf("%w: %x", ErrHTLCMismatch, pkScript)
+	}
+
+	return nil
+}
+
+// VerifyPreimage returns ErrInvalidPreimage if the preimage is not the swap secret of the payment hash.
+func (htlc HTLC) VerifyPreimage(preimage []byte) error {
+	hash := sha256.Sum256(preimage)
+	if len(preimage) != HTLCPreimageSize || !bytes.Equal(hash[:], htlc.PaymentHash) {
+		return fmt.Errorf("%w: %x", ErrInvalidPreimage, hash)
+	}
+
+	return nil
+}
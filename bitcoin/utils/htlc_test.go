@@ -0,0 +1,113 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestHTLC(t *testing.T) {
+	recipientKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	senderKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	preimage := make([]byte, utils.HTLCPreimageSize)
+	preimage[0] = 1
+	paymentHash := sha256.Sum256(preimage)
+
+	htlc := utils.HTLC{
+		PaymentHash:     paymentHash[:],
+		RecipientPubKey: recipientKey.PubKey().SerializeCompressed(),
+		SenderPubKey:    schnorr.SerializePubKey(senderKey.PubKey()),
+		LockTime:        850000,
+	}
+
+	t.Run("scripts", func(t *testing.T) {
+		claimScript, err := htlc.ClaimScript()
+		require.NoError(t, err)
+
+		disassembled, err := txscript.DisasmString(claimScript)
+		require.NoError(t, err)
+		require.Equal(t, "OP_SIZE 20 OP_EQUALVERIFY OP_SHA256 "+hex.EncodeToString(paymentHash[:])+" OP_EQUALVERIFY "+
+			hex.EncodeToString(schnorr.SerializePubKey(recipientKey.PubKey()))+" OP_CHECKSIG", disassembled)
+
+		refundScript, err := htlc.RefundScript()
+		require.NoError(t, err)
+
+		disassembled, err = txscript.DisasmString(refundScript)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(schnorr.SerializePubKey(senderKey.PubKey()))+
+			" OP_CHECKSIGVERIFY 50f80c OP_CHECKLOCKTIMEVERIFY", disassembled)
+
+		leaves, err := htlc.TapLeaves()
+		require.NoError(t, err)
+		require.Equal(t, []txscript.TapLeaf{txscript.NewBaseTapLeaf(claimScript), txscript.NewBaseTapLeaf(refundScript)}, leaves)
+	})
+
+	t.Run("address", func(t *testing.T) {
+		address, err := htlc.Address(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		pkScript, err := txscript.PayToAddrScript(address)
+		require.NoError(t, err)
+		require.NoError(t, htlc.VerifyOutput(pkScript))
+
+		// INFO: key path is disabled by the NUMS internal key.
+		require.Equal(t, "50929b74c1a04954b78b4b6035e97a5e078a5a0f28ec96d547bfee9ace803ac0",
+			hex.EncodeToString(schnorr.SerializePubKey(htlc.TaprootInternalKey())))
+
+		cooperative := htlc
+		cooperative.InternalKey, err = utils.AggregateKeys(recipientKey.PubKey(), senderKey.PubKey())
+		require.NoError(t, err)
+
+		cooperativeAddress, err := cooperative.Address(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.NotEqual(t, address.EncodeAddress(), cooperativeAddress.EncodeAddress())
+		require.ErrorIs(t, cooperative.VerifyOutput(pkScript), utils.ErrHTLCMismatch)
+
+		other := htlc
+		other.LockTime++
+		require.ErrorIs(t, other.VerifyOutput(pkScript), utils.ErrHTLCMismatch)
+		require.ErrorIs(t, htlc.VerifyOutput([]byte{txscript.OP_RETURN}), utils.ErrHTLCMismatch)
+	})
+
+	t.Run("preimage", func(t *testing.T) {
+		require.NoError(t, htlc.VerifyPreimage(preimage))
+		require.ErrorIs(t, htlc.VerifyPreimage(make([]byte, utils.HTLCPreimageSize)), utils.ErrInvalidPreimage)
+		require.ErrorIs(t, htlc.VerifyPreimage(preimage[:31]), utils.ErrInvalidPreimage)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := htlc
+		invalid.PaymentHash = paymentHash[:20]
+		_, err := invalid.Address(&chaincfg.MainNetParams)
+		require.ErrorIs(t, err, utils.ErrInvalidHTLC)
+
+		invalid = htlc
+		invalid.LockTime = 0
+		_, err = invalid.Address(&chaincfg.MainNetParams)
+		require.ErrorIs(t, err, utils.ErrInvalidHTLC)
+
+		invalid = htlc
+		invalid.SenderPubKey = []byte{0x02, 0x01}
+		_, err = invalid.Address(&chaincfg.MainNetParams)
+		require.ErrorIs(t, err, utils.ErrInvalidHTLC)
+
+		invalid = htlc
+		invalid.RecipientPubKey = nil
+		_, err = invalid.TapLeaves()
+		require.ErrorIs(t, err, utils.ErrInvalidHTLC)
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// ErrInvalidLeafScript describes that tapscript leaf script can not be parsed.
+var ErrInvalidLeafScript = errors.New("invalid leaf script")
+
+// maxScriptNumberSize defines maximum size of the number operand, e.g. lock time of CHECKLOCKTIMEVERIFY (BIP-65).
+const maxScriptNumberSize = 5
+
+// LeafScript defines parsed tapscript leaf: script operations, keys checked by the signature opcodes
+// and thresholds of the CHECKSIGADD chains.
+type LeafScript struct {
+	Ops        []LeafScriptOp
+	Keys       []LeafScriptKey
+	Thresholds []LeafScriptThreshold
+}
+
+// LeafScriptOp defines opcode of the leaf script with pushed data.
+type LeafScriptOp struct {
+	Opcode byte
+	Data   []byte
+}
+
+// LeafScriptKey defines x-only public key of the leaf script and signature checking opcode following it.
+type LeafScriptKey struct {
+	XOnlyPubKey []byte
+	Opcode      byte // OP_CHECKSIG, OP_CHECKSIGVERIFY or OP_CHECKSIGADD.
+}
+
+// LeafScriptThreshold defines signatures threshold of the CHECKSIG followed by CHECKSIGADD keys chain.
+type LeafScriptThreshold struct {
+	From, To int  // chain keys indexes range.
+	Required int  // signatures threshold.
+	Exact    bool // exactly required signatures (OP_NUMEQUAL), at least otherwise.
+}
+
+// ParseLeafScript returns operations of the leaf script, keys checked by CHECKSIG, CHECKSIGVERIFY or CHECKSIGADD
+// in the script order and thresholds of the CHECKSIGADD chains compared by OP_NUMEQUAL(VERIFY) or
+// OP_GREATERTHANOREQUAL, e.g. leaves built by NewTaprootThresholdMultiSigLeafTapScript.
+func ParseLeafScript(script []byte) (parsed LeafScript, _ error) {
+	var (
+		chainStart = -1 // index of the CHECKSIG key starting CHECKSIGADD chain, -1 if there is no chain.
+		lastKeyOp  = -1 // index of the last signature checking operation.
+		tokenizer  = txscript.MakeScriptTokenizer(0, script)
+	)
+	// previous returns operation preceding the current one by the offset, invalid opcode if there is no one.
+	previous := func(offset int) LeafScriptOp {
+		if len(parsed.Ops) <= offset {
+			return LeafScriptOp{Opcode: txscript.OP_INVALIDOPCODE}
+		}
+
+		return parsed.Ops[len(parsed.Ops)-1-offset]
+	}
+
+	for tokenizer.Next() {
+		parsed.Ops = append(parsed.Ops, LeafScriptOp{Opcode: tokenizer.Opcode(), Data: tokenizer.Data()})
+		op, prev := previous(0), previous(1)
+
+		switch op.Opcode {
+		case txscript.OP_CHECKSIG, txscript.OP_CHECKSIGVERIFY, txscript.OP_CHECKSIGADD:
+			if len(prev.Data) != schnorr.PubKeyBytesLen {
+				break
+			}
+
+			parsed.Keys = append(parsed.Keys, LeafScriptKey{XOnlyPubKey: prev.Data, Opcode: op.Opcode})
+			lastKeyOp = len(parsed.Ops) - 1
+			switch op.Opcode {
+			case txscript.OP_CHECKSIG:
+				chainStart = len(parsed.Keys) - 1
+			case txscript.OP_CHECKSIGVERIFY:
+				chainStart = -1
+			}
+		case txscript.OP_NUMEQUAL, txscript.OP_NUMEQUALVERIFY, txscript.OP_GREATERTHANOREQUAL:
+			required, ok := scriptNumber(prev.Opcode, prev.Data)
+			// INFO: threshold follows the chain key directly, 1 of 1 chain has no CHECKSIGADD.
+			if !ok || chainStart < 0 || lastKeyOp != len(parsed.Ops)-3 {
+				break
+			}
+
+			parsed.Thresholds = append(parsed.Thresholds, LeafScriptThreshold{
+				From:     chainStart,
+				To:       len(parsed.Keys),
+				Required: required,
+				Exact:    op.Opcode != txscript.OP_GREATERTHANOREQUAL,
+			})
+			chainStart = -1
+		}
+	}
+	if err := tokenizer.Err(); err != nil {
+		return LeafScript{}, fmt.Errorf("%w: %w", ErrInvalidLeafScript, err)
+	}
+
+	return parsed, nil
+}
+
+// RequiredSignatures returns count of the signatures satisfying the leaf script, 0 if it can not be determined:
+// the script has no keys, CHECKSIGADD result is not compared with a threshold or a threshold is unreachable.
+// Every CHECKSIG and CHECKSIGVERIFY key out of the CHECKSIGADD chains is required.
+func (leaf LeafScript) RequiredSignatures() (required int) {
+	chained := make([]bool, len(leaf.Keys))
+	for _, threshold := range leaf.Thresholds {
+		if threshold.Required > threshold.To-threshold.From {
+			return 0
+		}
+
+		required += threshold.Required
+		for i := threshold.From; i < threshold.To; i++ {
+			chained[i] = true
+		}
+	}
+
+	for i, key := range leaf.Keys {
+		switch {
+		case chained[i]:
+		case key.Opcode == txscript.OP_CHECKSIGADD:
+			return 0
+		default:
+			required++
+		}
+	}
+
+	return required
+}
+
+// scriptNumber returns non-negative number pushed by the opcode, false if the opcode does not push a number.
+func scriptNumber(opcode byte, data []byte) (int, bool) {
+	switch {
+	case opcode == txscript.OP_0:
+		return 0, true
+	case opcode >= txscript.OP_1 && opcode <= txscript.OP_16:
+		return int(opcode-txscript.OP_1) + 1, true
+	case len(data) == 0 || len(data) > maxScriptNumberSize || data[len(data)-1]&0x80 != 0:
+		return 0, false
+	}
+
+	number := 0
+	for i := len(data) - 1; i >= 0; i-- {
+		number = number<<8 | int(data[i])
+	}
+
+	return number, true
+}
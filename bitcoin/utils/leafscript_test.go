@@ -0,0 +1,92 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestParseLeafScript(t *testing.T) {
+	keys := make([]*btcec.PublicKey, 3)
+	for i := range keys {
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		keys[i] = key.PubKey()
+	}
+	xOnly := func(i int) []byte { return schnorr.SerializePubKey(keys[i]) }
+
+	t.Run("threshold", func(t *testing.T) {
+		leaf, err := utils.NewTaprootThresholdMultiSigLeafTapScript(2, utils.ThresholdAtLeast, keys...)
+		require.NoError(t, err)
+
+		parsed, err := utils.ParseLeafScript(leaf.Script)
+		require.NoError(t, err)
+		require.Len(t, parsed.Ops, 8)
+		require.Equal(t, []utils.LeafScriptKey{
+			{XOnlyPubKey: xOnly(0), Opcode: txscript.OP_CHECKSIG},
+			{XOnlyPubKey: xOnly(1), Opcode: txscript.OP_CHECKSIGADD},
+			{XOnlyPubKey: xOnly(2), Opcode: txscript.OP_CHECKSIGADD},
+		}, parsed.Keys)
+		require.Equal(t, []utils.LeafScriptThreshold{{From: 0, To: 3, Required: 2}}, parsed.Thresholds)
+		require.Equal(t, 2, parsed.RequiredSignatures())
+	})
+
+	t.Run("single key threshold", func(t *testing.T) {
+		leaf, err := utils.NewTaprootThresholdMultiSigLeafTapScript(1, utils.ThresholdExact, keys[0])
+		require.NoError(t, err)
+
+		parsed, err := utils.ParseLeafScript(leaf.Script)
+		require.NoError(t, err)
+		require.Equal(t, []utils.LeafScriptThreshold{{From: 0, To: 1, Required: 1, Exact: true}}, parsed.Thresholds)
+		require.Equal(t, 1, parsed.RequiredSignatures())
+	})
+
+	t.Run("checksigverify", func(t *testing.T) {
+		leaf, err := utils.NewTaprootDelayedLeafTapScript(65535, keys[0])
+		require.NoError(t, err)
+
+		parsed, err := utils.ParseLeafScript(leaf.Script)
+		require.NoError(t, err)
+		require.Equal(t, []utils.LeafScriptKey{{XOnlyPubKey: xOnly(0), Opcode: txscript.OP_CHECKSIGVERIFY}}, parsed.Keys)
+		require.Empty(t, parsed.Thresholds)
+		require.Equal(t, 1, parsed.RequiredSignatures())
+	})
+
+	t.Run("unknown required signatures", func(t *testing.T) {
+		// INFO: CHECKSIGADD result is not compared with the threshold.
+		script, err := txscript.NewScriptBuilder().
+			AddData(xOnly(0)).AddOp(txscript.OP_CHECKSIG).
+			AddData(xOnly(1)).AddOp(txscript.OP_CHECKSIGADD).Script()
+		require.NoError(t, err)
+
+		parsed, err := utils.ParseLeafScript(script)
+		require.NoError(t, err)
+		require.Len(t, parsed.Keys, 2)
+		require.Zero(t, parsed.RequiredSignatures())
+
+		// INFO: threshold is greater than the chain keys count.
+		script, err = txscript.NewScriptBuilder().
+			AddData(xOnly(0)).AddOp(txscript.OP_CHECKSIG).
+			AddData(xOnly(1)).AddOp(txscript.OP_CHECKSIGADD).
+			AddInt64(3).AddOp(txscript.OP_NUMEQUAL).Script()
+		require.NoError(t, err)
+
+		parsed, err = utils.ParseLeafScript(script)
+		require.NoError(t, err)
+		require.Zero(t, parsed.RequiredSignatures())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := utils.ParseLeafScript([]byte{txscript.OP_DATA_32, 1})
+		require.ErrorIs(t, err, utils.ErrInvalidLeafScript)
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// ErrInvalidMultiSig describes that tapscript multisig keys or threshold are invalid.
+var ErrInvalidMultiSig = errors.New("invalid tapscript multisig")
+
+// maxMultiSigKeys defines maximum keys count of the tapscript multisig leaf, limited by the witness
+// stack size (BIP-342), which holds a signature or an empty placeholder for each key.
+const maxMultiSigKeys = txscript.MaxStackSize - 1
+
+// ThresholdCheck defines opcode comparing count of the valid CHECKSIGADD signatures with the threshold.
+type ThresholdCheck byte
+
+const (
+	// ThresholdExact requires exactly threshold signatures, signatures of the other keys should be empty.
+	ThresholdExact ThresholdCheck = txscript.OP_NUMEQUAL
+	// ThresholdAtLeast requires threshold signatures or more.
+	ThresholdAtLeast ThresholdCheck = txscript.OP_GREATERTHANOREQUAL
+)
+
+// NewTaprootMultiSigLeafTapScript returns N-of-N tapscript multisig leaf of the keys in the provided order:
+// <pubkey 1> OP_CHECKSIG <pubkey 2> OP_CHECKSIGADD ... <pubkey N> OP_CHECKSIGADD <N> OP_NUMEQUAL.
+// NOTE: Keys are not sorted, use SortKeys to build the same leaf independently of the keys order.
+func NewTaprootMultiSigLeafTapScript(pubKeys ...*btcec.PublicKey) (txscript.TapLeaf, error) {
+	return NewTaprootThresholdMultiSigLeafTapScript(len(pubKeys), ThresholdExact, pubKeys...)
+}
+
+// NewTaprootThresholdMultiSigLeafTapScript returns M-of-N tapscript multisig leaf of the keys in the provided order:
+// <pubkey 1> OP_CHECKSIG <pubkey 2> OP_CHECKSIGADD ... <pubkey N> OP_CHECKSIGADD <M> OP_NUMEQUAL|OP_GREATERTHANOREQUAL.
+// Witness has a signature or an empty placeholder for each key, see signer.FinalizeTapscript.
+func NewTaprootThresholdMultiSigLeafTapScript(threshold int, check ThresholdCheck, pubKeys ...*btcec.PublicKey) (txscript.TapLeaf, error) {
+	switch {
+	case len(pubKeys) == 0 || len(pubKeys) > maxMultiSigKeys:
+		return txscript.TapLeaf{}, fmt.Errorf("%w: keys count %d", ErrInvalidMultiSig, len(pubKeys))
+	case threshold < 1 || threshold > len(pubKeys):
+		return txscript.TapLeaf{}, fmt.Errorf("%w: threshold %d of %d", ErrInvalidMultiSig, threshold, len(pubKeys))
+	case check != ThresholdExact && check != ThresholdAtLeast:
+		return txscript.TapLeaf{}, fmt.Errorf("%w: threshold check opcode %d", ErrInvalidMultiSig, check)
+	}
+
+	// INFO: the same key signs once, so the duplicate would make threshold unreachable.
+	seen := make(map[string]bool, len(pubKeys))
+	builder := txscript.NewScriptBuilder()
+	for i, pubKey := range pubKeys {
+		if pubKey == nil {
+			return txscript.TapLeaf{}, fmt.Errorf("%w: key %d is nil", ErrInvalidMultiSig, i)
+		}
+
+		xOnlyPubKey := schnorr.SerializePubKey(pubKey)
+		if seen[string(xOnlyPubKey)] {
+			return txscript.TapLeaf{}, fmt.Errorf("%w: duplicate key %x", ErrInvalidMultiSig, xOnlyPubKey)
+		}
+		seen[string(xOnlyPubKey)] = true
+
+		builder.AddData(xOnlyPubKey)
+		if i == 0 {
+			builder.AddOp(txscript.OP_CHECKSIG)
+		} else {
+			builder.AddOp(txscript.OP_CHECKSIGADD)
+		}
+	}
+
+	script, err := builder.AddInt64(int64(threshold)).AddOp(byte(check)).Script()
+	if err != nil {
+		return txscript.TapLeaf{}, err
+	}
+
+	return txscript.NewBaseTapLeaf(script), nil
+}
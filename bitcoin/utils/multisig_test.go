@@ -0,0 +1,76 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestTaprootMultiSigLeafTapScript(t *testing.T) {
+	keys := make([]*btcec.PublicKey, 3)
+	xOnly := make([]string, len(keys))
+	for i := range keys {
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		keys[i] = key.PubKey()
+		xOnly[i] = hex.EncodeToString(schnorr.SerializePubKey(keys[i]))
+	}
+
+	t.Run("n of n", func(t *testing.T) {
+		leaf, err := utils.NewTaprootMultiSigLeafTapScript(keys...)
+		require.NoError(t, err)
+		require.Equal(t, txscript.BaseLeafVersion, leaf.LeafVersion)
+
+		disassembled, err := txscript.DisasmString(leaf.Script)
+		require.NoError(t, err)
+		require.Equal(t, xOnly[0]+" OP_CHECKSIG "+xOnly[1]+" OP_CHECKSIGADD "+xOnly[2]+" OP_CHECKSIGADD 3 OP_NUMEQUAL",
+			disassembled)
+	})
+
+	t.Run("m of n", func(t *testing.T) {
+		leaf, err := utils.NewTaprootThresholdMultiSigLeafTapScript(2, utils.ThresholdExact, keys...)
+		require.NoError(t, err)
+
+		disassembled, err := txscript.DisasmString(leaf.Script)
+		require.NoError(t, err)
+		require.Equal(t, xOnly[0]+" OP_CHECKSIG "+xOnly[1]+" OP_CHECKSIGADD "+xOnly[2]+" OP_CHECKSIGADD 2 OP_NUMEQUAL",
+			disassembled)
+
+		leaf, err = utils.NewTaprootThresholdMultiSigLeafTapScript(1, utils.ThresholdAtLeast, keys[:2]...)
+		require.NoError(t, err)
+
+		disassembled, err = txscript.DisasmString(leaf.Script)
+		require.NoError(t, err)
+		require.Equal(t, xOnly[0]+" OP_CHECKSIG "+xOnly[1]+" OP_CHECKSIGADD 1 OP_GREATERTHANOREQUAL", disassembled)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := utils.NewTaprootMultiSigLeafTapScript()
+		require.ErrorIs(t, err, utils.ErrInvalidMultiSig)
+
+		_, err = utils.NewTaprootThresholdMultiSigLeafTapScript(0, utils.ThresholdExact, keys...)
+		require.ErrorIs(t, err, utils.ErrInvalidMultiSig)
+
+		_, err = utils.NewTaprootThresholdMultiSigLeafTapScript(4, utils.ThresholdExact, keys...)
+		require.ErrorIs(t, err, utils.ErrInvalidMultiSig)
+
+		_, err = utils.NewTaprootThresholdMultiSigLeafTapScript(2, utils.ThresholdCheck(txscript.OP_EQUAL), keys...)
+		require.ErrorIs(t, err, utils.ErrInvalidMultiSig)
+
+		_, err = utils.NewTaprootMultiSigLeafTapScript(keys[0], keys[1], keys[0])
+		require.ErrorIs(t, err, utils.ErrInvalidMultiSig)
+
+		_, err = utils.NewTaprootMultiSigLeafTapScript(keys[0], nil)
+		require.ErrorIs(t, err, utils.ErrInvalidMultiSig)
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+var (
+	// ErrNoKeys describes that there are no public keys to aggregate.
+	ErrNoKeys = errors.New("no public keys to aggregate")
+	// ErrInfinityKey describes that aggregated public key is the point at infinity.
+	ErrInfinityKey = errors.New("aggregated public key is infinity")
+)
+
+var (
+	// keyAggListTag defines BIP-327 tag of the public keys list hash.
+	keyAggListTag = []byte("KeyAgg list")
+	// keyAggCoefficientTag defines BIP-327 tag of the public key coefficient hash.
+	keyAggCoefficientTag = []byte("KeyAgg coefficient")
+)
+
+// SortKeys returns public keys sorted by their compressed serialization (BIP-327 KeySort).
+func SortKeys(keys []*btcec.PublicKey) []*btcec.PublicKey {
+	sorted := append([]*btcec.PublicKey(nil), keys...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].SerializeCompressed(), sorted[j].SerializeCompressed()) < 0
+	})
+
+	return sorted
+}
+
+// AggregateKeys returns MuSig2 aggregated public key of the keys in the provided order (BIP-327 KeyAgg).
+// NOTE: Keys are not sorted, use SortKeys to aggregate keys independently of their order.
+func AggregateKeys(keys ...*btcec.PublicKey) (*btcec.PublicKey, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	serialized := make([][]byte, len(keys))
+	for i, key := range keys {
+		serialized[i] = key.SerializeCompressed()
+	}
+
+	list := chainhash.TaggedHash(keyAggListTag, bytes.Join(serialized, nil))
+
+	// INFO: the second distinct key has coefficient 1 to optimize aggregation, see BIP-327 GetSecondKey.
+	var secondKey []byte
+	for _, key := range serialized[1:] {
+		if !bytes.Equal(key, serialized[0]) {
+			secondKey = key
+			break
+		}
+	}
+
+	var aggregated btcec.JacobianPoint
+	for i, key := range keys {
+		var point, term btcec.JacobianPoint
+		key.AsJacobian(&point)
+
+		if bytes.Equal(serialized[i], secondKey) {
+			term = point
+		} else {
+			var coefficient btcec.ModNScalar
+			coefficient.SetByteSlice(chainhash.TaggedHash(keyAggCoefficientTag, list[:], serialized[i])[:])
+			btcec.ScalarMultNonConst(&coefficient, &point, &term)
+		}
+
+		var sum btcec.JacobianPoint
+		btcec.AddNonConst(&aggregated, &term, &sum)
+		aggregated = sum
+	}
+
+	if (aggregated.X.IsZero() && aggregated.Y.IsZero()) || aggregated.Z.IsZero() {
+		return nil, ErrInfinityKey
+	}
+
+	aggregated.ToAffine()
+
+	return btcec.NewPublicKey(&aggregated.X, &aggregated.Y), nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestAggregateKeys(t *testing.T) {
+	// INFO: BIP-327 key_agg_vectors.json.
+	var keys []*btcec.PublicKey
+	for _, key := range []string{
+		"02f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+		"03dff1d77f2a671c5f36183726db2341be58feae1da2deced843240f7b502ba659",
+		"023590a94e768f8e1815c2f24b4d80a8e3149316c3518ce7b7ad338368d038ca66",
+	} {
+		keyBytes, err := hex.DecodeString(key)
+		require.NoError(t, err)
+
+		publicKey, err := btcec.ParsePubKey(keyBytes)
+		require.NoError(t, err)
+
+		keys = append(keys, publicKey)
+	}
+
+	tests := []struct {
+		indexes  []int
+		expected string
+	}{
+		{[]int{0, 1, 2}, "90539eede565f5d054f32cc0c220126889ed1e5d193baf15aef344fe59d4610c"},
+		{[]int{2, 1, 0}, "6204de8b083426dc6eaf9502d27024d53fc826bf7d2012148a0575435df54b2b"},
+		{[]int{0, 0, 0}, "b436e3bad62b8cd409969a224731c193d051162d8c5ae8b109306127da3aa935"},
+		{[]int{0, 0, 1, 1}, "69bc22bfa5d106306e48a20679de1d7389386124d07571d0d872686028c26a3e"},
+	}
+
+	for _, test := range tests {
+		var ordered []*btcec.PublicKey
+		for _, index := range test.indexes {
+			ordered = append(ordered, keys[index])
+		}
+
+		aggregated, err := utils.AggregateKeys(ordered...)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, hex.EncodeToString(schnorr.SerializePubKey(aggregated)))
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		first, err := utils.AggregateKeys(utils.SortKeys([]*btcec.PublicKey{keys[0], keys[1], keys[2]})...)
+		require.NoError(t, err)
+
+		second, err := utils.AggregateKeys(utils.SortKeys([]*btcec.PublicKey{keys[2], keys[0], keys[1]})...)
+		require.NoError(t, err)
+		require.True(t, first.IsEqual(second))
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		_, err := utils.AggregateKeys()
+		require.ErrorIs(t, err, utils.ErrNoKeys)
+	})
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+var (
+	// ErrInvalidPolicy describes that taproot policy leaves are missing or duplicated.
+	ErrInvalidPolicy = errors.New("invalid taproot policy")
+	// ErrPolicyMismatch describes that output script pub key is not the taproot output committing to the policy.
+	ErrPolicyMismatch = errors.New("output does not commit to the taproot policy")
+)
+
+// TaprootPolicy defines taproot output script tree of several spending conditions, e.g. 2-of-3 operations
+// multisig leaf and 1-of-1 emergency leaf with relative lock time. Leaves are assembled into the balanced
+// tree (txscript.AssembleTaprootScriptTree), so the leaves order may change the output key.
+type TaprootPolicy struct {
+	Leaves []txscript.TapLeaf
+	// InternalKey is taproot internal key, optional, key path spending is disabled if not set.
+	InternalKey *btcec.PublicKey
+}
+
+// NewTaprootDelayedLeafTapScript returns single key leaf spendable after the relative lock time in blocks (BIP-68):
+// <x-only pubkey> OP_CHECKSIGVERIFY <delay> OP_CHECKSEQUENCEVERIFY, miniscript and_v(v:pk(KEY),older(DELAY)),
+// so the leaf has the descriptor expression, see TaprootPolicy.Descriptor.
+// NOTE: the leaf script is committed to by the policy addresses, its form must not be changed.
+func NewTaprootDelayedLeafTapScript(delay uint16, pubKey *btcec.PublicKey) (txscript.TapLeaf, error) {
+	if delay == 0 || pubKey == nil {
+		return txscript.TapLeaf{}, fmt.Errorf("%w: delay %d, public key is set: %t", ErrInvalidPolicy, delay, pubKey != nil)
+	}
+
+	script, err := txscript.NewScriptBuilder().
+		AddData(schnorr.SerializePubKey(pubKey)).
+		AddOp(txscript.OP_CHECKSIGVERIFY).
+		AddInt64(int64(delay)).
+		AddOp(txscript.OP_CHECKSEQUENCEVERIFY).
+		Script()
+	if err != nil {
+		return txscript.TapLeaf{}, err
+	}
+
+	return txscript.NewBaseTapLeaf(script), nil
+}
+
+// TaprootInternalKey returns policy internal key, NUMS point if InternalKey is not set.
+func (policy TaprootPolicy) TaprootInternalKey() *btcec.PublicKey {
+	if policy.InternalKey != nil {
+		return policy.InternalKey
+	}
+
+	return NUMSInternalKey()
+}
+
+// ScriptRoot returns merkle root of the policy script tree.
+func (policy TaprootPolicy) ScriptRoot() ([]byte, error) {
+	tree, err := policy.scriptTree()
+	if err != nil {
+		return nil, err
+	}
+
+	root := tree.RootNode.TapHash()
+
+	return root[:], nil
+}
+
+// Address returns taproot address of the policy output.
+func (policy TaprootPolicy) Address(networkParams *chaincfg.Params) (*btcutil.AddressTaproot, error) {
+	scriptRoot, err := policy.ScriptRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return P2TRAddressFromInternalKey(policy.TaprootInternalKey(), scriptRoot, networkParams)
+}
+
+// VerifyOutput returns ErrPolicyMismatch if output script pub key is not the taproot output committing to the policy.
+func (policy TaprootPolicy) VerifyOutput(pkScript []byte) error {
+	scriptRoot, err := policy.ScriptRoot()
+	if err != nil {
+		return err
+	}
+
+	if !txscript.IsPayToTaproot(pkScript) || !VerifyTweak(policy.TaprootInternalKey(), pkScript[2:], scriptRoot) {
+		return fmt.Errorf("%w: %x", ErrPolicyMismatch, pkScript)
+	}
+
+	return nil
+}
+
+// LeafHash returns hash of the leaf by index, used to select the leaf to sign, see signer.SignTaprootParams.
+func (policy TaprootPolicy) LeafHash(leaf int) (chainhash.Hash, error) {
+	if leaf < 0 || len(policy.Leaves) <= leaf {
+		return chainhash.Hash{}, fmt.Errorf("%w: leaf %d, leaves: %d", ErrInvalidPolicy, leaf, len(policy.Leaves))
+	}
+
+	return policy.Leaves[leaf].TapHash(), nil
+}
+
+// TapLeafScripts returns leaf scripts with control blocks of all the policy leaves to set as PSBT input
+// taproot leaf scripts, so signers choose the leaf to satisfy.
+func (policy TaprootPolicy) TapLeafScripts() ([]*psbt.TaprootTapLeafScript, error) {
+	tree, err := policy.scriptTree()
+	if err != nil {
+		return nil, err
+	}
+
+	internalKey := policy.TaprootInternalKey()
+	leafScripts := make([]*psbt.TaprootTapLeafScript, len(policy.Leaves))
+	for i, leaf := range policy.Leaves {
+		proof := tree.LeafMerkleProofs[tree.LeafProofIndex[leaf.TapHash()]]
+
+		controlBlock := proof.ToControlBlock(internalKey)
+		controlBlockBytes, err := controlBlock.ToBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		leafScripts[i] = &psbt.TaprootTapLeafScript{
+			ControlBlock: controlBlockBytes,
+			Script:       leaf.Script,
+			LeafVersion:  leaf.LeafVersion,
+		}
+	}
+
+	return leafScripts, nil
+}
+
+// scriptTree returns indexed script tree of the policy leaves.
+func (policy TaprootPolicy) scriptTree() (*txscript.IndexedTapScriptTree, error) {
+	if len(policy.Leaves) == 0 {
+		return nil, fmt.Errorf("%w: no leaves", ErrInvalidPolicy)
+	}
+
+	// INFO: leaves are indexed by hash, duplicate leaf would have ambiguous control block.
+	seen := make(map[chainhash.Hash]bool, len(policy.Leaves))
+	for i, leaf := range policy.Leaves {
+		leafHash := leaf.TapHash()
+		if seen[leafHash] {
+			return nil, fmt.Errorf("%w: duplicate leaf %d", ErrInvalidPolicy, i)
+		}
+		seen[leafHash] = true
+	}
+
+	return txscript.AssembleTaprootScriptTree(policy.Leaves...), nil
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestTaprootPolicy(t *testing.T) {
+	keys := make([]*btcec.PublicKey, 4)
+	for i := range keys {
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		keys[i] = key.PubKey()
+	}
+
+	ops, err := utils.NewTaprootThresholdMultiSigLeafTapScript(2, utils.ThresholdExact, keys[:3]...)
+	require.NoError(t, err)
+	emergency, err := utils.NewTaprootDelayedLeafTapScript(144, keys[3])
+	require.NoError(t, err)
+
+	policy := utils.TaprootPolicy{Leaves: []txscript.TapLeaf{ops, emergency}}
+
+	t.Run("delayed leaf", func(t *testing.T) {
+		disassembled, err := txscript.DisasmString(emergency.Script)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(schnorr.SerializePubKey(keys[3]))+
+			" OP_CHECKSIGVERIFY 9000 OP_CHECKSEQUENCEVERIFY", disassembled)
+
+		_, err = utils.NewTaprootDelayedLeafTapScript(0, keys[3])
+		require.ErrorIs(t, err, utils.ErrInvalidPolicy)
+		_, err = utils.NewTaprootDelayedLeafTapScript(144, nil)
+		require.ErrorIs(t, err, utils.ErrInvalidPolicy)
+	})
+
+	t.Run("address", func(t *testing.T) {
+		address, err := policy.Address(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		pkScript, err := txscript.PayToAddrScript(address)
+		require.NoError(t, err)
+		require.NoError(t, policy.VerifyOutput(pkScript))
+		require.Equal(t, utils.NUMSInternalKey(), policy.TaprootInternalKey())
+
+		reordered := utils.TaprootPolicy{Leaves: []txscript.TapLeaf{emergency, ops}}
+		require.NoError(t, reordered.VerifyOutput(pkScript))
+
+		withKey := policy
+		withKey.InternalKey = keys[0]
+		require.ErrorIs(t, withKey.VerifyOutput(pkScript), utils.ErrPolicyMismatch)
+
+		single := utils.TaprootPolicy{Leaves: []txscript.TapLeaf{ops}}
+		require.ErrorIs(t, single.VerifyOutput(pkScript), utils.ErrPolicyMismatch)
+	})
+
+	t.Run("leaf scripts", func(t *testing.T) {
+		address, err := policy.Address(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		leafScripts, err := policy.TapLeafScripts()
+		require.NoError(t, err)
+		require.Len(t, leafScripts, 2)
+
+		for i, leafScript := range leafScripts {
+			require.Equal(t, policy.Leaves[i].Script, leafScript.Script)
+
+			controlBlock, err := txscript.ParseControlBlock(leafScript.ControlBlock)
+			require.NoError(t, err)
+			require.NoError(t, txscript.VerifyTaprootLeafCommitment(controlBlock, address.ScriptAddress(), leafScript.Script))
+
+			leafHash, err := policy.LeafHash(i)
+			require.NoError(t, err)
+			require.Equal(t, policy.Leaves[i].TapHash(), leafHash)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := utils.TaprootPolicy{}.ScriptRoot()
+		require.ErrorIs(t, err, utils.ErrInvalidPolicy)
+
+		_, err = utils.TaprootPolicy{Leaves: []txscript.TapLeaf{ops, emergency, ops}}.TapLeafScripts()
+		require.ErrorIs(t, err, utils.ErrInvalidPolicy)
+
+		_, err = policy.LeafHash(2)
+		require.ErrorIs(t, err, utils.ErrInvalidPolicy)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package utils provides helpers shared by bitcoin transaction building and verification code.
+package utils
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// nothingUpMySleeve defines x-only NUMS point with unknown discrete logarithm (BIP-341).
+var nothingUpMySleeve, _ = hex.DecodeString("50929b74c1a04954b78b4b6035e97a5e078a5a0f28ec96d547bfee9ace803ac0")
+
+// NUMSInternalKey returns NUMS point with unknown discrete logarithm (BIP-341), used as taproot
+// internal key to disable key path spending of the script tree outputs.
+func NUMSInternalKey() *btcec.PublicKey {
+	// INFO: constant point is valid, error is impossible.
+	key, _ := schnorr.ParsePubKey(nothingUpMySleeve)
+
+	return key
+}
+
+// TweakedOutputKey returns taproot output key of the internal key tweaked with the script tree root hash.
+// Empty script root means key path only spending output key (BIP-86).
+func TweakedOutputKey(internalKey *btcec.PublicKey, scriptRoot []byte) *btcec.PublicKey {
+	if len(scriptRoot) == 0 {
+		return txscript.ComputeTaprootKeyNoScript(internalKey)
+	}
+
+	return txscript.ComputeTaprootOutputKey(internalKey, scriptRoot)
+}
+
+// P2TRAddressFromInternalKey returns taproot address of the internal key tweaked with the script tree
+// root hash, empty script root means key path only spending address (BIP-86).
+func P2TRAddressFromInternalKey(internalKey *btcec.PublicKey, scriptRoot []byte, networkParams *chaincfg.Params) (*btcutil.AddressTaproot, error) {
+	return btcutil.NewAddressTaproot(schnorr.SerializePubKey(TweakedOutputKey(internalKey, scriptRoot)), networkParams)
+}
+
+// VerifyTweak returns true if x-only output key is the internal key tweaked with the script tree root hash.
+func VerifyTweak(internalKey *btcec.PublicKey, outputKey, scriptRoot []byte) bool {
+	return bytes.Equal(schnorr.SerializePubKey(TweakedOutputKey(internalKey, scriptRoot)), outputKey)
+}
+
+// TapScriptRoot returns script tree root hash of the single leaf tree.
+func TapScriptRoot(script []byte) []byte {
+	root := txscript.AssembleTaprootScriptTree(txscript.NewBaseTapLeaf(script)).RootNode.TapHash()
+
+	return root[:]
+}
+
+// ParseXOnlyPubKey parses either compressed (33 bytes) or x-only (32 bytes) public key as taproot
+// x-only public key.
+func ParseXOnlyPubKey(pubKey []byte) (*btcec.PublicKey, error) {
+	if len(pubKey) != schnorr.PubKeyBytesLen {
+		key, err := btcec.ParsePubKey(pubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		pubKey = schnorr.SerializePubKey(key)
+	}
+
+	return schnorr.ParsePubKey(pubKey)
+}
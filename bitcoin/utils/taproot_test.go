@@ -0,0 +1,71 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package utils_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/inscriptions"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+func TestTaproot(t *testing.T) {
+	t.Run("key path (BIP-86)", func(t *testing.T) {
+		internalKeyBytes, err := hex.DecodeString("cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+		require.NoError(t, err)
+
+		internalKey, err := schnorr.ParsePubKey(internalKeyBytes)
+		require.NoError(t, err)
+
+		outputKey, err := hex.DecodeString("a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c")
+		require.NoError(t, err)
+		require.Equal(t, outputKey, schnorr.SerializePubKey(utils.TweakedOutputKey(internalKey, nil)))
+		require.True(t, utils.VerifyTweak(internalKey, outputKey, nil))
+		require.False(t, utils.VerifyTweak(internalKey, outputKey, make([]byte, 32)))
+
+		address, err := utils.P2TRAddressFromInternalKey(internalKey, nil, &chaincfg.MainNetParams)
+		require.NoError(t, err)
+		require.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", address.EncodeAddress())
+	})
+
+	t.Run("inscription commit address", func(t *testing.T) {
+		privateKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		inscription := &inscriptions.Inscription{ContentType: "text/plain;charset=utf-8", Body: []byte("Hello, world!")}
+		expected, err := inscription.IntoAddress(hex.EncodeToString(privateKey.PubKey().SerializeCompressed()), &chaincfg.TestNet3Params)
+		require.NoError(t, err)
+
+		script, err := inscription.IntoScriptForWitness(schnorr.SerializePubKey(privateKey.PubKey()))
+		require.NoError(t, err)
+
+		scriptRoot := utils.TapScriptRoot(script)
+		address, err := utils.P2TRAddressFromInternalKey(privateKey.PubKey(), scriptRoot, &chaincfg.TestNet3Params)
+		require.NoError(t, err)
+		require.Equal(t, expected, address.EncodeAddress())
+		require.True(t, utils.VerifyTweak(privateKey.PubKey(), address.ScriptAddress(), scriptRoot))
+	})
+
+	t.Run("x-only public key", func(t *testing.T) {
+		privateKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		xOnly := schnorr.SerializePubKey(privateKey.PubKey())
+
+		for _, pubKey := range [][]byte{xOnly, privateKey.PubKey().SerializeCompressed()} {
+			key, err := utils.ParseXOnlyPubKey(pubKey)
+			require.NoError(t, err)
+			require.Equal(t, xOnly, schnorr.SerializePubKey(key))
+			require.Equal(t, xOnly, key.SerializeCompressed()[1:])
+		}
+
+		_, err = utils.ParseXOnlyPubKey(xOnly[1:])
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin
+
+import (
+	"math/big"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// RuneAmount returns amount of the rune linked to the UTXO, 0 if UTXO does not hold the rune.
+func (utxo *UTXO) RuneAmount(runeID runes.RuneID) *big.Int {
+	amount := big.NewInt(0)
+	for _, rune_ := range utxo.Runes {
+		if rune_.RuneID == runeID && rune_.Amount != nil {
+			amount.Add(amount, rune_.Amount)
+		}
+	}
+
+	return amount
+}
+
+// HasRunes returns true if any rune with positive amount is linked to the UTXO.
+func (utxo *UTXO) HasRunes() bool {
+	for _, rune_ := range utxo.Runes {
+		if rune_.Amount != nil && numbers.IsPositive(rune_.Amount) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TotalSatoshi returns total amount of the UTXOs in satoshi.
+func TotalSatoshi(utxos []UTXO) *big.Int {
+	total := big.NewInt(0)
+	for _, utxo := range utxos {
+		if utxo.Amount != nil {
+			total.Add(total, utxo.Amount)
+		}
+	}
+
+	return total
+}
+
+// TotalRune returns total amount of the rune linked to the UTXOs.
+func TotalRune(utxos []UTXO, runeID runes.RuneID) *big.Int {
+	total := big.NewInt(0)
+	for i := range utxos {
+		total.Add(total, utxos[i].RuneAmount(runeID))
+	}
+
+	return total
+}
+
+// Clone returns deep copy of the UTXO which shares no memory with the original one.
+func (utxo *UTXO) Clone() *UTXO {
+	clone := *utxo
+	if utxo.Amount != nil {
+		clone.Amount = new(big.Int).Set(utxo.Amount)
+	}
+	if utxo.Script != nil {
+		clone.Script = append([]byte(nil), utxo.Script...)
+	}
+	if utxo.Runes != nil {
+		clone.Runes = make([]RuneUTXO, len(utxo.Runes))
+		for i, rune_ := range utxo.Runes {
+			clone.Runes[i] = RuneUTXO{RuneID: rune_.RuneID}
+			if rune_.Amount != nil {
+				clone.Runes[i].Amount = new(big.Int).Set(rune_.Amount)
+			}
+		}
+	}
+
+	return &clone
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/ord/runes"
+)
+
+func TestUTXOAmounts(t *testing.T) {
+	first, second := runes.RuneID{Block: 840000, TxID: 1}, runes.RuneID{Block: 840000, TxID: 2}
+	utxos := []bitcoin.UTXO{
+		{
+			Amount: big.NewInt(546),
+			Runes: []bitcoin.RuneUTXO{
+				{RuneID: first, Amount: big.NewInt(100)},
+				{RuneID: second, Amount: big.NewInt(5)},
+			},
+		},
+		{
+			Amount: big.NewInt(10000),
+		},
+		{
+			Amount: big.NewInt(546),
+			Runes: []bitcoin.RuneUTXO{
+				{RuneID: first, Amount: big.NewInt(50)},
+				{RuneID: second, Amount: big.NewInt(0)},
+			},
+		},
+	}
+
+	t.Run("utxo", func(t *testing.T) {
+		require.EqualValues(t, 100, utxos[0].RuneAmount(first).Int64())
+		require.EqualValues(t, 5, utxos[0].RuneAmount(second).Int64())
+		require.EqualValues(t, 0, utxos[1].RuneAmount(first).Int64())
+		require.True(t, utxos[0].HasRunes())
+		require.False(t, utxos[1].HasRunes())
+		require.True(t, utxos[2].HasRunes())
+		require.False(t, (&bitcoin.UTXO{Runes: []bitcoin.RuneUTXO{{RuneID: first, Amount: big.NewInt(0)}}}).HasRunes())
+
+		utxos[0].RuneAmount(first).SetInt64(0)
+		require.EqualValues(t, 100, utxos[0].Runes[0].Amount.Int64())
+	})
+
+	t.Run("aggregates", func(t *testing.T) {
+		require.EqualValues(t, 11092, bitcoin.TotalSatoshi(utxos).Int64())
+		require.EqualValues(t, 150, bitcoin.TotalRune(utxos, first).Int64())
+		require.EqualValues(t, 5, bitcoin.TotalRune(utxos, second).Int64())
+		require.EqualValues(t, 0, bitcoin.TotalSatoshi(nil).Int64())
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		utxo := bitcoin.UTXO{Outpoint: bitcoin.Outpoint{Index: 1}, Amount: big.NewInt(546), Script: []byte{0x51}, Runes: utxos[0].Runes}
+		clone := utxo.Clone()
+		require.Equal(t, utxo, *clone)
+
+		clone.Amount.SetInt64(0)
+		clone.Script[0] = 0
+		clone.Runes[0].Amount.SetInt64(0)
+		clone.Runes[1].RuneID = first
+		require.EqualValues(t, 546, utxo.Amount.Int64())
+		require.Equal(t, []byte{0x51}, utxo.Script)
+		require.EqualValues(t, 100, utxos[0].Runes[0].Amount.Int64())
+		require.Equal(t, second, utxos[0].Runes[1].RuneID)
+		require.Equal(t, bitcoin.UTXO{}, *(&bitcoin.UTXO{}).Clone())
+	})
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/rpc"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// Default relay policy limits of the Bitcoin Core.
+const (
+	// witnessScaleFactor defines how many times non-witness data costs more than witness data.
+	witnessScaleFactor = 4
+	// maxStandardTxVersion defines maximum standard transaction version (TRUC).
+	maxStandardTxVersion = 3
+	// maxStandardTxWeight defines maximum standard transaction weight in weight units.
+	maxStandardTxWeight = 400_000
+	// minStandardTxNonWitnessSize defines minimum transaction size without witness in bytes.
+	minStandardTxNonWitnessSize = 65
+	// maxStandardScriptSigSize defines maximum standard signature script size in bytes.
+	maxStandardScriptSigSize = 1650
+	// witnessOutputSpendSize defines spending input size in vBytes of the witness output used in dust threshold:
+	// outpoint (36) + script sig length (1) + sequence (4) + witness of the signature and public key (107 / 4).
+	witnessOutputSpendSize = 32 + 4 + 1 + 107/witnessScaleFactor + 4
+	// outputSpendSize defines spending input size in vBytes of the non-witness output used in dust threshold.
+	outputSpendSize = 32 + 4 + 1 + 107 + 4
+)
+
+// newIssue is a constructor for Issue.
+func newIssue(check Check, input, output int, format string, args ...any) Issue {
+	return Issue{Check: check, Input: input, Output: output, Reason: fmt.Sprintf(format, args...)}
+}
+
+// checkScripts executes inputs scripts against the spent outputs with standard verification flags.
+// INFO: keyless pay to anchor inputs are skipped, they are valid with any witness.
+func checkScripts(tx *wire.MsgTx, prevOuts []*wire.TxOut) (issues []Issue) {
+	fetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, input := range tx.TxIn {
+		fetcher.AddPrevOut(input.PreviousOutPoint, prevOuts[i])
+	}
+
+	sigHashes := txscript.NewTxSigHashes(tx, fetcher)
+	for i, prevOut := range prevOuts {
+		if bitcoin.IsP2AScript(prevOut.PkScript) {
+			continue
+		}
+
+		engine, err := txscript.NewEngine(prevOut.PkScript, tx, i, txscript.StandardVerifyFlags, nil, sigHashes,
+			prevOut.Value, fetcher)
+		if err == nil {
+			err = engine.Execute()
+		}
+		if err != nil {
+			issues = append(issues, newIssue(CheckScripts, i, -1, "%s", err))
+		}
+	}
+
+	return issues
+}
+
+// checkStandardness checks transaction against default relay policy. Single dust output is allowed
+// in zero fee transaction as ephemeral dust, which should be spent by the child in the same package.
+func (v *Validator) checkStandardness(tx *wire.MsgTx, report Report) (issues []Issue) {
+	if tx.Version < 1 || tx.Version > maxStandardTxVersion {
+		issues = append(issues, newIssue(CheckStandardness, -1, -1, "version %d", tx.Version))
+	}
+	if report.Weight > maxStandardTxWeight {
+		issues = append(issues, newIssue(CheckStandardness, -1, -1, "weight %d exceeds %d", report.Weight, maxStandardTxWeight))
+	}
+	if size := tx.SerializeSizeStripped(); size < minStandardTxNonWitnessSize {
+		issues = append(issues, newIssue(CheckStandardness, -1, -1, "non-witness size %d is less than %d", size,
+			minStandardTxNonWitnessSize))
+	}
+
+	for i, input := range tx.TxIn {
+		if len(input.SignatureScript) > maxStandardScriptSigSize {
+			issues = append(issues, newIssue(CheckStandardness, i, -1, "signature script size %d exceeds %d",
+				len(input.SignatureScript), maxStandardScriptSigSize))
+		}
+		if !txscript.IsPushOnlyScript(input.SignatureScript) {
+			issues = append(issues, newIssue(CheckStandardness, i, -1, "signature script is not push only"))
+		}
+	}
+
+	var dust []Issue
+	for i, output := range tx.TxOut {
+		utxo := bitcoin.UTXO{Script: output.PkScript}
+		if utxo.ScriptType() == bitcoin.ScriptTypeNonStandard && !txscript.IsWitnessProgram(output.PkScript) {
+			issues = append(issues, newIssue(CheckStandardness, -1, i, "non-standard script pub key"))
+		}
+
+		if threshold := v.dustThreshold(output); output.Value < threshold {
+			dust = append(dust, newIssue(CheckStandardness, -1, i, "dust amount %d is less than %d", output.Value, threshold))
+		}
+	}
+
+	if len(dust) == 1 && report.Fee.Sign() == 0 {
+		return issues
+	}
+
+	return append(issues, dust...)
+}
+
+// dustThreshold returns minimum non-dust output value in satoshi, which is the fee for the output
+// and its spending input at the dust relay fee rate. OP_RETURN outputs have zero threshold.
+func (v *Validator) dustThreshold(output *wire.TxOut) int64 {
+	if txscript.GetScriptClass(output.PkScript) == txscript.NullDataTy {
+		return 0
+	}
+
+	size := int64(output.SerializeSize())
+	if txscript.IsWitnessProgram(output.PkScript) {
+		size += witnessOutputSpendSize
+	} else {
+		size += outputSpendSize
+	}
+
+	// INFO: vB * ( sat / kvB ) = 1000 sat.
+	threshold := new(big.Int).Mul(big.NewInt(size), v.config.DustRelaySatoshiPerKVByte)
+
+	return threshold.Div(threshold, big.NewInt(1000)).Int64()
+}
+
+// checkFee checks that inputs cover outputs and fee is within configured bounds.
+func (v *Validator) checkFee(tx *wire.MsgTx, report Report) (issues []Issue) {
+	if report.Fee.Sign() < 0 {
+		return []Issue{newIssue(CheckFee, -1, -1, "outputs exceed inputs by %s", new(big.Int).Neg(report.Fee))}
+	}
+
+	ephemeral := report.Fee.Sign() == 0 && hasAnchor(tx)
+	if !ephemeral && numbers.IsLess(report.SatoshiPerKVByte, v.config.MinSatoshiPerKVByte) {
+		issues = append(issues, newIssue(CheckFee, -1, -1, "fee rate %s is less than %s", report.SatoshiPerKVByte,
+			v.config.MinSatoshiPerKVByte))
+	}
+	if numbers.IsGreater(report.SatoshiPerKVByte, v.config.MaxSatoshiPerKVByte) {
+		issues = append(issues, newIssue(CheckFee, -1, -1, "fee rate %s is greater than %s", report.SatoshiPerKVByte,
+			v.config.MaxSatoshiPerKVByte))
+	}
+	if v.config.MaxFee != nil && numbers.IsGreater(report.Fee, v.config.MaxFee) {
+		issues = append(issues, newIssue(CheckFee, -1, -1, "fee %s is greater than %s", report.Fee, v.config.MaxFee))
+	}
+
+	return issues
+}
+
+// hasAnchor returns true if transaction has pay to anchor (P2A) output.
+func hasAnchor(tx *wire.MsgTx) bool {
+	for _, output := range tx.TxOut {
+		if bitcoin.IsP2AScript(output.PkScript) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// testMempoolAccept returns result of the node testmempoolaccept call for the transaction.
+func testMempoolAccept(ctx context.Context, caller rpc.Caller, tx *wire.MsgTx) (result MempoolAcceptResult, _ error) {
+	var buffer bytes.Buffer
+	if err := tx.Serialize(&buffer); err != nil {
+		return result, err
+	}
+
+	var response []struct {
+		Allowed bool  `json:"allowed"`
+		VSize   int64 `json:"vsize"`
+		Fees    struct {
+			Base float64 `json:"base"` // in BTC.
+		} `json:"fees"`
+		RejectReason string `json:"reject-reason"`
+	}
+	if err := caller.Call(ctx, "testmempoolaccept", &response, []string{hex.EncodeToString(buffer.Bytes())}); err != nil {
+		return result, err
+	}
+
+	if len(response) != 1 {
+		return result, fmt.Errorf("testmempoolaccept: unexpected %d results", len(response))
+	}
+
+	result.Allowed = response[0].Allowed
+	result.RejectReason = response[0].RejectReason
+	if result.Allowed {
+		fee, err := btcutil.NewAmount(response[0].Fees.Base)
+		if err != nil {
+			return result, err
+		}
+
+		result.VSize = response[0].VSize
+		result.Fee = big.NewInt(int64(fee))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package validator provides pre-broadcast validation of the signed transactions.
+package validator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/rpc"
+)
+
+var (
+	// ErrInvalidTx describes that transaction can not be validated, e.g. PSBT is not signed or misses previous outputs.
+	ErrInvalidTx = errors.New("invalid transaction")
+	// ErrValidationFailed describes that transaction did not pass the validation checks.
+	ErrValidationFailed = errors.New("transaction validation failed")
+)
+
+// Check defines validation check of the transaction.
+type Check string
+
+const (
+	// CheckScripts defines local verification of the inputs scripts and signatures.
+	CheckScripts Check = "scripts"
+	// CheckStandardness defines default relay policy checks: version, size, scripts types and dust outputs.
+	CheckStandardness Check = "standardness"
+	// CheckFee defines fee sanity check: inputs cover outputs and fee rate is within configured bounds.
+	CheckFee Check = "fee"
+	// CheckMempoolAccept defines node testmempoolaccept RPC call, requires Config.RPC.
+	CheckMempoolAccept Check = "mempool-accept"
+)
+
+// Default policy values of the Bitcoin Core.
+const (
+	// DefaultMinRelaySatoshiPerKVByte defines minimum relay fee rate.
+	DefaultMinRelaySatoshiPerKVByte int64 = 1000
+	// DefaultMaxSatoshiPerKVByte defines maximum fee rate accepted by sendrawtransaction.
+	DefaultMaxSatoshiPerKVByte int64 = 10_000_000
+	// DefaultDustRelaySatoshiPerKVByte defines fee rate used to calculate dust threshold.
+	DefaultDustRelaySatoshiPerKVByte int64 = 3000
+)
+
+// Config defines Validator configuration.
+type Config struct {
+	// Checks to run, optional, all local checks and CheckMempoolAccept if RPC is set are run if empty.
+	Checks []Check
+	// RPC is used by CheckMempoolAccept, optional.
+	RPC rpc.Caller
+	// MinSatoshiPerKVByte is a minimum fee rate, optional, DefaultMinRelaySatoshiPerKVByte if not set.
+	// NOTE: Zero fee transaction with ephemeral anchor is not checked, since it is relayed as a package.
+	MinSatoshiPerKVByte *big.Int
+	// MaxSatoshiPerKVByte is a maximum fee rate, optional, DefaultMaxSatoshiPerKVByte if not set.
+	MaxSatoshiPerKVByte *big.Int
+	// MaxFee is a maximum absolute fee in satoshi, optional, not limited if not set.
+	MaxFee *big.Int
+	// DustRelaySatoshiPerKVByte is a dust threshold fee rate, optional, DefaultDustRelaySatoshiPerKVByte if not set.
+	DustRelaySatoshiPerKVByte *big.Int
+}
+
+// Issue describes failed validation check.
+type Issue struct {
+	Check  Check
+	Input  int // index of the input caused the issue, -1 if not related to any input.
+	Output int // index of the output caused the issue, -1 if not related to any output.
+	Reason string
+}
+
+// String returns human-readable issue description.
+func (issue Issue) String() string {
+	switch {
+	case issue.Input >= 0:
+		return fmt.Sprintf("%s: input %d: %s", issue.Check, issue.Input, issue.Reason)
+	case issue.Output >= 0:
+		return fmt.Sprintf("%s: output %d: %s", issue.Check, issue.Output, issue.Reason)
+	default:
+		return fmt.Sprintf("%s: %s", issue.Check, issue.Reason)
+	}
+}
+
+// MempoolAcceptResult describes result of the node testmempoolaccept call.
+type MempoolAcceptResult struct {
+	Allowed      bool
+	RejectReason string   // empty if allowed.
+	VSize        int64    // virtual size in vBytes calculated by the node, 0 if not allowed.
+	Fee          *big.Int // fee in satoshi calculated by the node, nil if not allowed.
+}
+
+// Report describes consolidated result of the transaction validation.
+type Report struct {
+	TxHash           string
+	Weight           int64    // in weight units.
+	VSize            int64    // in virtual bytes.
+	Fee              *big.Int // fee in satoshi, negative if outputs exceed inputs.
+	SatoshiPerKVByte *big.Int // fee rate in satoshi per kilo virtual byte.
+	Checks           []Check  // checks which were run.
+	Issues           []Issue  // failed checks, empty if transaction is valid.
+	// MempoolAccept is a node testmempoolaccept result, nil if CheckMempoolAccept was not run.
+	MempoolAccept *MempoolAcceptResult
+}
+
+// OK returns true if transaction passed all checks.
+func (r Report) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Err returns ErrValidationFailed with all issues, nil if transaction passed all checks.
+func (r Report) Err() error {
+	if r.OK() {
+		return nil
+	}
+
+	errs := make([]error, 0, len(r.Issues))
+	for _, issue := range r.Issues {
+		errs = append(errs, errors.New(issue.String()))
+	}
+
+	return fmt.Errorf("%w: %s: %w", ErrValidationFailed, r.TxHash, errors.Join(errs...))
+}
+
+// Validator runs signed transactions through configured checks before the broadcast.
+type Validator struct {
+	config Config
+}
+
+// New is a constructor for Validator.
+func New(config Config) *Validator {
+	if len(config.Checks) == 0 {
+		config.Checks = []Check{CheckScripts, CheckStandardness, CheckFee}
+		if config.RPC != nil {
+			config.Checks = append(config.Checks, CheckMempoolAccept)
+		}
+	}
+	if config.MinSatoshiPerKVByte == nil {
+		config.MinSatoshiPerKVByte = big.NewInt(DefaultMinRelaySatoshiPerKVByte)
+	}
+	if config.MaxSatoshiPerKVByte == nil {
+		config.MaxSatoshiPerKVByte = big.NewInt(DefaultMaxSatoshiPerKVByte)
+	}
+	if config.DustRelaySatoshiPerKVByte == nil {
+		config.DustRelaySatoshiPerKVByte = big.NewInt(DefaultDustRelaySatoshiPerKVByte)
+	}
+
+	return &Validator{config: config}
+}
+
+// ValidatePSBT finalizes signed PSBT and validates extracted transaction, inputs should hold previous outputs.
+func (v *Validator) ValidatePSBT(ctx context.Context, signedPSBT []byte) (Report, error) {
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBT), false)
+	if err != nil {
+		return Report{}, fmt.Errorf("%w: %w", ErrInvalidTx, err)
+	}
+
+	prevOuts := make([]*wire.TxOut, len(p.Inputs))
+	for i, input := range p.Inputs {
+		switch {
+		case input.WitnessUtxo != nil:
+			prevOuts[i] = input.WitnessUtxo
+		case input.NonWitnessUtxo != nil:
+			index := p.UnsignedTx.TxIn[i].PreviousOutPoint.Index
+			if int(index) >= len(input.NonWitnessUtxo.TxOut) {
+				return Report{}, fmt.Errorf("%w: input %d: previous output index %d", ErrInvalidTx, i, index)
+			}
+			prevOuts[i] = input.NonWitnessUtxo.TxOut[index]
+		default:
+			return Report{}, fmt.Errorf("%w: input %d: missing previous output", ErrInvalidTx, i)
+		}
+	}
+
+	if err = psbt.MaybeFinalizeAll(p); err != nil {
+		return Report{}, fmt.Errorf("%w: %w", ErrInvalidTx, err)
+	}
+
+	tx, err := psbt.Extract(p)
+	if err != nil {
+		return Report{}, fmt.Errorf("%w: %w", ErrInvalidTx, err)
+	}
+
+	return v.Validate(ctx, tx, prevOuts)
+}
+
+// Validate runs signed transaction through configured checks, prevOuts are outputs spent by the inputs
+// by their indexes. Failed checks are reported by the Report issues, error is returned only if the checks
+// can not be run, e.g. RPC call fails.
+func (v *Validator) Validate(ctx context.Context, tx *wire.MsgTx, prevOuts []*wire.TxOut) (report Report, _ error) {
+	if len(prevOuts) != len(tx.TxIn) || slices.Contains(prevOuts, nil) {
+		return report, fmt.Errorf("%w: %d previous outputs for %d inputs", ErrInvalidTx, len(prevOuts), len(tx.TxIn))
+	}
+
+	report.TxHash = tx.TxHash().String()
+	report.Weight, report.VSize = bitcoin.MsgTxWeight(tx)
+
+	report.Fee = big.NewInt(0)
+	for _, prevOut := range prevOuts {
+		report.Fee.Add(report.Fee, big.NewInt(prevOut.Value))
+	}
+	for _, output := range tx.TxOut {
+		report.Fee.Sub(report.Fee, big.NewInt(output.Value))
+	}
+	report.SatoshiPerKVByte = new(big.Int).Mul(report.Fee, big.NewInt(1000))
+	report.SatoshiPerKVByte.Div(report.SatoshiPerKVByte, big.NewInt(report.VSize))
+
+	for _, check := range v.config.Checks {
+		report.Checks = append(report.Checks, check)
+
+		switch check {
+		case CheckScripts:
+			report.Issues = append(report.Issues, checkScripts(tx, prevOuts)...)
+		case CheckStandardness:
+			report.Issues = append(report.Issues, v.checkStandardness(tx, report)...)
+		case CheckFee:
+			report.Issues = append(report.Issues, v.checkFee(tx, report)...)
+		case CheckMempoolAccept:
+			if v.config.RPC == nil {
+				return report, fmt.Errorf("%w: rpc is not configured", ErrInvalidTx)
+			}
+
+			result, err := testMempoolAccept(ctx, v.config.RPC, tx)
+			if err != nil {
+				return report, err
+			}
+
+			report.MempoolAccept = &result
+			if !result.Allowed {
+				report.Issues = append(report.Issues, newIssue(CheckMempoolAccept, -1, -1, "%s", result.RejectReason))
+			}
+		default:
+			return report, fmt.Errorf("unknown check %q", check)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package validator_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+	"github.com/BoostyLabs/blockchain/bitcoin/validator"
+)
+
+// rpc responds to testmempoolaccept with the JSON response.
+type rpc struct {
+	response string
+	rawTxs   []string
+}
+
+func (r *rpc) Call(_ context.Context, method string, result any, params ...any) error {
+	if method == "testmempoolaccept" {
+		r.rawTxs = append(r.rawTxs, params[0].([]string)...)
+	}
+
+	return json.Unmarshal([]byte(r.response), result)
+}
+
+func TestValidator(t *testing.T) {
+	ctx := context.Background()
+	networkParams := &chaincfg.TestNet3Params
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	params := txbuilder.BaseBTCTransferParams{
+		Sender:                wallet.PaymentData("d78a52d61c43ec43d56e270e8f87ebe952f3bb5fe0a042494ed6ebf753285746", 100000),
+		TransferSatoshiAmount: big.NewInt(10000),
+		SatoshiPerKVByte:      big.NewInt(5000),
+		RecipientAddress:      wallet.Address,
+	}
+
+	signed := func(t *testing.T, params txbuilder.BaseBTCTransferParams) []byte {
+		result, err := txbuilder.NewTxBuilder(networkParams).BuildBTCTransferTx(params)
+		require.NoError(t, err)
+
+		signedPSBT, err := signer.NewSigner(networkParams).SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: result.SerializedPSBT,
+			Inputs:         []int{0},
+			PrivateKey:     wallet.PrivateKey,
+		})
+		require.NoError(t, err)
+
+		return signedPSBT
+	}
+	extract := func(t *testing.T, signedPSBT []byte) (*wire.MsgTx, []*wire.TxOut) {
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(signedPSBT), false)
+		require.NoError(t, err)
+		require.NoError(t, psbt.MaybeFinalizeAll(p))
+		tx, err := psbt.Extract(p)
+		require.NoError(t, err)
+
+		return tx, []*wire.TxOut{p.Inputs[0].WitnessUtxo}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		caller := &rpc{response: `[{"allowed": true, "vsize": 111, "fees": {"base": 0.00000555}}]`}
+		report, err := validator.New(validator.Config{RPC: caller}).ValidatePSBT(ctx, signed(t, params))
+		require.NoError(t, err)
+		require.True(t, report.OK(), report.Issues)
+		require.NoError(t, report.Err())
+		require.Equal(t, []validator.Check{validator.CheckScripts, validator.CheckStandardness, validator.CheckFee,
+			validator.CheckMempoolAccept}, report.Checks)
+		require.Len(t, caller.rawTxs, 1)
+		require.True(t, report.MempoolAccept.Allowed)
+		require.EqualValues(t, 555, report.MempoolAccept.Fee.Int64())
+		require.True(t, report.SatoshiPerKVByte.Cmp(big.NewInt(5000)) >= 0)
+	})
+
+	t.Run("mempool reject", func(t *testing.T) {
+		caller := &rpc{response: `[{"allowed": false, "reject-reason": "missing-inputs"}]`}
+		report, err := validator.New(validator.Config{RPC: caller}).ValidatePSBT(ctx, signed(t, params))
+		require.NoError(t, err)
+		require.Equal(t, []validator.Issue{{Check: validator.CheckMempoolAccept, Input: -1, Output: -1,
+			Reason: "missing-inputs"}}, report.Issues)
+		require.ErrorIs(t, report.Err(), validator.ErrValidationFailed)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		tx, prevOuts := extract(t, signed(t, params))
+		tx.TxOut[0].Value--
+
+		report, err := validator.New(validator.Config{Checks: []validator.Check{validator.CheckScripts}}).Validate(ctx, tx, prevOuts)
+		require.NoError(t, err)
+		require.Len(t, report.Issues, 1)
+		require.Equal(t, validator.CheckScripts, report.Issues[0].Check)
+		require.Equal(t, 0, report.Issues[0].Input)
+	})
+
+	t.Run("dust and fee", func(t *testing.T) {
+		tx, prevOuts := extract(t, signed(t, params))
+		tx.TxOut[0].Value = 300 // P2TR dust threshold is 330.
+		tx.TxOut[1].Value = 100
+
+		report, err := validator.New(validator.Config{
+			Checks:              []validator.Check{validator.CheckStandardness, validator.CheckFee},
+			MaxSatoshiPerKVByte: big.NewInt(100000),
+			MaxFee:              big.NewInt(50000),
+		}).Validate(ctx, tx, prevOuts)
+		require.NoError(t, err)
+
+		outputs := make(map[validator.Check][]int)
+		for _, issue := range report.Issues {
+			outputs[issue.Check] = append(outputs[issue.Check], issue.Output)
+		}
+		require.Equal(t, map[validator.Check][]int{
+			validator.CheckStandardness: {0, 1},
+			validator.CheckFee:          {-1, -1}, // fee rate and absolute fee.
+		}, outputs)
+	})
+
+	t.Run("ephemeral anchor", func(t *testing.T) {
+		withAnchor := params
+		withAnchor.SatoshiPerKVByte = big.NewInt(0)
+		withAnchor.EphemeralAnchor = true
+
+		report, err := validator.New(validator.Config{}).ValidatePSBT(ctx, signed(t, withAnchor))
+		require.NoError(t, err)
+		require.True(t, report.OK(), report.Issues)
+		require.Zero(t, report.Fee.Sign())
+	})
+
+	t.Run("not signed", func(t *testing.T) {
+		result, err := txbuilder.NewTxBuilder(networkParams).BuildBTCTransferTx(params)
+		require.NoError(t, err)
+
+		_, err = validator.New(validator.Config{}).ValidatePSBT(ctx, result.SerializedPSBT)
+		require.ErrorIs(t, err, validator.ErrInvalidTx)
+	})
+}
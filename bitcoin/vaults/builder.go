@@ -0,0 +1,312 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package vaults
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// ErrInvalidFee describes that fixed fee and anchor amount can not be paid from the input amount.
+var ErrInvalidFee = errors.New("invalid fixed fee")
+
+// txVersion defines version of the vault transactions, enables relative lock time (BIP-68).
+const txVersion int32 = 2
+
+// FixedFee defines fee of the pre-signed transaction. It can not be changed after signing, so transaction
+// has pay to anchor (P2A) output to be bumped by the child, see txbuilder.BuildAnchorSpendTx.
+type FixedFee struct {
+	Fee *big.Int // fee in satoshi, zero if not set.
+	// AnchorAmount is anchor output amount in satoshi, zero if not set.
+	// NOTE: Zero value (ephemeral) anchor is relayed only by the zero fee transaction, since Bitcoin Core 29.
+	AnchorAmount *big.Int
+}
+
+// BuildUnvaultTxParams describes data needed to build unvault transaction.
+type BuildUnvaultTxParams struct {
+	Vault       Vault
+	DepositUTXO *bitcoin.UTXO // taproot deposit output, spent by the deposit key path.
+	FixedFee    FixedFee
+}
+
+// BuildClawbackTxParams describes data needed to build clawback transaction.
+type BuildClawbackTxParams struct {
+	Vault       Vault
+	VaultUTXO   *bitcoin.UTXO // vault output of the unvault transaction.
+	ColdAddress string        // clawback destination address.
+	FixedFee    FixedFee
+}
+
+// BuildTxResult describes result of the vault transaction building.
+type BuildTxResult struct {
+	SerializedPSBT []byte
+	// TxHash is transaction id, it does not depend on signatures, since all inputs are taproot.
+	TxHash chainhash.Hash
+	Amount *big.Int // main output amount in satoshi.
+}
+
+// PSBTBase64 returns base64 encoded SerializedPSBT.
+func (result BuildTxResult) PSBTBase64() string {
+	return base64.StdEncoding.EncodeToString(result.SerializedPSBT)
+}
+
+// PSBTHex returns hex encoded SerializedPSBT.
+func (result BuildTxResult) PSBTHex() string {
+	return hex.EncodeToString(result.SerializedPSBT)
+}
+
+// PreSignParams describes data needed for the pre-signing ceremony.
+type PreSignParams struct {
+	Vault       Vault
+	DepositUTXO *bitcoin.UTXO
+	// DepositKey is deposit output private key, should be destroyed after the ceremony, so the deposit
+	// is spendable by the pre-signed unvault transaction only.
+	DepositKey  *btcec.PrivateKey
+	ColdKey     *btcec.PrivateKey // cold wallet private key, signs clawback transaction.
+	ColdAddress string            // clawback destination address.
+	UnvaultFee  FixedFee
+	ClawbackFee FixedFee
+}
+
+// PreSignResult describes result of the pre-signing ceremony.
+type PreSignResult struct {
+	Unvault  BuildTxResult // signed unvault transaction.
+	Clawback BuildTxResult // signed clawback transaction.
+}
+
+// Builder builds vault transactions and coordinates signer for the pre-signing ceremony.
+type Builder struct {
+	networkParams *chaincfg.Params
+	signer        *signer.Signer
+	dustAmount    *big.Int // the smallest main output amount in satoshi.
+}
+
+// NewBuilder is a constructor for Builder. Options configure the dust amount the same way as
+// for txbuilder.TxBuilder, e.g. txbuilder.WithDustAmount, other configuration is not used.
+func NewBuilder(networkParams *chaincfg.Params, signer *signer.Signer, opts ...txbuilder.Option) *Builder {
+	config := txbuilder.DefaultTxBuilderConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return &Builder{
+		networkParams: networkParams,
+		signer:        signer,
+		dustAmount:    config.DustAmount,
+	}
+}
+
+// BuildUnvaultTx constructs unvault transaction moving deposit to the vault output.
+//
+//	Tx struct
+//	inputs:
+//	┌─────────┬───────────────┬───────────────────────────────────────┐
+//	│  index  │     type      │             description               │
+//	├=========┼===============┼=======================================┤
+//	│       0 │ deposit input │ deposit output, key path spent.       │
+//	└─────────┴───────────────┴───────────────────────────────────────┘
+//
+//	outputs:
+//	┌─────────┬───────────────┬───────────────────────────────────────┐
+//	│  index  │     type      │             description               │
+//	├=========┼===============┼=======================================┤
+//	│       0 │ vault output  │ deposit amount without fee and        │
+//	│         │               │ anchor amount.                        │
+//	├─────────┼───────────────┼───────────────────────────────────────┤
+//	│       1 │ anchor output │ pay to anchor (P2A) output.           │
+//	└─────────┴───────────────┴───────────────────────────────────────┘
+func (b *Builder) BuildUnvaultTx(params BuildUnvaultTxParams) (result BuildTxResult, _ error) {
+	if params.DepositUTXO == nil || params.DepositUTXO.ScriptType() != bitcoin.ScriptTypeP2TR {
+		return result, fmt.Errorf("%w: deposit utxo should be taproot output", txbuilder.ErrUnsupportedAddressType)
+	}
+	if params.DepositUTXO.Amount == nil || params.DepositUTXO.Amount.Sign() <= 0 {
+		return result, fmt.Errorf("%w: deposit utxo amount is required", txbuilder.ErrInvalidUTXOAmount)
+	}
+
+	vaultScript, err := params.Vault.PkScript()
+	if err != nil {
+		return result, err
+	}
+
+	return b.buildTx(params.DepositUTXO, vaultScript, params.FixedFee, nil)
+}
+
+// BuildClawbackTx constructs clawback transaction spending the vault output by the cold leaf to the cold address.
+//
+//	Tx struct
+//	inputs:
+//	┌─────────┬───────────────┬───────────────────────────────────────┐
+//	│  index  │     type      │             description               │
+//	├=========┼===============┼=======================================┤
+//	│       0 │ vault input   │ vault output, cold leaf spent.        │
+//	└─────────┴───────────────┴───────────────────────────────────────┘
+//
+//	outputs:
+//	┌─────────┬───────────────┬───────────────────────────────────────┐
+//	│  index  │     type      │             description               │
+//	├=========┼===============┼=======================================┤
+//	│       0 │ cold output   │ vault amount without fee and anchor   │
+//	│         │               │ amount.                               │
+//	├─────────┼───────────────┼───────────────────────────────────────┤
+//	│       1 │ anchor output │ pay to anchor (P2A) output.           │
+//	└─────────┴───────────────┴───────────────────────────────────────┘
+func (b *Builder) BuildClawbackTx(params BuildClawbackTxParams) (result BuildTxResult, _ error) {
+	if params.VaultUTXO == nil {
+		return result, fmt.Errorf("%w: vault utxo is required", ErrVaultMismatch)
+	}
+	if params.VaultUTXO.Amount == nil || params.VaultUTXO.Amount.Sign() <= 0 {
+		return result, fmt.Errorf("%w: vault utxo amount is required", txbuilder.ErrInvalidUTXOAmount)
+	}
+	if err := params.Vault.VerifyOutput(params.VaultUTXO.Script); err != nil {
+		return result, err
+	}
+
+	coldAddress, err := txbuilder.DecodeAddress(params.ColdAddress, b.networkParams)
+	if err != nil {
+		return result, err
+	}
+
+	coldScript, err := txscript.PayToAddrScript(coldAddress)
+	if err != nil {
+		return result, err
+	}
+
+	return b.buildTx(params.VaultUTXO, coldScript, params.FixedFee, func(input *psbt.PInput) {
+		input.TaprootInternalKey = schnorr.SerializePubKey(params.Vault.TaprootInternalKey())
+	})
+}
+
+// PreSign runs the pre-signing ceremony: builds unvault and clawback transactions, signs clawback by the cold key
+// and only then unvault by the deposit key, so the vault output is never created without the clawback.
+// NOTE: Deposit key should be destroyed after the ceremony, signed transactions should be stored instead.
+func (b *Builder) PreSign(params PreSignParams) (result PreSignResult, err error) {
+	if params.DepositKey == nil || params.ColdKey == nil {
+		return result, signer.ErrMissingPrivateKey
+	}
+
+	result.Unvault, err = b.BuildUnvaultTx(BuildUnvaultTxParams{
+		Vault:       params.Vault,
+		DepositUTXO: params.DepositUTXO,
+		FixedFee:    params.UnvaultFee,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	vaultScript, err := params.Vault.PkScript()
+	if err != nil {
+		return result, err
+	}
+
+	result.Clawback, err = b.BuildClawbackTx(BuildClawbackTxParams{
+		Vault: params.Vault,
+		VaultUTXO: &bitcoin.UTXO{
+			Outpoint: bitcoin.Outpoint{Hash: result.Unvault.TxHash, Index: 0},
+			Amount:   result.Unvault.Amount,
+			Script:   vaultScript,
+		},
+		ColdAddress: params.ColdAddress,
+		FixedFee:    params.ClawbackFee,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	leaves, err := params.Vault.TapLeaves()
+	if err != nil {
+		return result, err
+	}
+
+	result.Clawback.SerializedPSBT, err = b.signer.SignTapLeaf(signer.SignTapLeafParams{
+		SerializedPSBT: result.Clawback.SerializedPSBT,
+		Input:          0,
+		Leaves:         leaves,
+		Leaf:           ColdLeaf,
+		InternalKey:    params.Vault.TaprootInternalKey(),
+		PrivateKey:     params.ColdKey,
+	})
+	if err != nil {
+		return result, fmt.Errorf("clawback: %w", err)
+	}
+
+	result.Unvault.SerializedPSBT, err = b.signer.SignTaproot(signer.SignTaprootParams{
+		SerializedPSBT: result.Unvault.SerializedPSBT,
+		Inputs:         []int{0},
+		PrivateKey:     params.DepositKey,
+	})
+	if err != nil {
+		return result, fmt.Errorf("unvault: %w", err)
+	}
+
+	return result, nil
+}
+
+// buildTx returns serialized PSBT of the single input transaction paying input amount without fixed fee
+// to the output script, with anchor output.
+func (b *Builder) buildTx(utxo *bitcoin.UTXO, pkScript []byte, fixedFee FixedFee, prepareInput func(input *psbt.PInput)) (result BuildTxResult, _ error) {
+	fee, anchorAmount := new(big.Int), new(big.Int)
+	if fixedFee.Fee != nil {
+		fee.Set(fixedFee.Fee)
+	}
+	if fixedFee.AnchorAmount != nil {
+		anchorAmount.Set(fixedFee.AnchorAmount)
+	}
+
+	switch {
+	case fee.Sign() < 0 || anchorAmount.Sign() < 0:
+		return result, fmt.Errorf("%w: negative fee %s or anchor amount %s", ErrInvalidFee, fee, anchorAmount)
+	case anchorAmount.Sign() == 0 && fee.Sign() != 0:
+		return result, fmt.Errorf("%w: zero value anchor requires zero fee, got %s", ErrInvalidFee, fee)
+	}
+
+	amount := new(big.Int).Sub(utxo.Amount, fee)
+	amount.Sub(amount, anchorAmount)
+	if numbers.IsLess(amount, b.dustAmount) {
+		return result, fmt.Errorf("%w: input amount %s, fee %s, anchor amount %s", ErrInvalidFee, utxo.Amount,
+			fee, anchorAmount)
+	}
+
+	tx := wire.NewMsgTx(txVersion)
+	tx.AddTxIn(wire.NewTxIn(utxo.WireOutPoint(), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(amount.Int64(), pkScript))
+	tx.AddTxOut(wire.NewTxOut(anchorAmount.Int64(), bitcoin.P2AScript()))
+
+	p, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return result, err
+	}
+
+	p.Inputs[0].WitnessUtxo = wire.NewTxOut(utxo.Amount.Int64(), utxo.Script)
+	if prepareInput != nil {
+		prepareInput(&p.Inputs[0])
+	}
+
+	w := bytes.NewBuffer(nil)
+	if err = p.Serialize(w); err != nil {
+		return result, err
+	}
+
+	return BuildTxResult{
+		SerializedPSBT: w.Bytes(),
+		TxHash:         tx.TxHash(),
+		Amount:         amount,
+	}, nil
+}
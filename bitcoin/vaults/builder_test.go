@@ -0,0 +1,226 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package vaults_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder"
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+	"github.com/BoostyLabs/blockchain/bitcoin/vaults"
+)
+
+func TestBuilder(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+	verifier := signer.NewSigner(networkParams, signer.VerifySignatures())
+	builder := vaults.NewBuilder(networkParams, verifier)
+
+	newKey := func() *btcec.PrivateKey {
+		key, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+
+		return key
+	}
+	depositKey, hotKey, coldKey := newKey(), newKey(), newKey()
+
+	vault := vaults.Vault{
+		HotPubKey:  hotKey.PubKey(),
+		ColdPubKey: coldKey.PubKey(),
+		Delay:      6,
+	}
+
+	depositScript, err := txscript.PayToTaprootScript(txscript.ComputeTaprootKeyNoScript(depositKey.PubKey()))
+	require.NoError(t, err)
+	depositUTXO := &bitcoin.UTXO{
+		Outpoint: bitcoin.Outpoint{Hash: chainhash.Hash{1}, Index: 2},
+		Amount:   big.NewInt(100000),
+		Script:   depositScript,
+	}
+
+	coldAddress, err := utils.P2TRAddressFromInternalKey(coldKey.PubKey(), nil, networkParams)
+	require.NoError(t, err)
+
+	params := vaults.PreSignParams{
+		Vault:       vault,
+		DepositUTXO: depositUTXO,
+		DepositKey:  depositKey,
+		ColdKey:     coldKey,
+		ColdAddress: coldAddress.EncodeAddress(),
+		UnvaultFee:  vaults.FixedFee{Fee: big.NewInt(500), AnchorAmount: big.NewInt(240)},
+		ClawbackFee: vaults.FixedFee{},
+	}
+
+	parse := func(t *testing.T, serialized []byte) *psbt.Packet {
+		packet, err := psbt.NewFromRawBytes(bytes.NewReader(serialized), false)
+		require.NoError(t, err)
+
+		return packet
+	}
+
+	t.Run("pre-sign", func(t *testing.T) {
+		result, err := builder.PreSign(params)
+		require.NoError(t, err)
+
+		unvault := parse(t, result.Unvault.SerializedPSBT)
+		require.Equal(t, result.Unvault.TxHash, unvault.UnsignedTx.TxHash())
+		require.Equal(t, depositUTXO.WireOutPoint(), &unvault.UnsignedTx.TxIn[0].PreviousOutPoint)
+		require.NotEmpty(t, unvault.Inputs[0].TaprootKeySpendSig)
+		require.NoError(t, vault.VerifyOutput(unvault.UnsignedTx.TxOut[0].PkScript))
+		require.EqualValues(t, 100000-500-240, unvault.UnsignedTx.TxOut[0].Value)
+		require.EqualValues(t, 100000-500-240, result.Unvault.Amount.Int64())
+		require.Equal(t, bitcoin.P2AScript(), unvault.UnsignedTx.TxOut[1].PkScript)
+		require.EqualValues(t, 240, unvault.UnsignedTx.TxOut[1].Value)
+
+		clawback := parse(t, result.Clawback.SerializedPSBT)
+		require.Equal(t, wire.OutPoint{Hash: result.Unvault.TxHash, Index: 0}, clawback.UnsignedTx.TxIn[0].PreviousOutPoint)
+		require.Len(t, clawback.Inputs[0].TaprootScriptSpendSig, 1)
+		require.Equal(t, result.Unvault.Amount.Int64(), clawback.UnsignedTx.TxOut[0].Value)
+		require.Equal(t, bitcoin.P2AScript(), clawback.UnsignedTx.TxOut[1].PkScript)
+		require.Zero(t, clawback.UnsignedTx.TxOut[1].Value)
+
+		decoded, err := txbuilder.PSBTFromBase64(result.Clawback.PSBTBase64())
+		require.NoError(t, err)
+		require.Equal(t, result.Clawback.SerializedPSBT, decoded)
+		decoded, err = txbuilder.PSBTFromHex(result.Unvault.PSBTHex())
+		require.NoError(t, err)
+		require.Equal(t, result.Unvault.SerializedPSBT, decoded)
+
+		require.NoError(t, psbt.MaybeFinalizeAll(clawback))
+		require.NoError(t, psbt.MaybeFinalizeAll(unvault))
+	})
+
+	t.Run("hot spend", func(t *testing.T) {
+		result, err := builder.BuildUnvaultTx(vaults.BuildUnvaultTxParams{Vault: vault, DepositUTXO: depositUTXO})
+		require.NoError(t, err)
+
+		vaultScript, err := vault.PkScript()
+		require.NoError(t, err)
+		leaves, err := vault.TapLeaves()
+		require.NoError(t, err)
+
+		spend := func(sequence uint32) error {
+			tx := wire.NewMsgTx(2)
+			tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&result.TxHash, 0), nil, nil))
+			tx.TxIn[0].Sequence = sequence
+			tx.AddTxOut(wire.NewTxOut(result.Amount.Int64()-1000, depositScript))
+
+			p, err := psbt.NewFromUnsignedTx(tx)
+			require.NoError(t, err)
+			p.Inputs[0].WitnessUtxo = wire.NewTxOut(result.Amount.Int64(), vaultScript)
+
+			var serialized bytes.Buffer
+			require.NoError(t, p.Serialize(&serialized))
+
+			_, err = verifier.SignTapLeaf(signer.SignTapLeafParams{
+				SerializedPSBT: serialized.Bytes(),
+				Leaves:         leaves,
+				Leaf:           vaults.HotLeaf,
+				InternalKey:    vault.TaprootInternalKey(),
+				PrivateKey:     hotKey,
+			})
+
+			return err
+		}
+
+		require.NoError(t, spend(vault.HotSequence()))
+		require.ErrorIs(t, spend(vault.HotSequence()-1), signer.ErrInvalidSignature)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		invalid := params
+		invalid.UnvaultFee = vaults.FixedFee{Fee: big.NewInt(500)}
+		_, err := builder.PreSign(invalid)
+		require.ErrorIs(t, err, vaults.ErrInvalidFee)
+
+		invalid = params
+		invalid.ClawbackFee = vaults.FixedFee{Fee: big.NewInt(100000), AnchorAmount: big.NewInt(240)}
+		_, err = builder.PreSign(invalid)
+		require.ErrorIs(t, err, vaults.ErrInvalidFee)
+
+		// INFO: vault output below the dust amount.
+		invalid = params
+		invalid.UnvaultFee = vaults.FixedFee{Fee: big.NewInt(99360), AnchorAmount: big.NewInt(240)}
+		_, err = builder.PreSign(invalid)
+		require.ErrorIs(t, err, vaults.ErrInvalidFee)
+		_, err = vaults.NewBuilder(networkParams, verifier, txbuilder.WithDustAmount(big.NewInt(330))).
+			BuildUnvaultTx(vaults.BuildUnvaultTxParams{Vault: vault, DepositUTXO: depositUTXO, FixedFee: invalid.UnvaultFee})
+		require.NoError(t, err)
+
+		invalid = params
+		invalid.ColdAddress = "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr"
+		_, err = builder.PreSign(invalid)
+		require.ErrorIs(t, err, txbuilder.ErrWrongNetwork)
+
+		invalid = params
+		invalid.ColdKey = hotKey
+		_, err = builder.PreSign(invalid)
+		require.ErrorIs(t, err, signer.ErrInvalidSignature)
+
+		invalid = params
+		invalid.DepositKey = nil
+		_, err = builder.PreSign(invalid)
+		require.ErrorIs(t, err, signer.ErrMissingPrivateKey)
+
+		_, err = builder.BuildUnvaultTx(vaults.BuildUnvaultTxParams{
+			Vault:       vault,
+			DepositUTXO: &bitcoin.UTXO{Amount: big.NewInt(100000), Script: bitcoin.P2AScript()},
+		})
+		require.ErrorIs(t, err, txbuilder.ErrUnsupportedAddressType)
+
+		_, err = builder.BuildUnvaultTx(vaults.BuildUnvaultTxParams{
+			Vault:       vault,
+			DepositUTXO: &bitcoin.UTXO{Outpoint: depositUTXO.Outpoint, Script: depositScript},
+		})
+		require.ErrorIs(t, err, txbuilder.ErrInvalidUTXOAmount)
+
+		_, err = builder.BuildClawbackTx(vaults.BuildClawbackTxParams{
+			Vault:       vault,
+			VaultUTXO:   &bitcoin.UTXO{Outpoint: depositUTXO.Outpoint, Script: depositScript},
+			ColdAddress: coldAddress.EncodeAddress(),
+		})
+		require.ErrorIs(t, err, txbuilder.ErrInvalidUTXOAmount)
+
+		_, err = builder.BuildClawbackTx(vaults.BuildClawbackTxParams{
+			Vault:       vault,
+			VaultUTXO:   depositUTXO,
+			ColdAddress: coldAddress.EncodeAddress(),
+		})
+		require.ErrorIs(t, err, vaults.ErrVaultMismatch)
+
+		leaves, err := vault.TapLeaves()
+		require.NoError(t, err)
+		unvault, err := builder.BuildUnvaultTx(vaults.BuildUnvaultTxParams{Vault: vault, DepositUTXO: depositUTXO})
+		require.NoError(t, err)
+
+		_, err = verifier.SignTapLeaf(signer.SignTapLeafParams{
+			SerializedPSBT: unvault.SerializedPSBT,
+			Leaves:         leaves,
+			Leaf:           vaults.ColdLeaf,
+			InternalKey:    vault.TaprootInternalKey(),
+			PrivateKey:     coldKey,
+		})
+		require.ErrorIs(t, err, signer.ErrScriptTreeMismatch)
+
+		_, err = verifier.SignTapLeaf(signer.SignTapLeafParams{
+			SerializedPSBT: unvault.SerializedPSBT,
+			Leaves:         leaves,
+			Leaf:           len(leaves),
+			InternalKey:    vault.TaprootInternalKey(),
+			PrivateKey:     coldKey,
+		})
+		require.ErrorIs(t, err, signer.ErrLeafNotFound)
+	})
+}
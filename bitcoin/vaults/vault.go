@@ -0,0 +1,161 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package vaults implements pre-signed transactions vaults: deposited funds are moved only by the pre-signed
+// unvault transaction to the vault output, spendable by the hot key after the delay, or immediately by the
+// pre-signed clawback transaction to the cold address, e.g. broadcast by a watchtower on unexpected unvault.
+package vaults
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+)
+
+var (
+	// ErrInvalidVault describes that vault public keys or delay are malformed.
+	ErrInvalidVault = errors.New("invalid vault")
+	// ErrVaultMismatch describes that output script pub key is not the taproot output committing to the vault.
+	ErrVaultMismatch = errors.New("output does not commit to the vault")
+)
+
+const (
+	// HotLeaf defines index of the hot leaf in the vault script tree.
+	HotLeaf = 0
+	// ColdLeaf defines index of the cold leaf in the vault script tree.
+	ColdLeaf = 1
+)
+
+// Vault defines taproot vault output: the hot key spends it by the hot leaf after the relative lock time
+// (BIP-68), the cold key spends it by the cold leaf immediately, so the delay is the clawback window.
+type Vault struct {
+	HotPubKey  *btcec.PublicKey // hot wallet public key.
+	ColdPubKey *btcec.PublicKey // cold wallet public key.
+	Delay      uint16           // hot leaf relative lock time in blocks.
+	// InternalKey is taproot internal key, optional, key path spending is disabled if not set.
+	InternalKey *btcec.PublicKey
+}
+
+// HotScript returns hot leaf script: <x-only hot pubkey> OP_CHECKSIGVERIFY <delay> OP_CHECKSEQUENCEVERIFY,
+// see utils.NewTaprootDelayedLeafTapScript.
+func (vault Vault) HotScript() ([]byte, error) {
+	switch {
+	case vault.HotPubKey == nil:
+		return nil, fmt.Errorf("%w: hot public key is required", ErrInvalidVault)
+	case vault.Delay == 0:
+		return nil, fmt.Errorf("%w: delay is not set", ErrInvalidVault)
+	}
+
+	leaf, err := utils.NewTaprootDelayedLeafTapScript(vault.Delay, vault.HotPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return leaf.Script, nil
+}
+
+// ColdScript returns cold leaf script: <x-only cold pubkey> OP_CHECKSIG.
+func (vault Vault) ColdScript() ([]byte, error) {
+	if vault.ColdPubKey == nil {
+		return nil, fmt.Errorf("%w: cold public key is required", ErrInvalidVault)
+	}
+
+	return txscript.NewScriptBuilder().
+		AddData(schnorr.SerializePubKey(vault.ColdPubKey)).
+		AddOp(txscript.OP_CHECKSIG).
+		Script()
+}
+
+// TapLeaves returns leaves of the vault script tree: hot leaf (#HotLeaf) and cold leaf (#ColdLeaf).
+func (vault Vault) TapLeaves() ([]txscript.TapLeaf, error) {
+	hotScript, err := vault.HotScript()
+	if err != nil {
+		return nil, err
+	}
+
+	coldScript, err := vault.ColdScript()
+	if err != nil {
+		return nil, err
+	}
+
+	return []txscript.TapLeaf{txscript.NewBaseTapLeaf(hotScript), txscript.NewBaseTapLeaf(coldScript)}, nil
+}
+
+// Descriptor returns tr() output descriptor of the vault, e.g. to watch the vault address by Bitcoin Core,
+// see utils.TaprootPolicy.Descriptor.
+func (vault Vault) Descriptor() (utils.TaprootDescriptor, error) {
+	leaves, err := vault.TapLeaves()
+	if err != nil {
+		return utils.TaprootDescriptor{}, err
+	}
+
+	return utils.TaprootPolicy{Leaves: leaves, InternalKey: vault.InternalKey}.Descriptor()
+}
+
+// TaprootInternalKey returns vault internal key, NUMS point if InternalKey is not set.
+func (vault Vault) TaprootInternalKey() *btcec.PublicKey {
+	if vault.InternalKey != nil {
+		return vault.InternalKey
+	}
+
+	return utils.NUMSInternalKey()
+}
+
+// ScriptRoot returns merkle root of the vault script tree.
+func (vault Vault) ScriptRoot() ([]byte, error) {
+	leaves, err := vault.TapLeaves()
+	if err != nil {
+		return nil, err
+	}
+
+	root := txscript.AssembleTaprootScriptTree(leaves...).RootNode.TapHash()
+
+	return root[:], nil
+}
+
+// Address returns taproot address of the vault output.
+func (vault Vault) Address(networkParams *chaincfg.Params) (*btcutil.AddressTaproot, error) {
+	scriptRoot, err := vault.ScriptRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.P2TRAddressFromInternalKey(vault.TaprootInternalKey(), scriptRoot, networkParams)
+}
+
+// PkScript returns script pub key of the vault output.
+func (vault Vault) PkScript() ([]byte, error) {
+	scriptRoot, err := vault.ScriptRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return txscript.PayToTaprootScript(utils.TweakedOutputKey(vault.TaprootInternalKey(), scriptRoot))
+}
+
+// VerifyOutput returns ErrVaultMismatch if output script pub key is not the taproot output committing to the vault.
+func (vault Vault) VerifyOutput(pkScript []byte) error {
+	scriptRoot, err := vault.ScriptRoot()
+	if err != nil {
+		return err
+	}
+
+	if !txscript.IsPayToTaproot(pkScript) || !utils.VerifyTweak(vault.TaprootInternalKey(), pkScript[2:], scriptRoot) {
+		return fmt.Errorf("%w: %x", ErrVaultMismatch, pkScript)
+	}
+
+	return nil
+}
+
+// HotSequence returns minimal sequence of the input spending the vault output by the hot leaf.
+// NOTE: Transaction version should be at least 2 to enable relative lock time (BIP-68).
+func (vault Vault) HotSequence() uint32 {
+	return uint32(vault.Delay)
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package vaults_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin/utils"
+	"github.com/BoostyLabs/blockchain/bitcoin/vaults"
+)
+
+func TestVault(t *testing.T) {
+	hotKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	coldKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	vault := vaults.Vault{
+		HotPubKey:  hotKey.PubKey(),
+		ColdPubKey: coldKey.PubKey(),
+		Delay:      144,
+	}
+
+	t.Run("scripts", func(t *testing.T) {
+		hotScript, err := vault.HotScript()
+		require.NoError(t, err)
+
+		disassembled, err := txscript.DisasmString(hotScript)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(schnorr.SerializePubKey(hotKey.PubKey()))+
+			" OP_CHECKSIGVERIFY 9000 OP_CHECKSEQUENCEVERIFY", disassembled)
+
+		coldScript, err := vault.ColdScript()
+		require.NoError(t, err)
+
+		disassembled, err = txscript.DisasmString(coldScript)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(schnorr.SerializePubKey(coldKey.PubKey()))+" OP_CHECKSIG", disassembled)
+
+		leaves, err := vault.TapLeaves()
+		require.NoError(t, err)
+		require.Equal(t, txscript.NewBaseTapLeaf(hotScript), leaves[vaults.HotLeaf])
+		require.Equal(t, txscript.NewBaseTapLeaf(coldScript), leaves[vaults.ColdLeaf])
+		require.EqualValues(t, 144, vault.HotSequence())
+	})
+
+	t.Run("address", func(t *testing.T) {
+		address, err := vault.Address(&chaincfg.MainNetParams)
+		require.NoError(t, err)
+
+		pkScript, err := txscript.PayToAddrScript(address)
+		require.NoError(t, err)
+		require.NoError(t, vault.VerifyOutput(pkScript))
+
+		vaultScript, err := vault.PkScript()
+		require.NoError(t, err)
+		require.Equal(t, pkScript, vaultScript)
+		require.Equal(t, utils.NUMSInternalKey(), vault.TaprootInternalKey())
+
+		descriptor, err := vault.Descriptor()
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(address.ScriptAddress()), descriptor.OutputKey)
+		require.Contains(t, descriptor.Descriptor, "and_v(v:pk("+hex.EncodeToString(schnorr.SerializePubKey(hotKey.PubKey()))+"),older(144))")
+
+		other := vault
+		other.Delay++
+		require.ErrorIs(t, other.VerifyOutput(pkScript), vaults.ErrVaultMismatch)
+
+		other = vault
+		other.InternalKey = hotKey.PubKey()
+		require.ErrorIs(t, other.VerifyOutput(pkScript), vaults.ErrVaultMismatch)
+		require.ErrorIs(t, vault.VerifyOutput([]byte{txscript.OP_RETURN}), vaults.ErrVaultMismatch)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := vault
+		invalid.Delay = 0
+		_, err := invalid.Address(&chaincfg.MainNetParams)
+		require.ErrorIs(t, err, vaults.ErrInvalidVault)
+
+		invalid = vault
+		invalid.HotPubKey = nil
+		_, err = invalid.TapLeaves()
+		require.ErrorIs(t, err, vaults.ErrInvalidVault)
+
+		invalid = vault
+		invalid.ColdPubKey = nil
+		_, err = invalid.PkScript()
+		require.ErrorIs(t, err, vaults.ErrInvalidVault)
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// Placeholder sizes in bytes of the signing data, used to estimate witness and signature script of the unsigned inputs.
+const (
+	// schnorrSignatureSize defines schnorr signature size with non-default sighash type.
+	schnorrSignatureSize = 65
+	// ecdsaSignatureSize defines the largest DER encoded ecdsa signature size with sighash type.
+	ecdsaSignatureSize = 72
+	// compressedPubKeySize defines compressed public key size.
+	compressedPubKeySize = 33
+	// p2wpkhProgramSize defines segwit v0 public key hash witness program push size.
+	p2wpkhProgramSize = 22
+)
+
+// maxWitnessItemSize defines maximum witness item size, limited by the block weight only.
+const maxWitnessItemSize = 4_000_000
+
+// witnessScaleFactor defines how many times non-witness data costs more than witness data.
+const witnessScaleFactor = 4
+
+// TxWeightReport describes expected size of the transaction after all its inputs are signed.
+type TxWeightReport struct {
+	Weight       int64 // in weight units.
+	VSize        int64 // in virtual bytes, weight divided by 4 rounded up.
+	StrippedSize int64 // size without witness data in bytes.
+	// EstimatedInputs are indexes of the inputs which are not finalized, their witness
+	// or signature script sizes are estimated by the spent script type.
+	EstimatedInputs []int
+	// UnknownInputs are indexes of the not finalized inputs which spending size can not be
+	// estimated, e.g. P2WSH without witness script or non-standard scripts, they are
+	// counted without witness and signature script.
+	UnknownInputs []int
+}
+
+// TxWeight returns expected weight and sizes of the PSBT transaction after signing. Finalized inputs are counted
+// as is, witness and signature script of other inputs are estimated by the spent output script type:
+// P2TR key path or the first leaf script path, P2WPKH, P2SH as nested P2WPKH, P2PKH, P2PK, P2A and P2WSH multisig.
+// NOTE: Estimates use the largest signatures, so the actual weight could be a few units less.
+func TxWeight(serializedPSBT []byte) (report TxWeightReport, _ error) {
+	p, err := psbt.NewFromRawBytes(bytes.NewReader(serializedPSBT), false)
+	if err != nil {
+		return report, err
+	}
+
+	return PacketWeight(p)
+}
+
+// PacketWeight is like TxWeight, but accepts parsed PSBT, e.g. the one being built.
+func PacketWeight(p *psbt.Packet) (report TxWeightReport, err error) {
+	tx := p.UnsignedTx.Copy()
+	for i := range tx.TxIn {
+		input := &p.Inputs[i]
+		if len(input.FinalScriptWitness) != 0 || len(input.FinalScriptSig) != 0 {
+			tx.TxIn[i].SignatureScript = input.FinalScriptSig
+			if len(input.FinalScriptWitness) != 0 {
+				tx.TxIn[i].Witness, err = ParseWitness(input.FinalScriptWitness)
+				if err != nil {
+					return report, fmt.Errorf("input %d: %w", i, err)
+				}
+			}
+
+			continue
+		}
+
+		report.EstimatedInputs = append(report.EstimatedInputs, i)
+
+		var ok bool
+		tx.TxIn[i].SignatureScript, tx.TxIn[i].Witness, ok = estimateInputSpending(p, i)
+		if !ok {
+			report.UnknownInputs = append(report.UnknownInputs, i)
+		}
+	}
+
+	report.StrippedSize = int64(tx.SerializeSizeStripped())
+	report.Weight, report.VSize = MsgTxWeight(tx)
+
+	return report, nil
+}
+
+// MsgTxWeight returns weight in weight units and virtual size in vBytes of the transaction with its current
+// witnesses and signature scripts, e.g. extracted signed transaction or the one with placeholder witnesses.
+func MsgTxWeight(tx *wire.MsgTx) (weight, vSize int64) {
+	weight = int64(tx.SerializeSizeStripped())*(witnessScaleFactor-1) + int64(tx.SerializeSize())
+
+	return weight, (weight + witnessScaleFactor - 1) / witnessScaleFactor
+}
+
+// estimateInputSpending returns placeholder signature script and witness of the input spending,
+// false if spending can not be estimated.
+func estimateInputSpending(p *psbt.Packet, index int) (sigScript []byte, witness wire.TxWitness, _ bool) {
+	input := &p.Inputs[index]
+
+	var pkScript []byte
+	switch {
+	case input.WitnessUtxo != nil:
+		pkScript = input.WitnessUtxo.PkScript
+	case input.NonWitnessUtxo != nil:
+		prevIndex := p.UnsignedTx.TxIn[index].PreviousOutPoint.Index
+		if int(prevIndex) >= len(input.NonWitnessUtxo.TxOut) {
+			return nil, nil, false
+		}
+		pkScript = input.NonWitnessUtxo.TxOut[prevIndex].PkScript
+	default:
+		return nil, nil, false
+	}
+
+	utxo := UTXO{Script: pkScript}
+	switch utxo.ScriptType() {
+	case ScriptTypeP2TR:
+		if len(input.TaprootLeafScript) != 0 {
+			leaf := input.TaprootLeafScript[0]
+			return nil, wire.TxWitness{make([]byte, schnorrSignatureSize), leaf.Script, leaf.ControlBlock}, true
+		}
+
+		signature := make([]byte, schnorrSignatureSize)
+		if len(input.TaprootKeySpendSig) != 0 {
+			signature = input.TaprootKeySpendSig
+		}
+
+		return nil, wire.TxWitness{signature}, true
+	case ScriptTypeP2WPKH:
+		return nil, wire.TxWitness{make([]byte, ecdsaSignatureSize), make([]byte, compressedPubKeySize)}, true
+	case ScriptTypeP2SH:
+		// INFO: nested P2WPKH, script sig pushes witness program.
+		return make([]byte, 1+p2wpkhProgramSize),
+			wire.TxWitness{make([]byte, ecdsaSignatureSize), make([]byte, compressedPubKeySize)}, true
+	case ScriptTypeP2PKH:
+		return make([]byte, 1+ecdsaSignatureSize+1+compressedPubKeySize), nil, true
+	case ScriptTypeP2PK:
+		return make([]byte, 1+ecdsaSignatureSize), nil, true
+	case ScriptTypeP2A:
+		return nil, nil, true
+	case ScriptTypeP2WSH:
+		if len(input.WitnessScript) == 0 {
+			return nil, nil, false
+		}
+
+		_, required, err := txscript.CalcMultiSigStats(input.WitnessScript)
+		if err != nil {
+			return nil, nil, false
+		}
+
+		// INFO: CHECKMULTISIG consumes an extra empty item.
+		witness = wire.TxWitness{nil}
+		for i := 0; i < required; i++ {
+			witness = append(witness, make([]byte, ecdsaSignatureSize))
+		}
+
+		return nil, append(witness, input.WitnessScript), true
+	default:
+		return nil, nil, false
+	}
+}
+
+// ParseWitness returns witness stack from serialized PSBT final script witness.
+// INFO: script path witness, e.g. inscription envelope, is not limited by the script size.
+func ParseWitness(serialized []byte) (wire.TxWitness, error) {
+	r := bytes.NewReader(serialized)
+	count, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if count > uint64(len(serialized)) {
+		return nil, fmt.Errorf("invalid witness items count: %d", count)
+	}
+
+	witness := make(wire.TxWitness, count)
+	for i := range witness {
+		witness[i], err = wire.ReadVarBytes(r, 0, maxWitnessItemSize, "witness item")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return witness, nil
+}
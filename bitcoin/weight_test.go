@@ -0,0 +1,105 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package bitcoin_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/signer"
+	"github.com/BoostyLabs/blockchain/bitcoin/txbuilder/txbuildertest"
+)
+
+func TestTxWeight(t *testing.T) {
+	networkParams := &chaincfg.TestNet3Params
+
+	wallet := txbuildertest.NewWallet(t, networkParams)
+
+	p2pkhAddress, err := btcutil.NewAddressPubKeyHash(make([]byte, 20), networkParams)
+	require.NoError(t, err)
+	p2pkhScript, err := txscript.PayToAddrScript(p2pkhAddress)
+	require.NoError(t, err)
+
+	newPSBT := func(t *testing.T, scripts ...[]byte) ([]byte, *wire.MsgTx) {
+		tx := wire.NewMsgTx(2)
+		for i := range scripts {
+			tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, uint32(i)), nil, nil))
+		}
+		tx.AddTxOut(wire.NewTxOut(1000, wallet.Script))
+
+		p, err := psbt.NewFromUnsignedTx(tx)
+		require.NoError(t, err)
+		for i, script := range scripts {
+			p.Inputs[i].WitnessUtxo = wire.NewTxOut(10000, script)
+			p.Inputs[i].SighashType = txscript.SigHashAll
+		}
+
+		var serialized bytes.Buffer
+		require.NoError(t, p.Serialize(&serialized))
+
+		return serialized.Bytes(), tx
+	}
+
+	t.Run("taproot", func(t *testing.T) {
+		unsigned, _ := newPSBT(t, wallet.Script, wallet.Script)
+
+		estimated, err := bitcoin.TxWeight(unsigned)
+		require.NoError(t, err)
+		require.Equal(t, []int{0, 1}, estimated.EstimatedInputs)
+		require.Empty(t, estimated.UnknownInputs)
+
+		signed, err := signer.NewSigner(networkParams).SignTaproot(signer.SignTaprootParams{
+			SerializedPSBT: unsigned,
+			Inputs:         []int{0, 1},
+			PrivateKey:     wallet.PrivateKey,
+		})
+		require.NoError(t, err)
+
+		p, err := psbt.NewFromRawBytes(bytes.NewReader(signed), false)
+		require.NoError(t, err)
+		require.NoError(t, psbt.MaybeFinalizeAll(p))
+
+		var finalized bytes.Buffer
+		require.NoError(t, p.Serialize(&finalized))
+
+		actual, err := bitcoin.TxWeight(finalized.Bytes())
+		require.NoError(t, err)
+		require.Empty(t, actual.EstimatedInputs)
+		require.Equal(t, estimated.Weight, actual.Weight)
+		require.Equal(t, estimated.VSize, actual.VSize)
+		require.Equal(t, estimated.StrippedSize, actual.StrippedSize)
+
+		tx, err := psbt.Extract(p)
+		require.NoError(t, err)
+		require.EqualValues(t, tx.SerializeSizeStripped()*3+tx.SerializeSize(), actual.Weight)
+	})
+
+	t.Run("legacy and unknown", func(t *testing.T) {
+		unsigned, tx := newPSBT(t, p2pkhScript, []byte{txscript.OP_TRUE})
+
+		report, err := bitcoin.TxWeight(unsigned)
+		require.NoError(t, err)
+		require.Equal(t, []int{0, 1}, report.EstimatedInputs)
+		require.Equal(t, []int{1}, report.UnknownInputs)
+
+		// INFO: signature script of the signature and compressed public key pushes.
+		require.EqualValues(t, tx.SerializeSizeStripped()+1+72+1+33, report.StrippedSize)
+		require.Equal(t, report.StrippedSize*4, report.Weight)
+		require.Equal(t, report.StrippedSize, report.VSize)
+	})
+
+	t.Run("invalid psbt", func(t *testing.T) {
+		_, err := bitcoin.TxWeight([]byte("psbt"))
+		require.Error(t, err)
+	})
+}
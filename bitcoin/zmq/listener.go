@@ -0,0 +1,232 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package zmq
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/rpc"
+)
+
+// ErrBackfillLimit describes that missed blocks exceed backfill limit.
+var ErrBackfillLimit = errors.New("backfill limit is exceeded")
+
+// DefaultMaxBackfillBlocks defines maximum number of the missed blocks fetched by RPC.
+const DefaultMaxBackfillBlocks = 100
+
+// Handler describes consumer of the decoded notifications, e.g. runes or inscriptions indexer.
+type Handler interface {
+	// HandleTx handles transaction entering mempool or connected by a block.
+	HandleTx(ctx context.Context, tx *wire.MsgTx) error
+	// HandleBlock handles connected block and its runes and inscriptions events.
+	HandleBlock(ctx context.Context, block *wire.MsgBlock, events []bitcoin.BlockEvent) error
+}
+
+// Gap describes missed notifications of the topic.
+type Gap struct {
+	Topic Topic
+	From  uint32 // first missed sequence.
+	To    uint32 // sequence of the received message.
+}
+
+// Config defines Listener configuration.
+type Config struct {
+	Subscriber Subscriber
+	Handler    Handler
+	// RPC is used to backfill missed notifications, optional, gaps are only reported if not set.
+	RPC               rpc.Caller
+	MaxBackfillBlocks int       // optional, DefaultMaxBackfillBlocks if not set.
+	OnGap             func(Gap) // optional, called before backfill.
+}
+
+// Listener receives bitcoind notifications, decodes them and passes to the handler in order.
+// Missed blocks are detected by the previous block hash, so chain reorganizations blocks and
+// blocks connected while the listener was not running are backfilled too. Missed transactions
+// are detected by the sequence gap and backfilled by re-delivering the whole mempool.
+// NOTE: Handlers should be idempotent, since backfilled transactions may be delivered twice.
+type Listener struct {
+	config Config
+
+	sequences map[Topic]uint32
+	blocks    []chainhash.Hash // recently handled blocks, the last is the best one.
+}
+
+// NewListener is a constructor for Listener.
+func NewListener(config Config) *Listener {
+	if config.MaxBackfillBlocks <= 0 {
+		config.MaxBackfillBlocks = DefaultMaxBackfillBlocks
+	}
+
+	return &Listener{
+		config:    config,
+		sequences: make(map[Topic]uint32),
+	}
+}
+
+// Run receives and handles notifications until the context is canceled or the subscriber fails.
+// Handling errors are not fatal, they are reported by the onError callback, which may be nil.
+func (l *Listener) Run(ctx context.Context, onError func(error)) error {
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = l.config.Subscriber.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		msg, err := l.config.Subscriber.Receive()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err == nil {
+			err = l.Handle(ctx, msg)
+		} else if !errors.Is(err, ErrInvalidMessage) {
+			return err
+		}
+
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// Handle detects gaps, backfills missed notifications and handles the message.
+// Unknown topics are ignored.
+func (l *Listener) Handle(ctx context.Context, msg Message) error {
+	var gap bool
+	if last, ok := l.sequences[msg.Topic]; ok && msg.Sequence != last+1 {
+		gap = true
+		if l.config.OnGap != nil {
+			l.config.OnGap(Gap{Topic: msg.Topic, From: last + 1, To: msg.Sequence})
+		}
+	}
+	l.sequences[msg.Topic] = msg.Sequence
+
+	switch msg.Topic {
+	case TopicRawTx:
+		var backfillErr error
+		if gap && l.config.RPC != nil {
+			backfillErr = l.backfillMempool(ctx)
+		}
+
+		tx := wire.NewMsgTx(wire.TxVersion)
+		if err := tx.Deserialize(bytes.NewReader(msg.Body)); err != nil {
+			return errors.Join(backfillErr, fmt.Errorf("%w: rawtx %d: %w", ErrInvalidMessage, msg.Sequence, err))
+		}
+
+		return errors.Join(backfillErr, l.config.Handler.HandleTx(ctx, tx))
+	case TopicRawBlock:
+		block := new(wire.MsgBlock)
+		if err := block.Deserialize(bytes.NewReader(msg.Body)); err != nil {
+			return fmt.Errorf("%w: rawblock %d: %w", ErrInvalidMessage, msg.Sequence, err)
+		}
+
+		var backfillErr error
+		if len(l.blocks) != 0 && block.Header.PrevBlock != l.blocks[len(l.blocks)-1] && l.config.RPC != nil {
+			backfillErr = l.backfillBlocks(ctx, block.Header.PrevBlock)
+		}
+
+		return errors.Join(backfillErr, l.handleBlock(ctx, block))
+	default:
+		return nil
+	}
+}
+
+// handleBlock parses block events and passes them to the handler.
+func (l *Listener) handleBlock(ctx context.Context, block *wire.MsgBlock) error {
+	hash := block.BlockHash()
+	l.blocks = append(l.blocks, hash)
+	if len(l.blocks) > l.config.MaxBackfillBlocks {
+		l.blocks = l.blocks[len(l.blocks)-l.config.MaxBackfillBlocks:]
+	}
+
+	events, err := bitcoin.ParseBlock(block)
+	if err != nil {
+		return fmt.Errorf("block %s: %w", hash, err)
+	}
+
+	return l.config.Handler.HandleBlock(ctx, block, events)
+}
+
+// backfillBlocks fetches ancestors of the block hash till already handled block and handles them in order.
+func (l *Listener) backfillBlocks(ctx context.Context, hash chainhash.Hash) error {
+	var missed []*wire.MsgBlock
+	for !slices.Contains(l.blocks, hash) {
+		if len(missed) == l.config.MaxBackfillBlocks {
+			return fmt.Errorf("%w: %d blocks", ErrBackfillLimit, len(missed))
+		}
+
+		var serialized string
+		if err := l.config.RPC.Call(ctx, "getblock", &serialized, hash.String(), 0); err != nil {
+			return fmt.Errorf("backfill block %s: %w", hash, err)
+		}
+
+		data, err := hex.DecodeString(serialized)
+		if err != nil {
+			return fmt.Errorf("backfill block %s: %w", hash, err)
+		}
+
+		block := new(wire.MsgBlock)
+		if err = block.Deserialize(bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("backfill block %s: %w", hash, err)
+		}
+
+		missed = append(missed, block)
+		hash = block.Header.PrevBlock
+	}
+
+	var errs []error
+	for i := len(missed) - 1; i >= 0; i-- {
+		errs = append(errs, l.handleBlock(ctx, missed[i]))
+	}
+
+	return errors.Join(errs...)
+}
+
+// backfillMempool fetches all mempool transactions and handles them.
+// INFO: transactions evicted during the backfill are skipped.
+func (l *Listener) backfillMempool(ctx context.Context) error {
+	var txHashes []string
+	if err := l.config.RPC.Call(ctx, "getrawmempool", &txHashes); err != nil {
+		return fmt.Errorf("backfill mempool: %w", err)
+	}
+
+	var errs []error
+	for _, txHash := range txHashes {
+		var serialized string
+		if err := l.config.RPC.Call(ctx, "getrawtransaction", &serialized, txHash, false); err != nil {
+			continue
+		}
+
+		data, err := hex.DecodeString(serialized)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("backfill tx %s: %w", txHash, err))
+			continue
+		}
+
+		tx := wire.NewMsgTx(wire.TxVersion)
+		if err = tx.Deserialize(bytes.NewReader(data)); err != nil {
+			errs = append(errs, fmt.Errorf("backfill tx %s: %w", txHash, err))
+			continue
+		}
+
+		errs = append(errs, l.config.Handler.HandleTx(ctx, tx))
+	}
+
+	return errors.Join(errs...)
+}
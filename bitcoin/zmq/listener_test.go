@@ -0,0 +1,167 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package zmq_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/bitcoin"
+	"github.com/BoostyLabs/blockchain/bitcoin/zmq"
+)
+
+// subscriber delivers queued messages, Receive fails when the queue is empty.
+type subscriber struct {
+	messages chan zmq.Message
+}
+
+func (s *subscriber) Receive() (zmq.Message, error) {
+	msg, ok := <-s.messages
+	if !ok {
+		return zmq.Message{}, errors.New("closed")
+	}
+
+	return msg, nil
+}
+
+func (s *subscriber) Close() error {
+	return nil
+}
+
+// rpc responds with serialized blocks by hash and mempool transactions.
+type rpc struct {
+	blocks  map[string]string
+	mempool map[string]string
+}
+
+func (r *rpc) Call(_ context.Context, method string, result any, params ...any) error {
+	switch method {
+	case "getblock":
+		*result.(*string) = r.blocks[params[0].(string)]
+	case "getrawmempool":
+		for txHash := range r.mempool {
+			*result.(*[]string) = append(*result.(*[]string), txHash)
+		}
+	case "getrawtransaction":
+		*result.(*string) = r.mempool[params[0].(string)]
+	}
+
+	return nil
+}
+
+// handler records handled transactions and blocks hashes.
+type handler struct {
+	txs    []string
+	blocks []string
+}
+
+func (h *handler) HandleTx(_ context.Context, tx *wire.MsgTx) error {
+	h.txs = append(h.txs, tx.TxHash().String())
+	return nil
+}
+
+func (h *handler) HandleBlock(_ context.Context, block *wire.MsgBlock, _ []bitcoin.BlockEvent) error {
+	h.blocks = append(h.blocks, block.BlockHash().String())
+	return nil
+}
+
+func newTx(lockTime uint32) *wire.MsgTx {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{txscript.OP_TRUE}))
+	tx.LockTime = lockTime
+
+	return tx
+}
+
+func newBlock(t *testing.T, prev chainhash.Hash, height int64) *wire.MsgBlock {
+	script, err := txscript.NewScriptBuilder().AddInt64(height).Script()
+	require.NoError(t, err)
+
+	coinbase := wire.NewMsgTx(2)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), script, nil))
+	coinbase.AddTxOut(wire.NewTxOut(5000000000, []byte{txscript.OP_TRUE}))
+
+	block := wire.NewMsgBlock(&wire.BlockHeader{Version: 4, PrevBlock: prev})
+	require.NoError(t, block.AddTransaction(coinbase))
+
+	return block
+}
+
+func TestListener(t *testing.T) {
+	ctx := context.Background()
+
+	blocks := []*wire.MsgBlock{newBlock(t, chainhash.Hash{}, 100)}
+	for height := int64(101); height < 104; height++ {
+		blocks = append(blocks, newBlock(t, blocks[len(blocks)-1].BlockHash(), height))
+	}
+
+	serializedBlocks := make([][]byte, len(blocks))
+	rpcBlocks := make(map[string]string)
+	for i, block := range blocks {
+		var buf bytes.Buffer
+		require.NoError(t, block.Serialize(&buf))
+		serializedBlocks[i] = buf.Bytes()
+		rpcBlocks[block.BlockHash().String()] = hex.EncodeToString(buf.Bytes())
+	}
+
+	txs := []*wire.MsgTx{newTx(1), newTx(2), newTx(3)}
+	serializedTxs := make([][]byte, len(txs))
+	for i, tx := range txs {
+		var buf bytes.Buffer
+		require.NoError(t, tx.Serialize(&buf))
+		serializedTxs[i] = buf.Bytes()
+	}
+
+	var gaps []zmq.Gap
+	h := new(handler)
+	messages := make(chan zmq.Message, 16)
+	listener := zmq.NewListener(zmq.Config{
+		Subscriber: &subscriber{messages: messages},
+		Handler:    h,
+		RPC: &rpc{
+			blocks:  rpcBlocks,
+			mempool: map[string]string{txs[1].TxHash().String(): hex.EncodeToString(serializedTxs[1])},
+		},
+		OnGap: func(gap zmq.Gap) { gaps = append(gaps, gap) },
+	})
+
+	t.Run("block gap", func(t *testing.T) {
+		require.NoError(t, listener.Handle(ctx, zmq.Message{Topic: zmq.TopicRawBlock, Body: serializedBlocks[0], Sequence: 7}))
+		require.NoError(t, listener.Handle(ctx, zmq.Message{Topic: zmq.TopicRawBlock, Body: serializedBlocks[3], Sequence: 9}))
+
+		require.Equal(t, []zmq.Gap{{Topic: zmq.TopicRawBlock, From: 8, To: 9}}, gaps)
+		require.Equal(t, []string{
+			blocks[0].BlockHash().String(),
+			blocks[1].BlockHash().String(),
+			blocks[2].BlockHash().String(),
+			blocks[3].BlockHash().String(),
+		}, h.blocks)
+	})
+
+	t.Run("tx gap", func(t *testing.T) {
+		gaps = nil
+		messages <- zmq.Message{Topic: zmq.TopicRawTx, Body: serializedTxs[0], Sequence: 0}
+		messages <- zmq.Message{Topic: zmq.TopicRawTx, Body: serializedTxs[2], Sequence: 2}
+		messages <- zmq.Message{Topic: zmq.TopicRawTx, Body: []byte{1, 2}, Sequence: 3}
+		close(messages)
+
+		var errs []error
+		err := listener.Run(ctx, func(err error) { errs = append(errs, err) })
+		require.EqualError(t, err, "closed")
+
+		require.Equal(t, []zmq.Gap{{Topic: zmq.TopicRawTx, From: 1, To: 2}}, gaps)
+		require.Equal(t, []string{txs[0].TxHash().String(), txs[1].TxHash().String(), txs[2].TxHash().String()}, h.txs)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], zmq.ErrInvalidMessage)
+	})
+}
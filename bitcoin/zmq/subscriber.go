@@ -0,0 +1,94 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+// Package zmq provides listener of the bitcoind ZeroMQ notifications, which decodes raw transactions
+// and blocks and feeds them to the runes and inscriptions indexers with RPC backfill of the missed ones.
+package zmq
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/lightninglabs/gozmq"
+)
+
+// ErrInvalidMessage describes that notification does not match bitcoind message format.
+var ErrInvalidMessage = errors.New("invalid zmq message")
+
+// Topic defines bitcoind ZeroMQ notification topic.
+type Topic string
+
+const (
+	// TopicRawTx defines topic of the serialized transactions entering mempool or connected by blocks,
+	// enabled by bitcoind -zmqpubrawtx option.
+	TopicRawTx Topic = "rawtx"
+	// TopicRawBlock defines topic of the serialized connected blocks, enabled by bitcoind -zmqpubrawblock option.
+	TopicRawBlock Topic = "rawblock"
+)
+
+// Message describes bitcoind notification.
+type Message struct {
+	Topic    Topic
+	Body     []byte
+	Sequence uint32 // message number of the topic, increased by one for every message.
+}
+
+// Subscriber describes ZeroMQ SUB socket.
+type Subscriber interface {
+	// Receive blocks until the next message is received.
+	Receive() (Message, error)
+	// Close closes the socket, blocked Receive returns an error.
+	Close() error
+}
+
+// conn is a Subscriber over ZeroMQ connection.
+type conn struct {
+	zmq *gozmq.Conn
+}
+
+// Subscribe connects to the bitcoind ZeroMQ publisher, e.g. tcp://127.0.0.1:28332, and subscribes
+// to the topics. Connection is restored automatically, reconnectTimeout is a delay between attempts.
+func Subscribe(address string, topics []Topic, reconnectTimeout time.Duration) (Subscriber, error) {
+	names := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		names = append(names, string(topic))
+	}
+
+	zmq, err := gozmq.Subscribe(address, names, reconnectTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conn{zmq: zmq}, nil
+}
+
+// Receive blocks until the next message is received.
+func (c *conn) Receive() (Message, error) {
+	for {
+		parts, err := c.zmq.Receive(nil)
+		if err != nil {
+			// INFO: timeout error is returned after reconnection attempt.
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+
+			return Message{}, err
+		}
+
+		// INFO: bitcoind messages consist of topic, body and 4 bytes little endian sequence.
+		if len(parts) != 3 || len(parts[2]) != 4 {
+			return Message{}, fmt.Errorf("%w: %d parts", ErrInvalidMessage, len(parts))
+		}
+
+		return Message{Topic: Topic(parts[0]), Body: parts[1], Sequence: binary.LittleEndian.Uint32(parts[2])}, nil
+	}
+}
+
+// Close closes the connection.
+func (c *conn) Close() error {
+	return c.zmq.Close()
+}
@@ -5,8 +5,12 @@ package sequencereader
 
 import (
 	"errors"
+	"fmt"
 )
 
+// ErrEnded defines that the sequence has no elements left to read.
+var ErrEnded = errors.New("the sequence is ended")
+
 // SequenceReader defines the simplest reader for sequences.
 type SequenceReader[T any] struct {
 	s    []T
@@ -29,15 +33,37 @@ func (sr *SequenceReader[T]) HasNext() bool {
 }
 
 // Next returns next element of the sequence.
+// Returns ErrEnded with the element position if the sequence is ended.
 func (sr *SequenceReader[T]) Next() (T, error) {
-	if !sr.HasNext() {
-		return *new(T), errors.New("the sequence is ended")
+	value, err := sr.Peek()
+	if err != nil {
+		return value, err
 	}
 
-	pIdx := sr.idx
 	sr.idx++
 
-	return sr.s[pIdx], nil
+	return value, nil
+}
+
+// Peek returns next element of the sequence without moving to the following one.
+// Returns ErrEnded with the element position if the sequence is ended.
+func (sr *SequenceReader[T]) Peek() (T, error) {
+	if !sr.HasNext() {
+		return *new(T), fmt.Errorf("%w: truncated at element %d", ErrEnded, sr.idx)
+	}
+
+	return sr.s[sr.idx], nil
+}
+
+// Remaining returns elements which are not read yet without moving to the sequence end.
+// NOTE: Returned slice shares memory with the sequence.
+func (sr *SequenceReader[T]) Remaining() []T {
+	return sr.s[sr.idx:sr.size]
+}
+
+// Pos returns position of the next element in the sequence, equals to the number of read elements.
+func (sr *SequenceReader[T]) Pos() int {
+	return sr.idx
 }
 
 // Len returns how many items are left.
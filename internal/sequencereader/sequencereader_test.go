@@ -81,6 +81,50 @@ func TestSequenceReader(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("Peek", func(t *testing.T) {
+		sr := sequencereader.New(seq)
+		for _, tVal := range seq {
+			peeked, err := sr.Peek()
+			require.NoError(t, err)
+			require.Equal(t, tVal, peeked)
+
+			val, err := sr.Next()
+			require.NoError(t, err)
+			require.Equal(t, peeked, val)
+		}
+
+		_, err := sr.Peek()
+		require.ErrorIs(t, err, sequencereader.ErrEnded)
+	})
+
+	t.Run("Remaining and Pos", func(t *testing.T) {
+		sr := sequencereader.New(seq)
+		require.Equal(t, seq, sr.Remaining())
+		require.Equal(t, 0, sr.Pos())
+
+		_, _ = sr.Next()
+		require.Equal(t, seq[1:], sr.Remaining())
+		require.Equal(t, 1, sr.Pos())
+		require.Equal(t, len(seq)-1, sr.Len())
+
+		for sr.HasNext() {
+			_, _ = sr.Next()
+		}
+		require.Empty(t, sr.Remaining())
+		require.Equal(t, len(seq), sr.Pos())
+	})
+
+	t.Run("error position", func(t *testing.T) {
+		sr := sequencereader.New(seq)
+		for sr.HasNext() {
+			_, _ = sr.Next()
+		}
+
+		_, err := sr.Next()
+		require.ErrorIs(t, err, sequencereader.ErrEnded)
+		require.ErrorContains(t, err, "truncated at element 4")
+	})
+
 	t.Run("SequenceReader for string type", func(t *testing.T) {
 		strSeq := []string{"a", "ab", "abc", "abcd"}
 		sr := sequencereader.New[string](strSeq)
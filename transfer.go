@@ -0,0 +1,94 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+)
+
+// ErrInvalidTransferRequest describes that transfer request is malformed.
+var ErrInvalidTransferRequest = errors.New("invalid transfer request")
+
+// Account describes chain account data needed to spend its funds.
+type Account struct {
+	Address string
+	PubKey  string // hex encoded public key, chain specific format.
+}
+
+// TransferRequest describes chain-agnostic request to transfer the asset amount.
+type TransferRequest struct {
+	Amount   Amount   // amount to transfer, must be 1 for the inscriptions.
+	From     Account  // sender account. mandatory.
+	FeePayer *Account // fee payer account, optional. if not provided, sender pays fee.
+	To       string   // recipient address. mandatory.
+	FeeRate  *big.Int // chain specific fee rate, e.g. satoshi per kilo virtual byte for bitcoin.
+}
+
+// Validate returns error if transfer request is malformed.
+func (request TransferRequest) Validate() error {
+	if err := request.Amount.Asset.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case request.Amount.Value == nil || !numbers.IsPositive(request.Amount.Value):
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidTransferRequest)
+	case request.Amount.Asset.Protocol == ProtocolInscriptions && request.Amount.Value.Cmp(big.NewInt(1)) != 0:
+		return fmt.Errorf("%w: inscription amount must be 1", ErrInvalidTransferRequest)
+	case request.From.Address == "":
+		return fmt.Errorf("%w: sender address is required", ErrInvalidTransferRequest)
+	case request.To == "":
+		return fmt.Errorf("%w: recipient address is required", ErrInvalidTransferRequest)
+	case request.FeeRate == nil || !numbers.IsPositive(request.FeeRate):
+		return fmt.Errorf("%w: fee rate must be positive", ErrInvalidTransferRequest)
+	}
+
+	return nil
+}
+
+// Transfer describes unsigned chain specific transfer transaction.
+type Transfer struct {
+	Chain   Chain
+	Payload []byte // unsigned transaction, e.g. serialized PSBT for bitcoin.
+	Fee     Amount // estimated fee in the chain native asset.
+}
+
+// TransferBuilder describes chain specific builder of the transfer transactions.
+type TransferBuilder interface {
+	// Chain returns the chain transfers are built for.
+	Chain() Chain
+	// BuildTransfer returns unsigned transfer transaction, ErrUnsupportedAsset should be
+	// returned (may be wrapped) if the asset protocol is not supported.
+	BuildTransfer(ctx context.Context, request TransferRequest) (Transfer, error)
+}
+
+// Router is a TransferBuilder which routes transfer requests to the chain specific builders.
+type Router struct {
+	builders map[Chain]TransferBuilder
+}
+
+// NewRouter is a constructor for Router.
+func NewRouter(builders ...TransferBuilder) *Router {
+	router := &Router{builders: make(map[Chain]TransferBuilder, len(builders))}
+	for _, builder := range builders {
+		router.builders[builder.Chain()] = builder
+	}
+
+	return router
+}
+
+// BuildTransfer returns unsigned transfer transaction built by the builder of the asset chain.
+func (router *Router) BuildTransfer(ctx context.Context, request TransferRequest) (Transfer, error) {
+	builder, ok := router.builders[request.Amount.Asset.Chain]
+	if !ok {
+		return Transfer{}, fmt.Errorf("%w: %s", ErrUnsupportedAsset, request.Amount.Asset.String())
+	}
+
+	return builder.BuildTransfer(ctx, request)
+}
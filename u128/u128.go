@@ -0,0 +1,262 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package u128
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+)
+
+// ErrOverflow defines that the value does not fit into uint128.
+var ErrOverflow = errors.New("uint128 overflow")
+
+// ErrUnderflow defines that the value is less than zero.
+var ErrUnderflow = errors.New("uint128 underflow")
+
+// ErrInvalidVarint defines that LEB128 encoded value is malformed.
+var ErrInvalidVarint = errors.New("invalid uint128 varint")
+
+// ErrVarintOverlong defines that LEB128 encoded value is longer than MaxVarintLen bytes.
+var ErrVarintOverlong = errors.New("varint too long")
+
+// ErrVarintUnterminated defines that LEB128 encoded value has no terminating byte.
+var ErrVarintUnterminated = errors.New("varint unterminated")
+
+// MaxVarintLen defines maximum length of the LEB128 encoded uint128 value in bytes.
+const MaxVarintLen = 19
+
+// Zero defines 0 as U128.
+var Zero = U128{}
+
+// Max defines maximum value of U128.
+var Max = U128{Hi: ^uint64(0), Lo: ^uint64(0)}
+
+// U128 defines unsigned 128-bit integer with checked arithmetic.
+type U128 struct {
+	Hi uint64
+	Lo uint64
+}
+
+// FromUint64 creates new U128 from uint64 value.
+func FromUint64(value uint64) U128 {
+	return U128{Lo: value}
+}
+
+// FromBig creates new U128 from *big.Int, returns error if the value is negative or overflows uint128.
+func FromBig(value *big.Int) (U128, error) {
+	if value.Sign() < 0 {
+		return Zero, ErrUnderflow
+	}
+
+	if value.BitLen() > 128 {
+		return Zero, ErrOverflow
+	}
+
+	lo := new(big.Int).And(value, new(big.Int).SetUint64(^uint64(0)))
+	hi := new(big.Int).Rsh(value, 64)
+
+	return U128{Hi: hi.Uint64(), Lo: lo.Uint64()}, nil
+}
+
+// FromString parses U128 from decimal string.
+func FromString(value string) (U128, error) {
+	number, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return Zero, fmt.Errorf("invalid uint128 string: %q", value)
+	}
+
+	return FromBig(number)
+}
+
+// Big returns U128 as *big.Int.
+func (u U128) Big() *big.Int {
+	value := new(big.Int).SetUint64(u.Hi)
+	value.Lsh(value, 64)
+
+	return value.Or(value, new(big.Int).SetUint64(u.Lo))
+}
+
+// String returns U128 as decimal string.
+func (u U128) String() string {
+	return u.Big().String()
+}
+
+// IsZero returns true if the value is zero.
+func (u U128) IsZero() bool {
+	return u.Hi == 0 && u.Lo == 0
+}
+
+// Cmp compares u and v and returns -1 if u < v, 0 if u == v, +1 if u > v.
+func (u U128) Cmp(v U128) int {
+	switch {
+	case u.Hi < v.Hi:
+		return -1
+	case u.Hi > v.Hi:
+		return 1
+	case u.Lo < v.Lo:
+		return -1
+	case u.Lo > v.Lo:
+		return 1
+	}
+
+	return 0
+}
+
+// Add returns u + v, returns ErrOverflow if the result does not fit into uint128.
+func (u U128) Add(v U128) (U128, error) {
+	lo, carry := bits.Add64(u.Lo, v.Lo, 0)
+	hi, carry := bits.Add64(u.Hi, v.Hi, carry)
+	if carry != 0 {
+		return Zero, ErrOverflow
+	}
+
+	return U128{Hi: hi, Lo: lo}, nil
+}
+
+// Sub returns u - v, returns ErrUnderflow if v is greater than u.
+func (u U128) Sub(v U128) (U128, error) {
+	lo, borrow := bits.Sub64(u.Lo, v.Lo, 0)
+	hi, borrow := bits.Sub64(u.Hi, v.Hi, borrow)
+	if borrow != 0 {
+		return Zero, ErrUnderflow
+	}
+
+	return U128{Hi: hi, Lo: lo}, nil
+}
+
+// Mul returns u * v, returns ErrOverflow if the result does not fit into uint128.
+func (u U128) Mul(v U128) (U128, error) {
+	if u.Hi != 0 && v.Hi != 0 {
+		return Zero, ErrOverflow
+	}
+
+	hi, lo := bits.Mul64(u.Lo, v.Lo)
+
+	crossHi, cross := bits.Mul64(u.Hi, v.Lo)
+	if crossHi != 0 {
+		return Zero, ErrOverflow
+	}
+
+	hi, carry := bits.Add64(hi, cross, 0)
+	if carry != 0 {
+		return Zero, ErrOverflow
+	}
+
+	crossHi, cross = bits.Mul64(u.Lo, v.Hi)
+	if crossHi != 0 {
+		return Zero, ErrOverflow
+	}
+
+	hi, carry = bits.Add64(hi, cross, 0)
+	if carry != 0 {
+		return Zero, ErrOverflow
+	}
+
+	return U128{Hi: hi, Lo: lo}, nil
+}
+
+// EncodeLEB128 returns U128 encoded as unsigned LEB128 varint.
+func (u U128) EncodeLEB128() []byte {
+	data := make([]byte, 0, MaxVarintLen)
+	for {
+		b := byte(u.Lo & 0x7f)
+		u.Lo = u.Lo>>7 | u.Hi<<57
+		u.Hi >>= 7
+		if u.IsZero() {
+			return append(data, b)
+		}
+
+		data = append(data, b|0x80)
+	}
+}
+
+// DecodeLEB128 decodes unsigned LEB128 varint from the beginning of data.
+// Returns decoded value with number of read bytes. Varints longer than
+// MaxVarintLen bytes, overflowing uint128 or not terminated are rejected.
+func DecodeLEB128(data []byte) (U128, int, error) {
+	var value U128
+	for i, b := range data {
+		if i >= MaxVarintLen {
+			return Zero, 0, fmt.Errorf("%w: %w", ErrInvalidVarint, ErrVarintOverlong)
+		}
+
+		bits7 := uint64(b & 0x7f)
+		if i == MaxVarintLen-1 && bits7&0b0111_1100 != 0 {
+			return Zero, 0, fmt.Errorf("%w: %w", ErrInvalidVarint, ErrOverflow)
+		}
+
+		shift := uint(7 * i)
+		switch {
+		case shift < 64:
+			value.Lo |= bits7 << shift
+			if shift > 57 {
+				value.Hi |= bits7 >> (64 - shift)
+			}
+		default:
+			value.Hi |= bits7 << (shift - 64)
+		}
+
+		if b&0x80 == 0 {
+			return value, i + 1, nil
+		}
+	}
+
+	return Zero, 0, fmt.Errorf("%w: %w", ErrInvalidVarint, ErrVarintUnterminated)
+}
+
+// ReadLEB128 reads unsigned LEB128 varint from the reader.
+func ReadLEB128(r io.ByteReader) (U128, error) {
+	buffer := make([]byte, 0, MaxVarintLen+1)
+	for len(buffer) <= MaxVarintLen {
+		b, err := r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return Zero, fmt.Errorf("%w: %w", ErrInvalidVarint, ErrVarintUnterminated)
+			}
+
+			return Zero, err
+		}
+
+		buffer = append(buffer, b)
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	value, _, err := DecodeLEB128(buffer)
+
+	return value, err
+}
+
+// MarshalJSON returns U128 as JSON number.
+func (u U128) MarshalJSON() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalJSON parses U128 from JSON number or string.
+func (u *U128) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+
+		data = []byte(str)
+	}
+
+	value, err := FromString(string(data))
+	if err != nil {
+		return err
+	}
+
+	*u = value
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2024 Creditor Corp. Group.
+// See LICENSE for copying information.
+
+package u128_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/aviate-labs/leb128"
+	"github.com/stretchr/testify/require"
+
+	"github.com/BoostyLabs/blockchain/internal/numbers"
+	"github.com/BoostyLabs/blockchain/u128"
+)
+
+func TestU128(t *testing.T) {
+	maxUint64 := u128.FromUint64(^uint64(0))
+
+	t.Run("FromBig", func(t *testing.T) {
+		value, err := u128.FromBig(numbers.MaxUInt128Value)
+		require.NoError(t, err)
+		require.Equal(t, u128.Max, value)
+		require.Equal(t, numbers.MaxUInt128Value, value.Big())
+
+		value, err = u128.FromBig(new(big.Int).Lsh(big.NewInt(5), 64))
+		require.NoError(t, err)
+		require.Equal(t, u128.U128{Hi: 5}, value)
+
+		_, err = u128.FromBig(new(big.Int).Add(numbers.MaxUInt128Value, big.NewInt(1)))
+		require.ErrorIs(t, err, u128.ErrOverflow)
+
+		_, err = u128.FromBig(big.NewInt(-1))
+		require.ErrorIs(t, err, u128.ErrUnderflow)
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		sum, err := maxUint64.Add(u128.FromUint64(1))
+		require.NoError(t, err)
+		require.Equal(t, u128.U128{Hi: 1}, sum)
+
+		_, err = u128.Max.Add(u128.FromUint64(1))
+		require.ErrorIs(t, err, u128.ErrOverflow)
+	})
+
+	t.Run("Sub", func(t *testing.T) {
+		diff, err := u128.U128{Hi: 1}.Sub(u128.FromUint64(1))
+		require.NoError(t, err)
+		require.Equal(t, maxUint64, diff)
+
+		_, err = u128.Zero.Sub(u128.FromUint64(1))
+		require.ErrorIs(t, err, u128.ErrUnderflow)
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+		product, err := maxUint64.Mul(maxUint64)
+		require.NoError(t, err)
+		require.Equal(t, new(big.Int).Mul(maxUint64.Big(), maxUint64.Big()), product.Big())
+
+		product, err = u128.U128{Hi: 3}.Mul(u128.FromUint64(7))
+		require.NoError(t, err)
+		require.Equal(t, u128.U128{Hi: 21}, product)
+
+		_, err = u128.U128{Hi: 1}.Mul(u128.U128{Hi: 1})
+		require.ErrorIs(t, err, u128.ErrOverflow)
+
+		_, err = u128.Max.Mul(u128.FromUint64(2))
+		require.ErrorIs(t, err, u128.ErrOverflow)
+	})
+
+	t.Run("Cmp", func(t *testing.T) {
+		require.Equal(t, 0, u128.Max.Cmp(u128.Max))
+		require.Equal(t, -1, maxUint64.Cmp(u128.U128{Hi: 1}))
+		require.Equal(t, 1, u128.U128{Hi: 1}.Cmp(maxUint64))
+		require.True(t, u128.Zero.IsZero())
+	})
+
+	t.Run("LEB128", func(t *testing.T) {
+		for _, value := range []u128.U128{u128.Zero, u128.FromUint64(127), u128.FromUint64(128), maxUint64, {Hi: 1}, u128.Max} {
+			expected, err := leb128.EncodeUnsigned(value.Big())
+			require.NoError(t, err)
+
+			encoded := value.EncodeLEB128()
+			require.Equal(t, []byte(expected), encoded)
+
+			decoded, n, err := u128.DecodeLEB128(append(encoded, 0x01))
+			require.NoError(t, err)
+			require.Equal(t, value, decoded)
+			require.Equal(t, len(encoded), n)
+
+			decoded, err = u128.ReadLEB128(bytes.NewReader(encoded))
+			require.NoError(t, err)
+			require.Equal(t, value, decoded)
+		}
+
+		overflow := append(bytes.Repeat([]byte{0xff}, 18), 0x04)
+		_, _, err := u128.DecodeLEB128(overflow)
+		require.ErrorIs(t, err, u128.ErrOverflow)
+
+		_, _, err = u128.DecodeLEB128(append(bytes.Repeat([]byte{0x80}, 19), 0x00))
+		require.ErrorIs(t, err, u128.ErrInvalidVarint)
+		require.ErrorIs(t, err, u128.ErrVarintOverlong)
+
+		_, _, err = u128.DecodeLEB128([]byte{0x80, 0x80})
+		require.ErrorIs(t, err, u128.ErrInvalidVarint)
+		require.ErrorIs(t, err, u128.ErrVarintUnterminated)
+
+		_, err = u128.ReadLEB128(bytes.NewReader(bytes.Repeat([]byte{0x80}, 25)))
+		require.ErrorIs(t, err, u128.ErrInvalidVarint)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(u128.Max)
+		require.NoError(t, err)
+		require.Equal(t, numbers.MaxUInt128Value.String(), string(data))
+
+		var value u128.U128
+		require.NoError(t, json.Unmarshal(data, &value))
+		require.Equal(t, u128.Max, value)
+
+		require.NoError(t, json.Unmarshal([]byte(`"12345"`), &value))
+		require.Equal(t, u128.FromUint64(12345), value)
+		require.Equal(t, "12345", value.String())
+
+		require.Error(t, json.Unmarshal([]byte(`-1`), &value))
+		require.Error(t, json.Unmarshal([]byte(`"abc"`), &value))
+	})
+}